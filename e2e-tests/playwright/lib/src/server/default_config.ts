@@ -577,6 +577,9 @@ const defaultServerConfig: AdminConfig = {
         RedisDB: -1,
         RedisCachePrefix: '',
         DisableClientCache: false,
+        CacheSizes: {},
+        HybridLocalCaches: ['UserProfileByIds', 'ChannelMembersForUser'],
+        HybridInvalidation: 'redis',
     },
     ClusterSettings: {
         Enable: false,
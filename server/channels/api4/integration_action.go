@@ -106,7 +106,7 @@ func submitDialog(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if submit.URL == "" {
+	if submit.URL == "" && submit.Session == "" {
 		c.SetInvalidParam("url")
 		return
 	}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -181,9 +182,44 @@ func TestOpenDialog(t *testing.T) {
 		},
 	}
 
-	t.Run("Should pass with valid request", func(t *testing.T) {
+	openDialog := func(t *testing.T, request model.OpenDialogRequest) model.OpenDialogRequest {
+		t.Helper()
+		webSocketClient := th.CreateConnectedWebSocketClient(t)
+
 		_, err := client.OpenInteractiveDialog(context.Background(), request)
 		require.NoError(t, err)
+
+		for {
+			select {
+			case event := <-webSocketClient.EventChannel:
+				if event.EventType() != model.WebsocketEventOpenDialog {
+					continue
+				}
+				var opened model.OpenDialogRequest
+				err := json.Unmarshal([]byte(event.GetData()["dialog"].(string)), &opened)
+				require.NoError(t, err)
+				return opened
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "Timeout, dialog not opened")
+			}
+		}
+	}
+
+	t.Run("Should pass with valid request", func(t *testing.T) {
+		opened := openDialog(t, request)
+		assert.Equal(t, request.URL, opened.URL, "single-page dialogs keep their URL for older clients")
+		assert.NotEmpty(t, opened.Session)
+	})
+
+	t.Run("Should only keep the URL of multi-page dialogs in the session", func(t *testing.T) {
+		multiPageRequest := request
+		multiPageRequest.Dialog.Pages = []model.DialogPage{
+			{Title: "Second Page", Elements: []model.DialogElement{{DisplayName: "Other", Name: "other", Type: "text"}}},
+		}
+
+		opened := openDialog(t, multiPageRequest)
+		assert.Empty(t, opened.URL)
+		assert.NotEmpty(t, opened.Session)
 	})
 
 	t.Run("Should fail on bad trigger ID", func(t *testing.T) {
@@ -296,15 +332,24 @@ func TestSubmitDialog(t *testing.T) {
 		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
 	})
 
-	submit := model.SubmitDialogRequest{
+	dialog := model.Dialog{
 		CallbackId: "callbackid",
+		Title:      "Some Title",
 		State:      "somestate",
+		Elements: []model.DialogElement{
+			{DisplayName: "Some Name", Name: "somename", Type: "text"},
+		},
+	}
+
+	submit := model.SubmitDialogRequest{
 		UserId:     th.BasicUser.Id,
 		ChannelId:  th.BasicChannel.Id,
 		TeamId:     th.BasicTeam.Id,
 		Submission: map[string]any{"somename": "somevalue"},
 	}
 
+	var expectValidated atomic.Bool
+	expectValidated.Store(true)
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var request model.SubmitDialogRequest
 		err := json.NewDecoder(r.Body).Decode(&request)
@@ -314,34 +359,48 @@ func TestSubmitDialog(t *testing.T) {
 		assert.Equal(t, request.UserId, submit.UserId)
 		assert.Equal(t, request.ChannelId, submit.ChannelId)
 		assert.Equal(t, request.TeamId, submit.TeamId)
-		assert.Equal(t, request.CallbackId, submit.CallbackId)
-		assert.Equal(t, request.State, submit.State)
+		assert.Equal(t, request.CallbackId, dialog.CallbackId)
+		assert.Equal(t, request.State, dialog.State)
+		assert.Equal(t, expectValidated.Load(), request.Validated)
 		val, ok := request.Submission["somename"].(string)
 		require.True(t, ok)
 		assert.Equal(t, "somevalue", val)
 	}))
 	defer ts.Close()
 
-	submit.URL = ts.URL
+	encodedSession, err := model.EncryptDialogSession(model.NewDialogSession(th.BasicUser.Id, ts.URL, dialog), th.App.PostActionCookieSecret())
+	require.NoError(t, err)
+	submit.Session = encodedSession
 
 	submitResp, _, err := client.SubmitInteractiveDialog(context.Background(), submit)
 	require.NoError(t, err)
 	assert.NotNil(t, submitResp)
 
-	submit.URL = ""
-	submitResp, resp, err := client.SubmitInteractiveDialog(context.Background(), submit)
+	// Older clients submit single-page dialogs to their URL, which are forwarded unvalidated
+	legacySubmit := submit
+	legacySubmit.Session = ""
+	legacySubmit.URL = ts.URL
+	legacySubmit.CallbackId = dialog.CallbackId
+	legacySubmit.State = dialog.State
+	legacySubmit.Validated = true
+	expectValidated.Store(false)
+	submitResp, _, err = client.SubmitInteractiveDialog(context.Background(), legacySubmit)
+	require.NoError(t, err)
+	assert.NotNil(t, submitResp)
+	expectValidated.Store(true)
+
+	legacySubmit.URL = ""
+	submitResp, resp, err := client.SubmitInteractiveDialog(context.Background(), legacySubmit)
 	require.Error(t, err)
 	CheckBadRequestStatus(t, resp)
 	assert.Nil(t, submitResp)
 
-	submit.URL = ts.URL
 	submit.ChannelId = model.NewId()
 	submitResp, resp, err = client.SubmitInteractiveDialog(context.Background(), submit)
 	require.Error(t, err)
 	CheckNotFoundStatus(t, resp)
 	assert.Nil(t, submitResp)
 
-	submit.URL = ts.URL
 	submit.ChannelId = th.BasicChannel.Id
 	submit.TeamId = model.NewId()
 	submitResp, resp, err = client.SubmitInteractiveDialog(context.Background(), submit)
@@ -188,7 +188,7 @@ func getJobs(c *Context, w http.ResponseWriter, r *http.Request) {
 	var validJobTypes []string
 
 	if jobType != "" {
-		isValidJobType := model.IsValidJobType(jobType)
+		isValidJobType := model.IsValidJobType(jobType) || model.IsValidPluginJobType(jobType)
 		if !isValidJobType {
 			c.SetInvalidURLParam("job_type")
 			return
@@ -204,7 +204,8 @@ func getJobs(c *Context, w http.ResponseWriter, r *http.Request) {
 		}
 		validJobTypes = append(validJobTypes, jobType)
 	} else {
-		for _, jType := range model.AllJobTypes {
+		allJobTypes := append(model.AllJobTypes[:], c.App.GetPluginJobTypes()...)
+		for _, jType := range allJobTypes {
 			hasPermission, permissionRequired := c.App.SessionHasPermissionToReadJob(*c.AppContext.Session(), jType)
 			if permissionRequired == nil {
 				c.Logger.Warn("The job types of a job you are trying to retrieve does not contain permissions", mlog.String("jobType", jType))
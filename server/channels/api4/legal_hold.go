@@ -0,0 +1,215 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *API) InitLegalHold() {
+	api.BaseRoutes.LegalHolds.Handle("", api.APISessionRequired(getLegalHolds)).Methods(http.MethodGet)
+	api.BaseRoutes.LegalHolds.Handle("", api.APISessionRequired(createLegalHold)).Methods(http.MethodPost)
+	api.BaseRoutes.LegalHolds.Handle("/{legal_hold_id:[A-Za-z0-9]+}", api.APISessionRequired(getLegalHold)).Methods(http.MethodGet)
+	api.BaseRoutes.LegalHolds.Handle("/{legal_hold_id:[A-Za-z0-9]+}", api.APISessionRequired(patchLegalHold)).Methods(http.MethodPatch)
+	api.BaseRoutes.LegalHolds.Handle("/{legal_hold_id:[A-Za-z0-9]+}", api.APISessionRequired(releaseLegalHold)).Methods(http.MethodDelete)
+	api.BaseRoutes.LegalHolds.Handle("/{legal_hold_id:[A-Za-z0-9]+}/export", api.APISessionRequired(exportLegalHold)).Methods(http.MethodPost)
+}
+
+func getLegalHolds(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceDataRetentionPolicy)
+		return
+	}
+
+	holds, appErr := c.App.GetLegalHolds(c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(holds)
+	if err != nil {
+		c.Err = model.NewAppError("getLegalHolds", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireLegalHoldId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleReadComplianceDataRetentionPolicy)
+		return
+	}
+
+	hold, appErr := c.App.GetLegalHold(c.Params.LegalHoldId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	js, err := json.Marshal(hold)
+	if err != nil {
+		c.Err = model.NewAppError("getLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func createLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	var hold model.LegalHold
+	if jsonErr := json.NewDecoder(r.Body).Decode(&hold); jsonErr != nil {
+		c.SetInvalidParamWithErr("legal_hold", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventCreateLegalHold, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "legal_hold", &hold)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteComplianceDataRetentionPolicy)
+		return
+	}
+
+	hold.Id = ""
+	hold.CreatorId = c.AppContext.Session().UserId
+
+	newHold, appErr := c.App.CreateLegalHold(&hold)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventResultState(newHold)
+	auditRec.AddEventObjectType("legal_hold")
+
+	js, err := json.Marshal(newHold)
+	if err != nil {
+		c.Err = model.NewAppError("createLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	auditRec.Success()
+	w.WriteHeader(http.StatusCreated)
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func patchLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireLegalHoldId()
+	if c.Err != nil {
+		return
+	}
+
+	var patch model.LegalHoldPatch
+	if jsonErr := json.NewDecoder(r.Body).Decode(&patch); jsonErr != nil {
+		c.SetInvalidParamWithErr("legal_hold", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventPatchLegalHold, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "legal_hold_id", c.Params.LegalHoldId)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "patch", &patch)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteComplianceDataRetentionPolicy)
+		return
+	}
+
+	hold, appErr := c.App.PatchLegalHold(c.Params.LegalHoldId, &patch)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventResultState(hold)
+	auditRec.AddEventObjectType("legal_hold")
+
+	js, err := json.Marshal(hold)
+	if err != nil {
+		c.Err = model.NewAppError("patchLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	auditRec.Success()
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func releaseLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireLegalHoldId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventReleaseLegalHold, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "legal_hold_id", c.Params.LegalHoldId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteComplianceDataRetentionPolicy) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteComplianceDataRetentionPolicy)
+		return
+	}
+
+	if appErr := c.App.ReleaseLegalHold(c.Params.LegalHoldId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
+func exportLegalHold(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireLegalHoldId()
+	if c.Err != nil {
+		return
+	}
+
+	exportType := r.URL.Query().Get("export_type")
+
+	auditRec := c.MakeAuditRecord(model.AuditEventExportLegalHold, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "legal_hold_id", c.Params.LegalHoldId)
+	model.AddEventParameterToAuditRec(auditRec, "export_type", exportType)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionCreateComplianceExportJob) {
+		c.SetPermissionError(model.PermissionCreateComplianceExportJob)
+		return
+	}
+
+	job, appErr := c.App.ExportLegalHold(c.AppContext, c.Params.LegalHoldId, exportType)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventResultState(job)
+	auditRec.AddEventObjectType("job")
+
+	js, err := json.Marshal(job)
+	if err != nil {
+		c.Err = model.NewAppError("exportLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	auditRec.Success()
+	w.WriteHeader(http.StatusCreated)
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
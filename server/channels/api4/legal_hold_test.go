@@ -0,0 +1,111 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestLegalHolds(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	hold := &model.LegalHold{
+		Name:        "hold-" + model.NewId(),
+		DisplayName: "Hold",
+		UserIds:     []string{th.BasicUser.Id},
+		ChannelIds:  []string{th.BasicChannel.Id},
+	}
+
+	t.Run("requires a license", func(t *testing.T) {
+		th.App.Srv().SetLicense(nil)
+
+		_, resp, err := th.SystemAdminClient.CreateLegalHold(context.Background(), hold)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.Srv().SetLicense(model.NewTestLicense("compliance"))
+
+	t.Run("requires permission", func(t *testing.T) {
+		_, resp, err := th.Client.CreateLegalHold(context.Background(), hold)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetLegalHolds(context.Background(), 0, 100)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	created, resp, err := th.SystemAdminClient.CreateLegalHold(context.Background(), hold)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, th.SystemAdminUser.Id, created.CreatorId)
+
+	t.Run("get", func(t *testing.T) {
+		fetched, _, err := th.SystemAdminClient.GetLegalHold(context.Background(), created.Id)
+		require.NoError(t, err)
+		assert.Equal(t, created.Name, fetched.Name)
+
+		holds, _, err := th.SystemAdminClient.GetLegalHolds(context.Background(), 0, 100)
+		require.NoError(t, err)
+		require.Len(t, holds, 1)
+		assert.Equal(t, created.Id, holds[0].Id)
+
+		_, resp, err := th.SystemAdminClient.GetLegalHold(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		patched, _, err := th.SystemAdminClient.PatchLegalHold(context.Background(), created.Id, &model.LegalHoldPatch{
+			DisplayName: model.NewPointer("Renamed"),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Renamed", patched.DisplayName)
+		assert.Equal(t, created.UserIds, patched.UserIds)
+	})
+
+	t.Run("held user cannot be permanently deleted", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableAPIUserDeletion = true })
+
+		resp, err := th.SystemAdminClient.PermanentDeleteUser(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.MessageExportSettings.EnableExport = true })
+
+		_, resp, err := th.Client.ExportLegalHold(context.Background(), created.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		job, resp, err := th.SystemAdminClient.ExportLegalHold(context.Background(), created.Id, model.ComplianceExportTypeCsv)
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, created.Id, job.Data[model.LegalHoldJobDataKey])
+	})
+
+	t.Run("release", func(t *testing.T) {
+		_, err := th.SystemAdminClient.ReleaseLegalHold(context.Background(), created.Id)
+		require.NoError(t, err)
+
+		holds, _, err := th.SystemAdminClient.GetLegalHolds(context.Background(), 0, 100)
+		require.NoError(t, err)
+		assert.Empty(t, holds)
+
+		resp, err := th.SystemAdminClient.ReleaseLegalHold(context.Background(), created.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
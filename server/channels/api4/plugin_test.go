@@ -234,6 +234,20 @@ func TestPlugin(t *testing.T) {
 		require.Error(t, err)
 		CheckNotFoundStatus(t, resp)
 
+		// Approve the permissions
+		_, err = client.ApprovePluginPermissions(context.Background(), manifest.Id)
+		require.NoError(t, err)
+		require.NotNil(t, th.App.Config().PluginSettings.PluginStates[manifest.Id])
+		assert.False(t, th.App.Config().PluginSettings.PluginStates[manifest.Id].Enable)
+
+		resp, err = client.ApprovePluginPermissions(context.Background(), "junk")
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		resp, err = th.Client.ApprovePluginPermissions(context.Background(), manifest.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
 		// Get error cases
 		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PluginSettings.Enable = false })
 		_, resp, err = client.GetPlugins(context.Background())
@@ -819,13 +819,22 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request, teamId stri
 		includeDeletedChannels = *params.IncludeDeletedChannels
 	}
 
+	semanticWeight := 0.0
+	if params.SemanticWeight != nil {
+		semanticWeight = *params.SemanticWeight
+		if semanticWeight < 0 || semanticWeight > 1 {
+			c.SetInvalidParam("semantic_weight")
+			return
+		}
+	}
+
 	auditRec := c.MakeAuditRecord(model.AuditEventSearchPosts, model.AuditStatusFail)
 	defer c.LogAuditRecWithLevel(auditRec, app.LevelAPI)
 	model.AddEventParameterAuditableToAuditRec(auditRec, "search_params", params)
 
 	startTime := time.Now()
 
-	results, err := c.App.SearchPostsForUser(c.AppContext, terms, c.AppContext.Session().UserId, teamId, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
+	results, err := c.App.SearchPostsForUser(c.AppContext, terms, c.AppContext.Session().UserId, teamId, isOrSearch, includeDeletedChannels, timeZoneOffset, semanticWeight, page, perPage)
 
 	elapsedTime := float64(time.Since(startTime)) / float64(time.Second)
 	metrics := c.App.Metrics()
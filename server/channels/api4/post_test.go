@@ -3980,6 +3980,22 @@ func TestSearchPosts(t *testing.T) {
 	require.Error(t, err)
 	CheckBadRequestStatus(t, resp)
 
+	terms = "search"
+	semanticWeight := 1.5
+	searchParams = model.SearchParameter{
+		Terms:          &terms,
+		SemanticWeight: &semanticWeight,
+	}
+	_, resp, err = client.SearchPostsWithParams(context.Background(), th.BasicTeam.Id, &searchParams)
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	// Without semantic search enabled, the weight is ignored.
+	semanticWeight = 0.5
+	posts, _, err = client.SearchPostsWithParams(context.Background(), th.BasicTeam.Id, &searchParams)
+	require.NoError(t, err)
+	require.Len(t, posts.Order, 3, "wrong search")
+
 	_, err = client.Logout(context.Background())
 	require.NoError(t, err)
 	_, resp, err = client.SearchPosts(context.Background(), th.BasicTeam.Id, "#sgtitlereview", false)
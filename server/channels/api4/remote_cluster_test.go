@@ -671,3 +671,61 @@ func TestDeleteRemoteCluster(t *testing.T) {
 		require.NotZero(t, deletedRC.DeleteAt)
 	})
 }
+
+func TestRemoteClusterSyncHealth(t *testing.T) {
+	mainHelper.Parallel(t)
+	newRC := &model.RemoteCluster{
+		Name:    "remotecluster",
+		SiteURL: "http://example.com",
+		Token:   model.NewId(),
+	}
+
+	t.Run("Should not work if the remote cluster service is not enabled", func(t *testing.T) {
+		th := Setup(t)
+		defer th.TearDown()
+
+		newRC.CreatorId = th.SystemAdminUser.Id
+
+		rc, appErr := th.App.AddRemoteCluster(newRC)
+		require.Nil(t, appErr)
+
+		health, resp, err := th.SystemAdminClient.GetRemoteClusterSyncHealth(context.Background(), rc.RemoteId)
+		CheckNotImplementedStatus(t, resp)
+		require.Error(t, err)
+		require.Nil(t, health)
+	})
+
+	th := setupForSharedChannels(t).InitBasic()
+	defer th.TearDown()
+
+	newRC.CreatorId = th.SystemAdminUser.Id
+
+	rc, appErr := th.App.AddRemoteCluster(newRC)
+	require.Nil(t, appErr)
+
+	t.Run("Should not work if the user doesn't have the right permissions", func(t *testing.T) {
+		all, resp, err := th.Client.GetRemoteClustersSyncHealth(context.Background())
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+		require.Nil(t, all)
+
+		health, resp, err := th.Client.GetRemoteClusterSyncHealth(context.Background(), rc.RemoteId)
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+		require.Nil(t, health)
+
+		resp, err = th.Client.ResyncRemoteCluster(context.Background(), rc.RemoteId, &model.SharedChannelResyncRequest{Since: model.GetMillis() - 1000})
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("Should not resync with an invalid request", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.ResyncRemoteCluster(context.Background(), rc.RemoteId, &model.SharedChannelResyncRequest{})
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+
+		resp, err = th.SystemAdminClient.ResyncRemoteCluster(context.Background(), rc.RemoteId, &model.SharedChannelResyncRequest{Since: model.GetMillis() + 60000})
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+	})
+}
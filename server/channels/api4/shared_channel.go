@@ -5,10 +5,12 @@ package api4
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
 )
 
 func (api *API) InitSharedChannels() {
@@ -20,6 +22,9 @@ func (api *API) InitSharedChannels() {
 	api.BaseRoutes.SharedChannelRemotes.Handle("", api.APISessionRequired(getSharedChannelRemotesByRemoteCluster)).Methods(http.MethodGet)
 	api.BaseRoutes.ChannelForRemote.Handle("/invite", api.APISessionRequired(inviteRemoteClusterToChannel)).Methods(http.MethodPost)
 	api.BaseRoutes.ChannelForRemote.Handle("/uninvite", api.APISessionRequired(uninviteRemoteClusterToChannel)).Methods(http.MethodPost)
+	api.BaseRoutes.ChannelForRemote.Handle("/sync_filters", api.APISessionRequired(getSharedChannelRemoteSyncFilters)).Methods(http.MethodGet)
+	api.BaseRoutes.ChannelForRemote.Handle("/sync_filters", api.APISessionRequired(updateSharedChannelRemoteSyncFilters)).Methods(http.MethodPut)
+	api.BaseRoutes.ChannelForRemote.Handle("/suppressed", api.APISessionRequired(getSharedChannelSyncSuppressions)).Methods(http.MethodGet)
 }
 
 func getSharedChannels(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -248,6 +253,119 @@ func uninviteRemoteClusterToChannel(c *Context, w http.ResponseWriter, r *http.R
 	ReturnStatusOK(w)
 }
 
+// requireSharedChannelRemote validates the remote and channel params and returns the
+// matching shared channel remote, or sets c.Err.
+func requireSharedChannelRemote(c *Context) *model.SharedChannelRemote {
+	c.RequireRemoteId()
+	if c.Err != nil {
+		return nil
+	}
+
+	c.RequireChannelId()
+	if c.Err != nil {
+		return nil
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSecureConnections) {
+		c.SetPermissionError(model.PermissionManageSecureConnections)
+		return nil
+	}
+
+	// make sure remote cluster service is enabled.
+	if _, appErr := c.App.GetRemoteClusterService(); appErr != nil {
+		c.Err = appErr
+		return nil
+	}
+
+	scr, err := c.App.GetSharedChannelRemoteByIds(c.Params.ChannelId, c.Params.RemoteId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			c.Err = model.NewAppError("requireSharedChannelRemote", "api.shared_channel.channel_remote_not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		} else {
+			c.Err = model.NewAppError("requireSharedChannelRemote", "api.shared_channel.get_shared_channel_remotes_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return nil
+	}
+	return scr
+}
+
+func getSharedChannelRemoteSyncFilters(c *Context, w http.ResponseWriter, r *http.Request) {
+	scr := requireSharedChannelRemote(c)
+	if c.Err != nil {
+		return
+	}
+
+	filters := scr.SyncFilters
+	if filters == nil {
+		filters = &model.SharedChannelRemoteSyncFilters{}
+	}
+
+	if err := json.NewEncoder(w).Encode(filters); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateSharedChannelRemoteSyncFilters(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireSharedChannelRemote(c)
+	if c.Err != nil {
+		return
+	}
+
+	var filters model.SharedChannelRemoteSyncFilters
+	if err := json.NewDecoder(r.Body).Decode(&filters); err != nil {
+		c.SetInvalidParamWithErr("sync_filters", err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord(model.AuditEventUpdateRemoteSyncFilters, model.AuditStatusFail)
+	defer c.LogAuditRec(auditRec)
+	model.AddEventParameterToAuditRec(auditRec, "remote_id", c.Params.RemoteId)
+	model.AddEventParameterToAuditRec(auditRec, "channel_id", c.Params.ChannelId)
+	model.AddEventParameterAuditableToAuditRec(auditRec, "sync_filters", &filters)
+
+	scr, err := c.App.UpdateSharedChannelRemoteSyncFilters(c.Params.ChannelId, c.Params.RemoteId, &filters)
+	if err != nil {
+		if appErr, ok := err.(*model.AppError); ok {
+			c.Err = appErr
+		} else {
+			c.Err = model.NewAppError("updateSharedChannelRemoteSyncFilters", "api.shared_channel.update_sync_filters_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return
+	}
+
+	auditRec.Success()
+
+	updated := scr.SyncFilters
+	if updated == nil {
+		updated = &model.SharedChannelRemoteSyncFilters{}
+	}
+	if err := json.NewEncoder(w).Encode(updated); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getSharedChannelSyncSuppressions(c *Context, w http.ResponseWriter, r *http.Request) {
+	requireSharedChannelRemote(c)
+	if c.Err != nil {
+		return
+	}
+
+	opts := model.SharedChannelSyncSuppressionFilterOpts{
+		ChannelId: c.Params.ChannelId,
+		RemoteId:  c.Params.RemoteId,
+	}
+	suppressions, err := c.App.GetSharedChannelSyncSuppressions(c.Params.Page, c.Params.PerPage, opts)
+	if err != nil {
+		c.Err = model.NewAppError("getSharedChannelSyncSuppressions", "api.shared_channel.get_sync_suppressions_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(suppressions); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 // getSharedChannelRemotes returns info about remote clusters for a shared channel
 func getSharedChannelRemotes(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireChannelId()
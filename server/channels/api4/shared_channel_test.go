@@ -626,3 +626,97 @@ func TestUninviteRemoteClusterToChannel(t *testing.T) {
 		t.Skip("Requires server2server communication: ToBeImplemented")
 	})
 }
+
+func TestSharedChannelRemoteSyncFilters(t *testing.T) {
+	mainHelper.Parallel(t)
+	t.Run("Should not work if the remote cluster service is not enabled", func(t *testing.T) {
+		th := Setup(t)
+		defer th.TearDown()
+
+		_, resp, err := th.SystemAdminClient.GetSharedChannelRemoteSyncFilters(context.Background(), model.NewId(), model.NewId())
+		CheckNotImplementedStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	th := setupForSharedChannels(t).InitBasic()
+	defer th.TearDown()
+
+	rc, appErr := th.App.AddRemoteCluster(&model.RemoteCluster{Name: "rc", SiteURL: "http://example.com", CreatorId: th.SystemAdminUser.Id})
+	require.Nil(t, appErr)
+
+	c1 := th.CreateChannelWithClientAndTeam(th.Client, model.ChannelTypeOpen, th.BasicTeam.Id)
+	_, err := th.App.ShareChannel(th.Context, &model.SharedChannel{
+		ChannelId: c1.Id,
+		TeamId:    th.BasicTeam.Id,
+		ShareName: "shared_1",
+		CreatorId: th.BasicUser.Id,
+		RemoteId:  rc.RemoteId,
+		Home:      true,
+	})
+	require.NoError(t, err)
+
+	_, err = th.App.SaveSharedChannelRemote(&model.SharedChannelRemote{
+		ChannelId:         c1.Id,
+		CreatorId:         th.BasicUser.Id,
+		RemoteId:          rc.RemoteId,
+		IsInviteAccepted:  true,
+		IsInviteConfirmed: true,
+	})
+	require.NoError(t, err)
+
+	t.Run("Should not work if the user doesn't have the right permissions", func(t *testing.T) {
+		_, resp, err := th.Client.GetSharedChannelRemoteSyncFilters(context.Background(), rc.RemoteId, c1.Id)
+		CheckForbiddenStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("should not work if the channel is not shared with the remote", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetSharedChannelRemoteSyncFilters(context.Background(), rc.RemoteId, model.NewId())
+		CheckNotFoundStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("should reject invalid filters", func(t *testing.T) {
+		filters := &model.SharedChannelRemoteSyncFilters{ExcludeUserIds: []string{"invalid"}}
+		_, resp, err := th.SystemAdminClient.UpdateSharedChannelRemoteSyncFilters(context.Background(), rc.RemoteId, c1.Id, filters)
+		CheckBadRequestStatus(t, resp)
+		require.Error(t, err)
+	})
+
+	t.Run("should update and return the filters", func(t *testing.T) {
+		filters, _, err := th.SystemAdminClient.GetSharedChannelRemoteSyncFilters(context.Background(), rc.RemoteId, c1.Id)
+		require.NoError(t, err)
+		require.True(t, filters.IsEmpty())
+
+		filters = &model.SharedChannelRemoteSyncFilters{
+			ExcludeBots:       true,
+			ExcludePostTypes:  []string{model.PostTypeJoinChannel},
+			ExcludeUserIds:    []string{th.BasicUser2.Id},
+			MaxAttachmentSize: 1024,
+		}
+		updated, _, err := th.SystemAdminClient.UpdateSharedChannelRemoteSyncFilters(context.Background(), rc.RemoteId, c1.Id, filters)
+		require.NoError(t, err)
+		require.Equal(t, filters, updated)
+
+		fetched, _, err := th.SystemAdminClient.GetSharedChannelRemoteSyncFilters(context.Background(), rc.RemoteId, c1.Id)
+		require.NoError(t, err)
+		require.Equal(t, filters, fetched)
+	})
+
+	t.Run("should return the suppressed items", func(t *testing.T) {
+		suppression := &model.SharedChannelSyncSuppression{
+			RemoteId:  rc.RemoteId,
+			ItemId:    model.NewId(),
+			ChannelId: c1.Id,
+			ItemType:  model.SharedChannelSyncItemPost,
+			Reason:    model.SharedChannelSyncSuppressedBot,
+			CreateAt:  model.GetMillis(),
+		}
+		require.NoError(t, th.App.Srv().Store().SharedChannel().SaveSyncSuppressions([]*model.SharedChannelSyncSuppression{suppression}))
+
+		suppressions, _, err := th.SystemAdminClient.GetSharedChannelSyncSuppressions(context.Background(), rc.RemoteId, c1.Id, 0, 100)
+		require.NoError(t, err)
+		require.Len(t, suppressions, 1)
+		require.Equal(t, suppression.ItemId, suppressions[0].ItemId)
+	})
+}
@@ -419,6 +419,30 @@ func TestInvalidateCaches(t *testing.T) {
 	})
 }
 
+func TestGetCacheStats(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+
+	t.Run("as system user", func(t *testing.T) {
+		_, resp, err := th.Client.GetCacheStats(context.Background())
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("as system admin", func(t *testing.T) {
+		stats, _, err := th.SystemAdminClient.GetCacheStats(context.Background())
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(stats))
+		for _, s := range stats {
+			names = append(names, s.Name)
+		}
+		assert.Contains(t, names, "Session")
+		assert.Contains(t, names, "Status")
+	})
+}
+
 func TestGetLogs(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t)
@@ -14,6 +14,7 @@ import (
 func (api *API) InitWebhook() {
 	api.BaseRoutes.IncomingHooks.Handle("", api.APISessionRequired(createIncomingHook)).Methods(http.MethodPost)
 	api.BaseRoutes.IncomingHooks.Handle("", api.APISessionRequired(getIncomingHooks)).Methods(http.MethodGet)
+	api.BaseRoutes.IncomingHooks.Handle("/transform/test", api.APISessionRequired(testIncomingHookTransform)).Methods(http.MethodPost)
 	api.BaseRoutes.IncomingHook.Handle("", api.APISessionRequired(getIncomingHook)).Methods(http.MethodGet)
 	api.BaseRoutes.IncomingHook.Handle("", api.APISessionRequired(updateIncomingHook)).Methods(http.MethodPut)
 	api.BaseRoutes.IncomingHook.Handle("", api.APISessionRequired(deleteIncomingHook)).Methods(http.MethodDelete)
@@ -176,6 +177,45 @@ func updateIncomingHook(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func testIncomingHookTransform(c *Context, w http.ResponseWriter, r *http.Request) {
+	var test model.IncomingWebhookTransformTest
+	if jsonErr := json.NewDecoder(r.Body).Decode(&test); jsonErr != nil {
+		c.SetInvalidParamWithErr("transform_test", jsonErr)
+		return
+	}
+
+	if test.TeamId != "" {
+		if !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), test.TeamId, model.PermissionManageIncomingWebhooks) {
+			c.SetPermissionError(model.PermissionManageIncomingWebhooks)
+			return
+		}
+	} else if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageIncomingWebhooks) {
+		c.SetPermissionError(model.PermissionManageIncomingWebhooks)
+		return
+	}
+
+	if len(test.TransformTemplate) > model.IncomingWebhookTransformMaxSize {
+		c.SetInvalidParam("transform_template")
+		return
+	}
+
+	if len(test.Payload) == 0 {
+		c.SetInvalidParam("payload")
+		return
+	}
+
+	// The tests of a user are capped like the renders of a webhook.
+	req, appErr := c.App.TransformIncomingWebhookPayload(c.AppContext.Session().UserId, test.TransformTemplate, test.Payload)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(req); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func getIncomingHooks(c *Context, w http.ResponseWriter, r *http.Request) {
 	var (
 		teamID = r.URL.Query().Get("team_id")
@@ -5,6 +5,7 @@ package api4
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -146,6 +147,54 @@ func TestCreateIncomingWebhook_BypassTeamPermissions(t *testing.T) {
 	CheckForbiddenStatus(t, resp)
 }
 
+func TestTestIncomingWebhookTransform(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	defaultRolePermissions := th.SaveDefaultRolePermissions()
+	defer func() {
+		th.RestoreDefaultRolePermissions(defaultRolePermissions)
+	}()
+	th.AddPermissionToRole(model.PermissionManageIncomingWebhooks.Id, model.TeamAdminRoleId)
+	th.RemovePermissionFromRole(model.PermissionManageIncomingWebhooks.Id, model.TeamUserRoleId)
+
+	test := &model.IncomingWebhookTransformTest{
+		TeamId:            th.BasicTeam.Id,
+		TransformTemplate: `{"text": {{json .title}}, "channel": "#{{.channel}}"}`,
+		Payload:           json.RawMessage(`{"title": "Build failed", "channel": "builds"}`),
+	}
+
+	t.Run("without permission", func(t *testing.T) {
+		_, resp, err := th.Client.TestIncomingWebhookTransform(context.Background(), test)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("render", func(t *testing.T) {
+		req, _, err := th.SystemAdminClient.TestIncomingWebhookTransform(context.Background(), test)
+		require.NoError(t, err)
+		assert.Equal(t, "Build failed", req.Text)
+		assert.Equal(t, "#builds", req.ChannelName)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		invalid := *test
+		invalid.TransformTemplate = "{{.title"
+		_, resp, err := th.SystemAdminClient.TestIncomingWebhookTransform(context.Background(), &invalid)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("missing payload", func(t *testing.T) {
+		invalid := *test
+		invalid.Payload = nil
+		_, resp, err := th.SystemAdminClient.TestIncomingWebhookTransform(context.Background(), &invalid)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
 func TestGetIncomingWebhooks(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic()
@@ -535,6 +584,25 @@ func TestCreateOutgoingWebhook(t *testing.T) {
 		CheckBadRequestStatus(t, response)
 	})
 
+	t.Run("Create an outgoing webhook subscribed to events", func(t *testing.T) {
+		eventHook := &model.OutgoingWebhook{TeamId: th.BasicTeam.Id, CallbackURLs: []string{"http://nowhere.com"}, EventTypes: []string{model.OutgoingWebhookEventUserJoinedTeam}}
+
+		rhook, _, err2 := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), eventHook)
+		require.NoError(t, err2)
+		assert.Equal(t, model.StringArray{model.OutgoingWebhookEventUserJoinedTeam}, rhook.EventTypes)
+
+		eventHook.EventTypes = []string{"unknown"}
+		_, response, err2 := th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), eventHook)
+		require.Error(t, err2)
+		CheckBadRequestStatus(t, response)
+
+		eventHook.ChannelId = th.BasicChannel.Id
+		eventHook.EventTypes = []string{model.OutgoingWebhookEventUserJoinedTeam}
+		_, response, err2 = th.SystemAdminClient.CreateOutgoingWebhook(context.Background(), eventHook)
+		require.Error(t, err2)
+		CheckBadRequestStatus(t, response)
+	})
+
 	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOutgoingWebhooks = false })
 	_, resp, err = client.CreateOutgoingWebhook(context.Background(), hook)
 	require.Error(t, err)
@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"encoding/json"
 	"net/http"
 	"strconv"
 
@@ -20,6 +21,9 @@ const (
 	sequenceNumberParam    = "sequence_number"
 	postedAckParam         = "posted_ack"
 	disconnectErrCodeParam = "disconnect_err_code"
+	compressionParam       = "compression"
+	encodingParam          = "encoding"
+	lastEventIDHeader      = "Last-Event-ID"
 
 	clientPingTimeoutErrCode      = 4000
 	clientSequenceMismatchErrCode = 4001
@@ -52,6 +56,10 @@ func validateDisconnectErrCode(errCode string) bool {
 func (api *API) InitWebSocket() {
 	// Optionally supports a trailing slash
 	api.BaseRoutes.APIRoot.Handle("/{websocket:websocket(?:\\/)?}", api.APIHandlerTrustRequester(connectWebSocket)).Methods(http.MethodGet)
+
+	// Server-Sent Events fallback for the clients that can't open a WebSocket
+	api.BaseRoutes.APIRoot.Handle("/sse", api.APISessionRequiredTrustRequester(connectSSE)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/sse/requests", api.APISessionRequired(sendSSERequest)).Methods(http.MethodPost)
 }
 
 func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -59,6 +67,8 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 		ReadBufferSize:  model.SocketMaxMessageSizeKb,
 		WriteBufferSize: model.SocketMaxMessageSizeKb,
 		CheckOrigin:     c.App.OriginChecker(),
+		// permessage-deflate is only used if the client also offers it.
+		EnableCompression: r.URL.Query().Get(compressionParam) == "true",
 	}
 
 	ws, err := upgrader.Upgrade(w, r, nil)
@@ -70,10 +80,33 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// We initialize webconn with all the necessary data.
-	// If the queues are empty, they are initialized in the constructor.
+	cfg, err := newWebConnConfig(c, r, r.URL.Query().Get(connectionIDParam), r.URL.Query().Get(sequenceNumberParam))
+	if err != nil {
+		c.Logger.Error("Error while populating webconn config", mlog.String("id", r.URL.Query().Get(connectionIDParam)), mlog.Err(err))
+		ws.Close()
+		return
+	}
+	cfg.WebSocket = ws
+	cfg.Msgpack = r.URL.Query().Get(encodingParam) == model.WebSocketEncodingMsgpack
+
+	wc := c.App.Srv().Platform().NewWebConn(cfg, c.App, c.App.Srv().Channels())
+	if c.AppContext.Session().UserId != "" {
+		err = c.App.Srv().Platform().HubRegister(wc)
+		if err != nil {
+			c.Logger.Error("Error while registering to hub", mlog.String("id", r.URL.Query().Get(connectionIDParam)), mlog.Err(err))
+			ws.Close()
+			return
+		}
+	}
+
+	wc.Pump()
+}
+
+// newWebConnConfig initializes the config of a connection with all the
+// necessary data, resuming the given connection if it still exists.
+// If the queues are empty, they are initialized in the constructor.
+func newWebConnConfig(c *Context, r *http.Request, connectionID, sequenceNumber string) (*platform.WebConnConfig, error) {
 	cfg := &platform.WebConnConfig{
-		WebSocket:     ws,
 		Session:       *c.AppContext.Session(),
 		TFunc:         c.AppContext.T,
 		Locale:        "",
@@ -96,30 +129,64 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 		cfg.OriginClient = string(web.GetOriginClient(r))
 	}
 
-	cfg.ConnectionID = r.URL.Query().Get(connectionIDParam)
+	cfg.ConnectionID = connectionID
 	if cfg.ConnectionID == "" || c.AppContext.Session().UserId == "" {
 		// If not present, we assume client is not capable yet, or it's a fresh connection.
 		// We just create a new ID.
 		cfg.ConnectionID = model.NewId()
 		// In case of fresh connection id, sequence number is already zero.
-	} else {
-		cfg, err = c.App.Srv().Platform().PopulateWebConnConfig(c.AppContext.Session(), cfg, r.URL.Query().Get(sequenceNumberParam))
-		if err != nil {
-			c.Logger.Error("Error while populating webconn config", mlog.String("id", r.URL.Query().Get(connectionIDParam)), mlog.Err(err))
-			ws.Close()
-			return
+		return cfg, nil
+	}
+
+	return c.App.Srv().Platform().PopulateWebConnConfig(c.AppContext.Session(), cfg, sequenceNumber)
+}
+
+func connectSSE(c *Context, w http.ResponseWriter, r *http.Request) {
+	connectionID := r.URL.Query().Get(connectionIDParam)
+	sequenceNumber := r.URL.Query().Get(sequenceNumberParam)
+
+	// EventSource reconnects by itself, sending the id of the last event
+	// it received, which takes precedence over the query.
+	if lastEventID := r.Header.Get(lastEventIDHeader); lastEventID != "" {
+		if id, nextSeq, ok := platform.ParseSSEEventID(lastEventID); ok {
+			connectionID = id
+			sequenceNumber = strconv.FormatInt(nextSeq, 10)
 		}
 	}
 
+	cfg, err := newWebConnConfig(c, r, connectionID, sequenceNumber)
+	if err != nil {
+		c.Err = model.NewAppError("connectSSE", "api.sse.connect.invalid_params.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		return
+	}
+	cfg.SSE = platform.NewSSEStream(w, r)
+
 	wc := c.App.Srv().Platform().NewWebConn(cfg, c.App, c.App.Srv().Channels())
-	if c.AppContext.Session().UserId != "" {
-		err = c.App.Srv().Platform().HubRegister(wc)
-		if err != nil {
-			c.Logger.Error("Error while registering to hub", mlog.String("id", r.URL.Query().Get(connectionIDParam)), mlog.Err(err))
-			ws.Close()
-			return
-		}
+	if err = c.App.Srv().Platform().HubRegister(wc); err != nil {
+		c.Err = model.NewAppError("connectSSE", "api.sse.connect.register.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
 	}
 
 	wc.Pump()
 }
+
+func sendSSERequest(c *Context, w http.ResponseWriter, r *http.Request) {
+	connectionID := r.URL.Query().Get(connectionIDParam)
+	if !model.IsValidId(connectionID) {
+		c.SetInvalidParam(connectionIDParam)
+		return
+	}
+
+	var req model.WebSocketRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, model.SocketMaxMessageSizeKb)).Decode(&req); err != nil {
+		c.SetInvalidParamWithErr("request", err)
+		return
+	}
+
+	if err := c.App.Srv().Platform().ServeSSERequest(c.AppContext.Session().UserId, connectionID, &req); err != nil {
+		c.Err = model.NewAppError("sendSSERequest", "api.sse.send_request.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		return
+	}
+
+	ReturnStatusOK(w)
+}
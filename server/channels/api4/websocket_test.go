@@ -4,6 +4,7 @@
 package api4
 
 import (
+	"bufio"
 	"context"
 	"fmt"
 	"net/http"
@@ -536,3 +537,125 @@ func TestValidateDisconnectErrCode(t *testing.T) {
 		})
 	}
 }
+
+type sseEvent struct {
+	id   string
+	data string
+}
+
+// connectTestSSE opens an events stream with the session of the client and
+// returns the channel its events are read into.
+func connectTestSSE(t *testing.T, th *TestHelper, lastEventID string) (<-chan sseEvent, func()) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, th.Client.APIURL+"/sse", nil)
+	require.NoError(t, err)
+	req.Header.Set(model.HeaderAuth, model.HeaderBearer+" "+th.Client.AuthToken)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	events := make(chan sseEvent, 10)
+	go func() {
+		defer close(events)
+		var evt sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if evt.data != "" {
+					events <- evt
+				}
+				evt = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				evt.id = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "data: "):
+				evt.data += strings.TrimPrefix(line, "data: ")
+			}
+		}
+	}()
+
+	return events, func() { resp.Body.Close() }
+}
+
+func readSSEEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+
+	select {
+	case evt, ok := <-events:
+		require.True(t, ok, "stream closed")
+		return evt
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for an event")
+	}
+	return sseEvent{}
+}
+
+func TestServerSentEvents(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	events, closeStream := connectTestSSE(t, th, "")
+
+	hello := readSSEEvent(t, events)
+	helloEvent, err := model.WebSocketEventFromJSON(strings.NewReader(hello.data))
+	require.NoError(t, err)
+	require.Equal(t, model.WebsocketEventHello, helloEvent.EventType())
+	connectionID := helloEvent.GetData()["connection_id"].(string)
+	require.Equal(t, connectionID+":0", hello.id)
+
+	t.Run("requests are answered through the stream", func(t *testing.T) {
+		_, err := th.Client.SendSSERequest(context.Background(), connectionID, &model.WebSocketRequest{
+			Seq:    1,
+			Action: "get_statuses",
+		})
+		require.NoError(t, err)
+
+		evt := readSSEEvent(t, events)
+		require.Empty(t, evt.id)
+		resp, err := model.WebSocketResponseFromJSON(strings.NewReader(evt.data))
+		require.NoError(t, err)
+		require.Equal(t, model.StatusOk, resp.Status)
+		require.Equal(t, int64(1), resp.SeqReply)
+	})
+
+	t.Run("requests to an unknown connection fail", func(t *testing.T) {
+		resp, err := th.Client.SendSSERequest(context.Background(), model.NewId(), &model.WebSocketRequest{
+			Seq:    2,
+			Action: "get_statuses",
+		})
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("the events missed while disconnected are resumed", func(t *testing.T) {
+		session, appErr := th.App.GetSession(th.Client.AuthToken)
+		require.Nil(t, appErr)
+
+		closeStream()
+		require.Eventually(t, func() bool {
+			return !th.App.Srv().Platform().SessionIsRegistered(*session)
+		}, 5*time.Second, 50*time.Millisecond)
+
+		evt := model.NewWebSocketEvent(model.WebsocketEventTyping, "", "", th.BasicUser.Id, nil, "")
+		evt.Add("user_id", "resumed")
+		th.App.Publish(evt)
+
+		events, closeStream = connectTestSSE(t, th, hello.id)
+		defer closeStream()
+
+		resumed := readSSEEvent(t, events)
+		require.Equal(t, connectionID+":1", resumed.id)
+		resumedEvent, err := model.WebSocketEventFromJSON(strings.NewReader(resumed.data))
+		require.NoError(t, err)
+		require.Equal(t, model.WebsocketEventTyping, resumedEvent.EventType())
+		require.Equal(t, "resumed", resumedEvent.GetData()["user_id"])
+	})
+}
@@ -145,6 +145,11 @@ func (s *Server) InvalidateAllCachesSkipSend() *model.AppError {
 	return s.platform.InvalidateAllCachesSkipSend()
 }
 
+// CacheStats returns the usage statistics of the caches of this node.
+func (s *Server) CacheStats() []*model.CacheStats {
+	return s.platform.CacheStats()
+}
+
 func (a *App) RecycleDatabaseConnection(rctx request.CTX) {
 	rctx.Logger().Info("Attempting to recycle database connections.")
 
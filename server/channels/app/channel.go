@@ -311,6 +311,13 @@ func (a *App) CreateChannel(c request.CTX, channel *model.Channel, addMember boo
 		}, plugin.ChannelHasBeenCreatedID)
 	})
 
+	a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+		eventType: model.OutgoingWebhookEventChannelCreated,
+		teamID:    sc.TeamId,
+		channel:   sc,
+		userID:    sc.CreatorId,
+	})
+
 	return sc, nil
 }
 
@@ -717,6 +724,11 @@ func (a *App) UpdateChannel(c request.CTX, channel *model.Channel) (*model.Chann
 		return nil, model.NewAppError("UpdateChannel", "api.channel.update_channel.not_allowed.app_error", nil, "", http.StatusForbidden)
 	}
 
+	oldChannel, appErr := a.GetChannel(c, channel.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
 	_, err := a.Srv().Store().Channel().Update(c, channel)
 	if err != nil {
 		var appErr *model.AppError
@@ -744,6 +756,14 @@ func (a *App) UpdateChannel(c request.CTX, channel *model.Channel) (*model.Chann
 	messageWs.Add("channel", string(channelJSON))
 	a.Publish(messageWs)
 
+	a.Srv().Go(func() {
+		pluginContext := pluginContext(c)
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.ChannelHasBeenUpdated(pluginContext, channel, oldChannel)
+			return true
+		}, plugin.ChannelHasBeenUpdatedID)
+	})
+
 	return channel, nil
 }
 
@@ -1547,6 +1567,16 @@ func (a *App) DeleteChannel(c request.CTX, channel *model.Channel, userID string
 		return err
 	}
 
+	var rejectionReason string
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.ChannelWillBeArchived(pluginContext, channel)
+		return rejectionReason == ""
+	}, plugin.ChannelWillBeArchivedID)
+	if rejectionReason != "" {
+		return model.NewAppError("DeleteChannel", "app.channel.delete.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+
 	if user != nil {
 		T := i18n.GetUserTranslations(user.Locale)
 
@@ -1584,6 +1614,14 @@ func (a *App) DeleteChannel(c request.CTX, channel *model.Channel, userID string
 		}
 	}
 
+	// The event is sent before the webhooks of the channel are deleted, so they receive it too
+	a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+		eventType: model.OutgoingWebhookEventChannelArchived,
+		teamID:    channel.TeamId,
+		channel:   channel,
+		userID:    userID,
+	})
+
 	now := model.GetMillis()
 	for _, hook := range ihcresult.Data {
 		if err := a.Srv().Store().Webhook().DeleteIncoming(hook.Id, now); err != nil {
@@ -1822,6 +1860,14 @@ func (a *App) AddChannelMember(c request.CTX, userID string, channel *model.Chan
 		}, plugin.UserHasJoinedChannelID)
 	})
 
+	a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+		eventType: model.OutgoingWebhookEventUserJoinedChannel,
+		teamID:    channel.TeamId,
+		channel:   channel,
+		userID:    user.Id,
+		actorID:   opts.UserRequestorID,
+	})
+
 	if opts.UserRequestorID == "" || userID == opts.UserRequestorID {
 		if err := a.postJoinChannelMessage(c, user, channel); err != nil {
 			return nil, err
@@ -2450,6 +2496,13 @@ func (a *App) JoinChannel(c request.CTX, channel *model.Channel, userID string)
 		}, plugin.UserHasJoinedChannelID)
 	})
 
+	a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+		eventType: model.OutgoingWebhookEventUserJoinedChannel,
+		teamID:    channel.TeamId,
+		channel:   channel,
+		userID:    user.Id,
+	})
+
 	if err := a.postJoinChannelMessage(c, user, channel); err != nil {
 		return err
 	}
@@ -2748,6 +2801,14 @@ func (a *App) removeUserFromChannel(c request.CTX, userIDToRemove string, remove
 		}, plugin.UserHasLeftChannelID)
 	})
 
+	a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+		eventType: model.OutgoingWebhookEventUserLeftChannel,
+		teamID:    channel.TeamId,
+		channel:   channel,
+		userID:    userIDToRemove,
+		actorID:   removerUserId,
+	})
+
 	message := model.NewWebSocketEvent(model.WebsocketEventUserRemoved, "", channel.Id, "", nil, "")
 	message.Add("user_id", userIDToRemove)
 	message.Add("remover_id", removerUserId)
@@ -3280,6 +3341,10 @@ func (a *App) ViewChannel(c request.CTX, view *model.ChannelView, userID string,
 }
 
 func (a *App) PermanentDeleteChannel(c request.CTX, channel *model.Channel) *model.AppError {
+	if appErr := a.checkChannelNotUnderLegalHold("PermanentDeleteChannel", channel.Id); appErr != nil {
+		return appErr
+	}
+
 	if err := a.Srv().Store().Post().PermanentDeleteByChannel(c, channel.Id); err != nil {
 		return model.NewAppError("PermanentDeleteChannel", "app.post.permanent_delete_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
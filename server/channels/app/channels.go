@@ -44,6 +44,11 @@ type Channels struct {
 
 	pluginCommandsLock            sync.RWMutex
 	pluginCommands                []*PluginCommand
+	pluginJobTypesLock            sync.RWMutex
+	pluginJobTypes                map[string]*PluginJobType
+	pluginDocumentExtractorsLock  sync.RWMutex
+	pluginDocumentExtractors      map[string]*PluginDocumentExtractor
+	pluginSearchEngineLock        sync.Mutex
 	pluginsLock                   sync.RWMutex
 	pluginsEnvironment            *plugin.Environment
 	pluginConfigListenerID        string
@@ -82,6 +87,10 @@ type Channels struct {
 	postReminderMut  sync.Mutex
 	postReminderTask *model.ScheduledTask
 
+	// incomingWebhookTransforms caps the transform templates of the incoming webhooks
+	// rendering at once.
+	incomingWebhookTransforms model.IncomingWebhookTransformLimiter
+
 	interruptQuitChan     chan struct{}
 	scheduledPostMut      sync.Mutex
 	scheduledPostTask     *model.ScheduledTask
@@ -66,6 +66,8 @@ type batchedNotification struct {
 	userID   string
 	post     *model.Post
 	teamName string
+	// message is the rendered message of the post
+	message string
 }
 
 type EmailBatchingJob struct {
@@ -249,6 +251,48 @@ func truncateUserNames(name string, i int) string {
 	return name
 }
 
+// filterBatchedNotifications renders the message of each post of a batch and
+// passes it to the EmailNotificationWillBeSent hook, keeping the message a
+// plugin replaced and dropping the posts that were rejected.
+func (es *Service) filterBatchedNotifications(user *model.User, notifications []*batchedNotification, siteURL string, translateFunc i18n.TranslateFunc) []*batchedNotification {
+	displayNameFormat := *es.config().TeamSettings.TeammateNameDisplay
+
+	filtered := make([]*batchedNotification, 0, len(notifications))
+	for _, notification := range notifications {
+		notification.message = es.GetMessageForNotification(notification.post, notification.teamName, siteURL, translateFunc)
+		if es.emailNotificationWillBeSent == nil {
+			filtered = append(filtered, notification)
+			continue
+		}
+
+		emailNotification := &model.EmailNotification{
+			To:        user.Email,
+			Body:      notification.message,
+			PostId:    notification.post.Id,
+			ChannelId: notification.post.ChannelId,
+			TeamName:  notification.teamName,
+			SenderId:  notification.post.UserId,
+			Batched:   true,
+		}
+		if channel, err := es.store.Channel().Get(notification.post.ChannelId, true); err == nil {
+			emailNotification.ChannelName = channel.DisplayName
+			emailNotification.ChannelType = channel.Type
+		}
+		if sender, err := es.userService.GetUser(notification.post.UserId); err == nil {
+			emailNotification.SenderName = sender.GetDisplayName(displayNameFormat)
+		}
+
+		emailNotification, rejectionReason := es.emailNotificationWillBeSent(emailNotification, user, notification.post)
+		if rejectionReason != "" {
+			continue
+		}
+		notification.message = emailNotification.Body
+		filtered = append(filtered, notification)
+	}
+
+	return filtered
+}
+
 func (es *Service) sendBatchedEmailNotification(userID string, notifications []*batchedNotification) {
 	user, err := es.userService.GetUser(userID)
 	if err != nil {
@@ -279,6 +323,11 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 		}
 	}
 
+	notifications = es.filterBatchedNotifications(user, notifications, siteURL, translateFunc)
+	if len(notifications) == 0 {
+		return
+	}
+
 	var useMilitaryTime bool
 	if data, err := es.store.Preference().Get(user.Id, model.PreferenceCategoryDisplaySettings, model.PreferenceNameUseMilitaryTime); err != nil {
 		useMilitaryTime = false
@@ -332,13 +381,12 @@ func (es *Service) sendBatchedEmailNotification(userID string, notifications []*
 				channelDisplayName = truncateUserNames(channel.DisplayName, 11)
 			}
 
-			postMessage := es.GetMessageForNotification(notification.post, notification.teamName, siteURL, translateFunc)
 			postsData = append(postsData, &postData{
 				SenderPhoto:              senderPhoto,
 				SenderName:               truncateUserNames(sender.GetDisplayName(displayNameFormat), 22),
 				Time:                     t,
 				ChannelName:              channelDisplayName,
-				Message:                  template.HTML(postMessage),
+				Message:                  template.HTML(notification.message),
 				MessageURL:               MessageURL,
 				ShowChannelIcon:          showChannelIcon,
 				OtherChannelMembersCount: otherChannelMembersCount,
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
 )
 
 func TestHandleNewNotifications(t *testing.T) {
@@ -288,3 +289,65 @@ func TestCheckPendingNotificationsCantParseInterval(t *testing.T) {
 
 	require.Nil(t, job.pendingNotifications[th.BasicUser.Id], "should have sent queued post")
 }
+
+func TestFilterBatchedNotifications(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newNotification := func(message string) *batchedNotification {
+		return &batchedNotification{
+			userID: th.BasicUser.Id,
+			post: &model.Post{
+				Id:        model.NewId(),
+				UserId:    th.BasicUser2.Id,
+				ChannelId: th.BasicChannel.Id,
+				Message:   message,
+			},
+			teamName: th.BasicTeam.Name,
+		}
+	}
+	translateFunc := i18n.GetUserTranslations("en")
+
+	t.Run("should render the messages without a hook", func(t *testing.T) {
+		notifications := th.service.filterBatchedNotifications(th.BasicUser, []*batchedNotification{newNotification("allowed")}, "http://localhost", translateFunc)
+		require.Len(t, notifications, 1)
+		assert.Contains(t, notifications[0].message, "allowed")
+	})
+
+	t.Run("should drop the rejected posts and keep the replaced messages", func(t *testing.T) {
+		var hookNotifications []*model.EmailNotification
+		th.service.emailNotificationWillBeSent = func(emailNotification *model.EmailNotification, recipient *model.User, post *model.Post) (*model.EmailNotification, string) {
+			hookNotifications = append(hookNotifications, emailNotification)
+			switch post.Message {
+			case "rejected":
+				return nil, "rejected by test"
+			case "replaced":
+				replacement := emailNotification.DeepCopy()
+				replacement.Body = "replacement"
+				return replacement, ""
+			}
+			return emailNotification, ""
+		}
+		defer func() { th.service.emailNotificationWillBeSent = nil }()
+
+		notifications := th.service.filterBatchedNotifications(th.BasicUser, []*batchedNotification{
+			newNotification("allowed"),
+			newNotification("rejected"),
+			newNotification("replaced"),
+		}, "http://localhost", translateFunc)
+
+		require.Len(t, notifications, 2)
+		assert.Equal(t, "allowed", notifications[0].post.Message)
+		assert.Contains(t, notifications[0].message, "allowed")
+		assert.Equal(t, "replacement", notifications[1].message)
+
+		require.Len(t, hookNotifications, 3)
+		assert.True(t, hookNotifications[0].Batched)
+		assert.Equal(t, th.BasicUser.Email, hookNotifications[0].To)
+		assert.Equal(t, th.BasicChannel.DisplayName, hookNotifications[0].ChannelName)
+		assert.Equal(t, th.BasicTeam.Name, hookNotifications[0].TeamName)
+		assert.Equal(t, th.BasicUser2.Id, hookNotifications[0].SenderId)
+		assert.NotEmpty(t, hookNotifications[0].SenderName)
+	})
+}
@@ -52,6 +52,8 @@ type Service struct {
 	perHourEmailRateLimiter *throttled.GCRARateLimiter
 	perDayEmailRateLimiter  *throttled.GCRARateLimiter
 	EmailBatching           *EmailBatchingJob
+
+	emailNotificationWillBeSent func(*model.EmailNotification, *model.User, *model.Post) (*model.EmailNotification, string)
 }
 
 type ServiceConfig struct {
@@ -61,6 +63,11 @@ type ServiceConfig struct {
 	TemplatesContainer *templates.Container
 	UserService        *users.UserService
 	Store              store.Store
+
+	// EmailNotificationWillBeSentFn, if set, is given each post of a batched
+	// email notification, returning the notification to send or a non empty
+	// rejection reason.
+	EmailNotificationWillBeSentFn func(*model.EmailNotification, *model.User, *model.Post) (*model.EmailNotification, string)
 }
 
 func NewService(config ServiceConfig) (*Service, error) {
@@ -73,6 +80,8 @@ func NewService(config ServiceConfig) (*Service, error) {
 		license:            config.LicenseFn,
 		store:              config.Store,
 		userService:        config.UserService,
+
+		emailNotificationWillBeSent: config.EmailNotificationWillBeSentFn,
 	}
 	if err := service.setUpRateLimiters(); err != nil {
 		return nil, err
@@ -17,6 +17,7 @@ import (
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -1485,8 +1486,10 @@ func (a *App) SearchFilesInTeamForUser(c request.CTX, terms string, userId strin
 }
 
 func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileInfo) error {
-	// We don't process images.
-	if fileInfo.IsImage() {
+	pluginExtractors := a.pluginDocumentExtractorsFor(rctx, fileInfo)
+
+	// We don't process images, unless a plugin does.
+	if fileInfo.IsImage() && !slices.ContainsFunc(pluginExtractors, func(e docextractor.Extractor) bool { return e.Match(fileInfo.Name) }) {
 		return nil
 	}
 
@@ -1495,9 +1498,9 @@ func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileI
 		return errors.Wrap(aerr, "failed to open file for extract file content")
 	}
 	defer file.Close()
-	text, err := docextractor.Extract(rctx.Logger(), fileInfo.Name, file, docextractor.ExtractSettings{
+	text, err := docextractor.ExtractWithExtraExtractors(rctx.Logger(), fileInfo.Name, file, docextractor.ExtractSettings{
 		ArchiveRecursion: *a.Config().FileSettings.ArchiveRecursion,
-	})
+	}, pluginExtractors)
 	if err != nil {
 		return errors.Wrap(err, "failed to extract file content")
 	}
@@ -881,6 +881,18 @@ func NewTestId() string {
 	return string(newId)
 }
 
+// approveAllPluginPermissions approves every permission for a test plugin, whose manifest
+// usually declares none, so that it can call the whole API.
+func approveAllPluginPermissions(app *App, pluginID string) {
+	app.UpdateConfig(func(cfg *model.Config) {
+		state, ok := cfg.PluginSettings.PluginStates[pluginID]
+		cfg.PluginSettings.PluginStates[pluginID] = &model.PluginState{
+			Enable:              ok && state.Enable,
+			ApprovedPermissions: model.AllPluginPermissions,
+		}
+	})
+}
+
 func (th *TestHelper) NewPluginAPI(manifest *model.Manifest) plugin.API {
 	return th.App.NewPluginAPI(th.Context, manifest)
 }
@@ -24,11 +24,14 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"net/url"
 	"path"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gorilla/mux"
 
@@ -476,6 +479,20 @@ func (a *App) OpenInteractiveDialog(c request.CTX, request model.OpenDialogReque
 
 	request.TriggerId = clientTriggerId
 
+	// The URL and the pages of the dialog are kept in its encrypted session, and only the
+	// current page is shown to the user. Older clients submit the dialog to its URL instead,
+	// which is only kept for single-page dialogs.
+	session := model.NewDialogSession(userID, request.URL, request.Dialog)
+	encodedSession, err := model.EncryptDialogSession(session, a.PostActionCookieSecret())
+	if err != nil {
+		return model.NewAppError("OpenInteractiveDialog", "app.open_interactive_dialog.session.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	request.Session = encodedSession
+	if !session.IsLastPage() {
+		request.URL = ""
+	}
+	request.Dialog = session.PageDialog()
+
 	jsonRequest, err := json.Marshal(request)
 	if err != nil {
 		a.ch.srv.Log().Warn("Error encoding request", mlog.Err(err))
@@ -489,6 +506,47 @@ func (a *App) OpenInteractiveDialog(c request.CTX, request model.OpenDialogReque
 }
 
 func (a *App) SubmitInteractiveDialog(c request.CTX, request model.SubmitDialogRequest) (*model.SubmitDialogResponse, *model.AppError) {
+	// Older clients submit single-page dialogs to their URL without the session, which are
+	// forwarded as submitted, without validation.
+	if request.Session == "" {
+		request.Validated = false
+	} else {
+		session, appErr := a.getDialogSession(request.Session, request.UserId)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		request.URL = session.URL
+		request.CallbackId = session.Dialog.CallbackId
+		request.State = session.Dialog.State
+		request.Session = ""
+
+		if !request.Cancelled {
+			submission, fieldErrors := a.validateDialogSubmission(c, request.UserId, session.PageElements(), request.Submission)
+			if len(fieldErrors) != 0 {
+				return &model.SubmitDialogResponse{Errors: fieldErrors}, nil
+			}
+
+			if session.Submission == nil {
+				session.Submission = map[string]any{}
+			}
+			maps.Copy(session.Submission, submission)
+
+			if !session.IsLastPage() {
+				session.Page++
+				encoded, err := model.EncryptDialogSession(session, a.PostActionCookieSecret())
+				if err != nil {
+					return nil, model.NewAppError("SubmitInteractiveDialog", "app.open_interactive_dialog.session.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+				}
+				dialog := session.PageDialog()
+				return &model.SubmitDialogResponse{Dialog: &dialog, Session: encoded}, nil
+			}
+
+			request.Submission = session.Submission
+			request.Validated = true
+		}
+	}
+
 	url := request.URL
 	request.URL = ""
 	request.Type = "dialog_submission"
@@ -532,3 +590,127 @@ func (a *App) SubmitInteractiveDialog(c request.CTX, request model.SubmitDialogR
 
 	return &response, nil
 }
+
+func (a *App) getDialogSession(encoded, userID string) (*model.DialogSession, *model.AppError) {
+	session, err := model.DecryptDialogSession(encoded, a.PostActionCookieSecret())
+	if err != nil {
+		return nil, model.NewAppError("SubmitInteractiveDialog", "app.submit_interactive_dialog.session.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if session.UserId != userID || session.IsExpired() {
+		return nil, model.NewAppError("SubmitInteractiveDialog", "app.submit_interactive_dialog.session.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return session, nil
+}
+
+// validateDialogSubmission checks the submitted values against the elements of a dialog page. It
+// returns the validated values, leaving out the values of unknown elements, and the errors of the
+// invalid ones by element name.
+func (a *App) validateDialogSubmission(c request.CTX, userID string, elements []model.DialogElement, submission map[string]any) (map[string]any, map[string]string) {
+	validated := make(map[string]any, len(elements))
+	fieldErrors := map[string]string{}
+
+	for _, element := range elements {
+		value := submission[element.Name]
+		if value == nil || value == "" {
+			if !element.Optional {
+				fieldErrors[element.Name] = c.T("app.submit_interactive_dialog.field_required")
+			}
+			continue
+		}
+
+		if errMessage := a.validateDialogElementValue(c, userID, element, value); errMessage != "" {
+			fieldErrors[element.Name] = errMessage
+			continue
+		}
+
+		validated[element.Name] = value
+	}
+
+	return validated, fieldErrors
+}
+
+func (a *App) validateDialogElementValue(c request.CTX, userID string, element model.DialogElement, value any) string {
+	switch element.Type {
+	case "text", "textarea":
+		var text string
+		switch v := value.(type) {
+		case string:
+			text = v
+		case float64:
+			// Numbers may be submitted as such
+			if element.SubType != "number" {
+				return c.T("app.submit_interactive_dialog.invalid_value")
+			}
+			return ""
+		default:
+			return c.T("app.submit_interactive_dialog.invalid_value")
+		}
+
+		length := utf8.RuneCountInString(text)
+		if element.MinLength > 0 && length < element.MinLength {
+			return c.T("app.submit_interactive_dialog.too_short", map[string]any{"MinLength": element.MinLength})
+		}
+		if element.MaxLength > 0 && length > element.MaxLength {
+			return c.T("app.submit_interactive_dialog.too_long", map[string]any{"MaxLength": element.MaxLength})
+		}
+
+		switch element.SubType {
+		case "number":
+			if _, err := strconv.ParseFloat(text, 64); err != nil {
+				return c.T("app.submit_interactive_dialog.invalid_number")
+			}
+		case "email":
+			if !model.IsValidEmail(text) {
+				return c.T("app.submit_interactive_dialog.invalid_email")
+			}
+		case "url":
+			if !model.IsValidHTTPURL(text) {
+				return c.T("app.submit_interactive_dialog.invalid_url")
+			}
+		}
+
+	case "select", "radio":
+		option, ok := value.(string)
+		if !ok {
+			return c.T("app.submit_interactive_dialog.invalid_option")
+		}
+
+		switch {
+		case element.Type == "select" && element.DataSource == "users":
+			// Only users that could be found through the autocomplete of the element are accepted
+			if !model.IsValidId(option) {
+				return c.T("app.submit_interactive_dialog.invalid_user")
+			}
+			if canSee, appErr := a.UserCanSeeOtherUser(c, userID, option); appErr != nil || !canSee {
+				return c.T("app.submit_interactive_dialog.invalid_user")
+			}
+			user, appErr := a.GetUser(option)
+			if appErr != nil || user.DeleteAt != 0 {
+				return c.T("app.submit_interactive_dialog.invalid_user")
+			}
+		case element.Type == "select" && element.DataSource == "channels":
+			if !model.IsValidId(option) || !a.HasPermissionToChannel(c, userID, option, model.PermissionReadChannelContent) {
+				return c.T("app.submit_interactive_dialog.invalid_channel")
+			}
+		default:
+			if !element.HasOption(option) {
+				return c.T("app.submit_interactive_dialog.invalid_option")
+			}
+		}
+
+	case "bool":
+		switch v := value.(type) {
+		case bool:
+		case string:
+			if v != "true" && v != "false" {
+				return c.T("app.submit_interactive_dialog.invalid_value")
+			}
+		default:
+			return c.T("app.submit_interactive_dialog.invalid_value")
+		}
+	}
+
+	return ""
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -559,17 +560,31 @@ func TestSubmitInteractiveDialog(t *testing.T) {
 		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
 	})
 
-	submit := model.SubmitDialogRequest{
-		UserId:     th.BasicUser.Id,
-		ChannelId:  th.BasicChannel.Id,
-		TeamId:     th.BasicTeam.Id,
+	dialog := model.Dialog{
 		CallbackId: "someid",
+		Title:      "Some Title",
 		State:      "somestate",
+		Elements: []model.DialogElement{
+			{DisplayName: "Name", Name: "name1", Type: "text"},
+		},
+	}
+	sessionForURL := func(url string) string {
+		encodedSession, err := model.EncryptDialogSession(model.NewDialogSession(th.BasicUser.Id, url, dialog), th.App.PostActionCookieSecret())
+		require.NoError(t, err)
+		return encodedSession
+	}
+
+	submit := model.SubmitDialogRequest{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		TeamId:    th.BasicTeam.Id,
 		Submission: map[string]any{
 			"name1": "value1",
 		},
 	}
 
+	var expectValidated atomic.Bool
+	expectValidated.Store(true)
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var request model.SubmitDialogRequest
 		err := json.NewDecoder(r.Body).Decode(&request)
@@ -580,8 +595,9 @@ func TestSubmitInteractiveDialog(t *testing.T) {
 		assert.Equal(t, request.UserId, submit.UserId)
 		assert.Equal(t, request.ChannelId, submit.ChannelId)
 		assert.Equal(t, request.TeamId, submit.TeamId)
-		assert.Equal(t, request.CallbackId, submit.CallbackId)
-		assert.Equal(t, request.State, submit.State)
+		assert.Equal(t, request.CallbackId, dialog.CallbackId)
+		assert.Equal(t, request.State, dialog.State)
+		assert.Equal(t, expectValidated.Load(), request.Validated)
 		val, ok := request.Submission["name1"].(string)
 		require.True(t, ok)
 		assert.Equal(t, "value1", val)
@@ -637,15 +653,31 @@ func TestSubmitInteractiveDialog(t *testing.T) {
 	require.NoError(t, err2)
 	require.NotNil(t, hooks)
 
-	submit.URL = ts.URL
-
 	resp, err := th.App.SubmitInteractiveDialog(th.Context, submit)
+	require.NotNil(t, err, "submissions need either a session or a URL")
+	assert.Nil(t, resp)
+
+	// Older clients submit single-page dialogs to their URL, which are forwarded unvalidated
+	legacySubmit := submit
+	legacySubmit.URL = ts.URL
+	legacySubmit.CallbackId = dialog.CallbackId
+	legacySubmit.State = dialog.State
+	legacySubmit.Validated = true
+	expectValidated.Store(false)
+	resp, err = th.App.SubmitInteractiveDialog(th.Context, legacySubmit)
+	require.Nil(t, err)
+	require.NotNil(t, resp)
+	assert.Equal(t, "some generic error", resp.Error)
+	expectValidated.Store(true)
+
+	submit.Session = sessionForURL(ts.URL)
+	resp, err = th.App.SubmitInteractiveDialog(th.Context, submit)
 	assert.Nil(t, err)
 	require.NotNil(t, resp)
 	assert.Equal(t, "some generic error", resp.Error)
 	assert.Equal(t, "some error", resp.Errors["name1"])
 
-	submit.URL = ""
+	submit.Session = sessionForURL("")
 	resp, err = th.App.SubmitInteractiveDialog(th.Context, submit)
 	assert.NotNil(t, err)
 	assert.Nil(t, resp)
@@ -655,24 +687,165 @@ func TestSubmitInteractiveDialog(t *testing.T) {
 		*cfg.ServiceSettings.SiteURL = ts.URL
 	})
 
-	submit.URL = "/notvalid/myplugin/myaction"
+	submit.Session = sessionForURL("/notvalid/myplugin/myaction")
 	resp, err = th.App.SubmitInteractiveDialog(th.Context, submit)
 	assert.NotNil(t, err)
 	require.Nil(t, resp)
 
-	submit.URL = "/plugins/myplugin/myaction"
+	submit.Session = sessionForURL("/plugins/myplugin/myaction")
 	resp, err = th.App.SubmitInteractiveDialog(th.Context, submit)
 	assert.Nil(t, err)
 	require.NotNil(t, resp)
 	assert.Equal(t, "some error", resp.Errors["name1"])
 
-	submit.URL = "/plugins/myplugin/myaction?abc=xyz"
+	submit.Session = sessionForURL("/plugins/myplugin/myaction?abc=xyz")
 	resp, err = th.App.SubmitInteractiveDialog(th.Context, submit)
 	assert.Nil(t, err)
 	require.NotNil(t, resp)
 	assert.Equal(t, "some other error", resp.Errors["name1"])
 }
 
+func TestSubmitInteractiveDialogWithSession(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	requests := make(chan model.SubmitDialogRequest, 1)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request model.SubmitDialogRequest
+		err := json.NewDecoder(r.Body).Decode(&request)
+		require.NoError(t, err)
+		requests <- request
+	}))
+	defer ts.Close()
+
+	dialog := model.Dialog{
+		CallbackId: "someid",
+		Title:      "Some Title",
+		State:      "somestate",
+		Pages: []model.DialogPage{
+			{
+				Elements: []model.DialogElement{
+					{DisplayName: "Name", Name: "name", Type: "text", MinLength: 2, MaxLength: 10},
+					{DisplayName: "Color", Name: "color", Type: "select", Options: []*model.PostActionOptions{{Text: "Red", Value: "red"}, {Text: "Blue", Value: "blue"}}},
+				},
+			},
+			{
+				Title: "Second Page",
+				Elements: []model.DialogElement{
+					{DisplayName: "Assignee", Name: "assignee", Type: "select", DataSource: "users"},
+					{DisplayName: "Agree", Name: "agree", Type: "bool", Optional: true},
+				},
+			},
+		},
+	}
+
+	encodedSession, err := model.EncryptDialogSession(model.NewDialogSession(th.BasicUser.Id, ts.URL, dialog), th.App.PostActionCookieSecret())
+	require.NoError(t, err)
+
+	submit := model.SubmitDialogRequest{
+		URL:       "http://elsewhere.example.com",
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		TeamId:    th.BasicTeam.Id,
+		Session:   encodedSession,
+	}
+
+	t.Run("invalid fields", func(t *testing.T) {
+		submit.Submission = map[string]any{"name": "x", "color": "green"}
+		resp, appErr := th.App.SubmitInteractiveDialog(th.Context, submit)
+		require.Nil(t, appErr)
+		require.NotNil(t, resp)
+		assert.Contains(t, resp.Errors, "name")
+		assert.Contains(t, resp.Errors, "color")
+		assert.Nil(t, resp.Dialog)
+		assert.Empty(t, requests)
+	})
+
+	t.Run("missing required field", func(t *testing.T) {
+		submit.Submission = map[string]any{"name": "some name"}
+		resp, appErr := th.App.SubmitInteractiveDialog(th.Context, submit)
+		require.Nil(t, appErr)
+		require.NotNil(t, resp)
+		assert.Contains(t, resp.Errors, "color")
+		assert.NotContains(t, resp.Errors, "name")
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		otherSubmit := submit
+		otherSubmit.UserId = th.BasicUser2.Id
+		_, appErr := th.App.SubmitInteractiveDialog(th.Context, otherSubmit)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.submit_interactive_dialog.session.app_error", appErr.Id)
+	})
+
+	t.Run("invalid session", func(t *testing.T) {
+		otherSubmit := submit
+		otherSubmit.Session = "invalid"
+		_, appErr := th.App.SubmitInteractiveDialog(th.Context, otherSubmit)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.submit_interactive_dialog.session.app_error", appErr.Id)
+	})
+
+	t.Run("user not visible", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PermissionViewMembers.Id, model.SystemUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionViewMembers.Id, model.SystemUserRoleId)
+
+		usersDialog := model.Dialog{
+			CallbackId: "someid",
+			Elements: []model.DialogElement{
+				{DisplayName: "Assignee", Name: "assignee", Type: "select", DataSource: "users"},
+			},
+		}
+		usersSession, err := model.EncryptDialogSession(model.NewDialogSession(th.BasicUser.Id, ts.URL, usersDialog), th.App.PostActionCookieSecret())
+		require.NoError(t, err)
+
+		otherSubmit := submit
+		otherSubmit.Session = usersSession
+		otherSubmit.Submission = map[string]any{"assignee": th.CreateUser().Id}
+		resp, appErr := th.App.SubmitInteractiveDialog(th.Context, otherSubmit)
+		require.Nil(t, appErr)
+		require.NotNil(t, resp)
+		assert.Contains(t, resp.Errors, "assignee")
+		assert.Empty(t, requests)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		submit.Submission = map[string]any{"name": "some name", "color": "red", "unknown": "value"}
+		resp, appErr := th.App.SubmitInteractiveDialog(th.Context, submit)
+		require.Nil(t, appErr)
+		require.NotNil(t, resp)
+		assert.Empty(t, resp.Errors)
+		require.NotNil(t, resp.Dialog)
+		assert.Equal(t, "Second Page", resp.Dialog.Title)
+		assert.Equal(t, "assignee", resp.Dialog.Elements[0].Name)
+		assert.NotEmpty(t, resp.Session)
+		assert.Empty(t, requests)
+
+		submit.Session = resp.Session
+		submit.Submission = map[string]any{"assignee": th.BasicUser2.Id}
+		resp, appErr = th.App.SubmitInteractiveDialog(th.Context, submit)
+		require.Nil(t, appErr)
+		require.NotNil(t, resp)
+		assert.Nil(t, resp.Dialog)
+
+		select {
+		case request := <-requests:
+			assert.Equal(t, "someid", request.CallbackId)
+			assert.Equal(t, "somestate", request.State)
+			assert.Empty(t, request.Session)
+			assert.True(t, request.Validated)
+			assert.Equal(t, map[string]any{"name": "some name", "color": "red", "assignee": th.BasicUser2.Id}, request.Submission)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Timeout, dialog submission not received")
+		}
+	})
+}
+
 func TestPostActionRelativeURL(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic()
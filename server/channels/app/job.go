@@ -103,6 +103,10 @@ func (a *App) SessionHasPermissionToCreateJob(session model.Session, job *model.
 		return a.SessionHasPermissionTo(session, model.PermissionManageSystem), model.PermissionManageSystem
 	}
 
+	if model.IsValidPluginJobType(job.Type) {
+		return a.SessionHasPermissionTo(session, model.PermissionManageJobs), model.PermissionManageJobs
+	}
+
 	return false, nil
 }
 
@@ -137,6 +141,10 @@ func (a *App) SessionHasPermissionToManageJob(session model.Session, job *model.
 		permission = model.PermissionManageJobs
 	case model.JobTypeAccessControlSync:
 		permission = model.PermissionManageSystem
+	default:
+		if model.IsValidPluginJobType(job.Type) {
+			permission = model.PermissionManageJobs
+		}
 	}
 
 	if permission == nil {
@@ -177,5 +185,9 @@ func (a *App) SessionHasPermissionToReadJob(session model.Session, jobType strin
 		return a.SessionHasPermissionTo(session, model.PermissionManageSystem), model.PermissionManageSystem
 	}
 
+	if model.IsValidPluginJobType(jobType) {
+		return a.SessionHasPermissionTo(session, model.PermissionReadJobs), model.PermissionReadJobs
+	}
+
 	return false, nil
 }
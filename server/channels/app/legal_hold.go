@@ -0,0 +1,178 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func (a *App) checkLegalHoldLicense(where string) *model.AppError {
+	if license := a.Srv().License(); license == nil || !*license.Features.Compliance {
+		return model.NewAppError(where, "app.legal_hold.license.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+func (a *App) GetLegalHolds(page, perPage int) ([]*model.LegalHold, *model.AppError) {
+	if appErr := a.checkLegalHoldLicense("GetLegalHolds"); appErr != nil {
+		return nil, appErr
+	}
+
+	holds, err := a.Srv().Store().LegalHold().GetAll(page*perPage, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetLegalHolds", "app.legal_hold.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return holds, nil
+}
+
+func (a *App) GetLegalHold(id string) (*model.LegalHold, *model.AppError) {
+	if appErr := a.checkLegalHoldLicense("GetLegalHold"); appErr != nil {
+		return nil, appErr
+	}
+
+	hold, err := a.Srv().Store().LegalHold().Get(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetLegalHold", "app.legal_hold.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetLegalHold", "app.legal_hold.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	return hold, nil
+}
+
+func (a *App) CreateLegalHold(hold *model.LegalHold) (*model.LegalHold, *model.AppError) {
+	if appErr := a.checkLegalHoldLicense("CreateLegalHold"); appErr != nil {
+		return nil, appErr
+	}
+
+	saved, err := a.Srv().Store().LegalHold().Save(hold)
+	if err != nil {
+		return nil, legalHoldStoreError("CreateLegalHold", "app.legal_hold.save.app_error", err)
+	}
+	return saved, nil
+}
+
+func (a *App) PatchLegalHold(id string, patch *model.LegalHoldPatch) (*model.LegalHold, *model.AppError) {
+	hold, appErr := a.GetLegalHold(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if hold.DeleteAt != 0 {
+		return nil, model.NewAppError("PatchLegalHold", "app.legal_hold.released.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	hold.Patch(patch)
+
+	updated, err := a.Srv().Store().LegalHold().Update(hold)
+	if err != nil {
+		return nil, legalHoldStoreError("PatchLegalHold", "app.legal_hold.update.app_error", err)
+	}
+	return updated, nil
+}
+
+// ReleaseLegalHold ends a legal hold. The hold is kept for auditing purposes, but the data it covered
+// becomes subject to data retention and permanent deletion again.
+func (a *App) ReleaseLegalHold(id string) *model.AppError {
+	if appErr := a.checkLegalHoldLicense("ReleaseLegalHold"); appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store().LegalHold().Delete(id, model.GetMillis()); err != nil {
+		return legalHoldStoreError("ReleaseLegalHold", "app.legal_hold.release.app_error", err)
+	}
+	return nil
+}
+
+// ExportLegalHold creates a message export job restricted to the posts covered by the legal hold.
+// An empty exportType uses the configured export format.
+func (a *App) ExportLegalHold(rctx request.CTX, id, exportType string) (*model.Job, *model.AppError) {
+	hold, appErr := a.GetLegalHold(id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if license := a.Srv().License(); !*a.Config().MessageExportSettings.EnableExport || license == nil || !*license.Features.MessageExport {
+		return nil, model.NewAppError("ExportLegalHold", "app.legal_hold.export.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	switch exportType {
+	case "",
+		model.ComplianceExportTypeActiance,
+		model.ComplianceExportTypeCsv,
+		model.ComplianceExportTypeGlobalrelayZip,
+		model.ComplianceExportTypeEml,
+		model.ComplianceExportTypeMbox,
+		model.ComplianceExportTypeJsonl:
+	default:
+		// GlobalRelay exports are emailed to the archive rather than stored, so they can't be produced on demand.
+		return nil, model.NewAppError("ExportLegalHold", "app.legal_hold.export.invalid_export_type.app_error", map[string]any{"ExportType": exportType}, "", http.StatusBadRequest)
+	}
+
+	// The export cursor walks posts by UpdateAt, which is never earlier than the CreateAt the hold applies to.
+	startTime := strconv.FormatInt(hold.StartsAt, 10)
+	data := map[string]string{
+		model.LegalHoldJobDataKey: hold.Id,
+		"batch_start_time":        startTime,
+		"job_start_time":          startTime,
+		"batch_start_id":          "",
+		"job_start_id":            "",
+	}
+	if exportType != "" {
+		data["export_type"] = exportType
+	}
+
+	return a.Srv().Jobs.CreateJob(rctx, model.JobTypeMessageExport, data)
+}
+
+// checkUserNotUnderLegalHold refuses operations that would destroy data covered by an active legal hold.
+func (a *App) checkUserNotUnderLegalHold(where, userID string) *model.AppError {
+	holds, err := a.Srv().Store().LegalHold().GetForUser(userID)
+	if err != nil {
+		return model.NewAppError(where, "app.legal_hold.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if len(holds) > 0 {
+		return model.NewAppError(where, "app.legal_hold.user_held.app_error", map[string]any{"Name": holds[0].Name}, "user_id="+userID, http.StatusConflict)
+	}
+	return nil
+}
+
+func (a *App) checkChannelNotUnderLegalHold(where, channelID string) *model.AppError {
+	holds, err := a.Srv().Store().LegalHold().GetForChannel(channelID)
+	if err != nil {
+		return model.NewAppError(where, "app.legal_hold.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if len(holds) > 0 {
+		return model.NewAppError(where, "app.legal_hold.channel_held.app_error", map[string]any{"Name": holds[0].Name}, "channel_id="+channelID, http.StatusConflict)
+	}
+	return nil
+}
+
+func legalHoldStoreError(where, id string, err error) *model.AppError {
+	var appErr *model.AppError
+	var nfErr *store.ErrNotFound
+	var uniqueErr *store.ErrUniqueConstraint
+	var invErr *store.ErrInvalidInput
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.As(err, &nfErr):
+		return model.NewAppError(where, "app.legal_hold.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	case errors.As(err, &uniqueErr):
+		return model.NewAppError(where, "app.legal_hold.save.name_exists.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	case errors.As(err, &invErr):
+		return model.NewAppError(where, id, nil, "", http.StatusBadRequest).Wrap(err)
+	default:
+		return model.NewAppError(where, id, nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestLegalHolds(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newHold := func() *model.LegalHold {
+		return &model.LegalHold{
+			Name:        "hold-" + model.NewId(),
+			DisplayName: "Hold",
+			UserIds:     []string{th.BasicUser.Id},
+			CreatorId:   th.SystemAdminUser.Id,
+		}
+	}
+
+	t.Run("requires a compliance license", func(t *testing.T) {
+		th.App.Srv().SetLicense(nil)
+
+		_, appErr := th.App.CreateLegalHold(newHold())
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	th.App.Srv().SetLicense(model.NewTestLicense("compliance"))
+
+	t.Run("create, patch and release", func(t *testing.T) {
+		hold, appErr := th.App.CreateLegalHold(newHold())
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.CreateLegalHold(&model.LegalHold{
+			Name:        hold.Name,
+			DisplayName: "Duplicate",
+			UserIds:     []string{th.BasicUser2.Id},
+			CreatorId:   th.SystemAdminUser.Id,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.legal_hold.save.name_exists.app_error", appErr.Id)
+
+		channelIDs := model.StringArray{th.BasicChannel.Id}
+		patched, appErr := th.App.PatchLegalHold(hold.Id, &model.LegalHoldPatch{ChannelIds: &channelIDs})
+		require.Nil(t, appErr)
+		assert.Equal(t, channelIDs, patched.ChannelIds)
+
+		appErr = th.App.ReleaseLegalHold(hold.Id)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.PatchLegalHold(hold.Id, &model.LegalHoldPatch{DisplayName: model.NewPointer("Released")})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		appErr = th.App.ReleaseLegalHold(hold.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("held users and channels cannot be permanently deleted", func(t *testing.T) {
+		user := th.CreateUser()
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+
+		hold := newHold()
+		hold.UserIds = []string{user.Id}
+		hold.ChannelIds = []string{channel.Id}
+		hold, appErr := th.App.CreateLegalHold(hold)
+		require.Nil(t, appErr)
+
+		appErr = th.App.PermanentDeleteUser(th.Context, user)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+
+		appErr = th.App.PermanentDeleteChannel(th.Context, channel)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+
+		require.Nil(t, th.App.ReleaseLegalHold(hold.Id))
+		require.Nil(t, th.App.PermanentDeleteUser(th.Context, user))
+		require.Nil(t, th.App.PermanentDeleteChannel(th.Context, channel))
+	})
+
+	t.Run("teams with a held channel cannot be permanently deleted", func(t *testing.T) {
+		team := th.CreateTeam()
+		channel := th.CreateChannel(th.Context, team)
+
+		hold := newHold()
+		hold.UserIds = nil
+		hold.ChannelIds = []string{channel.Id}
+		hold, appErr := th.App.CreateLegalHold(hold)
+		require.Nil(t, appErr)
+
+		appErr = th.App.PermanentDeleteTeam(th.Context, team)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.legal_hold.channel_held.app_error", appErr.Id)
+
+		fetched, appErr := th.App.GetTeam(team.Id)
+		require.Nil(t, appErr, "the team is left untouched")
+		assert.Zero(t, fetched.DeleteAt)
+		_, appErr = th.App.GetChannel(th.Context, channel.Id)
+		require.Nil(t, appErr)
+
+		require.Nil(t, th.App.ReleaseLegalHold(hold.Id))
+		require.Nil(t, th.App.PermanentDeleteTeam(th.Context, team))
+	})
+
+	t.Run("permanently deleting a user keeps their reactions in held channels", func(t *testing.T) {
+		user := th.CreateUser()
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		post := th.CreatePost(channel)
+
+		_, err := th.App.Srv().Store().Reaction().Save(&model.Reaction{UserId: user.Id, PostId: post.Id, EmojiName: "smile"})
+		require.NoError(t, err)
+
+		hold := newHold()
+		hold.UserIds = nil
+		hold.ChannelIds = []string{channel.Id}
+		hold, appErr := th.App.CreateLegalHold(hold)
+		require.Nil(t, appErr)
+		defer th.App.ReleaseLegalHold(hold.Id)
+
+		require.Nil(t, th.App.PermanentDeleteUser(th.Context, user))
+
+		reactions, err := th.App.Srv().Store().Reaction().GetForPost(post.Id, false)
+		require.NoError(t, err)
+		require.Len(t, reactions, 1)
+		assert.Equal(t, user.Id, reactions[0].UserId)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		hold, appErr := th.App.CreateLegalHold(newHold())
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.ExportLegalHold(th.Context, hold.Id, "")
+		require.NotNil(t, appErr, "export requires message export to be enabled")
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+
+		license := model.NewTestLicense("compliance", "message_export")
+		th.App.Srv().SetLicense(license)
+		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.MessageExportSettings.EnableExport = true })
+
+		_, appErr = th.App.ExportLegalHold(th.Context, hold.Id, model.ComplianceExportTypeGlobalrelay)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+		job, appErr := th.App.ExportLegalHold(th.Context, hold.Id, model.ComplianceExportTypeCsv)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.JobTypeMessageExport, job.Type)
+		assert.Equal(t, hold.Id, job.Data[model.LegalHoldJobDataKey])
+		assert.Equal(t, model.ComplianceExportTypeCsv, job.Data["export_type"])
+	})
+}
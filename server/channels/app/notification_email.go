@@ -13,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -128,9 +129,30 @@ func (a *App) sendNotificationEmail(c request.CTX, notification *PostNotificatio
 		references = referencesVal
 	}
 
+	emailNotification := &model.EmailNotification{
+		To:          user.Email,
+		Subject:     html.UnescapeString(subjectText),
+		Body:        bodyText,
+		PostId:      post.Id,
+		ChannelId:   channel.Id,
+		ChannelName: channelName,
+		ChannelType: channel.Type,
+		TeamName:    team.Name,
+		SenderId:    post.UserId,
+		SenderName:  senderName,
+	}
+
+	// Plugins are given the notification from the goroutine sending it, so
+	// that a slow plugin doesn't hold up the notifications of the post.
 	a.Srv().Go(func() {
-		if nErr := a.Srv().EmailService.SendMailWithEmbeddedFiles(user.Email, html.UnescapeString(subjectText), bodyText, embeddedFiles, messageID, inReplyTo, references, "Notification"); nErr != nil {
-			c.Logger().Error("Error while sending the email", mlog.String("user_email", user.Email), mlog.Err(nErr))
+		var rejectionReason string
+		emailNotification, rejectionReason = a.runEmailNotificationWillBeSentHook(emailNotification, user, post)
+		if rejectionReason != "" {
+			return
+		}
+
+		if nErr := a.Srv().EmailService.SendMailWithEmbeddedFiles(emailNotification.To, emailNotification.Subject, emailNotification.Body, embeddedFiles, messageID, inReplyTo, references, "Notification"); nErr != nil {
+			c.Logger().Error("Error while sending the email", mlog.String("user_email", emailNotification.To), mlog.Err(nErr))
 		}
 	})
 
@@ -141,6 +163,45 @@ func (a *App) sendNotificationEmail(c request.CTX, notification *PostNotificatio
 	return nil
 }
 
+// runEmailNotificationWillBeSentHook lets plugins modify or reject an email
+// notification. It returns the notification to send, or a non empty rejection
+// reason which is logged to the notifications log.
+func (a *App) runEmailNotificationWillBeSentHook(emailNotification *model.EmailNotification, recipient *model.User, post *model.Post) (*model.EmailNotification, string) {
+	pluginRecipient := recipient.DeepCopy()
+	pluginRecipient.Sanitize(map[string]bool{})
+	pluginPost := post.ForPlugin()
+
+	rejectionReason := ""
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		var replacement *model.EmailNotification
+		replacement, rejectionReason = hooks.EmailNotificationWillBeSent(emailNotification.DeepCopy(), pluginRecipient, pluginPost)
+		if rejectionReason != "" {
+			return false
+		}
+		if replacement != nil {
+			if replacement.To == "" {
+				rejectionReason = "plugin " + manifest.Id + " removed the recipient address"
+				return false
+			}
+			emailNotification = replacement
+		}
+		return true
+	}, plugin.EmailNotificationWillBeSentID)
+
+	if rejectionReason != "" {
+		a.NotificationsLog().Debug("Notification rejected by plugin",
+			mlog.String("type", model.NotificationTypeEmail),
+			mlog.String("status", model.NotificationStatusNotSent),
+			mlog.String("reason", model.NotificationReasonRejectedByPlugin),
+			mlog.String("rejection_reason", rejectionReason),
+			mlog.String("post_id", post.Id),
+			mlog.String("user_id", recipient.Id),
+		)
+	}
+
+	return emailNotification, rejectionReason
+}
+
 /**
  * Computes the subject line for direct notification email messages
  */
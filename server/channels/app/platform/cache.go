@@ -0,0 +1,39 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"maps"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+// CacheStats returns the usage statistics of the caches of this node.
+func (ps *PlatformService) CacheStats() []*model.CacheStats {
+	stats := []*model.CacheStats{}
+	for _, provider := range []cache.Provider{ps.cacheProvider, ps.localCacheProvider} {
+		if provider == nil {
+			continue
+		}
+		for _, c := range provider.Caches() {
+			stats = append(stats, c.Stats())
+		}
+	}
+	return stats
+}
+
+// resizeCaches applies the cache sizes of the config when they change.
+func (ps *PlatformService) resizeCaches(oldConfig, newConfig *model.Config) {
+	if maps.Equal(oldConfig.CacheSettings.CacheSizes, newConfig.CacheSettings.CacheSizes) {
+		return
+	}
+
+	for _, provider := range []cache.Provider{ps.cacheProvider, ps.localCacheProvider} {
+		if err := provider.SetSizes(newConfig.CacheSettings.CacheSizes); err != nil {
+			ps.logger.Warn("Failed to apply the cache sizes", mlog.Err(err))
+		}
+	}
+}
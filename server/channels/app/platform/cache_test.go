@@ -0,0 +1,40 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestResizeCaches(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+
+	cacheSize := func(name string) int {
+		for _, stats := range th.Service.CacheStats() {
+			if stats.Name == name {
+				return stats.Size
+			}
+		}
+		require.Failf(t, "cache not found", "no cache named %q", name)
+		return 0
+	}
+
+	initialSize := cacheSize("Session")
+
+	th.Service.UpdateConfig(func(cfg *model.Config) {
+		cfg.CacheSettings.CacheSizes = map[string]int{"Session": model.SessionCacheSize * 2}
+	})
+	require.Greater(t, cacheSize("Session"), initialSize)
+
+	th.Service.UpdateConfig(func(cfg *model.Config) {
+		cfg.CacheSettings.CacheSizes = map[string]int{}
+	})
+	require.Equal(t, initialSize, cacheSize("Session"))
+}
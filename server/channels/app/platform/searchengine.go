@@ -91,4 +91,9 @@ func (ps *PlatformService) StopSearchEngine() {
 			ps.Log().Error("Failed to stop Bleve Engine", mlog.Err(err))
 		}
 	}
+	if ps.SearchEngine != nil && ps.SearchEngine.SemanticEngine != nil && ps.SearchEngine.SemanticEngine.IsActive() {
+		if err := ps.SearchEngine.SemanticEngine.Stop(); err != nil {
+			ps.Log().Error("Failed to stop semantic search engine", mlog.Err(err))
+		}
+	}
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
 	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/bleveengine"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine/semanticengine"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
 
@@ -55,9 +56,10 @@ type PlatformService struct {
 	statusUpdateExitSignal chan struct{}
 	statusUpdateDoneSignal chan struct{}
 
-	cacheProvider cache.Provider
-	statusCache   cache.Cache
-	sessionCache  cache.Cache
+	cacheProvider      cache.Provider
+	localCacheProvider cache.Provider
+	statusCache        cache.Cache
+	sessionCache       cache.Cache
 
 	asymmetricSigningKey atomic.Pointer[ecdsa.PrivateKey]
 	clientConfig         atomic.Value
@@ -84,9 +86,10 @@ type PlatformService struct {
 	licenseListeners   map[string]func(*model.License, *model.License)
 	licenseManager     einterfaces.LicenseInterface
 
-	telemetryId       string
-	configListenerId  string
-	licenseListenerId string
+	telemetryId          string
+	configListenerId     string
+	licenseListenerId    string
+	cacheSizesListenerId string
 
 	clusterLeaderListeners sync.Map
 	clusterIFace           einterfaces.ClusterInterface
@@ -218,6 +221,20 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 				DisableCache:     *cacheConfig.DisableClientCache,
 			},
 		)
+	} else if *cacheConfig.CacheType == model.CacheTypeHybrid {
+		ps.cacheProvider, err = cache.NewHybridProvider(
+			&cache.HybridOptions{
+				RedisOptions: cache.RedisOptions{
+					RedisAddr:        *cacheConfig.RedisAddress,
+					RedisPassword:    *cacheConfig.RedisPassword,
+					RedisDB:          *cacheConfig.RedisDB,
+					RedisCachePrefix: *cacheConfig.RedisCachePrefix,
+					DisableCache:     *cacheConfig.DisableClientCache,
+				},
+				LocalCaches:  cacheConfig.HybridLocalCaches,
+				Invalidation: *cacheConfig.HybridInvalidation,
+			},
+		)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("unable to create cache provider: %w", err)
@@ -228,6 +245,15 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		return nil, fmt.Errorf("unable to connect to cache provider: %w", err)
 	}
 
+	// The status and session caches are always local to the node.
+	ps.localCacheProvider = cache.NewProvider()
+
+	// The sizes are applied to the caches as they get created. No cache
+	// exists yet, so there's nothing to resize.
+	_ = ps.cacheProvider.SetSizes(cacheConfig.CacheSizes)
+	_ = ps.localCacheProvider.SetSizes(cacheConfig.CacheSizes)
+	ps.cacheSizesListenerId = ps.AddConfigListener(ps.resizeCaches)
+
 	ps.Log().Info("Successfully connected to cache backend", mlog.String("backend", *cacheConfig.CacheType), mlog.String("result", res))
 
 	// Step 3: Search Engine
@@ -237,11 +263,17 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		return nil, err
 	}
 	searchEngine.RegisterBleveEngine(bleveEngine)
+	semanticEngine := semanticengine.NewSemanticEngine(ps.Config())
+	if err := semanticEngine.Start(); err != nil {
+		return nil, err
+	}
+	searchEngine.RegisterSemanticEngine(semanticEngine)
 	ps.SearchEngine = searchEngine
 
 	// Step 4: Init Enterprise
 	// Depends on step 3 (s.SearchEngine must be non-nil)
 	ps.initEnterprise()
+	ps.cacheProvider.SetCluster(ps.clusterIFace)
 
 	// Step 5: Init Metrics
 	if metricsInterfaceFn != nil && ps.metricsIFace == nil { // if the metrics interface is set by options, do not override it
@@ -249,6 +281,9 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 	}
 
 	ps.cacheProvider.SetMetrics(ps.metricsIFace)
+	if ps.metricsIFace != nil {
+		ps.metricsIFace.RegisterCacheStatsCollector(ps.CacheStats)
+	}
 
 	// Step 6: Store.
 	// Depends on Step 0 (config), 1 (cacheProvider), 3 (search engine), 5 (metrics) and cluster.
@@ -314,7 +349,7 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 	// to a lot of SCAN calls in case of Redis. We could potentially have a
 	// reverse mapping to avoid the scan, but this needs more complicated code.
 	// Leaving this for now.
-	ps.statusCache, err = cache.NewProvider().NewCache(&cache.CacheOptions{
+	ps.statusCache, err = ps.localCacheProvider.NewCache(&cache.CacheOptions{
 		Name:           "Status",
 		Size:           model.StatusCacheSize,
 		Striped:        true,
@@ -325,7 +360,7 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 		return nil, fmt.Errorf("unable to create status cache: %w", err)
 	}
 
-	ps.sessionCache, err = cache.NewProvider().NewCache(&cache.CacheOptions{
+	ps.sessionCache, err = ps.localCacheProvider.NewCache(&cache.CacheOptions{
 		Name:           "Session",
 		Size:           model.SessionCacheSize,
 		Striped:        true,
@@ -345,7 +380,7 @@ func New(sc ServiceConfig, options ...Option) (*PlatformService, error) {
 	// if the license didn't have clustering. But there's an intricate deadlock
 	// where license cannot be loaded before store, and store cannot be loaded before
 	// cache. So loading license before loading cache is an uphill battle.
-	if (license == nil || !*license.Features.Cluster) && *cacheConfig.CacheType == model.CacheTypeRedis && !ps.forceEnableRedis {
+	if (license == nil || !*license.Features.Cluster) && (*cacheConfig.CacheType == model.CacheTypeRedis || *cacheConfig.CacheType == model.CacheTypeHybrid) && !ps.forceEnableRedis {
 		return nil, fmt.Errorf("Redis cannot be used in an instance without a license or a license without clustering")
 	}
 
@@ -470,6 +505,7 @@ func (ps *PlatformService) ShutdownMetrics() error {
 
 func (ps *PlatformService) ShutdownConfig() error {
 	ps.RemoveConfigListener(ps.configListenerId)
+	ps.RemoveConfigListener(ps.cacheSizesListenerId)
 
 	if ps.configStore != nil {
 		err := ps.configStore.Close()
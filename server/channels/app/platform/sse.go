@@ -0,0 +1,170 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// ErrSSEConnNotFound is returned when a request is sent to a Server-Sent
+// Events connection that doesn't exist, or that is already closed.
+var ErrSSEConnNotFound = errors.New("server-sent events connection not found")
+
+// SSEStream is a Server-Sent Events response that a WebConn writes its
+// messages to, for the clients that can't open a WebSocket. The client
+// sends its requests through a separate HTTP request instead.
+type SSEStream struct {
+	w         http.ResponseWriter
+	rc        *http.ResponseController
+	done      <-chan struct{}
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	// mut serializes the requests of the client, like the read pump
+	// does for WebSockets.
+	mut      sync.Mutex
+	finished bool
+}
+
+// NewSSEStream creates the stream of the given request. Nothing is written
+// to the response until the WebConn using the stream starts pumping.
+func NewSSEStream(w http.ResponseWriter, r *http.Request) *SSEStream {
+	return &SSEStream{
+		w:      w,
+		rc:     http.NewResponseController(w),
+		done:   r.Context().Done(),
+		closed: make(chan struct{}),
+	}
+}
+
+// start writes the headers of the response.
+func (s *SSEStream) start() error {
+	header := s.w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	// Prevents proxies like nginx from buffering the events.
+	header.Set("X-Accel-Buffering", "no")
+	s.w.WriteHeader(http.StatusOK)
+
+	return s.write(nil)
+}
+
+// wait blocks until either the client or the server closes the stream.
+func (s *SSEStream) wait() {
+	select {
+	case <-s.done:
+	case <-s.closed:
+	}
+}
+
+// close ends the stream. It doesn't wait for the WebConn to finish.
+func (s *SSEStream) close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+}
+
+// writeMessage writes a WebSocket message of the given type. Pings are
+// written as comments, which keep the connection alive without
+// reaching the client.
+func (s *SSEStream) writeMessage(msgType int, data []byte) error {
+	switch msgType {
+	case websocket.PingMessage:
+		return s.write([]byte(": ping\n\n"))
+	case websocket.CloseMessage:
+		s.close()
+		return nil
+	default:
+		return s.writeData(data, "")
+	}
+}
+
+// writeData writes an encoded message as an event. The id is sent back
+// by the client in the Last-Event-ID header when it reconnects.
+func (s *SSEStream) writeData(data []byte, id string) error {
+	var buf bytes.Buffer
+	buf.Grow(len(data) + len(id) + 16)
+	if id != "" {
+		buf.WriteString("id: ")
+		buf.WriteString(id)
+		buf.WriteByte('\n')
+	}
+	for line := range bytes.SplitSeq(bytes.TrimRight(data, "\n"), []byte("\n")) {
+		buf.WriteString("data: ")
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	buf.WriteByte('\n')
+
+	return s.write(buf.Bytes())
+}
+
+func (s *SSEStream) write(p []byte) error {
+	// The deadline is pushed back on every write, since the stream
+	// outlives the write timeout of the server.
+	if err := s.rc.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	if len(p) > 0 {
+		if _, err := s.w.Write(p); err != nil {
+			return err
+		}
+	}
+	return s.rc.Flush()
+}
+
+// formatSSEEventID returns the id of an event, made of the connection id
+// and the sequence number of the event.
+func formatSSEEventID(connectionID string, seq int64) string {
+	return connectionID + ":" + strconv.FormatInt(seq, 10)
+}
+
+// ParseSSEEventID returns the connection id and the sequence number to
+// resume a connection from, given the id of the last event received.
+func ParseSSEEventID(id string) (connectionID string, nextSeq int64, ok bool) {
+	connectionID, seqStr, found := strings.Cut(id, ":")
+	if !found || !model.IsValidId(connectionID) {
+		return "", 0, false
+	}
+
+	seq, err := strconv.ParseInt(seqStr, 10, 64)
+	if err != nil || seq < 0 {
+		return "", 0, false
+	}
+
+	return connectionID, seq + 1, true
+}
+
+// ServeSSERequest handles a request sent by the client of a Server-Sent
+// Events connection, as if it was received through its WebSocket.
+func (ps *PlatformService) ServeSSERequest(userID, connectionID string, req *model.WebSocketRequest) error {
+	hub := ps.GetHubForUserId(userID)
+	if hub == nil {
+		return ErrSSEConnNotFound
+	}
+
+	wc := hub.GetConn(userID, connectionID)
+	if wc == nil || wc.sse == nil {
+		return ErrSSEConnNotFound
+	}
+
+	wc.sse.mut.Lock()
+	defer wc.sse.mut.Unlock()
+	if wc.sse.finished {
+		return ErrSSEConnNotFound
+	}
+
+	wc.serveRequest(req)
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestParseSSEEventID(t *testing.T) {
+	connectionID := model.NewId()
+
+	id, nextSeq, ok := ParseSSEEventID(formatSSEEventID(connectionID, 41))
+	require.True(t, ok)
+	assert.Equal(t, connectionID, id)
+	assert.Equal(t, int64(42), nextSeq)
+
+	for _, invalid := range []string{"", connectionID, "invalid:1", connectionID + ":", connectionID + ":-1", connectionID + ":a"} {
+		_, _, ok := ParseSSEEventID(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
+
+func TestSSEStream(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/v4/sse", nil)
+	s := NewSSEStream(rec, req)
+
+	require.NoError(t, s.start())
+	assert.Equal(t, "text/event-stream", rec.Header().Get("Content-Type"))
+	assert.True(t, rec.Flushed)
+
+	require.NoError(t, s.writeData([]byte("{\"event\":\"hello\"}\n"), "id:0"))
+	require.NoError(t, s.writeData([]byte("{\"status\":\"OK\"}"), ""))
+	require.NoError(t, s.writeMessage(websocket.PingMessage, nil))
+	assert.Equal(t, "id: id:0\ndata: {\"event\":\"hello\"}\n\ndata: {\"status\":\"OK\"}\n\n: ping\n\n", rec.Body.String())
+
+	require.NoError(t, s.writeMessage(websocket.CloseMessage, nil))
+	s.wait()
+}
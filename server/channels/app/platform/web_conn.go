@@ -62,7 +62,10 @@ type pluginWSPostedHook struct {
 }
 
 type WebConnConfig struct {
-	WebSocket         *websocket.Conn
+	WebSocket *websocket.Conn
+	// SSE is set instead of WebSocket for the clients connected through
+	// Server-Sent Events.
+	SSE               *SSEStream
 	Session           model.Session
 	TFunc             i18n.TranslateFunc
 	Locale            string
@@ -74,12 +77,16 @@ type WebConnConfig struct {
 	RemoteAddress     string
 	XForwardedFor     string
 	DisconnectErrCode string
+	// Msgpack sends the messages as msgpack binary frames instead of
+	// JSON text frames. It's ignored for Server-Sent Events.
+	Msgpack bool
 
 	// These aren't necessary to be exported to api layer.
 	sequence         int64
 	activeQueue      chan model.WebSocketMessage
 	deadQueue        []*model.WebSocketEvent
 	deadQueuePointer int
+	subscription     *webConnSubscription
 }
 
 // WebConn represents a single websocket connection to a user.
@@ -91,6 +98,7 @@ type WebConn struct {
 	Suite             SuiteIFace
 	HookRunner        HookRunner
 	WebSocket         *websocket.Conn
+	sse               *SSEStream
 	T                 i18n.TranslateFunc
 	Locale            string
 	Sequence          int64
@@ -126,6 +134,8 @@ type WebConn struct {
 
 	// The client type behind the connection (i.e. web, desktop or mobile)
 	originClient string
+	// Whether the messages are sent as msgpack binary frames
+	msgpack bool
 	// The remote address from the original HTTP Upgrade request
 	remoteAddress string
 	// The X-Forwarded-For HTTP header value from the origina HTTP Upgrade request
@@ -135,6 +145,7 @@ type WebConn struct {
 	activeTeamID                    atomic.Value
 	activeRHSThreadChannelID        atomic.Value
 	activeThreadViewThreadChannelID atomic.Value
+	subscription                    atomic.Pointer[webConnSubscription]
 
 	endWritePump chan struct{}
 	pumpFinished chan struct{}
@@ -157,6 +168,8 @@ type CheckConnResult struct {
 	DeadQueue        []*model.WebSocketEvent
 	DeadQueuePointer int
 	ReuseCount       int
+
+	subscription *webConnSubscription
 }
 
 // PopulateWebConnConfig checks if the connection id already exists in the hub,
@@ -192,6 +205,7 @@ func (ps *PlatformService) PopulateWebConnConfig(s *model.Session, cfg *WebConnC
 		cfg.Active = false
 		cfg.ReuseCount = res.ReuseCount
 		cfg.sequence = seqNum
+		cfg.subscription = res.subscription
 	}
 	return cfg, nil
 }
@@ -209,13 +223,15 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 
 	// Disable TCP_NO_DELAY for higher throughput
 	var tcpConn *net.TCPConn
-	switch conn := cfg.WebSocket.UnderlyingConn().(type) {
-	case *net.TCPConn:
-		tcpConn = conn
-	case *tls.Conn:
-		newConn, ok := conn.NetConn().(*net.TCPConn)
-		if ok {
-			tcpConn = newConn
+	if cfg.WebSocket != nil {
+		switch conn := cfg.WebSocket.UnderlyingConn().(type) {
+		case *net.TCPConn:
+			tcpConn = conn
+		case *tls.Conn:
+			newConn, ok := conn.NetConn().(*net.TCPConn)
+			if ok {
+				tcpConn = newConn
+			}
 		}
 	}
 
@@ -243,6 +259,7 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		deadQueuePointer:   cfg.deadQueuePointer,
 		Sequence:           cfg.sequence,
 		WebSocket:          cfg.WebSocket,
+		sse:                cfg.SSE,
 		lastUserActivityAt: model.GetMillis(),
 		UserId:             cfg.Session.UserId,
 		T:                  cfg.TFunc,
@@ -256,10 +273,12 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		lastLogTimeSlow:    time.Now(),
 		lastLogTimeFull:    time.Now(),
 		originClient:       cfg.OriginClient,
+		msgpack:            cfg.Msgpack && cfg.SSE == nil,
 		remoteAddress:      cfg.RemoteAddress,
 		xForwardedFor:      cfg.XForwardedFor,
 	}
 	wc.Active.Store(cfg.Active)
+	wc.subscription.Store(cfg.subscription)
 
 	wc.SetSession(&cfg.Session)
 	wc.SetSessionToken(cfg.Session.Token)
@@ -295,10 +314,25 @@ func (wc *WebConn) pluginPostedConsumer(wg *sync.WaitGroup) {
 
 // Close closes the WebConn.
 func (wc *WebConn) Close() {
-	wc.WebSocket.Close()
+	wc.closeTransport()
 	<-wc.pumpFinished
 }
 
+// closeTransport closes the WebSocket or the Server-Sent Events stream
+// of the connection, without waiting for the pumps to finish.
+func (wc *WebConn) closeTransport() {
+	if wc.sse != nil {
+		wc.sse.close()
+		return
+	}
+	wc.WebSocket.Close()
+}
+
+// IsSSE returns whether the client is connected through Server-Sent Events.
+func (wc *WebConn) IsSSE() bool {
+	return wc.sse != nil
+}
+
 // GetSessionExpiresAt returns the time at which the session expires.
 func (wc *WebConn) GetSessionExpiresAt() int64 {
 	return atomic.LoadInt64(&wc.sessionExpiresAt)
@@ -416,7 +450,11 @@ func (wc *WebConn) Pump() {
 	wg.Add(1)
 	go wc.pluginPostedConsumer(&wg)
 
-	wc.readPump()
+	if wc.sse != nil {
+		wc.ssePump()
+	} else {
+		wc.readPump()
+	}
 	close(wc.endWritePump)
 	close(wc.pluginPosted)
 	wg.Wait()
@@ -483,30 +521,57 @@ func (wc *WebConn) readPump() {
 			return
 		}
 
-		// Messages which actions are prefixed with the plugin prefix
-		// should only be dispatched to the plugins
-		if !strings.HasPrefix(req.Action, websocketMessagePluginPrefix) {
-			wc.Platform.WebSocketRouter.ServeWebSocket(wc, &req)
-		}
+		wc.serveRequest(&req)
+	}
+}
 
-		clonedReq, err := req.Clone()
-		if err != nil {
-			wc.logSocketErr("websocket.cloneRequest", err)
-			continue
+// ssePump waits for the Server-Sent Events stream to be closed, since
+// the requests of the client are received through separate HTTP requests.
+func (wc *WebConn) ssePump() {
+	defer func() {
+		if metrics := wc.Platform.metricsIFace; metrics != nil {
+			metrics.DecrementHTTPWebSockets(wc.originClient)
 		}
+		wc.sse.close()
 
-		if session := wc.GetSession(); session != nil {
-			clonedReq.Session.Id = session.Id
-		}
+		// No request can be served after this, since the plugin
+		// hooks channel is about to be closed.
+		wc.sse.mut.Lock()
+		wc.sse.finished = true
+		wc.sse.mut.Unlock()
+	}()
+	if metrics := wc.Platform.metricsIFace; metrics != nil {
+		metrics.IncrementHTTPWebSockets(wc.originClient)
+	}
 
-		if clonedReq.Data == nil {
-			clonedReq.Data = map[string]any{}
-		}
-		clonedReq.Data[model.WebSocketRemoteAddr] = wc.remoteAddress
-		clonedReq.Data[model.WebSocketXForwardedFor] = wc.xForwardedFor
+	wc.sse.wait()
+}
 
-		wc.pluginPosted <- pluginWSPostedHook{wc.GetConnectionID(), wc.UserId, clonedReq}
+// serveRequest routes a request of the client, and passes it to the plugins.
+func (wc *WebConn) serveRequest(req *model.WebSocketRequest) {
+	// Messages which actions are prefixed with the plugin prefix
+	// should only be dispatched to the plugins
+	if !strings.HasPrefix(req.Action, websocketMessagePluginPrefix) {
+		wc.Platform.WebSocketRouter.ServeWebSocket(wc, req)
 	}
+
+	clonedReq, err := req.Clone()
+	if err != nil {
+		wc.logSocketErr("websocket.cloneRequest", err)
+		return
+	}
+
+	if session := wc.GetSession(); session != nil {
+		clonedReq.Session.Id = session.Id
+	}
+
+	if clonedReq.Data == nil {
+		clonedReq.Data = map[string]any{}
+	}
+	clonedReq.Data[model.WebSocketRemoteAddr] = wc.remoteAddress
+	clonedReq.Data[model.WebSocketXForwardedFor] = wc.xForwardedFor
+
+	wc.pluginPosted <- pluginWSPostedHook{wc.GetConnectionID(), wc.UserId, clonedReq}
 }
 
 func (wc *WebConn) writePump() {
@@ -516,9 +581,16 @@ func (wc *WebConn) writePump() {
 	defer func() {
 		ticker.Stop()
 		authTicker.Stop()
-		wc.WebSocket.Close()
+		wc.closeTransport()
 	}()
 
+	if wc.sse != nil {
+		if err := wc.sse.start(); err != nil {
+			wc.logSocketErr("sse.start", err)
+			return
+		}
+	}
+
 	if wc.Sequence != 0 {
 		if ok, index := wc.isInDeadQueue(wc.Sequence); ok {
 			if err := wc.drainDeadQueue(index); err != nil {
@@ -571,14 +643,14 @@ func (wc *WebConn) writePump() {
 			evt, evtOk := msg.(*model.WebSocketEvent)
 
 			buf.Reset()
-			var err error
+			seq := int64(-1)
 			if evtOk {
 				evt = evt.SetSequence(wc.Sequence)
-				err = evt.Encode(enc, &buf)
+				msg = evt
+				seq = wc.Sequence
 				wc.Sequence++
-			} else {
-				err = enc.Encode(msg)
 			}
+			data, err := wc.encodeMessage(msg, enc, &buf)
 			if err != nil {
 				wc.Platform.logger.Warn("Error in encoding websocket message", mlog.Err(err))
 				continue
@@ -589,7 +661,7 @@ func (wc *WebConn) writePump() {
 					mlog.String("user_id", wc.UserId),
 					mlog.String("conn_id", wc.GetConnectionID()),
 					mlog.String("type", msg.EventType()),
-					mlog.Int("size", buf.Len()),
+					mlog.Int("size", len(data)),
 				}
 				if evtOk {
 					logData = append(logData, mlog.String("channel_id", evt.GetBroadcast().ChannelId))
@@ -603,7 +675,7 @@ func (wc *WebConn) writePump() {
 				wc.addToDeadQueue(evt)
 			}
 
-			if err := wc.writeMessageBuf(websocket.TextMessage, buf.Bytes()); err != nil {
+			if err := wc.writeData(data, seq); err != nil {
 				wc.logSocketErr("websocket.send", err)
 				return
 			}
@@ -617,12 +689,21 @@ func (wc *WebConn) writePump() {
 				return
 			}
 
+			// Server-Sent Events clients don't answer pings, so the
+			// status is checked here instead of in the pong handler.
+			if wc.sse != nil && wc.IsAuthenticated() {
+				userID := wc.UserId
+				wc.Platform.Go(func() {
+					wc.Platform.SetStatusAwayIfNeeded(userID, false)
+				})
+			}
+
 		case <-wc.endWritePump:
 			return
 
 		case <-authTicker.C:
 			if wc.GetSessionToken() == "" {
-				wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.Stringer("ip_address", wc.WebSocket.RemoteAddr()))
+				wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.String("ip_address", wc.remoteAddress))
 				return
 			}
 			authTicker.Stop()
@@ -633,6 +714,9 @@ func (wc *WebConn) writePump() {
 // writeMessageBuf is a helper utility that wraps the write to the socket
 // along with setting the write deadline.
 func (wc *WebConn) writeMessageBuf(msgType int, data []byte) error {
+	if wc.sse != nil {
+		return wc.sse.writeMessage(msgType, data)
+	}
 	if err := wc.WebSocket.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil {
 		return err
 	}
@@ -643,14 +727,54 @@ func (wc *WebConn) writeMessage(msg *model.WebSocketEvent) error {
 	// We don't use the encoder from the write pump because it's unwieldy to pass encoders
 	// around, and this is only called during initialization of the webConn.
 	var buf bytes.Buffer
-	err := msg.Encode(json.NewEncoder(&buf), &buf)
+	data, err := wc.encodeMessage(msg, json.NewEncoder(&buf), &buf)
 	if err != nil {
 		wc.Platform.logger.Warn("Error in encoding websocket message", mlog.Err(err))
 		return nil
 	}
 	wc.Sequence++
 
-	return wc.writeMessageBuf(websocket.TextMessage, buf.Bytes())
+	return wc.writeData(data, msg.GetSequence())
+}
+
+// encodeMessage encodes a message with the framing chosen by the client.
+// JSON is encoded to buf, which the returned slice then points to.
+func (wc *WebConn) encodeMessage(msg model.WebSocketMessage, enc *json.Encoder, buf *bytes.Buffer) ([]byte, error) {
+	if wc.msgpack {
+		switch m := msg.(type) {
+		case *model.WebSocketEvent:
+			return m.ToMsgpack()
+		case *model.WebSocketResponse:
+			return m.ToMsgpack()
+		}
+	}
+
+	var err error
+	if evt, ok := msg.(*model.WebSocketEvent); ok {
+		err = evt.Encode(enc, buf)
+	} else {
+		err = enc.Encode(msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeData writes an encoded message. seq is the sequence number of the
+// message if it's an event, or -1 otherwise.
+func (wc *WebConn) writeData(data []byte, seq int64) error {
+	if wc.sse != nil {
+		var id string
+		if seq >= 0 {
+			id = formatSSEEventID(wc.GetConnectionID(), seq)
+		}
+		return wc.sse.writeData(data, id)
+	}
+	if wc.msgpack {
+		return wc.writeMessageBuf(websocket.BinaryMessage, data)
+	}
+	return wc.writeMessageBuf(websocket.TextMessage, data)
 }
 
 // addToDeadQueue appends a message to the dead queue.
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// MaxWebConnSubscriptionItems is the maximum number of event types or
+// channels a connection can subscribe to.
+const MaxWebConnSubscriptionItems = 1000
+
+// webConnSubscription restricts the events broadcast to a connection. A nil
+// set lets every event through.
+type webConnSubscription struct {
+	eventTypes map[model.WebsocketEventType]struct{}
+	channelIDs map[string]struct{}
+}
+
+// SetSubscription restricts the events broadcast to the connection to the
+// given event types and channels. The channel filter only applies to the
+// events scoped to a channel. An empty list lifts the corresponding
+// restriction, so calling it with no lists receives every event again.
+func (wc *WebConn) SetSubscription(eventTypes []model.WebsocketEventType, channelIDs []string) {
+	if len(eventTypes) == 0 && len(channelIDs) == 0 {
+		wc.subscription.Store(nil)
+		return
+	}
+
+	sub := &webConnSubscription{}
+	if len(eventTypes) > 0 {
+		sub.eventTypes = make(map[model.WebsocketEventType]struct{}, len(eventTypes))
+		for _, eventType := range eventTypes {
+			sub.eventTypes[eventType] = struct{}{}
+		}
+	}
+	if len(channelIDs) > 0 {
+		sub.channelIDs = make(map[string]struct{}, len(channelIDs))
+		for _, channelID := range channelIDs {
+			sub.channelIDs[channelID] = struct{}{}
+		}
+	}
+	wc.subscription.Store(sub)
+}
+
+// GetSubscription returns the event types and channels the connection is
+// subscribed to. Nil lists mean there is no restriction.
+func (wc *WebConn) GetSubscription() (eventTypes []model.WebsocketEventType, channelIDs []string) {
+	sub := wc.subscription.Load()
+	if sub == nil {
+		return nil, nil
+	}
+
+	for eventType := range sub.eventTypes {
+		eventTypes = append(eventTypes, eventType)
+	}
+	for channelID := range sub.channelIDs {
+		channelIDs = append(channelIDs, channelID)
+	}
+	return eventTypes, channelIDs
+}
+
+// isSubscribed returns whether the event passes the subscription filters
+// of the connection.
+func (wc *WebConn) isSubscribed(msg *model.WebSocketEvent) bool {
+	sub := wc.subscription.Load()
+	if sub == nil {
+		return true
+	}
+
+	if sub.eventTypes != nil {
+		if _, ok := sub.eventTypes[msg.EventType()]; !ok {
+			return false
+		}
+	}
+
+	if chID := msg.GetBroadcast().ChannelId; chID != "" && sub.channelIDs != nil {
+		if _, ok := sub.channelIDs[chID]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestWebConnSubscription(t *testing.T) {
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+
+	posted := model.NewWebSocketEvent(model.WebsocketEventPosted, "", channelID, "", nil, "")
+	postedElsewhere := model.NewWebSocketEvent(model.WebsocketEventPosted, "", otherChannelID, "", nil, "")
+	typing := model.NewWebSocketEvent(model.WebsocketEventTyping, "", channelID, "", nil, "")
+	status := model.NewWebSocketEvent(model.WebsocketEventStatusChange, "", "", model.NewId(), nil, "")
+
+	t.Run("no subscription lets every event through", func(t *testing.T) {
+		wc := &WebConn{}
+		for _, ev := range []*model.WebSocketEvent{posted, postedElsewhere, typing, status} {
+			assert.True(t, wc.isSubscribed(ev))
+		}
+		eventTypes, channelIDs := wc.GetSubscription()
+		assert.Nil(t, eventTypes)
+		assert.Nil(t, channelIDs)
+	})
+
+	t.Run("event type filter", func(t *testing.T) {
+		wc := &WebConn{}
+		wc.SetSubscription([]model.WebsocketEventType{model.WebsocketEventPosted}, nil)
+		assert.True(t, wc.isSubscribed(posted))
+		assert.True(t, wc.isSubscribed(postedElsewhere))
+		assert.False(t, wc.isSubscribed(typing))
+		assert.False(t, wc.isSubscribed(status))
+	})
+
+	t.Run("channel filter only applies to channel events", func(t *testing.T) {
+		wc := &WebConn{}
+		wc.SetSubscription(nil, []string{channelID})
+		assert.True(t, wc.isSubscribed(posted))
+		assert.False(t, wc.isSubscribed(postedElsewhere))
+		assert.True(t, wc.isSubscribed(typing))
+		assert.True(t, wc.isSubscribed(status))
+
+		eventTypes, channelIDs := wc.GetSubscription()
+		assert.Nil(t, eventTypes)
+		assert.Equal(t, []string{channelID}, channelIDs)
+	})
+
+	t.Run("empty lists clear the subscription", func(t *testing.T) {
+		wc := &WebConn{}
+		wc.SetSubscription([]model.WebsocketEventType{model.WebsocketEventPosted}, []string{channelID})
+		assert.False(t, wc.isSubscribed(status))
+
+		wc.SetSubscription(nil, nil)
+		assert.True(t, wc.isSubscribed(status))
+		assert.Nil(t, wc.subscription.Load())
+	})
+}
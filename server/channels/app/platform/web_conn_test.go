@@ -5,11 +5,14 @@ package platform
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
@@ -17,6 +20,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
 )
 
 type hookRunner struct {
@@ -241,3 +245,156 @@ func TestWebConnDrainDeadQueue(t *testing.T) {
 		t.Run("Overwritten First", func(t *testing.T) { run(int64(128), deadQueueSize+10) })
 	})
 }
+
+func TestWebConnMsgpack(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	received := make(chan *model.WebSocketEvent, 1)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		upgrader := &websocket.Upgrader{}
+		conn, err := upgrader.Upgrade(w, req, nil)
+		require.NoError(t, err)
+		msgType, buf, err := conn.ReadMessage()
+		require.NoError(t, err)
+		assert.Equal(t, websocket.BinaryMessage, msgType)
+		ev, err := model.WebSocketEventFromMsgpack(bytes.NewReader(buf))
+		require.NoError(t, err)
+		received <- ev
+	}))
+	defer s.Close()
+
+	d := websocket.Dialer{}
+	c, _, err := d.Dial("ws://"+s.Listener.Addr().String()+"/ws", nil)
+	require.NoError(t, err)
+
+	wc := th.Service.NewWebConn(&WebConnConfig{
+		WebSocket: c,
+		Msgpack:   true,
+	}, th.Suite, &hookRunner{})
+	defer wc.WebSocket.Close()
+
+	msg := model.NewWebSocketEvent(model.WebsocketEventPosted, "", model.NewId(), "", nil, "")
+	msg.Add("message", "hello")
+	require.NoError(t, wc.writeMessage(msg.PrecomputeJSON()))
+
+	ev := <-received
+	assert.Equal(t, model.WebsocketEventPosted, ev.EventType())
+	assert.Equal(t, msg.GetBroadcast().ChannelId, ev.GetBroadcast().ChannelId)
+	assert.Equal(t, "hello", ev.GetData()["message"])
+}
+
+// BenchmarkWebConnEncodeMessage measures the encoding of an event broadcast
+// to many connections, which share its precomputed encoding.
+func BenchmarkWebConnEncodeMessage(b *testing.B) {
+	msg := model.NewWebSocketEvent(model.WebsocketEventPosted, "", model.NewId(), "", nil, "")
+	for range 100 {
+		msg.Add(model.NewId(), model.NewId())
+	}
+
+	for _, useMsgpack := range []bool{false, true} {
+		name := "JSON"
+		if useMsgpack {
+			name = "Msgpack"
+		}
+		b.Run(name, func(b *testing.B) {
+			wc := &WebConn{msgpack: useMsgpack}
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			precomputed := msg.PrecomputeJSON()
+			b.ReportAllocs()
+			for i := 0; b.Loop(); i++ {
+				buf.Reset()
+				if _, err := wc.encodeMessage(precomputed.SetSequence(int64(i)), enc, &buf); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkHubBroadcast measures an event broadcast by the hub through to
+// many connections of a user, sent as JSON, msgpack or compressed frames.
+func BenchmarkHubBroadcast(b *testing.B) {
+	th := Setup(b).InitBasic()
+	defer th.TearDown()
+
+	const numConns = 100
+
+	msg := model.NewWebSocketEvent(model.WebsocketEventPosted, "", model.NewId(), th.BasicUser.Id, nil, "")
+	for range 100 {
+		msg.Add(model.NewId(), model.NewId())
+	}
+
+	for _, tc := range []struct {
+		name     string
+		msgpack  bool
+		compress bool
+	}{
+		{name: "JSON"},
+		{name: "Msgpack", msgpack: true},
+		{name: "CompressedJSON", compress: true},
+		{name: "CompressedMsgpack", msgpack: true, compress: true},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			var received atomic.Int64
+			s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				upgrader := &websocket.Upgrader{EnableCompression: tc.compress}
+				conn, err := upgrader.Upgrade(w, req, nil)
+				for err == nil {
+					if _, _, err = conn.ReadMessage(); err == nil {
+						received.Add(1)
+					}
+				}
+			}))
+			defer s.Close()
+
+			// The session doesn't expire, so it's never looked up.
+			session := model.Session{Token: model.NewId(), UserId: th.BasicUser.Id, ExpiresAt: model.GetMillis() + time.Hour.Milliseconds()}
+			d := websocket.Dialer{EnableCompression: tc.compress}
+			conns := make([]*WebConn, 0, numConns)
+			defer func() {
+				for _, wc := range conns {
+					wc.Close()
+				}
+			}()
+			for range numConns {
+				c, _, err := d.Dial("ws://"+s.Listener.Addr().String()+"/ws", nil)
+				require.NoError(b, err)
+				wc := th.Service.NewWebConn(&WebConnConfig{
+					WebSocket: c,
+					Session:   session,
+					TFunc:     i18n.IdentityTfunc(),
+					Locale:    "en",
+					Msgpack:   tc.msgpack,
+				}, th.Suite, &hookRunner{})
+				require.NoError(b, th.Service.HubRegister(wc))
+				go wc.Pump()
+				conns = append(conns, wc)
+			}
+
+			waitForMessages := func(count int64) {
+				deadline := time.Now().Add(10 * time.Second)
+				for received.Load() < count {
+					if time.Now().After(deadline) {
+						b.Fatalf("received %d messages, expected %d", received.Load(), count)
+					}
+					time.Sleep(10 * time.Microsecond)
+				}
+			}
+
+			// Each connection first receives the hello message.
+			waitForMessages(numConns)
+			received.Store(0)
+
+			hub := th.Service.GetHubForUserId(th.BasicUser.Id)
+			var sent int64
+			b.ReportAllocs()
+			for b.Loop() {
+				hub.Broadcast(msg)
+				sent += numConns
+				waitForMessages(sent)
+			}
+		})
+	}
+}
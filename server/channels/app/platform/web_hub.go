@@ -65,6 +65,12 @@ type webConnCountMessage struct {
 	result chan int
 }
 
+type webConnGetMessage struct {
+	userID       string
+	connectionID string
+	result       chan *WebConn
+}
+
 var hubSemaphoreCount = runtime.NumCPU() * 4
 
 // Hub is the central place to manage all websocket connections in the server.
@@ -88,6 +94,7 @@ type Hub struct {
 	checkRegistered chan *webConnSessionMessage
 	checkConn       chan *webConnCheckMessage
 	connCount       chan *webConnCountMessage
+	getConn         chan *webConnGetMessage
 	broadcastHooks  map[string]BroadcastHook
 
 	// Hub-specific semaphore for limiting concurrent goroutines
@@ -109,6 +116,7 @@ func newWebHub(ps *PlatformService) *Hub {
 		checkRegistered: make(chan *webConnSessionMessage),
 		checkConn:       make(chan *webConnCheckMessage),
 		connCount:       make(chan *webConnCountMessage),
+		getConn:         make(chan *webConnGetMessage),
 		hubSemaphore:    make(chan struct{}, hubSemaphoreCount),
 	}
 }
@@ -423,6 +431,22 @@ func (h *Hub) WebConnCountForUser(userID string) int {
 	return 0
 }
 
+// GetConn returns the active connection of the user with the given
+// connection id, or nil if there's none.
+func (h *Hub) GetConn(userID, connectionID string) *WebConn {
+	req := &webConnGetMessage{
+		userID:       userID,
+		connectionID: connectionID,
+		result:       make(chan *WebConn),
+	}
+	select {
+	case h.getConn <- req:
+		return <-req.result
+	case <-h.stop:
+	}
+	return nil
+}
+
 // Broadcast broadcasts the message to all connections in the hub.
 func (h *Hub) Broadcast(message *model.WebSocketEvent) {
 	// XXX: The hub nil check is because of the way we setup our tests. We call
@@ -552,11 +576,21 @@ func (h *Hub) Start() {
 						DeadQueue:        conn.deadQueue,
 						DeadQueuePointer: conn.deadQueuePointer,
 						ReuseCount:       conn.reuseCount + 1,
+						subscription:     conn.subscription.Load(),
 					}
 				}
 				req.result <- res
 			case req := <-h.connCount:
 				req.result <- connIndex.ForUserActiveCount(req.userID)
+			case req := <-h.getConn:
+				var res *WebConn
+				for conn := range connIndex.ForUser(req.userID) {
+					if conn.Active.Load() && conn.GetConnectionID() == req.connectionID {
+						res = conn
+						break
+					}
+				}
+				req.result <- res
 			case <-ticker.C:
 				connIndex.RemoveInactiveConnections()
 			case webConnReg := <-h.register:
@@ -691,6 +725,14 @@ func (h *Hub) Start() {
 						return
 					}
 					if webConn.ShouldSendEvent(msg) {
+						// The subscription filters are applied last, so that only
+						// the events the connection would have received are counted.
+						if !webConn.isSubscribed(msg) {
+							if metrics := h.platform.metricsIFace; metrics != nil {
+								metrics.AddWebSocketFilteredEvent(msg.EventType(), float64(msg.PrecomputedJSONSize()))
+							}
+							return
+						}
 						select {
 						case webConn.send <- h.runBroadcastHooks(msg, webConn, broadcastHooks, broadcastHookArgs):
 						default:
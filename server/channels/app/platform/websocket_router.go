@@ -43,14 +43,14 @@ func (wr *WebSocketRouter) ServeWebSocket(conn *WebConn, r *model.WebSocketReque
 
 		token, ok := r.Data["token"].(string)
 		if !ok {
-			conn.WebSocket.Close()
+			conn.closeTransport()
 			return
 		}
 
 		session, err := conn.Suite.GetSession(token)
 		if err != nil {
 			conn.Platform.Log().Warn("Error while getting session token", mlog.Err(err))
-			conn.WebSocket.Close()
+			conn.closeTransport()
 			return
 		}
 		conn.SetSession(session)
@@ -60,7 +60,7 @@ func (wr *WebSocketRouter) ServeWebSocket(conn *WebConn, r *model.WebSocketReque
 		nErr := conn.Platform.HubRegister(conn)
 		if nErr != nil {
 			conn.Platform.Log().Error("Error while registering to hub", mlog.String("user_id", conn.UserId), mlog.Err(nErr))
-			conn.WebSocket.Close()
+			conn.closeTransport()
 			return
 		}
 
@@ -11,9 +11,11 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/blang/semver/v4"
 	svg "github.com/h2non/go-is-svg"
@@ -145,6 +147,10 @@ func (ch *Channels) syncPluginsActiveState() {
 				}
 
 				if activated {
+					if pending := ch.pendingPluginPermissions(updatedManifest); len(pending) > 0 {
+						logger.Warn("Plugin was activated with permissions a system admin hasn't approved, the API calls requiring them will be denied", mlog.Array("pending_permissions", pending))
+					}
+
 					// Notify all cluster clients if ready
 					if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
 						logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
@@ -162,14 +168,24 @@ func (ch *Channels) syncPluginsActiveState() {
 	}
 }
 
+// NewPluginAPI returns the API of the given plugin, checking the permission of every method.
 func (a *App) NewPluginAPI(c request.CTX, manifest *model.Manifest) plugin.API {
-	return NewPluginAPI(a, c, manifest)
+	api := NewPluginAPI(a, c, manifest)
+	return plugin.NewAPIPermissionLayer(api, api.checkPermission)
 }
 
 func (a *App) InitPlugins(c request.CTX, pluginDir, webappPluginDir string) {
 	a.ch.initPlugins(c, pluginDir, webappPluginDir)
 }
 
+// pluginWasmLimits returns the resource limits of the plugins running in the WebAssembly runtime.
+func pluginWasmLimits(cfg *model.Config) plugin.WasmLimits {
+	return plugin.WasmLimits{
+		MemoryLimitMB: *cfg.PluginSettings.WasmMemoryLimitMB,
+		HookTimeout:   time.Duration(*cfg.PluginSettings.WasmHookTimeoutSeconds) * time.Second,
+	}
+}
+
 func (ch *Channels) initPlugins(c request.CTX, pluginDir, webappPluginDir string) {
 	// Acquiring lock manually, as plugins might be disabled. See GetPluginsEnvironment.
 	defer func() {
@@ -183,6 +199,7 @@ func (ch *Channels) initPlugins(c request.CTX, pluginDir, webappPluginDir string
 		ch.syncPluginsActiveState()
 		if pluginsEnvironment != nil {
 			pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
+			pluginsEnvironment.SetWasmLimits(pluginWasmLimits(ch.cfgSvc.Config()))
 		}
 		return
 	}
@@ -219,7 +236,11 @@ func (ch *Channels) initPlugins(c request.CTX, pluginDir, webappPluginDir string
 	ch.pluginsEnvironment = env
 	ch.pluginsLock.Unlock()
 
+	// The job types of a plugin are registered again when it's activated, so they're
+	// unregistered however the plugin stops, including when it crashes.
+	ch.pluginsEnvironment.SetDeactivatedHandler(ch.unregisterPluginJobTypes)
 	ch.pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
+	ch.pluginsEnvironment.SetWasmLimits(pluginWasmLimits(ch.cfgSvc.Config()))
 
 	if err := ch.syncPlugins(); err != nil {
 		ch.srv.Log().Error("Failed to sync plugins from the file store", mlog.Err(err))
@@ -441,7 +462,8 @@ func (ch *Channels) enablePlugin(id string) *model.AppError {
 	}
 
 	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
-		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: true}
+		// Enabling the plugin approves the permissions requested by its manifest.
+		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: true, ApprovedPermissions: manifest.RequestedPermissions()}
 	})
 
 	// This call will implicitly invoke SyncPluginsActiveState which will activate enabled plugins.
@@ -455,6 +477,89 @@ func (ch *Channels) enablePlugin(id string) *model.AppError {
 	return nil
 }
 
+// ApprovePluginPermissions approves the permissions requested by the manifest of an installed
+// plugin, for instance after an upgrade declaring new permissions, or for a plugin enabled
+// through the configuration. Notifies cluster peers through config change.
+func (a *App) ApprovePluginPermissions(id string) *model.AppError {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return model.NewAppError("ApprovePluginPermissions", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	availablePlugins, err := pluginsEnvironment.Available()
+	if err != nil {
+		return model.NewAppError("ApprovePluginPermissions", "app.plugin.config.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	id = strings.ToLower(id)
+
+	var manifest *model.Manifest
+	for _, p := range availablePlugins {
+		if p.Manifest.Id == id {
+			manifest = p.Manifest
+			break
+		}
+	}
+
+	if manifest == nil {
+		return model.NewAppError("ApprovePluginPermissions", "app.plugin.not_installed.app_error", nil, "", http.StatusNotFound)
+	}
+
+	a.ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
+		var enable bool
+		if state, ok := cfg.PluginSettings.PluginStates[id]; ok {
+			enable = state.Enable
+		}
+		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: enable, ApprovedPermissions: manifest.RequestedPermissions()}
+	})
+
+	if _, _, err := a.ch.cfgSvc.SaveConfig(a.ch.cfgSvc.Config(), true); err != nil {
+		if err.Id == "ent.cluster.save_config.error" {
+			return model.NewAppError("ApprovePluginPermissions", "app.plugin.cluster.save_config.app_error", nil, "", http.StatusInternalServerError)
+		}
+		return model.NewAppError("ApprovePluginPermissions", "app.plugin.config.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// pendingPluginPermissions returns the permissions requested by the manifest which the system
+// admin hasn't approved yet.
+func (ch *Channels) pendingPluginPermissions(manifest *model.Manifest) []string {
+	if ch.isPrepackagedPlugin(manifest) {
+		return nil
+	}
+
+	var approved []string
+	if state, ok := ch.cfgSvc.Config().PluginSettings.PluginStates[manifest.Id]; ok {
+		approved = state.ApprovedPermissions
+	}
+
+	var pending []string
+	for _, permission := range manifest.RequestedPermissions() {
+		if !slices.Contains(approved, permission) {
+			pending = append(pending, permission)
+		}
+	}
+	return pending
+}
+
+// isPrepackagedPlugin returns whether the given manifest is the one of a plugin prepackaged with
+// the server.
+func (ch *Channels) isPrepackagedPlugin(manifest *model.Manifest) bool {
+	pluginsEnvironment := ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return false
+	}
+
+	for _, p := range pluginsEnvironment.PrepackagedPlugins() {
+		if p.Manifest.Id == manifest.Id && p.Manifest.Version == manifest.Version {
+			return true
+		}
+	}
+	return false
+}
+
 // DisablePlugin will set the config for an installed plugin to disabled, triggering deactivation if active.
 // Notifies cluster peers through config change.
 func (a *App) DisablePlugin(id string) *model.AppError {
@@ -495,6 +600,9 @@ func (ch *Channels) disablePlugin(id string) *model.AppError {
 		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: false}
 	})
 	ch.unregisterPluginCommands(id)
+	ch.unregisterPluginJobTypes(id)
+	ch.unregisterPluginDocumentExtractor(id)
+	ch.unregisterPluginSearchEngine(id)
 
 	// This call will implicitly invoke SyncPluginsActiveState which will deactivate disabled plugins.
 	if _, _, err := ch.cfgSvc.SaveConfig(ch.cfgSvc.Config(), true); err != nil {
@@ -521,7 +629,8 @@ func (a *App) GetPlugins() (*model.PluginsResponse, *model.AppError) {
 		}
 
 		info := &model.PluginInfo{
-			Manifest: *plugin.Manifest,
+			Manifest:           *plugin.Manifest,
+			PendingPermissions: a.ch.pendingPluginPermissions(plugin.Manifest),
 		}
 
 		if pluginsEnvironment.IsActive(plugin.Manifest.Id) {
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -47,6 +48,35 @@ func (api *PluginAPI) checkLDAPLicense() error {
 	return nil
 }
 
+// checkPermission returns a permission error if the plugin calls an API method covered by a
+// permission it didn't request in its manifest, or that the system admin didn't approve. Plugins
+// declaring no permissions request all of them, unless PluginSettings.RequirePluginPermissions is
+// set, and the permissions of the prepackaged plugins are approved by shipping them with the server.
+func (api *PluginAPI) checkPermission(method, permission string) *model.AppError {
+	cfg := api.app.Config()
+	requested := len(api.manifest.Permissions) > 0 || !*cfg.PluginSettings.RequirePluginPermissions
+
+	if requested && slices.Contains(api.manifest.RequestedPermissions(), permission) {
+		if state, ok := cfg.PluginSettings.PluginStates[api.id]; ok && slices.Contains(state.ApprovedPermissions, permission) {
+			return nil
+		}
+		if api.app.ch.isPrepackagedPlugin(api.manifest) {
+			return nil
+		}
+	}
+
+	appErr := model.NewAppError(method, "app.plugin.api.permission_denied.app_error", map[string]any{"Method": method, "Permission": permission}, "", http.StatusForbidden)
+
+	api.logger.Warn("Plugin API call denied for a missing permission", mlog.String("method", method), mlog.String("permission", permission))
+	auditRec := api.app.MakeAuditRecord(api.ctx, model.AuditEventPluginAPICallDenied, model.AuditStatusFail)
+	model.AddEventParameterToAuditRec(auditRec, "plugin_id", api.id)
+	model.AddEventParameterToAuditRec(auditRec, "method", method)
+	model.AddEventParameterToAuditRec(auditRec, "permission", permission)
+	api.app.LogAuditRec(api.ctx, auditRec, appErr)
+
+	return appErr
+}
+
 func (api *PluginAPI) LoadPluginConfiguration(dest any) error {
 	finalConfig := make(map[string]any)
 
@@ -591,7 +621,7 @@ func (api *PluginAPI) SearchPostsInTeamForUser(teamID string, userID string, sea
 		includeDeletedChannels = *searchParams.IncludeDeletedChannels
 	}
 
-	results, appErr := api.app.SearchPostsForUser(api.ctx, terms, userID, teamID, isOrSearch, includeDeletedChannels, timeZoneOffset, page, perPage)
+	results, appErr := api.app.SearchPostsForUser(api.ctx, terms, userID, teamID, isOrSearch, includeDeletedChannels, timeZoneOffset, 0, page, perPage)
 	if results != nil {
 		results = results.ForPlugin()
 	}
@@ -1089,6 +1119,22 @@ func (api *PluginAPI) KVList(page, perPage int) ([]string, *model.AppError) {
 	return api.app.ListPluginKeys(api.id, page, perPage)
 }
 
+func (api *PluginAPI) KVListWithValues(prefix string, page, perPage int) ([]*model.PluginKeyValue, *model.AppError) {
+	return api.app.ListPluginKeyValues(api.id, prefix, page, perPage)
+}
+
+func (api *PluginAPI) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	return api.app.GetPluginKeys(api.id, keys)
+}
+
+func (api *PluginAPI) KVSetMany(values map[string][]byte, expireInSeconds int64) *model.AppError {
+	return api.app.SetPluginKeys(api.id, values, expireInSeconds)
+}
+
+func (api *PluginAPI) KVCompareAndSwapMany(operations []*model.PluginKVCompareAndSwap) (bool, *model.AppError) {
+	return api.app.CompareAndSwapPluginKeys(api.id, operations)
+}
+
 func (api *PluginAPI) PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast) {
 	ev := model.NewWebSocketEvent(model.WebsocketEventType(fmt.Sprintf("custom_%v_%v", api.id, event)), "", "", "", nil, "")
 	ev = ev.SetBroadcast(broadcast).SetData(payload)
@@ -1585,3 +1631,66 @@ func (api *PluginAPI) DeletePropertyValuesForTarget(groupID, targetType, targetI
 func (api *PluginAPI) DeletePropertyValuesForField(groupID, fieldID string) error {
 	return api.app.PropertyService().DeletePropertyValuesForField(groupID, fieldID)
 }
+
+func (api *PluginAPI) RegisterJobType(jobType string, options *model.PluginJobTypeOptions) *model.AppError {
+	return api.app.RegisterPluginJobType(api.id, jobType, options)
+}
+
+func (api *PluginAPI) UnregisterJobType(jobType string) *model.AppError {
+	return api.app.UnregisterPluginJobType(api.id, jobType)
+}
+
+func (api *PluginAPI) CreateJob(jobType string, data map[string]string) (*model.Job, *model.AppError) {
+	if !api.app.isPluginJobTypeOwner(api.id, jobType) {
+		return nil, model.NewAppError("CreateJob", "app.plugin.job_type.not_found.app_error", nil, "job_type="+jobType, http.StatusNotFound)
+	}
+
+	return api.app.Srv().Jobs.CreateJob(api.ctx, jobType, data)
+}
+
+func (api *PluginAPI) GetJob(jobID string) (*model.Job, *model.AppError) {
+	job, appErr := api.app.GetJob(api.ctx, jobID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Plugins only get to see the jobs of the types they registered.
+	if !api.app.isPluginJobTypeOwner(api.id, job.Type) {
+		return nil, model.NewAppError("GetJob", "app.job.get.app_error", nil, "", http.StatusNotFound)
+	}
+
+	return job, nil
+}
+
+func (api *PluginAPI) UpdateJobProgress(jobID string, progress int64) *model.AppError {
+	if progress < 0 || progress > 100 {
+		return model.NewAppError("UpdateJobProgress", "app.plugin.update_job_progress.invalid_progress.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	job, appErr := api.GetJob(jobID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if job.Status != model.JobStatusInProgress {
+		return model.NewAppError("UpdateJobProgress", "app.plugin.update_job_progress.not_in_progress.app_error", nil, "status="+job.Status, http.StatusBadRequest)
+	}
+
+	return api.app.Srv().Jobs.SetJobProgress(job, progress)
+}
+
+func (api *PluginAPI) RegisterDocumentExtractor(extractor *model.PluginDocumentExtractor) *model.AppError {
+	return api.app.RegisterPluginDocumentExtractor(api.id, extractor)
+}
+
+func (api *PluginAPI) UnregisterDocumentExtractor() *model.AppError {
+	return api.app.UnregisterPluginDocumentExtractor(api.id)
+}
+
+func (api *PluginAPI) RegisterSearchEngine() *model.AppError {
+	return api.app.RegisterPluginSearchEngine(api.id)
+}
+
+func (api *PluginAPI) UnregisterSearchEngine() *model.AppError {
+	return api.app.UnregisterPluginSearchEngine(api.id)
+}
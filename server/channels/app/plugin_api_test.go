@@ -109,6 +109,7 @@ func setupMultiPluginAPITest(t *testing.T, pluginCodes []string, pluginManifests
 
 		err := os.WriteFile(filepath.Join(pluginDir, pluginID, "plugin.json"), []byte(pluginManifests[i]), 0600)
 		require.NoError(t, err)
+		approveAllPluginPermissions(app, pluginID)
 		manifest, activated, reterr := env.Activate(pluginID)
 		require.NoError(t, reterr)
 		require.NotNil(t, manifest)
@@ -116,7 +117,8 @@ func setupMultiPluginAPITest(t *testing.T, pluginCodes []string, pluginManifests
 
 		app.UpdateConfig(func(cfg *model.Config) {
 			cfg.PluginSettings.PluginStates[pluginID] = &model.PluginState{
-				Enable: true,
+				Enable:              true,
+				ApprovedPermissions: model.AllPluginPermissions,
 			}
 		})
 	}
@@ -1083,6 +1085,7 @@ func TestInstallPlugin(t *testing.T) {
 
 		err = os.WriteFile(filepath.Join(pluginDir, pluginID, "plugin.json"), []byte(pluginManifest), 0600)
 		require.NoError(t, err)
+		approveAllPluginPermissions(app, pluginID)
 		manifest, activated, reterr := env.Activate(pluginID)
 		require.NoError(t, reterr)
 		require.NotNil(t, manifest)
@@ -1627,6 +1630,87 @@ func TestPluginAPIGetUnsanitizedConfig(t *testing.T) {
 	}
 }
 
+func TestPluginAPIPermissions(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	manifest := &model.Manifest{
+		Id:          "pluginid",
+		Permissions: []string{model.PluginPermissionReadPosts, model.PluginPermissionReadUsers},
+	}
+	api := th.App.NewPluginAPI(th.Context, manifest)
+
+	t.Run("permissions not approved", func(t *testing.T) {
+		post, appErr := api.GetPost(th.BasicPost.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.api.permission_denied.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+		assert.Nil(t, post)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		cfg.PluginSettings.PluginStates["pluginid"] = &model.PluginState{Enable: true, ApprovedPermissions: []string{model.PluginPermissionReadPosts}}
+	})
+
+	t.Run("approved permission", func(t *testing.T) {
+		post, appErr := api.GetPost(th.BasicPost.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicPost.Id, post.Id)
+	})
+
+	t.Run("declared permission not approved", func(t *testing.T) {
+		_, appErr := api.GetUser(th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.api.permission_denied.app_error", appErr.Id)
+	})
+
+	t.Run("undeclared permission", func(t *testing.T) {
+		_, appErr := api.UpdateUserRoles(th.BasicUser.Id, model.SystemAdminRoleId)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.plugin.api.permission_denied.app_error", appErr.Id)
+		assert.Nil(t, api.GetConfig())
+	})
+
+	t.Run("pending permissions", func(t *testing.T) {
+		assert.Equal(t, []string{model.PluginPermissionReadUsers}, th.App.ch.pendingPluginPermissions(manifest))
+		assert.Equal(t, model.AllPluginPermissions, th.App.ch.pendingPluginPermissions(&model.Manifest{Id: "legacyid"}), "plugins declaring no permissions request all of them")
+	})
+
+	t.Run("methods only concerning the plugin", func(t *testing.T) {
+		assert.Nil(t, api.KVSet("key", []byte("value")))
+		assert.Equal(t, "pluginid", api.GetPluginID())
+	})
+
+	t.Run("sensitive methods", func(t *testing.T) {
+		_, appErr := api.GetSession(model.NewId())
+		require.NotNil(t, appErr, "sessions require managing users")
+		assert.Equal(t, "app.plugin.api.permission_denied.app_error", appErr.Id)
+
+		_, err := api.ExecuteSlashCommand(&model.CommandArgs{UserId: th.BasicUser.Id, Command: "/echo hello"})
+		require.Error(t, err, "executing commands requires managing users")
+
+		resp := api.PluginHTTP(httptest.NewRequest(http.MethodGet, "/otherplugin/path", nil))
+		require.NotNil(t, resp)
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode, "calling other plugins requires a permission")
+	})
+
+	t.Run("plugin without permissions", func(t *testing.T) {
+		legacyAPI := th.App.NewPluginAPI(th.Context, &model.Manifest{Id: "legacyid"})
+		assert.Nil(t, legacyAPI.GetConfig(), "full access requires the approval of the system admin")
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			cfg.PluginSettings.PluginStates["legacyid"] = &model.PluginState{ApprovedPermissions: model.AllPluginPermissions}
+		})
+		assert.NotNil(t, legacyAPI.GetConfig())
+
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.PluginSettings.RequirePluginPermissions = true
+		})
+		assert.Nil(t, legacyAPI.GetConfig())
+	})
+}
+
 func TestPluginAddUserToChannel(t *testing.T) {
 	mainHelper.Parallel(t)
 	th := Setup(t).InitBasic()
@@ -1826,6 +1910,7 @@ func TestAPIMetrics(t *testing.T) {
 		// Setup mocks
 		metricsMock.On("ObservePluginAPIDuration", pluginID, "UpdateUser", true, mock.Anything).Return()
 
+		approveAllPluginPermissions(th.App, pluginID)
 		_, _, activationErr := env.Activate(pluginID)
 		require.NoError(t, activationErr)
 
@@ -2352,6 +2437,7 @@ func TestPluginUploadsAPI(t *testing.T) {
 
 	err = os.WriteFile(filepath.Join(pluginDir, pluginID, "plugin.json"), []byte(pluginManifest), 0600)
 	require.NoError(t, err)
+	approveAllPluginPermissions(th.App, pluginID)
 	manifest, activated, reterr := env.Activate(pluginID)
 	require.NoError(t, reterr)
 	require.NotNil(t, manifest)
@@ -2393,6 +2479,7 @@ func TestConfigurationWillBeSavedHook(t *testing.T) {
 
 		err = os.WriteFile(filepath.Join(pluginDir, pluginID, "plugin.json"), []byte(pluginManifest), 0600)
 		require.NoError(t, err)
+		approveAllPluginPermissions(th.App, pluginID)
 		manifest, activated, reterr := env.Activate(pluginID)
 		require.NoError(t, reterr)
 		require.NotNil(t, manifest)
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package main
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/v8/channels/app/plugin_api_tests"
+)
+
+type MyPlugin struct {
+	plugin.MattermostPlugin
+	configuration plugin_api_tests.BasicConfig
+	updated       chan [2]*model.Channel
+}
+
+func (p *MyPlugin) OnConfigurationChange() error {
+	if err := p.API.LoadPluginConfiguration(&p.configuration); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *MyPlugin) ChannelWillBeArchived(_ *plugin.Context, channel *model.Channel) string {
+	if channel.Id == p.configuration.BasicChannelID {
+		return "channel is regulated"
+	}
+	return ""
+}
+
+func (p *MyPlugin) ChannelHasBeenUpdated(_ *plugin.Context, newChannel, oldChannel *model.Channel) {
+	p.updated <- [2]*model.Channel{newChannel, oldChannel}
+}
+
+func (p *MyPlugin) MessageWillBePosted(_ *plugin.Context, _ *model.Post) (*model.Post, string) {
+	if appErr := p.API.DeleteChannel(p.configuration.BasicChannelID); appErr == nil {
+		return nil, "archiving a regulated channel should have been rejected"
+	}
+
+	channel, appErr := p.API.GetChannel(p.configuration.BasicChannelID)
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if channel.DeleteAt != 0 {
+		return nil, "regulated channel was archived"
+	}
+
+	oldHeader := channel.Header
+	channel.Header = "updated header"
+	if _, appErr = p.API.UpdateChannel(channel); appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	select {
+	case channels := <-p.updated:
+		if channels[0].Header != "updated header" || channels[1].Header != oldHeader {
+			return nil, "ChannelHasBeenUpdated received the wrong channels"
+		}
+	case <-time.After(10 * time.Second):
+		return nil, "ChannelHasBeenUpdated was not invoked"
+	}
+
+	other, appErr := p.API.CreateChannel(&model.Channel{
+		TeamId:      p.configuration.BasicTeamID,
+		Name:        model.NewId(),
+		DisplayName: "Unregulated",
+		Type:        model.ChannelTypeOpen,
+	})
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if appErr = p.API.DeleteChannel(other.Id); appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	return nil, "OK"
+}
+
+func main() {
+	plugin.ClientMain(&MyPlugin{updated: make(chan [2]*model.Channel, 1)})
+}
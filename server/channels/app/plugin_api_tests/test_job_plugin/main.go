@@ -0,0 +1,76 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package main
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/v8/channels/app/plugin_api_tests"
+)
+
+const jobType = "plugin_test_job"
+
+type MyPlugin struct {
+	plugin.MattermostPlugin
+	configuration plugin_api_tests.BasicConfig
+}
+
+func (p *MyPlugin) OnConfigurationChange() error {
+	if err := p.API.LoadPluginConfiguration(&p.configuration); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *MyPlugin) ExecuteJob(_ *plugin.Context, _ *model.Job) error {
+	return nil
+}
+
+func (p *MyPlugin) MessageWillBePosted(_ *plugin.Context, _ *model.Post) (*model.Post, string) {
+	if appErr := p.API.RegisterJobType("test_job", nil); appErr == nil {
+		return nil, "RegisterJobType should have rejected a job type without the plugin prefix"
+	}
+
+	if appErr := p.API.RegisterJobType(jobType, &model.PluginJobTypeOptions{IntervalSeconds: 3600}); appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	job, appErr := p.API.CreateJob(jobType, map[string]string{"key": "value"})
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if job.Type != jobType || job.Status != model.JobStatusPending || job.Data["key"] != "value" {
+		return nil, "CreateJob returned an unexpected job"
+	}
+
+	fetched, appErr := p.API.GetJob(job.Id)
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if fetched.Id != job.Id {
+		return nil, "GetJob returned the wrong job"
+	}
+
+	if appErr = p.API.UpdateJobProgress(job.Id, 50); appErr == nil {
+		return nil, "UpdateJobProgress should have rejected a pending job"
+	}
+
+	if appErr = p.API.UnregisterJobType(jobType); appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	if _, appErr = p.API.CreateJob(jobType, nil); appErr == nil {
+		return nil, "CreateJob should have rejected an unregistered job type"
+	}
+
+	if _, appErr = p.API.GetJob(job.Id); appErr == nil {
+		return nil, "GetJob should have rejected a job of an unregistered job type"
+	}
+
+	return nil, "OK"
+}
+
+func main() {
+	plugin.ClientMain(&MyPlugin{})
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package main
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/v8/channels/app/plugin_api_tests"
+)
+
+type MyPlugin struct {
+	plugin.MattermostPlugin
+	configuration plugin_api_tests.BasicConfig
+}
+
+func (p *MyPlugin) OnConfigurationChange() error {
+	if err := p.API.LoadPluginConfiguration(&p.configuration); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *MyPlugin) MessageWillBeDeleted(_ *plugin.Context, post *model.Post) string {
+	if post.Id == p.configuration.BasicPostID {
+		return "post is under review"
+	}
+	return ""
+}
+
+func (p *MyPlugin) MessageWillBePosted(_ *plugin.Context, post *model.Post) (*model.Post, string) {
+	// Let through the posts created by this test.
+	if post != nil {
+		return post, ""
+	}
+
+	if appErr := p.API.DeletePost(p.configuration.BasicPostID); appErr == nil {
+		return nil, "deleting a post under review should have been rejected"
+	}
+
+	post, appErr := p.API.GetPost(p.configuration.BasicPostID)
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if post.DeleteAt != 0 {
+		return nil, "post under review was deleted"
+	}
+
+	other, appErr := p.API.CreatePost(&model.Post{
+		UserId:    p.configuration.BasicUserID,
+		ChannelId: p.configuration.BasicChannelID,
+		Message:   "not under review",
+	})
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if appErr = p.API.DeletePost(other.Id); appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	return nil, "OK"
+}
+
+func main() {
+	plugin.ClientMain(&MyPlugin{})
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package main
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/v8/channels/app/plugin_api_tests"
+)
+
+type MyPlugin struct {
+	plugin.MattermostPlugin
+	configuration plugin_api_tests.BasicConfig
+}
+
+func (p *MyPlugin) OnConfigurationChange() error {
+	if err := p.API.LoadPluginConfiguration(&p.configuration); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *MyPlugin) ExtractDocumentText(_ *plugin.Context, _ *model.FileInfo, content []byte) (string, error) {
+	return string(content), nil
+}
+
+func (p *MyPlugin) SearchEngineSearchPosts(_ *plugin.Context, _ model.ChannelList, _ []*model.SearchParams, _, _ int) ([]string, model.PostSearchMatches, error) {
+	return []string{}, model.PostSearchMatches{}, nil
+}
+
+func (p *MyPlugin) MessageWillBePosted(_ *plugin.Context, _ *model.Post) (*model.Post, string) {
+	if appErr := p.API.RegisterDocumentExtractor(&model.PluginDocumentExtractor{Extensions: []string{"dwg"}}); appErr == nil {
+		return nil, "RegisterDocumentExtractor should have rejected an extension without a period"
+	}
+
+	if appErr := p.API.RegisterDocumentExtractor(&model.PluginDocumentExtractor{
+		Extensions: []string{".dwg"},
+		MimeTypes:  []string{"image/vnd.dwg"},
+	}); appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	if appErr := p.API.UnregisterDocumentExtractor(); appErr != nil {
+		return nil, appErr.Error()
+	}
+	if appErr := p.API.UnregisterDocumentExtractor(); appErr == nil {
+		return nil, "UnregisterDocumentExtractor should have failed without a registered extractor"
+	}
+
+	if appErr := p.API.RegisterSearchEngine(); appErr != nil {
+		return nil, appErr.Error()
+	}
+	// Registering again is a no-op.
+	if appErr := p.API.RegisterSearchEngine(); appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	if appErr := p.API.UnregisterSearchEngine(); appErr != nil {
+		return nil, appErr.Error()
+	}
+	if appErr := p.API.UnregisterSearchEngine(); appErr == nil {
+		return nil, "UnregisterSearchEngine should have failed without a registered search engine"
+	}
+
+	return nil, "OK"
+}
+
+func main() {
+	plugin.ClientMain(&MyPlugin{})
+}
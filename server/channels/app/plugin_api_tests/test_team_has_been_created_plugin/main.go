@@ -0,0 +1,55 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package main
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/v8/channels/app/plugin_api_tests"
+)
+
+type MyPlugin struct {
+	plugin.MattermostPlugin
+	configuration plugin_api_tests.BasicConfig
+	created       chan *model.Team
+}
+
+func (p *MyPlugin) OnConfigurationChange() error {
+	if err := p.API.LoadPluginConfiguration(&p.configuration); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *MyPlugin) TeamHasBeenCreated(_ *plugin.Context, team *model.Team) {
+	p.created <- team
+}
+
+func (p *MyPlugin) MessageWillBePosted(_ *plugin.Context, _ *model.Post) (*model.Post, string) {
+	team, appErr := p.API.CreateTeam(&model.Team{
+		Name:        "governed-" + model.NewId()[:10],
+		DisplayName: "Governed",
+		Type:        model.TeamOpen,
+	})
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	select {
+	case created := <-p.created:
+		if created.Id != team.Id {
+			return nil, "TeamHasBeenCreated received the wrong team"
+		}
+	case <-time.After(10 * time.Second):
+		return nil, "TeamHasBeenCreated was not invoked"
+	}
+
+	return nil, "OK"
+}
+
+func main() {
+	plugin.ClientMain(&MyPlugin{created: make(chan *model.Team, 1)})
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package main
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/v8/channels/app/plugin_api_tests"
+)
+
+type MyPlugin struct {
+	plugin.MattermostPlugin
+	configuration plugin_api_tests.BasicConfig
+}
+
+func (p *MyPlugin) OnConfigurationChange() error {
+	if err := p.API.LoadPluginConfiguration(&p.configuration); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *MyPlugin) UserWillBeUpdated(_ *plugin.Context, newUser, oldUser *model.User) (*model.User, string) {
+	if newUser.Password != "" || oldUser.Password != "" {
+		return nil, "the password was passed to the hook"
+	}
+	if newUser.Nickname == "blocked" {
+		return nil, "nickname is not allowed"
+	}
+	if newUser.Position == oldUser.Position {
+		return nil, ""
+	}
+	newUser.Position = "reviewed " + newUser.Position
+	newUser.Id = model.NewId()
+	return newUser, ""
+}
+
+func (p *MyPlugin) MessageWillBePosted(_ *plugin.Context, _ *model.Post) (*model.Post, string) {
+	user, appErr := p.API.GetUser(p.configuration.BasicUserID)
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+
+	user.Nickname = "blocked"
+	if _, appErr = p.API.UpdateUser(user); appErr == nil {
+		return nil, "the update should have been rejected"
+	}
+
+	user, appErr = p.API.GetUser(p.configuration.BasicUserID)
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if user.Nickname == "blocked" {
+		return nil, "rejected update was saved"
+	}
+
+	user.Position = "engineer"
+	updated, appErr := p.API.UpdateUser(user)
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if updated.Id != p.configuration.BasicUserID {
+		return nil, "the user id was changed by the hook"
+	}
+	if updated.Position != "reviewed engineer" {
+		return nil, "the update was not modified by the hook"
+	}
+
+	updated.Nickname = "allowed"
+	updated, appErr = p.API.UpdateUser(updated)
+	if appErr != nil {
+		return nil, appErr.Error()
+	}
+	if updated.Nickname != "allowed" || updated.Position != "reviewed engineer" {
+		return nil, "the update allowed by the hook was not saved"
+	}
+
+	return nil, "OK"
+}
+
+func main() {
+	plugin.ClientMain(&MyPlugin{})
+}
@@ -57,13 +57,15 @@ func setAppEnvironmentWithPlugins(t *testing.T, pluginCode []string, app *App, a
 
 		err = os.WriteFile(filepath.Join(pluginDir, pluginID, "plugin.json"), []byte(`{"id": "`+pluginID+`", "server": {"executable": "backend.exe"}}`), 0600)
 		require.NoError(t, err)
+		approveAllPluginPermissions(app, pluginID)
 		_, _, activationErr := env.Activate(pluginID)
 		pluginIDs = append(pluginIDs, pluginID)
 		activationErrors = append(activationErrors, activationErr)
 
 		app.UpdateConfig(func(cfg *model.Config) {
 			cfg.PluginSettings.PluginStates[pluginID] = &model.PluginState{
-				Enable: true,
+				Enable:              true,
+				ApprovedPermissions: model.AllPluginPermissions,
 			}
 		})
 	}
@@ -1223,12 +1225,14 @@ func TestHookMetrics(t *testing.T) {
 		metricsMock.On("ObservePluginMultiHookIterationDuration", mock.Anything, mock.Anything, mock.Anything).Return()
 		metricsMock.On("ObservePluginMultiHookDuration", mock.Anything).Return()
 
+		approveAllPluginPermissions(th.App, pluginID)
 		_, _, activationErr := env.Activate(pluginID)
 		require.NoError(t, activationErr)
 
 		th.App.UpdateConfig(func(cfg *model.Config) {
 			cfg.PluginSettings.PluginStates[pluginID] = &model.PluginState{
-				Enable: true,
+				Enable:              true,
+				ApprovedPermissions: model.AllPluginPermissions,
 			}
 		})
 
@@ -1617,6 +1621,80 @@ func TestHookNotificationWillBePushed(t *testing.T) {
 	}
 }
 
+//go:embed test_templates/hook_email_notification_will_be_sent.tmpl
+var hookEmailNotificationWillBeSentTmpl string
+
+func TestHookEmailNotificationWillBeSent(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	tests := []struct {
+		name              string
+		testCode          string
+		expectedRejection string
+		expectedTo        string
+		expectedBody      string
+	}{
+		{
+			name:         "sent unmodified",
+			testCode:     `return nil, ""`,
+			expectedTo:   "recipient@example.com",
+			expectedBody: "message body",
+		},
+		{
+			name:              "rejected",
+			testCode:          `return nil, "rejected"`,
+			expectedRejection: "rejected",
+		},
+		{
+			name: "modified",
+			testCode: `if recipient.Password != "" || post.Message != "message" {
+		return nil, "unexpected recipient or post"
+	}
+	notification.To = "archive@example.com"
+	notification.Body += " disclaimer"
+	return notification, ""`,
+			expectedTo:   "archive@example.com",
+			expectedBody: "message body disclaimer",
+		},
+		{
+			name: "recipient removed",
+			testCode: `notification.To = ""
+	return notification, ""`,
+			expectedRejection: "removed the recipient address",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mainHelper.Parallel(t)
+
+			th := Setup(t)
+			defer th.TearDown()
+
+			templatedPlugin := fmt.Sprintf(hookEmailNotificationWillBeSentTmpl, tt.testCode)
+			tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{templatedPlugin}, th.App, th.NewPluginAPI)
+			defer tearDown()
+
+			recipient := &model.User{Id: model.NewId(), Email: "recipient@example.com", Password: "hash"}
+			post := &model.Post{Id: model.NewId(), Message: "message"}
+			emailNotification := &model.EmailNotification{
+				To:     recipient.Email,
+				Body:   "message body",
+				PostId: post.Id,
+			}
+
+			emailNotification, rejectionReason := th.App.runEmailNotificationWillBeSentHook(emailNotification, recipient, post)
+			if tt.expectedRejection != "" {
+				assert.Contains(t, rejectionReason, tt.expectedRejection)
+				return
+			}
+			require.Empty(t, rejectionReason)
+			assert.Equal(t, tt.expectedTo, emailNotification.To)
+			assert.Equal(t, tt.expectedBody, emailNotification.Body)
+			assert.Equal(t, "hash", recipient.Password)
+		})
+	}
+}
+
 func TestHookMessagesWillBeConsumed(t *testing.T) {
 	mainHelper.Parallel(t)
 
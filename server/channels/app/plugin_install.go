@@ -501,6 +501,11 @@ func (ch *Channels) installExtractedPlugin(manifest *model.Manifest, fromPluginD
 		manifest = updatedManifest
 	}
 
+	// New permissions, including those declared by an upgrade, must be approved by a system admin.
+	if pending := ch.pendingPluginPermissions(manifest); len(pending) > 0 {
+		logger.Warn("Plugin declares permissions a system admin must approve before the API calls requiring them are allowed", mlog.Array("pending_permissions", pending))
+	}
+
 	// Activate the plugin if enabled.
 	pluginState := ch.cfgSvc.Config().PluginSettings.PluginStates[manifest.Id]
 	if pluginState != nil && pluginState.Enable {
@@ -593,6 +598,9 @@ func (ch *Channels) removePluginLocally(id string) *model.AppError {
 	pluginsEnvironment.Deactivate(id)
 	pluginsEnvironment.RemovePlugin(id)
 	ch.unregisterPluginCommands(id)
+	ch.unregisterPluginJobTypes(id)
+	ch.unregisterPluginDocumentExtractor(id)
+	ch.unregisterPluginSearchEngine(id)
 
 	if err := os.RemoveAll(unpackedBundlePath); err != nil {
 		return model.NewAppError("removePlugin", "app.plugin.remove.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
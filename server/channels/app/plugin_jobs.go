@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugin_jobs"
+)
+
+type PluginJobType struct {
+	JobType  string
+	PluginId string
+	Options  model.PluginJobTypeOptions
+}
+
+// RegisterPluginJobType registers a job type with the job server on behalf of the given plugin,
+// or updates its options if the plugin already registered it.
+func (a *App) RegisterPluginJobType(pluginID, jobType string, options *model.PluginJobTypeOptions) *model.AppError {
+	if !model.IsValidPluginJobType(jobType) {
+		return model.NewAppError("RegisterPluginJobType", "app.plugin.register_job_type.invalid_type.app_error", map[string]any{"Prefix": model.PluginJobTypePrefix}, "job_type="+jobType, http.StatusBadRequest)
+	}
+	if options == nil {
+		options = &model.PluginJobTypeOptions{}
+	}
+	if appErr := options.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	a.ch.pluginJobTypesLock.Lock()
+	existing, ok := a.ch.pluginJobTypes[jobType]
+	if ok && existing.PluginId != pluginID {
+		a.ch.pluginJobTypesLock.Unlock()
+		return model.NewAppError("RegisterPluginJobType", "app.plugin.register_job_type.conflict.app_error", nil, "job_type="+jobType+", plugin_id="+existing.PluginId, http.StatusConflict)
+	}
+	if ok && existing.Options == *options {
+		a.ch.pluginJobTypesLock.Unlock()
+		return nil
+	}
+	if a.ch.pluginJobTypes == nil {
+		a.ch.pluginJobTypes = make(map[string]*PluginJobType)
+	}
+	a.ch.pluginJobTypes[jobType] = &PluginJobType{
+		JobType:  jobType,
+		PluginId: pluginID,
+		Options:  *options,
+	}
+	a.ch.pluginJobTypesLock.Unlock()
+
+	// Replace the worker and scheduler registered with the previous options.
+	if ok {
+		a.Srv().Jobs.UnregisterJobType(jobType)
+	}
+
+	var scheduler jobs.Scheduler
+	if options.IntervalSeconds > 0 {
+		scheduler = plugin_jobs.MakeScheduler(a.Srv().Jobs, jobType, pluginID, time.Duration(options.IntervalSeconds)*time.Second, a)
+	}
+	a.Srv().Jobs.RegisterJobType(jobType, plugin_jobs.MakeWorker(a.Srv().Jobs, jobType, pluginID, a), scheduler)
+
+	return nil
+}
+
+// UnregisterPluginJobType unregisters a job type previously registered by the given plugin.
+func (a *App) UnregisterPluginJobType(pluginID, jobType string) *model.AppError {
+	a.ch.pluginJobTypesLock.Lock()
+	existing, ok := a.ch.pluginJobTypes[jobType]
+	if !ok || existing.PluginId != pluginID {
+		a.ch.pluginJobTypesLock.Unlock()
+		return model.NewAppError("UnregisterPluginJobType", "app.plugin.job_type.not_found.app_error", nil, "job_type="+jobType, http.StatusNotFound)
+	}
+	delete(a.ch.pluginJobTypes, jobType)
+	a.ch.pluginJobTypesLock.Unlock()
+
+	a.Srv().Jobs.UnregisterJobType(jobType)
+
+	return nil
+}
+
+func (ch *Channels) unregisterPluginJobTypes(pluginID string) {
+	var jobTypes []string
+	ch.pluginJobTypesLock.Lock()
+	for jobType, pjt := range ch.pluginJobTypes {
+		if pjt.PluginId == pluginID {
+			jobTypes = append(jobTypes, jobType)
+			delete(ch.pluginJobTypes, jobType)
+		}
+	}
+	ch.pluginJobTypesLock.Unlock()
+
+	for _, jobType := range jobTypes {
+		ch.srv.Jobs.UnregisterJobType(jobType)
+	}
+}
+
+// GetPluginJobTypes returns the sorted job types currently registered by plugins.
+func (a *App) GetPluginJobTypes() []string {
+	a.ch.pluginJobTypesLock.RLock()
+	defer a.ch.pluginJobTypesLock.RUnlock()
+
+	jobTypes := make([]string, 0, len(a.ch.pluginJobTypes))
+	for jobType := range a.ch.pluginJobTypes {
+		jobTypes = append(jobTypes, jobType)
+	}
+	sort.Strings(jobTypes)
+
+	return jobTypes
+}
+
+// IsPluginJobType reports whether the given job type is currently registered by a plugin.
+func (a *App) IsPluginJobType(jobType string) bool {
+	a.ch.pluginJobTypesLock.RLock()
+	defer a.ch.pluginJobTypesLock.RUnlock()
+
+	_, ok := a.ch.pluginJobTypes[jobType]
+	return ok
+}
+
+func (a *App) isPluginJobTypeOwner(pluginID, jobType string) bool {
+	a.ch.pluginJobTypesLock.RLock()
+	defer a.ch.pluginJobTypesLock.RUnlock()
+
+	pjt, ok := a.ch.pluginJobTypes[jobType]
+	return ok && pjt.PluginId == pluginID
+}
+
+// ExecutePluginJob runs the given job through the ExecuteJob hook of the plugin that registered its type.
+func (a *App) ExecutePluginJob(c request.CTX, pluginID string, job *model.Job) error {
+	pluginsEnvironment := a.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return errors.New("plugins are disabled")
+	}
+
+	hooks, err := pluginsEnvironment.HooksForPlugin(pluginID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get hooks for plugin")
+	}
+
+	implemented, err := hooks.Implemented()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the hooks implemented by the plugin")
+	}
+	if !slices.Contains(implemented, "ExecuteJob") {
+		return errors.Errorf("plugin %s does not implement the ExecuteJob hook", pluginID)
+	}
+
+	if err := hooks.ExecuteJob(pluginContext(c), job); err != nil {
+		return err
+	}
+
+	// Checking if plugin crashed while running the job
+	if err := pluginsEnvironment.PerformHealthCheck(pluginID); err != nil {
+		return errors.Wrapf(err, "plugin %s crashed while running the job", pluginID)
+	}
+
+	return nil
+}
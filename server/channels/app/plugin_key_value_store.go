@@ -124,3 +124,65 @@ func (a *App) DeleteAllExpiredPluginKeys() *model.AppError {
 func (a *App) ListPluginKeys(pluginID string, page, perPage int) ([]string, *model.AppError) {
 	return a.Srv().Platform().ListPluginKeys(pluginID, page, perPage)
 }
+
+func (a *App) ListPluginKeyValues(pluginID, prefix string, page, perPage int) ([]*model.PluginKeyValue, *model.AppError) {
+	kvs, err := a.Srv().Store().Plugin().ListWithValues(pluginID, prefix, page*perPage, perPage)
+	if err != nil {
+		mlog.Error("Failed to list plugin key values", mlog.String("plugin_id", pluginID), mlog.Int("page", page), mlog.Int("perPage", perPage), mlog.Err(err))
+		return nil, model.NewAppError("ListPluginKeyValues", "app.plugin_store.list.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return kvs, nil
+}
+
+func (a *App) GetPluginKeys(pluginID string, keys []string) (map[string][]byte, *model.AppError) {
+	kvs, err := a.Srv().Store().Plugin().GetMany(pluginID, keys)
+	if err != nil {
+		mlog.Error("Failed to query plugin key values", mlog.String("plugin_id", pluginID), mlog.Err(err))
+		return nil, model.NewAppError("GetPluginKeys", "app.plugin_store.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	values := make(map[string][]byte, len(kvs))
+	for _, kv := range kvs {
+		values[kv.Key] = kv.Value
+	}
+
+	return values, nil
+}
+
+func (a *App) SetPluginKeys(pluginID string, values map[string][]byte, expireInSeconds int64) *model.AppError {
+	kvs := make([]*model.PluginKeyValue, 0, len(values))
+	for key, value := range values {
+		kv, _ := model.NewPluginKeyValueFromOptions(pluginID, key, value, model.PluginKVSetOptions{ExpireInSeconds: expireInSeconds})
+		kvs = append(kvs, kv)
+	}
+
+	if err := a.Srv().Store().Plugin().SetMany(kvs); err != nil {
+		mlog.Error("Failed to set plugin key values", mlog.String("plugin_id", pluginID), mlog.Err(err))
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return appErr
+		default:
+			return model.NewAppError("SetPluginKeys", "app.plugin_store.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+func (a *App) CompareAndSwapPluginKeys(pluginID string, operations []*model.PluginKVCompareAndSwap) (bool, *model.AppError) {
+	swapped, err := a.Srv().Store().Plugin().CompareAndSwapMany(pluginID, operations)
+	if err != nil {
+		mlog.Error("Failed to compare and swap plugin key values", mlog.String("plugin_id", pluginID), mlog.Err(err))
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return false, appErr
+		default:
+			return false, model.NewAppError("CompareAndSwapPluginKeys", "app.plugin_store.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return swapped, nil
+}
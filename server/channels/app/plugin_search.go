@@ -0,0 +1,412 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/services/docextractor"
+	"github.com/mattermost/mattermost/server/v8/platform/services/searchengine"
+)
+
+// runPluginHook runs the given hook of a plugin, returning an error if the plugin is not active,
+// doesn't implement the hook or crashed while running it. The RPC hooks return zero values when
+// the plugin crashes, which callers relying on the returned error to fall back can't tell apart
+// from a successful call otherwise.
+func (ch *Channels) runPluginHook(pluginID string, hookID int, fn func(hooks plugin.Hooks) error) error {
+	pluginsEnvironment := ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return errors.New("plugins are disabled")
+	}
+
+	if !pluginsEnvironment.PluginImplementsHook(pluginID, hookID) {
+		return errors.Errorf("plugin %s is not active or does not implement the hook", pluginID)
+	}
+
+	hooks, err := pluginsEnvironment.HooksForPlugin(pluginID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get hooks for plugin")
+	}
+
+	if err := fn(hooks); err != nil {
+		return err
+	}
+
+	if err := pluginsEnvironment.PerformHealthCheck(pluginID); err != nil {
+		return errors.Wrapf(err, "plugin %s crashed while running the hook", pluginID)
+	}
+
+	return nil
+}
+
+type PluginDocumentExtractor struct {
+	PluginId  string
+	Extractor model.PluginDocumentExtractor
+}
+
+// RegisterPluginDocumentExtractor registers the given plugin to extract the text content of the
+// files matched by the extractor, replacing its previous registration if any.
+func (a *App) RegisterPluginDocumentExtractor(pluginID string, extractor *model.PluginDocumentExtractor) *model.AppError {
+	if extractor == nil {
+		return model.NewAppError("RegisterPluginDocumentExtractor", "model.plugin_document_extractor.is_valid.empty.app_error", nil, "", http.StatusBadRequest)
+	}
+	if appErr := extractor.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	a.ch.pluginDocumentExtractorsLock.Lock()
+	defer a.ch.pluginDocumentExtractorsLock.Unlock()
+
+	if a.ch.pluginDocumentExtractors == nil {
+		a.ch.pluginDocumentExtractors = make(map[string]*PluginDocumentExtractor)
+	}
+	a.ch.pluginDocumentExtractors[pluginID] = &PluginDocumentExtractor{
+		PluginId: pluginID,
+		Extractor: model.PluginDocumentExtractor{
+			Extensions: append([]string(nil), extractor.Extensions...),
+			MimeTypes:  append([]string(nil), extractor.MimeTypes...),
+		},
+	}
+
+	return nil
+}
+
+// UnregisterPluginDocumentExtractor unregisters the document extractor of the given plugin.
+func (a *App) UnregisterPluginDocumentExtractor(pluginID string) *model.AppError {
+	a.ch.pluginDocumentExtractorsLock.Lock()
+	defer a.ch.pluginDocumentExtractorsLock.Unlock()
+
+	if _, ok := a.ch.pluginDocumentExtractors[pluginID]; !ok {
+		return model.NewAppError("UnregisterPluginDocumentExtractor", "app.plugin.document_extractor.not_found.app_error", nil, "plugin_id="+pluginID, http.StatusNotFound)
+	}
+	delete(a.ch.pluginDocumentExtractors, pluginID)
+
+	return nil
+}
+
+func (ch *Channels) unregisterPluginDocumentExtractor(pluginID string) {
+	ch.pluginDocumentExtractorsLock.Lock()
+	defer ch.pluginDocumentExtractorsLock.Unlock()
+
+	delete(ch.pluginDocumentExtractors, pluginID)
+}
+
+// pluginDocumentExtractorsFor returns the extractors registered by plugins, ordered by plugin
+// id, to be run ahead of the built-in ones when extracting the content of the given file.
+func (a *App) pluginDocumentExtractorsFor(rctx request.CTX, fileInfo *model.FileInfo) []docextractor.Extractor {
+	a.ch.pluginDocumentExtractorsLock.RLock()
+	defer a.ch.pluginDocumentExtractorsLock.RUnlock()
+
+	extractors := make([]docextractor.Extractor, 0, len(a.ch.pluginDocumentExtractors))
+	for _, pde := range a.ch.pluginDocumentExtractors {
+		extractors = append(extractors, &pluginDocumentExtractor{
+			ch:        a.ch,
+			rctx:      rctx,
+			pluginID:  pde.PluginId,
+			extractor: pde.Extractor,
+			fileInfo:  fileInfo,
+		})
+	}
+	sort.Slice(extractors, func(i, j int) bool {
+		return extractors[i].(*pluginDocumentExtractor).pluginID < extractors[j].(*pluginDocumentExtractor).pluginID
+	})
+
+	return extractors
+}
+
+// pluginDocumentExtractor extracts the text content of a file through the ExtractDocumentText
+// hook. Should the hook fail, the next matching extractor is used instead.
+type pluginDocumentExtractor struct {
+	ch        *Channels
+	rctx      request.CTX
+	pluginID  string
+	extractor model.PluginDocumentExtractor
+	// fileInfo is the file being extracted, as opposed to the files found within an archive.
+	fileInfo *model.FileInfo
+}
+
+func (pde *pluginDocumentExtractor) Name() string {
+	return "plugin:" + pde.pluginID
+}
+
+func (pde *pluginDocumentExtractor) fileInfoFor(filename string) *model.FileInfo {
+	if filename == pde.fileInfo.Name {
+		return pde.fileInfo
+	}
+
+	return &model.FileInfo{
+		Name:     filename,
+		MimeType: mime.TypeByExtension(filepath.Ext(filename)),
+	}
+}
+
+func (pde *pluginDocumentExtractor) Match(filename string) bool {
+	fileInfo := pde.fileInfoFor(filename)
+	return pde.extractor.Matches(fileInfo.Name, fileInfo.MimeType)
+}
+
+func (pde *pluginDocumentExtractor) Extract(filename string, r io.ReadSeeker) (string, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read the file content")
+	}
+
+	var text string
+	err = pde.ch.runPluginHook(pde.pluginID, plugin.ExtractDocumentTextID, func(hooks plugin.Hooks) error {
+		var hookErr error
+		text, hookErr = hooks.ExtractDocumentText(pluginContext(pde.rctx), pde.fileInfoFor(filename), content)
+		return hookErr
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return text, nil
+}
+
+// RegisterPluginSearchEngine registers the given plugin as the search engine for posts and
+// files. Only one plugin can provide a search engine at a time.
+func (a *App) RegisterPluginSearchEngine(pluginID string) *model.AppError {
+	a.ch.pluginSearchEngineLock.Lock()
+	defer a.ch.pluginSearchEngineLock.Unlock()
+
+	if current, ok := a.SearchEngine().GetPluginEngine().(*pluginSearchEngine); ok {
+		if current.pluginID == pluginID {
+			return nil
+		}
+		return model.NewAppError("RegisterPluginSearchEngine", "app.plugin.register_search_engine.conflict.app_error", nil, "plugin_id="+current.pluginID, http.StatusConflict)
+	}
+
+	a.SearchEngine().RegisterPluginEngine(&pluginSearchEngine{ch: a.ch, pluginID: pluginID})
+
+	return nil
+}
+
+// UnregisterPluginSearchEngine unregisters the search engine of the given plugin.
+func (a *App) UnregisterPluginSearchEngine(pluginID string) *model.AppError {
+	if !a.ch.unregisterPluginSearchEngine(pluginID) {
+		return model.NewAppError("UnregisterPluginSearchEngine", "app.plugin.search_engine.not_found.app_error", nil, "plugin_id="+pluginID, http.StatusNotFound)
+	}
+
+	return nil
+}
+
+func (ch *Channels) unregisterPluginSearchEngine(pluginID string) bool {
+	ch.pluginSearchEngineLock.Lock()
+	defer ch.pluginSearchEngineLock.Unlock()
+
+	current, ok := ch.srv.platform.SearchEngine.GetPluginEngine().(*pluginSearchEngine)
+	if !ok || current.pluginID != pluginID {
+		return false
+	}
+	ch.srv.platform.SearchEngine.UnregisterPluginEngine()
+
+	return true
+}
+
+// pluginSearchEngine is a search engine backed by a plugin through the SearchEngine* hooks. It
+// only handles posts and files, and is only active while the plugin is. Failing calls return an
+// error so the next engine, or the database, is used instead.
+type pluginSearchEngine struct {
+	ch       *Channels
+	pluginID string
+}
+
+var _ searchengine.SearchEngineInterface = (*pluginSearchEngine)(nil)
+
+func (pse *pluginSearchEngine) runHook(where string, hookID int, fn func(hooks plugin.Hooks) error) *model.AppError {
+	if err := pse.ch.runPluginHook(pse.pluginID, hookID, fn); err != nil {
+		return model.NewAppError(where, "app.plugin.search_engine.hook_failed.app_error", nil, "plugin_id="+pse.pluginID, http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+func (pse *pluginSearchEngine) delete(where string, request *model.PluginSearchDeleteRequest) *model.AppError {
+	return pse.runHook(where, plugin.SearchEngineDeleteID, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineDelete(&plugin.Context{}, request)
+	})
+}
+
+func (pse *pluginSearchEngine) Start() *model.AppError { return nil }
+func (pse *pluginSearchEngine) Stop() *model.AppError  { return nil }
+func (pse *pluginSearchEngine) GetFullVersion() string { return "" }
+func (pse *pluginSearchEngine) GetVersion() int        { return 0 }
+func (pse *pluginSearchEngine) GetPlugins() []string   { return []string{} }
+
+func (pse *pluginSearchEngine) UpdateConfig(cfg *model.Config) {}
+
+func (pse *pluginSearchEngine) GetName() string {
+	return "plugin:" + pse.pluginID
+}
+
+func (pse *pluginSearchEngine) IsEnabled() bool {
+	return true
+}
+
+func (pse *pluginSearchEngine) IsActive() bool {
+	pluginsEnvironment := pse.ch.GetPluginsEnvironment()
+	return pluginsEnvironment != nil && pluginsEnvironment.IsActive(pse.pluginID)
+}
+
+func (pse *pluginSearchEngine) IsIndexingEnabled() bool       { return true }
+func (pse *pluginSearchEngine) IsSearchEnabled() bool         { return true }
+func (pse *pluginSearchEngine) IsAutocompletionEnabled() bool { return false }
+func (pse *pluginSearchEngine) IsIndexingSync() bool          { return false }
+
+func (pse *pluginSearchEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
+	return pse.runHook("pluginSearchEngine.IndexPost", plugin.SearchEngineIndexPostID, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineIndexPost(&plugin.Context{}, post, teamId)
+	})
+}
+
+func (pse *pluginSearchEngine) SearchPosts(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	var postIds []string
+	var matches model.PostSearchMatches
+	appErr := pse.runHook("pluginSearchEngine.SearchPosts", plugin.SearchEngineSearchPostsID, func(hooks plugin.Hooks) error {
+		var hookErr error
+		postIds, matches, hookErr = hooks.SearchEngineSearchPosts(&plugin.Context{}, channels, searchParams, page, perPage)
+		return hookErr
+	})
+	if appErr != nil {
+		return nil, nil, appErr
+	}
+
+	return postIds, matches, nil
+}
+
+func (pse *pluginSearchEngine) DeletePost(post *model.Post) *model.AppError {
+	return pse.delete("pluginSearchEngine.DeletePost", &model.PluginSearchDeleteRequest{
+		DocumentType: model.PluginSearchDocumentTypePost,
+		Ids:          []string{post.Id},
+	})
+}
+
+func (pse *pluginSearchEngine) DeleteChannelPosts(rctx request.CTX, channelID string) *model.AppError {
+	return pse.delete("pluginSearchEngine.DeleteChannelPosts", &model.PluginSearchDeleteRequest{
+		DocumentType: model.PluginSearchDocumentTypePost,
+		ChannelId:    channelID,
+	})
+}
+
+func (pse *pluginSearchEngine) DeleteUserPosts(rctx request.CTX, userID string) *model.AppError {
+	return pse.delete("pluginSearchEngine.DeleteUserPosts", &model.PluginSearchDeleteRequest{
+		DocumentType: model.PluginSearchDocumentTypePost,
+		UserId:       userID,
+	})
+}
+
+// Channels and users are left to the other engines.
+
+func (pse *pluginSearchEngine) IndexChannel(rctx request.CTX, channel *model.Channel, userIDs, teamMemberIDs []string) *model.AppError {
+	return nil
+}
+
+func (pse *pluginSearchEngine) SearchChannels(teamId, userID, term string, isGuest, includeDeleted bool) ([]string, *model.AppError) {
+	return nil, model.NewAppError("pluginSearchEngine.SearchChannels", "app.plugin.search_engine.not_supported.app_error", nil, "", http.StatusNotImplemented)
+}
+
+func (pse *pluginSearchEngine) DeleteChannel(channel *model.Channel) *model.AppError {
+	return nil
+}
+
+func (pse *pluginSearchEngine) IndexUser(rctx request.CTX, user *model.User, teamsIds, channelsIds []string) *model.AppError {
+	return nil
+}
+
+func (pse *pluginSearchEngine) SearchUsersInChannel(teamId, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError) {
+	return nil, nil, model.NewAppError("pluginSearchEngine.SearchUsersInChannel", "app.plugin.search_engine.not_supported.app_error", nil, "", http.StatusNotImplemented)
+}
+
+func (pse *pluginSearchEngine) SearchUsersInTeam(teamId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, *model.AppError) {
+	return nil, model.NewAppError("pluginSearchEngine.SearchUsersInTeam", "app.plugin.search_engine.not_supported.app_error", nil, "", http.StatusNotImplemented)
+}
+
+func (pse *pluginSearchEngine) DeleteUser(user *model.User) *model.AppError {
+	return nil
+}
+
+func (pse *pluginSearchEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	return pse.runHook("pluginSearchEngine.IndexFile", plugin.SearchEngineIndexFileID, func(hooks plugin.Hooks) error {
+		return hooks.SearchEngineIndexFile(&plugin.Context{}, file, channelId)
+	})
+}
+
+func (pse *pluginSearchEngine) SearchFiles(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	var fileIds []string
+	appErr := pse.runHook("pluginSearchEngine.SearchFiles", plugin.SearchEngineSearchFilesID, func(hooks plugin.Hooks) error {
+		var hookErr error
+		fileIds, hookErr = hooks.SearchEngineSearchFiles(&plugin.Context{}, channels, searchParams, page, perPage)
+		return hookErr
+	})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return fileIds, nil
+}
+
+func (pse *pluginSearchEngine) DeleteFile(fileID string) *model.AppError {
+	return pse.delete("pluginSearchEngine.DeleteFile", &model.PluginSearchDeleteRequest{
+		DocumentType: model.PluginSearchDocumentTypeFile,
+		Ids:          []string{fileID},
+	})
+}
+
+func (pse *pluginSearchEngine) DeletePostFiles(rctx request.CTX, postID string) *model.AppError {
+	return pse.delete("pluginSearchEngine.DeletePostFiles", &model.PluginSearchDeleteRequest{
+		DocumentType: model.PluginSearchDocumentTypeFile,
+		PostId:       postID,
+	})
+}
+
+func (pse *pluginSearchEngine) DeleteUserFiles(rctx request.CTX, userID string) *model.AppError {
+	return pse.delete("pluginSearchEngine.DeleteUserFiles", &model.PluginSearchDeleteRequest{
+		DocumentType: model.PluginSearchDocumentTypeFile,
+		UserId:       userID,
+	})
+}
+
+func (pse *pluginSearchEngine) DeleteFilesBatch(rctx request.CTX, endTime, limit int64) *model.AppError {
+	return pse.delete("pluginSearchEngine.DeleteFilesBatch", &model.PluginSearchDeleteRequest{
+		DocumentType: model.PluginSearchDocumentTypeFile,
+		EndTime:      endTime,
+		Limit:        limit,
+	})
+}
+
+func (pse *pluginSearchEngine) TestConfig(rctx request.CTX, cfg *model.Config) *model.AppError {
+	return nil
+}
+
+func (pse *pluginSearchEngine) PurgeIndexes(rctx request.CTX) *model.AppError {
+	return pse.delete("pluginSearchEngine.PurgeIndexes", &model.PluginSearchDeleteRequest{
+		All: true,
+	})
+}
+
+// PurgeIndexList is a no-op as the indexes of the plugin are not exposed.
+func (pse *pluginSearchEngine) PurgeIndexList(rctx request.CTX, indexes []string) *model.AppError {
+	return nil
+}
+
+func (pse *pluginSearchEngine) RefreshIndexes(rctx request.CTX) *model.AppError {
+	return nil
+}
+
+func (pse *pluginSearchEngine) DataRetentionDeleteIndexes(rctx request.CTX, cutoff time.Time) *model.AppError {
+	return pse.delete("pluginSearchEngine.DataRetentionDeleteIndexes", &model.PluginSearchDeleteRequest{
+		EndTime: model.GetMillisForTime(cutoff),
+	})
+}
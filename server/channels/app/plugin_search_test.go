@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/services/docextractor"
+)
+
+func TestPluginSearchEngine(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+
+	appErr := th.App.RegisterPluginSearchEngine("plugin1")
+	require.Nil(t, appErr)
+	defer th.App.UnregisterPluginSearchEngine("plugin1")
+
+	appErr = th.App.RegisterPluginSearchEngine("plugin2")
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+
+	engine := th.App.SearchEngine().GetPluginEngine()
+	require.NotNil(t, engine)
+
+	t.Run("inactive plugin falls back", func(t *testing.T) {
+		assert.False(t, engine.IsActive())
+		assert.NotContains(t, th.App.SearchEngine().GetActiveContentEngines(), engine)
+
+		_, _, appErr := engine.SearchPosts(model.ChannelList{}, []*model.SearchParams{{Terms: "test"}}, 0, 20)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("unregister", func(t *testing.T) {
+		appErr := th.App.UnregisterPluginSearchEngine("plugin2")
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+		th.App.ch.unregisterPluginSearchEngine("plugin1")
+		assert.Nil(t, th.App.SearchEngine().GetPluginEngine())
+	})
+}
+
+func TestPluginDocumentExtractor(t *testing.T) {
+	mainHelper.Parallel(t)
+	th := Setup(t)
+	defer th.TearDown()
+
+	appErr := th.App.RegisterPluginDocumentExtractor("plugin1", &model.PluginDocumentExtractor{})
+	require.NotNil(t, appErr)
+
+	appErr = th.App.RegisterPluginDocumentExtractor("plugin1", &model.PluginDocumentExtractor{Extensions: []string{".txt"}})
+	require.Nil(t, appErr)
+	defer th.App.ch.unregisterPluginDocumentExtractor("plugin1")
+
+	fileInfo := &model.FileInfo{Name: "notes.txt", MimeType: "text/plain"}
+	extractors := th.App.pluginDocumentExtractorsFor(th.Context, fileInfo)
+	require.Len(t, extractors, 1)
+	assert.True(t, extractors[0].Match("notes.txt"))
+	assert.False(t, extractors[0].Match("notes.pdf"))
+
+	t.Run("inactive plugin falls back", func(t *testing.T) {
+		text, err := docextractor.ExtractWithExtraExtractors(th.Context.Logger(), fileInfo.Name, bytes.NewReader([]byte("some notes")), docextractor.ExtractSettings{}, extractors)
+		require.NoError(t, err)
+		assert.Equal(t, "some notes", text)
+	})
+
+	appErr = th.App.UnregisterPluginDocumentExtractor("plugin1")
+	require.Nil(t, appErr)
+	assert.Empty(t, th.App.pluginDocumentExtractorsFor(th.Context, fileInfo))
+}
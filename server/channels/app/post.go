@@ -1455,6 +1455,10 @@ func (a *App) DeletePost(rctx request.CTX, postID, deleteByID string) (*model.Po
 		return nil, model.NewAppError("DeletePost", "api.post.delete_post.can_not_delete_post_in_deleted.error", nil, "", http.StatusBadRequest)
 	}
 
+	if appErr = a.runMessageWillBeDeletedHook(rctx, post); appErr != nil {
+		return nil, appErr
+	}
+
 	err = a.Srv().Store().Post().Delete(rctx, postID, model.GetMillis(), deleteByID)
 	if err != nil {
 		var nfErr *store.ErrNotFound
@@ -1713,7 +1717,10 @@ func (a *App) SearchPostsInTeam(teamID string, paramsList []*model.SearchParams)
 	})
 }
 
-func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, page, perPage int) (*model.PostSearchResults, *model.AppError) {
+// SearchPostsForUser searches the posts of the channels the user is a member of. A
+// positive semanticWeight merges the results of the semantic search, when it is
+// enabled, with the keyword ones.
+func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, teamID string, isOrSearch bool, includeDeletedChannels bool, timeZoneOffset int, semanticWeight float64, page, perPage int) (*model.PostSearchResults, *model.AppError) {
 	var postSearchResults *model.PostSearchResults
 	paramsList := model.ParseSearchParams(strings.TrimSpace(terms), timeZoneOffset)
 	includeDeleted := includeDeletedChannels && *a.Config().TeamSettings.ExperimentalViewArchivedChannels
@@ -1727,6 +1734,7 @@ func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, tea
 	for _, params := range paramsList {
 		params.OrTerms = isOrSearch
 		params.IncludeDeletedChannels = includeDeleted
+		params.SemanticWeight = semanticWeight
 		// Don't allow users to search for "*"
 		if params.Terms != "*" {
 			// TODO: we have to send channel ids
@@ -1760,7 +1768,9 @@ func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, tea
 		}
 	}
 
-	if appErr := a.filterInaccessiblePosts(postSearchResults.PostList, filterPostOptions{assumeSortedCreatedAt: true}); appErr != nil {
+	// Results merged with the semantic search are ranked by relevance rather
+	// than by creation time.
+	if appErr := a.filterInaccessiblePosts(postSearchResults.PostList, filterPostOptions{assumeSortedCreatedAt: semanticWeight == 0}); appErr != nil {
 		return nil, appErr
 	}
 
@@ -2711,6 +2721,10 @@ func (a *App) PermanentDeletePost(rctx request.CTX, postID, deleteByID string) *
 		return model.NewAppError("DeletePost", "app.post.get.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 	}
 
+	if appErr := a.runMessageWillBeDeletedHook(rctx, post); appErr != nil {
+		return appErr
+	}
+
 	if len(post.FileIds) > 0 {
 		appErr := a.PermanentDeleteFilesByPost(rctx, post.Id)
 		if appErr != nil {
@@ -2731,6 +2745,21 @@ func (a *App) PermanentDeletePost(rctx request.CTX, postID, deleteByID string) *
 	return nil
 }
 
+// runMessageWillBeDeletedHook gives plugins the chance to reject the deletion
+// of the post.
+func (a *App) runMessageWillBeDeletedHook(c request.CTX, post *model.Post) *model.AppError {
+	var rejectionReason string
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		rejectionReason = hooks.MessageWillBeDeleted(pluginContext, post.ForPlugin())
+		return rejectionReason == ""
+	}, plugin.MessageWillBeDeletedID)
+	if rejectionReason != "" {
+		return model.NewAppError("DeletePost", "app.post.delete.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
 func (a *App) CleanUpAfterPostDeletion(c request.CTX, post *model.Post, deleteByID string) *model.AppError {
 	channel, appErr := a.GetChannel(c, post.ChannelId)
 	if appErr != nil {
@@ -90,6 +90,14 @@ func (a *App) SaveReactionForPost(c request.CTX, reaction *model.Reaction) (*mod
 		}, plugin.ReactionHasBeenAddedID)
 	})
 
+	a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+		eventType: model.OutgoingWebhookEventReactionAdded,
+		teamID:    channel.TeamId,
+		channel:   channel,
+		userID:    reaction.UserId,
+		reaction:  reaction,
+	})
+
 	a.sendReactionEvent(c, model.WebsocketEventReactionAdded, reaction, post)
 
 	return reaction, nil
@@ -358,6 +358,8 @@ func NewServer(options ...Option) (*Server, error) {
 		TemplatesContainer: s.TemplatesContainer(),
 		UserService:        s.userService,
 		Store:              s.GetStore(),
+
+		EmailNotificationWillBeSentFn: app.runEmailNotificationWillBeSentHook,
 	})
 	if err != nil {
 		return nil, errors.Wrapf(err, "unable to initialize email service")
@@ -168,6 +168,36 @@ func (a *App) UpdateSharedChannelRemoteCursor(id string, cursor model.GetPostsSi
 	return a.Srv().Store().SharedChannel().UpdateRemoteCursor(id, cursor)
 }
 
+// UpdateSharedChannelRemoteSyncFilters replaces the sync filters applied when syncing a shared
+// channel to a remote. Passing nil filters removes all filtering.
+func (a *App) UpdateSharedChannelRemoteSyncFilters(channelID, remoteID string, filters *model.SharedChannelRemoteSyncFilters) (*model.SharedChannelRemote, error) {
+	if err := a.checkChannelIsShared(channelID); err != nil {
+		return nil, err
+	}
+
+	if filters.IsEmpty() {
+		filters = nil
+	} else if appErr := filters.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	scr, err := a.Srv().Store().SharedChannel().GetRemoteByIds(channelID, remoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.Srv().Store().SharedChannel().UpdateRemoteSyncFilters(scr.Id, filters); err != nil {
+		return nil, err
+	}
+	scr.SyncFilters = filters
+	return scr, nil
+}
+
+// GetSharedChannelSyncSuppressions returns the posts and files withheld from a remote by its sync filters.
+func (a *App) GetSharedChannelSyncSuppressions(page, perPage int, opts model.SharedChannelSyncSuppressionFilterOpts) ([]*model.SharedChannelSyncSuppression, error) {
+	return a.Srv().Store().SharedChannel().GetSyncSuppressions(page*perPage, perPage, opts)
+}
+
 func (a *App) DeleteSharedChannelRemote(id string) (bool, error) {
 	return a.Srv().Store().SharedChannel().DeleteRemote(id)
 }
@@ -222,6 +252,80 @@ func (a *App) SyncSharedChannel(channelID string) error {
 	return nil
 }
 
+// GetRemoteClusterSyncHealth reports the sync state of every channel shared with a remote cluster.
+func (a *App) GetRemoteClusterSyncHealth(remoteID string) (*model.RemoteClusterSyncHealth, *model.AppError) {
+	syncService := a.Srv().GetSharedChannelSyncService()
+	if syncService == nil || !syncService.Active() {
+		return nil, model.NewAppError("GetRemoteClusterSyncHealth", "api.command_share.service_disabled",
+			nil, "", http.StatusBadRequest)
+	}
+
+	rc, appErr := a.GetRemoteCluster(remoteID, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	health, err := syncService.GetSyncHealth(rc)
+	if err != nil {
+		return nil, model.NewAppError("GetRemoteClusterSyncHealth", "app.shared_channel.get_sync_health.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return health, nil
+}
+
+// GetAllRemoteClustersSyncHealth reports the sync state of every confirmed remote cluster.
+func (a *App) GetAllRemoteClustersSyncHealth() ([]*model.RemoteClusterSyncHealth, *model.AppError) {
+	syncService := a.Srv().GetSharedChannelSyncService()
+	if syncService == nil || !syncService.Active() {
+		return nil, model.NewAppError("GetAllRemoteClustersSyncHealth", "api.command_share.service_disabled",
+			nil, "", http.StatusBadRequest)
+	}
+
+	remotes, appErr := a.GetAllRemoteClusters(0, 999999, model.RemoteClusterQueryFilter{OnlyConfirmed: true})
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	result := make([]*model.RemoteClusterSyncHealth, 0, len(remotes))
+	for _, rc := range remotes {
+		health, err := syncService.GetSyncHealth(rc)
+		if err != nil {
+			return nil, model.NewAppError("GetAllRemoteClustersSyncHealth", "app.shared_channel.get_sync_health.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		result = append(result, health)
+	}
+	return result, nil
+}
+
+// ResyncRemoteCluster rewinds the sync cursors of the channels shared with a remote cluster
+// so their content since the requested time is sent again.
+func (a *App) ResyncRemoteCluster(remoteID string, req *model.SharedChannelResyncRequest) *model.AppError {
+	if appErr := req.IsValid(); appErr != nil {
+		return appErr
+	}
+
+	syncService := a.Srv().GetSharedChannelSyncService()
+	if syncService == nil || !syncService.Active() {
+		return model.NewAppError("ResyncRemoteCluster", "api.command_share.service_disabled",
+			nil, "", http.StatusBadRequest)
+	}
+
+	rc, appErr := a.GetRemoteCluster(remoteID, false)
+	if appErr != nil {
+		return appErr
+	}
+
+	if req.ChannelId != "" {
+		if _, err := a.GetSharedChannelRemoteByIds(req.ChannelId, remoteID); err != nil {
+			return model.NewAppError("ResyncRemoteCluster", "api.shared_channel.channel_remote_not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+	}
+
+	if err := syncService.ResyncFromTimestamp(rc, req.ChannelId, req.Since); err != nil {
+		return model.NewAppError("ResyncRemoteCluster", "app.shared_channel.resync.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
 // Hooks
 
 var ErrPluginUnavailable = errors.New("plugin unavailable")
@@ -29,6 +29,8 @@ type SharedChannelServiceIFace interface {
 	CheckCanInviteToSharedChannel(channelId string) error
 	HandleMembershipChange(channelID, userID string, isAdd bool, remoteID string)
 	IsRemoteClusterDirectlyConnected(remoteId string) bool
+	GetSyncHealth(rc *model.RemoteCluster) (*model.RemoteClusterSyncHealth, error)
+	ResyncFromTimestamp(rc *model.RemoteCluster, channelID string, since int64) error
 	TransformMentionsOnReceiveForTesting(ctx request.CTX, post *model.Post, targetChannel *model.Channel, rc *model.RemoteCluster, mentionTransforms map[string]string)
 }
 
@@ -6,6 +6,7 @@ package slashcommands
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -22,7 +23,7 @@ var _ app.AutocompleteDynamicArgProvider = (*ShareProvider)(nil)
 
 const (
 	CommandTriggerShare   = "share-channel"
-	AvailableShareActions = "invite, uninvite, unshare, status"
+	AvailableShareActions = "invite, uninvite, unshare, status, filters"
 )
 
 func init() {
@@ -47,10 +48,19 @@ func (sp *ShareProvider) GetCommand(a *app.App, T i18n.TranslateFunc) *model.Com
 
 	status := model.NewAutocompleteData("status", "", T("api.command_share.channel_status.help"))
 
+	filters := model.NewAutocompleteData("filters", "", T("api.command_share.filters.help"))
+	filters.AddNamedDynamicListArgument("connectionID", T("api.command_share.filters_remote_id.help"), "builtin:"+CommandTriggerShare, true)
+	filters.AddNamedTextArgument("exclude_bots", T("api.command_share.filters_exclude_bots.help"), "Y|N", "Y|N|y|n", false)
+	filters.AddNamedTextArgument("exclude_post_types", T("api.command_share.filters_exclude_post_types.help"), T("api.command_share.filters_exclude_post_types.hint"), "", false)
+	filters.AddNamedTextArgument("exclude_users", T("api.command_share.filters_exclude_users.help"), T("api.command_share.filters_exclude_users.hint"), "", false)
+	filters.AddNamedTextArgument("exclude_groups", T("api.command_share.filters_exclude_groups.help"), T("api.command_share.filters_exclude_groups.hint"), "", false)
+	filters.AddNamedTextArgument("max_attachment_size", T("api.command_share.filters_max_attachment_size.help"), T("api.command_share.filters_max_attachment_size.hint"), "", false)
+
 	share.AddCommand(inviteRemote)
 	share.AddCommand(unInviteRemote)
 	share.AddCommand(unshareChannel)
 	share.AddCommand(status)
+	share.AddCommand(filters)
 
 	return &model.Command{
 		Trigger:          CommandTriggerShare,
@@ -72,7 +82,7 @@ func (sp *ShareProvider) GetAutoCompleteListItems(c request.CTX, a *app.App, com
 
 		return sp.getAutoCompleteInviteRemote(a, commandArgs, arg)
 
-	case strings.Contains(parsed, " uninvite "):
+	case strings.Contains(parsed, " uninvite "), strings.Contains(parsed, " filters "):
 
 		return sp.getAutoCompleteUnInviteRemote(a, commandArgs, arg)
 	}
@@ -154,6 +164,8 @@ func (sp *ShareProvider) DoCommand(a *app.App, c request.CTX, args *model.Comman
 		return sp.doUninviteRemote(a, args, margs)
 	case "status":
 		return sp.doStatus(a, args, margs)
+	case "filters":
+		return sp.doFilters(a, args, margs)
 	}
 	return response(args.T("api.command_share.unknown_action", map[string]any{"Action": action, "Actions": AvailableShareActions}))
 }
@@ -303,3 +315,94 @@ func (sp *ShareProvider) doStatus(a *app.App, args *model.CommandArgs, _ map[str
 	}
 	return response(sb.String())
 }
+
+func (sp *ShareProvider) doFilters(a *app.App, args *model.CommandArgs, margs map[string]string) *model.CommandResponse {
+	remoteID, ok := margs["connectionID"]
+	if !ok || remoteID == "" {
+		return response(args.T("api.command_share.must_specify_valid_remote"))
+	}
+
+	scr, err := a.GetSharedChannelRemoteByIds(args.ChannelId, remoteID)
+	if err != nil {
+		return response(args.T("api.command_share.fetch_remote.error", map[string]any{"Error": err.Error()}))
+	}
+
+	filters := &model.SharedChannelRemoteSyncFilters{}
+	if scr.SyncFilters != nil {
+		filters = scr.SyncFilters
+	}
+
+	var changed bool
+	if val, ok := margs["exclude_bots"]; ok {
+		excludeBots, err := parseBool(val)
+		if err != nil {
+			return response(args.T("api.command_share.invalid_value.error", map[string]any{"Arg": "exclude_bots", "Error": err.Error()}))
+		}
+		filters.ExcludeBots = excludeBots
+		changed = true
+	}
+	if val, ok := margs["exclude_post_types"]; ok {
+		filters.ExcludePostTypes = splitList(val)
+		changed = true
+	}
+	if val, ok := margs["exclude_users"]; ok {
+		userIDs := make([]string, 0)
+		for _, username := range splitList(val) {
+			user, appErr := a.GetUserByUsername(strings.TrimPrefix(username, "@"))
+			if appErr != nil {
+				return response(args.T("api.command_share.invalid_value.error", map[string]any{"Arg": "exclude_users", "Error": appErr.Error()}))
+			}
+			userIDs = append(userIDs, user.Id)
+		}
+		filters.ExcludeUserIds = userIDs
+		changed = true
+	}
+	if val, ok := margs["exclude_groups"]; ok {
+		groupIDs := make([]string, 0)
+		for _, name := range splitList(val) {
+			group, appErr := a.GetGroupByName(strings.TrimPrefix(name, "@"), model.GroupSearchOpts{})
+			if appErr != nil {
+				return response(args.T("api.command_share.invalid_value.error", map[string]any{"Arg": "exclude_groups", "Error": appErr.Error()}))
+			}
+			groupIDs = append(groupIDs, group.Id)
+		}
+		filters.ExcludeGroupIds = groupIDs
+		changed = true
+	}
+	if val, ok := margs["max_attachment_size"]; ok {
+		size, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return response(args.T("api.command_share.invalid_value.error", map[string]any{"Arg": "max_attachment_size", "Error": err.Error()}))
+		}
+		filters.MaxAttachmentSize = size
+		changed = true
+	}
+
+	if changed {
+		if _, err := a.UpdateSharedChannelRemoteSyncFilters(args.ChannelId, remoteID, filters); err != nil {
+			return response(args.T("api.command_share.update_filters.error", map[string]any{"Error": err.Error()}))
+		}
+	}
+
+	var sb strings.Builder
+	if changed {
+		fmt.Fprintf(&sb, "##### %s\n\n", args.T("api.command_share.filters_updated"))
+	}
+	fmt.Fprintf(&sb, "%s \n", args.T("api.command_share.filters_table_header"))
+	// "| Exclude Bots | Excluded Post Types | Excluded Users | Excluded Groups | Max Attachment Size |"
+	fmt.Fprintf(&sb, "| ---- | ---- | ---- | ---- | ---- | \n")
+	fmt.Fprintf(&sb, "| %s | %s | %d | %d | %d |\n", formatBool(args.T, filters.ExcludeBots),
+		strings.Join(filters.ExcludePostTypes, ", "), len(filters.ExcludeUserIds), len(filters.ExcludeGroupIds), filters.MaxAttachmentSize)
+	return response(sb.String())
+}
+
+// splitList splits a comma separated argument, ignoring empty items.
+func splitList(s string) []string {
+	items := make([]string, 0)
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
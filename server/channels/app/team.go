@@ -136,6 +136,14 @@ func (a *App) CreateTeam(c request.CTX, team *model.Team) (*model.Team, *model.A
 		}
 	}
 
+	a.Srv().Go(func() {
+		pluginContext := pluginContext(c)
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.TeamHasBeenCreated(pluginContext, rteam)
+			return true
+		}, plugin.TeamHasBeenCreatedID)
+	})
+
 	return rteam, nil
 }
 
@@ -811,6 +819,13 @@ func (a *App) JoinUserToTeam(c request.CTX, team *model.Team, user *model.User,
 		}, plugin.UserHasJoinedTeamID)
 	})
 
+	a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+		eventType: model.OutgoingWebhookEventUserJoinedTeam,
+		teamID:    team.Id,
+		userID:    user.Id,
+		actorID:   userRequestorId,
+	})
+
 	message := model.NewWebSocketEvent(model.WebsocketEventAddedToTeam, "", "", user.Id, nil, "")
 	message.Add("team_id", team.Id)
 	message.Add("user_id", user.Id)
@@ -1182,6 +1197,13 @@ func (a *App) postProcessTeamMemberLeave(c request.CTX, teamMember *model.TeamMe
 		}, plugin.UserHasLeftTeamID)
 	})
 
+	a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+		eventType: model.OutgoingWebhookEventUserLeftTeam,
+		teamID:    teamMember.TeamId,
+		userID:    teamMember.UserId,
+		actorID:   requestorId,
+	})
+
 	user, nErr := a.Srv().Store().User().Get(context.Background(), teamMember.UserId)
 	if nErr != nil {
 		var nfErr *store.ErrNotFound
@@ -1739,6 +1761,21 @@ func (a *App) PermanentDeleteTeamId(c request.CTX, teamID string) *model.AppErro
 }
 
 func (a *App) PermanentDeleteTeam(c request.CTX, team *model.Team) *model.AppError {
+	channels, err := a.Srv().Store().Channel().GetTeamChannels(team.Id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			return model.NewAppError("PermanentDeleteTeam", "app.channel.get_channels.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	// Nothing is deleted when any channel of the team is under a legal hold
+	for _, ch := range channels {
+		if appErr := a.checkChannelNotUnderLegalHold("PermanentDeleteTeam", ch.Id); appErr != nil {
+			return appErr
+		}
+	}
+
 	team.DeleteAt = model.GetMillis()
 	if _, err := a.Srv().Store().Team().Update(team); err != nil {
 		var invErr *store.ErrInvalidInput
@@ -1753,16 +1790,9 @@ func (a *App) PermanentDeleteTeam(c request.CTX, team *model.Team) *model.AppErr
 		}
 	}
 
-	if channels, err := a.Srv().Store().Channel().GetTeamChannels(team.Id); err != nil {
-		var nfErr *store.ErrNotFound
-		if !errors.As(err, &nfErr) {
-			return model.NewAppError("PermanentDeleteTeam", "app.channel.get_channels.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
-		}
-	} else {
-		for _, ch := range channels {
-			if err := a.PermanentDeleteChannel(c, ch); err != nil {
-				c.Logger().Warn("Error permanently deleting channel during team deletion", mlog.String("channel_id", ch.Id), mlog.String("team_id", team.Id), mlog.Err(err))
-			}
+	for _, ch := range channels {
+		if err := a.PermanentDeleteChannel(c, ch); err != nil {
+			c.Logger().Warn("Error permanently deleting channel during team deletion", mlog.String("channel_id", ch.Id), mlog.String("team_id", team.Id), mlog.Err(err))
 		}
 	}
 
@@ -12,6 +12,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -1103,6 +1104,24 @@ func (a *App) UpdateActive(c request.CTX, user *model.User, active bool) (*model
 				return true
 			}, plugin.UserHasBeenDeactivatedID)
 		})
+
+		if *a.Config().ServiceSettings.EnableOutgoingWebhooks {
+			a.Srv().Go(func() {
+				teams, err := a.Srv().Store().Team().GetTeamsByUserId(user.Id)
+				if err != nil {
+					c.Logger().Warn("Failed to get the teams of the deactivated user for the outgoing webhooks", mlog.String("user_id", user.Id), mlog.Err(err))
+					return
+				}
+
+				for _, team := range teams {
+					a.handleOutgoingWebhookEvent(c, outgoingWebhookEvent{
+						eventType: model.OutgoingWebhookEventUserDeactivated,
+						teamID:    team.Id,
+						userID:    user.Id,
+					})
+				}
+			})
+		}
 	}
 
 	if active {
@@ -1294,6 +1313,37 @@ func (a *App) UpdateUser(c request.CTX, user *model.User, sendNotifications bool
 		user.CreateAt = prev.CreateAt
 	}
 
+	// Plugins get sanitized copies of the users and can't change the
+	// sanitized fields
+	oldUser := prev.DeepCopy()
+	oldUser.Sanitize(map[string]bool{})
+	var rejectionReason string
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		newUser := user.DeepCopy()
+		newUser.Sanitize(map[string]bool{})
+
+		var replacementUser *model.User
+		replacementUser, rejectionReason = hooks.UserWillBeUpdated(pluginContext, newUser, oldUser)
+		if rejectionReason != "" {
+			return false
+		}
+		if replacementUser != nil {
+			replacementUser.Password = user.Password
+			replacementUser.MfaSecret = user.MfaSecret
+			replacementUser.MfaUsedTimestamps = user.MfaUsedTimestamps
+			replacementUser.LastLogin = user.LastLogin
+			user = replacementUser
+		}
+
+		return true
+	}, plugin.UserWillBeUpdatedID)
+	if rejectionReason != "" {
+		return nil, model.NewAppError("UpdateUser", "app.user.update.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "", http.StatusBadRequest)
+	}
+	user.Id = prev.Id
+	user.CreateAt = prev.CreateAt
+
 	if user.Username != prev.Username {
 		if err := a.isUniqueToGroupNames(user.Username); err != nil {
 			err.Where = "UpdateUser"
@@ -1817,6 +1867,10 @@ func (a *App) UpdateUserRolesWithUser(c request.CTX, user *model.User, newRoles
 
 func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.AppError {
 	rctx.Logger().Warn("Attempting to permanently delete account", mlog.String("user_id", user.Id), mlog.String("user_email", user.Email))
+	if appErr := a.checkUserNotUnderLegalHold("PermanentDeleteUser", user.Id); appErr != nil {
+		return appErr
+	}
+
 	if user.IsInRole(model.SystemAdminRoleId) {
 		rctx.Logger().Warn("You are deleting a user that is a system administrator.  You may need to set another account as the system administrator using the command line tools.", mlog.String("user_email", user.Email))
 	}
@@ -1887,13 +1941,6 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		}
 	}
 
-	infos, err := a.Srv().Store().FileInfo().GetForUser(user.Id)
-	if err != nil {
-		rctx.Logger().Warn("Error getting file list for user from FileInfoStore", mlog.Err(err))
-	}
-
-	a.RemoveFilesFromFileStore(rctx, infos)
-
 	// delete directory containing user's profile image
 	profileImageDirectory := getProfileImageDirectory(user.Id)
 	profileImagePath := getProfileImagePath(user.Id)
@@ -1923,10 +1970,29 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		}
 	}
 
+	infos, err := a.Srv().Store().FileInfo().GetForUser(user.Id)
+	if err != nil {
+		rctx.Logger().Warn("Error getting file list for user from FileInfoStore", mlog.Err(err))
+	}
+
 	if _, err := a.Srv().Store().FileInfo().PermanentDeleteByUser(rctx, user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.file_info.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	// Files covered by a legal hold are kept by the store, and so are their contents
+	if kept, err := a.Srv().Store().FileInfo().GetForUser(user.Id); err != nil {
+		rctx.Logger().Warn("Error getting file list for user from FileInfoStore", mlog.Err(err))
+		infos = nil
+	} else if len(kept) > 0 {
+		keptIDs := make(map[string]bool, len(kept))
+		for _, info := range kept {
+			keptIDs[info.Id] = true
+		}
+		infos = slices.DeleteFunc(infos, func(info *model.FileInfo) bool { return keptIDs[info.Id] })
+	}
+
+	a.RemoveFilesFromFileStore(rctx, infos)
+
 	if err := a.Srv().Store().User().PermanentDelete(rctx, user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.user.permanent_delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"maps"
 	"net/http"
@@ -60,6 +61,10 @@ func (a *App) handleWebhookEvents(c request.CTX, post *model.Post, team *model.T
 
 	relevantHooks := []*model.OutgoingWebhook{}
 	for _, hook := range hooks {
+		if !hook.ReceivesPosts() {
+			continue
+		}
+
 		if hook.ChannelId == post.ChannelId || hook.ChannelId == "" {
 			if hook.ChannelId == post.ChannelId && len(hook.TriggerWords) == 0 {
 				relevantHooks = append(relevantHooks, hook)
@@ -127,32 +132,9 @@ func (a *App) TriggerWebhook(c request.CTX, payload *model.OutgoingWebhookPayloa
 		go func() {
 			defer wg.Done()
 
-			var accessToken *model.OutgoingOAuthConnectionToken
-
-			// Retrieve an access token from a connection if one exists to use for the webhook request
-			if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
-				connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, url)
-				if err != nil {
-					logger.Error("Failed to find an outgoing oauth connection for the webhook", mlog.Err(err))
-					return
-				}
-
-				if connection != nil {
-					accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
-					if err != nil {
-						logger.Error("Failed to retrieve token for outgoing oauth connection", mlog.Err(err))
-						return
-					}
-				}
-			}
-
-			webhookResp, err := a.doOutgoingWebhookRequest(url, body, contentType, accessToken)
+			webhookResp, err := a.sendOutgoingWebhookRequest(c, url, body, contentType)
 			if err != nil {
-				if errors.Is(err, context.DeadlineExceeded) {
-					logger.Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
-				} else {
-					logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
-				}
+				logOutgoingWebhookRequestError(logger, err)
 				return
 			}
 
@@ -191,6 +173,165 @@ func (a *App) TriggerWebhook(c request.CTX, payload *model.OutgoingWebhookPayloa
 	wg.Wait()
 }
 
+// outgoingWebhookEvent is an event, besides posts, sent to the outgoing webhooks subscribed to it.
+type outgoingWebhookEvent struct {
+	eventType string
+	teamID    string
+	// channel is set for the channel events.
+	channel *model.Channel
+	userID  string
+	// actorID is the user who added or removed the user, if it's someone else.
+	actorID  string
+	reaction *model.Reaction
+}
+
+// handleOutgoingWebhookEvent sends the event to the outgoing webhooks of the team subscribed to it.
+// The webhooks are looked up right away, and the requests are sent in the background. As with
+// posts, the events of private channels are never sent.
+func (a *App) handleOutgoingWebhookEvent(c request.CTX, event outgoingWebhookEvent) {
+	if !*a.Config().ServiceSettings.EnableOutgoingWebhooks {
+		return
+	}
+
+	if event.channel != nil && event.channel.Type != model.ChannelTypeOpen {
+		return
+	}
+
+	logger := c.Logger().With(mlog.String("event", event.eventType), mlog.String("team_id", event.teamID))
+
+	hooks, err := a.Srv().Store().Webhook().GetOutgoingByTeam(event.teamID, -1, -1)
+	if err != nil {
+		logger.Error("Failed to get the outgoing webhooks of the team", mlog.Err(err))
+		return
+	}
+
+	relevantHooks := []*model.OutgoingWebhook{}
+	for _, hook := range hooks {
+		if !hook.IsSubscribedTo(event.eventType) {
+			continue
+		}
+		if hook.ChannelId != "" && event.channel != nil && hook.ChannelId != event.channel.Id {
+			continue
+		}
+		relevantHooks = append(relevantHooks, hook)
+	}
+
+	if len(relevantHooks) == 0 {
+		return
+	}
+
+	a.Srv().Go(func() {
+		team, appErr := a.GetTeam(event.teamID)
+		if appErr != nil {
+			logger.Error("Failed to get the team of the outgoing webhook event", mlog.Err(appErr))
+			return
+		}
+
+		payload := &model.OutgoingWebhookEventPayload{
+			Event:      event.eventType,
+			TeamId:     team.Id,
+			TeamDomain: team.Name,
+			Timestamp:  model.GetMillis(),
+			UserId:     event.userID,
+		}
+		if event.channel != nil {
+			payload.ChannelId = event.channel.Id
+			payload.ChannelName = event.channel.Name
+		}
+		if event.userID != "" {
+			if user, appErr := a.GetUser(event.userID); appErr == nil {
+				payload.UserName = user.Username
+			}
+		}
+		if event.actorID != "" && event.actorID != event.userID {
+			payload.ActorId = event.actorID
+			if actor, appErr := a.GetUser(event.actorID); appErr == nil {
+				payload.ActorName = actor.Username
+			}
+		}
+		if event.reaction != nil {
+			payload.PostId = event.reaction.PostId
+			payload.EmojiName = event.reaction.EmojiName
+			payload.Timestamp = event.reaction.CreateAt
+		}
+
+		var wg sync.WaitGroup
+		for _, hook := range relevantHooks {
+			hookPayload := *payload
+			hookPayload.Token = hook.Token
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				a.triggerWebhookEvent(c, &hookPayload, hook)
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+// triggerWebhookEvent sends the event payload to the callback URLs of the webhook. Unlike for
+// posts, the responses are ignored.
+func (a *App) triggerWebhookEvent(c request.CTX, payload *model.OutgoingWebhookEventPayload, hook *model.OutgoingWebhook) {
+	logger := c.Logger().With(mlog.String("outgoing_webhook_id", hook.Id), mlog.String("event", payload.Event), mlog.String("content_type", hook.ContentType))
+
+	var jsonBytes []byte
+	var err error
+	contentType := "application/x-www-form-urlencoded"
+	if hook.ContentType == "application/json" {
+		contentType = "application/json"
+		jsonBytes, err = json.Marshal(payload)
+		if err != nil {
+			logger.Warn("Failed to encode to JSON", mlog.Err(err))
+			return
+		}
+	}
+
+	for _, url := range hook.CallbackURLs {
+		var body io.Reader
+		if hook.ContentType == "application/json" {
+			body = bytes.NewReader(jsonBytes)
+		} else {
+			body = strings.NewReader(payload.ToFormValues())
+		}
+
+		if _, err := a.sendOutgoingWebhookRequest(c, url, body, contentType); err != nil {
+			logOutgoingWebhookRequestError(logger, err)
+		}
+	}
+}
+
+// sendOutgoingWebhookRequest sends an outgoing webhook request to the callback URL, with an access
+// token from the outgoing OAuth connection of the URL if there's one.
+func (a *App) sendOutgoingWebhookRequest(c request.CTX, url string, body io.Reader, contentType string) (*model.OutgoingWebhookResponse, error) {
+	var accessToken *model.OutgoingOAuthConnectionToken
+
+	// Retrieve an access token from a connection if one exists to use for the webhook request
+	if a.Config().ServiceSettings.EnableOutgoingOAuthConnections != nil && *a.Config().ServiceSettings.EnableOutgoingOAuthConnections && a.OutgoingOAuthConnections() != nil {
+		connection, err := a.OutgoingOAuthConnections().GetConnectionForAudience(c, url)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find an outgoing oauth connection for the webhook: %w", err)
+		}
+
+		if connection != nil {
+			accessToken, err = a.OutgoingOAuthConnections().RetrieveTokenForConnection(c, connection)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve token for outgoing oauth connection: %w", err)
+			}
+		}
+	}
+
+	return a.doOutgoingWebhookRequest(url, body, contentType, accessToken)
+}
+
+func logOutgoingWebhookRequestError(logger mlog.LoggerIFace, err error) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		logger.Error("Outgoing Webhook POST timed out. Consider increasing ServiceSettings.OutgoingIntegrationRequestsTimeout.", mlog.Err(err))
+	} else {
+		logger.Error("Outgoing Webhook POST failed", mlog.Err(err))
+	}
+}
+
 func (a *App) doOutgoingWebhookRequest(url string, body io.Reader, contentType string, accessToken *model.OutgoingOAuthConnectionToken) (*model.OutgoingWebhookResponse, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*a.Config().ServiceSettings.OutgoingIntegrationRequestsTimeout)*time.Second)
 	defer cancel()
@@ -437,6 +578,10 @@ func (a *App) UpdateIncomingWebhook(oldHook, updatedHook *model.IncomingWebhook)
 	updatedHook.TeamId = oldHook.TeamId
 	updatedHook.DeleteAt = oldHook.DeleteAt
 
+	if appErr := updatedHook.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
 	newWebhook, err := a.Srv().Store().Webhook().UpdateIncoming(updatedHook)
 	if err != nil {
 		return nil, model.NewAppError("UpdateIncomingWebhook", "app.webhooks.update_incoming.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -550,7 +695,7 @@ func (a *App) CreateOutgoingWebhook(hook *model.OutgoingWebhook) (*model.Outgoin
 		if channel.Type != model.ChannelTypeOpen || channel.TeamId != hook.TeamId {
 			return nil, model.NewAppError("CreateOutgoingWebhook", "api.webhook.create_outgoing.permissions.app_error", nil, "", http.StatusForbidden)
 		}
-	} else if len(hook.TriggerWords) == 0 {
+	} else if len(hook.TriggerWords) == 0 && len(hook.EventTypes) == 0 {
 		return nil, model.NewAppError("CreateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusBadRequest)
 	}
 
@@ -602,7 +747,7 @@ func (a *App) UpdateOutgoingWebhook(c request.CTX, oldHook, updatedHook *model.O
 		if channel.TeamId != oldHook.TeamId {
 			return nil, model.NewAppError("UpdateOutgoingWebhook", "api.webhook.create_outgoing.permissions.app_error", nil, "", http.StatusForbidden)
 		}
-	} else if len(updatedHook.TriggerWords) == 0 {
+	} else if len(updatedHook.TriggerWords) == 0 && len(updatedHook.EventTypes) == 0 {
 		return nil, model.NewAppError("UpdateOutgoingWebhook", "api.webhook.create_outgoing.triggers.app_error", nil, "", http.StatusInternalServerError)
 	}
 
@@ -727,6 +872,29 @@ func (a *App) RegenOutgoingWebhookToken(hook *model.OutgoingWebhook) (*model.Out
 	return webhook, nil
 }
 
+// DecodeIncomingWebhookPayload decodes the JSON payload posted to the given incoming webhook,
+// rendering it through the transform template of the webhook if it has one.
+func (a *App) DecodeIncomingWebhookPayload(hookID string, payload io.Reader) (*model.IncomingWebhookRequest, *model.AppError) {
+	hook, err := a.Srv().Store().Webhook().GetIncoming(hookID, true)
+	if err != nil || hook.TransformTemplate == "" {
+		// An invalid webhook is reported when handling the request.
+		return model.IncomingWebhookRequestFromJSON(payload)
+	}
+
+	data, err := io.ReadAll(payload)
+	if err != nil {
+		return nil, model.NewAppError("DecodeIncomingWebhookPayload", "model.incoming_hook.parse_data.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return a.TransformIncomingWebhookPayload(hook.Id, hook.TransformTemplate, data)
+}
+
+// TransformIncomingWebhookPayload renders a transform template with the payload posted to the
+// given incoming webhook, capping the renders running at once on the server and for the webhook.
+func (a *App) TransformIncomingWebhookPayload(hookID, transformTemplate string, payload []byte) (*model.IncomingWebhookRequest, *model.AppError) {
+	return model.TransformIncomingWebhookPayload(&a.ch.incomingWebhookTransforms, hookID, transformTemplate, payload)
+}
+
 func (a *App) HandleIncomingWebhook(c request.CTX, hookID string, req *model.IncomingWebhookRequest) *model.AppError {
 	if !*a.Config().ServiceSettings.EnableIncomingWebhooks {
 		return model.NewAppError("HandleIncomingWebhook", "web.incoming_webhook.disabled.app_error", nil, "", http.StatusNotImplemented)
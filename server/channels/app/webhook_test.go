@@ -1035,6 +1035,123 @@ func TestTriggerOutGoingWebhookWithMultipleURLs(t *testing.T) {
 	}
 }
 
+func TestTriggerOutgoingWebhookEvents(t *testing.T) {
+	mainHelper.Parallel(t)
+
+	payloads := make(chan *model.OutgoingWebhookEventPayload, 10)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload model.OutgoingWebhookEventPayload
+		if err := json.NewDecoder(r.Body).Decode(&payload); err == nil {
+			payloads <- &payload
+		}
+	}))
+	defer ts.Close()
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableOutgoingWebhooks = true
+		*cfg.ServiceSettings.AllowedUntrustedInternalConnections = "localhost,127.0.0.1"
+	})
+
+	hook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+		TeamId:       th.BasicTeam.Id,
+		CreatorId:    th.BasicUser.Id,
+		CallbackURLs: []string{ts.URL},
+		ContentType:  "application/json",
+		EventTypes:   []string{model.OutgoingWebhookEventUserJoinedChannel, model.OutgoingWebhookEventReactionAdded},
+	})
+	require.Nil(t, appErr)
+
+	waitForPayload := func(t *testing.T) *model.OutgoingWebhookEventPayload {
+		t.Helper()
+		select {
+		case payload := <-payloads:
+			return payload
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "Timeout, webhook event not received")
+			return nil
+		}
+	}
+
+	t.Run("user joined channel", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		_, appErr := th.App.AddChannelMember(th.Context, th.BasicUser2.Id, channel, ChannelMemberOpts{UserRequestorID: th.BasicUser.Id})
+		require.Nil(t, appErr)
+
+		payload := waitForPayload(t)
+		assert.Equal(t, model.OutgoingWebhookEventUserJoinedChannel, payload.Event)
+		assert.Equal(t, hook.Token, payload.Token)
+		assert.Equal(t, th.BasicTeam.Name, payload.TeamDomain)
+		assert.Equal(t, channel.Id, payload.ChannelId)
+		assert.Equal(t, th.BasicUser2.Id, payload.UserId)
+		assert.Equal(t, th.BasicUser2.Username, payload.UserName)
+		assert.Equal(t, th.BasicUser.Id, payload.ActorId)
+	})
+
+	t.Run("reaction added", func(t *testing.T) {
+		_, appErr := th.App.SaveReactionForPost(th.Context, &model.Reaction{
+			UserId:    th.BasicUser.Id,
+			PostId:    th.BasicPost.Id,
+			EmojiName: "smile",
+		})
+		require.Nil(t, appErr)
+
+		payload := waitForPayload(t)
+		assert.Equal(t, model.OutgoingWebhookEventReactionAdded, payload.Event)
+		assert.Equal(t, th.BasicPost.Id, payload.PostId)
+		assert.Equal(t, "smile", payload.EmojiName)
+		assert.Equal(t, th.BasicUser.Id, payload.UserId)
+	})
+
+	t.Run("private channel events are not sent", func(t *testing.T) {
+		channel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+		_, appErr := th.App.AddChannelMember(th.Context, th.BasicUser2.Id, channel, ChannelMemberOpts{})
+		require.Nil(t, appErr)
+
+		select {
+		case payload := <-payloads:
+			require.Failf(t, "Unexpected webhook event", "%+v", payload)
+		case <-time.After(time.Second):
+		}
+	})
+
+	t.Run("posts without trigger words are not sent", func(t *testing.T) {
+		th.CreatePost(th.BasicChannel)
+
+		select {
+		case payload := <-payloads:
+			require.Failf(t, "Unexpected webhook event", "%+v", payload)
+		case <-time.After(time.Second):
+		}
+	})
+
+	t.Run("posts are sent to the webhooks subscribed to them", func(t *testing.T) {
+		channel := th.CreateChannel(th.Context, th.BasicTeam)
+		channelHook, appErr := th.App.CreateOutgoingWebhook(&model.OutgoingWebhook{
+			TeamId:       th.BasicTeam.Id,
+			ChannelId:    channel.Id,
+			CreatorId:    th.BasicUser.Id,
+			CallbackURLs: []string{ts.URL},
+			ContentType:  "application/json",
+			EventTypes:   []string{model.OutgoingWebhookEventReactionAdded, model.OutgoingWebhookEventPosted},
+		})
+		require.Nil(t, appErr)
+		defer func() {
+			require.Nil(t, th.App.DeleteOutgoingWebhook(channelHook.Id))
+		}()
+
+		post := th.CreatePost(channel)
+
+		payload := waitForPayload(t)
+		assert.Empty(t, payload.Event)
+		assert.Equal(t, channelHook.Token, payload.Token)
+		assert.Equal(t, channel.Id, payload.ChannelId)
+		assert.Equal(t, post.Id, payload.PostId)
+	})
+}
+
 type InfiniteReader struct {
 	Prefix string
 }
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_sharedchannelsyncsuppressions_channelid_createat;
+DROP TABLE IF EXISTS sharedchannelsyncsuppressions;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS sharedchannelsyncsuppressions (
+    remoteid varchar(26) NOT NULL,
+    itemid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    itemtype varchar(32) NOT NULL,
+    reason varchar(32) NOT NULL,
+    createat bigint NOT NULL,
+    PRIMARY KEY (remoteid, itemid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_sharedchannelsyncsuppressions_channelid_createat ON sharedchannelsyncsuppressions (channelid, createat);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_legalholdschannels_channelid;
+DROP TABLE IF EXISTS legalholdschannels;
+DROP INDEX IF EXISTS idx_legalholdsusers_userid;
+DROP TABLE IF EXISTS legalholdsusers;
+DROP INDEX IF EXISTS idx_legalholds_name;
+DROP TABLE IF EXISTS legalholds;
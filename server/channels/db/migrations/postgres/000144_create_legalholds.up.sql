@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS legalholds (
+    id varchar(26) PRIMARY KEY,
+    name varchar(64) NOT NULL,
+    displayname varchar(64) NOT NULL,
+    description varchar(1024),
+    startsat bigint NOT NULL DEFAULT 0,
+    endsat bigint NOT NULL DEFAULT 0,
+    creatorid varchar(26) NOT NULL,
+    createat bigint NOT NULL,
+    updateat bigint NOT NULL,
+    deleteat bigint NOT NULL DEFAULT 0
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_legalholds_name ON legalholds (name) WHERE deleteat = 0;
+
+CREATE TABLE IF NOT EXISTS legalholdsusers (
+    legalholdid varchar(26) NOT NULL,
+    userid varchar(26) NOT NULL,
+    PRIMARY KEY (legalholdid, userid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_legalholdsusers_userid ON legalholdsusers (userid);
+
+CREATE TABLE IF NOT EXISTS legalholdschannels (
+    legalholdid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    PRIMARY KEY (legalholdid, channelid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_legalholdschannels_channelid ON legalholdschannels (channelid);
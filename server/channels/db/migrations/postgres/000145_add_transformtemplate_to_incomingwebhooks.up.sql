@@ -0,0 +1 @@
+ALTER TABLE incomingwebhooks ADD COLUMN IF NOT EXISTS transformtemplate text NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE outgoingwebhooks DROP COLUMN IF EXISTS eventtypes;
@@ -0,0 +1 @@
+ALTER TABLE outgoingwebhooks ADD COLUMN IF NOT EXISTS eventtypes VARCHAR(1024) NOT NULL DEFAULT '[]';
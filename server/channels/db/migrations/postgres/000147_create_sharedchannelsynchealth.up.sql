@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS sharedchannelsynchealth (
+    remoteid varchar(26) NOT NULL,
+    channelid varchar(26) NOT NULL,
+    retrycount bigint NOT NULL DEFAULT 0,
+    lastsyncat bigint NOT NULL DEFAULT 0,
+    lasterror varchar(1024) NOT NULL DEFAULT '',
+    lasterrorat bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (remoteid, channelid)
+);
@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin_jobs
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+// MakeScheduler creates a scheduler creating a job of the given type every interval,
+// as long as the plugin that registered it is active on the cluster leader.
+func MakeScheduler(jobServer *jobs.JobServer, jobType, pluginID string, interval time.Duration, app AppIface) *jobs.PeriodicScheduler {
+	isEnabled := func(_ *model.Config) bool {
+		active, err := app.IsPluginActive(pluginID)
+		return err == nil && active
+	}
+	return jobs.NewPeriodicScheduler(jobServer, jobType, interval, isEnabled)
+}
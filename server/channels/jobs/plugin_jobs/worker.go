@@ -0,0 +1,149 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin_jobs
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+type AppIface interface {
+	IsPluginActive(pluginID string) (bool, error)
+	ExecutePluginJob(c request.CTX, pluginID string, job *model.Job) error
+}
+
+// Worker runs the jobs of a type registered by a plugin by invoking the ExecuteJob hook of that plugin.
+type Worker struct {
+	name      string
+	pluginID  string
+	stop      chan bool
+	stopped   chan bool
+	jobs      chan model.Job
+	jobServer *jobs.JobServer
+	logger    mlog.LoggerIFace
+	app       AppIface
+}
+
+func MakeWorker(jobServer *jobs.JobServer, jobType, pluginID string, app AppIface) *Worker {
+	worker := Worker{
+		name:      jobType,
+		pluginID:  pluginID,
+		stop:      make(chan bool, 1),
+		stopped:   make(chan bool, 1),
+		jobs:      make(chan model.Job),
+		jobServer: jobServer,
+		logger:    jobServer.Logger().With(mlog.String("worker_name", jobType), mlog.String("plugin_id", pluginID)),
+		app:       app,
+	}
+
+	return &worker
+}
+
+func (worker *Worker) Run() {
+	worker.logger.Debug("Worker started")
+
+	defer func() {
+		worker.logger.Debug("Worker finished")
+		worker.stopped <- true
+	}()
+
+	for {
+		select {
+		case <-worker.stop:
+			worker.logger.Debug("Worker received stop signal")
+			return
+		case job := <-worker.jobs:
+			worker.DoJob(&job)
+		}
+	}
+}
+
+func (worker *Worker) Stop() {
+	worker.logger.Debug("Worker stopping")
+	worker.stop <- true
+	<-worker.stopped
+}
+
+func (worker *Worker) JobChannel() chan<- model.Job {
+	return worker.jobs
+}
+
+func (worker *Worker) IsEnabled(cfg *model.Config) bool {
+	return true
+}
+
+func (worker *Worker) DoJob(job *model.Job) {
+	logger := worker.logger.With(jobs.JobLoggerFields(job)...)
+	logger.Debug("Worker: Received a new candidate job.")
+
+	// Leave the job pending for another node, or for later, while the plugin isn't running here.
+	if active, err := worker.app.IsPluginActive(worker.pluginID); err != nil || !active {
+		logger.Debug("Worker: Skipping job as the plugin is not active", mlog.Err(err))
+		return
+	}
+
+	var appErr *model.AppError
+	job, appErr = worker.jobServer.ClaimJob(job)
+	if appErr != nil {
+		logger.Warn("Worker experienced an error while trying to claim job", mlog.Err(appErr))
+		return
+	} else if job == nil {
+		return
+	}
+
+	c := request.EmptyContext(logger)
+	execErr := worker.app.ExecutePluginJob(c, worker.pluginID, job)
+
+	// The plugin may have updated the job while running it, so carry on from its current state.
+	current, appErr := worker.jobServer.GetJob(c, job.Id)
+	if appErr != nil {
+		logger.Error("Worker: Failed to get job", mlog.Err(appErr))
+		worker.setJobError(logger, job, appErr)
+		return
+	}
+
+	if current.Status == model.JobStatusCancelRequested {
+		logger.Info("Worker: Job has been canceled")
+		worker.setJobCanceled(logger, current)
+		return
+	}
+
+	if execErr != nil {
+		logger.Error("Worker: Failed to execute job", mlog.Err(execErr))
+		worker.setJobError(logger, current, model.NewAppError("DoJob", "app.job.error", nil, "", http.StatusInternalServerError).Wrap(execErr))
+		return
+	}
+
+	logger.Info("Worker: Job is complete")
+	worker.setJobSuccess(logger, current)
+}
+
+func (worker *Worker) setJobSuccess(logger mlog.LoggerIFace, job *model.Job) {
+	if err := worker.jobServer.SetJobProgress(job, 100); err != nil {
+		logger.Error("Worker: Failed to update progress for job", mlog.Err(err))
+		worker.setJobError(logger, job, err)
+		return
+	}
+
+	if err := worker.jobServer.SetJobSuccess(job); err != nil {
+		logger.Error("Worker: Failed to set success for job", mlog.Err(err))
+		worker.setJobError(logger, job, err)
+	}
+}
+
+func (worker *Worker) setJobCanceled(logger mlog.LoggerIFace, job *model.Job) {
+	if err := worker.jobServer.SetJobCanceled(job); err != nil {
+		logger.Error("Worker: Failed to mark job as canceled", mlog.Err(err))
+	}
+}
+
+func (worker *Worker) setJobError(logger mlog.LoggerIFace, job *model.Job, appError *model.AppError) {
+	if err := worker.jobServer.SetJobError(job, appError); err != nil {
+		logger.Error("Worker: Failed to set job error", mlog.Err(err))
+	}
+}
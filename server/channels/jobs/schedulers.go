@@ -6,6 +6,7 @@ package jobs
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -29,6 +30,9 @@ type Schedulers struct {
 	isLeader             bool
 	running              bool
 
+	// mut protects schedulers and nextRunTimes, since job types registered by
+	// plugins come and go while the schedulers run.
+	mut          sync.Mutex
 	schedulers   map[string]Scheduler
 	nextRunTimes map[string]*time.Time
 }
@@ -39,8 +43,31 @@ var (
 	ErrSchedulersUninitialized = errors.New("job schedulers are not initialized")
 )
 
+// AddScheduler registers the scheduler for the given job type. If the schedulers
+// are already running, its next run time is computed right away.
 func (schedulers *Schedulers) AddScheduler(name string, scheduler Scheduler) {
+	schedulers.mut.Lock()
+	defer schedulers.mut.Unlock()
+
 	schedulers.schedulers[name] = scheduler
+
+	if schedulers.running {
+		cfg := schedulers.jobs.Config()
+		if !schedulers.isLeader || !scheduler.Enabled(cfg) {
+			schedulers.nextRunTimes[name] = nil
+		} else {
+			schedulers.setNextRunTime(cfg, name, time.Now(), false)
+		}
+	}
+}
+
+// RemoveScheduler unregisters the scheduler for the given job type.
+func (schedulers *Schedulers) RemoveScheduler(name string) {
+	schedulers.mut.Lock()
+	defer schedulers.mut.Unlock()
+
+	delete(schedulers.schedulers, name)
+	delete(schedulers.nextRunTimes, name)
 }
 
 // Start starts the schedulers. This call is not safe for concurrent use.
@@ -59,6 +86,7 @@ func (schedulers *Schedulers) Start() {
 		}()
 
 		now := time.Now()
+		schedulers.mut.Lock()
 		for name, scheduler := range schedulers.schedulers {
 			if !scheduler.Enabled(schedulers.jobs.Config()) {
 				schedulers.nextRunTimes[name] = nil
@@ -66,6 +94,7 @@ func (schedulers *Schedulers) Start() {
 				schedulers.setNextRunTime(schedulers.jobs.Config(), name, now, false)
 			}
 		}
+		schedulers.mut.Unlock()
 
 		for {
 			timer := time.NewTimer(1 * time.Minute)
@@ -77,6 +106,7 @@ func (schedulers *Schedulers) Start() {
 			case now = <-timer.C:
 				cfg := schedulers.jobs.Config()
 
+				schedulers.mut.Lock()
 				for name, nextTime := range schedulers.nextRunTimes {
 					if nextTime == nil {
 						continue
@@ -95,7 +125,9 @@ func (schedulers *Schedulers) Start() {
 						schedulers.setNextRunTime(cfg, name, now, true)
 					}
 				}
+				schedulers.mut.Unlock()
 			case newCfg := <-schedulers.configChanged:
+				schedulers.mut.Lock()
 				for name, scheduler := range schedulers.schedulers {
 					if !schedulers.isLeader || !scheduler.Enabled(newCfg) {
 						schedulers.nextRunTimes[name] = nil
@@ -103,21 +135,26 @@ func (schedulers *Schedulers) Start() {
 						schedulers.setNextRunTime(newCfg, name, now, false)
 					}
 				}
+				schedulers.mut.Unlock()
 			case isLeader := <-schedulers.clusterLeaderChanged:
+				schedulers.mut.Lock()
+				schedulers.isLeader = isLeader
 				for name := range schedulers.schedulers {
-					schedulers.isLeader = isLeader
 					if !isLeader {
 						schedulers.nextRunTimes[name] = nil
 					} else {
 						schedulers.setNextRunTime(schedulers.jobs.Config(), name, now, false)
 					}
 				}
+				schedulers.mut.Unlock()
 			}
 			timer.Stop()
 		}
 	}()
 
+	schedulers.mut.Lock()
 	schedulers.running = true
+	schedulers.mut.Unlock()
 }
 
 // Stop stops the schedulers. This call is not safe for concurrent use.
@@ -128,7 +165,9 @@ func (schedulers *Schedulers) Stop() {
 	<-schedulers.stopped
 	schedulers.jobs.ConfigService.RemoveConfigListener(schedulers.listenerId)
 	schedulers.listenerId = ""
+	schedulers.mut.Lock()
 	schedulers.running = false
+	schedulers.mut.Unlock()
 }
 
 func (schedulers *Schedulers) setNextRunTime(cfg *model.Config, name string, now time.Time, pendingJobs bool) {
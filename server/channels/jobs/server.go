@@ -20,7 +20,9 @@ type JobServer struct {
 	metrics       einterfaces.MetricsInterface
 	logger        mlog.LoggerIFace
 
-	// mut is used to protect the following fields from concurrent access.
+	// mut serializes starting and stopping the following. They are set up when the job
+	// server is created, and job types are registered with them without holding mut, so
+	// that plugins can register and unregister job types while the workers are stopping.
 	mut        sync.Mutex
 	workers    *Workers
 	schedulers *Schedulers
@@ -66,8 +68,6 @@ func (srv *JobServer) Logger() mlog.LoggerIFace {
 }
 
 func (srv *JobServer) RegisterJobType(name string, worker model.Worker, scheduler Scheduler) {
-	srv.mut.Lock()
-	defer srv.mut.Unlock()
 	if worker != nil {
 		srv.workers.AddWorker(name, worker)
 	}
@@ -76,6 +76,17 @@ func (srv *JobServer) RegisterJobType(name string, worker model.Worker, schedule
 	}
 }
 
+// UnregisterJobType removes the worker and scheduler of the given job type, stopping
+// the worker once it's done with its current job. Existing jobs of that type are left untouched.
+func (srv *JobServer) UnregisterJobType(name string) {
+	if srv.workers != nil {
+		srv.workers.RemoveWorker(name)
+	}
+	if srv.schedulers != nil {
+		srv.schedulers.RemoveScheduler(name)
+	}
+}
+
 func (srv *JobServer) StartWorkers() error {
 	srv.mut.Lock()
 	defer srv.mut.Unlock()
@@ -8,7 +8,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
 func TestStartWorkers(t *testing.T) {
@@ -138,3 +142,95 @@ func TestStopSchedulers(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestRegisterJobTypeWhileRunning(t *testing.T) {
+	if os.Getenv("ENABLE_FULLY_PARALLEL_TESTS") == "true" {
+		t.Parallel()
+	}
+
+	t.Run("workers", func(t *testing.T) {
+		jobServer, _, _ := makeJobServer(t)
+		jobServer.initWorkers()
+		err := jobServer.StartWorkers()
+		require.NoError(t, err)
+		// Parking the go routing to let the worker watcher start
+		time.Sleep(1 * time.Millisecond)
+
+		worker := NewSimpleWorker("test", jobServer, func(_ mlog.LoggerIFace, _ *model.Job) error { return nil }, func(_ *model.Config) bool { return true })
+		jobServer.RegisterJobType("test", worker, nil)
+		require.Equal(t, worker, jobServer.workers.Get("test"))
+
+		jobServer.UnregisterJobType("test")
+		require.Nil(t, jobServer.workers.Get("test"))
+		select {
+		case <-worker.stopped:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "worker started on registration was not stopped")
+		}
+
+		err = jobServer.StopWorkers()
+		require.NoError(t, err)
+	})
+
+	t.Run("unregistering while the workers stop", func(t *testing.T) {
+		jobServer, _, _ := makeJobServer(t)
+		jobServer.initWorkers()
+		err := jobServer.StartWorkers()
+		require.NoError(t, err)
+		// Parking the go routing to let the worker watcher start
+		time.Sleep(1 * time.Millisecond)
+
+		jobServer.RegisterJobType("test", NewSimpleWorker("test", jobServer, func(_ mlog.LoggerIFace, _ *model.Job) error { return nil }, func(_ *model.Config) bool { return true }), nil)
+		// the job of this worker unregisters the other job type, as a plugin being
+		// disabled by its job would, while the worker waits for it to stop
+		jobServer.RegisterJobType("unregistering", &stopFuncWorker{stop: func() { jobServer.UnregisterJobType("test") }}, nil)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			err = jobServer.StopWorkers()
+		}()
+		select {
+		case <-done:
+			require.NoError(t, err)
+		case <-time.After(time.Duration(DefaultWatcherPollingInterval)*time.Millisecond + 5*time.Second):
+			// the watcher waits for up to a polling interval before it can be stopped
+			require.Fail(t, "stopping the workers deadlocked")
+		}
+		require.Nil(t, jobServer.workers.Get("test"))
+	})
+
+	t.Run("schedulers", func(t *testing.T) {
+		jobServer, mockStore, _ := makeJobServer(t)
+		mockStore.JobStore.On("GetCountByStatusAndType", model.JobStatusPending, "test").Return(int64(0), nil)
+		mockStore.JobStore.On("GetNewestJobByStatusesAndType", []string{model.JobStatusSuccess}, "test").Return(nil, nil)
+
+		jobServer.initSchedulers()
+		err := jobServer.StartSchedulers()
+		require.NoError(t, err)
+
+		jobServer.RegisterJobType("test", nil, new(MockScheduler))
+		jobServer.schedulers.mut.Lock()
+		assert.NotNil(t, jobServer.schedulers.nextRunTimes["test"])
+		jobServer.schedulers.mut.Unlock()
+
+		jobServer.UnregisterJobType("test")
+		jobServer.schedulers.mut.Lock()
+		assert.NotContains(t, jobServer.schedulers.nextRunTimes, "test")
+		assert.NotContains(t, jobServer.schedulers.schedulers, "test")
+		jobServer.schedulers.mut.Unlock()
+
+		err = jobServer.StopSchedulers()
+		require.NoError(t, err)
+	})
+}
+
+// stopFuncWorker is a worker calling the given function when it's stopped.
+type stopFuncWorker struct {
+	stop func()
+}
+
+func (w *stopFuncWorker) Run()                           {}
+func (w *stopFuncWorker) Stop()                          { w.stop() }
+func (w *stopFuncWorker) JobChannel() chan<- model.Job   { return nil }
+func (w *stopFuncWorker) IsEnabled(_ *model.Config) bool { return true }
@@ -5,6 +5,7 @@ package jobs
 
 import (
 	"errors"
+	"sync"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/configservice"
@@ -15,6 +16,8 @@ type Workers struct {
 	ConfigService configservice.ConfigService
 	Watcher       *Watcher
 
+	// mut protects workers, since job types registered by plugins come and go while the workers run.
+	mut     sync.RWMutex
 	workers map[string]model.Worker
 
 	listenerId string
@@ -34,11 +37,40 @@ func NewWorkers(configService configservice.ConfigService) *Workers {
 	}
 }
 
+// AddWorker registers the worker for the given job type, starting it right away
+// if the workers are already running.
 func (workers *Workers) AddWorker(name string, worker model.Worker) {
+	workers.mut.Lock()
+	defer workers.mut.Unlock()
+
 	workers.workers[name] = worker
+
+	if workers.running && worker.IsEnabled(workers.ConfigService.Config()) {
+		go worker.Run()
+	}
+}
+
+// RemoveWorker unregisters the worker for the given job type. If the workers are
+// running, the worker is stopped in the background, after it's done with its current job.
+func (workers *Workers) RemoveWorker(name string) {
+	workers.mut.Lock()
+	defer workers.mut.Unlock()
+
+	worker, ok := workers.workers[name]
+	if !ok {
+		return
+	}
+	delete(workers.workers, name)
+
+	if workers.running && worker.IsEnabled(workers.ConfigService.Config()) {
+		go worker.Stop()
+	}
 }
 
 func (workers *Workers) Get(name string) model.Worker {
+	workers.mut.RLock()
+	defer workers.mut.RUnlock()
+
 	return workers.workers[name]
 }
 
@@ -47,6 +79,9 @@ func (workers *Workers) Get(name string) model.Worker {
 func (workers *Workers) Start() {
 	mlog.Info("Starting workers")
 
+	workers.mut.Lock()
+	defer workers.mut.Unlock()
+
 	for _, w := range workers.workers {
 		if w.IsEnabled(workers.ConfigService.Config()) {
 			go w.Run()
@@ -62,7 +97,7 @@ func (workers *Workers) Start() {
 func (workers *Workers) handleConfigChange(oldConfig *model.Config, newConfig *model.Config) {
 	mlog.Debug("Workers received config change.")
 
-	for _, w := range workers.workers {
+	for _, w := range workers.all() {
 		if w.IsEnabled(oldConfig) && !w.IsEnabled(newConfig) {
 			w.Stop()
 		}
@@ -79,13 +114,33 @@ func (workers *Workers) Stop() {
 
 	workers.Watcher.Stop()
 
+	// The workers are stopped without holding the lock, since stopping a worker waits for
+	// its current job, which may add or remove the workers of plugin job types.
+	workers.mut.Lock()
+	workers.running = false
+	running := make([]model.Worker, 0, len(workers.workers))
 	for _, w := range workers.workers {
+		running = append(running, w)
+	}
+	workers.mut.Unlock()
+
+	for _, w := range running {
 		if w.IsEnabled(workers.ConfigService.Config()) {
 			w.Stop()
 		}
 	}
 
-	workers.running = false
-
 	mlog.Info("Stopped workers")
 }
+
+// all returns a copy of the registered workers.
+func (workers *Workers) all() []model.Worker {
+	workers.mut.RLock()
+	defer workers.mut.RUnlock()
+
+	all := make([]model.Worker, 0, len(workers.workers))
+	for _, w := range workers.workers {
+		all = append(all, w)
+	}
+	return all
+}
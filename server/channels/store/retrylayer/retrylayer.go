@@ -37,6 +37,7 @@ type RetryLayer struct {
 	FileInfoStore                   store.FileInfoStore
 	GroupStore                      store.GroupStore
 	JobStore                        store.JobStore
+	LegalHoldStore                  store.LegalHoldStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
 	NotifyAdminStore                store.NotifyAdminStore
@@ -141,6 +142,10 @@ func (s *RetryLayer) Job() store.JobStore {
 	return s.JobStore
 }
 
+func (s *RetryLayer) LegalHold() store.LegalHoldStore {
+	return s.LegalHoldStore
+}
+
 func (s *RetryLayer) License() store.LicenseStore {
 	return s.LicenseStore
 }
@@ -362,6 +367,11 @@ type RetryLayerJobStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerLegalHoldStore struct {
+	store.LegalHoldStore
+	Root *RetryLayer
+}
+
 type RetryLayerLicenseStore struct {
 	store.LicenseStore
 	Root *RetryLayer
@@ -6627,6 +6637,153 @@ func (s *RetryLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 
 }
 
+func (s *RetryLayerLegalHoldStore) Delete(id string, deleteAt int64) error {
+
+	tries := 0
+	for {
+		err := s.LegalHoldStore.Delete(id, deleteAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerLegalHoldStore) Get(id string) (*model.LegalHold, error) {
+
+	tries := 0
+	for {
+		result, err := s.LegalHoldStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerLegalHoldStore) GetAll(offset int, limit int) ([]*model.LegalHold, error) {
+
+	tries := 0
+	for {
+		result, err := s.LegalHoldStore.GetAll(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerLegalHoldStore) GetForChannel(channelID string) ([]*model.LegalHold, error) {
+
+	tries := 0
+	for {
+		result, err := s.LegalHoldStore.GetForChannel(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerLegalHoldStore) GetForUser(userID string) ([]*model.LegalHold, error) {
+
+	tries := 0
+	for {
+		result, err := s.LegalHoldStore.GetForUser(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerLegalHoldStore) Save(hold *model.LegalHold) (*model.LegalHold, error) {
+
+	tries := 0
+	for {
+		result, err := s.LegalHoldStore.Save(hold)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerLegalHoldStore) Update(hold *model.LegalHold) (*model.LegalHold, error) {
+
+	tries := 0
+	for {
+		result, err := s.LegalHoldStore.Update(hold)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerLicenseStore) Get(c request.CTX, id string) (*model.LicenseRecord, error) {
 
 	tries := 0
@@ -7425,6 +7582,27 @@ func (s *RetryLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 
 }
 
+func (s *RetryLayerPluginStore) CompareAndSwapMany(pluginID string, operations []*model.PluginKVCompareAndSwap) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.CompareAndSwapMany(pluginID, operations)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) Delete(pluginID string, key string) error {
 
 	tries := 0
@@ -7509,6 +7687,27 @@ func (s *RetryLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 
 }
 
+func (s *RetryLayerPluginStore) GetMany(pluginID string, keys []string) ([]*model.PluginKeyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.GetMany(pluginID, keys)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 
 	tries := 0
@@ -7530,6 +7729,27 @@ func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 
 }
 
+func (s *RetryLayerPluginStore) ListWithValues(pluginID string, prefix string, offset int, limit int) ([]*model.PluginKeyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.ListWithValues(pluginID, prefix, offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 
 	tries := 0
@@ -7551,6 +7771,27 @@ func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 
 }
 
+func (s *RetryLayerPluginStore) SetMany(kvs []*model.PluginKeyValue) error {
+
+	tries := 0
+	for {
+		err := s.PluginStore.SetMany(kvs)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 
 	tries := 0
@@ -7662,6 +7903,27 @@ func (s *RetryLayerPostStore) ClearCaches() {
 
 }
 
+func (s *RetryLayerPostStore) CountPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor) (int64, int64, error) {
+
+	tries := 0
+	for {
+		result, resultVar1, err := s.PostStore.CountPostsSinceForSync(options, cursor)
+		if err == nil {
+			return result, resultVar1, nil
+		}
+		if !isRepeatableError(err) {
+			return result, resultVar1, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, resultVar1, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
 
 	tries := 0
@@ -11760,6 +12022,48 @@ func (s *RetryLayerSharedChannelStore) GetSingleUser(userID string, channelID st
 
 }
 
+func (s *RetryLayerSharedChannelStore) GetSyncHealth(remoteID string) ([]*model.SharedChannelSyncHealth, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetSyncHealth(remoteID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) GetSyncSuppressions(offset int, limit int, opts model.SharedChannelSyncSuppressionFilterOpts) ([]*model.SharedChannelSyncSuppression, error) {
+
+	tries := 0
+	for {
+		result, err := s.SharedChannelStore.GetSyncSuppressions(offset, limit, opts)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerSharedChannelStore) GetUserChanges(userID string, channelID string, afterTime int64) ([]*model.SharedChannelUser, error) {
 
 	tries := 0
@@ -11928,6 +12232,69 @@ func (s *RetryLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRem
 
 }
 
+func (s *RetryLayerSharedChannelStore) SaveSyncError(channelID string, remoteID string, syncError string, errorAt int64, retry bool) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.SaveSyncError(channelID, remoteID, syncError, errorAt, retry)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) SaveSyncSuccess(channelID string, remoteID string, syncAt int64) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.SaveSyncSuccess(channelID, remoteID, syncAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSharedChannelStore) SaveSyncSuppressions(suppressions []*model.SharedChannelSyncSuppression) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.SaveSyncSuppressions(suppressions)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
 
 	tries := 0
@@ -12054,6 +12421,27 @@ func (s *RetryLayerSharedChannelStore) UpdateRemoteMembershipCursor(id string, s
 
 }
 
+func (s *RetryLayerSharedChannelStore) UpdateRemoteSyncFilters(id string, filters *model.SharedChannelRemoteSyncFilters) error {
+
+	tries := 0
+	for {
+		err := s.SharedChannelStore.UpdateRemoteSyncFilters(id, filters)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerSharedChannelStore) UpdateUserLastMembershipSyncAt(userID string, channelID string, remoteID string, syncTime int64) error {
 
 	tries := 0
@@ -16846,6 +17234,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.FileInfoStore = &RetryLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &RetryLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LegalHoldStore = &RetryLayerLegalHoldStore{LegalHoldStore: childStore.LegalHold(), Root: &newStore}
 	newStore.LicenseStore = &RetryLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &RetryLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.NotifyAdminStore = &RetryLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
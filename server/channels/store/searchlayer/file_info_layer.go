@@ -19,7 +19,7 @@ type SearchFileInfoStore struct {
 }
 
 func (s SearchFileInfoStore) indexFile(rctx request.CTX, file *model.FileInfo) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.rootStore.searchEngine.GetActiveContentEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				if file.PostId == "" && file.CreatorId != model.BookmarkFileOwner {
@@ -41,7 +41,7 @@ func (s SearchFileInfoStore) indexFile(rctx request.CTX, file *model.FileInfo) {
 }
 
 func (s SearchFileInfoStore) deleteFileIndex(rctx request.CTX, fileID string) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.rootStore.searchEngine.GetActiveContentEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				if err := engineCopy.DeleteFile(fileID); err != nil {
@@ -54,7 +54,7 @@ func (s SearchFileInfoStore) deleteFileIndex(rctx request.CTX, fileID string) {
 }
 
 func (s SearchFileInfoStore) deleteFileIndexForUser(rctx request.CTX, userID string) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.rootStore.searchEngine.GetActiveContentEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				if err := engineCopy.DeleteUserFiles(rctx, userID); err != nil {
@@ -69,7 +69,7 @@ func (s SearchFileInfoStore) deleteFileIndexForUser(rctx request.CTX, userID str
 
 //nolint:unused // Temporarily unused until the post_id is indexed with the file
 func (s SearchFileInfoStore) deleteFileIndexForPost(rctx request.CTX, postID string) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.rootStore.searchEngine.GetActiveContentEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				if err := engineCopy.DeletePostFiles(rctx, postID); err != nil {
@@ -83,7 +83,7 @@ func (s SearchFileInfoStore) deleteFileIndexForPost(rctx request.CTX, postID str
 }
 
 func (s SearchFileInfoStore) deleteFileIndexBatch(rctx request.CTX, endTime, limit int64) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.rootStore.searchEngine.GetActiveContentEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				if err := engineCopy.DeleteFilesBatch(rctx, endTime, limit); err != nil {
@@ -182,7 +182,7 @@ func (s SearchFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userId stri
 }
 
 func (s SearchFileInfoStore) Search(rctx request.CTX, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.FileInfoList, error) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.rootStore.searchEngine.GetActiveContentEngines() {
 		if engine.IsSearchEnabled() {
 			userChannels, nErr := s.rootStore.Channel().GetChannels(teamId, userId, &model.ChannelSearchOpts{
 				IncludeDeleted: paramsList[0].IncludeDeletedChannels,
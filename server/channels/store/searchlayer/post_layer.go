@@ -4,6 +4,8 @@
 package searchlayer
 
 import (
+	"sort"
+
 	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -18,8 +20,18 @@ type SearchPostStore struct {
 	rootStore *SearchStore
 }
 
+// postIndexingEngines returns the active content engines along with the
+// semantic engine, which only indexes posts.
+func (s SearchPostStore) postIndexingEngines() []searchengine.SearchEngineInterface {
+	engines := s.rootStore.searchEngine.GetActiveContentEngines()
+	if semanticEngine := s.rootStore.searchEngine.GetActiveSemanticEngine(); semanticEngine != nil {
+		engines = append(engines, semanticEngine)
+	}
+	return engines
+}
+
 func (s SearchPostStore) indexPost(rctx request.CTX, post *model.Post) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.postIndexingEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				channel, chanErr := s.rootStore.Channel().Get(post.ChannelId, true)
@@ -37,7 +49,7 @@ func (s SearchPostStore) indexPost(rctx request.CTX, post *model.Post) {
 }
 
 func (s SearchPostStore) deletePostIndex(rctx request.CTX, post *model.Post) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.postIndexingEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				if err := engineCopy.DeletePost(post); err != nil {
@@ -50,7 +62,7 @@ func (s SearchPostStore) deletePostIndex(rctx request.CTX, post *model.Post) {
 }
 
 func (s SearchPostStore) deleteChannelPostsIndex(rctx request.CTX, channelID string) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.postIndexingEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				if err := engineCopy.DeleteChannelPosts(rctx, channelID); err != nil {
@@ -64,7 +76,7 @@ func (s SearchPostStore) deleteChannelPostsIndex(rctx request.CTX, channelID str
 }
 
 func (s SearchPostStore) deleteUserPostsIndex(rctx request.CTX, userID string) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	for _, engine := range s.postIndexingEngines() {
 		if engine.IsIndexingEnabled() {
 			runIndexFn(rctx, engine, func(engineCopy searchengine.SearchEngineInterface) {
 				if err := engineCopy.DeleteUserPosts(rctx, userID); err != nil {
@@ -166,26 +178,49 @@ func (s SearchPostStore) searchPostsForUserByEngine(engine searchengine.SearchEn
 		return nil, err
 	}
 
-	// Get the posts
+	// Get the posts, dropping any the engine returned from channels the user isn't a member of.
+	userChannelIds := make(map[string]bool, len(userChannels))
+	for _, channel := range userChannels {
+		userChannelIds[channel.Id] = true
+	}
 	postList := model.NewPostList()
+	postMatches := model.PostSearchMatches{}
 	if len(postIds) > 0 {
 		posts, err := s.PostStore.GetPostsByIds(postIds)
 		if err != nil {
 			return nil, err
 		}
 		for _, p := range posts {
-			if p.DeleteAt == 0 {
+			if p.DeleteAt == 0 && userChannelIds[p.ChannelId] {
 				postList.AddPost(p)
 				postList.AddOrder(p.Id)
+				if m, ok := matches[p.Id]; ok {
+					postMatches[p.Id] = m
+				}
 			}
 		}
 	}
 
-	return model.MakePostSearchResults(postList, matches), nil
+	return model.MakePostSearchResults(postList, postMatches), nil
 }
 
 func (s SearchPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
-	for _, engine := range s.rootStore.searchEngine.GetActiveEngines() {
+	// Invalid params are rejected by every engine, so there is no point in
+	// falling back to the next one
+	if err := model.IsSearchParamsListValid(paramsList); err != nil {
+		return nil, err
+	}
+
+	semanticEngine := s.rootStore.searchEngine.GetActiveSemanticEngine()
+	if semanticEngine != nil && semanticEngine.IsSearchEnabled() && paramsList[0].SemanticWeight > 0 {
+		return s.searchPostsForUserHybrid(rctx, semanticEngine, paramsList, userId, teamId, page, perPage)
+	}
+
+	return s.searchPostsForUserByKeywords(rctx, paramsList, userId, teamId, page, perPage)
+}
+
+func (s SearchPostStore) searchPostsForUserByKeywords(rctx request.CTX, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
+	for _, engine := range s.rootStore.searchEngine.GetActiveContentEngines() {
 		if engine.IsSearchEnabled() {
 			results, err := s.searchPostsForUserByEngine(engine, paramsList, userId, teamId, page, perPage)
 			if err != nil {
@@ -202,3 +237,69 @@ func (s SearchPostStore) SearchPostsForUser(rctx request.CTX, paramsList []*mode
 
 	return s.PostStore.SearchPostsForUser(rctx, paramsList, userId, teamId, page, perPage)
 }
+
+// searchPostsForUserHybrid merges the results of the keyword search with the
+// ones of the semantic engine. As the scores of both searches are not
+// comparable, both lists are fetched up to the requested page and merged by
+// rank. If the semantic search fails, the keyword results are returned.
+func (s SearchPostStore) searchPostsForUserHybrid(rctx request.CTX, semanticEngine searchengine.SearchEngineInterface, paramsList []*model.SearchParams, userId, teamId string, page, perPage int) (*model.PostSearchResults, error) {
+	keywordResults, err := s.searchPostsForUserByKeywords(rctx, paramsList, userId, teamId, 0, (page+1)*perPage)
+	if err != nil {
+		return nil, err
+	}
+
+	semanticResults, err := s.searchPostsForUserByEngine(semanticEngine, paramsList, userId, teamId, 0, (page+1)*perPage)
+	if err != nil {
+		rctx.Logger().Warn("Encountered error on the semantic search, falling back to the keyword search.", mlog.String("search_engine", semanticEngine.GetName()), mlog.Err(err))
+		semanticResults = model.MakePostSearchResults(model.NewPostList(), model.PostSearchMatches{})
+	}
+
+	return fusePostSearchResults(keywordResults, semanticResults, paramsList[0].SemanticWeight, page, perPage), nil
+}
+
+// hybridRankConstant dampens the weight of the first ranks in the reciprocal
+// rank fusion, the usual value is 60.
+const hybridRankConstant = 60
+
+// fusePostSearchResults ranks the posts of both results by weighted
+// reciprocal rank fusion, where a post scores (1-w)/(k+rank) for its rank in
+// the keyword results plus w/(k+rank) for its rank in the semantic results,
+// and returns the requested page.
+func fusePostSearchResults(keywordResults, semanticResults *model.PostSearchResults, semanticWeight float64, page, perPage int) *model.PostSearchResults {
+	scores := map[string]float64{}
+	var order []string
+
+	addScores := func(postIds []string, weight float64) {
+		for rank, postId := range postIds {
+			if _, ok := scores[postId]; !ok {
+				order = append(order, postId)
+			}
+			scores[postId] += weight / float64(hybridRankConstant+rank+1)
+		}
+	}
+	addScores(keywordResults.Order, 1-semanticWeight)
+	addScores(semanticResults.Order, semanticWeight)
+
+	// Ties keep the keyword order first.
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] > scores[order[j]]
+	})
+
+	postList := model.NewPostList()
+	matches := model.PostSearchMatches{}
+	for i := page * perPage; i < len(order) && i < (page+1)*perPage; i++ {
+		postId := order[i]
+		post, ok := keywordResults.Posts[postId]
+		if !ok {
+			post = semanticResults.Posts[postId]
+		}
+		postList.AddPost(post)
+		postList.AddOrder(postId)
+
+		if postMatches, ok := keywordResults.Matches[postId]; ok {
+			matches[postId] = postMatches
+		}
+	}
+
+	return model.MakePostSearchResults(postList, matches)
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package searchlayer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+	searchenginemocks "github.com/mattermost/mattermost/server/v8/platform/services/searchengine/mocks"
+)
+
+func makeSearchResults(postIds ...string) *model.PostSearchResults {
+	postList := model.NewPostList()
+	matches := model.PostSearchMatches{}
+	for _, postId := range postIds {
+		postList.AddPost(&model.Post{Id: postId})
+		postList.AddOrder(postId)
+		matches[postId] = []string{"match-" + postId}
+	}
+	return model.MakePostSearchResults(postList, matches)
+}
+
+func TestFusePostSearchResults(t *testing.T) {
+	keywordResults := makeSearchResults("a", "b", "c")
+	semanticResults := makeSearchResults("d", "c", "a")
+	semanticResults.Matches = model.PostSearchMatches{}
+
+	t.Run("should rank posts found by both searches first", func(t *testing.T) {
+		results := fusePostSearchResults(keywordResults, semanticResults, 0.5, 0, 10)
+		assert.Equal(t, []string{"a", "c", "d", "b"}, results.Order)
+		assert.Len(t, results.Posts, 4)
+		assert.Equal(t, model.PostSearchMatches{
+			"a": {"match-a"},
+			"b": {"match-b"},
+			"c": {"match-c"},
+		}, results.Matches)
+	})
+
+	t.Run("should follow the weight", func(t *testing.T) {
+		results := fusePostSearchResults(keywordResults, semanticResults, 0.01, 0, 10)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, results.Order)
+
+		results = fusePostSearchResults(keywordResults, semanticResults, 1, 0, 10)
+		assert.Equal(t, []string{"d", "c", "a", "b"}, results.Order)
+	})
+
+	t.Run("should paginate", func(t *testing.T) {
+		results := fusePostSearchResults(keywordResults, semanticResults, 0.5, 1, 3)
+		assert.Equal(t, []string{"b"}, results.Order)
+		assert.Len(t, results.Posts, 1)
+
+		results = fusePostSearchResults(keywordResults, semanticResults, 0.5, 2, 3)
+		assert.Empty(t, results.Order)
+	})
+}
+
+func TestSearchPostsForUserByEngine(t *testing.T) {
+	memberChannel := &model.Channel{Id: model.NewId()}
+	otherChannelId := model.NewId()
+	visiblePost := &model.Post{Id: model.NewId(), ChannelId: memberChannel.Id}
+	hiddenPost := &model.Post{Id: model.NewId(), ChannelId: otherChannelId}
+	deletedPost := &model.Post{Id: model.NewId(), ChannelId: memberChannel.Id, DeleteAt: 1}
+
+	channelStore := &mocks.ChannelStore{}
+	channelStore.On("GetChannels", "team_id", "user_id", mock.Anything).Return(model.ChannelList{memberChannel}, nil)
+	postStore := &mocks.PostStore{}
+	postStore.On("GetPostsByIds", []string{visiblePost.Id, hiddenPost.Id, deletedPost.Id}).Return([]*model.Post{visiblePost, hiddenPost, deletedPost}, nil)
+
+	rootStore := &SearchStore{}
+	rootStore.channel = &SearchChannelStore{ChannelStore: channelStore, rootStore: rootStore}
+	postLayer := SearchPostStore{PostStore: postStore, rootStore: rootStore}
+
+	// An engine returning posts of channels the user isn't a member of, e.g. a plugin backend.
+	engine := &searchenginemocks.SearchEngineInterface{}
+	engine.On("SearchPosts", model.ChannelList{memberChannel}, mock.Anything, 0, 20).Return(
+		[]string{visiblePost.Id, hiddenPost.Id, deletedPost.Id},
+		model.PostSearchMatches{visiblePost.Id: {"visible"}, hiddenPost.Id: {"hidden"}},
+		nil,
+	)
+
+	results, err := postLayer.searchPostsForUserByEngine(engine, []*model.SearchParams{{Terms: "test"}}, "user_id", "team_id", 0, 20)
+	require.NoError(t, err)
+	assert.Equal(t, []string{visiblePost.Id}, results.Order)
+	assert.Len(t, results.Posts, 1)
+	assert.Equal(t, model.PostSearchMatches{visiblePost.Id: {"visible"}}, results.Matches)
+}
@@ -33,31 +33,33 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should be able to search for quoted patterns with AND OR combinations",
 		Fn:   testSearchANDORQuotesCombinations,
-		Tags: []string{EnginePostgres, EngineElasticSearch},
+		Tags: []string{EnginePostgres, EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should be able to search without stemming",
 		Fn:   testStemming,
-		Tags: []string{EnginePostgres},
+		Tags: []string{EnginePostgres, EngineBleve},
 	},
 	{
 		// Postgres supports search with and without quotes
 		Name: "Should be able to search for email addresses with or without quotes",
 		Fn:   testSearchEmailAddresses,
-		Tags: []string{EnginePostgres, EngineElasticSearch},
+		Tags: []string{EnginePostgres, EngineElasticSearch, EngineBleve},
 	},
 	{
 		// MySql supports search with quotes only
 		Name: "Should be able to search for email addresses with quotes",
 		Fn:   testSearchEmailAddressesWithQuotes,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should be able to search when markdown underscores are applied",
 		Fn:   testSearchMarkdownUnderscores,
-		Tags: []string{EnginePostgres, EngineElasticSearch},
+		Tags: []string{EnginePostgres, EngineElasticSearch, EngineBleve},
 	},
 	{
+		// Bleve splits Chinese text into single characters, so "你" also
+		// matches "你好"
 		Name: "Should be able to search for non-latin words",
 		Fn:   testSearchNonLatinWords,
 		Tags: []string{EngineElasticSearch},
@@ -65,12 +67,12 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should be able to search for alternative spellings of words",
 		Fn:   testSearchAlternativeSpellings,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should be able to search for alternative spellings of words with and without accents",
 		Fn:   testSearchAlternativeSpellingsAccents,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should be able to search or exclude messages written by a specific user",
@@ -105,12 +107,12 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should be able to exclude messages that contain a search term",
 		Fn:   testFilterMessagesWithATerm,
-		Tags: []string{EnginePostgres},
+		Tags: []string{EnginePostgres, EngineBleve},
 	},
 	{
 		Name: "Should be able to search using boolean operators",
 		Fn:   testSearchUsingBooleanOperators,
-		Tags: []string{EnginePostgres, EngineElasticSearch},
+		Tags: []string{EnginePostgres, EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should be able to search with combined filters",
@@ -120,12 +122,12 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should be able to ignore stop words",
 		Fn:   testSearchIgnoringStopWords,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should support search stemming",
 		Fn:   testSupportStemming,
-		Tags: []string{EnginePostgres, EngineElasticSearch},
+		Tags: []string{EnginePostgres, EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should support search with wildcards",
@@ -151,7 +153,7 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should support terms with underscore",
 		Fn:   testSupportTermsWithUnderscore,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should search or exclude post using hashtags",
@@ -166,7 +168,7 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should support searching for multiple hashtags",
 		Fn:   testSearchWithMultipleHashtags,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should support searching hashtags with dots",
@@ -216,7 +218,7 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should be able to search in deleted/archived channels",
 		Fn:   testSearchInDeletedOrArchivedChannels,
-		Tags: []string{EnginePostgres},
+		Tags: []string{EnginePostgres, EngineBleve},
 	},
 	{
 		Name:        "Should be able to search terms with dashes",
@@ -228,12 +230,12 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should be able to search terms with dots",
 		Fn:   testSearchTermsWithDots,
-		Tags: []string{EnginePostgres, EngineElasticSearch},
+		Tags: []string{EnginePostgres, EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should be able to search terms with underscores",
 		Fn:   testSearchTermsWithUnderscores,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should be able to search posts made by bot accounts",
@@ -241,6 +243,8 @@ var searchPostStoreTests = []searchTest{
 		Tags: []string{EngineAll},
 	},
 	{
+		// Bleve matches wildcards against the words as written, the same
+		// as Postgres, so "approve*" also matches "approve" and "approved"
 		Name: "Should be able to combine stemming and wildcards",
 		Fn:   testSupportStemmingAndWildcards,
 		Tags: []string{EngineElasticSearch},
@@ -248,7 +252,7 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should support wildcard outside quotes",
 		Fn:   testSupportWildcardOutsideQuotes,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should support hashtags with 3 or more characters",
@@ -258,7 +262,7 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should not support slash as character separator",
 		Fn:   testSlashShouldNotBeCharSeparator,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should be able to search in comments",
@@ -268,12 +272,12 @@ var searchPostStoreTests = []searchTest{
 	{
 		Name: "Should be able to search terms within links",
 		Fn:   testSupportSearchTermsWithinLinks,
-		Tags: []string{EngineElasticSearch},
+		Tags: []string{EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should not return links that are embedded in markdown",
 		Fn:   testShouldNotReturnLinksEmbeddedInMarkdown,
-		Tags: []string{EnginePostgres, EngineElasticSearch},
+		Tags: []string{EnginePostgres, EngineElasticSearch, EngineBleve},
 	},
 	{
 		Name: "Should search across teams",
@@ -239,6 +239,7 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatchForRetentionPolicies(r
 		TimeColumn:          "LeaveTime",
 		PrimaryKeys:         []string{"ChannelId", "UserId", "JoinTime"},
 		ChannelIDTable:      "ChannelMemberHistory",
+		UserIDColumn:        "ChannelMemberHistory.UserId",
 		NowMillis:           retentionPolicyBatchConfigs.Now,
 		GlobalPolicyEndTime: retentionPolicyBatchConfigs.GlobalPolicyEndTime,
 		Limit:               retentionPolicyBatchConfigs.Limit,
@@ -274,6 +275,8 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 		err   error
 	)
 
+	notHeld := sq.Expr(notLegalHoldCondition("ChannelMemberHistory.LeaveTime", "ChannelMemberHistory.ChannelId", "ChannelMemberHistory.UserId"))
+
 	if s.DriverName() == model.DatabaseDriverPostgres {
 		var innerSelect string
 		innerSelect, args, err = s.getQueryBuilder().
@@ -282,6 +285,7 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 			Where(sq.And{
 				sq.NotEq{"LeaveTime": nil},
 				sq.LtOrEq{"LeaveTime": endTime},
+				notHeld,
 			}).Limit(uint64(limit)).
 			ToSql()
 		if err != nil {
@@ -298,6 +302,7 @@ func (s SqlChannelMemberHistoryStore) PermanentDeleteBatch(endTime int64, limit
 			Where(sq.And{
 				sq.NotEq{"LeaveTime": nil},
 				sq.LtOrEq{"LeaveTime": endTime},
+				notHeld,
 			}).
 			Limit(uint64(limit)).ToSql()
 	}
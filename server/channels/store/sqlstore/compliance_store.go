@@ -360,6 +360,10 @@ func (s SqlComplianceStore) MessageExport(c request.CTX, cursor model.MessageExp
 		builder = builder.Where(sq.LtOrEq{"Posts.UpdateAt": cursor.UntilUpdateAt})
 	}
 
+	if cursor.LegalHoldId != "" {
+		builder = builder.Where(legalHoldExportCondition(cursor.LegalHoldId))
+	}
+
 	cposts := []*model.MessageExport{}
 	if err := s.GetReplica().SelectBuilderCtx(c.Context(), &cposts, builder); err != nil {
 		return nil, cursor, errors.Wrap(err, "unable to export messages")
@@ -494,11 +494,13 @@ func (fs SqlFileInfoStore) PermanentDelete(rctx request.CTX, fileId string) erro
 }
 
 func (fs SqlFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64, limit int64) (int64, error) {
+	notHeld := notLegalHoldCondition("FileInfo.CreateAt", "FileInfo.ChannelId", "FileInfo.CreatorId")
+
 	var query string
 	if fs.DriverName() == "postgres" {
-		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?))"
+		query = "DELETE from FileInfo WHERE Id = any (array (SELECT Id FROM FileInfo WHERE CreateAt < ? AND CreatorId != ? AND " + notHeld + " LIMIT ?))"
 	} else {
-		query = "DELETE from FileInfo WHERE CreateAt < ? AND CreatorId != ? LIMIT ?"
+		query = "DELETE from FileInfo WHERE CreateAt < ? AND CreatorId != ? AND " + notHeld + " LIMIT ?"
 	}
 
 	sqlResult, err := fs.GetMaster().Exec(query, endTime, model.BookmarkFileOwner, limit)
@@ -515,7 +517,8 @@ func (fs SqlFileInfoStore) PermanentDeleteBatch(rctx request.CTX, endTime int64,
 }
 
 func (fs SqlFileInfoStore) PermanentDeleteByUser(rctx request.CTX, userId string) (int64, error) {
-	query := "DELETE from FileInfo WHERE CreatorId = ?"
+	// Files covered by a legal hold are kept
+	query := "DELETE from FileInfo WHERE CreatorId = ? AND " + notLegalHoldCondition("FileInfo.CreateAt", "FileInfo.ChannelId", "FileInfo.CreatorId")
 
 	sqlResult, err := fs.GetMaster().Exec(query, userId)
 	if err != nil {
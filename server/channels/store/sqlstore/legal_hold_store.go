@@ -0,0 +1,318 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"strings"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlLegalHoldStore struct {
+	*SqlStore
+
+	tableSelectQuery sq.SelectBuilder
+}
+
+func newSqlLegalHoldStore(sqlStore *SqlStore) store.LegalHoldStore {
+	s := &SqlLegalHoldStore{
+		SqlStore: sqlStore,
+	}
+
+	s.tableSelectQuery = s.getQueryBuilder().
+		Select("LegalHolds.Id", "LegalHolds.Name", "LegalHolds.DisplayName", "COALESCE(LegalHolds.Description, '') AS Description",
+			"LegalHolds.StartsAt", "LegalHolds.EndsAt", "LegalHolds.CreatorId", "LegalHolds.CreateAt", "LegalHolds.UpdateAt", "LegalHolds.DeleteAt").
+		From("LegalHolds")
+
+	return s
+}
+
+// legalHoldCoverageCondition returns an SQL condition which is true when a row is covered
+// by a legal hold that has not been released. The time, channel and user arguments are
+// fully qualified column names of the row; channelIDColumn or userIDColumn may be empty
+// when the table has no such column. The condition has no placeholders so that it can be
+// used in raw queries as well as in builders.
+func legalHoldCoverageCondition(timeColumn, channelIDColumn, userIDColumn string) string {
+	members := []string{}
+	if channelIDColumn != "" {
+		members = append(members, "EXISTS (SELECT 1 FROM LegalHoldsChannels WHERE LegalHoldsChannels.LegalHoldId = LegalHolds.Id AND LegalHoldsChannels.ChannelId = "+channelIDColumn+")")
+	}
+	if userIDColumn != "" {
+		members = append(members, "EXISTS (SELECT 1 FROM LegalHoldsUsers WHERE LegalHoldsUsers.LegalHoldId = LegalHolds.Id AND LegalHoldsUsers.UserId = "+userIDColumn+")")
+	}
+
+	return "EXISTS (SELECT 1 FROM LegalHolds WHERE LegalHolds.DeleteAt = 0" +
+		" AND (LegalHolds.StartsAt = 0 OR " + timeColumn + " >= LegalHolds.StartsAt)" +
+		" AND (LegalHolds.EndsAt = 0 OR " + timeColumn + " <= LegalHolds.EndsAt)" +
+		" AND (" + strings.Join(members, " OR ") + "))"
+}
+
+// notLegalHoldCondition is the negation of legalHoldCoverageCondition and is used by the
+// data retention deletion queries to skip the rows covered by a legal hold.
+func notLegalHoldCondition(timeColumn, channelIDColumn, userIDColumn string) string {
+	return "NOT " + legalHoldCoverageCondition(timeColumn, channelIDColumn, userIDColumn)
+}
+
+// legalHoldExportCondition restricts a message export query on the Posts table to the
+// posts covered by a single legal hold.
+func legalHoldExportCondition(legalHoldID string) sq.Sqlizer {
+	return sq.Expr(`EXISTS (SELECT 1 FROM LegalHolds WHERE LegalHolds.Id = ?
+		AND (LegalHolds.StartsAt = 0 OR Posts.CreateAt >= LegalHolds.StartsAt)
+		AND (LegalHolds.EndsAt = 0 OR Posts.CreateAt <= LegalHolds.EndsAt)
+		AND (EXISTS (SELECT 1 FROM LegalHoldsChannels WHERE LegalHoldsChannels.LegalHoldId = LegalHolds.Id AND LegalHoldsChannels.ChannelId = Posts.ChannelId)
+			OR EXISTS (SELECT 1 FROM LegalHoldsUsers WHERE LegalHoldsUsers.LegalHoldId = LegalHolds.Id AND LegalHoldsUsers.UserId = Posts.UserId)))`, legalHoldID)
+}
+
+func (s *SqlLegalHoldStore) Save(hold *model.LegalHold) (_ *model.LegalHold, err error) {
+	if hold.Id != "" {
+		return nil, store.NewErrInvalidInput("LegalHold", "Id", hold.Id)
+	}
+
+	hold.PreSave()
+	if appErr := hold.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	txn, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(txn, &err)
+
+	query := s.getQueryBuilder().
+		Insert("LegalHolds").
+		Columns("Id", "Name", "DisplayName", "Description", "StartsAt", "EndsAt", "CreatorId", "CreateAt", "UpdateAt", "DeleteAt").
+		Values(hold.Id, hold.Name, hold.DisplayName, hold.Description, hold.StartsAt, hold.EndsAt, hold.CreatorId, hold.CreateAt, hold.UpdateAt, 0)
+	if _, err = txn.ExecBuilder(query); err != nil {
+		if IsUniqueConstraintError(err, []string{"Name", "idx_legalholds_name"}) {
+			return nil, store.NewErrUniqueConstraint("Name")
+		}
+		return nil, errors.Wrap(err, "failed to save LegalHold")
+	}
+
+	if err = s.insertMembers(txn, hold); err != nil {
+		return nil, err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return hold, nil
+}
+
+func (s *SqlLegalHoldStore) Update(hold *model.LegalHold) (_ *model.LegalHold, err error) {
+	hold.PreUpdate()
+	if appErr := hold.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	txn, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(txn, &err)
+
+	query := s.getQueryBuilder().
+		Update("LegalHolds").
+		Set("DisplayName", hold.DisplayName).
+		Set("Description", hold.Description).
+		Set("StartsAt", hold.StartsAt).
+		Set("EndsAt", hold.EndsAt).
+		Set("UpdateAt", hold.UpdateAt).
+		Where(sq.Eq{"Id": hold.Id, "DeleteAt": 0})
+	result, err := txn.ExecBuilder(query)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update LegalHold")
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return nil, store.NewErrNotFound("LegalHold", hold.Id)
+	}
+
+	if _, err = txn.Exec("DELETE FROM LegalHoldsUsers WHERE LegalHoldId = ?", hold.Id); err != nil {
+		return nil, errors.Wrap(err, "failed to delete LegalHoldsUsers")
+	}
+	if _, err = txn.Exec("DELETE FROM LegalHoldsChannels WHERE LegalHoldId = ?", hold.Id); err != nil {
+		return nil, errors.Wrap(err, "failed to delete LegalHoldsChannels")
+	}
+	if err = s.insertMembers(txn, hold); err != nil {
+		return nil, err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return hold, nil
+}
+
+func (s *SqlLegalHoldStore) insertMembers(txn *sqlxTxWrapper, hold *model.LegalHold) error {
+	if len(hold.UserIds) > 0 {
+		query := s.getQueryBuilder().Insert("LegalHoldsUsers").Columns("LegalHoldId", "UserId")
+		for _, userID := range hold.UserIds {
+			query = query.Values(hold.Id, userID)
+		}
+		if _, err := txn.ExecBuilder(query); err != nil {
+			return errors.Wrap(err, "failed to save LegalHoldsUsers")
+		}
+	}
+
+	if len(hold.ChannelIds) > 0 {
+		query := s.getQueryBuilder().Insert("LegalHoldsChannels").Columns("LegalHoldId", "ChannelId")
+		for _, channelID := range hold.ChannelIds {
+			query = query.Values(hold.Id, channelID)
+		}
+		if _, err := txn.ExecBuilder(query); err != nil {
+			return errors.Wrap(err, "failed to save LegalHoldsChannels")
+		}
+	}
+
+	return nil
+}
+
+func (s *SqlLegalHoldStore) Get(id string) (*model.LegalHold, error) {
+	var hold model.LegalHold
+	if err := s.GetReplica().GetBuilder(&hold, s.tableSelectQuery.Where(sq.Eq{"LegalHolds.Id": id})); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("LegalHold", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get LegalHold with id=%s", id)
+	}
+
+	if err := s.loadMembers([]*model.LegalHold{&hold}); err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+func (s *SqlLegalHoldStore) GetAll(offset, limit int) ([]*model.LegalHold, error) {
+	query := s.tableSelectQuery.
+		Where(sq.Eq{"LegalHolds.DeleteAt": 0}).
+		OrderBy("LegalHolds.CreateAt", "LegalHolds.Id").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	return s.getHolds(query)
+}
+
+func (s *SqlLegalHoldStore) Delete(id string, deleteAt int64) error {
+	query := s.getQueryBuilder().
+		Update("LegalHolds").
+		Set("DeleteAt", deleteAt).
+		Set("UpdateAt", deleteAt).
+		Where(sq.Eq{"Id": id, "DeleteAt": 0})
+
+	result, err := s.GetMaster().ExecBuilder(query)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete LegalHold with id=%s", id)
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return store.NewErrNotFound("LegalHold", id)
+	}
+
+	return nil
+}
+
+func (s *SqlLegalHoldStore) GetForUser(userID string) ([]*model.LegalHold, error) {
+	query := s.tableSelectQuery.
+		Where(sq.Eq{"LegalHolds.DeleteAt": 0}).
+		Where(sq.Or{
+			sq.Expr("EXISTS (SELECT 1 FROM LegalHoldsUsers WHERE LegalHoldsUsers.LegalHoldId = LegalHolds.Id AND LegalHoldsUsers.UserId = ?)", userID),
+			sq.Expr(`EXISTS (SELECT 1 FROM LegalHoldsChannels
+				INNER JOIN Posts ON Posts.ChannelId = LegalHoldsChannels.ChannelId
+				WHERE LegalHoldsChannels.LegalHoldId = LegalHolds.Id
+				AND Posts.UserId = ?
+				AND (LegalHolds.StartsAt = 0 OR Posts.CreateAt >= LegalHolds.StartsAt)
+				AND (LegalHolds.EndsAt = 0 OR Posts.CreateAt <= LegalHolds.EndsAt))`, userID),
+		}).
+		OrderBy("LegalHolds.CreateAt", "LegalHolds.Id")
+
+	return s.getHolds(query)
+}
+
+func (s *SqlLegalHoldStore) GetForChannel(channelID string) ([]*model.LegalHold, error) {
+	query := s.tableSelectQuery.
+		Where(sq.Eq{"LegalHolds.DeleteAt": 0}).
+		Where(sq.Or{
+			sq.Expr("EXISTS (SELECT 1 FROM LegalHoldsChannels WHERE LegalHoldsChannels.LegalHoldId = LegalHolds.Id AND LegalHoldsChannels.ChannelId = ?)", channelID),
+			sq.Expr(`EXISTS (SELECT 1 FROM LegalHoldsUsers
+				INNER JOIN Posts ON Posts.UserId = LegalHoldsUsers.UserId
+				WHERE LegalHoldsUsers.LegalHoldId = LegalHolds.Id
+				AND Posts.ChannelId = ?
+				AND (LegalHolds.StartsAt = 0 OR Posts.CreateAt >= LegalHolds.StartsAt)
+				AND (LegalHolds.EndsAt = 0 OR Posts.CreateAt <= LegalHolds.EndsAt))`, channelID),
+		}).
+		OrderBy("LegalHolds.CreateAt", "LegalHolds.Id")
+
+	return s.getHolds(query)
+}
+
+func (s *SqlLegalHoldStore) getHolds(query sq.SelectBuilder) ([]*model.LegalHold, error) {
+	holds := []*model.LegalHold{}
+	if err := s.GetReplica().SelectBuilder(&holds, query); err != nil {
+		return nil, errors.Wrap(err, "failed to find LegalHolds")
+	}
+
+	if err := s.loadMembers(holds); err != nil {
+		return nil, err
+	}
+
+	return holds, nil
+}
+
+// loadMembers fills in the user and channel IDs of the given holds.
+func (s *SqlLegalHoldStore) loadMembers(holds []*model.LegalHold) error {
+	if len(holds) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*model.LegalHold, len(holds))
+	ids := make([]string, 0, len(holds))
+	for _, hold := range holds {
+		hold.UserIds = model.StringArray{}
+		hold.ChannelIds = model.StringArray{}
+		byID[hold.Id] = hold
+		ids = append(ids, hold.Id)
+	}
+
+	var users []struct {
+		LegalHoldId string
+		UserId      string
+	}
+	usersQuery := s.getQueryBuilder().
+		Select("LegalHoldId", "UserId").
+		From("LegalHoldsUsers").
+		Where(sq.Eq{"LegalHoldId": ids}).
+		OrderBy("LegalHoldId", "UserId")
+	if err := s.GetReplica().SelectBuilder(&users, usersQuery); err != nil {
+		return errors.Wrap(err, "failed to find LegalHoldsUsers")
+	}
+	for _, user := range users {
+		byID[user.LegalHoldId].UserIds = append(byID[user.LegalHoldId].UserIds, user.UserId)
+	}
+
+	var channels []struct {
+		LegalHoldId string
+		ChannelId   string
+	}
+	channelsQuery := s.getQueryBuilder().
+		Select("LegalHoldId", "ChannelId").
+		From("LegalHoldsChannels").
+		Where(sq.Eq{"LegalHoldId": ids}).
+		OrderBy("LegalHoldId", "ChannelId")
+	if err := s.GetReplica().SelectBuilder(&channels, channelsQuery); err != nil {
+		return errors.Wrap(err, "failed to find LegalHoldsChannels")
+	}
+	for _, channel := range channels {
+		byID[channel.LegalHoldId].ChannelIds = append(byID[channel.LegalHoldId].ChannelIds, channel.ChannelId)
+	}
+
+	return nil
+}
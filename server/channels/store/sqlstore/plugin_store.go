@@ -4,8 +4,10 @@
 package sqlstore
 
 import (
+	"bytes"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	sq "github.com/mattermost/squirrel"
 	"github.com/pkg/errors"
@@ -18,6 +20,9 @@ const (
 	defaultPluginKeyFetchLimit = 10
 )
 
+// pluginKeyValueColumns are the columns selected to scan a model.PluginKeyValue.
+var pluginKeyValueColumns = []string{"PluginId", `PKey AS "PKey"`, `PValue AS "PValue"`, "ExpireAt"}
+
 type SqlPluginStore struct {
 	*SqlStore
 }
@@ -314,3 +319,216 @@ func (ps SqlPluginStore) List(pluginId string, offset int, limit int) ([]string,
 
 	return keys, nil
 }
+
+// ListWithValues returns the unexpired key value pairs of the plugin whose key
+// starts with the given prefix, ordered by key.
+func (ps SqlPluginStore) ListWithValues(pluginId, prefix string, offset, limit int) ([]*model.PluginKeyValue, error) {
+	if limit <= 0 {
+		limit = defaultPluginKeyFetchLimit
+	}
+
+	if offset <= 0 {
+		offset = 0
+	}
+
+	query := ps.getQueryBuilder().
+		Select(pluginKeyValueColumns...).
+		From("PluginKeyValueStore").
+		Where(sq.Eq{"PluginId": pluginId}).
+		Where(sq.Or{
+			sq.Eq{"ExpireAt": int(0)},
+			sq.Gt{"ExpireAt": model.GetMillis()},
+		}).
+		OrderBy("PKey").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if prefix != "" {
+		query = query.Where("PKey LIKE ? ESCAPE '*'", escapeLikePrefix(prefix, "*")+"%")
+	}
+
+	kvs := []*model.PluginKeyValue{}
+	if err := ps.GetReplica().SelectBuilder(&kvs, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PluginKeyValues with pluginId=%s", pluginId)
+	}
+
+	return kvs, nil
+}
+
+// GetMany returns the unexpired key value pairs of the plugin with the given
+// keys. Keys that don't exist are left out.
+func (ps SqlPluginStore) GetMany(pluginId string, keys []string) ([]*model.PluginKeyValue, error) {
+	kvs := []*model.PluginKeyValue{}
+	if len(keys) == 0 {
+		return kvs, nil
+	}
+
+	query := ps.getQueryBuilder().
+		Select(pluginKeyValueColumns...).
+		From("PluginKeyValueStore").
+		Where(sq.Eq{"PluginId": pluginId}).
+		Where(sq.Eq{"PKey": keys}).
+		Where(sq.Or{
+			sq.Eq{"ExpireAt": int(0)},
+			sq.Gt{"ExpireAt": model.GetMillis()},
+		})
+
+	if err := ps.GetReplica().SelectBuilder(&kvs, query); err != nil {
+		return nil, errors.Wrapf(err, "failed to get PluginKeyValues with pluginId=%s", pluginId)
+	}
+
+	return kvs, nil
+}
+
+// SetMany stores the key value pairs in a single transaction. Pairs with an
+// empty value are removed.
+func (ps SqlPluginStore) SetMany(kvs []*model.PluginKeyValue) (err error) {
+	for _, kv := range kvs {
+		if appErr := kv.IsValid(); appErr != nil {
+			return appErr
+		}
+	}
+
+	transaction, err := ps.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	for _, kv := range kvs {
+		if err = ps.setT(transaction, kv); err != nil {
+			return err
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+// CompareAndSwapMany applies all the operations in a single transaction if,
+// and only if, the current value of every key matches the expected old value.
+// It returns false without error when one of the values doesn't match.
+func (ps SqlPluginStore) CompareAndSwapMany(pluginId string, operations []*model.PluginKVCompareAndSwap) (swapped bool, err error) {
+	keys := make([]string, 0, len(operations))
+	for _, op := range operations {
+		if appErr := op.IsValid(); appErr != nil {
+			return false, appErr
+		}
+		keys = append(keys, op.Key)
+	}
+
+	if len(operations) == 0 {
+		return true, nil
+	}
+
+	transaction, err := ps.GetMaster().Beginx()
+	if err != nil {
+		return false, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	// Expired values are considered missing, remove them so that they can
+	// be inserted again.
+	if _, err = transaction.ExecBuilder(ps.getQueryBuilder().
+		Delete("PluginKeyValueStore").
+		Where(sq.Eq{"PluginId": pluginId}).
+		Where(sq.Eq{"PKey": keys}).
+		Where(sq.NotEq{"ExpireAt": int(0)}).
+		Where(sq.Lt{"ExpireAt": model.GetMillis()})); err != nil {
+		return false, errors.Wrap(err, "failed to delete expired PluginKeyValues")
+	}
+
+	current := []*model.PluginKeyValue{}
+	if err = transaction.SelectBuilder(&current, ps.getQueryBuilder().
+		Select(pluginKeyValueColumns...).
+		From("PluginKeyValueStore").
+		Where(sq.Eq{"PluginId": pluginId}).
+		Where(sq.Eq{"PKey": keys}).
+		Suffix("FOR UPDATE")); err != nil {
+		return false, errors.Wrap(err, "failed to get PluginKeyValues")
+	}
+
+	currentValues := make(map[string][]byte, len(current))
+	for _, kv := range current {
+		currentValues[kv.Key] = kv.Value
+	}
+
+	for _, op := range operations {
+		currentValue, exists := currentValues[op.Key]
+		if len(op.OldValue) == 0 {
+			if exists {
+				return false, nil
+			}
+			continue
+		}
+		if !exists || !bytes.Equal(currentValue, op.OldValue) {
+			return false, nil
+		}
+	}
+
+	for _, op := range operations {
+		kv, _ := model.NewPluginKeyValueFromOptions(pluginId, op.Key, op.NewValue, model.PluginKVSetOptions{ExpireInSeconds: op.ExpireInSeconds})
+		if _, exists := currentValues[op.Key]; exists || len(op.NewValue) == 0 {
+			if err = ps.setT(transaction, kv); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		// The key was missing, a concurrent insert fails the swap.
+		if _, err = transaction.ExecBuilder(ps.getQueryBuilder().
+			Insert("PluginKeyValueStore").
+			Columns("PluginId", "PKey", "PValue", "ExpireAt").
+			Values(kv.PluginId, kv.Key, kv.Value, kv.ExpireAt)); err != nil {
+			if IsUniqueConstraintError(err, []string{"PRIMARY", "PluginId", "Key", "PKey", "pkey"}) {
+				err = nil
+				return false, nil
+			}
+			return false, errors.Wrap(err, "failed to insert PluginKeyValue")
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return false, errors.Wrap(err, "commit_transaction")
+	}
+
+	return true, nil
+}
+
+// setT upserts the key value pair, or removes it when its value is empty,
+// within the given transaction.
+func (ps SqlPluginStore) setT(transaction *sqlxTxWrapper, kv *model.PluginKeyValue) error {
+	if len(kv.Value) == 0 {
+		if _, err := transaction.ExecBuilder(ps.getQueryBuilder().
+			Delete("PluginKeyValueStore").
+			Where(sq.Eq{"PluginId": kv.PluginId}).
+			Where(sq.Eq{"PKey": kv.Key})); err != nil {
+			return errors.Wrapf(err, "failed to delete PluginKeyValue with pluginId=%s and key=%s", kv.PluginId, kv.Key)
+		}
+		return nil
+	}
+
+	query := ps.getQueryBuilder().
+		Insert("PluginKeyValueStore").
+		Columns("PluginId", "PKey", "PValue", "ExpireAt").
+		Values(kv.PluginId, kv.Key, kv.Value, kv.ExpireAt).
+		SuffixExpr(sq.Expr("ON CONFLICT (pluginid, pkey) DO UPDATE SET PValue = ?, ExpireAt = ?", kv.Value, kv.ExpireAt))
+	if _, err := transaction.ExecBuilder(query); err != nil {
+		return errors.Wrapf(err, "failed to upsert PluginKeyValue with pluginId=%s and key=%s", kv.PluginId, kv.Key)
+	}
+
+	return nil
+}
+
+// escapeLikePrefix escapes the wildcards of a LIKE pattern, and the escape
+// character itself, so that the prefix is matched literally.
+func escapeLikePrefix(prefix, escapeChar string) string {
+	prefix = strings.ReplaceAll(prefix, escapeChar, escapeChar+escapeChar)
+	for _, c := range escapeLikeSearchChar {
+		prefix = strings.ReplaceAll(prefix, c, escapeChar+c)
+	}
+	return prefix
+}
@@ -999,10 +999,12 @@ func (s *SqlPostStore) Delete(rctx request.CTX, postID string, time int64, delet
 }
 
 func (s *SqlPostStore) PermanentDelete(rctx request.CTX, postID string) (err error) {
-	return s.permanentDelete([]string{postID})
+	return s.permanentDelete([]string{postID}, false)
 }
 
-func (s *SqlPostStore) permanentDelete(postIds []string) (err error) {
+// permanentDelete deletes the given posts along with their replies and reactions. When
+// skipHeld is set, the replies and reactions covered by a legal hold are kept.
+func (s *SqlPostStore) permanentDelete(postIds []string, skipHeld bool) (err error) {
 	transaction, err := s.GetMaster().Beginx()
 	if err != nil {
 		return errors.Wrap(err, "begin_transaction")
@@ -1013,7 +1015,7 @@ func (s *SqlPostStore) permanentDelete(postIds []string) (err error) {
 		return err
 	}
 
-	if err = s.permanentDeleteReactions(transaction, postIds); err != nil {
+	if err = s.permanentDeleteReactions(transaction, postIds, skipHeld); err != nil {
 		return err
 	}
 
@@ -1025,6 +1027,9 @@ func (s *SqlPostStore) permanentDelete(postIds []string) (err error) {
 				sq.Eq{"RootId": postIds},
 			},
 		)
+	if skipHeld {
+		query = query.Where(notLegalHoldCondition("Posts.CreateAt", "Posts.ChannelId", "Posts.UserId"))
+	}
 	if _, err = transaction.ExecBuilder(query); err != nil {
 		return errors.Wrap(err, "failed to delete Posts")
 	}
@@ -1050,12 +1055,14 @@ func (s *SqlPostStore) permanentDeleteAllCommentByUser(userId string) (err error
 	}
 	defer finalizeTransactionX(transaction, &err)
 
-	err = transaction.Select(&results, "Select Id, RootId FROM Posts WHERE UserId = ? AND RootId != ''", userId)
+	// Comments covered by a legal hold are kept
+	notHeld := notLegalHoldCondition("Posts.CreateAt", "Posts.ChannelId", "Posts.UserId")
+	err = transaction.Select(&results, "Select Id, RootId FROM Posts WHERE UserId = ? AND RootId != '' AND "+notHeld, userId)
 	if err != nil {
 		return errors.Wrapf(err, "failed to fetch Posts with userId=%s", userId)
 	}
 
-	_, err = transaction.Exec("DELETE FROM Posts WHERE UserId = ? AND RootId != ''", userId)
+	_, err = transaction.Exec("DELETE FROM Posts WHERE UserId = ? AND RootId != '' AND "+notHeld, userId)
 	if err != nil {
 		return errors.Wrapf(err, "failed to delete Posts with userId=%s", userId)
 	}
@@ -1069,7 +1076,7 @@ func (s *SqlPostStore) permanentDeleteAllCommentByUser(userId string) (err error
 	}
 
 	// Delete all the reactions on the comments
-	if err = s.permanentDeleteReactions(transaction, postIds); err != nil {
+	if err = s.permanentDeleteReactions(transaction, postIds, true); err != nil {
 		return err
 	}
 
@@ -1083,7 +1090,8 @@ func (s *SqlPostStore) permanentDeleteAllCommentByUser(userId string) (err error
 // Permanently deletes all comments by user,
 // cleans up threads (removes said user from participants and decreases reply count),
 // permanent delete all root posts by user,
-// and delete threads and thread memberships for those root posts
+// and delete threads and thread memberships for those root posts.
+// Posts and reactions covered by a legal hold are kept.
 func (s *SqlPostStore) PermanentDeleteByUser(rctx request.CTX, userId string) error {
 	// First attempt to delete all the comments for a user
 	if err := s.permanentDeleteAllCommentByUser(userId); err != nil {
@@ -1096,7 +1104,7 @@ func (s *SqlPostStore) PermanentDeleteByUser(rctx request.CTX, userId string) er
 	count := 0
 	for {
 		var ids []string
-		err := s.GetMaster().Select(&ids, "SELECT Id FROM Posts WHERE UserId = ? LIMIT 1000", userId)
+		err := s.GetMaster().Select(&ids, "SELECT Id FROM Posts WHERE UserId = ? AND "+notLegalHoldCondition("Posts.CreateAt", "Posts.ChannelId", "Posts.UserId")+" LIMIT 1000", userId)
 		if err != nil {
 			return errors.Wrapf(err, "failed to find Posts with userId=%s", userId)
 		}
@@ -1105,7 +1113,7 @@ func (s *SqlPostStore) PermanentDeleteByUser(rctx request.CTX, userId string) er
 			break
 		}
 
-		if err = s.permanentDelete(ids); err != nil {
+		if err = s.permanentDelete(ids, true); err != nil {
 			return err
 		}
 
@@ -1123,6 +1131,7 @@ func (s *SqlPostStore) PermanentDeleteByUser(rctx request.CTX, userId string) er
 // deletes all threads and thread memberships
 // deletes all reactions
 // no thread comment cleanup needed, since we are deleting threads and thread memberships
+// posts and reactions covered by a legal hold are kept
 func (s *SqlPostStore) PermanentDeleteByChannel(rctx request.CTX, channelId string) (err error) {
 	transaction, err := s.GetMaster().Beginx()
 	if err != nil {
@@ -1133,7 +1142,7 @@ func (s *SqlPostStore) PermanentDeleteByChannel(rctx request.CTX, channelId stri
 	id := ""
 	for {
 		ids := []string{}
-		err = transaction.Select(&ids, "SELECT Id FROM Posts WHERE ChannelId = ? AND Id > ? ORDER BY Id ASC LIMIT 500", channelId, id)
+		err = transaction.Select(&ids, "SELECT Id FROM Posts WHERE ChannelId = ? AND Id > ? AND "+notLegalHoldCondition("Posts.CreateAt", "Posts.ChannelId", "Posts.UserId")+" ORDER BY Id ASC LIMIT 500", channelId, id)
 		if err != nil {
 			return errors.Wrapf(err, "failed to fetch Posts with channelId=%s", channelId)
 		}
@@ -1149,7 +1158,7 @@ func (s *SqlPostStore) PermanentDeleteByChannel(rctx request.CTX, channelId stri
 		}
 		time.Sleep(10 * time.Millisecond)
 
-		if err = s.permanentDeleteReactions(transaction, ids); err != nil {
+		if err = s.permanentDeleteReactions(transaction, ids, true); err != nil {
 			return err
 		}
 		time.Sleep(10 * time.Millisecond)
@@ -1440,6 +1449,61 @@ func (s *SqlPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOp
 		OrderBy("Posts.UpdateAt", "Id").
 		Limit(uint64(limit))
 
+	query = postsSinceForSyncFilter(query, options, cursor)
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, cursor, errors.Wrap(err, "getpostssinceforsync_tosql")
+	}
+
+	posts := []*model.Post{}
+	err = s.GetReplica().Select(&posts, queryString, args...)
+	if err != nil {
+		return nil, cursor, errors.Wrapf(err, "error getting Posts with channelId=%s", options.ChannelId)
+	}
+
+	if len(posts) != 0 {
+		if options.SinceCreateAt {
+			cursor.LastPostCreateAt = posts[len(posts)-1].CreateAt
+			cursor.LastPostCreateID = posts[len(posts)-1].Id
+		} else {
+			cursor.LastPostUpdateAt = posts[len(posts)-1].UpdateAt
+			cursor.LastPostUpdateID = posts[len(posts)-1].Id
+		}
+	}
+	return posts, cursor, nil
+}
+
+// CountPostsSinceForSync returns the number of posts GetPostsSinceForSync would return without
+// a limit, and the oldest CreateAt or UpdateAt (depending on options.SinceCreateAt) among them.
+func (s *SqlPostStore) CountPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor) (int64, int64, error) {
+	column := "Posts.UpdateAt"
+	if options.SinceCreateAt {
+		column = "Posts.CreateAt"
+	}
+
+	query := s.getQueryBuilder().
+		Select("COUNT(*) AS Count", "COALESCE(MIN("+column+"), 0) AS Oldest").
+		From("Posts")
+
+	query = postsSinceForSyncFilter(query, options, cursor)
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "countpostssinceforsync_tosql")
+	}
+
+	var result struct {
+		Count  int64
+		Oldest int64
+	}
+	if err := s.GetReplica().Get(&result, queryString, args...); err != nil {
+		return 0, 0, errors.Wrapf(err, "error counting Posts with channelId=%s", options.ChannelId)
+	}
+	return result.Count, result.Oldest, nil
+}
+
+func postsSinceForSyncFilter(query sq.SelectBuilder, options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor) sq.SelectBuilder {
 	if options.SinceCreateAt {
 		query = query.Where(sq.Or{
 			sq.Gt{"Posts.CreateAt": cursor.LastPostCreateAt},
@@ -1472,28 +1536,7 @@ func (s *SqlPostStore) GetPostsSinceForSync(options model.GetPostsSinceForSyncOp
 			model.PostTypePurposeChange,
 		}})
 	}
-
-	queryString, args, err := query.ToSql()
-	if err != nil {
-		return nil, cursor, errors.Wrap(err, "getpostssinceforsync_tosql")
-	}
-
-	posts := []*model.Post{}
-	err = s.GetReplica().Select(&posts, queryString, args...)
-	if err != nil {
-		return nil, cursor, errors.Wrapf(err, "error getting Posts with channelId=%s", options.ChannelId)
-	}
-
-	if len(posts) != 0 {
-		if options.SinceCreateAt {
-			cursor.LastPostCreateAt = posts[len(posts)-1].CreateAt
-			cursor.LastPostCreateID = posts[len(posts)-1].Id
-		} else {
-			cursor.LastPostUpdateAt = posts[len(posts)-1].UpdateAt
-			cursor.LastPostUpdateID = posts[len(posts)-1].Id
-		}
-	}
-	return posts, cursor, nil
+	return query
 }
 
 func (s *SqlPostStore) GetPostsBefore(options model.GetPostsOptions, sanitizeOptions map[string]bool) (*model.PostList, error) {
@@ -2544,6 +2587,7 @@ func (s *SqlPostStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyB
 		TimeColumn:          "CreateAt",
 		PrimaryKeys:         []string{"Id"},
 		ChannelIDTable:      "Posts",
+		UserIDColumn:        "Posts.UserId",
 		NowMillis:           retentionPolicyBatchConfigs.Now,
 		GlobalPolicyEndTime: retentionPolicyBatchConfigs.GlobalPolicyEndTime,
 		Limit:               retentionPolicyBatchConfigs.Limit,
@@ -2552,11 +2596,13 @@ func (s *SqlPostStore) PermanentDeleteBatchForRetentionPolicies(retentionPolicyB
 }
 
 func (s *SqlPostStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	notHeld := notLegalHoldCondition("Posts.CreateAt", "Posts.ChannelId", "Posts.UserId")
+
 	var query string
 	if s.DriverName() == model.DatabaseDriverPostgres {
-		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE CreateAt < ? LIMIT ?))"
+		query = "DELETE from Posts WHERE Id = any (array (SELECT Id FROM Posts WHERE CreateAt < ? AND " + notHeld + " LIMIT ?))"
 	} else {
-		query = "DELETE from Posts WHERE CreateAt < ? LIMIT ?"
+		query = "DELETE from Posts WHERE CreateAt < ? AND " + notHeld + " LIMIT ?"
 	}
 
 	sqlResult, err := s.GetMaster().Exec(query, endTime, limit)
@@ -2886,12 +2932,15 @@ func (s *SqlPostStore) permanentDeleteThreads(transaction *sqlxTxWrapper, postId
 	return nil
 }
 
-func (s *SqlPostStore) permanentDeleteReactions(transaction *sqlxTxWrapper, postIds []string) error {
+func (s *SqlPostStore) permanentDeleteReactions(transaction *sqlxTxWrapper, postIds []string, skipHeld bool) error {
 	query := s.getQueryBuilder().
 		Delete("Reactions").
 		Where(
 			sq.Eq{"PostId": postIds},
 		)
+	if skipHeld {
+		query = query.Where(notLegalHoldCondition("Reactions.CreateAt", "Reactions.ChannelId", "Reactions.UserId"))
+	}
 	if _, err := transaction.ExecBuilder(query); err != nil {
 		return errors.Wrap(err, "failed to delete Reactions")
 	}
@@ -258,8 +258,11 @@ func (s *SqlReactionStore) permanentDeleteReactions(userId string) ([]string, er
 	}
 	defer finalizeTransactionX(txn, &err)
 
+	// Reactions covered by a legal hold are kept
+	notHeld := notLegalHoldCondition("Reactions.CreateAt", "Reactions.ChannelId", "Reactions.UserId")
+
 	postIds := []string{}
-	err = txn.Select(&postIds, "SELECT PostId FROM Reactions WHERE UserId = ?", userId)
+	err = txn.Select(&postIds, "SELECT PostId FROM Reactions WHERE UserId = ? AND "+notHeld, userId)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get Reactions with userId=%s", userId)
 	}
@@ -269,6 +272,7 @@ func (s *SqlReactionStore) permanentDeleteReactions(userId string) ([]string, er
 		Where(sq.And{
 			sq.Eq{"PostId": postIds},
 			sq.Eq{"UserId": userId},
+			sq.Expr(notHeld),
 		})
 
 	_, err = txn.ExecBuilder(query)
@@ -345,11 +349,13 @@ func (s *SqlReactionStore) DeleteOrphanedRowsByIds(r *model.RetentionIdsForDelet
 }
 
 func (s *SqlReactionStore) PermanentDeleteBatch(endTime int64, limit int64) (int64, error) {
+	notHeld := notLegalHoldCondition("Reactions.CreateAt", "Reactions.ChannelId", "Reactions.UserId")
+
 	var query string
 	if s.DriverName() == "postgres" {
-		query = "DELETE from Reactions WHERE CreateAt = any (array (SELECT CreateAt FROM Reactions WHERE CreateAt < ? LIMIT ?))"
+		query = "DELETE from Reactions WHERE CreateAt = any (array (SELECT CreateAt FROM Reactions WHERE CreateAt < ? AND " + notHeld + " LIMIT ?)) AND " + notHeld
 	} else {
-		query = "DELETE from Reactions WHERE CreateAt < ? LIMIT ?"
+		query = "DELETE from Reactions WHERE CreateAt < ? AND " + notHeld + " LIMIT ?"
 	}
 
 	sqlResult, err := s.GetMaster().Exec(query, endTime, limit)
@@ -922,12 +922,15 @@ func insertRetentionIdsForDeletion(txn *sqlxTxWrapper, row *model.RetentionIdsFo
 // will be deleted by the global policy if it does not fall under a granular policy.
 // To disable the granular policies, set `NowMillis` to 0.
 // To disable the global policy, set `GlobalPolicyEndTime` to 0.
+// `UserIDColumn` is the fully qualified column holding the user who owns the record, if
+// any. Records of held users or channels are never deleted; see `notLegalHoldCondition`.
 type RetentionPolicyBatchDeletionInfo struct {
 	BaseBuilder         sq.SelectBuilder
 	Table               string
 	TimeColumn          string
 	PrimaryKeys         []string
 	ChannelIDTable      string
+	UserIDColumn        string
 	NowMillis           int64
 	GlobalPolicyEndTime int64
 	Limit               int64
@@ -941,9 +944,12 @@ func genericPermanentDeleteBatchForRetentionPolicies(
 	s *SqlStore,
 	cursor model.RetentionPolicyCursor,
 ) (int64, model.RetentionPolicyCursor, error) {
-	baseBuilder := r.BaseBuilder.InnerJoin("Channels ON " + r.ChannelIDTable + ".ChannelId = Channels.Id")
-
 	scopedTimeColumn := r.Table + "." + r.TimeColumn
+
+	baseBuilder := r.BaseBuilder.
+		InnerJoin("Channels ON " + r.ChannelIDTable + ".ChannelId = Channels.Id").
+		Where(notLegalHoldCondition(scopedTimeColumn, r.ChannelIDTable+".ChannelId", r.UserIDColumn))
+
 	nowStr := strconv.FormatInt(r.NowMillis, 10)
 	// A record falls under the scope of a granular retention policy if:
 	// 1. The policy's post duration is >= 0
@@ -343,9 +343,9 @@ func (s SqlSharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*m
 
 	query, args, err := s.getQueryBuilder().Insert("SharedChannelRemotes").
 		Columns("Id", "ChannelId", "CreatorId", "CreateAt", "UpdateAt", "DeleteAt", "IsInviteAccepted", "IsInviteConfirmed", "RemoteId",
-			"LastPostCreateAt", "LastPostCreateId", "LastPostUpdateAt", "LastPostId").
+			"LastPostCreateAt", "LastPostCreateId", "LastPostUpdateAt", "LastPostId", "SyncFilters").
 		Values(remote.Id, remote.ChannelId, remote.CreatorId, remote.CreateAt, remote.UpdateAt, remote.DeleteAt, remote.IsInviteAccepted, remote.IsInviteConfirmed,
-			remote.RemoteId, remote.LastPostCreateAt, remote.LastPostCreateID, remote.LastPostUpdateAt, remote.LastPostUpdateID).
+			remote.RemoteId, remote.LastPostCreateAt, remote.LastPostCreateID, remote.LastPostUpdateAt, remote.LastPostUpdateID, remote.SyncFilters).
 		ToSql()
 	if err != nil {
 		return nil, errors.Wrapf(err, "savesharedchannelremote_tosql")
@@ -418,6 +418,7 @@ func sharedChannelRemoteFields(prefix string) []string {
 		prefix + "LastPostUpdateAt",
 		"COALESCE(" + prefix + "LastPostId,'') AS LastPostUpdateID",
 		prefix + "LastMembersSyncAt",
+		prefix + "SyncFilters",
 	}
 }
 
@@ -643,6 +644,38 @@ func (s SqlSharedChannelStore) UpdateRemoteCursor(id string, cursor model.GetPos
 	return nil
 }
 
+// UpdateRemoteSyncFilters updates the sync filters for the specified SharedChannelRemote.
+// Nil or empty filters remove any filtering.
+func (s SqlSharedChannelStore) UpdateRemoteSyncFilters(id string, filters *model.SharedChannelRemoteSyncFilters) error {
+	if filters.IsEmpty() {
+		filters = nil
+	}
+
+	squery, args, err := s.getQueryBuilder().
+		Update("SharedChannelRemotes").
+		Set("SyncFilters", filters).
+		Set("UpdateAt", model.GetMillis()).
+		Where(sq.Eq{"Id": id}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "update_shared_channel_remote_sync_filters_tosql")
+	}
+
+	result, err := s.GetMaster().Exec(squery, args...)
+	if err != nil {
+		return errors.Wrap(err, "failed to update sync filters for SharedChannelRemote")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to determine rows affected")
+	}
+	if count == 0 {
+		return store.NewErrNotFound("SharedChannelRemote", id)
+	}
+	return nil
+}
+
 // DeleteRemote deletes a single shared channel remote.
 // Returns true if remote found and deleted, false if not found.
 func (s SqlSharedChannelStore) DeleteRemote(id string) (bool, error) {
@@ -698,6 +731,150 @@ func (s SqlSharedChannelStore) GetRemotesStatus(channelId string) ([]*model.Shar
 	return status, nil
 }
 
+// SaveSyncSuppressions records the items that were not synchronized with a remote
+// because of its sync filters. Items already recorded for the remote are ignored.
+func (s SqlSharedChannelStore) SaveSyncSuppressions(suppressions []*model.SharedChannelSyncSuppression) error {
+	if len(suppressions) == 0 {
+		return nil
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("SharedChannelSyncSuppressions").
+		Columns("RemoteId", "ItemId", "ChannelId", "ItemType", "Reason", "CreateAt")
+
+	for _, suppression := range suppressions {
+		suppression.PreSave()
+		if err := suppression.IsValid(); err != nil {
+			return err
+		}
+		builder = builder.Values(suppression.RemoteId, suppression.ItemId, suppression.ChannelId,
+			suppression.ItemType, suppression.Reason, suppression.CreateAt)
+	}
+
+	query, args, err := builder.Suffix("ON CONFLICT (RemoteId, ItemId) DO NOTHING").ToSql()
+	if err != nil {
+		return errors.Wrap(err, "save_shared_channel_sync_suppressions_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(query, args...); err != nil {
+		return errors.Wrap(err, "failed to save shared channel sync suppressions")
+	}
+	return nil
+}
+
+// GetSyncSuppressions fetches the items that were not synchronized with remotes,
+// newest first.
+func (s SqlSharedChannelStore) GetSyncSuppressions(offset, limit int, opts model.SharedChannelSyncSuppressionFilterOpts) ([]*model.SharedChannelSyncSuppression, error) {
+	if offset < 0 {
+		return nil, errors.New("offset must be a positive integer")
+	}
+	if limit < 0 {
+		return nil, errors.New("limit must be a positive integer")
+	}
+
+	query := s.getQueryBuilder().
+		Select("RemoteId", "ItemId", "ChannelId", "ItemType", "Reason", "CreateAt").
+		From("SharedChannelSyncSuppressions").
+		OrderBy("CreateAt DESC", "ItemId").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	if opts.ChannelId != "" {
+		query = query.Where(sq.Eq{"ChannelId": opts.ChannelId})
+	}
+
+	if opts.RemoteId != "" {
+		query = query.Where(sq.Eq{"RemoteId": opts.RemoteId})
+	}
+
+	if len(opts.ItemIds) > 0 {
+		query = query.Where(sq.Eq{"ItemId": opts.ItemIds})
+	}
+
+	squery, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_shared_channel_sync_suppressions_tosql")
+	}
+
+	suppressions := []*model.SharedChannelSyncSuppression{}
+	if err := s.GetReplica().Select(&suppressions, squery, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get shared channel sync suppressions for channel_id=%s; remote_id=%s",
+			opts.ChannelId, opts.RemoteId)
+	}
+	return suppressions, nil
+}
+
+// SaveSyncSuccess records the last time the channel was synchronized with the remote.
+// An empty channelID records the global user sync of the remote.
+func (s SqlSharedChannelStore) SaveSyncSuccess(channelID string, remoteID string, syncAt int64) error {
+	query, args, err := s.getQueryBuilder().
+		Insert("SharedChannelSyncHealth").
+		Columns("RemoteId", "ChannelId", "LastSyncAt").
+		Values(remoteID, channelID, syncAt).
+		Suffix("ON CONFLICT (RemoteId, ChannelId) DO UPDATE SET LastSyncAt = EXCLUDED.LastSyncAt").
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "save_shared_channel_sync_success_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to save shared channel sync success for channel_id=%s; remote_id=%s", channelID, remoteID)
+	}
+	return nil
+}
+
+// SaveSyncError records the last failed sync of the channel with the remote, and counts
+// it as a retry if it was scheduled to be retried. An empty channelID records the global
+// user sync of the remote.
+func (s SqlSharedChannelStore) SaveSyncError(channelID string, remoteID string, syncError string, errorAt int64, retry bool) error {
+	if len(syncError) > model.SharedChannelSyncErrorMaxLength {
+		syncError = strings.ToValidUTF8(syncError[:model.SharedChannelSyncErrorMaxLength], "")
+	}
+	var retryCount int64
+	if retry {
+		retryCount = 1
+	}
+
+	query, args, err := s.getQueryBuilder().
+		Insert("SharedChannelSyncHealth").
+		Columns("RemoteId", "ChannelId", "RetryCount", "LastError", "LastErrorAt").
+		Values(remoteID, channelID, retryCount, syncError, errorAt).
+		Suffix(`ON CONFLICT (RemoteId, ChannelId) DO UPDATE SET
+			RetryCount = SharedChannelSyncHealth.RetryCount + EXCLUDED.RetryCount,
+			LastError = EXCLUDED.LastError,
+			LastErrorAt = EXCLUDED.LastErrorAt`).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "save_shared_channel_sync_error_tosql")
+	}
+
+	if _, err := s.GetMaster().Exec(query, args...); err != nil {
+		return errors.Wrapf(err, "failed to save shared channel sync error for channel_id=%s; remote_id=%s", channelID, remoteID)
+	}
+	return nil
+}
+
+// GetSyncHealth fetches the retries, last sync and last error recorded for the channels
+// shared with the remote. Only ChannelId, RetryCount, LastSyncAt, LastError and LastErrorAt
+// are set.
+func (s SqlSharedChannelStore) GetSyncHealth(remoteID string) ([]*model.SharedChannelSyncHealth, error) {
+	query, args, err := s.getQueryBuilder().
+		Select("ChannelId", "RetryCount", "LastSyncAt", "LastError", "LastErrorAt").
+		From("SharedChannelSyncHealth").
+		Where(sq.Eq{"RemoteId": remoteID}).
+		OrderBy("ChannelId").
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "get_shared_channel_sync_health_tosql")
+	}
+
+	health := []*model.SharedChannelSyncHealth{}
+	if err := s.GetReplica().Select(&health, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get shared channel sync health for remote_id=%s", remoteID)
+	}
+	return health, nil
+}
+
 func sharedChannelUserFields(prefix string) []string {
 	if prefix != "" && !strings.HasSuffix(prefix, ".") {
 		prefix = prefix + "."
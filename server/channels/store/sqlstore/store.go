@@ -64,6 +64,7 @@ type SqlStoreStores struct {
 	channel                    store.ChannelStore
 	post                       store.PostStore
 	retentionPolicy            store.RetentionPolicyStore
+	legalHold                  store.LegalHoldStore
 	thread                     store.ThreadStore
 	user                       store.UserStore
 	bot                        store.BotStore
@@ -214,6 +215,7 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.channel = newSqlChannelStore(store, metrics)
 	store.stores.post = newSqlPostStore(store, metrics)
 	store.stores.retentionPolicy = newSqlRetentionPolicyStore(store, metrics)
+	store.stores.legalHold = newSqlLegalHoldStore(store)
 	store.stores.user = newSqlUserStore(store, metrics)
 	store.stores.bot = newSqlBotStore(store, metrics)
 	store.stores.audit = newSqlAuditStore(store)
@@ -700,6 +702,10 @@ func (ss *SqlStore) RetentionPolicy() store.RetentionPolicyStore {
 	return ss.stores.retentionPolicy
 }
 
+func (ss *SqlStore) LegalHold() store.LegalHoldStore {
+	return ss.stores.legalHold
+}
+
 func (ss *SqlStore) User() store.UserStore {
 	return ss.stores.user
 }
@@ -27,6 +27,7 @@ type Store interface {
 	Channel() ChannelStore
 	Post() PostStore
 	RetentionPolicy() RetentionPolicyStore
+	LegalHold() LegalHoldStore
 	Thread() ThreadStore
 	User() UserStore
 	Bot() BotStore
@@ -123,6 +124,22 @@ type RetentionPolicyStore interface {
 	GetIdsForDeletionByTableName(tableName string, limit int) ([]*model.RetentionIdsForDeletion, error)
 }
 
+// LegalHoldStore persists legal holds. The GetFor* methods only consider holds that
+// have not been released.
+type LegalHoldStore interface {
+	Save(hold *model.LegalHold) (*model.LegalHold, error)
+	Update(hold *model.LegalHold) (*model.LegalHold, error)
+	Get(id string) (*model.LegalHold, error)
+	GetAll(offset, limit int) ([]*model.LegalHold, error)
+	Delete(id string, deleteAt int64) error
+	// GetForUser returns the holds naming the user, and the holds naming a channel in
+	// which the user has posts within the hold's time range.
+	GetForUser(userID string) ([]*model.LegalHold, error)
+	// GetForChannel returns the holds naming the channel, and the holds naming a user
+	// who has posts in the channel within the hold's time range.
+	GetForChannel(channelID string) ([]*model.LegalHold, error)
+}
+
 type TeamStore interface {
 	Save(team *model.Team) (*model.Team, error)
 	Update(team *model.Team) (*model.Team, error)
@@ -375,6 +392,9 @@ type PostStore interface {
 	GetSingle(rctx request.CTX, id string, inclDeleted bool) (*model.Post, error)
 	Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error
 	PermanentDelete(rctx request.CTX, postID string) error
+	// PermanentDeleteByUser and PermanentDeleteByChannel keep the posts and reactions
+	// covered by a legal hold, as do the PermanentDeleteByUser methods of the reaction
+	// and file info stores.
 	PermanentDeleteByUser(rctx request.CTX, userID string) error
 	PermanentDeleteByChannel(rctx request.CTX, channelID string) error
 	GetPosts(options model.GetPostsOptions, allowFromCache bool, sanitizeOptions map[string]bool) (*model.PostList, error)
@@ -413,6 +433,7 @@ type PostStore interface {
 	GetOldestEntityCreationTime() (int64, error)
 	HasAutoResponsePostByUserSince(options model.GetPostsSinceOptions, userID string) (bool, error)
 	GetPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor, limit int) ([]*model.Post, model.GetPostsSinceForSyncCursor, error)
+	CountPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor) (int64, int64, error)
 	SetPostReminder(reminder *model.PostReminder) error
 	GetPostReminders(now int64) ([]*model.PostReminder, error)
 	GetPostReminderMetadata(postID string) (*PostReminderMetadata, error)
@@ -827,6 +848,10 @@ type PluginStore interface {
 	DeleteAllForPlugin(PluginID string) error
 	DeleteAllExpired() error
 	List(pluginID string, page, perPage int) ([]string, error)
+	ListWithValues(pluginID, prefix string, offset, limit int) ([]*model.PluginKeyValue, error)
+	GetMany(pluginID string, keys []string) ([]*model.PluginKeyValue, error)
+	SetMany(kvs []*model.PluginKeyValue) error
+	CompareAndSwapMany(pluginID string, operations []*model.PluginKVCompareAndSwap) (bool, error)
 }
 
 type RoleStore interface {
@@ -1017,9 +1042,17 @@ type SharedChannelStore interface {
 	GetRemotes(offset, limit int, opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error)
 	UpdateRemoteCursor(id string, cursor model.GetPostsSinceForSyncCursor) error
 	UpdateRemoteMembershipCursor(id string, syncTime int64) error
+	UpdateRemoteSyncFilters(id string, filters *model.SharedChannelRemoteSyncFilters) error
 	DeleteRemote(remoteID string) (bool, error)
 	GetRemotesStatus(channelID string) ([]*model.SharedChannelRemoteStatus, error)
 
+	SaveSyncSuppressions(suppressions []*model.SharedChannelSyncSuppression) error
+	GetSyncSuppressions(offset, limit int, opts model.SharedChannelSyncSuppressionFilterOpts) ([]*model.SharedChannelSyncSuppression, error)
+
+	SaveSyncSuccess(channelID string, remoteID string, syncAt int64) error
+	SaveSyncError(channelID string, remoteID string, syncError string, errorAt int64, retry bool) error
+	GetSyncHealth(remoteID string) ([]*model.SharedChannelSyncHealth, error)
+
 	SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error)
 	GetSingleUser(userID string, channelID string, remoteID string) (*model.SharedChannelUser, error)
 	GetUsersForUser(userID string) ([]*model.SharedChannelUser, error)
@@ -0,0 +1,342 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestLegalHoldStore(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("Save", func(t *testing.T) { testLegalHoldStoreSave(t, ss) })
+	t.Run("Update", func(t *testing.T) { testLegalHoldStoreUpdate(t, ss) })
+	t.Run("GetAllAndDelete", func(t *testing.T) { testLegalHoldStoreGetAllAndDelete(t, ss) })
+	t.Run("GetForUserAndChannel", func(t *testing.T) { testLegalHoldStoreGetForUserAndChannel(t, rctx, ss) })
+	t.Run("RetentionSkipsHeldData", func(t *testing.T) { testLegalHoldStoreRetentionSkipsHeldData(t, rctx, ss) })
+	t.Run("PermanentDeletesSkipHeldData", func(t *testing.T) { testLegalHoldStorePermanentDeletesSkipHeldData(t, rctx, ss) })
+	t.Run("MessageExport", func(t *testing.T) { testLegalHoldStoreMessageExport(t, rctx, ss) })
+}
+
+func newTestLegalHold(userIDs, channelIDs []string) *model.LegalHold {
+	return &model.LegalHold{
+		Name:        "hold-" + NewTestID(),
+		DisplayName: "Test Hold",
+		UserIds:     userIDs,
+		ChannelIds:  channelIDs,
+		CreatorId:   model.NewId(),
+	}
+}
+
+func saveTestLegalHold(t *testing.T, ss store.Store, hold *model.LegalHold) *model.LegalHold {
+	t.Helper()
+
+	saved, err := ss.LegalHold().Save(hold)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = ss.LegalHold().Delete(saved.Id, model.GetMillis())
+	})
+	return saved
+}
+
+func saveLegalHoldTestChannel(t *testing.T, rctx request.CTX, ss store.Store) *model.Channel {
+	t.Helper()
+
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "DisplayName",
+		Name:        "channel" + model.NewId(),
+		Type:        model.ChannelTypeOpen,
+	}, -1)
+	require.NoError(t, err)
+	return channel
+}
+
+func saveLegalHoldTestPost(t *testing.T, rctx request.CTX, ss store.Store, channelID, userID string, createAt int64) *model.Post {
+	t.Helper()
+
+	post, err := ss.Post().Save(rctx, &model.Post{
+		ChannelId: channelID,
+		UserId:    userID,
+		Message:   NewTestID(),
+		CreateAt:  createAt,
+	})
+	require.NoError(t, err)
+	return post
+}
+
+func testLegalHoldStoreSave(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	channelID := model.NewId()
+
+	hold := saveTestLegalHold(t, ss, newTestLegalHold([]string{userID, userID}, []string{channelID}))
+	require.True(t, model.IsValidId(hold.Id))
+
+	fetched, err := ss.LegalHold().Get(hold.Id)
+	require.NoError(t, err)
+	assert.Equal(t, hold.Name, fetched.Name)
+	assert.Equal(t, model.StringArray{userID}, fetched.UserIds)
+	assert.Equal(t, model.StringArray{channelID}, fetched.ChannelIds)
+
+	t.Run("duplicate name", func(t *testing.T) {
+		duplicate := newTestLegalHold([]string{userID}, nil)
+		duplicate.Name = hold.Name
+		_, err := ss.LegalHold().Save(duplicate)
+		var uniqueErr *store.ErrUniqueConstraint
+		require.ErrorAs(t, err, &uniqueErr)
+	})
+
+	t.Run("existing id", func(t *testing.T) {
+		_, err := ss.LegalHold().Save(&model.LegalHold{Id: model.NewId()})
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.LegalHold().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testLegalHoldStoreUpdate(t *testing.T, ss store.Store) {
+	hold := saveTestLegalHold(t, ss, newTestLegalHold([]string{model.NewId()}, []string{model.NewId()}))
+
+	newUserID := model.NewId()
+	hold.DisplayName = "Updated"
+	hold.UserIds = model.StringArray{newUserID}
+	hold.ChannelIds = nil
+	hold.EndsAt = 5000
+	_, err := ss.LegalHold().Update(hold)
+	require.NoError(t, err)
+
+	fetched, err := ss.LegalHold().Get(hold.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Updated", fetched.DisplayName)
+	assert.Equal(t, model.StringArray{newUserID}, fetched.UserIds)
+	assert.Empty(t, fetched.ChannelIds)
+	assert.Equal(t, int64(5000), fetched.EndsAt)
+
+	require.NoError(t, ss.LegalHold().Delete(hold.Id, model.GetMillis()))
+	_, err = ss.LegalHold().Update(hold)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr, "released holds cannot be updated")
+}
+
+func testLegalHoldStoreGetAllAndDelete(t *testing.T, ss store.Store) {
+	hold1 := saveTestLegalHold(t, ss, newTestLegalHold([]string{model.NewId()}, nil))
+	hold2 := saveTestLegalHold(t, ss, newTestLegalHold(nil, []string{model.NewId()}))
+
+	containsHold := func(holds []*model.LegalHold, id string) bool {
+		for _, hold := range holds {
+			if hold.Id == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	holds, err := ss.LegalHold().GetAll(0, 1000)
+	require.NoError(t, err)
+	assert.True(t, containsHold(holds, hold1.Id))
+	assert.True(t, containsHold(holds, hold2.Id))
+
+	require.NoError(t, ss.LegalHold().Delete(hold1.Id, model.GetMillis()))
+
+	holds, err = ss.LegalHold().GetAll(0, 1000)
+	require.NoError(t, err)
+	assert.False(t, containsHold(holds, hold1.Id))
+	assert.True(t, containsHold(holds, hold2.Id))
+
+	released, err := ss.LegalHold().Get(hold1.Id)
+	require.NoError(t, err)
+	assert.NotZero(t, released.DeleteAt)
+
+	err = ss.LegalHold().Delete(hold1.Id, model.GetMillis())
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testLegalHoldStoreGetForUserAndChannel(t *testing.T, rctx request.CTX, ss store.Store) {
+	heldChannel := saveLegalHoldTestChannel(t, rctx, ss)
+	otherChannel := saveLegalHoldTestChannel(t, rctx, ss)
+	heldUserID := model.NewId()
+	postingUserID := model.NewId()
+	lateUserID := model.NewId()
+
+	saveLegalHoldTestPost(t, rctx, ss, heldChannel.Id, postingUserID, 1500)
+	saveLegalHoldTestPost(t, rctx, ss, heldChannel.Id, lateUserID, 5000)
+	saveLegalHoldTestPost(t, rctx, ss, otherChannel.Id, heldUserID, 1500)
+
+	channelHold := newTestLegalHold(nil, []string{heldChannel.Id})
+	channelHold.StartsAt = 1000
+	channelHold.EndsAt = 2000
+	channelHold = saveTestLegalHold(t, ss, channelHold)
+	userHold := saveTestLegalHold(t, ss, newTestLegalHold([]string{heldUserID}, nil))
+
+	holds, err := ss.LegalHold().GetForUser(heldUserID)
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	assert.Equal(t, userHold.Id, holds[0].Id)
+
+	holds, err = ss.LegalHold().GetForUser(postingUserID)
+	require.NoError(t, err)
+	require.Len(t, holds, 1, "posts in a held channel put their author under the hold")
+	assert.Equal(t, channelHold.Id, holds[0].Id)
+
+	holds, err = ss.LegalHold().GetForUser(lateUserID)
+	require.NoError(t, err)
+	assert.Empty(t, holds, "posts outside of the hold's time range are not covered")
+
+	holds, err = ss.LegalHold().GetForChannel(heldChannel.Id)
+	require.NoError(t, err)
+	require.Len(t, holds, 1)
+	assert.Equal(t, channelHold.Id, holds[0].Id)
+
+	holds, err = ss.LegalHold().GetForChannel(otherChannel.Id)
+	require.NoError(t, err)
+	require.Len(t, holds, 1, "posts of a held user put their channel under the hold")
+	assert.Equal(t, userHold.Id, holds[0].Id)
+
+	require.NoError(t, ss.LegalHold().Delete(userHold.Id, model.GetMillis()))
+	holds, err = ss.LegalHold().GetForUser(heldUserID)
+	require.NoError(t, err)
+	assert.Empty(t, holds)
+}
+
+func testLegalHoldStoreRetentionSkipsHeldData(t *testing.T, rctx request.CTX, ss store.Store) {
+	heldChannel := saveLegalHoldTestChannel(t, rctx, ss)
+	otherChannel := saveLegalHoldTestChannel(t, rctx, ss)
+	heldUserID := model.NewId()
+
+	inHeldChannel := saveLegalHoldTestPost(t, rctx, ss, heldChannel.Id, model.NewId(), 1000)
+	outsideTimeRange := saveLegalHoldTestPost(t, rctx, ss, heldChannel.Id, model.NewId(), 100)
+	byHeldUser := saveLegalHoldTestPost(t, rctx, ss, otherChannel.Id, heldUserID, 1000)
+	unheld := saveLegalHoldTestPost(t, rctx, ss, otherChannel.Id, model.NewId(), 1000)
+
+	channelHold := newTestLegalHold(nil, []string{heldChannel.Id})
+	channelHold.StartsAt = 500
+	saveTestLegalHold(t, ss, channelHold)
+	saveTestLegalHold(t, ss, newTestLegalHold([]string{heldUserID}, nil))
+
+	_, _, err := ss.Post().PermanentDeleteBatchForRetentionPolicies(model.RetentionPolicyBatchConfigs{
+		GlobalPolicyEndTime: 2000,
+		Limit:               1000,
+	}, model.RetentionPolicyCursor{})
+	require.NoError(t, err)
+
+	getPost := func(id string) error {
+		_, err := ss.Post().Get(context.Background(), id, model.GetPostsOptions{}, "", map[string]bool{})
+		return err
+	}
+	assert.NoError(t, getPost(inHeldChannel.Id))
+	assert.NoError(t, getPost(byHeldUser.Id))
+	assert.Error(t, getPost(outsideTimeRange.Id))
+	assert.Error(t, getPost(unheld.Id))
+
+	_, err = ss.Post().PermanentDeleteBatch(2000, 1000)
+	require.NoError(t, err)
+	assert.NoError(t, getPost(inHeldChannel.Id))
+	assert.NoError(t, getPost(byHeldUser.Id))
+}
+
+func testLegalHoldStorePermanentDeletesSkipHeldData(t *testing.T, rctx request.CTX, ss store.Store) {
+	heldChannel := saveLegalHoldTestChannel(t, rctx, ss)
+	otherChannel := saveLegalHoldTestChannel(t, rctx, ss)
+	heldUserID := model.NewId()
+	userID := model.NewId()
+
+	saveTestLegalHold(t, ss, newTestLegalHold(nil, []string{heldChannel.Id}))
+	saveTestLegalHold(t, ss, newTestLegalHold([]string{heldUserID}, nil))
+
+	getPost := func(id string) error {
+		_, err := ss.Post().Get(context.Background(), id, model.GetPostsOptions{}, "", map[string]bool{})
+		return err
+	}
+
+	t.Run("deleting a user keeps the data in held channels and of held users", func(t *testing.T) {
+		inHeldChannel := saveLegalHoldTestPost(t, rctx, ss, heldChannel.Id, userID, 1000)
+		root := saveLegalHoldTestPost(t, rctx, ss, otherChannel.Id, userID, 1000)
+		replyOfHeldUser, err := ss.Post().Save(rctx, &model.Post{
+			ChannelId: otherChannel.Id,
+			UserId:    heldUserID,
+			RootId:    root.Id,
+			Message:   NewTestID(),
+			CreateAt:  1001,
+		})
+		require.NoError(t, err)
+
+		heldPost := saveLegalHoldTestPost(t, rctx, ss, heldChannel.Id, model.NewId(), 1000)
+		_, err = ss.Reaction().Save(&model.Reaction{UserId: userID, PostId: heldPost.Id, EmojiName: "smile"})
+		require.NoError(t, err)
+
+		heldFile, err := ss.FileInfo().Save(rctx, &model.FileInfo{ChannelId: heldChannel.Id, CreatorId: userID, Path: "held.txt"})
+		require.NoError(t, err)
+		otherFile, err := ss.FileInfo().Save(rctx, &model.FileInfo{ChannelId: otherChannel.Id, CreatorId: userID, Path: "other.txt"})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.Post().PermanentDeleteByUser(rctx, userID))
+		require.NoError(t, ss.Reaction().PermanentDeleteByUser(userID))
+		_, err = ss.FileInfo().PermanentDeleteByUser(rctx, userID)
+		require.NoError(t, err)
+
+		assert.NoError(t, getPost(inHeldChannel.Id))
+		assert.NoError(t, getPost(replyOfHeldUser.Id))
+		assert.Error(t, getPost(root.Id))
+
+		reactions, err := ss.Reaction().GetForPost(heldPost.Id, false)
+		require.NoError(t, err)
+		assert.Len(t, reactions, 1)
+
+		_, err = ss.FileInfo().Get(heldFile.Id)
+		assert.NoError(t, err)
+		_, err = ss.FileInfo().Get(otherFile.Id)
+		assert.Error(t, err)
+	})
+
+	t.Run("deleting the posts of a channel keeps the data of held users", func(t *testing.T) {
+		byHeldUser := saveLegalHoldTestPost(t, rctx, ss, otherChannel.Id, heldUserID, 1000)
+		unheld := saveLegalHoldTestPost(t, rctx, ss, otherChannel.Id, model.NewId(), 1000)
+		_, err := ss.Reaction().Save(&model.Reaction{UserId: heldUserID, PostId: unheld.Id, EmojiName: "smile"})
+		require.NoError(t, err)
+
+		require.NoError(t, ss.Post().PermanentDeleteByChannel(rctx, otherChannel.Id))
+
+		assert.NoError(t, getPost(byHeldUser.Id))
+		assert.Error(t, getPost(unheld.Id))
+
+		reactions, err := ss.Reaction().GetForPost(unheld.Id, false)
+		require.NoError(t, err)
+		assert.Len(t, reactions, 1)
+	})
+}
+
+func testLegalHoldStoreMessageExport(t *testing.T, rctx request.CTX, ss store.Store) {
+	heldChannel := saveLegalHoldTestChannel(t, rctx, ss)
+	otherChannel := saveLegalHoldTestChannel(t, rctx, ss)
+	heldUserID := model.NewId()
+
+	inHeldChannel := saveLegalHoldTestPost(t, rctx, ss, heldChannel.Id, model.NewId(), 1000)
+	byHeldUser := saveLegalHoldTestPost(t, rctx, ss, otherChannel.Id, heldUserID, 1000)
+	saveLegalHoldTestPost(t, rctx, ss, otherChannel.Id, model.NewId(), 1000)
+	saveLegalHoldTestPost(t, rctx, ss, heldChannel.Id, model.NewId(), 3000)
+
+	hold := newTestLegalHold([]string{heldUserID}, []string{heldChannel.Id})
+	hold.EndsAt = 2000
+	hold = saveTestLegalHold(t, ss, hold)
+
+	posts, _, err := ss.Compliance().MessageExport(rctx, model.MessageExportCursor{LegalHoldId: hold.Id}, 1000)
+	require.NoError(t, err)
+
+	postIDs := make([]string, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, *post.PostId)
+	}
+	assert.ElementsMatch(t, []string{inHeldChannel.Id, byHeldUser.Id}, postIDs)
+}
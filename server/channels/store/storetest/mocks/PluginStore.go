@@ -70,6 +70,34 @@ func (_m *PluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldValue []by
 	return r0, r1
 }
 
+// CompareAndSwapMany provides a mock function with given fields: pluginID, operations
+func (_m *PluginStore) CompareAndSwapMany(pluginID string, operations []*model.PluginKVCompareAndSwap) (bool, error) {
+	ret := _m.Called(pluginID, operations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompareAndSwapMany")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []*model.PluginKVCompareAndSwap) (bool, error)); ok {
+		return rf(pluginID, operations)
+	}
+	if rf, ok := ret.Get(0).(func(string, []*model.PluginKVCompareAndSwap) bool); ok {
+		r0 = rf(pluginID, operations)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []*model.PluginKVCompareAndSwap) error); ok {
+		r1 = rf(pluginID, operations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: pluginID, key
 func (_m *PluginStore) Delete(pluginID string, key string) error {
 	ret := _m.Called(pluginID, key)
@@ -154,6 +182,36 @@ func (_m *PluginStore) Get(pluginID string, key string) (*model.PluginKeyValue,
 	return r0, r1
 }
 
+// GetMany provides a mock function with given fields: pluginID, keys
+func (_m *PluginStore) GetMany(pluginID string, keys []string) ([]*model.PluginKeyValue, error) {
+	ret := _m.Called(pluginID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []*model.PluginKeyValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string) ([]*model.PluginKeyValue, error)); ok {
+		return rf(pluginID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string) []*model.PluginKeyValue); ok {
+		r0 = rf(pluginID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PluginKeyValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(pluginID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: pluginID, page, perPage
 func (_m *PluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 	ret := _m.Called(pluginID, page, perPage)
@@ -184,6 +242,36 @@ func (_m *PluginStore) List(pluginID string, page int, perPage int) ([]string, e
 	return r0, r1
 }
 
+// ListWithValues provides a mock function with given fields: pluginID, prefix, offset, limit
+func (_m *PluginStore) ListWithValues(pluginID string, prefix string, offset int, limit int) ([]*model.PluginKeyValue, error) {
+	ret := _m.Called(pluginID, prefix, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWithValues")
+	}
+
+	var r0 []*model.PluginKeyValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]*model.PluginKeyValue, error)); ok {
+		return rf(pluginID, prefix, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int, int) []*model.PluginKeyValue); ok {
+		r0 = rf(pluginID, prefix, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PluginKeyValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int, int) error); ok {
+		r1 = rf(pluginID, prefix, offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveOrUpdate provides a mock function with given fields: keyVal
 func (_m *PluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 	ret := _m.Called(keyVal)
@@ -214,6 +302,24 @@ func (_m *PluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.Plugin
 	return r0, r1
 }
 
+// SetMany provides a mock function with given fields: kvs
+func (_m *PluginStore) SetMany(kvs []*model.PluginKeyValue) error {
+	ret := _m.Called(kvs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetMany")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*model.PluginKeyValue) error); ok {
+		r0 = rf(kvs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetWithOptions provides a mock function with given fields: pluginID, key, value, options
 func (_m *PluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 	ret := _m.Called(pluginID, key, value, options)
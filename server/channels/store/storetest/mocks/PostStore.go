@@ -141,6 +141,41 @@ func (_m *PostStore) ClearCaches() {
 	_m.Called()
 }
 
+// CountPostsSinceForSync provides a mock function with given fields: options, cursor
+func (_m *PostStore) CountPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor) (int64, int64, error) {
+	ret := _m.Called(options, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountPostsSinceForSync")
+	}
+
+	var r0 int64
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(model.GetPostsSinceForSyncOptions, model.GetPostsSinceForSyncCursor) (int64, int64, error)); ok {
+		return rf(options, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(model.GetPostsSinceForSyncOptions, model.GetPostsSinceForSyncCursor) int64); ok {
+		r0 = rf(options, cursor)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(model.GetPostsSinceForSyncOptions, model.GetPostsSinceForSyncCursor) int64); ok {
+		r1 = rf(options, cursor)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(model.GetPostsSinceForSyncOptions, model.GetPostsSinceForSyncCursor) error); ok {
+		r2 = rf(options, cursor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Delete provides a mock function with given fields: rctx, postID, timestamp, deleteByID
 func (_m *PostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
 	ret := _m.Called(rctx, postID, timestamp, deleteByID)
@@ -368,6 +368,66 @@ func (_m *SharedChannelStore) GetSingleUser(userID string, channelID string, rem
 	return r0, r1
 }
 
+// GetSyncHealth provides a mock function with given fields: remoteID
+func (_m *SharedChannelStore) GetSyncHealth(remoteID string) ([]*model.SharedChannelSyncHealth, error) {
+	ret := _m.Called(remoteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyncHealth")
+	}
+
+	var r0 []*model.SharedChannelSyncHealth
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]*model.SharedChannelSyncHealth, error)); ok {
+		return rf(remoteID)
+	}
+	if rf, ok := ret.Get(0).(func(string) []*model.SharedChannelSyncHealth); ok {
+		r0 = rf(remoteID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SharedChannelSyncHealth)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(remoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSyncSuppressions provides a mock function with given fields: offset, limit, opts
+func (_m *SharedChannelStore) GetSyncSuppressions(offset int, limit int, opts model.SharedChannelSyncSuppressionFilterOpts) ([]*model.SharedChannelSyncSuppression, error) {
+	ret := _m.Called(offset, limit, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSyncSuppressions")
+	}
+
+	var r0 []*model.SharedChannelSyncSuppression
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int, model.SharedChannelSyncSuppressionFilterOpts) ([]*model.SharedChannelSyncSuppression, error)); ok {
+		return rf(offset, limit, opts)
+	}
+	if rf, ok := ret.Get(0).(func(int, int, model.SharedChannelSyncSuppressionFilterOpts) []*model.SharedChannelSyncSuppression); ok {
+		r0 = rf(offset, limit, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.SharedChannelSyncSuppression)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int, model.SharedChannelSyncSuppressionFilterOpts) error); ok {
+		r1 = rf(offset, limit, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUserChanges provides a mock function with given fields: userID, channelID, afterTime
 func (_m *SharedChannelStore) GetUserChanges(userID string, channelID string, afterTime int64) ([]*model.SharedChannelUser, error) {
 	ret := _m.Called(userID, channelID, afterTime)
@@ -604,6 +664,60 @@ func (_m *SharedChannelStore) SaveRemote(remote *model.SharedChannelRemote) (*mo
 	return r0, r1
 }
 
+// SaveSyncError provides a mock function with given fields: channelID, remoteID, syncError, errorAt, retry
+func (_m *SharedChannelStore) SaveSyncError(channelID string, remoteID string, syncError string, errorAt int64, retry bool) error {
+	ret := _m.Called(channelID, remoteID, syncError, errorAt, retry)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSyncError")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, int64, bool) error); ok {
+		r0 = rf(channelID, remoteID, syncError, errorAt, retry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSyncSuccess provides a mock function with given fields: channelID, remoteID, syncAt
+func (_m *SharedChannelStore) SaveSyncSuccess(channelID string, remoteID string, syncAt int64) error {
+	ret := _m.Called(channelID, remoteID, syncAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSyncSuccess")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, int64) error); ok {
+		r0 = rf(channelID, remoteID, syncAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveSyncSuppressions provides a mock function with given fields: suppressions
+func (_m *SharedChannelStore) SaveSyncSuppressions(suppressions []*model.SharedChannelSyncSuppression) error {
+	ret := _m.Called(suppressions)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveSyncSuppressions")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*model.SharedChannelSyncSuppression) error); ok {
+		r0 = rf(suppressions)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SaveUser provides a mock function with given fields: remote
 func (_m *SharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
 	ret := _m.Called(remote)
@@ -748,6 +862,24 @@ func (_m *SharedChannelStore) UpdateRemoteMembershipCursor(id string, syncTime i
 	return r0
 }
 
+// UpdateRemoteSyncFilters provides a mock function with given fields: id, filters
+func (_m *SharedChannelStore) UpdateRemoteSyncFilters(id string, filters *model.SharedChannelRemoteSyncFilters) error {
+	ret := _m.Called(id, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateRemoteSyncFilters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *model.SharedChannelRemoteSyncFilters) error); ok {
+		r0 = rf(id, filters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateUserLastMembershipSyncAt provides a mock function with given fields: userID, channelID, remoteID, syncTime
 func (_m *SharedChannelStore) UpdateUserLastMembershipSyncAt(userID string, channelID string, remoteID string, syncTime int64) error {
 	ret := _m.Called(userID, channelID, remoteID, syncTime)
@@ -618,6 +618,26 @@ func (_m *Store) License() store.LicenseStore {
 	return r0
 }
 
+// LegalHold provides a mock function with no fields
+func (_m *Store) LegalHold() store.LegalHoldStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for LegalHold")
+	}
+
+	var r0 store.LegalHoldStore
+	if rf, ok := ret.Get(0).(func() store.LegalHoldStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.LegalHoldStore)
+		}
+	}
+
+	return r0
+}
+
 // LinkMetadata provides a mock function with no fields
 func (_m *Store) LinkMetadata() store.LinkMetadataStore {
 	ret := _m.Called()
@@ -25,6 +25,10 @@ func TestPluginStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore)
 	t.Run("DeleteAllForPlugin", func(t *testing.T) { testPluginDeleteAllForPlugin(t, rctx, ss) })
 	t.Run("DeleteAllExpired", func(t *testing.T) { testPluginDeleteAllExpired(t, rctx, ss) })
 	t.Run("List", func(t *testing.T) { testPluginList(t, rctx, ss) })
+	t.Run("ListWithValues", func(t *testing.T) { testPluginListWithValues(t, rctx, ss) })
+	t.Run("GetMany", func(t *testing.T) { testPluginGetMany(t, rctx, ss) })
+	t.Run("SetMany", func(t *testing.T) { testPluginSetMany(t, rctx, ss) })
+	t.Run("CompareAndSwapMany", func(t *testing.T) { testPluginCompareAndSwapMany(t, rctx, ss) })
 }
 
 func setupKVs(t *testing.T, rctx request.CTX, ss store.Store) (string, func()) {
@@ -1370,3 +1374,185 @@ func testPluginList(t *testing.T, rctx request.CTX, ss store.Store) {
 		})
 	})
 }
+
+func testPluginListWithValues(t *testing.T, rctx request.CTX, ss store.Store) {
+	pluginID, tearDown := setupKVs(t, rctx, ss)
+	defer tearDown()
+
+	expireAt := model.GetMillis() + 5*60*1000
+	for _, kv := range []*model.PluginKeyValue{
+		{PluginId: pluginID, Key: "index_b", Value: []byte("b"), ExpireAt: expireAt},
+		{PluginId: pluginID, Key: "index_a", Value: []byte("a")},
+		{PluginId: pluginID, Key: "index_expired", Value: []byte("expired"), ExpireAt: 1},
+		{PluginId: pluginID, Key: "indexc", Value: []byte("c")},
+		{PluginId: pluginID, Key: "other", Value: []byte("other")},
+	} {
+		_, err := ss.Plugin().SaveOrUpdate(kv)
+		require.NoError(t, err)
+	}
+
+	t.Run("prefix", func(t *testing.T) {
+		kvs, err := ss.Plugin().ListWithValues(pluginID, "index_", 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.PluginKeyValue{
+			{PluginId: pluginID, Key: "index_a", Value: []byte("a")},
+			{PluginId: pluginID, Key: "index_b", Value: []byte("b"), ExpireAt: expireAt},
+		}, kvs)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		kvs, err := ss.Plugin().ListWithValues(pluginID, "index", 1, 1)
+		require.NoError(t, err)
+		require.Len(t, kvs, 1)
+		assert.Equal(t, "index_b", kvs[0].Key)
+	})
+
+	t.Run("no prefix", func(t *testing.T) {
+		kvs, err := ss.Plugin().ListWithValues(pluginID, "", 0, 100)
+		require.NoError(t, err)
+		// The key value created by setupKVs is listed too.
+		assert.Len(t, kvs, 5)
+	})
+
+	t.Run("unknown plugin", func(t *testing.T) {
+		kvs, err := ss.Plugin().ListWithValues(model.NewId(), "index_", 0, 100)
+		require.NoError(t, err)
+		assert.Empty(t, kvs)
+	})
+}
+
+func testPluginGetMany(t *testing.T, rctx request.CTX, ss store.Store) {
+	pluginID, tearDown := setupKVs(t, rctx, ss)
+	defer tearDown()
+
+	for _, kv := range []*model.PluginKeyValue{
+		{PluginId: pluginID, Key: "key1", Value: []byte("value1")},
+		{PluginId: pluginID, Key: "key2", Value: []byte("value2")},
+		{PluginId: pluginID, Key: "expired", Value: []byte("expired"), ExpireAt: 1},
+	} {
+		_, err := ss.Plugin().SaveOrUpdate(kv)
+		require.NoError(t, err)
+	}
+
+	kvs, err := ss.Plugin().GetMany(pluginID, []string{"key1", "key2", "expired", "missing"})
+	require.NoError(t, err)
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	assert.Equal(t, []*model.PluginKeyValue{
+		{PluginId: pluginID, Key: "key1", Value: []byte("value1")},
+		{PluginId: pluginID, Key: "key2", Value: []byte("value2")},
+	}, kvs)
+
+	kvs, err = ss.Plugin().GetMany(pluginID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, kvs)
+}
+
+func testPluginSetMany(t *testing.T, rctx request.CTX, ss store.Store) {
+	pluginID, tearDown := setupKVs(t, rctx, ss)
+	defer tearDown()
+
+	_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "deleted", Value: []byte("value")})
+	require.NoError(t, err)
+	_, err = ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "updated", Value: []byte("old")})
+	require.NoError(t, err)
+
+	err = ss.Plugin().SetMany([]*model.PluginKeyValue{
+		{PluginId: pluginID, Key: "deleted"},
+		{PluginId: pluginID, Key: "updated", Value: []byte("new")},
+		{PluginId: pluginID, Key: "inserted", Value: []byte("value"), ExpireAt: model.GetMillis() + 60*1000},
+	})
+	require.NoError(t, err)
+
+	_, err = ss.Plugin().Get(pluginID, "deleted")
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr)
+
+	kv, err := ss.Plugin().Get(pluginID, "updated")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("new"), kv.Value)
+
+	kv, err = ss.Plugin().Get(pluginID, "inserted")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), kv.Value)
+	assert.NotZero(t, kv.ExpireAt)
+
+	t.Run("invalid key value", func(t *testing.T) {
+		err = ss.Plugin().SetMany([]*model.PluginKeyValue{
+			{PluginId: pluginID, Key: "valid", Value: []byte("value")},
+			{PluginId: pluginID, Key: "", Value: []byte("value")},
+		})
+		require.Error(t, err)
+
+		_, err = ss.Plugin().Get(pluginID, "valid")
+		assert.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testPluginCompareAndSwapMany(t *testing.T, rctx request.CTX, ss store.Store) {
+	pluginID, tearDown := setupKVs(t, rctx, ss)
+	defer tearDown()
+
+	for _, kv := range []*model.PluginKeyValue{
+		{PluginId: pluginID, Key: "key1", Value: []byte("value1")},
+		{PluginId: pluginID, Key: "key2", Value: []byte("value2")},
+		{PluginId: pluginID, Key: "expired", Value: []byte("expired"), ExpireAt: 1},
+	} {
+		_, err := ss.Plugin().SaveOrUpdate(kv)
+		require.NoError(t, err)
+	}
+
+	assertValue := func(t *testing.T, key string, expected []byte) {
+		t.Helper()
+		kv, err := ss.Plugin().Get(pluginID, key)
+		if expected == nil {
+			var nfErr *store.ErrNotFound
+			assert.ErrorAs(t, err, &nfErr)
+			return
+		}
+		require.NoError(t, err)
+		assert.Equal(t, expected, kv.Value)
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		swapped, err := ss.Plugin().CompareAndSwapMany(pluginID, []*model.PluginKVCompareAndSwap{
+			{Key: "key1", OldValue: []byte("value1"), NewValue: []byte("new1")},
+			{Key: "key2", OldValue: []byte("wrong"), NewValue: []byte("new2")},
+		})
+		require.NoError(t, err)
+		assert.False(t, swapped)
+		assertValue(t, "key1", []byte("value1"))
+		assertValue(t, "key2", []byte("value2"))
+	})
+
+	t.Run("key expected to be missing exists", func(t *testing.T) {
+		swapped, err := ss.Plugin().CompareAndSwapMany(pluginID, []*model.PluginKVCompareAndSwap{
+			{Key: "key1", OldValue: []byte("value1"), NewValue: []byte("new1")},
+			{Key: "key2", NewValue: []byte("new2")},
+		})
+		require.NoError(t, err)
+		assert.False(t, swapped)
+		assertValue(t, "key1", []byte("value1"))
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		swapped, err := ss.Plugin().CompareAndSwapMany(pluginID, []*model.PluginKVCompareAndSwap{
+			{Key: "key1", OldValue: []byte("value1"), NewValue: []byte("new1")},
+			{Key: "key2", OldValue: []byte("value2")},
+			{Key: "expired", NewValue: []byte("inserted")},
+			{Key: "key3", NewValue: []byte("new3"), ExpireInSeconds: 60},
+		})
+		require.NoError(t, err)
+		assert.True(t, swapped)
+		assertValue(t, "key1", []byte("new1"))
+		assertValue(t, "key2", nil)
+		assertValue(t, "expired", []byte("inserted"))
+		assertValue(t, "key3", []byte("new3"))
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		_, err := ss.Plugin().CompareAndSwapMany(pluginID, []*model.PluginKVCompareAndSwap{
+			{Key: "", NewValue: []byte("value")},
+		})
+		require.Error(t, err)
+	})
+}
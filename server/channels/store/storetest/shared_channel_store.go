@@ -5,6 +5,7 @@ package storetest
 
 import (
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -34,6 +35,9 @@ func TestSharedChannelStore(t *testing.T, rctx request.CTX, ss store.Store, s Sq
 	t.Run("UpdateSharedChannelRemoteNextSyncAt", func(t *testing.T) { testUpdateSharedChannelRemoteCursor(t, rctx, ss) })
 	t.Run("UpdateGlobalUserSyncCursor", func(t *testing.T) { testUpdateGlobalUserSyncCursor(t, rctx, ss) })
 	t.Run("DeleteSharedChannelRemote", func(t *testing.T) { testDeleteSharedChannelRemote(t, rctx, ss) })
+	t.Run("UpdateSharedChannelRemoteSyncFilters", func(t *testing.T) { testUpdateSharedChannelRemoteSyncFilters(t, rctx, ss) })
+	t.Run("SharedChannelSyncSuppressions", func(t *testing.T) { testSharedChannelSyncSuppressions(t, rctx, ss) })
+	t.Run("SharedChannelSyncHealth", func(t *testing.T) { testSharedChannelSyncHealth(t, rctx, ss) })
 
 	t.Run("SaveSharedChannelUser", func(t *testing.T) { testSaveSharedChannelUser(t, rctx, ss) })
 	t.Run("GetSharedChannelSingleUser", func(t *testing.T) { testGetSingleSharedChannelUser(t, rctx, ss) })
@@ -933,6 +937,141 @@ func testUpdateSharedChannelRemoteCursor(t *testing.T, rctx request.CTX, ss stor
 	})
 }
 
+func testUpdateSharedChannelRemoteSyncFilters(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel, err := createTestChannel(ss, rctx, "test_remote_update_sync_filters")
+	require.NoError(t, err)
+
+	remote := &model.SharedChannelRemote{
+		ChannelId: channel.Id,
+		CreatorId: model.NewId(),
+		RemoteId:  model.NewId(),
+	}
+
+	remoteSaved, err := ss.SharedChannel().SaveRemote(remote)
+	require.NoError(t, err, "couldn't save remote", err)
+	require.Nil(t, remoteSaved.SyncFilters)
+
+	filters := &model.SharedChannelRemoteSyncFilters{
+		ExcludeBots:       true,
+		ExcludePostTypes:  []string{model.PostTypeJoinChannel},
+		ExcludeUserIds:    []string{model.NewId()},
+		ExcludeGroupIds:   []string{model.NewId()},
+		MaxAttachmentSize: 1024,
+	}
+
+	t.Run("Update sync filters for remote", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteSyncFilters(remoteSaved.Id, filters)
+		require.NoError(t, err)
+
+		r, err := ss.SharedChannel().GetRemote(remoteSaved.Id)
+		require.NoError(t, err)
+		require.Equal(t, filters, r.SyncFilters)
+	})
+
+	t.Run("Clear sync filters for remote", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteSyncFilters(remoteSaved.Id, nil)
+		require.NoError(t, err)
+
+		r, err := ss.SharedChannel().GetRemote(remoteSaved.Id)
+		require.NoError(t, err)
+		require.True(t, r.SyncFilters.IsEmpty())
+	})
+
+	t.Run("Update sync filters for non-existent shared channel remote", func(t *testing.T) {
+		err := ss.SharedChannel().UpdateRemoteSyncFilters(model.NewId(), filters)
+		var nfErr *store.ErrNotFound
+		require.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testSharedChannelSyncSuppressions(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	remoteID := model.NewId()
+	otherRemoteID := model.NewId()
+
+	suppressions := []*model.SharedChannelSyncSuppression{
+		{RemoteId: remoteID, ItemId: model.NewId(), ChannelId: channelID, ItemType: model.SharedChannelSyncItemPost, Reason: model.SharedChannelSyncSuppressedBot, CreateAt: 1000},
+		{RemoteId: remoteID, ItemId: model.NewId(), ChannelId: channelID, ItemType: model.SharedChannelSyncItemFile, Reason: model.SharedChannelSyncSuppressedAttachmentSize, CreateAt: 2000},
+		{RemoteId: otherRemoteID, ItemId: model.NewId(), ChannelId: channelID, ItemType: model.SharedChannelSyncItemPost, Reason: model.SharedChannelSyncSuppressedUser, CreateAt: 3000},
+	}
+
+	t.Run("Save sync suppressions", func(t *testing.T) {
+		err := ss.SharedChannel().SaveSyncSuppressions(suppressions)
+		require.NoError(t, err)
+
+		// saving the same items again is a no-op
+		err = ss.SharedChannel().SaveSyncSuppressions(suppressions)
+		require.NoError(t, err)
+	})
+
+	t.Run("Save invalid sync suppression", func(t *testing.T) {
+		err := ss.SharedChannel().SaveSyncSuppressions([]*model.SharedChannelSyncSuppression{{RemoteId: remoteID, ItemId: model.NewId(), ChannelId: channelID, ItemType: "invalid"}})
+		require.Error(t, err)
+	})
+
+	t.Run("Get sync suppressions by channel", func(t *testing.T) {
+		result, err := ss.SharedChannel().GetSyncSuppressions(0, 100, model.SharedChannelSyncSuppressionFilterOpts{ChannelId: channelID})
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		require.Equal(t, suppressions[2].ItemId, result[0].ItemId, "newest first")
+	})
+
+	t.Run("Get sync suppressions by remote", func(t *testing.T) {
+		result, err := ss.SharedChannel().GetSyncSuppressions(0, 100, model.SharedChannelSyncSuppressionFilterOpts{ChannelId: channelID, RemoteId: remoteID})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+	})
+
+	t.Run("Get sync suppressions by item ids", func(t *testing.T) {
+		opts := model.SharedChannelSyncSuppressionFilterOpts{RemoteId: remoteID, ItemIds: []string{suppressions[0].ItemId, model.NewId()}}
+		result, err := ss.SharedChannel().GetSyncSuppressions(0, 100, opts)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, suppressions[0].ItemId, result[0].ItemId)
+	})
+
+	t.Run("Get sync suppressions paginated", func(t *testing.T) {
+		result, err := ss.SharedChannel().GetSyncSuppressions(1, 1, model.SharedChannelSyncSuppressionFilterOpts{ChannelId: channelID})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, suppressions[1].ItemId, result[0].ItemId)
+	})
+}
+
+func testSharedChannelSyncHealth(t *testing.T, rctx request.CTX, ss store.Store) {
+	channelID := model.NewId()
+	remoteID := model.NewId()
+
+	t.Run("Get sync health of a remote without any sync", func(t *testing.T) {
+		result, err := ss.SharedChannel().GetSyncHealth(model.NewId())
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("Save sync errors and successes", func(t *testing.T) {
+		require.NoError(t, ss.SharedChannel().SaveSyncError(channelID, remoteID, "first failure", 1000, true))
+		require.NoError(t, ss.SharedChannel().SaveSyncError(channelID, remoteID, "remote is offline", 2000, false))
+		require.NoError(t, ss.SharedChannel().SaveSyncSuccess(channelID, remoteID, 3000))
+		require.NoError(t, ss.SharedChannel().SaveSyncError("", remoteID, "user sync failed", 4000, true))
+
+		result, err := ss.SharedChannel().GetSyncHealth(remoteID)
+		require.NoError(t, err)
+		require.Equal(t, []*model.SharedChannelSyncHealth{
+			{ChannelId: "", RetryCount: 1, LastError: "user sync failed", LastErrorAt: 4000},
+			{ChannelId: channelID, RetryCount: 1, LastSyncAt: 3000, LastError: "remote is offline", LastErrorAt: 2000},
+		}, result)
+	})
+
+	t.Run("Save a sync error longer than the maximum length", func(t *testing.T) {
+		require.NoError(t, ss.SharedChannel().SaveSyncError(channelID, remoteID, strings.Repeat("é", model.SharedChannelSyncErrorMaxLength), 5000, false))
+
+		result, err := ss.SharedChannel().GetSyncHealth(remoteID)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		require.LessOrEqual(t, len(result[1].LastError), model.SharedChannelSyncErrorMaxLength)
+	})
+}
+
 func testUpdateGlobalUserSyncCursor(t *testing.T, rctx request.CTX, ss store.Store) {
 	// Create a remote cluster first
 	rc := &model.RemoteCluster{
@@ -23,6 +23,7 @@ type Store struct {
 	PostStore                       mocks.PostStore
 	UserStore                       mocks.UserStore
 	RetentionPolicyStore            mocks.RetentionPolicyStore
+	LegalHoldStore                  mocks.LegalHoldStore
 	BotStore                        mocks.BotStore
 	AuditStore                      mocks.AuditStore
 	ClusterDiscoveryStore           mocks.ClusterDiscoveryStore
@@ -81,6 +82,7 @@ func (s *Store) Channel() store.ChannelStore                   { return &s.Chann
 func (s *Store) Post() store.PostStore                         { return &s.PostStore }
 func (s *Store) User() store.UserStore                         { return &s.UserStore }
 func (s *Store) RetentionPolicy() store.RetentionPolicyStore   { return &s.RetentionPolicyStore }
+func (s *Store) LegalHold() store.LegalHoldStore               { return &s.LegalHoldStore }
 func (s *Store) Bot() store.BotStore                           { return &s.BotStore }
 func (s *Store) ProductNotices() store.ProductNoticesStore     { return &s.ProductNoticesStore }
 func (s *Store) Audit() store.AuditStore                       { return &s.AuditStore }
@@ -564,9 +564,14 @@ func testWebhookStoreUpdateOutgoing(t *testing.T, rctx request.CTX, ss store.Sto
 
 	o1.Token = model.NewId()
 	o1.Username = "another-test-user-name"
+	o1.EventTypes = []string{model.OutgoingWebhookEventUserJoinedChannel}
 
 	_, err := ss.Webhook().UpdateOutgoing(o1)
 	require.NoError(t, err)
+
+	webhook, err := ss.Webhook().GetOutgoing(o1.Id)
+	require.NoError(t, err)
+	require.Equal(t, model.StringArray{model.OutgoingWebhookEventUserJoinedChannel}, webhook.EventTypes)
 }
 
 func testWebhookStoreCountIncoming(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -36,6 +36,7 @@ type TimerLayer struct {
 	FileInfoStore                   store.FileInfoStore
 	GroupStore                      store.GroupStore
 	JobStore                        store.JobStore
+	LegalHoldStore                  store.LegalHoldStore
 	LicenseStore                    store.LicenseStore
 	LinkMetadataStore               store.LinkMetadataStore
 	NotifyAdminStore                store.NotifyAdminStore
@@ -140,6 +141,10 @@ func (s *TimerLayer) Job() store.JobStore {
 	return s.JobStore
 }
 
+func (s *TimerLayer) LegalHold() store.LegalHoldStore {
+	return s.LegalHoldStore
+}
+
 func (s *TimerLayer) License() store.LicenseStore {
 	return s.LicenseStore
 }
@@ -361,6 +366,11 @@ type TimerLayerJobStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerLegalHoldStore struct {
+	store.LegalHoldStore
+	Root *TimerLayer
+}
+
 type TimerLayerLicenseStore struct {
 	store.LicenseStore
 	Root *TimerLayer
@@ -5303,6 +5313,118 @@ func (s *TimerLayerJobStore) UpdateStatusOptimistically(id string, currentStatus
 	return result, err
 }
 
+func (s *TimerLayerLegalHoldStore) Delete(id string, deleteAt int64) error {
+	start := time.Now()
+
+	err := s.LegalHoldStore.Delete(id, deleteAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LegalHoldStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerLegalHoldStore) Get(id string) (*model.LegalHold, error) {
+	start := time.Now()
+
+	result, err := s.LegalHoldStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LegalHoldStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerLegalHoldStore) GetAll(offset int, limit int) ([]*model.LegalHold, error) {
+	start := time.Now()
+
+	result, err := s.LegalHoldStore.GetAll(offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LegalHoldStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerLegalHoldStore) GetForChannel(channelID string) ([]*model.LegalHold, error) {
+	start := time.Now()
+
+	result, err := s.LegalHoldStore.GetForChannel(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LegalHoldStore.GetForChannel", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerLegalHoldStore) GetForUser(userID string) ([]*model.LegalHold, error) {
+	start := time.Now()
+
+	result, err := s.LegalHoldStore.GetForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LegalHoldStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerLegalHoldStore) Save(hold *model.LegalHold) (*model.LegalHold, error) {
+	start := time.Now()
+
+	result, err := s.LegalHoldStore.Save(hold)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LegalHoldStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerLegalHoldStore) Update(hold *model.LegalHold) (*model.LegalHold, error) {
+	start := time.Now()
+
+	result, err := s.LegalHoldStore.Update(hold)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("LegalHoldStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerLicenseStore) Get(c request.CTX, id string) (*model.LicenseRecord, error) {
 	start := time.Now()
 
@@ -5911,6 +6033,22 @@ func (s *TimerLayerPluginStore) CompareAndSet(keyVal *model.PluginKeyValue, oldV
 	return result, err
 }
 
+func (s *TimerLayerPluginStore) CompareAndSwapMany(pluginID string, operations []*model.PluginKVCompareAndSwap) (bool, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.CompareAndSwapMany(pluginID, operations)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.CompareAndSwapMany", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPluginStore) Delete(pluginID string, key string) error {
 	start := time.Now()
 
@@ -5975,6 +6113,22 @@ func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 	return result, err
 }
 
+func (s *TimerLayerPluginStore) GetMany(pluginID string, keys []string) ([]*model.PluginKeyValue, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.GetMany(pluginID, keys)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.GetMany", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 	start := time.Now()
 
@@ -5991,6 +6145,22 @@ func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 	return result, err
 }
 
+func (s *TimerLayerPluginStore) ListWithValues(pluginID string, prefix string, offset int, limit int) ([]*model.PluginKeyValue, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.ListWithValues(pluginID, prefix, offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.ListWithValues", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 	start := time.Now()
 
@@ -6007,6 +6177,22 @@ func (s *TimerLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*mod
 	return result, err
 }
 
+func (s *TimerLayerPluginStore) SetMany(kvs []*model.PluginKeyValue) error {
+	start := time.Now()
+
+	err := s.PluginStore.SetMany(kvs)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.SetMany", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerPluginStore) SetWithOptions(pluginID string, key string, value []byte, options model.PluginKVSetOptions) (bool, error) {
 	start := time.Now()
 
@@ -6102,6 +6288,22 @@ func (s *TimerLayerPostStore) ClearCaches() {
 	}
 }
 
+func (s *TimerLayerPostStore) CountPostsSinceForSync(options model.GetPostsSinceForSyncOptions, cursor model.GetPostsSinceForSyncCursor) (int64, int64, error) {
+	start := time.Now()
+
+	result, resultVar1, err := s.PostStore.CountPostsSinceForSync(options, cursor)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostStore.CountPostsSinceForSync", success, elapsed)
+	}
+	return result, resultVar1, err
+}
+
 func (s *TimerLayerPostStore) Delete(rctx request.CTX, postID string, timestamp int64, deleteByID string) error {
 	start := time.Now()
 
@@ -9269,6 +9471,38 @@ func (s *TimerLayerSharedChannelStore) GetSingleUser(userID string, channelID st
 	return result, err
 }
 
+func (s *TimerLayerSharedChannelStore) GetSyncHealth(remoteID string) ([]*model.SharedChannelSyncHealth, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.GetSyncHealth(remoteID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetSyncHealth", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSharedChannelStore) GetSyncSuppressions(offset int, limit int, opts model.SharedChannelSyncSuppressionFilterOpts) ([]*model.SharedChannelSyncSuppression, error) {
+	start := time.Now()
+
+	result, err := s.SharedChannelStore.GetSyncSuppressions(offset, limit, opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.GetSyncSuppressions", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSharedChannelStore) GetUserChanges(userID string, channelID string, afterTime int64) ([]*model.SharedChannelUser, error) {
 	start := time.Now()
 
@@ -9397,6 +9631,54 @@ func (s *TimerLayerSharedChannelStore) SaveRemote(remote *model.SharedChannelRem
 	return result, err
 }
 
+func (s *TimerLayerSharedChannelStore) SaveSyncError(channelID string, remoteID string, syncError string, errorAt int64, retry bool) error {
+	start := time.Now()
+
+	err := s.SharedChannelStore.SaveSyncError(channelID, remoteID, syncError, errorAt, retry)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveSyncError", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSharedChannelStore) SaveSyncSuccess(channelID string, remoteID string, syncAt int64) error {
+	start := time.Now()
+
+	err := s.SharedChannelStore.SaveSyncSuccess(channelID, remoteID, syncAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveSyncSuccess", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSharedChannelStore) SaveSyncSuppressions(suppressions []*model.SharedChannelSyncSuppression) error {
+	start := time.Now()
+
+	err := s.SharedChannelStore.SaveSyncSuppressions(suppressions)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.SaveSyncSuppressions", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerSharedChannelStore) SaveUser(remote *model.SharedChannelUser) (*model.SharedChannelUser, error) {
 	start := time.Now()
 
@@ -9493,6 +9775,22 @@ func (s *TimerLayerSharedChannelStore) UpdateRemoteMembershipCursor(id string, s
 	return err
 }
 
+func (s *TimerLayerSharedChannelStore) UpdateRemoteSyncFilters(id string, filters *model.SharedChannelRemoteSyncFilters) error {
+	start := time.Now()
+
+	err := s.SharedChannelStore.UpdateRemoteSyncFilters(id, filters)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SharedChannelStore.UpdateRemoteSyncFilters", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerSharedChannelStore) UpdateUserLastMembershipSyncAt(userID string, channelID string, remoteID string, syncTime int64) error {
 	start := time.Now()
 
@@ -13287,6 +13585,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.FileInfoStore = &TimerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &TimerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
+	newStore.LegalHoldStore = &TimerLayerLegalHoldStore{LegalHoldStore: childStore.LegalHold(), Root: &newStore}
 	newStore.LicenseStore = &TimerLayerLicenseStore{LicenseStore: childStore.License(), Root: &newStore}
 	newStore.LinkMetadataStore = &TimerLayerLinkMetadataStore{LinkMetadataStore: childStore.LinkMetadata(), Root: &newStore}
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
@@ -422,6 +422,17 @@ func (c *Context) RequirePolicyId() *Context {
 	return c
 }
 
+func (c *Context) RequireLegalHoldId() *Context {
+	if c.Err != nil {
+		return c
+	}
+
+	if !model.IsValidId(c.Params.LegalHoldId) {
+		c.SetInvalidURLParam("legal_hold_id")
+	}
+	return c
+}
+
 func (c *Context) RequireAppId() *Context {
 	if c.Err != nil {
 		return c
@@ -36,6 +36,7 @@ type Params struct {
 	ChannelId                          string
 	PostId                             string
 	PolicyId                           string
+	LegalHoldId                        string
 	FileId                             string
 	Filename                           string
 	UploadId                           string
@@ -145,6 +146,7 @@ func ParamsFromRequest(r *http.Request) *Params {
 
 	params.PostId = props["post_id"]
 	params.PolicyId = props["policy_id"]
+	params.LegalHoldId = props["legal_hold_id"]
 	params.FileId = props["file_id"]
 	params.Filename = query.Get("filename")
 	params.UploadId = props["upload_id"]
@@ -60,3 +60,9 @@ func (rw *responseWriterWrapper) Flush() {
 		rw.flusher.Flush()
 	}
 }
+
+// Unwrap allows http.ResponseController to reach the original writer,
+// e.g. to extend the write deadline of long-lived responses.
+func (rw *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -86,7 +86,7 @@ func incomingWebhook(c *Context, w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	} else {
-		incomingWebhookPayload, appErr = decodePayload(r.Body)
+		incomingWebhookPayload, appErr = c.App.DecodeIncomingWebhookPayload(id, r.Body)
 		if appErr != nil {
 			c.Err = model.NewAppError("incomingWebhook", "web.incoming_webhook.decode.app_error", errCtx, "", appErr.StatusCode).Wrap(appErr)
 			return
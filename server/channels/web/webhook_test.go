@@ -228,6 +228,31 @@ func TestIncomingWebhook(t *testing.T) {
 		assert.True(t, resp.StatusCode == http.StatusForbidden)
 	})
 
+	t.Run("TransformedWebhook", func(t *testing.T) {
+		hook, err := th.App.CreateIncomingWebhookForChannel(th.BasicUser.Id, th.BasicChannel, &model.IncomingWebhook{
+			ChannelId:         th.BasicChannel.Id,
+			TransformTemplate: `{"text": {{json (printf "[%s] %s" (upper .status) .alert.name)}}, "props": {"severity": {{json .alert.severity}}}}`,
+		})
+		require.Nil(t, err)
+
+		apiHookURL := apiClient.URL + "/hooks/" + hook.Id
+
+		resp, err2 := http.Post(apiHookURL, "application/json", strings.NewReader(`{"status": "firing", "alert": {"name": "HighLatency", "severity": "critical"}}`))
+		require.NoError(t, err2)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		posts, err := th.App.GetPostsPage(model.GetPostsOptions{ChannelId: th.BasicChannel.Id, Page: 0, PerPage: 1})
+		require.Nil(t, err)
+		require.Len(t, posts.Order, 1)
+		post := posts.Posts[posts.Order[0]]
+		assert.Equal(t, "[FIRING] HighLatency", post.Message)
+		assert.Equal(t, "critical", post.GetProp("severity"))
+
+		resp, err2 = http.Post(apiHookURL, "application/json", strings.NewReader(`{"status": "firing"`))
+		require.NoError(t, err2)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
 	t.Run("DisableWebhooks", func(t *testing.T) {
 		th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableIncomingWebhooks = false })
 		resp, err := http.Post(url, "application/json", strings.NewReader("{\"text\":\"this is a test\"}"))
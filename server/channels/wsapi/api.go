@@ -24,4 +24,5 @@ func Init(s *app.Server) {
 	api.InitUser()
 	api.InitSystem()
 	api.InitStatus()
+	api.InitSubscription()
 }
@@ -0,0 +1,53 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package wsapi
+
+import (
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
+)
+
+func (api *API) InitSubscription() {
+	api.Router.Handle("subscribe", api.APIWebSocketConnHandler(api.subscribe))
+}
+
+func (api *API) subscribe(conn *platform.WebConn, req *model.WebSocketRequest) (map[string]any, *model.AppError) {
+	for _, name := range []string{"event_types", "channel_ids"} {
+		if value, ok := req.Data[name]; ok && value != nil {
+			if _, ok := value.([]any); !ok {
+				return nil, NewInvalidWebSocketParamError(req.Action, name)
+			}
+		}
+	}
+
+	eventTypeNames := model.ArrayFromInterface(req.Data["event_types"])
+	if len(eventTypeNames) > platform.MaxWebConnSubscriptionItems {
+		return nil, NewInvalidWebSocketParamError(req.Action, "event_types")
+	}
+	eventTypes := make([]model.WebsocketEventType, 0, len(eventTypeNames))
+	for _, name := range eventTypeNames {
+		if name == "" {
+			return nil, NewInvalidWebSocketParamError(req.Action, "event_types")
+		}
+		eventTypes = append(eventTypes, model.WebsocketEventType(name))
+	}
+
+	channelIDs := model.ArrayFromInterface(req.Data["channel_ids"])
+	if len(channelIDs) > platform.MaxWebConnSubscriptionItems {
+		return nil, NewInvalidWebSocketParamError(req.Action, "channel_ids")
+	}
+	for _, channelID := range channelIDs {
+		if !model.IsValidId(channelID) {
+			return nil, NewInvalidWebSocketParamError(req.Action, "channel_ids")
+		}
+	}
+
+	conn.SetSubscription(eventTypes, channelIDs)
+
+	eventTypes, channelIDs = conn.GetSubscription()
+	return map[string]any{
+		"event_types": eventTypes,
+		"channel_ids": channelIDs,
+	}, nil
+}
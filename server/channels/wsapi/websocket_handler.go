@@ -14,12 +14,20 @@ import (
 )
 
 func (api *API) APIWebSocketHandler(wh func(*model.WebSocketRequest) (map[string]any, *model.AppError)) webSocketHandler {
+	return webSocketHandler{api.App, func(_ *platform.WebConn, r *model.WebSocketRequest) (map[string]any, *model.AppError) {
+		return wh(r)
+	}}
+}
+
+// APIWebSocketConnHandler is like APIWebSocketHandler, for handlers that act
+// on the connection the request was received on.
+func (api *API) APIWebSocketConnHandler(wh func(*platform.WebConn, *model.WebSocketRequest) (map[string]any, *model.AppError)) webSocketHandler {
 	return webSocketHandler{api.App, wh}
 }
 
 type webSocketHandler struct {
 	app         *app.App
-	handlerFunc func(*model.WebSocketRequest) (map[string]any, *model.AppError)
+	handlerFunc func(*platform.WebConn, *model.WebSocketRequest) (map[string]any, *model.AppError)
 }
 
 func (wh webSocketHandler) ServeWebSocket(conn *platform.WebConn, r *model.WebSocketRequest) {
@@ -56,7 +64,7 @@ func (wh webSocketHandler) ServeWebSocket(conn *platform.WebConn, r *model.WebSo
 	var data map[string]any
 	var err *model.AppError
 
-	if data, err = wh.handlerFunc(r); err != nil {
+	if data, err = wh.handlerFunc(conn, r); err != nil {
 		mlog.Error(
 			"websocket request handling error",
 			mlog.String("action", r.Action),
@@ -59,6 +59,7 @@ type Client interface {
 	RemovePlugin(ctx context.Context, id string) (*model.Response, error)
 	EnablePlugin(ctx context.Context, id string) (*model.Response, error)
 	DisablePlugin(ctx context.Context, id string) (*model.Response, error)
+	ApprovePluginPermissions(ctx context.Context, id string) (*model.Response, error)
 	GetPlugins(ctx context.Context) (*model.PluginsResponse, *model.Response, error)
 	GetUser(ctx context.Context, userID, etag string) (*model.User, *model.Response, error)
 	GetUserByUsername(ctx context.Context, userName, etag string) (*model.User, *model.Response, error)
@@ -165,4 +166,31 @@ type Client interface {
 	DeletePreferences(ctx context.Context, userId string, preferences model.Preferences) (*model.Response, error)
 	PermanentDeletePost(ctx context.Context, postID string) (*model.Response, error)
 	DeletePost(ctx context.Context, postId string) (*model.Response, error)
+	GetMe(ctx context.Context, etag string) (*model.User, *model.Response, error)
+	CreateEmoji(ctx context.Context, emoji *model.Emoji, image []byte, filename string) (*model.Emoji, *model.Response, error)
+	GetSortedEmojiList(ctx context.Context, page, perPage int, sort string) ([]*model.Emoji, *model.Response, error)
+	GetEmojiByName(ctx context.Context, name string) (*model.Emoji, *model.Response, error)
+	GetEmojiImage(ctx context.Context, emojiId string) ([]byte, *model.Response, error)
+	SearchEmoji(ctx context.Context, search *model.EmojiSearch) ([]*model.Emoji, *model.Response, error)
+	DeleteEmoji(ctx context.Context, emojiId string) (*model.Response, error)
+	ListChannelBookmarksForChannel(ctx context.Context, channelId string, since int64) ([]*model.ChannelBookmarkWithFileInfo, *model.Response, error)
+	CreateChannelBookmark(ctx context.Context, channelBookmark *model.ChannelBookmark) (*model.ChannelBookmarkWithFileInfo, *model.Response, error)
+	UpdateChannelBookmark(ctx context.Context, channelId, bookmarkId string, patch *model.ChannelBookmarkPatch) (*model.UpdateChannelBookmarkResponse, *model.Response, error)
+	UpdateChannelBookmarkSortOrder(ctx context.Context, channelId, bookmarkId string, sortOrder int64) ([]*model.ChannelBookmarkWithFileInfo, *model.Response, error)
+	DeleteChannelBookmark(ctx context.Context, channelId, bookmarkId string) (*model.ChannelBookmarkWithFileInfo, *model.Response, error)
+	GetSidebarCategoriesForTeamForUser(ctx context.Context, userID, teamID, etag string) (*model.OrderedSidebarCategories, *model.Response, error)
+	CreateSidebarCategoryForTeamForUser(ctx context.Context, userID, teamID string, category *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.Response, error)
+	UpdateSidebarCategoryForTeamForUser(ctx context.Context, userID, teamID, categoryID string, category *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.Response, error)
+	GetTeamMembers(ctx context.Context, teamID string, page int, perPage int, etag string) ([]*model.TeamMember, *model.Response, error)
+	UpdateTeamMemberSchemeRoles(ctx context.Context, teamID string, userID string, schemeRoles *model.SchemeRoles) (*model.Response, error)
+	UpdateChannelMemberSchemeRoles(ctx context.Context, channelID string, userID string, schemeRoles *model.SchemeRoles) (*model.Response, error)
+	GetRemoteClustersSyncHealth(ctx context.Context) ([]*model.RemoteClusterSyncHealth, *model.Response, error)
+	GetRemoteClusterSyncHealth(ctx context.Context, remoteClusterId string) (*model.RemoteClusterSyncHealth, *model.Response, error)
+	ResyncRemoteCluster(ctx context.Context, remoteClusterId string, req *model.SharedChannelResyncRequest) (*model.Response, error)
+	GetLegalHolds(ctx context.Context, page, perPage int) ([]*model.LegalHold, *model.Response, error)
+	GetLegalHold(ctx context.Context, legalHoldID string) (*model.LegalHold, *model.Response, error)
+	CreateLegalHold(ctx context.Context, hold *model.LegalHold) (*model.LegalHold, *model.Response, error)
+	PatchLegalHold(ctx context.Context, legalHoldID string, patch *model.LegalHoldPatch) (*model.LegalHold, *model.Response, error)
+	ReleaseLegalHold(ctx context.Context, legalHoldID string) (*model.Response, error)
+	ExportLegalHold(ctx context.Context, legalHoldID, exportType string) (*model.Job, *model.Response, error)
 }
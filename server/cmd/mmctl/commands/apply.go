@@ -0,0 +1,1093 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	applyActionCreate  = "create"
+	applyActionUpdate  = "update"
+	applyActionDelete  = "delete"
+	applyActionArchive = "archive"
+	applyActionRestore = "restore"
+	applyActionAdd     = "add"
+	applyActionRemove  = "remove"
+
+	applyKindTeam            = "team"
+	applyKindTeamMember      = "team member"
+	applyKindChannel         = "channel"
+	applyKindChannelMember   = "channel member"
+	applyKindIncomingWebhook = "incoming webhook"
+	applyKindOutgoingWebhook = "outgoing webhook"
+	applyKindCommand         = "command"
+	applyKindUserRoles       = "user roles"
+
+	applyPlanTemplate = "{{.Action}} {{.Kind}} {{.Name}}{{range .Changes}}\n    {{.}}{{end}}"
+
+	applyPerPage = 200
+)
+
+var ApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Apply a desired state to the server",
+	Long: `Compare the teams, channels, memberships, roles, webhooks and slash commands described in a YAML state file with the server, print the changes needed to reach that state, and apply them.
+
+Running the command again with the same file produces no changes. Only the collections present in the file are managed: for example, the members of a team are only compared when the team declares a "members" list.
+
+With --prune, items of a managed collection that are not in the file are removed: team and channel members are removed, channels are archived, and webhooks and slash commands are deleted. The user running the command and the creators of the channels, who the server adds as members, are never removed.
+
+Example state file:
+
+  teams:
+    - name: engineering
+      display_name: Engineering
+      type: I
+      members:
+        - alice
+        - user: bob
+          admin: true
+      channels:
+        - name: deployments
+          display_name: Deployments
+          type: O
+          purpose: Deployment notifications
+          members: [alice, bob]
+      incoming_webhooks:
+        - display_name: CI
+          channel: deployments
+      outgoing_webhooks:
+        - display_name: Deploy bot
+          channel: deployments
+          trigger_words: [deploy]
+          callback_urls: [https://example.com/deploy]
+      commands:
+        - trigger: oncall
+          url: https://example.com/oncall
+          method: POST
+  users:
+    - user: alice
+      roles: system_user system_user_manager`,
+	Example: `  apply -f state.yaml
+  apply -f state.yaml --dry-run
+  apply -f state.yaml --prune`,
+	Args: cobra.NoArgs,
+	RunE: withClient(applyCmdF),
+}
+
+func init() {
+	ApplyCmd.Flags().StringP("file", "f", "", "Required. Path to the YAML file describing the desired state")
+	_ = ApplyCmd.MarkFlagRequired("file")
+	ApplyCmd.Flags().Bool("dry-run", false, "Only print the changes, without applying them")
+	ApplyCmd.Flags().Bool("prune", false, "Remove the items of the managed collections that are not present in the state file")
+
+	RootCmd.AddCommand(ApplyCmd)
+}
+
+// applyAction is a single change of the plan. The apply function is
+// only run once the whole plan has been computed, and it can depend on
+// the effects of the actions that precede it.
+type applyAction struct {
+	Action  string   `json:"action"`
+	Kind    string   `json:"kind"`
+	Name    string   `json:"name"`
+	Changes []string `json:"changes,omitempty"`
+
+	apply func() error
+}
+
+func applyCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	path, _ := cmd.Flags().GetString("file")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	prune, _ := cmd.Flags().GetBool("prune")
+
+	state, err := loadApplyState(path)
+	if err != nil {
+		return err
+	}
+
+	planner := newApplyPlanner(c, prune)
+	actions, err := planner.plan(state)
+	if err != nil {
+		return errors.Wrap(err, "could not compute the plan")
+	}
+
+	if len(actions) == 0 {
+		printer.Print("No changes. The server matches the state file.")
+		return nil
+	}
+
+	for _, action := range actions {
+		printer.PrintT(applyPlanTemplate, action)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	for i, action := range actions {
+		if err := action.apply(); err != nil {
+			return errors.Wrapf(err, "could not %s %s %q, %d of %d changes applied", action.Action, action.Kind, action.Name, i, len(actions))
+		}
+	}
+
+	printer.Print(fmt.Sprintf("Applied %d changes.", len(actions)))
+	return nil
+}
+
+type applyPlanner struct {
+	c       client.Client
+	prune   bool
+	actions []*applyAction
+
+	// usersByArg caches the users referenced in the state file and
+	// usernames the users found in the server by ID
+	usersByArg map[string]*model.User
+	usernames  map[string]string
+
+	// me is the user applying the state, who the server adds to the
+	// teams and channels it creates. It's only loaded when pruning.
+	me       *model.User
+	meLoaded bool
+}
+
+func newApplyPlanner(c client.Client, prune bool) *applyPlanner {
+	return &applyPlanner{
+		c:          c,
+		prune:      prune,
+		usersByArg: map[string]*model.User{},
+		usernames:  map[string]string{},
+	}
+}
+
+func (p *applyPlanner) add(action, kind, name string, changes []string, apply func() error) {
+	p.actions = append(p.actions, &applyAction{
+		Action:  action,
+		Kind:    kind,
+		Name:    name,
+		Changes: changes,
+		apply:   apply,
+	})
+}
+
+func (p *applyPlanner) plan(state *applyState) ([]*applyAction, error) {
+	for _, team := range state.Teams {
+		if err := p.planTeam(team); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, user := range state.Users {
+		if err := p.planUserRoles(user); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.actions, nil
+}
+
+func (p *applyPlanner) user(userArg string) (*model.User, error) {
+	if user, ok := p.usersByArg[userArg]; ok {
+		return user, nil
+	}
+
+	user := getUserFromUserArg(p.c, userArg)
+	if user == nil {
+		return nil, errors.Errorf("unable to find user %q", userArg)
+	}
+	p.usersByArg[userArg] = user
+	p.usernames[user.Id] = user.Username
+
+	return user, nil
+}
+
+// applyingUserID returns the ID of the user applying the state, or an
+// empty string in local mode, where teams and channels are created
+// without any member
+func (p *applyPlanner) applyingUserID() string {
+	if !p.meLoaded {
+		p.meLoaded = true
+		if me, _, err := p.c.GetMe(context.TODO(), ""); err == nil {
+			p.me = me
+		}
+	}
+	if p.me == nil {
+		return ""
+	}
+	return p.me.Id
+}
+
+// username returns a readable name for a user found in the server,
+// falling back to its ID if it hasn't been loaded
+func (p *applyPlanner) username(userID string) string {
+	if name, ok := p.usernames[userID]; ok {
+		return name
+	}
+	return userID
+}
+
+// loadUsernames fetches the users that are not known yet in a single
+// request
+func (p *applyPlanner) loadUsernames(userIDs []string) error {
+	var missing []string
+	for _, id := range userIDs {
+		if _, ok := p.usernames[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	users, _, err := p.c.GetUsersByIds(context.TODO(), missing)
+	if err != nil {
+		return errors.Wrap(err, "could not get users")
+	}
+	for _, user := range users {
+		p.usernames[user.Id] = user.Username
+	}
+
+	return nil
+}
+
+func isNotFound(resp *model.Response, err error) bool {
+	return err != nil && resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+func (p *applyPlanner) planTeam(desired *applyTeam) error {
+	existing, resp, err := p.c.GetTeamByName(context.TODO(), desired.Name, "")
+	if err != nil && !isNotFound(resp, err) {
+		return errors.Wrapf(err, "could not get team %q", desired.Name)
+	}
+
+	// team is shared with the actions planned for the team's children
+	// so they can use its ID once the team has been created
+	team := &model.Team{Name: desired.Name}
+	if existing == nil {
+		newTeam := &model.Team{
+			Name:        desired.Name,
+			DisplayName: desired.DisplayName,
+			Type:        desired.Type,
+			Description: desired.Description,
+		}
+		if newTeam.DisplayName == "" {
+			newTeam.DisplayName = desired.Name
+		}
+		if newTeam.Type == "" {
+			newTeam.Type = model.TeamOpen
+		}
+		if desired.AllowOpenInvite != nil {
+			newTeam.AllowOpenInvite = *desired.AllowOpenInvite
+		}
+
+		p.add(applyActionCreate, applyKindTeam, desired.Name, nil, func() error {
+			created, _, err := p.c.CreateTeam(context.TODO(), newTeam)
+			if err != nil {
+				return err
+			}
+			*team = *created
+			return nil
+		})
+	} else {
+		*team = *existing
+		p.planTeamUpdate(team, desired)
+	}
+
+	if err := p.planTeamMembers(team, existing != nil, desired); err != nil {
+		return err
+	}
+
+	channels := map[string]*model.Channel{}
+	for _, desiredChannel := range desired.Channels {
+		channel, err := p.planChannel(team, existing != nil, desiredChannel)
+		if err != nil {
+			return err
+		}
+		channels[desiredChannel.Name] = channel
+	}
+
+	if p.prune && existing != nil && desired.Channels != nil {
+		if err := p.planChannelsPrune(team, channels); err != nil {
+			return err
+		}
+	}
+
+	if err := p.planIncomingWebhooks(team, existing != nil, desired, channels); err != nil {
+		return err
+	}
+
+	if err := p.planOutgoingWebhooks(team, existing != nil, desired, channels); err != nil {
+		return err
+	}
+
+	return p.planCommands(team, existing != nil, desired)
+}
+
+func (p *applyPlanner) planTeamUpdate(team *model.Team, desired *applyTeam) {
+	var changes []string
+	patch := &model.TeamPatch{}
+	if desired.DisplayName != "" && desired.DisplayName != team.DisplayName {
+		changes = append(changes, fmt.Sprintf("display_name: %q -> %q", team.DisplayName, desired.DisplayName))
+		patch.DisplayName = model.NewPointer(desired.DisplayName)
+	}
+	if desired.Description != "" && desired.Description != team.Description {
+		changes = append(changes, fmt.Sprintf("description: %q -> %q", team.Description, desired.Description))
+		patch.Description = model.NewPointer(desired.Description)
+	}
+	if desired.AllowOpenInvite != nil && *desired.AllowOpenInvite != team.AllowOpenInvite {
+		changes = append(changes, fmt.Sprintf("allow_open_invite: %t -> %t", team.AllowOpenInvite, *desired.AllowOpenInvite))
+		patch.AllowOpenInvite = desired.AllowOpenInvite
+	}
+	patchChanges := len(changes)
+
+	updatePrivacy := desired.Type != "" && desired.Type != team.Type
+	if updatePrivacy {
+		changes = append(changes, fmt.Sprintf("type: %q -> %q", team.Type, desired.Type))
+	}
+
+	if len(changes) == 0 {
+		return
+	}
+
+	p.add(applyActionUpdate, applyKindTeam, desired.Name, changes, func() error {
+		if patchChanges > 0 {
+			if _, _, err := p.c.PatchTeam(context.TODO(), team.Id, patch); err != nil {
+				return err
+			}
+		}
+		if updatePrivacy {
+			if _, _, err := p.c.UpdateTeamPrivacy(context.TODO(), team.Id, desired.Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (p *applyPlanner) planTeamMembers(team *model.Team, exists bool, desired *applyTeam) error {
+	if desired.Members == nil {
+		return nil
+	}
+
+	current := map[string]*model.TeamMember{}
+	if exists {
+		for page := 0; ; page++ {
+			members, _, err := p.c.GetTeamMembers(context.TODO(), team.Id, page, applyPerPage, "")
+			if err != nil {
+				return errors.Wrapf(err, "could not get members of team %q", desired.Name)
+			}
+			for _, member := range members {
+				if member.DeleteAt == 0 {
+					current[member.UserId] = member
+				}
+			}
+			if len(members) < applyPerPage {
+				break
+			}
+		}
+	}
+
+	wanted := map[string]bool{}
+	for _, desiredMember := range desired.Members {
+		user, err := p.user(desiredMember.User)
+		if err != nil {
+			return errors.Wrapf(err, "team %q", desired.Name)
+		}
+		wanted[user.Id] = true
+
+		name := desired.Name + ":" + user.Username
+		admin := desiredMember.Admin
+		member, ok := current[user.Id]
+		switch {
+		case !ok:
+			var changes []string
+			if admin {
+				changes = []string{"admin: true"}
+			}
+			p.add(applyActionAdd, applyKindTeamMember, name, changes, func() error {
+				if _, _, err := p.c.AddTeamMember(context.TODO(), team.Id, user.Id); err != nil {
+					return err
+				}
+				if admin {
+					_, err := p.c.UpdateTeamMemberSchemeRoles(context.TODO(), team.Id, user.Id, &model.SchemeRoles{SchemeUser: true, SchemeAdmin: true})
+					return err
+				}
+				return nil
+			})
+		case member.SchemeAdmin != admin:
+			schemeRoles := &model.SchemeRoles{SchemeUser: member.SchemeUser, SchemeGuest: member.SchemeGuest, SchemeAdmin: admin}
+			p.add(applyActionUpdate, applyKindTeamMember, name, []string{fmt.Sprintf("admin: %t -> %t", member.SchemeAdmin, admin)}, func() error {
+				_, err := p.c.UpdateTeamMemberSchemeRoles(context.TODO(), team.Id, user.Id, schemeRoles)
+				return err
+			})
+		}
+	}
+
+	if !p.prune {
+		return nil
+	}
+
+	// the server adds the creator of a team as its member, so the user
+	// applying the state is never pruned, or a second run wouldn't be a
+	// no-op
+	wanted[p.applyingUserID()] = true
+
+	var toRemove []string
+	for userID := range current {
+		if !wanted[userID] {
+			toRemove = append(toRemove, userID)
+		}
+	}
+	if err := p.loadUsernames(toRemove); err != nil {
+		return err
+	}
+	sort.Slice(toRemove, func(i, j int) bool { return p.username(toRemove[i]) < p.username(toRemove[j]) })
+
+	for _, userID := range toRemove {
+		p.add(applyActionRemove, applyKindTeamMember, desired.Name+":"+p.username(userID), nil, func() error {
+			_, err := p.c.RemoveTeamMember(context.TODO(), team.Id, userID)
+			return err
+		})
+	}
+
+	return nil
+}
+
+func (p *applyPlanner) planChannel(team *model.Team, teamExists bool, desired *applyChannel) (*model.Channel, error) {
+	name := team.Name + ":" + desired.Name
+
+	var existing *model.Channel
+	if teamExists {
+		var resp *model.Response
+		var err error
+		existing, resp, err = p.c.GetChannelByNameIncludeDeleted(context.TODO(), desired.Name, team.Id, "")
+		if err != nil && !isNotFound(resp, err) {
+			return nil, errors.Wrapf(err, "could not get channel %q", name)
+		}
+	}
+
+	channel := &model.Channel{}
+	if existing == nil {
+		p.add(applyActionCreate, applyKindChannel, name, nil, func() error {
+			// default channels are created along with their team, so
+			// they might exist by now even if they didn't exist when
+			// the plan was computed
+			if found, _, err := p.c.GetChannelByName(context.TODO(), desired.Name, team.Id, ""); err == nil {
+				*channel = *found
+				return p.updateChannel(channel, desired)
+			}
+
+			newChannel := &model.Channel{
+				TeamId:      team.Id,
+				Name:        desired.Name,
+				DisplayName: desired.DisplayName,
+				Type:        model.ChannelType(desired.Type),
+				Purpose:     desired.Purpose,
+				Header:      desired.Header,
+			}
+			if newChannel.DisplayName == "" {
+				newChannel.DisplayName = desired.Name
+			}
+			if newChannel.Type == "" {
+				newChannel.Type = model.ChannelTypeOpen
+			}
+
+			created, _, err := p.c.CreateChannel(context.TODO(), newChannel)
+			if err != nil {
+				return err
+			}
+			*channel = *created
+			return nil
+		})
+	} else {
+		*channel = *existing
+		if existing.DeleteAt != 0 {
+			p.add(applyActionRestore, applyKindChannel, name, nil, func() error {
+				_, _, err := p.c.RestoreChannel(context.TODO(), channel.Id)
+				return err
+			})
+		}
+
+		if changes := diffApplyChannel(existing, desired); len(changes) > 0 {
+			p.add(applyActionUpdate, applyKindChannel, name, changes, func() error {
+				return p.updateChannel(channel, desired)
+			})
+		}
+	}
+
+	if err := p.planChannelMembers(channel, existing != nil, name, desired); err != nil {
+		return nil, err
+	}
+
+	return channel, nil
+}
+
+func diffApplyChannel(channel *model.Channel, desired *applyChannel) []string {
+	var changes []string
+	if desired.DisplayName != "" && desired.DisplayName != channel.DisplayName {
+		changes = append(changes, fmt.Sprintf("display_name: %q -> %q", channel.DisplayName, desired.DisplayName))
+	}
+	if desired.Purpose != "" && desired.Purpose != channel.Purpose {
+		changes = append(changes, fmt.Sprintf("purpose: %q -> %q", channel.Purpose, desired.Purpose))
+	}
+	if desired.Header != "" && desired.Header != channel.Header {
+		changes = append(changes, fmt.Sprintf("header: %q -> %q", channel.Header, desired.Header))
+	}
+	if desired.Type != "" && desired.Type != string(channel.Type) {
+		changes = append(changes, fmt.Sprintf("type: %q -> %q", channel.Type, desired.Type))
+	}
+	return changes
+}
+
+// updateChannel brings an existing channel to the desired state,
+// leaving the attributes that the state doesn't declare untouched
+func (p *applyPlanner) updateChannel(channel *model.Channel, desired *applyChannel) error {
+	patch := &model.ChannelPatch{}
+	patched := false
+	if desired.DisplayName != "" && desired.DisplayName != channel.DisplayName {
+		patch.DisplayName = model.NewPointer(desired.DisplayName)
+		patched = true
+	}
+	if desired.Purpose != "" && desired.Purpose != channel.Purpose {
+		patch.Purpose = model.NewPointer(desired.Purpose)
+		patched = true
+	}
+	if desired.Header != "" && desired.Header != channel.Header {
+		patch.Header = model.NewPointer(desired.Header)
+		patched = true
+	}
+
+	if patched {
+		if _, _, err := p.c.PatchChannel(context.TODO(), channel.Id, patch); err != nil {
+			return err
+		}
+	}
+
+	if desired.Type != "" && desired.Type != string(channel.Type) {
+		if _, _, err := p.c.UpdateChannelPrivacy(context.TODO(), channel.Id, model.ChannelType(desired.Type)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *applyPlanner) planChannelMembers(channel *model.Channel, exists bool, name string, desired *applyChannel) error {
+	if desired.Members == nil {
+		return nil
+	}
+
+	current := map[string]model.ChannelMember{}
+	if exists {
+		for page := 0; ; page++ {
+			members, _, err := p.c.GetChannelMembers(context.TODO(), channel.Id, page, applyPerPage, "")
+			if err != nil {
+				return errors.Wrapf(err, "could not get members of channel %q", name)
+			}
+			for _, member := range members {
+				current[member.UserId] = member
+			}
+			if len(members) < applyPerPage {
+				break
+			}
+		}
+	}
+
+	wanted := map[string]bool{}
+	for _, desiredMember := range desired.Members {
+		user, err := p.user(desiredMember.User)
+		if err != nil {
+			return errors.Wrapf(err, "channel %q", name)
+		}
+		wanted[user.Id] = true
+
+		memberName := name + ":" + user.Username
+		admin := desiredMember.Admin
+		member, ok := current[user.Id]
+		switch {
+		case !ok:
+			var changes []string
+			if admin {
+				changes = []string{"admin: true"}
+			}
+			p.add(applyActionAdd, applyKindChannelMember, memberName, changes, func() error {
+				if _, _, err := p.c.AddChannelMember(context.TODO(), channel.Id, user.Id); err != nil {
+					return err
+				}
+				if admin {
+					_, err := p.c.UpdateChannelMemberSchemeRoles(context.TODO(), channel.Id, user.Id, &model.SchemeRoles{SchemeUser: true, SchemeAdmin: true})
+					return err
+				}
+				return nil
+			})
+		case member.SchemeAdmin != admin:
+			schemeRoles := &model.SchemeRoles{SchemeUser: member.SchemeUser, SchemeGuest: member.SchemeGuest, SchemeAdmin: admin}
+			p.add(applyActionUpdate, applyKindChannelMember, memberName, []string{fmt.Sprintf("admin: %t -> %t", member.SchemeAdmin, admin)}, func() error {
+				_, err := p.c.UpdateChannelMemberSchemeRoles(context.TODO(), channel.Id, user.Id, schemeRoles)
+				return err
+			})
+		}
+	}
+
+	// members can't leave the default channel of a team
+	if !p.prune || channel.Name == model.DefaultChannelName {
+		return nil
+	}
+
+	// same as for teams, the creator of a channel is added by the server
+	wanted[p.applyingUserID()] = true
+	wanted[channel.CreatorId] = true
+
+	var toRemove []string
+	for userID := range current {
+		if !wanted[userID] {
+			toRemove = append(toRemove, userID)
+		}
+	}
+	if err := p.loadUsernames(toRemove); err != nil {
+		return err
+	}
+	sort.Slice(toRemove, func(i, j int) bool { return p.username(toRemove[i]) < p.username(toRemove[j]) })
+
+	for _, userID := range toRemove {
+		p.add(applyActionRemove, applyKindChannelMember, name+":"+p.username(userID), nil, func() error {
+			_, err := p.c.RemoveUserFromChannel(context.TODO(), channel.Id, userID)
+			return err
+		})
+	}
+
+	return nil
+}
+
+func (p *applyPlanner) planChannelsPrune(team *model.Team, wanted map[string]*model.Channel) error {
+	var current []*model.Channel
+	for page := 0; ; page++ {
+		channels, _, err := p.c.GetPublicChannelsForTeam(context.TODO(), team.Id, page, applyPerPage, "")
+		if err != nil {
+			return errors.Wrapf(err, "could not get channels of team %q", team.Name)
+		}
+		current = append(current, channels...)
+		if len(channels) < applyPerPage {
+			break
+		}
+	}
+	for page := 0; ; page++ {
+		channels, _, err := p.c.GetPrivateChannelsForTeam(context.TODO(), team.Id, page, applyPerPage, "")
+		if err != nil {
+			return errors.Wrapf(err, "could not get private channels of team %q", team.Name)
+		}
+		current = append(current, channels...)
+		if len(channels) < applyPerPage {
+			break
+		}
+	}
+
+	sort.Slice(current, func(i, j int) bool { return current[i].Name < current[j].Name })
+	for _, channel := range current {
+		if _, ok := wanted[channel.Name]; ok || channel.Name == model.DefaultChannelName || channel.DeleteAt != 0 {
+			continue
+		}
+
+		channelID := channel.Id
+		p.add(applyActionArchive, applyKindChannel, team.Name+":"+channel.Name, nil, func() error {
+			_, err := p.c.DeleteChannel(context.TODO(), channelID)
+			return err
+		})
+	}
+
+	return nil
+}
+
+// applyChannelRef resolves a channel referenced by a webhook, which is
+// either declared in the state file or must already exist
+func (p *applyPlanner) applyChannelRef(team *model.Team, teamExists bool, channels map[string]*model.Channel, channelName string) (*model.Channel, error) {
+	if channel, ok := channels[channelName]; ok {
+		return channel, nil
+	}
+
+	if teamExists {
+		if channel, _, err := p.c.GetChannelByName(context.TODO(), channelName, team.Id, ""); err == nil {
+			channels[channelName] = channel
+			return channel, nil
+		}
+	}
+
+	return nil, errors.Errorf("unable to find channel %q in team %q", channelName, team.Name)
+}
+
+func (p *applyPlanner) ownerID(owner string) (string, error) {
+	if owner == "" {
+		return "", nil
+	}
+	user, err := p.user(owner)
+	if err != nil {
+		return "", err
+	}
+	return user.Id, nil
+}
+
+func (p *applyPlanner) planIncomingWebhooks(team *model.Team, teamExists bool, desired *applyTeam, channels map[string]*model.Channel) error {
+	if desired.IncomingWebhooks == nil {
+		return nil
+	}
+
+	current := map[string]*model.IncomingWebhook{}
+	if teamExists {
+		for page := 0; ; page++ {
+			hooks, _, err := p.c.GetIncomingWebhooksForTeam(context.TODO(), team.Id, page, applyPerPage, "")
+			if err != nil {
+				return errors.Wrapf(err, "could not get incoming webhooks of team %q", team.Name)
+			}
+			for _, hook := range hooks {
+				current[hook.DisplayName] = hook
+			}
+			if len(hooks) < applyPerPage {
+				break
+			}
+		}
+	}
+
+	for _, desiredHook := range desired.IncomingWebhooks {
+		name := desired.Name + ":" + desiredHook.DisplayName
+		channel, err := p.applyChannelRef(team, teamExists, channels, desiredHook.Channel)
+		if err != nil {
+			return errors.Wrapf(err, "incoming webhook %q", name)
+		}
+		ownerID, err := p.ownerID(desiredHook.Owner)
+		if err != nil {
+			return errors.Wrapf(err, "incoming webhook %q", name)
+		}
+
+		hook := &model.IncomingWebhook{
+			DisplayName:   desiredHook.DisplayName,
+			Description:   desiredHook.Description,
+			Username:      desiredHook.Username,
+			IconURL:       desiredHook.IconURL,
+			ChannelLocked: desiredHook.ChannelLocked,
+			UserId:        ownerID,
+		}
+
+		existing, ok := current[desiredHook.DisplayName]
+		if !ok {
+			p.add(applyActionCreate, applyKindIncomingWebhook, name, nil, func() error {
+				hook.ChannelId = channel.Id
+				_, _, err := p.c.CreateIncomingWebhook(context.TODO(), hook)
+				return err
+			})
+			continue
+		}
+		delete(current, desiredHook.DisplayName)
+
+		var changes []string
+		if channel.Id != existing.ChannelId {
+			changes = append(changes, fmt.Sprintf("channel: %q", desiredHook.Channel))
+		}
+		changes = appendStringChange(changes, "description", existing.Description, hook.Description)
+		changes = appendStringChange(changes, "username", existing.Username, hook.Username)
+		changes = appendStringChange(changes, "icon_url", existing.IconURL, hook.IconURL)
+		if existing.ChannelLocked != hook.ChannelLocked {
+			changes = append(changes, fmt.Sprintf("channel_locked: %t -> %t", existing.ChannelLocked, hook.ChannelLocked))
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		p.add(applyActionUpdate, applyKindIncomingWebhook, name, changes, func() error {
+			updated := *existing
+			updated.ChannelId = channel.Id
+			updated.Description = hook.Description
+			updated.Username = hook.Username
+			updated.IconURL = hook.IconURL
+			updated.ChannelLocked = hook.ChannelLocked
+			_, _, err := p.c.UpdateIncomingWebhook(context.TODO(), &updated)
+			return err
+		})
+	}
+
+	if !p.prune {
+		return nil
+	}
+
+	for _, displayName := range sortedKeys(current) {
+		hookID := current[displayName].Id
+		p.add(applyActionDelete, applyKindIncomingWebhook, desired.Name+":"+displayName, nil, func() error {
+			_, err := p.c.DeleteIncomingWebhook(context.TODO(), hookID)
+			return err
+		})
+	}
+
+	return nil
+}
+
+func (p *applyPlanner) planOutgoingWebhooks(team *model.Team, teamExists bool, desired *applyTeam, channels map[string]*model.Channel) error {
+	if desired.OutgoingWebhooks == nil {
+		return nil
+	}
+
+	current := map[string]*model.OutgoingWebhook{}
+	if teamExists {
+		for page := 0; ; page++ {
+			hooks, _, err := p.c.GetOutgoingWebhooksForTeam(context.TODO(), team.Id, page, applyPerPage, "")
+			if err != nil {
+				return errors.Wrapf(err, "could not get outgoing webhooks of team %q", team.Name)
+			}
+			for _, hook := range hooks {
+				current[hook.DisplayName] = hook
+			}
+			if len(hooks) < applyPerPage {
+				break
+			}
+		}
+	}
+
+	for _, desiredHook := range desired.OutgoingWebhooks {
+		name := desired.Name + ":" + desiredHook.DisplayName
+
+		var channel *model.Channel
+		if desiredHook.Channel != "" {
+			var err error
+			channel, err = p.applyChannelRef(team, teamExists, channels, desiredHook.Channel)
+			if err != nil {
+				return errors.Wrapf(err, "outgoing webhook %q", name)
+			}
+		}
+		ownerID, err := p.ownerID(desiredHook.Owner)
+		if err != nil {
+			return errors.Wrapf(err, "outgoing webhook %q", name)
+		}
+
+		triggerWhen := 0
+		if desiredHook.TriggerWhen == applyTriggerWhenStart {
+			triggerWhen = 1
+		}
+		hook := &model.OutgoingWebhook{
+			DisplayName:  desiredHook.DisplayName,
+			Description:  desiredHook.Description,
+			TriggerWords: desiredHook.TriggerWords,
+			TriggerWhen:  triggerWhen,
+			CallbackURLs: desiredHook.CallbackURLs,
+			ContentType:  desiredHook.ContentType,
+			Username:     desiredHook.Username,
+			IconURL:      desiredHook.IconURL,
+			CreatorId:    ownerID,
+		}
+		channelID := func() string {
+			if channel == nil {
+				return ""
+			}
+			return channel.Id
+		}
+
+		existing, ok := current[desiredHook.DisplayName]
+		if !ok {
+			p.add(applyActionCreate, applyKindOutgoingWebhook, name, nil, func() error {
+				hook.TeamId = team.Id
+				hook.ChannelId = channelID()
+				_, _, err := p.c.CreateOutgoingWebhook(context.TODO(), hook)
+				return err
+			})
+			continue
+		}
+		delete(current, desiredHook.DisplayName)
+
+		var changes []string
+		if channelID() != existing.ChannelId {
+			changes = append(changes, fmt.Sprintf("channel: %q", desiredHook.Channel))
+		}
+		changes = appendStringChange(changes, "description", existing.Description, hook.Description)
+		changes = appendStringChange(changes, "trigger_words", strings.Join(existing.TriggerWords, ","), strings.Join(hook.TriggerWords, ","))
+		changes = appendStringChange(changes, "callback_urls", strings.Join(existing.CallbackURLs, ","), strings.Join(hook.CallbackURLs, ","))
+		changes = appendStringChange(changes, "username", existing.Username, hook.Username)
+		changes = appendStringChange(changes, "icon_url", existing.IconURL, hook.IconURL)
+		if hook.ContentType != "" {
+			changes = appendStringChange(changes, "content_type", existing.ContentType, hook.ContentType)
+		}
+		if existing.TriggerWhen != hook.TriggerWhen {
+			changes = append(changes, fmt.Sprintf("trigger_when: %q", desiredHook.TriggerWhen))
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		p.add(applyActionUpdate, applyKindOutgoingWebhook, name, changes, func() error {
+			updated := *existing
+			updated.ChannelId = channelID()
+			updated.Description = hook.Description
+			updated.TriggerWords = hook.TriggerWords
+			updated.TriggerWhen = hook.TriggerWhen
+			updated.CallbackURLs = hook.CallbackURLs
+			updated.Username = hook.Username
+			updated.IconURL = hook.IconURL
+			if hook.ContentType != "" {
+				updated.ContentType = hook.ContentType
+			}
+			_, _, err := p.c.UpdateOutgoingWebhook(context.TODO(), &updated)
+			return err
+		})
+	}
+
+	if !p.prune {
+		return nil
+	}
+
+	for _, displayName := range sortedKeys(current) {
+		hookID := current[displayName].Id
+		p.add(applyActionDelete, applyKindOutgoingWebhook, desired.Name+":"+displayName, nil, func() error {
+			_, err := p.c.DeleteOutgoingWebhook(context.TODO(), hookID)
+			return err
+		})
+	}
+
+	return nil
+}
+
+func (p *applyPlanner) planCommands(team *model.Team, teamExists bool, desired *applyTeam) error {
+	if desired.Commands == nil {
+		return nil
+	}
+
+	current := map[string]*model.Command{}
+	if teamExists {
+		commands, _, err := p.c.ListCommands(context.TODO(), team.Id, true)
+		if err != nil {
+			return errors.Wrapf(err, "could not get commands of team %q", team.Name)
+		}
+		for _, command := range commands {
+			if command.TeamId == team.Id && command.PluginId == "" {
+				current[command.Trigger] = command
+			}
+		}
+	}
+
+	for _, desiredCommand := range desired.Commands {
+		name := desired.Name + ":" + desiredCommand.Trigger
+		ownerID, err := p.ownerID(desiredCommand.Owner)
+		if err != nil {
+			return errors.Wrapf(err, "command %q", name)
+		}
+
+		command := &model.Command{
+			Trigger:          desiredCommand.Trigger,
+			URL:              desiredCommand.URL,
+			Method:           desiredCommand.Method,
+			DisplayName:      desiredCommand.DisplayName,
+			Description:      desiredCommand.Description,
+			Username:         desiredCommand.Username,
+			IconURL:          desiredCommand.IconURL,
+			AutoComplete:     desiredCommand.Autocomplete,
+			AutoCompleteDesc: desiredCommand.AutocompleteDesc,
+			AutoCompleteHint: desiredCommand.AutocompleteHint,
+			CreatorId:        ownerID,
+		}
+
+		existing, ok := current[desiredCommand.Trigger]
+		if !ok {
+			p.add(applyActionCreate, applyKindCommand, name, nil, func() error {
+				command.TeamId = team.Id
+				_, _, err := p.c.CreateCommand(context.TODO(), command)
+				return err
+			})
+			continue
+		}
+		delete(current, desiredCommand.Trigger)
+
+		var changes []string
+		changes = appendStringChange(changes, "url", existing.URL, command.URL)
+		changes = appendStringChange(changes, "method", existing.Method, command.Method)
+		changes = appendStringChange(changes, "display_name", existing.DisplayName, command.DisplayName)
+		changes = appendStringChange(changes, "description", existing.Description, command.Description)
+		changes = appendStringChange(changes, "username", existing.Username, command.Username)
+		changes = appendStringChange(changes, "icon_url", existing.IconURL, command.IconURL)
+		changes = appendStringChange(changes, "autocomplete_desc", existing.AutoCompleteDesc, command.AutoCompleteDesc)
+		changes = appendStringChange(changes, "autocomplete_hint", existing.AutoCompleteHint, command.AutoCompleteHint)
+		if existing.AutoComplete != command.AutoComplete {
+			changes = append(changes, fmt.Sprintf("autocomplete: %t -> %t", existing.AutoComplete, command.AutoComplete))
+		}
+		if len(changes) == 0 {
+			continue
+		}
+
+		p.add(applyActionUpdate, applyKindCommand, name, changes, func() error {
+			updated := *existing
+			updated.URL = command.URL
+			updated.Method = command.Method
+			updated.DisplayName = command.DisplayName
+			updated.Description = command.Description
+			updated.Username = command.Username
+			updated.IconURL = command.IconURL
+			updated.AutoComplete = command.AutoComplete
+			updated.AutoCompleteDesc = command.AutoCompleteDesc
+			updated.AutoCompleteHint = command.AutoCompleteHint
+			_, _, err := p.c.UpdateCommand(context.TODO(), &updated)
+			return err
+		})
+	}
+
+	if !p.prune {
+		return nil
+	}
+
+	for _, trigger := range sortedKeys(current) {
+		commandID := current[trigger].Id
+		p.add(applyActionDelete, applyKindCommand, desired.Name+":"+trigger, nil, func() error {
+			_, err := p.c.DeleteCommand(context.TODO(), commandID)
+			return err
+		})
+	}
+
+	return nil
+}
+
+func (p *applyPlanner) planUserRoles(desired *applyUser) error {
+	user, err := p.user(desired.User)
+	if err != nil {
+		return err
+	}
+
+	wanted := strings.Fields(desired.Roles)
+	current := strings.Fields(user.Roles)
+	slices.Sort(wanted)
+	slices.Sort(current)
+	if slices.Equal(wanted, current) {
+		return nil
+	}
+
+	roles := strings.Join(wanted, " ")
+	p.add(applyActionUpdate, applyKindUserRoles, user.Username, []string{fmt.Sprintf("roles: %q -> %q", user.Roles, roles)}, func() error {
+		_, err := p.c.UpdateUserRoles(context.TODO(), user.Id, roles)
+		return err
+	})
+
+	return nil
+}
+
+func appendStringChange(changes []string, field, current, desired string) []string {
+	if current == desired {
+		return changes
+	}
+	return append(changes, fmt.Sprintf("%s: %q -> %q", field, current, desired))
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
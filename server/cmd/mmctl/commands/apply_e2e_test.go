@@ -0,0 +1,122 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestApplyCmd() {
+	s.SetupTestHelper().InitBasic()
+	s.th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableIncomingWebhooks = true
+	})
+
+	teamName := "apply-" + model.NewId()[:8]
+	writeState := func(content string) string {
+		path := filepath.Join(s.T().TempDir(), "state.yaml")
+		s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+		return path
+	}
+
+	state := fmt.Sprintf(`
+teams:
+  - name: %[1]s
+    display_name: Apply
+    members:
+      - %[2]s
+      - user: %[3]s
+        admin: true
+    channels:
+      - name: deployments
+        display_name: Deployments
+        members: [%[2]s]
+      - name: releases
+        type: P
+    incoming_webhooks:
+      - display_name: CI
+        channel: deployments
+`, teamName, s.th.BasicUser.Username, s.th.BasicUser2.Username)
+
+	s.Run("Apply a new team", func() {
+		printer.Clean()
+
+		err := applyCmdF(s.th.SystemAdminClient, newApplyCmd(writeState(state), false, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+
+		team, appErr := s.th.App.GetTeamByName(teamName)
+		s.Require().Nil(appErr)
+		s.Require().Equal("Apply", team.DisplayName)
+
+		member, appErr := s.th.App.GetTeamMember(s.th.Context, team.Id, s.th.BasicUser2.Id)
+		s.Require().Nil(appErr)
+		s.Require().True(member.SchemeAdmin)
+
+		channel, appErr := s.th.App.GetChannelByName(s.th.Context, "deployments", team.Id, false)
+		s.Require().Nil(appErr)
+		_, appErr = s.th.App.GetChannelMember(s.th.Context, channel.Id, s.th.BasicUser.Id)
+		s.Require().Nil(appErr)
+
+		private, appErr := s.th.App.GetChannelByName(s.th.Context, "releases", team.Id, false)
+		s.Require().Nil(appErr)
+		s.Require().Equal(model.ChannelTypePrivate, private.Type)
+
+		hooks, appErr := s.th.App.GetIncomingWebhooksForTeamPage(team.Id, 0, 10)
+		s.Require().Nil(appErr)
+		s.Require().Len(hooks, 1)
+		s.Require().Equal(channel.Id, hooks[0].ChannelId)
+	})
+
+	s.Run("Applying the same state again produces no changes", func() {
+		printer.Clean()
+
+		err := applyCmdF(s.th.SystemAdminClient, newApplyCmd(writeState(state), false, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{"No changes. The server matches the state file."}, printer.GetLines())
+	})
+
+	s.Run("Prune the channels and members that are not in the state", func() {
+		printer.Clean()
+
+		pruned := fmt.Sprintf(`
+teams:
+  - name: %[1]s
+    members: [%[2]s]
+    channels:
+      - name: deployments
+`, teamName, s.th.BasicUser.Username)
+
+		err := applyCmdF(s.th.SystemAdminClient, newApplyCmd(writeState(pruned), false, true), []string{})
+		s.Require().NoError(err)
+
+		team, appErr := s.th.App.GetTeamByName(teamName)
+		s.Require().Nil(appErr)
+
+		_, _, err = s.th.SystemAdminClient.GetTeamMember(context.TODO(), team.Id, s.th.BasicUser2.Id, "")
+		s.Require().Error(err)
+
+		releases, appErr := s.th.App.GetChannelByName(s.th.Context, "releases", team.Id, true)
+		s.Require().Nil(appErr)
+		s.Require().NotZero(releases.DeleteAt)
+	})
+
+	s.Run("Dry run doesn't change the server", func() {
+		printer.Clean()
+
+		otherTeam := "apply-" + model.NewId()[:8]
+		err := applyCmdF(s.th.SystemAdminClient, newApplyCmd(writeState("teams:\n  - name: "+otherTeam+"\n"), true, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		_, appErr := s.th.App.GetTeamByName(otherTeam)
+		s.Require().NotNil(appErr)
+	})
+}
@@ -0,0 +1,253 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	applyTriggerWhenExact = "exact"
+	applyTriggerWhenStart = "start"
+)
+
+// applyState is the desired state of the server as described by the
+// file passed to the apply command. Collections that are omitted from
+// the file are not managed, so they are neither compared nor pruned.
+type applyState struct {
+	Teams []*applyTeam `yaml:"teams"`
+	Users []*applyUser `yaml:"users"`
+}
+
+type applyUser struct {
+	User  string `yaml:"user"`
+	Roles string `yaml:"roles"`
+}
+
+type applyTeam struct {
+	Name             string                  `yaml:"name"`
+	DisplayName      string                  `yaml:"display_name"`
+	Type             string                  `yaml:"type"`
+	Description      string                  `yaml:"description"`
+	AllowOpenInvite  *bool                   `yaml:"allow_open_invite"`
+	Members          []*applyMember          `yaml:"members"`
+	Channels         []*applyChannel         `yaml:"channels"`
+	IncomingWebhooks []*applyIncomingWebhook `yaml:"incoming_webhooks"`
+	OutgoingWebhooks []*applyOutgoingWebhook `yaml:"outgoing_webhooks"`
+	Commands         []*applyCommand         `yaml:"commands"`
+}
+
+type applyChannel struct {
+	Name        string         `yaml:"name"`
+	DisplayName string         `yaml:"display_name"`
+	Type        string         `yaml:"type"`
+	Purpose     string         `yaml:"purpose"`
+	Header      string         `yaml:"header"`
+	Members     []*applyMember `yaml:"members"`
+}
+
+// applyMember is a team or channel membership. It can be written
+// either as a plain user reference or as a mapping with the admin
+// flag set.
+type applyMember struct {
+	User  string `yaml:"user"`
+	Admin bool   `yaml:"admin"`
+}
+
+func (m *applyMember) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		m.User = value.Value
+		return nil
+	}
+
+	type plain applyMember
+	return value.Decode((*plain)(m))
+}
+
+type applyIncomingWebhook struct {
+	DisplayName   string `yaml:"display_name"`
+	Channel       string `yaml:"channel"`
+	Description   string `yaml:"description"`
+	Owner         string `yaml:"owner"`
+	Username      string `yaml:"username"`
+	IconURL       string `yaml:"icon_url"`
+	ChannelLocked bool   `yaml:"channel_locked"`
+}
+
+type applyOutgoingWebhook struct {
+	DisplayName  string   `yaml:"display_name"`
+	Channel      string   `yaml:"channel"`
+	Description  string   `yaml:"description"`
+	Owner        string   `yaml:"owner"`
+	TriggerWords []string `yaml:"trigger_words"`
+	TriggerWhen  string   `yaml:"trigger_when"`
+	CallbackURLs []string `yaml:"callback_urls"`
+	ContentType  string   `yaml:"content_type"`
+	Username     string   `yaml:"username"`
+	IconURL      string   `yaml:"icon_url"`
+}
+
+type applyCommand struct {
+	Trigger          string `yaml:"trigger"`
+	URL              string `yaml:"url"`
+	Method           string `yaml:"method"`
+	DisplayName      string `yaml:"display_name"`
+	Description      string `yaml:"description"`
+	Owner            string `yaml:"owner"`
+	Username         string `yaml:"username"`
+	IconURL          string `yaml:"icon_url"`
+	Autocomplete     bool   `yaml:"autocomplete"`
+	AutocompleteDesc string `yaml:"autocomplete_desc"`
+	AutocompleteHint string `yaml:"autocomplete_hint"`
+}
+
+func loadApplyState(path string) (*applyState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read state file %q", path)
+	}
+
+	var state applyState
+	if err := yaml.Unmarshal(data, &state); err != nil {
+		return nil, errors.Wrapf(err, "could not parse state file %q", path)
+	}
+
+	if err := state.validate(); err != nil {
+		return nil, errors.Wrapf(err, "invalid state file %q", path)
+	}
+
+	return &state, nil
+}
+
+// validate checks the state for missing identifiers, invalid values
+// and duplicates, and normalizes the values that accept aliases.
+func (s *applyState) validate() error {
+	var result *multierror.Error
+
+	teamNames := map[string]bool{}
+	for i, team := range s.Teams {
+		if team == nil || team.Name == "" {
+			result = multierror.Append(result, fmt.Errorf("teams[%d]: name is required", i))
+			continue
+		}
+		if teamNames[team.Name] {
+			result = multierror.Append(result, fmt.Errorf("team %q is declared more than once", team.Name))
+		}
+		teamNames[team.Name] = true
+
+		if team.Type != "" && team.Type != model.TeamOpen && team.Type != model.TeamInvite {
+			result = multierror.Append(result, fmt.Errorf("team %q: type must be %q or %q", team.Name, model.TeamOpen, model.TeamInvite))
+		}
+
+		result = multierror.Append(result, validateApplyMembers("team "+team.Name, team.Members)...)
+
+		channelNames := map[string]bool{}
+		for j, channel := range team.Channels {
+			if channel == nil || channel.Name == "" {
+				result = multierror.Append(result, fmt.Errorf("team %q: channels[%d]: name is required", team.Name, j))
+				continue
+			}
+			if channelNames[channel.Name] {
+				result = multierror.Append(result, fmt.Errorf("team %q: channel %q is declared more than once", team.Name, channel.Name))
+			}
+			channelNames[channel.Name] = true
+
+			if channel.Type != "" && channel.Type != string(model.ChannelTypeOpen) && channel.Type != string(model.ChannelTypePrivate) {
+				result = multierror.Append(result, fmt.Errorf("team %q: channel %q: type must be %q or %q", team.Name, channel.Name, model.ChannelTypeOpen, model.ChannelTypePrivate))
+			}
+
+			result = multierror.Append(result, validateApplyMembers("channel "+team.Name+":"+channel.Name, channel.Members)...)
+		}
+
+		hookNames := map[string]bool{}
+		for j, hook := range team.IncomingWebhooks {
+			if hook == nil || hook.DisplayName == "" || hook.Channel == "" {
+				result = multierror.Append(result, fmt.Errorf("team %q: incoming_webhooks[%d]: display_name and channel are required", team.Name, j))
+				continue
+			}
+			if hookNames[hook.DisplayName] {
+				result = multierror.Append(result, fmt.Errorf("team %q: incoming webhook %q is declared more than once", team.Name, hook.DisplayName))
+			}
+			hookNames[hook.DisplayName] = true
+		}
+
+		hookNames = map[string]bool{}
+		for j, hook := range team.OutgoingWebhooks {
+			if hook == nil || hook.DisplayName == "" || len(hook.CallbackURLs) == 0 {
+				result = multierror.Append(result, fmt.Errorf("team %q: outgoing_webhooks[%d]: display_name and callback_urls are required", team.Name, j))
+				continue
+			}
+			if hookNames[hook.DisplayName] {
+				result = multierror.Append(result, fmt.Errorf("team %q: outgoing webhook %q is declared more than once", team.Name, hook.DisplayName))
+			}
+			hookNames[hook.DisplayName] = true
+
+			if hook.TriggerWhen == "" {
+				hook.TriggerWhen = applyTriggerWhenExact
+			}
+			if hook.TriggerWhen != applyTriggerWhenExact && hook.TriggerWhen != applyTriggerWhenStart {
+				result = multierror.Append(result, fmt.Errorf("team %q: outgoing webhook %q: trigger_when must be %q or %q", team.Name, hook.DisplayName, applyTriggerWhenExact, applyTriggerWhenStart))
+			}
+		}
+
+		triggers := map[string]bool{}
+		for j, command := range team.Commands {
+			if command == nil || command.Trigger == "" || command.URL == "" {
+				result = multierror.Append(result, fmt.Errorf("team %q: commands[%d]: trigger and url are required", team.Name, j))
+				continue
+			}
+			if triggers[command.Trigger] {
+				result = multierror.Append(result, fmt.Errorf("team %q: command %q is declared more than once", team.Name, command.Trigger))
+			}
+			triggers[command.Trigger] = true
+
+			switch strings.ToUpper(command.Method) {
+			case "", "P", "POST":
+				command.Method = model.CommandMethodPost
+			case "G", "GET":
+				command.Method = model.CommandMethodGet
+			default:
+				result = multierror.Append(result, fmt.Errorf("team %q: command %q: method must be POST or GET", team.Name, command.Trigger))
+			}
+		}
+	}
+
+	users := map[string]bool{}
+	for i, user := range s.Users {
+		if user == nil || user.User == "" || user.Roles == "" {
+			result = multierror.Append(result, fmt.Errorf("users[%d]: user and roles are required", i))
+			continue
+		}
+		if users[user.User] {
+			result = multierror.Append(result, fmt.Errorf("user %q is declared more than once", user.User))
+		}
+		users[user.User] = true
+	}
+
+	return result.ErrorOrNil()
+}
+
+func validateApplyMembers(parent string, members []*applyMember) []error {
+	var errs []error
+	seen := map[string]bool{}
+	for i, member := range members {
+		if member == nil || member.User == "" {
+			errs = append(errs, fmt.Errorf("%s: members[%d]: user is required", parent, i))
+			continue
+		}
+		if seen[member.User] {
+			errs = append(errs, fmt.Errorf("%s: member %q is declared more than once", parent, member.User))
+		}
+		seen[member.User] = true
+	}
+	return errs
+}
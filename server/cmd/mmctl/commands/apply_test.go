@@ -0,0 +1,263 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) writeApplyState(content string) string {
+	path := filepath.Join(s.T().TempDir(), "state.yaml")
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func newApplyCmd(path string, dryRun, prune bool) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("file", path, "")
+	cmd.Flags().Bool("dry-run", dryRun, "")
+	cmd.Flags().Bool("prune", prune, "")
+	return cmd
+}
+
+func (s *MmctlUnitTestSuite) TestLoadApplyState() {
+	s.Run("Should parse members written as scalars or mappings", func() {
+		path := s.writeApplyState(`
+teams:
+  - name: eng
+    members:
+      - alice
+      - user: bob
+        admin: true
+    outgoing_webhooks:
+      - display_name: hook
+        callback_urls: [https://example.com]
+    commands:
+      - trigger: deploy
+        url: https://example.com
+        method: get
+`)
+
+		state, err := loadApplyState(path)
+		s.Require().NoError(err)
+		s.Require().Len(state.Teams, 1)
+		s.Require().Equal([]*applyMember{{User: "alice"}, {User: "bob", Admin: true}}, state.Teams[0].Members)
+		s.Require().Equal(applyTriggerWhenExact, state.Teams[0].OutgoingWebhooks[0].TriggerWhen)
+		s.Require().Equal(model.CommandMethodGet, state.Teams[0].Commands[0].Method)
+		s.Require().Nil(state.Teams[0].Channels)
+	})
+
+	s.Run("Should report every validation error", func() {
+		path := s.writeApplyState(`
+teams:
+  - name: eng
+    type: X
+    channels:
+      - name: dev
+      - name: dev
+    commands:
+      - trigger: deploy
+  - name: eng
+users:
+  - user: alice
+`)
+
+		_, err := loadApplyState(path)
+		s.Require().Error(err)
+		s.Require().Contains(err.Error(), `team "eng" is declared more than once`)
+		s.Require().Contains(err.Error(), `team "eng": type must be "O" or "I"`)
+		s.Require().Contains(err.Error(), `team "eng": channel "dev" is declared more than once`)
+		s.Require().Contains(err.Error(), `team "eng": commands[0]: trigger and url are required`)
+		s.Require().Contains(err.Error(), `users[0]: user and roles are required`)
+	})
+
+	s.Run("Should fail if the file can't be parsed", func() {
+		path := s.writeApplyState("teams: [")
+
+		_, err := loadApplyState(path)
+		s.Require().ErrorContains(err, "could not parse state file")
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestApplyCmd() {
+	alice := &model.User{Id: model.NewId(), Username: "alice", Roles: model.SystemUserRoleId}
+	bob := &model.User{Id: model.NewId(), Username: "bob", Roles: model.SystemUserRoleId}
+	admin := &model.User{Id: model.NewId(), Username: "admin", Roles: model.SystemAdminRoleId}
+
+	s.Run("Should only print the plan for a new team on a dry run", func() {
+		printer.Clean()
+
+		path := s.writeApplyState(`
+teams:
+  - name: eng
+    members: [alice]
+    channels:
+      - name: dev
+        members: [alice]
+    commands:
+      - trigger: deploy
+        url: https://example.com
+`)
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), "eng", "").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+		s.client.EXPECT().GetUserByUsername(context.TODO(), alice.Username, "").Return(alice, &model.Response{}, nil).Times(1)
+
+		err := applyCmdF(s.client, newApplyCmd(path, true, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 5)
+		s.Require().Equal(&applyAction{Action: applyActionCreate, Kind: applyKindTeam, Name: "eng"}, stripApplyFunc(printer.GetLines()[0]))
+		s.Require().Equal(&applyAction{Action: applyActionAdd, Kind: applyKindTeamMember, Name: "eng:alice"}, stripApplyFunc(printer.GetLines()[1]))
+		s.Require().Equal(&applyAction{Action: applyActionCreate, Kind: applyKindChannel, Name: "eng:dev"}, stripApplyFunc(printer.GetLines()[2]))
+		s.Require().Equal(&applyAction{Action: applyActionAdd, Kind: applyKindChannelMember, Name: "eng:dev:alice"}, stripApplyFunc(printer.GetLines()[3]))
+		s.Require().Equal(&applyAction{Action: applyActionCreate, Kind: applyKindCommand, Name: "eng:deploy"}, stripApplyFunc(printer.GetLines()[4]))
+	})
+
+	s.Run("Should update and prune the members of an existing team", func() {
+		printer.Clean()
+
+		team := &model.Team{Id: model.NewId(), Name: "eng", DisplayName: "Engineering", Type: model.TeamOpen}
+		path := s.writeApplyState(`
+teams:
+  - name: eng
+    display_name: Engineering
+    members:
+      - user: alice
+        admin: true
+`)
+
+		s.client.EXPECT().GetTeamByName(context.TODO(), team.Name, "").Return(team, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			GetTeamMembers(context.TODO(), team.Id, 0, applyPerPage, "").
+			Return([]*model.TeamMember{
+				{TeamId: team.Id, UserId: alice.Id, SchemeUser: true},
+				{TeamId: team.Id, UserId: bob.Id, SchemeUser: true},
+			}, &model.Response{}, nil).
+			Times(1)
+		s.client.EXPECT().GetUserByUsername(context.TODO(), alice.Username, "").Return(alice, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetMe(context.TODO(), "").Return(admin, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetUsersByIds(context.TODO(), []string{bob.Id}).Return([]*model.User{bob}, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			UpdateTeamMemberSchemeRoles(context.TODO(), team.Id, alice.Id, &model.SchemeRoles{SchemeUser: true, SchemeAdmin: true}).
+			Return(&model.Response{}, nil).
+			Times(1)
+		s.client.EXPECT().RemoveTeamMember(context.TODO(), team.Id, bob.Id).Return(&model.Response{}, nil).Times(1)
+
+		err := applyCmdF(s.client, newApplyCmd(path, false, true), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 3)
+		s.Require().Equal(&applyAction{Action: applyActionUpdate, Kind: applyKindTeamMember, Name: "eng:alice", Changes: []string{"admin: false -> true"}}, stripApplyFunc(printer.GetLines()[0]))
+		s.Require().Equal(&applyAction{Action: applyActionRemove, Kind: applyKindTeamMember, Name: "eng:bob"}, stripApplyFunc(printer.GetLines()[1]))
+		s.Require().Equal("Applied 2 changes.", printer.GetLines()[2])
+	})
+
+	s.Run("Should not prune the members added by the server", func() {
+		printer.Clean()
+
+		team := &model.Team{Id: model.NewId(), Name: "eng", DisplayName: "Engineering", Type: model.TeamOpen}
+		channel := &model.Channel{Id: model.NewId(), TeamId: team.Id, Name: "dev", DisplayName: "dev", Type: model.ChannelTypeOpen, CreatorId: bob.Id}
+		path := s.writeApplyState(`
+teams:
+  - name: eng
+    display_name: Engineering
+    members: [alice]
+    channels:
+      - name: dev
+        display_name: dev
+        members: [alice]
+`)
+
+		s.client.EXPECT().GetTeamByName(context.TODO(), team.Name, "").Return(team, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			GetTeamMembers(context.TODO(), team.Id, 0, applyPerPage, "").
+			Return([]*model.TeamMember{
+				{TeamId: team.Id, UserId: alice.Id, SchemeUser: true},
+				{TeamId: team.Id, UserId: admin.Id, SchemeUser: true},
+			}, &model.Response{}, nil).
+			Times(1)
+		s.client.EXPECT().GetUserByUsername(context.TODO(), alice.Username, "").Return(alice, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetMe(context.TODO(), "").Return(admin, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetChannelByNameIncludeDeleted(context.TODO(), channel.Name, team.Id, "").Return(channel, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			GetChannelMembers(context.TODO(), channel.Id, 0, applyPerPage, "").
+			Return(model.ChannelMembers{
+				{ChannelId: channel.Id, UserId: alice.Id, SchemeUser: true},
+				{ChannelId: channel.Id, UserId: admin.Id, SchemeUser: true},
+				{ChannelId: channel.Id, UserId: bob.Id, SchemeUser: true},
+			}, &model.Response{}, nil).
+			Times(1)
+		s.client.EXPECT().GetPublicChannelsForTeam(context.TODO(), team.Id, 0, applyPerPage, "").Return([]*model.Channel{channel}, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetPrivateChannelsForTeam(context.TODO(), team.Id, 0, applyPerPage, "").Return([]*model.Channel{}, &model.Response{}, nil).Times(1)
+
+		err := applyCmdF(s.client, newApplyCmd(path, false, true), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No changes. The server matches the state file.", printer.GetLines()[0])
+	})
+
+	s.Run("Should stop at the first change that fails", func() {
+		printer.Clean()
+
+		path := s.writeApplyState(`
+users:
+  - user: alice
+    roles: system_user system_admin
+  - user: bob
+    roles: system_user system_user_manager
+`)
+
+		s.client.EXPECT().GetUserByUsername(context.TODO(), alice.Username, "").Return(alice, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetUserByUsername(context.TODO(), bob.Username, "").Return(bob, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			UpdateUserRoles(context.TODO(), alice.Id, "system_admin system_user").
+			Return(&model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := applyCmdF(s.client, newApplyCmd(path, false, false), []string{})
+		s.Require().EqualError(err, `could not update user roles "alice", 0 of 2 changes applied: mock error`)
+		s.Require().Len(printer.GetLines(), 2)
+	})
+
+	s.Run("Should report that there are no changes", func() {
+		printer.Clean()
+
+		path := s.writeApplyState(`
+users:
+  - user: alice
+    roles: system_user
+`)
+
+		s.client.EXPECT().GetUserByUsername(context.TODO(), alice.Username, "").Return(alice, &model.Response{}, nil).Times(1)
+
+		err := applyCmdF(s.client, newApplyCmd(path, false, false), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("No changes. The server matches the state file.", printer.GetLines()[0])
+	})
+}
+
+// stripApplyFunc removes the apply function from a printed action so
+// it can be compared
+func stripApplyFunc(line any) *applyAction {
+	action := *(line.(*applyAction))
+	action.apply = nil
+	return &action
+}
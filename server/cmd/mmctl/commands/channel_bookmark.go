@@ -0,0 +1,225 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const channelBookmarkTemplate = `{{.Id}}: {{.DisplayName}} ({{.Type}}{{if .LinkUrl}}, {{.LinkUrl}}{{end}})`
+
+var ChannelBookmarkCmd = &cobra.Command{
+	Use:   "bookmark",
+	Short: "Management of channel bookmarks",
+}
+
+var ChannelBookmarkListCmd = &cobra.Command{
+	Use:     "list [channel]",
+	Short:   "List channel bookmarks",
+	Long:    "List the bookmarks of a channel, in their display order.",
+	Example: "  channel bookmark list myteam:mychannel",
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(channelBookmarkListCmdF),
+}
+
+var ChannelBookmarkAddCmd = &cobra.Command{
+	Use:     "add [channel]",
+	Short:   "Add a link bookmark to a channel",
+	Long:    "Add a link bookmark to a channel. The bookmark is appended after the existing ones.",
+	Example: `  channel bookmark add myteam:mychannel --display-name "Runbook" --url https://example.com/runbook --emoji :book:`,
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(channelBookmarkAddCmdF),
+}
+
+var ChannelBookmarkUpdateCmd = &cobra.Command{
+	Use:     "update [channel] [bookmark-id]",
+	Short:   "Update a channel bookmark",
+	Long:    "Update the display name, link, image or emoji of a channel bookmark.",
+	Example: `  channel bookmark update myteam:mychannel 7ptbcmy8xjb3xdrc1ta4p4j3qr --display-name "New runbook"`,
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(2),
+	RunE:    withClient(channelBookmarkUpdateCmdF),
+}
+
+var ChannelBookmarkDeleteCmd = &cobra.Command{
+	Use:     "delete [channel] [bookmark-ids]",
+	Short:   "Delete channel bookmarks",
+	Long:    "Delete one or more bookmarks of a channel.",
+	Example: "  channel bookmark delete myteam:mychannel 7ptbcmy8xjb3xdrc1ta4p4j3qr",
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(channelBookmarkDeleteCmdF),
+}
+
+var ChannelBookmarkReorderCmd = &cobra.Command{
+	Use:   "reorder [channel] [bookmark-ids]",
+	Short: "Reorder channel bookmarks",
+	Long: `Move the given bookmarks to the beginning of the channel's bookmarks, in the order they are provided.
+Bookmarks that are not listed keep their relative order after the listed ones.`,
+	Example: "  channel bookmark reorder myteam:mychannel 7ptbcmy8xjb3xdrc1ta4p4j3qr 9dp4zdjr6ib9tme6jgpe5iocnw",
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    withClient(channelBookmarkReorderCmdF),
+}
+
+func init() {
+	ChannelBookmarkAddCmd.Flags().String("display-name", "", "Required. The display name of the bookmark")
+	_ = ChannelBookmarkAddCmd.MarkFlagRequired("display-name")
+	ChannelBookmarkAddCmd.Flags().String("url", "", "Required. The URL the bookmark links to")
+	_ = ChannelBookmarkAddCmd.MarkFlagRequired("url")
+	ChannelBookmarkAddCmd.Flags().String("image-url", "", "Optional. The URL of the image shown next to the bookmark")
+	ChannelBookmarkAddCmd.Flags().String("emoji", "", "Optional. The emoji shown next to the bookmark")
+
+	ChannelBookmarkUpdateCmd.Flags().String("display-name", "", "Optional. The new display name of the bookmark")
+	ChannelBookmarkUpdateCmd.Flags().String("url", "", "Optional. The new URL of a link bookmark")
+	ChannelBookmarkUpdateCmd.Flags().String("image-url", "", "Optional. The new image URL of the bookmark")
+	ChannelBookmarkUpdateCmd.Flags().String("emoji", "", "Optional. The new emoji of the bookmark")
+
+	ChannelBookmarkCmd.AddCommand(
+		ChannelBookmarkListCmd,
+		ChannelBookmarkAddCmd,
+		ChannelBookmarkUpdateCmd,
+		ChannelBookmarkDeleteCmd,
+		ChannelBookmarkReorderCmd,
+	)
+
+	ChannelCmd.AddCommand(ChannelBookmarkCmd)
+}
+
+func channelBookmarkListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	bookmarks, _, err := c.ListChannelBookmarksForChannel(context.TODO(), channel.Id, 0)
+	if err != nil {
+		return errors.Wrapf(err, "could not list bookmarks for channel %q", args[0])
+	}
+
+	for _, bookmark := range bookmarks {
+		printer.PrintT(channelBookmarkTemplate, bookmark)
+	}
+
+	return nil
+}
+
+func channelBookmarkAddCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	displayName, _ := cmd.Flags().GetString("display-name")
+	linkURL, _ := cmd.Flags().GetString("url")
+	imageURL, _ := cmd.Flags().GetString("image-url")
+	emoji, _ := cmd.Flags().GetString("emoji")
+
+	bookmark, _, err := c.CreateChannelBookmark(context.TODO(), &model.ChannelBookmark{
+		ChannelId:   channel.Id,
+		DisplayName: displayName,
+		LinkUrl:     linkURL,
+		ImageUrl:    imageURL,
+		Emoji:       emoji,
+		Type:        model.ChannelBookmarkLink,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "could not add bookmark to channel %q", args[0])
+	}
+
+	printer.PrintT(channelBookmarkTemplate, bookmark)
+	return nil
+}
+
+func channelBookmarkUpdateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	flags := []string{"display-name", "url", "image-url", "emoji"}
+
+	patch := &model.ChannelBookmarkPatch{}
+	fields := []**string{&patch.DisplayName, &patch.LinkUrl, &patch.ImageUrl, &patch.Emoji}
+	changed := false
+	for i, flag := range flags {
+		if !cmd.Flags().Changed(flag) {
+			continue
+		}
+		value, err := cmd.Flags().GetString(flag)
+		if err != nil {
+			return err
+		}
+		*fields[i] = &value
+		changed = true
+	}
+	if !changed {
+		return errors.New("at least one of --display-name, --url, --image-url or --emoji must be set")
+	}
+
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	res, _, err := c.UpdateChannelBookmark(context.TODO(), channel.Id, args[1], patch)
+	if err != nil {
+		return errors.Wrapf(err, "could not update bookmark %q", args[1])
+	}
+
+	printer.PrintT(channelBookmarkTemplate, res.Updated)
+	return nil
+}
+
+func channelBookmarkDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	var result *multierror.Error
+	for _, bookmarkID := range args[1:] {
+		bookmark, _, err := c.DeleteChannelBookmark(context.TODO(), channel.Id, bookmarkID)
+		if err != nil {
+			printer.PrintError(fmt.Sprintf("could not delete bookmark '%v'", bookmarkID))
+			result = multierror.Append(result, fmt.Errorf("could not delete bookmark %q: %w", bookmarkID, err))
+			continue
+		}
+
+		printer.PrintT("Deleted bookmark {{.Id}} ({{.DisplayName}})", bookmark)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func channelBookmarkReorderCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	channel := getChannelFromChannelArg(c, args[0])
+	if channel == nil {
+		return errors.Errorf("unable to find channel %q", args[0])
+	}
+
+	// placing each bookmark at its index in turn leaves the already
+	// placed ones untouched, so the end result follows the given order
+	var bookmarks []*model.ChannelBookmarkWithFileInfo
+	for i, bookmarkID := range args[1:] {
+		var err error
+		bookmarks, _, err = c.UpdateChannelBookmarkSortOrder(context.TODO(), channel.Id, bookmarkID, int64(i))
+		if err != nil {
+			return errors.Wrapf(err, "could not move bookmark %q", bookmarkID)
+		}
+	}
+
+	for _, bookmark := range bookmarks {
+		printer.PrintT(channelBookmarkTemplate, bookmark)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestChannelBookmarkCmds() {
+	s.SetupTestHelper().InitBasic()
+	s.th.App.Srv().SetLicense(model.NewTestLicense())
+
+	channelArg := s.th.BasicTeam.Name + ":" + s.th.BasicChannel.Name
+
+	addBookmark := func(displayName string) *model.ChannelBookmarkWithFileInfo {
+		bookmark, appErr := s.th.App.CreateChannelBookmark(s.th.Context, &model.ChannelBookmark{
+			ChannelId:   s.th.BasicChannel.Id,
+			OwnerId:     s.th.BasicUser.Id,
+			DisplayName: displayName,
+			LinkUrl:     "https://mattermost.com",
+			Type:        model.ChannelBookmarkLink,
+		}, "")
+		s.Require().Nil(appErr)
+		return bookmark
+	}
+
+	s.Run("Add and list bookmarks", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Runbook", "")
+		cmd.Flags().String("url", "https://example.com/runbook", "")
+		cmd.Flags().String("image-url", "", "")
+		cmd.Flags().String("emoji", "", "")
+
+		err := channelBookmarkAddCmdF(s.th.Client, cmd, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		printer.Clean()
+		err = channelBookmarkListCmdF(s.th.Client, &cobra.Command{}, []string{channelArg})
+		s.Require().NoError(err)
+		s.Require().NotEmpty(printer.GetLines())
+
+		found := false
+		for _, line := range printer.GetLines() {
+			if line.(*model.ChannelBookmarkWithFileInfo).DisplayName == "Runbook" {
+				found = true
+			}
+		}
+		s.Require().True(found)
+	})
+
+	s.Run("Update a bookmark", func() {
+		printer.Clean()
+		bookmark := addBookmark("to update")
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("url", "", "")
+		cmd.Flags().String("image-url", "", "")
+		cmd.Flags().String("emoji", "", "")
+		s.Require().NoError(cmd.Flags().Set("display-name", "updated"))
+
+		err := channelBookmarkUpdateCmdF(s.th.Client, cmd, []string{channelArg, bookmark.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("updated", printer.GetLines()[0].(*model.ChannelBookmarkWithFileInfo).DisplayName)
+	})
+
+	s.Run("Reorder and delete bookmarks", func() {
+		printer.Clean()
+		first := addBookmark("first")
+		second := addBookmark("second")
+
+		err := channelBookmarkReorderCmdF(s.th.Client, &cobra.Command{}, []string{channelArg, second.Id, first.Id})
+		s.Require().NoError(err)
+		lines := printer.GetLines()
+		s.Require().GreaterOrEqual(len(lines), 2)
+		s.Require().Equal(second.Id, lines[0].(*model.ChannelBookmarkWithFileInfo).Id)
+		s.Require().Equal(first.Id, lines[1].(*model.ChannelBookmarkWithFileInfo).Id)
+
+		printer.Clean()
+		err = channelBookmarkDeleteCmdF(s.th.Client, &cobra.Command{}, []string{channelArg, first.Id, second.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+
+		bookmarks, appErr := s.th.App.GetChannelBookmarks(s.th.BasicChannel.Id, 0)
+		s.Require().Nil(appErr)
+		for _, b := range bookmarks {
+			s.Require().NotEqual(first.Id, b.Id)
+			s.Require().NotEqual(second.Id, b.Id)
+		}
+	})
+}
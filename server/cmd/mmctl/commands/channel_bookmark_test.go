@@ -0,0 +1,209 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkListCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+
+	s.Run("Should list the bookmarks of a channel", func() {
+		printer.Clean()
+
+		bookmarks := []*model.ChannelBookmarkWithFileInfo{
+			{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId(), DisplayName: "one", Type: model.ChannelBookmarkLink, LinkUrl: "https://example.com"}},
+			{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId(), DisplayName: "two", Type: model.ChannelBookmarkFile}},
+		}
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ListChannelBookmarksForChannel(context.TODO(), channel.Id, int64(0)).
+			Return(bookmarks, &model.Response{}, nil).
+			Times(1)
+
+		err := channelBookmarkListCmdF(s.client, &cobra.Command{}, []string{channel.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(bookmarks[0], printer.GetLines()[0])
+		s.Require().Equal(bookmarks[1], printer.GetLines()[1])
+	})
+
+	s.Run("Should fail if the channel doesn't exist", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), "missing", "").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		err := channelBookmarkListCmdF(s.client, &cobra.Command{}, []string{"missing"})
+		s.Require().EqualError(err, `unable to find channel "missing"`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkAddCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+
+	s.Run("Should add a link bookmark", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "Runbook", "")
+		cmd.Flags().String("url", "https://example.com", "")
+		cmd.Flags().String("image-url", "", "")
+		cmd.Flags().String("emoji", ":book:", "")
+
+		expected := &model.ChannelBookmark{
+			ChannelId:   channel.Id,
+			DisplayName: "Runbook",
+			LinkUrl:     "https://example.com",
+			Emoji:       ":book:",
+			Type:        model.ChannelBookmarkLink,
+		}
+		created := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: expected}
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateChannelBookmark(context.TODO(), expected).
+			Return(created, &model.Response{}, nil).
+			Times(1)
+
+		err := channelBookmarkAddCmdF(s.client, cmd, []string{channel.Id})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(created, printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkUpdateCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+	bookmarkID := model.NewId()
+
+	s.Run("Should update only the changed fields", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("url", "", "")
+		cmd.Flags().String("image-url", "", "")
+		cmd.Flags().String("emoji", "", "")
+		s.Require().NoError(cmd.Flags().Set("display-name", "New name"))
+
+		newName := "New name"
+		updated := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: bookmarkID, DisplayName: newName}}
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateChannelBookmark(context.TODO(), channel.Id, bookmarkID, &model.ChannelBookmarkPatch{DisplayName: &newName}).
+			Return(&model.UpdateChannelBookmarkResponse{Updated: updated}, &model.Response{}, nil).
+			Times(1)
+
+		err := channelBookmarkUpdateCmdF(s.client, cmd, []string{channel.Id, bookmarkID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(updated, printer.GetLines()[0])
+	})
+
+	s.Run("Should fail if no field is changed", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("url", "", "")
+		cmd.Flags().String("image-url", "", "")
+		cmd.Flags().String("emoji", "", "")
+
+		err := channelBookmarkUpdateCmdF(s.client, cmd, []string{channel.Id, bookmarkID})
+		s.Require().Error(err)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkDeleteCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+
+	s.Run("Should delete bookmarks and report failures", func() {
+		printer.Clean()
+
+		deleted := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId(), DisplayName: "one"}}
+		failing := model.NewId()
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteChannelBookmark(context.TODO(), channel.Id, deleted.Id).
+			Return(deleted, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteChannelBookmark(context.TODO(), channel.Id, failing).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := channelBookmarkDeleteCmdF(s.client, &cobra.Command{}, []string{channel.Id, deleted.Id, failing})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(deleted, printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestChannelBookmarkReorderCmd() {
+	channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+
+	s.Run("Should move the bookmarks in the given order", func() {
+		printer.Clean()
+
+		first := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId()}}
+		second := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId()}}
+		other := &model.ChannelBookmarkWithFileInfo{ChannelBookmark: &model.ChannelBookmark{Id: model.NewId()}}
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateChannelBookmarkSortOrder(context.TODO(), channel.Id, second.Id, int64(0)).
+			Return([]*model.ChannelBookmarkWithFileInfo{second, first, other}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateChannelBookmarkSortOrder(context.TODO(), channel.Id, other.Id, int64(1)).
+			Return([]*model.ChannelBookmarkWithFileInfo{second, other, first}, &model.Response{}, nil).
+			Times(1)
+
+		err := channelBookmarkReorderCmdF(s.client, &cobra.Command{}, []string{channel.Id, second.Id, other.Id})
+		s.Require().NoError(err)
+		s.Require().Equal([]any{second, other, first}, printer.GetLines())
+	})
+}
@@ -0,0 +1,585 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// emojiPackFileName is the name of the pack definition written by
+	// export-pack and looked up by import-pack when given a directory
+	emojiPackFileName = "emojis.yaml"
+
+	// maxEmojiImageSize mirrors the server side limit for emoji
+	// images so oversized files are rejected before uploading them
+	maxEmojiImageSize = 1 << 19
+
+	emojiConflictSkip    = "skip"
+	emojiConflictReplace = "replace"
+	emojiConflictRename  = "rename"
+	emojiConflictFail    = "fail"
+
+	// maxEmojiRenameAttempts limits how many suffixed names are tried
+	// when resolving a name collision with the rename strategy
+	maxEmojiRenameAttempts = 100
+
+	// emojiPackDownloadTimeout bounds the download of an image
+	// referenced by URL in a pack
+	emojiPackDownloadTimeout = 30 * time.Second
+)
+
+var emojiPackHTTPClient = &http.Client{Timeout: emojiPackDownloadTimeout}
+
+var emojiImageExtensions = map[string]bool{
+	".gif":  true,
+	".jpeg": true,
+	".jpg":  true,
+	".png":  true,
+	".webp": true,
+}
+
+// emojiPack is the YAML representation of a set of custom emojis. The
+// format is compatible with the widely used emojipacks definitions,
+// where src can be a path relative to the pack file or an HTTP(S) URL.
+type emojiPack struct {
+	Title  string           `yaml:"title,omitempty"`
+	Emojis []emojiPackEntry `yaml:"emojis"`
+}
+
+type emojiPackEntry struct {
+	Name string `yaml:"name"`
+	Src  string `yaml:"src"`
+}
+
+var EmojiCmd = &cobra.Command{
+	Use:   "emoji",
+	Short: "Management of custom emojis",
+}
+
+var ListEmojiCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List custom emojis",
+	Long:    "List the custom emojis of the server.",
+	Example: `  emoji list --all`,
+	PreRun:  disableLocalPrecheck,
+	RunE:    withClient(emojiListCmdF),
+	Args:    cobra.NoArgs,
+}
+
+var AddEmojiCmd = &cobra.Command{
+	Use:     "add [name] [image]",
+	Short:   "Add a custom emoji",
+	Long:    "Create a new custom emoji from an image file. The emoji is created on behalf of the authenticated user.",
+	Example: `  emoji add partyparrot ./parrot.gif`,
+	PreRun:  disableLocalPrecheck,
+	RunE:    withClient(emojiAddCmdF),
+	Args:    cobra.ExactArgs(2),
+}
+
+var DeleteEmojiCmd = &cobra.Command{
+	Use:     "delete [names]",
+	Short:   "Delete custom emojis",
+	Long:    "Delete one or more custom emojis by name.",
+	Example: `  emoji delete partyparrot sadparrot`,
+	PreRun:  disableLocalPrecheck,
+	RunE:    withClient(emojiDeleteCmdF),
+	Args:    cobra.MinimumNArgs(1),
+}
+
+var SearchEmojiCmd = &cobra.Command{
+	Use:     "search [term]",
+	Short:   "Search custom emojis",
+	Long:    "Search custom emojis whose name contains, or starts with, the given term.",
+	Example: `  emoji search parrot --prefix-only`,
+	PreRun:  disableLocalPrecheck,
+	RunE:    withClient(emojiSearchCmdF),
+	Args:    cobra.ExactArgs(1),
+}
+
+var ImportPackEmojiCmd = &cobra.Command{
+	Use:   "import-pack [directory | pack.yaml]",
+	Short: "Import a pack of custom emojis",
+	Long: `Import custom emojis in bulk, either from a directory or from a YAML pack file.
+
+When a directory is given and it contains an "emojis.yaml" file, that file is used as the pack definition. Otherwise every image in the directory is imported, using the file name without its extension as the emoji name.
+
+A pack file has the following format, where src is either a path relative to the pack file or an HTTP(S) URL:
+
+  title: Parrots
+  emojis:
+    - name: partyparrot
+      src: partyparrot.gif
+
+The --conflict flag controls what happens when an emoji with the same name already exists:
+  skip     leaves the existing emoji untouched (default)
+  replace  deletes the existing emoji and creates the new one
+  rename   creates the new emoji with a numeric suffix, e.g. partyparrot-1
+  fail     stops the import`,
+	Example: `  emoji import-pack ./parrots
+  emoji import-pack ./parrots/emojis.yaml --conflict rename`,
+	PreRun: disableLocalPrecheck,
+	RunE:   withClient(emojiImportPackCmdF),
+	Args:   cobra.ExactArgs(1),
+}
+
+var ExportPackEmojiCmd = &cobra.Command{
+	Use:     "export-pack [directory]",
+	Short:   "Export custom emojis as a pack",
+	Long:    "Download every custom emoji image of the server into a directory and write an \"emojis.yaml\" pack file that can be used with import-pack.",
+	Example: `  emoji export-pack ./exported-emojis --title "Our emojis"`,
+	PreRun:  disableLocalPrecheck,
+	RunE:    withClient(emojiExportPackCmdF),
+	Args:    cobra.ExactArgs(1),
+}
+
+func init() {
+	ListEmojiCmd.Flags().Int("page", 0, "Page number to fetch for the list of emojis")
+	ListEmojiCmd.Flags().Int("per-page", DefaultPageSize, "Number of emojis to be fetched")
+	ListEmojiCmd.Flags().Bool("all", false, "Fetch all emojis. --page flag will be ignored if provided")
+	ListEmojiCmd.Flags().Bool("sort-by-name", false, "Sort the emojis by name")
+
+	SearchEmojiCmd.Flags().Bool("prefix-only", false, "Only match emojis whose name starts with the term")
+
+	ImportPackEmojiCmd.Flags().String("conflict", emojiConflictSkip, "Strategy for emojis whose name already exists: skip, replace, rename or fail")
+
+	ExportPackEmojiCmd.Flags().String("title", "", "Optional. Title written to the pack file")
+
+	EmojiCmd.AddCommand(
+		ListEmojiCmd,
+		AddEmojiCmd,
+		DeleteEmojiCmd,
+		SearchEmojiCmd,
+		ImportPackEmojiCmd,
+		ExportPackEmojiCmd,
+	)
+
+	RootCmd.AddCommand(EmojiCmd)
+}
+
+func emojiListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	page, err := cmd.Flags().GetInt("page")
+	if err != nil {
+		return err
+	}
+	perPage, err := cmd.Flags().GetInt("per-page")
+	if err != nil {
+		return err
+	}
+	showAll, err := cmd.Flags().GetBool("all")
+	if err != nil {
+		return err
+	}
+	sortByName, _ := cmd.Flags().GetBool("sort-by-name")
+
+	if showAll {
+		page = 0
+	}
+
+	sortBy := ""
+	if sortByName {
+		sortBy = model.EmojiSortByName
+	}
+
+	tpl := `{{.Id}}: {{.Name}}`
+	for {
+		emojis, _, err := c.GetSortedEmojiList(context.TODO(), page, perPage, sortBy)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch emojis")
+		}
+
+		if len(emojis) == 0 {
+			break
+		}
+
+		for _, emoji := range emojis {
+			printer.PrintT(tpl, emoji)
+		}
+
+		if !showAll {
+			break
+		}
+		page++
+	}
+
+	return nil
+}
+
+func emojiAddCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	name, imagePath := args[0], args[1]
+
+	image, err := readEmojiImage(imagePath)
+	if err != nil {
+		return err
+	}
+
+	me, _, err := c.GetMe(context.TODO(), "")
+	if err != nil {
+		return errors.Wrap(err, "could not get the authenticated user")
+	}
+
+	emoji, _, err := c.CreateEmoji(context.TODO(), &model.Emoji{Name: name, CreatorId: me.Id}, image, filepath.Base(imagePath))
+	if err != nil {
+		return errors.Errorf("could not create emoji %q: %s", name, err)
+	}
+
+	printer.PrintT("Created emoji {{.Name}} ({{.Id}})", emoji)
+	return nil
+}
+
+func emojiDeleteCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+
+	for _, name := range args {
+		emoji, _, err := c.GetEmojiByName(context.TODO(), name)
+		if err != nil {
+			printer.PrintError(fmt.Sprintf("can't find emoji '%v'", name))
+			result = multierror.Append(result, fmt.Errorf("can't find emoji %q: %w", name, err))
+			continue
+		}
+
+		if _, err := c.DeleteEmoji(context.TODO(), emoji.Id); err != nil {
+			printer.PrintError(fmt.Sprintf("could not delete emoji '%v'", name))
+			result = multierror.Append(result, fmt.Errorf("could not delete emoji %q: %w", name, err))
+			continue
+		}
+
+		printer.PrintT("Deleted emoji {{.Name}} ({{.Id}})", emoji)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func emojiSearchCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	prefixOnly, _ := cmd.Flags().GetBool("prefix-only")
+
+	emojis, _, err := c.SearchEmoji(context.TODO(), &model.EmojiSearch{Term: args[0], PrefixOnly: prefixOnly})
+	if err != nil {
+		return errors.Wrap(err, "failed to search emojis")
+	}
+
+	for _, emoji := range emojis {
+		printer.PrintT(`{{.Id}}: {{.Name}}`, emoji)
+	}
+
+	return nil
+}
+
+func emojiImportPackCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	conflict, _ := cmd.Flags().GetString("conflict")
+	switch conflict {
+	case emojiConflictSkip, emojiConflictReplace, emojiConflictRename, emojiConflictFail:
+	default:
+		return errors.Errorf("invalid conflict strategy %q, must be one of skip, replace, rename or fail", conflict)
+	}
+
+	pack, baseDir, err := loadEmojiPack(args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(pack.Emojis) == 0 {
+		return errors.Errorf("no emojis found in %q", args[0])
+	}
+
+	me, _, err := c.GetMe(context.TODO(), "")
+	if err != nil {
+		return errors.Wrap(err, "could not get the authenticated user")
+	}
+
+	var result *multierror.Error
+	for _, entry := range pack.Emojis {
+		if entry.Name == "" || entry.Src == "" {
+			printer.PrintError(fmt.Sprintf("skipping invalid pack entry '%v'", entry.Name))
+			result = multierror.Append(result, fmt.Errorf("invalid pack entry %q: name and src are required", entry.Name))
+			continue
+		}
+
+		name, replaced, err := resolveEmojiNameConflict(c, entry.Name, conflict)
+		if err != nil {
+			if conflict == emojiConflictFail {
+				return multierror.Append(result, err).ErrorOrNil()
+			}
+			printer.PrintError(err.Error())
+			result = multierror.Append(result, err)
+			continue
+		}
+		if name == "" {
+			printer.PrintWarning(fmt.Sprintf("emoji %q already exists, skipping", entry.Name))
+			continue
+		}
+
+		image, err := readEmojiPackSource(baseDir, entry.Src)
+		if err != nil {
+			printer.PrintError(fmt.Sprintf("could not read image for emoji '%v'", entry.Name))
+			result = multierror.Append(result, err)
+			continue
+		}
+
+		// The existing emoji is only deleted once the new image is
+		// known to be readable.
+		if replaced != nil {
+			if _, err := c.DeleteEmoji(context.TODO(), replaced.Id); err != nil {
+				printer.PrintError(fmt.Sprintf("could not delete existing emoji '%v'", name))
+				result = multierror.Append(result, fmt.Errorf("could not delete existing emoji %q: %w", name, err))
+				continue
+			}
+		}
+
+		emoji, _, err := c.CreateEmoji(context.TODO(), &model.Emoji{Name: name, CreatorId: me.Id}, image, filepath.Base(entry.Src))
+		if err != nil {
+			printer.PrintError(fmt.Sprintf("could not create emoji '%v'", name))
+			result = multierror.Append(result, fmt.Errorf("could not create emoji %q: %w", name, err))
+			continue
+		}
+
+		printer.PrintT("Created emoji {{.Name}} ({{.Id}})", emoji)
+	}
+
+	return result.ErrorOrNil()
+}
+
+func emojiExportPackCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	dir := args[0]
+	title, _ := cmd.Flags().GetString("title")
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrapf(err, "could not create directory %q", dir)
+	}
+
+	pack := emojiPack{Title: title}
+	var result *multierror.Error
+	for page := 0; ; page++ {
+		emojis, _, err := c.GetSortedEmojiList(context.TODO(), page, DefaultPageSize, model.EmojiSortByName)
+		if err != nil {
+			return errors.Wrap(err, "failed to fetch emojis")
+		}
+
+		if len(emojis) == 0 {
+			break
+		}
+
+		for _, emoji := range emojis {
+			image, _, err := c.GetEmojiImage(context.TODO(), emoji.Id)
+			if err != nil {
+				printer.PrintError(fmt.Sprintf("could not download image for emoji '%v'", emoji.Name))
+				result = multierror.Append(result, fmt.Errorf("could not download image for emoji %q: %w", emoji.Name, err))
+				continue
+			}
+
+			fileName := emoji.Name + emojiImageExtension(image)
+			if err := os.WriteFile(filepath.Join(dir, fileName), image, 0o644); err != nil {
+				return errors.Wrapf(err, "could not write image for emoji %q", emoji.Name)
+			}
+
+			pack.Emojis = append(pack.Emojis, emojiPackEntry{Name: emoji.Name, Src: fileName})
+			printer.PrintT("Exported emoji {{.Name}} ({{.Id}})", emoji)
+		}
+	}
+
+	data, err := yaml.Marshal(pack)
+	if err != nil {
+		return errors.Wrap(err, "could not encode the emoji pack")
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, emojiPackFileName), data, 0o644); err != nil {
+		return errors.Wrap(err, "could not write the emoji pack file")
+	}
+
+	return result.ErrorOrNil()
+}
+
+// resolveEmojiNameConflict returns the name that should be used to
+// create an emoji according to the conflict strategy, and the existing
+// emoji to delete beforehand with the replace strategy. An empty name
+// with no error means that the emoji should be skipped.
+func resolveEmojiNameConflict(c client.Client, name, conflict string) (string, *model.Emoji, error) {
+	existing, err := getExistingEmoji(c, name)
+	if err != nil {
+		return "", nil, err
+	}
+	if existing == nil {
+		return name, nil, nil
+	}
+
+	switch conflict {
+	case emojiConflictReplace:
+		return name, existing, nil
+	case emojiConflictRename:
+		for i := 1; i <= maxEmojiRenameAttempts; i++ {
+			candidate := fmt.Sprintf("%s-%d", name, i)
+			if len(candidate) > model.EmojiNameMaxLength {
+				break
+			}
+			existing, err := getExistingEmoji(c, candidate)
+			if err != nil {
+				return "", nil, err
+			}
+			if existing == nil {
+				return candidate, nil, nil
+			}
+		}
+		return "", nil, fmt.Errorf("could not find an available name for emoji %q", name)
+	case emojiConflictFail:
+		return "", nil, fmt.Errorf("emoji %q already exists", name)
+	default:
+		return "", nil, nil
+	}
+}
+
+// getExistingEmoji returns the emoji with the given name, or nil if
+// there is none
+func getExistingEmoji(c client.Client, name string) (*model.Emoji, error) {
+	emoji, resp, err := c.GetEmojiByName(context.TODO(), name)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not check if emoji %q exists: %w", name, err)
+	}
+	return emoji, nil
+}
+
+// loadEmojiPack reads a pack from either a YAML file or a directory,
+// returning the directory that relative sources should be resolved
+// against
+func loadEmojiPack(path string) (*emojiPack, string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "could not read %q", path)
+	}
+
+	if !info.IsDir() {
+		pack, err := readEmojiPackFile(path)
+		return pack, filepath.Dir(path), err
+	}
+
+	packFile := filepath.Join(path, emojiPackFileName)
+	if _, err := os.Stat(packFile); err == nil {
+		pack, err := readEmojiPackFile(packFile)
+		return pack, path, err
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "could not read directory %q", path)
+	}
+
+	pack := &emojiPack{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := filepath.Ext(entry.Name())
+		if !emojiImageExtensions[strings.ToLower(ext)] {
+			continue
+		}
+		pack.Emojis = append(pack.Emojis, emojiPackEntry{
+			Name: strings.ToLower(strings.TrimSuffix(entry.Name(), ext)),
+			Src:  entry.Name(),
+		})
+	}
+	sort.Slice(pack.Emojis, func(i, j int) bool { return pack.Emojis[i].Name < pack.Emojis[j].Name })
+
+	return pack, path, nil
+}
+
+func readEmojiPackFile(path string) (*emojiPack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read pack file %q", path)
+	}
+
+	var pack emojiPack
+	if err := yaml.Unmarshal(data, &pack); err != nil {
+		return nil, errors.Wrapf(err, "could not parse pack file %q", path)
+	}
+
+	return &pack, nil
+}
+
+func readEmojiPackSource(baseDir, src string) ([]byte, error) {
+	if !strings.HasPrefix(src, "http://") && !strings.HasPrefix(src, "https://") {
+		if !filepath.IsAbs(src) {
+			src = filepath.Join(baseDir, src)
+		}
+		return readEmojiImage(src)
+	}
+
+	resp, err := emojiPackHTTPClient.Get(src)
+	if err != nil {
+		return nil, fmt.Errorf("could not download %q: %w", src, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("could not download %q: unexpected status %d", src, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxEmojiImageSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("could not download %q: %w", src, err)
+	}
+	if len(data) > maxEmojiImageSize {
+		return nil, fmt.Errorf("image %q is larger than %d bytes", src, maxEmojiImageSize)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("image %q is empty", src)
+	}
+
+	return data, nil
+}
+
+func readEmojiImage(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read image %q: %w", path, err)
+	}
+	if info.Size() > maxEmojiImageSize {
+		return nil, fmt.Errorf("image %q is larger than %d bytes", path, maxEmojiImageSize)
+	}
+	if info.Size() == 0 {
+		return nil, fmt.Errorf("image %q is empty", path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read image %q: %w", path, err)
+	}
+
+	return data, nil
+}
+
+// emojiImageExtension guesses the file extension of an emoji image
+// from its contents
+func emojiImageExtension(image []byte) string {
+	switch http.DetectContentType(image) {
+	case "image/gif":
+		return ".gif"
+	case "image/jpeg":
+		return ".jpg"
+	case "image/webp":
+		return ".webp"
+	default:
+		return ".png"
+	}
+}
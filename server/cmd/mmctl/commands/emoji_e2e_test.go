@@ -0,0 +1,175 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/utils"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) setupEmojiTest() {
+	s.SetupTestHelper().InitBasic()
+
+	s.th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableCustomEmoji = true
+		*cfg.FileSettings.DriverName = model.ImageDriverLocal
+	})
+}
+
+func (s *MmctlE2ETestSuite) createTestEmoji(name string) *model.Emoji {
+	emoji := &model.Emoji{Name: name, CreatorId: s.th.SystemAdminUser.Id}
+	emoji, _, err := s.th.SystemAdminClient.CreateEmoji(context.Background(), emoji, utils.CreateTestGif(s.T(), 10, 10), "image.gif")
+	s.Require().NoError(err)
+	return emoji
+}
+
+func (s *MmctlE2ETestSuite) TestEmojiAddCmd() {
+	s.setupEmojiTest()
+
+	imagePath := filepath.Join(s.T().TempDir(), "emoji.gif")
+	s.Require().NoError(os.WriteFile(imagePath, utils.CreateTestGif(s.T(), 10, 10), 0o600))
+
+	s.Run("Add emoji with the system admin client", func() {
+		printer.Clean()
+		name := "a" + model.NewId()
+
+		err := emojiAddCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{name, imagePath})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		emoji, appErr := s.th.App.GetEmojiByName(s.th.Context, name)
+		s.Require().Nil(appErr)
+		s.Require().Equal(s.th.SystemAdminUser.Id, emoji.CreatorId)
+	})
+
+	s.Run("Add an emoji with an existing name", func() {
+		printer.Clean()
+		emoji := s.createTestEmoji("a" + model.NewId())
+
+		err := emojiAddCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{emoji.Name, imagePath})
+		s.Require().Error(err)
+		s.Require().Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlE2ETestSuite) TestEmojiListCmd() {
+	s.setupEmojiTest()
+
+	emoji1 := s.createTestEmoji("a" + model.NewId())
+	emoji2 := s.createTestEmoji("b" + model.NewId())
+
+	for name, c := range map[string]client.Client{"Client": s.th.Client, "SystemAdminClient": s.th.SystemAdminClient} {
+		s.Run("List all emojis/"+name, func() {
+			printer.Clean()
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Int("page", 0, "")
+			cmd.Flags().Int("per-page", 1, "")
+			cmd.Flags().Bool("all", true, "")
+			cmd.Flags().Bool("sort-by-name", true, "")
+
+			err := emojiListCmdF(c, cmd, []string{})
+			s.Require().NoError(err)
+			s.Require().Len(printer.GetLines(), 2)
+			s.Require().Equal(emoji1.Name, printer.GetLines()[0].(*model.Emoji).Name)
+			s.Require().Equal(emoji2.Name, printer.GetLines()[1].(*model.Emoji).Name)
+		})
+	}
+}
+
+func (s *MmctlE2ETestSuite) TestEmojiSearchCmd() {
+	s.setupEmojiTest()
+
+	prefix := "s" + model.NewId()[:10]
+	emoji := s.createTestEmoji(prefix + "parrot")
+	s.createTestEmoji("a" + model.NewId())
+
+	s.Run("Search emojis by prefix", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("prefix-only", true, "")
+
+		err := emojiSearchCmdF(s.th.Client, cmd, []string{prefix})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(emoji.Name, printer.GetLines()[0].(*model.Emoji).Name)
+	})
+}
+
+func (s *MmctlE2ETestSuite) TestEmojiDeleteCmd() {
+	s.setupEmojiTest()
+
+	s.Run("Delete an emoji with the system admin client", func() {
+		printer.Clean()
+		emoji := s.createTestEmoji("a" + model.NewId())
+
+		err := emojiDeleteCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{emoji.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		_, appErr := s.th.App.GetEmojiByName(s.th.Context, emoji.Name)
+		s.Require().NotNil(appErr)
+	})
+
+	s.Run("Delete a nonexistent emoji", func() {
+		printer.Clean()
+
+		err := emojiDeleteCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{"a" + model.NewId()})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
+
+func (s *MmctlE2ETestSuite) TestEmojiImportExportPackCmd() {
+	s.setupEmojiTest()
+
+	s.Run("Import a directory and export it back", func() {
+		printer.Clean()
+
+		existing := s.createTestEmoji("a" + model.NewId())
+		newName := "b" + model.NewId()
+
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, existing.Name+".gif"), utils.CreateTestGif(s.T(), 10, 10), 0o600))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, newName+".png"), utils.CreateTestPng(s.T(), 10, 10), 0o600))
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("conflict", emojiConflictRename, "")
+
+		err := emojiImportPackCmdF(s.th.SystemAdminClient, cmd, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+
+		_, appErr := s.th.App.GetEmojiByName(s.th.Context, existing.Name+"-1")
+		s.Require().Nil(appErr)
+		_, appErr = s.th.App.GetEmojiByName(s.th.Context, newName)
+		s.Require().Nil(appErr)
+
+		printer.Clean()
+		exportDir := filepath.Join(s.T().TempDir(), "export")
+
+		cmd = &cobra.Command{}
+		cmd.Flags().String("title", "", "")
+
+		err = emojiExportPackCmdF(s.th.SystemAdminClient, cmd, []string{exportDir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 3)
+
+		pack, err := readEmojiPackFile(filepath.Join(exportDir, emojiPackFileName))
+		s.Require().NoError(err)
+		s.Require().Len(pack.Emojis, 3)
+		for _, entry := range pack.Emojis {
+			s.Require().FileExists(filepath.Join(exportDir, entry.Src))
+		}
+	})
+}
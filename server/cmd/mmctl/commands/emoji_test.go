@@ -0,0 +1,372 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/golang/mock/gomock"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestEmojiListCmd() {
+	s.Run("Should list a page of emojis", func() {
+		printer.Clean()
+
+		emojis := []*model.Emoji{
+			{Id: model.NewId(), Name: "emoji1"},
+			{Id: model.NewId(), Name: "emoji2"},
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 2, "")
+		cmd.Flags().Bool("all", false, "")
+		cmd.Flags().Bool("sort-by-name", true, "")
+
+		s.client.
+			EXPECT().
+			GetSortedEmojiList(context.TODO(), 0, 2, model.EmojiSortByName).
+			Return(emojis, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(emojis[0], printer.GetLines()[0])
+		s.Require().Equal(emojis[1], printer.GetLines()[1])
+	})
+
+	s.Run("Should list all emojis", func() {
+		printer.Clean()
+
+		emojis := []*model.Emoji{{Id: model.NewId(), Name: "emoji1"}}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 3, "")
+		cmd.Flags().Int("per-page", 1, "")
+		cmd.Flags().Bool("all", true, "")
+		cmd.Flags().Bool("sort-by-name", false, "")
+
+		s.client.
+			EXPECT().
+			GetSortedEmojiList(context.TODO(), 0, 1, "").
+			Return(emojis, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSortedEmojiList(context.TODO(), 1, 1, "").
+			Return([]*model.Emoji{}, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Should fail when the emojis can't be fetched", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", 2, "")
+		cmd.Flags().Bool("all", false, "")
+
+		s.client.
+			EXPECT().
+			GetSortedEmojiList(context.TODO(), 0, 2, "").
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := emojiListCmdF(s.client, cmd, []string{})
+		s.Require().Error(err)
+		s.Require().Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiAddCmd() {
+	imagePath := filepath.Join(s.T().TempDir(), "parrot.png")
+	s.Require().NoError(os.WriteFile(imagePath, []byte("image"), 0o600))
+
+	s.Run("Should create an emoji", func() {
+		printer.Clean()
+
+		me := &model.User{Id: model.NewId()}
+		emoji := &model.Emoji{Name: "parrot", CreatorId: me.Id}
+		created := &model.Emoji{Id: model.NewId(), Name: "parrot", CreatorId: me.Id}
+
+		s.client.
+			EXPECT().
+			GetMe(context.TODO(), "").
+			Return(me, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), emoji, []byte("image"), "parrot.png").
+			Return(created, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiAddCmdF(s.client, &cobra.Command{}, []string{"parrot", imagePath})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(created, printer.GetLines()[0])
+	})
+
+	s.Run("Should fail if the image doesn't exist", func() {
+		printer.Clean()
+
+		err := emojiAddCmdF(s.client, &cobra.Command{}, []string{"parrot", filepath.Join(s.T().TempDir(), "missing.png")})
+		s.Require().Error(err)
+		s.Require().Empty(printer.GetLines())
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiDeleteCmd() {
+	s.Run("Should delete the emojis and report the missing ones", func() {
+		printer.Clean()
+
+		emoji := &model.Emoji{Id: model.NewId(), Name: "parrot"}
+
+		s.client.
+			EXPECT().
+			GetEmojiByName(context.TODO(), "parrot").
+			Return(emoji, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			DeleteEmoji(context.TODO(), emoji.Id).
+			Return(&model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiByName(context.TODO(), "missing").
+			Return(nil, &model.Response{StatusCode: http.StatusNotFound}, errors.New("not found")).
+			Times(1)
+
+		err := emojiDeleteCmdF(s.client, &cobra.Command{}, []string{"parrot", "missing"})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(emoji, printer.GetLines()[0])
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiSearchCmd() {
+	s.Run("Should search emojis", func() {
+		printer.Clean()
+
+		emojis := []*model.Emoji{{Id: model.NewId(), Name: "parrot"}}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("prefix-only", true, "")
+
+		s.client.
+			EXPECT().
+			SearchEmoji(context.TODO(), &model.EmojiSearch{Term: "par", PrefixOnly: true}).
+			Return(emojis, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiSearchCmdF(s.client, cmd, []string{"par"})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(emojis[0], printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiImportPackCmd() {
+	me := &model.User{Id: model.NewId()}
+	notFound := &model.Response{StatusCode: http.StatusNotFound}
+
+	newPackDir := func() string {
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "Parrot.png"), []byte("parrot"), 0o600))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "cat.gif"), []byte("cat"), 0o600))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+		return dir
+	}
+
+	newCmd := func(conflict string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("conflict", conflict, "")
+		return cmd
+	}
+
+	s.Run("Should import every image of a directory", func() {
+		printer.Clean()
+		dir := newPackDir()
+
+		s.client.EXPECT().GetMe(context.TODO(), "").Return(me, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "cat").Return(nil, notFound, errors.New("not found")).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "parrot").Return(nil, notFound, errors.New("not found")).Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "cat", CreatorId: me.Id}, []byte("cat"), "cat.gif").
+			Return(&model.Emoji{Id: model.NewId(), Name: "cat"}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "parrot", CreatorId: me.Id}, []byte("parrot"), "Parrot.png").
+			Return(&model.Emoji{Id: model.NewId(), Name: "parrot"}, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiImportPackCmdF(s.client, newCmd(emojiConflictSkip), []string{dir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+	})
+
+	s.Run("Should import a pack file and skip existing emojis", func() {
+		printer.Clean()
+		dir := newPackDir()
+
+		pack := emojiPack{Emojis: []emojiPackEntry{
+			{Name: "partyparrot", Src: "Parrot.png"},
+			{Name: "cat", Src: "cat.gif"},
+		}}
+		data, err := yaml.Marshal(pack)
+		s.Require().NoError(err)
+		packFile := filepath.Join(dir, "pack.yml")
+		s.Require().NoError(os.WriteFile(packFile, data, 0o600))
+
+		s.client.EXPECT().GetMe(context.TODO(), "").Return(me, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "partyparrot").Return(nil, notFound, errors.New("not found")).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "cat").Return(&model.Emoji{Id: model.NewId(), Name: "cat"}, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "partyparrot", CreatorId: me.Id}, []byte("parrot"), "Parrot.png").
+			Return(&model.Emoji{Id: model.NewId(), Name: "partyparrot"}, &model.Response{}, nil).
+			Times(1)
+
+		err = emojiImportPackCmdF(s.client, newCmd(emojiConflictSkip), []string{packFile})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Empty(printer.GetErrorLines())
+	})
+
+	s.Run("Should rename emojis on conflict", func() {
+		printer.Clean()
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "cat.gif"), []byte("cat"), 0o600))
+
+		s.client.EXPECT().GetMe(context.TODO(), "").Return(me, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "cat").Return(&model.Emoji{Id: model.NewId(), Name: "cat"}, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "cat-1").Return(&model.Emoji{Id: model.NewId(), Name: "cat-1"}, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "cat-2").Return(nil, notFound, errors.New("not found")).Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "cat-2", CreatorId: me.Id}, []byte("cat"), "cat.gif").
+			Return(&model.Emoji{Id: model.NewId(), Name: "cat-2"}, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiImportPackCmdF(s.client, newCmd(emojiConflictRename), []string{dir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Should replace emojis on conflict", func() {
+		printer.Clean()
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "cat.gif"), []byte("cat"), 0o600))
+		existing := &model.Emoji{Id: model.NewId(), Name: "cat"}
+
+		s.client.EXPECT().GetMe(context.TODO(), "").Return(me, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "cat").Return(existing, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().DeleteEmoji(context.TODO(), existing.Id).Return(&model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			CreateEmoji(context.TODO(), &model.Emoji{Name: "cat", CreatorId: me.Id}, []byte("cat"), "cat.gif").
+			Return(&model.Emoji{Id: model.NewId(), Name: "cat"}, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiImportPackCmdF(s.client, newCmd(emojiConflictReplace), []string{dir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Should keep the existing emoji when the replacement image can't be read", func() {
+		printer.Clean()
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "cat.gif"), []byte{}, 0o600))
+		existing := &model.Emoji{Id: model.NewId(), Name: "cat"}
+
+		s.client.EXPECT().GetMe(context.TODO(), "").Return(me, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "cat").Return(existing, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().DeleteEmoji(gomock.Any(), gomock.Any()).Times(0)
+		s.client.EXPECT().CreateEmoji(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := emojiImportPackCmdF(s.client, newCmd(emojiConflictReplace), []string{dir})
+		s.Require().Error(err)
+		s.Require().Empty(printer.GetLines())
+		s.Require().Len(printer.GetErrorLines(), 1)
+	})
+
+	s.Run("Should stop on conflict with the fail strategy", func() {
+		printer.Clean()
+		dir := newPackDir()
+
+		s.client.EXPECT().GetMe(context.TODO(), "").Return(me, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetEmojiByName(context.TODO(), "cat").Return(&model.Emoji{Id: model.NewId(), Name: "cat"}, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().CreateEmoji(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := emojiImportPackCmdF(s.client, newCmd(emojiConflictFail), []string{dir})
+		s.Require().Error(err)
+		s.Require().Empty(printer.GetLines())
+	})
+
+	s.Run("Should reject an invalid conflict strategy", func() {
+		printer.Clean()
+
+		err := emojiImportPackCmdF(s.client, newCmd("merge"), []string{newPackDir()})
+		s.Require().Error(err)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestEmojiExportPackCmd() {
+	s.Run("Should write the images and the pack file", func() {
+		printer.Clean()
+		dir := filepath.Join(s.T().TempDir(), "export")
+
+		gifData := []byte("GIF89a-emoji")
+		emoji := &model.Emoji{Id: model.NewId(), Name: "parrot"}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("title", "Birds", "")
+
+		s.client.
+			EXPECT().
+			GetSortedEmojiList(context.TODO(), 0, DefaultPageSize, model.EmojiSortByName).
+			Return([]*model.Emoji{emoji}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetSortedEmojiList(context.TODO(), 1, DefaultPageSize, model.EmojiSortByName).
+			Return([]*model.Emoji{}, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetEmojiImage(context.TODO(), emoji.Id).
+			Return(gifData, &model.Response{}, nil).
+			Times(1)
+
+		err := emojiExportPackCmdF(s.client, cmd, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+
+		image, err := os.ReadFile(filepath.Join(dir, "parrot.gif"))
+		s.Require().NoError(err)
+		s.Require().Equal(gifData, image)
+
+		pack, err := readEmojiPackFile(filepath.Join(dir, emojiPackFileName))
+		s.Require().NoError(err)
+		s.Require().Equal("Birds", pack.Title)
+		s.Require().Equal([]emojiPackEntry{{Name: "parrot", Src: "parrot.gif"}}, pack.Emojis)
+	})
+}
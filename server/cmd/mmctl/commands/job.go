@@ -147,7 +147,7 @@ func jobListCmdF(c client.Client, command *cobra.Command, jobType string, status
 		page = 0
 	}
 
-	if jobType != "" && !model.IsValidJobType(jobType) {
+	if jobType != "" && !model.IsValidJobType(jobType) && !model.IsValidPluginJobType(jobType) {
 		return fmt.Errorf("invalid job type: %s", jobType)
 	}
 
@@ -0,0 +1,290 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const legalHoldTemplate = `{{.Id}}: {{.Name}} ({{.DisplayName}}), {{len .UserIds}} users, {{len .ChannelIds}} channels{{if .StartsAt}}, from {{millisToTime .StartsAt}}{{end}}{{if .EndsAt}}, until {{millisToTime .EndsAt}}{{end}}{{if .DeleteAt}}, released at {{millisToTime .DeleteAt}}{{end}}`
+
+var LegalHoldCmd = &cobra.Command{
+	Use:   "legal-hold",
+	Short: "Management of legal holds",
+	Long: `Management of legal holds. Posts, files and channel history of the users and channels named by a legal hold
+are kept from data retention and permanent deletion while the hold is active.`,
+}
+
+var LegalHoldListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List active legal holds",
+	Example: "  legal-hold list",
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.NoArgs,
+	RunE:    withClient(legalHoldListCmdF),
+}
+
+var LegalHoldShowCmd = &cobra.Command{
+	Use:     "show [legal-hold-id]",
+	Short:   "Show a legal hold",
+	Example: "  legal-hold show o98rj3ur83dp5dppfyk5yk6osy",
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldShowCmdF),
+}
+
+var LegalHoldCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a legal hold",
+	Long: `Create a legal hold on the given users and channels. Data created between --starts and --ends is held;
+either side of the time range is left open when omitted.`,
+	Example: `  legal-hold create --name acme-litigation --display-name "ACME litigation" --user john.doe --channel myteam:mychannel --starts 2024-10-01T00:00:00+00:00`,
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.NoArgs,
+	RunE:    withClient(legalHoldCreateCmdF),
+}
+
+var LegalHoldPatchCmd = &cobra.Command{
+	Use:   "patch [legal-hold-id]",
+	Short: "Modify a legal hold",
+	Long: `Modify the given fields of a legal hold. The --user and --channel flags replace the users and channels
+the hold names.`,
+	Example: `  legal-hold patch o98rj3ur83dp5dppfyk5yk6osy --ends 2025-01-01T00:00:00+00:00`,
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldPatchCmdF),
+}
+
+var LegalHoldReleaseCmd = &cobra.Command{
+	Use:     "release [legal-hold-id]",
+	Short:   "Release a legal hold",
+	Long:    "Release a legal hold. The data it covered becomes subject to data retention and permanent deletion again.",
+	Example: "  legal-hold release o98rj3ur83dp5dppfyk5yk6osy",
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldReleaseCmdF),
+}
+
+var LegalHoldExportCmd = &cobra.Command{
+	Use:   "export [legal-hold-id]",
+	Short: "Export the data covered by a legal hold",
+	Long: `Create a compliance export job restricted to the posts covered by a legal hold. The job can be followed and
+downloaded with the compliance-export commands.`,
+	Example: "  legal-hold export o98rj3ur83dp5dppfyk5yk6osy --format csv",
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(legalHoldExportCmdF),
+}
+
+func init() {
+	LegalHoldListCmd.Flags().Int("page", 0, "Page number to fetch for the list of legal holds")
+	LegalHoldListCmd.Flags().Int("per-page", DefaultPageSize, "Number of legal holds to be fetched")
+
+	LegalHoldCreateCmd.Flags().String("name", "", "Required. Unique name of the legal hold")
+	_ = LegalHoldCreateCmd.MarkFlagRequired("name")
+	LegalHoldCreateCmd.Flags().String("display-name", "", "Required. Display name of the legal hold")
+	_ = LegalHoldCreateCmd.MarkFlagRequired("display-name")
+	LegalHoldCreateCmd.Flags().String("description", "", "Description of the legal hold")
+	LegalHoldCreateCmd.Flags().StringSlice("user", nil, "Users to hold, by username, email or ID")
+	LegalHoldCreateCmd.Flags().StringSlice("channel", nil, "Channels to hold, as team:channel or channel ID")
+	LegalHoldCreateCmd.Flags().String("starts", "", "Hold data created after this time (ISO 8601)")
+	LegalHoldCreateCmd.Flags().String("ends", "", "Hold data created before this time (ISO 8601)")
+
+	LegalHoldPatchCmd.Flags().String("display-name", "", "Display name of the legal hold")
+	LegalHoldPatchCmd.Flags().String("description", "", "Description of the legal hold")
+	LegalHoldPatchCmd.Flags().StringSlice("user", nil, "Users to hold, by username, email or ID")
+	LegalHoldPatchCmd.Flags().StringSlice("channel", nil, "Channels to hold, as team:channel or channel ID")
+	LegalHoldPatchCmd.Flags().String("starts", "", "Hold data created after this time (ISO 8601)")
+	LegalHoldPatchCmd.Flags().String("ends", "", "Hold data created before this time (ISO 8601)")
+
+	LegalHoldExportCmd.Flags().String("format", "", "Export format, one of 'csv', 'actiance', 'globalrelay-zip', 'eml', 'mbox' or 'jsonl'. Defaults to the configured export format")
+
+	LegalHoldCmd.AddCommand(
+		LegalHoldListCmd,
+		LegalHoldShowCmd,
+		LegalHoldCreateCmd,
+		LegalHoldPatchCmd,
+		LegalHoldReleaseCmd,
+		LegalHoldExportCmd,
+	)
+
+	RootCmd.AddCommand(LegalHoldCmd)
+}
+
+func printLegalHold(hold *model.LegalHold) {
+	printer.SetTemplateFunc("millisToTime", func(ms int64) string {
+		return model.GetTimeForMillis(ms).Format(ISO8601Layout)
+	})
+	printer.PrintT(legalHoldTemplate, hold)
+}
+
+func legalHoldListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	page, _ := cmd.Flags().GetInt("page")
+	perPage, _ := cmd.Flags().GetInt("per-page")
+
+	holds, _, err := c.GetLegalHolds(context.TODO(), page, perPage)
+	if err != nil {
+		return errors.Wrap(err, "could not get the legal holds")
+	}
+
+	for _, hold := range holds {
+		printLegalHold(hold)
+	}
+	return nil
+}
+
+func legalHoldShowCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	hold, _, err := c.GetLegalHold(context.TODO(), args[0])
+	if err != nil {
+		return errors.Wrapf(err, "could not get legal hold %q", args[0])
+	}
+
+	printLegalHold(hold)
+	return nil
+}
+
+func legalHoldCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	hold := &model.LegalHold{}
+	hold.Name, _ = cmd.Flags().GetString("name")
+	hold.DisplayName, _ = cmd.Flags().GetString("display-name")
+	hold.Description, _ = cmd.Flags().GetString("description")
+
+	var err error
+	if hold.UserIds, err = legalHoldUserIDs(c, cmd); err != nil {
+		return err
+	}
+	if hold.ChannelIds, err = legalHoldChannelIDs(c, cmd); err != nil {
+		return err
+	}
+	if hold.StartsAt, err = legalHoldTime(cmd, "starts"); err != nil {
+		return err
+	}
+	if hold.EndsAt, err = legalHoldTime(cmd, "ends"); err != nil {
+		return err
+	}
+
+	created, _, err := c.CreateLegalHold(context.TODO(), hold)
+	if err != nil {
+		return errors.Wrap(err, "could not create the legal hold")
+	}
+
+	printLegalHold(created)
+	return nil
+}
+
+func legalHoldPatchCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	patch := &model.LegalHoldPatch{}
+
+	if cmd.Flags().Changed("display-name") {
+		displayName, _ := cmd.Flags().GetString("display-name")
+		patch.DisplayName = &displayName
+	}
+	if cmd.Flags().Changed("description") {
+		description, _ := cmd.Flags().GetString("description")
+		patch.Description = &description
+	}
+	if cmd.Flags().Changed("user") {
+		userIDs, err := legalHoldUserIDs(c, cmd)
+		if err != nil {
+			return err
+		}
+		patch.UserIds = &userIDs
+	}
+	if cmd.Flags().Changed("channel") {
+		channelIDs, err := legalHoldChannelIDs(c, cmd)
+		if err != nil {
+			return err
+		}
+		patch.ChannelIds = &channelIDs
+	}
+	if cmd.Flags().Changed("starts") {
+		startsAt, err := legalHoldTime(cmd, "starts")
+		if err != nil {
+			return err
+		}
+		patch.StartsAt = &startsAt
+	}
+	if cmd.Flags().Changed("ends") {
+		endsAt, err := legalHoldTime(cmd, "ends")
+		if err != nil {
+			return err
+		}
+		patch.EndsAt = &endsAt
+	}
+
+	hold, _, err := c.PatchLegalHold(context.TODO(), args[0], patch)
+	if err != nil {
+		return errors.Wrapf(err, "could not update legal hold %q", args[0])
+	}
+
+	printLegalHold(hold)
+	return nil
+}
+
+func legalHoldReleaseCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	if _, err := c.ReleaseLegalHold(context.TODO(), args[0]); err != nil {
+		return errors.Wrapf(err, "could not release legal hold %q", args[0])
+	}
+
+	printer.Print("Legal hold " + args[0] + " released")
+	return nil
+}
+
+func legalHoldExportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+
+	job, _, err := c.ExportLegalHold(context.TODO(), args[0], format)
+	if err != nil {
+		return errors.Wrapf(err, "could not export legal hold %q", args[0])
+	}
+
+	printJob(job)
+	return nil
+}
+
+func legalHoldUserIDs(c client.Client, cmd *cobra.Command) (model.StringArray, error) {
+	userArgs, _ := cmd.Flags().GetStringSlice("user")
+	userIDs := make(model.StringArray, 0, len(userArgs))
+	for i, user := range getUsersFromUserArgs(c, userArgs) {
+		if user == nil {
+			return nil, errors.Errorf("unable to find user %q", userArgs[i])
+		}
+		userIDs = append(userIDs, user.Id)
+	}
+	return userIDs, nil
+}
+
+func legalHoldChannelIDs(c client.Client, cmd *cobra.Command) (model.StringArray, error) {
+	channelArgs, _ := cmd.Flags().GetStringSlice("channel")
+	channelIDs := make(model.StringArray, 0, len(channelArgs))
+	for i, channel := range getChannelsFromChannelArgs(c, channelArgs) {
+		if channel == nil {
+			return nil, errors.Errorf("unable to find channel %q", channelArgs[i])
+		}
+		channelIDs = append(channelIDs, channel.Id)
+	}
+	return channelIDs, nil
+}
+
+func legalHoldTime(cmd *cobra.Command, flag string) (int64, error) {
+	value, _ := cmd.Flags().GetString(flag)
+	if value == "" {
+		return 0, nil
+	}
+
+	t, err := time.Parse(ISO8601Layout, value)
+	if err != nil {
+		return 0, errors.Errorf("invalid %s time %q", flag, value)
+	}
+	return model.GetMillisForTime(t), nil
+}
@@ -0,0 +1,200 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestLegalHoldListCmd() {
+	s.Run("Should list the legal holds", func() {
+		printer.Clean()
+
+		holds := []*model.LegalHold{
+			{Id: model.NewId(), Name: "one", UserIds: []string{model.NewId()}},
+			{Id: model.NewId(), Name: "two", ChannelIds: []string{model.NewId()}},
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Int("page", 0, "")
+		cmd.Flags().Int("per-page", DefaultPageSize, "")
+
+		s.client.
+			EXPECT().
+			GetLegalHolds(context.TODO(), 0, DefaultPageSize).
+			Return(holds, &model.Response{}, nil).
+			Times(1)
+
+		err := legalHoldListCmdF(s.client, cmd, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(holds[0], printer.GetLines()[0])
+		s.Require().Equal(holds[1], printer.GetLines()[1])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestLegalHoldCreateCmd() {
+	newCmd := func(user, channel, starts string) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().String("name", "acme-litigation", "")
+		cmd.Flags().String("display-name", "ACME litigation", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().StringSlice("user", []string{user}, "")
+		cmd.Flags().StringSlice("channel", []string{channel}, "")
+		cmd.Flags().String("starts", starts, "")
+		cmd.Flags().String("ends", "", "")
+		return cmd
+	}
+
+	s.Run("Should create a legal hold", func() {
+		printer.Clean()
+
+		user := &model.User{Id: model.NewId(), Username: "john.doe"}
+		channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+		starts := "2024-10-01T00:00:00+00:00"
+		startsTime, _ := time.Parse(ISO8601Layout, starts)
+
+		expected := &model.LegalHold{
+			Name:        "acme-litigation",
+			DisplayName: "ACME litigation",
+			UserIds:     model.StringArray{user.Id},
+			ChannelIds:  model.StringArray{channel.Id},
+			StartsAt:    model.GetMillisForTime(startsTime),
+		}
+		created := &model.LegalHold{Id: model.NewId(), Name: expected.Name}
+
+		s.client.
+			EXPECT().
+			GetUserByUsername(context.TODO(), user.Username, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateLegalHold(context.TODO(), expected).
+			Return(created, &model.Response{}, nil).
+			Times(1)
+
+		err := legalHoldCreateCmdF(s.client, newCmd(user.Username, channel.Id, starts), []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(created, printer.GetLines()[0])
+	})
+
+	s.Run("Should fail with an invalid start time", func() {
+		printer.Clean()
+
+		user := &model.User{Id: model.NewId(), Username: "john.doe"}
+		channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+
+		s.client.
+			EXPECT().
+			GetUserByUsername(context.TODO(), user.Username, "").
+			Return(user, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+
+		err := legalHoldCreateCmdF(s.client, newCmd(user.Username, channel.Id, "yesterday"), []string{})
+		s.Require().EqualError(err, `invalid starts time "yesterday"`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestLegalHoldPatchCmd() {
+	s.Run("Should only patch the changed fields", func() {
+		printer.Clean()
+
+		holdID := model.NewId()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("display-name", "", "")
+		cmd.Flags().String("description", "", "")
+		cmd.Flags().StringSlice("user", nil, "")
+		cmd.Flags().StringSlice("channel", nil, "")
+		cmd.Flags().String("starts", "", "")
+		cmd.Flags().String("ends", "", "")
+		s.Require().NoError(cmd.Flags().Set("display-name", "Renamed"))
+
+		hold := &model.LegalHold{Id: holdID, DisplayName: "Renamed"}
+
+		s.client.
+			EXPECT().
+			PatchLegalHold(context.TODO(), holdID, &model.LegalHoldPatch{DisplayName: model.NewPointer("Renamed")}).
+			Return(hold, &model.Response{}, nil).
+			Times(1)
+
+		err := legalHoldPatchCmdF(s.client, cmd, []string{holdID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(hold, printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestLegalHoldReleaseCmd() {
+	s.Run("Should release a legal hold", func() {
+		printer.Clean()
+
+		holdID := model.NewId()
+
+		s.client.
+			EXPECT().
+			ReleaseLegalHold(context.TODO(), holdID).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := legalHoldReleaseCmdF(s.client, &cobra.Command{}, []string{holdID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("Legal hold "+holdID+" released", printer.GetLines()[0])
+	})
+
+	s.Run("Should fail if the legal hold can't be released", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			ReleaseLegalHold(context.TODO(), "missing").
+			Return(&model.Response{}, errors.New("not found")).
+			Times(1)
+
+		err := legalHoldReleaseCmdF(s.client, &cobra.Command{}, []string{"missing"})
+		s.Require().EqualError(err, `could not release legal hold "missing": not found`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestLegalHoldExportCmd() {
+	s.Run("Should create an export job", func() {
+		printer.Clean()
+
+		holdID := model.NewId()
+		cmd := &cobra.Command{}
+		cmd.Flags().String("format", model.ComplianceExportTypeCsv, "")
+
+		job := &model.Job{Id: model.NewId(), Type: model.JobTypeMessageExport}
+
+		s.client.
+			EXPECT().
+			ExportLegalHold(context.TODO(), holdID, model.ComplianceExportTypeCsv).
+			Return(job, &model.Response{}, nil).
+			Times(1)
+
+		err := legalHoldExportCmdF(s.client, cmd, []string{holdID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(job, printer.GetLines()[0])
+	})
+}
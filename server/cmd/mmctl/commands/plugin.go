@@ -70,6 +70,15 @@ var PluginDisableCmd = &cobra.Command{
 	Args:    cobra.MinimumNArgs(1),
 }
 
+var PluginApprovePermissionsCmd = &cobra.Command{
+	Use:     "approve-permissions [plugins]",
+	Short:   "Approve the permissions of plugins",
+	Long:    "Approve the permissions declared by installed plugins, allowing the plugin API calls which require them.",
+	Example: `  plugin approve-permissions hovercardexample pluginexample`,
+	RunE:    withClient(pluginApprovePermissionsCmdF),
+	Args:    cobra.MinimumNArgs(1),
+}
+
 var PluginListCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "List plugins",
@@ -88,6 +97,7 @@ func init() {
 		PluginDeleteCmd,
 		PluginEnableCmd,
 		PluginDisableCmd,
+		PluginApprovePermissionsCmd,
 		PluginListCmd,
 	)
 	RootCmd.AddCommand(PluginCmd)
@@ -180,6 +190,20 @@ func pluginDisableCmdF(c client.Client, cmd *cobra.Command, args []string) error
 	return multiErr.ErrorOrNil()
 }
 
+func pluginApprovePermissionsCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var multiErr *multierror.Error
+	for _, plugin := range args {
+		if _, err := c.ApprovePluginPermissions(context.TODO(), plugin); err != nil {
+			printer.PrintError("Unable to approve the permissions of plugin: " + plugin + ". Error: " + err.Error())
+			multiErr = multierror.Append(multiErr, err)
+		} else {
+			printer.Print("Approved the permissions of plugin: " + plugin)
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
 func pluginListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	pluginsResp, _, err := c.GetPlugins(context.TODO())
 	if err != nil {
@@ -206,6 +206,43 @@ func (s *MmctlUnitTestSuite) TestPluginInstallUrlCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestPluginApprovePermissionsCmd() {
+	s.Run("Approve the permissions of 1 plugin", func() {
+		printer.Clean()
+		pluginArg := "test-plugin"
+
+		s.client.
+			EXPECT().
+			ApprovePluginPermissions(context.TODO(), pluginArg).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := pluginApprovePermissionsCmdF(s.client, &cobra.Command{}, []string{pluginArg})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("Approved the permissions of plugin: "+pluginArg, printer.GetLines()[0])
+	})
+
+	s.Run("Fail to approve the permissions of a plugin", func() {
+		printer.Clean()
+		pluginArg := "fail-plugin"
+		mockErr := errors.New("mock error")
+
+		s.client.
+			EXPECT().
+			ApprovePluginPermissions(context.TODO(), pluginArg).
+			Return(&model.Response{StatusCode: http.StatusNotFound}, mockErr).
+			Times(1)
+
+		err := pluginApprovePermissionsCmdF(s.client, &cobra.Command{}, []string{pluginArg})
+		s.Require().ErrorContains(err, "mock error")
+		s.Require().Len(printer.GetLines(), 0)
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Equal("Unable to approve the permissions of plugin: "+pluginArg+". Error: "+mockErr.Error(), printer.GetErrorLines()[0])
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestPluginDisableCmd() {
 	s.Run("Disable 1 plugin", func() {
 		printer.Clean()
@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const remoteStatusTemplate = `{{.DisplayName}} ({{.RemoteId}}): {{if .Online}}online{{else}}offline{{end}}, lag {{.LagPosts}} posts / {{millisToDuration .LagMillis}}, {{.PendingTasks}} pending tasks, {{.RetryCount}} retries{{if .LastError}}, last error at {{millisToTime .LastErrorAt}}: {{.LastError}}{{end}}
+{{range .Channels}}  {{.ChannelId}}: cursor create {{.LastPostCreateAt}}/{{.LastPostCreateID}} update {{.LastPostUpdateAt}}/{{.LastPostUpdateID}}, lag {{.LagPosts}} posts / {{millisToDuration .LagMillis}}, {{.PendingTasks}} pending tasks, {{.RetryCount}} retries{{if .LastError}}, last error at {{millisToTime .LastErrorAt}}: {{.LastError}}{{end}}
+{{end}}`
+
+var RemoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Management of secure connections",
+}
+
+var RemoteStatusCmd = &cobra.Command{
+	Use:   "status [remote-ids]",
+	Short: "Show the shared channel sync status of secure connections",
+	Long: `Show, per secure connection and per shared channel, the last sync cursor, the lag in posts and time,
+the pending sync tasks, the retry count and the last error. All secure connections are shown if none is given.`,
+	Example: "  remote status 7ptbcmy8xjb3xdrc1ta4p4j3qr",
+	PreRun:  disableLocalPrecheck,
+	RunE:    withClient(remoteStatusCmdF),
+}
+
+var RemoteResyncCmd = &cobra.Command{
+	Use:   "resync [remote-id]",
+	Short: "Resync shared channels with a secure connection",
+	Long: `Rewind the sync cursor of the channels shared with a secure connection so every post created or
+updated since the given time is sent again. All the shared channels are resynced unless one is given.`,
+	Example: `  remote resync 7ptbcmy8xjb3xdrc1ta4p4j3qr --since 2024-10-01T00:00:00+00:00 --channel myteam:mychannel`,
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(1),
+	RunE:    withClient(remoteResyncCmdF),
+}
+
+func init() {
+	RemoteResyncCmd.Flags().String("since", "", "Required. Resync posts created or updated after this time (ISO 8601)")
+	_ = RemoteResyncCmd.MarkFlagRequired("since")
+	RemoteResyncCmd.Flags().String("channel", "", "Optional. Only resync this shared channel")
+
+	RemoteCmd.AddCommand(
+		RemoteStatusCmd,
+		RemoteResyncCmd,
+	)
+
+	RootCmd.AddCommand(RemoteCmd)
+}
+
+func remoteStatusCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var health []*model.RemoteClusterSyncHealth
+	if len(args) == 0 {
+		all, _, err := c.GetRemoteClustersSyncHealth(context.TODO())
+		if err != nil {
+			return errors.Wrap(err, "could not get the sync status of the secure connections")
+		}
+		health = all
+	}
+
+	for _, remoteID := range args {
+		h, _, err := c.GetRemoteClusterSyncHealth(context.TODO(), remoteID)
+		if err != nil {
+			return errors.Wrapf(err, "could not get the sync status of secure connection %q", remoteID)
+		}
+		health = append(health, h)
+	}
+
+	printer.SetTemplateFunc("millisToDuration", func(ms int64) string {
+		return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+	})
+	printer.SetTemplateFunc("millisToTime", func(ms int64) string {
+		return model.GetTimeForMillis(ms).Format(ISO8601Layout)
+	})
+	for _, h := range health {
+		printer.PrintT(remoteStatusTemplate, h)
+	}
+	return nil
+}
+
+func remoteResyncCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetString("since")
+	sinceTime, err := time.Parse(ISO8601Layout, since)
+	if err != nil {
+		return errors.Errorf("invalid since time %q", since)
+	}
+
+	req := &model.SharedChannelResyncRequest{
+		Since: model.GetMillisForTime(sinceTime),
+	}
+
+	if channelArg, _ := cmd.Flags().GetString("channel"); channelArg != "" {
+		channel := getChannelFromChannelArg(c, channelArg)
+		if channel == nil {
+			return errors.Errorf("unable to find channel %q", channelArg)
+		}
+		req.ChannelId = channel.Id
+	}
+
+	if _, err := c.ResyncRemoteCluster(context.TODO(), args[0], req); err != nil {
+		return errors.Wrapf(err, "could not resync secure connection %q", args[0])
+	}
+
+	printer.Print("Resync scheduled for secure connection " + args[0])
+	return nil
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestRemoteStatusCmd() {
+	s.Run("Should show the status of all remotes", func() {
+		printer.Clean()
+
+		health := []*model.RemoteClusterSyncHealth{
+			{RemoteId: model.NewId(), DisplayName: "one", Online: true},
+			{RemoteId: model.NewId(), DisplayName: "two", LagPosts: 3},
+		}
+
+		s.client.
+			EXPECT().
+			GetRemoteClustersSyncHealth(context.TODO()).
+			Return(health, &model.Response{}, nil).
+			Times(1)
+
+		err := remoteStatusCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(health[0], printer.GetLines()[0])
+		s.Require().Equal(health[1], printer.GetLines()[1])
+	})
+
+	s.Run("Should show the status of the given remote", func() {
+		printer.Clean()
+
+		health := &model.RemoteClusterSyncHealth{
+			RemoteId:    model.NewId(),
+			DisplayName: "one",
+			Channels:    []*model.SharedChannelSyncHealth{{ChannelId: model.NewId(), PendingTasks: 1}},
+		}
+
+		s.client.
+			EXPECT().
+			GetRemoteClusterSyncHealth(context.TODO(), health.RemoteId).
+			Return(health, &model.Response{}, nil).
+			Times(1)
+
+		err := remoteStatusCmdF(s.client, &cobra.Command{}, []string{health.RemoteId})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(health, printer.GetLines()[0])
+	})
+
+	s.Run("Should fail if the remote can't be found", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetRemoteClusterSyncHealth(context.TODO(), "missing").
+			Return(nil, &model.Response{}, errors.New("not found")).
+			Times(1)
+
+		err := remoteStatusCmdF(s.client, &cobra.Command{}, []string{"missing"})
+		s.Require().EqualError(err, `could not get the sync status of secure connection "missing": not found`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestRemoteResyncCmd() {
+	remoteID := model.NewId()
+
+	s.Run("Should resync all the shared channels of a remote", func() {
+		printer.Clean()
+
+		since := "2024-10-01T00:00:00+00:00"
+		sinceTime, _ := time.Parse(ISO8601Layout, since)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", since, "")
+		cmd.Flags().String("channel", "", "")
+
+		s.client.
+			EXPECT().
+			ResyncRemoteCluster(context.TODO(), remoteID, &model.SharedChannelResyncRequest{Since: model.GetMillisForTime(sinceTime)}).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := remoteResyncCmdF(s.client, cmd, []string{remoteID})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal("Resync scheduled for secure connection "+remoteID, printer.GetLines()[0])
+	})
+
+	s.Run("Should resync a single shared channel", func() {
+		printer.Clean()
+
+		channel := &model.Channel{Id: model.NewId(), Name: "channel"}
+		since := "2024-10-01T00:00:00+00:00"
+		sinceTime, _ := time.Parse(ISO8601Layout, since)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", since, "")
+		cmd.Flags().String("channel", channel.Id, "")
+
+		s.client.
+			EXPECT().
+			GetChannel(context.TODO(), channel.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			ResyncRemoteCluster(context.TODO(), remoteID, &model.SharedChannelResyncRequest{ChannelId: channel.Id, Since: model.GetMillisForTime(sinceTime)}).
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := remoteResyncCmdF(s.client, cmd, []string{remoteID})
+		s.Require().NoError(err)
+	})
+
+	s.Run("Should fail with an invalid since time", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("since", "yesterday", "")
+		cmd.Flags().String("channel", "", "")
+
+		err := remoteResyncCmdF(s.client, cmd, []string{remoteID})
+		s.Require().EqualError(err, `invalid since time "yesterday"`)
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const sidebarCategoryTemplate = `{{.Id}}: {{.DisplayName}} ({{.Type}}, {{len .Channels}} channels)`
+
+var UserCategoryCmd = &cobra.Command{
+	Use:   "category",
+	Short: "Management of user sidebar categories",
+}
+
+var UserCategoryListCmd = &cobra.Command{
+	Use:     "list [user] [team]",
+	Short:   "List sidebar categories",
+	Long:    "List the sidebar categories of a user in a team, in their display order.",
+	Example: "  user category list john myteam",
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(2),
+	RunE:    withClient(userCategoryListCmdF),
+}
+
+var UserCategoryCreateCmd = &cobra.Command{
+	Use:     "create [user] [team] [display-name]",
+	Short:   "Create a sidebar category",
+	Long:    "Create a custom sidebar category for a user in a team, optionally moving some channels into it.",
+	Example: `  user category create john myteam "Projects" --channel project-a --channel project-b`,
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.ExactArgs(3),
+	RunE:    withClient(userCategoryCreateCmdF),
+}
+
+var UserCategoryAssignCmd = &cobra.Command{
+	Use:   "assign [user] [team] [category] [channels]",
+	Short: "Assign channels to a sidebar category",
+	Long: `Move channels into one of the sidebar categories of a user in a team. The category can be referenced by its ID or its display name.
+The channels are removed from the category they were previously assigned to.`,
+	Example: `  user category assign john myteam "Projects" project-a myteam:project-b`,
+	PreRun:  disableLocalPrecheck,
+	Args:    cobra.MinimumNArgs(4),
+	RunE:    withClient(userCategoryAssignCmdF),
+}
+
+func init() {
+	UserCategoryCreateCmd.Flags().StringSlice("channel", []string{}, "Optional. Channels to move into the new category")
+
+	UserCategoryCmd.AddCommand(
+		UserCategoryListCmd,
+		UserCategoryCreateCmd,
+		UserCategoryAssignCmd,
+	)
+
+	UserCmd.AddCommand(UserCategoryCmd)
+}
+
+func userCategoryListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	user, team, err := getUserAndTeamForCategories(c, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	categories, _, err := c.GetSidebarCategoriesForTeamForUser(context.TODO(), user.Id, team.Id, "")
+	if err != nil {
+		return errors.Wrap(err, "could not get sidebar categories")
+	}
+
+	for _, category := range categories.Categories {
+		printer.PrintT(sidebarCategoryTemplate, category)
+	}
+
+	return nil
+}
+
+func userCategoryCreateCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	user, team, err := getUserAndTeamForCategories(c, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	channelArgs, _ := cmd.Flags().GetStringSlice("channel")
+	channelIDs, err := getChannelIDsForCategory(c, team, channelArgs)
+	if err != nil {
+		return err
+	}
+
+	category, _, err := c.CreateSidebarCategoryForTeamForUser(context.TODO(), user.Id, team.Id, &model.SidebarCategoryWithChannels{
+		SidebarCategory: model.SidebarCategory{
+			UserId:      user.Id,
+			TeamId:      team.Id,
+			DisplayName: args[2],
+			Type:        model.SidebarCategoryCustom,
+		},
+		Channels: channelIDs,
+	})
+	if err != nil {
+		return errors.Wrap(err, "could not create sidebar category")
+	}
+
+	printer.PrintT(sidebarCategoryTemplate, category)
+	return nil
+}
+
+func userCategoryAssignCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	user, team, err := getUserAndTeamForCategories(c, args[0], args[1])
+	if err != nil {
+		return err
+	}
+
+	categories, _, err := c.GetSidebarCategoriesForTeamForUser(context.TODO(), user.Id, team.Id, "")
+	if err != nil {
+		return errors.Wrap(err, "could not get sidebar categories")
+	}
+
+	var category *model.SidebarCategoryWithChannels
+	for _, cat := range categories.Categories {
+		if cat.Id == args[2] || strings.EqualFold(cat.DisplayName, args[2]) {
+			category = cat
+			break
+		}
+	}
+	if category == nil {
+		return errors.Errorf("unable to find sidebar category %q", args[2])
+	}
+
+	channelIDs, err := getChannelIDsForCategory(c, team, args[3:])
+	if err != nil {
+		return err
+	}
+
+	for _, channelID := range channelIDs {
+		if !slices.Contains(category.Channels, channelID) {
+			category.Channels = append(category.Channels, channelID)
+		}
+	}
+
+	updated, _, err := c.UpdateSidebarCategoryForTeamForUser(context.TODO(), user.Id, team.Id, category.Id, category)
+	if err != nil {
+		return errors.Wrap(err, "could not update sidebar category")
+	}
+
+	printer.PrintT(sidebarCategoryTemplate, updated)
+	return nil
+}
+
+func getUserAndTeamForCategories(c client.Client, userArg, teamArg string) (*model.User, *model.Team, error) {
+	user := getUserFromUserArg(c, userArg)
+	if user == nil {
+		return nil, nil, errors.Errorf("unable to find user %q", userArg)
+	}
+
+	team := getTeamFromTeamArg(c, teamArg)
+	if team == nil {
+		return nil, nil, errors.Errorf("unable to find team %q", teamArg)
+	}
+
+	return user, team, nil
+}
+
+// getChannelIDsForCategory resolves channel arguments that can omit
+// the team part, in which case the category's team is used
+func getChannelIDsForCategory(c client.Client, team *model.Team, channelArgs []string) ([]string, error) {
+	var result *multierror.Error
+	channelIDs := make([]string, 0, len(channelArgs))
+	for _, channelArg := range channelArgs {
+		arg := channelArg
+		if !strings.Contains(arg, channelArgSeparator) {
+			arg = team.Id + channelArgSeparator + arg
+		}
+
+		channel := getChannelFromChannelArg(c, arg)
+		if channel == nil {
+			result = multierror.Append(result, errors.Errorf("unable to find channel %q", channelArg))
+			continue
+		}
+		channelIDs = append(channelIDs, channel.Id)
+	}
+
+	return channelIDs, result.ErrorOrNil()
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"slices"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlE2ETestSuite) TestUserCategoryCmds() {
+	s.SetupTestHelper().InitBasic()
+
+	userArg := s.th.BasicUser.Username
+	teamArg := s.th.BasicTeam.Name
+
+	s.Run("Create a category and assign channels to it", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("channel", []string{s.th.BasicChannel.Name}, "")
+
+		err := userCategoryCreateCmdF(s.th.SystemAdminClient, cmd, []string{userArg, teamArg, "Projects"})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		category := printer.GetLines()[0].(*model.SidebarCategoryWithChannels)
+		s.Require().Equal([]string{s.th.BasicChannel.Id}, category.Channels)
+
+		printer.Clean()
+		err = userCategoryAssignCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{userArg, teamArg, "Projects", s.th.BasicChannel2.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		category = printer.GetLines()[0].(*model.SidebarCategoryWithChannels)
+		s.Require().True(slices.Contains(category.Channels, s.th.BasicChannel2.Id))
+
+		printer.Clean()
+		err = userCategoryListCmdF(s.th.SystemAdminClient, &cobra.Command{}, []string{userArg, teamArg})
+		s.Require().NoError(err)
+
+		found := false
+		for _, line := range printer.GetLines() {
+			if line.(*model.SidebarCategoryWithChannels).DisplayName == "Projects" {
+				found = true
+			}
+		}
+		s.Require().True(found)
+	})
+
+	s.Run("A user can't list the categories of another user", func() {
+		printer.Clean()
+
+		err := userCategoryListCmdF(s.th.Client, &cobra.Command{}, []string{s.th.BasicUser2.Username, teamArg})
+		s.Require().Error(err)
+	})
+}
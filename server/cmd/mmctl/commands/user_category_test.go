@@ -0,0 +1,162 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package commands
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/spf13/cobra"
+
+	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
+)
+
+func (s *MmctlUnitTestSuite) TestUserCategoryListCmd() {
+	user := &model.User{Id: model.NewId(), Username: "john"}
+	team := &model.Team{Id: model.NewId(), Name: "team"}
+
+	s.Run("Should list the categories of a user", func() {
+		printer.Clean()
+
+		categories := &model.OrderedSidebarCategories{
+			Categories: model.SidebarCategoriesWithChannels{
+				{SidebarCategory: model.SidebarCategory{Id: model.NewId(), DisplayName: "Favorites", Type: model.SidebarCategoryFavorites}},
+				{SidebarCategory: model.SidebarCategory{Id: model.NewId(), DisplayName: "Projects", Type: model.SidebarCategoryCustom}, Channels: []string{model.NewId()}},
+			},
+		}
+
+		s.client.EXPECT().GetUserByUsername(context.TODO(), user.Username, "").Return(user, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetTeam(context.TODO(), team.Name, "").Return(nil, &model.Response{}, errors.New("not found")).Times(1)
+		s.client.EXPECT().GetTeamByName(context.TODO(), team.Name, "").Return(team, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			GetSidebarCategoriesForTeamForUser(context.TODO(), user.Id, team.Id, "").
+			Return(categories, &model.Response{}, nil).
+			Times(1)
+
+		err := userCategoryListCmdF(s.client, &cobra.Command{}, []string{user.Username, team.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(categories.Categories[0], printer.GetLines()[0])
+		s.Require().Equal(categories.Categories[1], printer.GetLines()[1])
+	})
+
+	s.Run("Should fail if the user doesn't exist", func() {
+		printer.Clean()
+
+		s.client.EXPECT().GetUserByUsername(context.TODO(), "missing", "").Return(nil, &model.Response{}, errors.New("not found")).Times(1)
+		s.client.EXPECT().GetUser(context.TODO(), "missing", "").Return(nil, &model.Response{}, errors.New("not found")).Times(1)
+
+		err := userCategoryListCmdF(s.client, &cobra.Command{}, []string{"missing", team.Name})
+		s.Require().EqualError(err, `unable to find user "missing"`)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestUserCategoryCreateCmd() {
+	user := &model.User{Id: model.NewId(), Username: "john"}
+	team := &model.Team{Id: model.NewId(), Name: "team"}
+	channel := &model.Channel{Id: model.NewId(), Name: "project-a", TeamId: team.Id}
+
+	s.Run("Should create a category with channels", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringSlice("channel", []string{channel.Name}, "")
+
+		expected := &model.SidebarCategoryWithChannels{
+			SidebarCategory: model.SidebarCategory{
+				UserId:      user.Id,
+				TeamId:      team.Id,
+				DisplayName: "Projects",
+				Type:        model.SidebarCategoryCustom,
+			},
+			Channels: []string{channel.Id},
+		}
+		created := &model.SidebarCategoryWithChannels{SidebarCategory: expected.SidebarCategory, Channels: expected.Channels}
+		created.Id = model.NewId()
+
+		s.client.EXPECT().GetUserByUsername(context.TODO(), user.Username, "").Return(user, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetTeam(context.TODO(), team.Id, "").Return(team, &model.Response{}, nil).Times(2)
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), channel.Name, team.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			CreateSidebarCategoryForTeamForUser(context.TODO(), user.Id, team.Id, expected).
+			Return(created, &model.Response{}, nil).
+			Times(1)
+
+		err := userCategoryCreateCmdF(s.client, cmd, []string{user.Username, team.Id, "Projects"})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(created, printer.GetLines()[0])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestUserCategoryAssignCmd() {
+	user := &model.User{Id: model.NewId(), Username: "john"}
+	team := &model.Team{Id: model.NewId(), Name: "team"}
+	channel := &model.Channel{Id: model.NewId(), Name: "project-b", TeamId: team.Id}
+	existingChannelID := model.NewId()
+
+	newCategories := func() *model.OrderedSidebarCategories {
+		return &model.OrderedSidebarCategories{
+			Categories: model.SidebarCategoriesWithChannels{
+				{SidebarCategory: model.SidebarCategory{Id: model.NewId(), DisplayName: "Channels", Type: model.SidebarCategoryChannels}},
+				{SidebarCategory: model.SidebarCategory{Id: model.NewId(), DisplayName: "Projects", Type: model.SidebarCategoryCustom}, Channels: []string{existingChannelID}},
+			},
+		}
+	}
+
+	s.Run("Should add the channels to the category found by name", func() {
+		printer.Clean()
+		categories := newCategories()
+		category := categories.Categories[1]
+
+		s.client.EXPECT().GetUserByUsername(context.TODO(), user.Username, "").Return(user, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetTeam(context.TODO(), team.Id, "").Return(team, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			GetSidebarCategoriesForTeamForUser(context.TODO(), user.Id, team.Id, "").
+			Return(categories, &model.Response{}, nil).
+			Times(1)
+		s.client.EXPECT().GetTeam(context.TODO(), team.Name, "").Return(nil, &model.Response{}, errors.New("not found")).Times(1)
+		s.client.EXPECT().GetTeamByName(context.TODO(), team.Name, "").Return(team, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			GetChannelByNameIncludeDeleted(context.TODO(), channel.Name, team.Id, "").
+			Return(channel, &model.Response{}, nil).
+			Times(1)
+		s.client.
+			EXPECT().
+			UpdateSidebarCategoryForTeamForUser(context.TODO(), user.Id, team.Id, category.Id, &model.SidebarCategoryWithChannels{
+				SidebarCategory: category.SidebarCategory,
+				Channels:        []string{existingChannelID, channel.Id},
+			}).
+			Return(category, &model.Response{}, nil).
+			Times(1)
+
+		err := userCategoryAssignCmdF(s.client, &cobra.Command{}, []string{user.Username, team.Id, "projects", team.Name + ":" + channel.Name})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Should fail if the category doesn't exist", func() {
+		printer.Clean()
+
+		s.client.EXPECT().GetUserByUsername(context.TODO(), user.Username, "").Return(user, &model.Response{}, nil).Times(1)
+		s.client.EXPECT().GetTeam(context.TODO(), team.Id, "").Return(team, &model.Response{}, nil).Times(1)
+		s.client.
+			EXPECT().
+			GetSidebarCategoriesForTeamForUser(context.TODO(), user.Id, team.Id, "").
+			Return(newCategories(), &model.Response{}, nil).
+			Times(1)
+
+		err := userCategoryAssignCmdF(s.client, &cobra.Command{}, []string{user.Username, team.Id, "Unknown", channel.Id})
+		s.Require().EqualError(err, `unable to find sidebar category "Unknown"`)
+	})
+}
@@ -72,6 +72,21 @@ func (mr *MockClientMockRecorder) AddTeamMember(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMember", reflect.TypeOf((*MockClient)(nil).AddTeamMember), arg0, arg1, arg2)
 }
 
+// ApprovePluginPermissions mocks base method.
+func (m *MockClient) ApprovePluginPermissions(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApprovePluginPermissions", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApprovePluginPermissions indicates an expected call of ApprovePluginPermissions.
+func (mr *MockClientMockRecorder) ApprovePluginPermissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApprovePluginPermissions", reflect.TypeOf((*MockClient)(nil).ApprovePluginPermissions), arg0, arg1)
+}
+
 // AssignBot mocks base method.
 func (m *MockClient) AssignBot(arg0 context.Context, arg1, arg2 string) (*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -198,6 +213,22 @@ func (mr *MockClientMockRecorder) CreateChannel(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannel", reflect.TypeOf((*MockClient)(nil).CreateChannel), arg0, arg1)
 }
 
+// CreateChannelBookmark mocks base method.
+func (m *MockClient) CreateChannelBookmark(arg0 context.Context, arg1 *model.ChannelBookmark) (*model.ChannelBookmarkWithFileInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChannelBookmark", arg0, arg1)
+	ret0, _ := ret[0].(*model.ChannelBookmarkWithFileInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateChannelBookmark indicates an expected call of CreateChannelBookmark.
+func (mr *MockClientMockRecorder) CreateChannelBookmark(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChannelBookmark", reflect.TypeOf((*MockClient)(nil).CreateChannelBookmark), arg0, arg1)
+}
+
 // CreateCommand mocks base method.
 func (m *MockClient) CreateCommand(arg0 context.Context, arg1 *model.Command) (*model.Command, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -214,6 +245,22 @@ func (mr *MockClientMockRecorder) CreateCommand(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCommand", reflect.TypeOf((*MockClient)(nil).CreateCommand), arg0, arg1)
 }
 
+// CreateEmoji mocks base method.
+func (m *MockClient) CreateEmoji(arg0 context.Context, arg1 *model.Emoji, arg2 []byte, arg3 string) (*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmoji", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateEmoji indicates an expected call of CreateEmoji.
+func (mr *MockClientMockRecorder) CreateEmoji(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmoji", reflect.TypeOf((*MockClient)(nil).CreateEmoji), arg0, arg1, arg2, arg3)
+}
+
 // CreateIncomingWebhook mocks base method.
 func (m *MockClient) CreateIncomingWebhook(arg0 context.Context, arg1 *model.IncomingWebhook) (*model.IncomingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -246,6 +293,22 @@ func (mr *MockClientMockRecorder) CreateJob(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateJob", reflect.TypeOf((*MockClient)(nil).CreateJob), arg0, arg1)
 }
 
+// CreateLegalHold mocks base method.
+func (m *MockClient) CreateLegalHold(arg0 context.Context, arg1 *model.LegalHold) (*model.LegalHold, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(*model.LegalHold)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateLegalHold indicates an expected call of CreateLegalHold.
+func (mr *MockClientMockRecorder) CreateLegalHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLegalHold", reflect.TypeOf((*MockClient)(nil).CreateLegalHold), arg0, arg1)
+}
+
 // CreateOutgoingWebhook mocks base method.
 func (m *MockClient) CreateOutgoingWebhook(arg0 context.Context, arg1 *model.OutgoingWebhook) (*model.OutgoingWebhook, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -278,6 +341,22 @@ func (mr *MockClientMockRecorder) CreatePost(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePost", reflect.TypeOf((*MockClient)(nil).CreatePost), arg0, arg1)
 }
 
+// CreateSidebarCategoryForTeamForUser mocks base method.
+func (m *MockClient) CreateSidebarCategoryForTeamForUser(arg0 context.Context, arg1, arg2 string, arg3 *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSidebarCategoryForTeamForUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.SidebarCategoryWithChannels)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateSidebarCategoryForTeamForUser indicates an expected call of CreateSidebarCategoryForTeamForUser.
+func (mr *MockClientMockRecorder) CreateSidebarCategoryForTeamForUser(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSidebarCategoryForTeamForUser", reflect.TypeOf((*MockClient)(nil).CreateSidebarCategoryForTeamForUser), arg0, arg1, arg2, arg3)
+}
+
 // CreateTeam mocks base method.
 func (m *MockClient) CreateTeam(arg0 context.Context, arg1 *model.Team) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -357,6 +436,22 @@ func (mr *MockClientMockRecorder) DeleteChannel(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannel", reflect.TypeOf((*MockClient)(nil).DeleteChannel), arg0, arg1)
 }
 
+// DeleteChannelBookmark mocks base method.
+func (m *MockClient) DeleteChannelBookmark(arg0 context.Context, arg1, arg2 string) (*model.ChannelBookmarkWithFileInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChannelBookmark", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.ChannelBookmarkWithFileInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteChannelBookmark indicates an expected call of DeleteChannelBookmark.
+func (mr *MockClientMockRecorder) DeleteChannelBookmark(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChannelBookmark", reflect.TypeOf((*MockClient)(nil).DeleteChannelBookmark), arg0, arg1, arg2)
+}
+
 // DeleteCommand mocks base method.
 func (m *MockClient) DeleteCommand(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -372,6 +467,21 @@ func (mr *MockClientMockRecorder) DeleteCommand(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommand", reflect.TypeOf((*MockClient)(nil).DeleteCommand), arg0, arg1)
 }
 
+// DeleteEmoji mocks base method.
+func (m *MockClient) DeleteEmoji(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmoji", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEmoji indicates an expected call of DeleteEmoji.
+func (mr *MockClientMockRecorder) DeleteEmoji(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmoji", reflect.TypeOf((*MockClient)(nil).DeleteEmoji), arg0, arg1)
+}
+
 // DeleteExport mocks base method.
 func (m *MockClient) DeleteExport(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -585,6 +695,22 @@ func (mr *MockClientMockRecorder) EnablePlugin(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePlugin", reflect.TypeOf((*MockClient)(nil).EnablePlugin), arg0, arg1)
 }
 
+// ExportLegalHold mocks base method.
+func (m *MockClient) ExportLegalHold(arg0 context.Context, arg1 string, arg2 string) (*model.Job, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportLegalHold", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Job)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ExportLegalHold indicates an expected call of ExportLegalHold.
+func (mr *MockClientMockRecorder) ExportLegalHold(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportLegalHold", reflect.TypeOf((*MockClient)(nil).ExportLegalHold), arg0, arg1, arg2)
+}
+
 // GeneratePresignedURL mocks base method.
 func (m *MockClient) GeneratePresignedURL(arg0 context.Context, arg1 string) (*model.PresignURLResponse, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -826,6 +952,38 @@ func (mr *MockClientMockRecorder) GetDeletedChannelsForTeam(arg0, arg1, arg2, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedChannelsForTeam", reflect.TypeOf((*MockClient)(nil).GetDeletedChannelsForTeam), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetEmojiByName mocks base method.
+func (m *MockClient) GetEmojiByName(arg0 context.Context, arg1 string) (*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmojiByName", arg0, arg1)
+	ret0, _ := ret[0].(*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmojiByName indicates an expected call of GetEmojiByName.
+func (mr *MockClientMockRecorder) GetEmojiByName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmojiByName", reflect.TypeOf((*MockClient)(nil).GetEmojiByName), arg0, arg1)
+}
+
+// GetEmojiImage mocks base method.
+func (m *MockClient) GetEmojiImage(arg0 context.Context, arg1 string) ([]byte, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmojiImage", arg0, arg1)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEmojiImage indicates an expected call of GetEmojiImage.
+func (mr *MockClientMockRecorder) GetEmojiImage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmojiImage", reflect.TypeOf((*MockClient)(nil).GetEmojiImage), arg0, arg1)
+}
+
 // GetGroupsByChannel mocks base method.
 func (m *MockClient) GetGroupsByChannel(arg0 context.Context, arg1 string, arg2 model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -972,6 +1130,38 @@ func (mr *MockClientMockRecorder) GetLdapGroups(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLdapGroups", reflect.TypeOf((*MockClient)(nil).GetLdapGroups), arg0)
 }
 
+// GetLegalHold mocks base method.
+func (m *MockClient) GetLegalHold(arg0 context.Context, arg1 string) (*model.LegalHold, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(*model.LegalHold)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLegalHold indicates an expected call of GetLegalHold.
+func (mr *MockClientMockRecorder) GetLegalHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegalHold", reflect.TypeOf((*MockClient)(nil).GetLegalHold), arg0, arg1)
+}
+
+// GetLegalHolds mocks base method.
+func (m *MockClient) GetLegalHolds(arg0 context.Context, arg1 int, arg2 int) ([]*model.LegalHold, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLegalHolds", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.LegalHold)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetLegalHolds indicates an expected call of GetLegalHolds.
+func (mr *MockClientMockRecorder) GetLegalHolds(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLegalHolds", reflect.TypeOf((*MockClient)(nil).GetLegalHolds), arg0, arg1, arg2)
+}
+
 // GetLogs mocks base method.
 func (m *MockClient) GetLogs(arg0 context.Context, arg1, arg2 int) ([]string, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1004,6 +1194,22 @@ func (mr *MockClientMockRecorder) GetMarketplacePlugins(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketplacePlugins", reflect.TypeOf((*MockClient)(nil).GetMarketplacePlugins), arg0, arg1)
 }
 
+// GetMe mocks base method.
+func (m *MockClient) GetMe(arg0 context.Context, arg1 string) (*model.User, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMe", arg0, arg1)
+	ret0, _ := ret[0].(*model.User)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMe indicates an expected call of GetMe.
+func (mr *MockClientMockRecorder) GetMe(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMe", reflect.TypeOf((*MockClient)(nil).GetMe), arg0, arg1)
+}
+
 // GetOAuthApps mocks base method.
 func (m *MockClient) GetOAuthApps(arg0 context.Context, arg1, arg2 int) ([]*model.OAuthApp, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1292,6 +1498,38 @@ func (mr *MockClientMockRecorder) GetPublicChannelsForTeam(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicChannelsForTeam", reflect.TypeOf((*MockClient)(nil).GetPublicChannelsForTeam), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetRemoteClusterSyncHealth mocks base method.
+func (m *MockClient) GetRemoteClusterSyncHealth(arg0 context.Context, arg1 string) (*model.RemoteClusterSyncHealth, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemoteClusterSyncHealth", arg0, arg1)
+	ret0, _ := ret[0].(*model.RemoteClusterSyncHealth)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRemoteClusterSyncHealth indicates an expected call of GetRemoteClusterSyncHealth.
+func (mr *MockClientMockRecorder) GetRemoteClusterSyncHealth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteClusterSyncHealth", reflect.TypeOf((*MockClient)(nil).GetRemoteClusterSyncHealth), arg0, arg1)
+}
+
+// GetRemoteClustersSyncHealth mocks base method.
+func (m *MockClient) GetRemoteClustersSyncHealth(arg0 context.Context) ([]*model.RemoteClusterSyncHealth, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRemoteClustersSyncHealth", arg0)
+	ret0, _ := ret[0].([]*model.RemoteClusterSyncHealth)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetRemoteClustersSyncHealth indicates an expected call of GetRemoteClustersSyncHealth.
+func (mr *MockClientMockRecorder) GetRemoteClustersSyncHealth(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemoteClustersSyncHealth", reflect.TypeOf((*MockClient)(nil).GetRemoteClustersSyncHealth), arg0)
+}
+
 // GetRoleByName mocks base method.
 func (m *MockClient) GetRoleByName(arg0 context.Context, arg1 string) (*model.Role, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1324,6 +1562,38 @@ func (mr *MockClientMockRecorder) GetServerBusy(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServerBusy", reflect.TypeOf((*MockClient)(nil).GetServerBusy), arg0)
 }
 
+// GetSidebarCategoriesForTeamForUser mocks base method.
+func (m *MockClient) GetSidebarCategoriesForTeamForUser(arg0 context.Context, arg1, arg2, arg3 string) (*model.OrderedSidebarCategories, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSidebarCategoriesForTeamForUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.OrderedSidebarCategories)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSidebarCategoriesForTeamForUser indicates an expected call of GetSidebarCategoriesForTeamForUser.
+func (mr *MockClientMockRecorder) GetSidebarCategoriesForTeamForUser(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSidebarCategoriesForTeamForUser", reflect.TypeOf((*MockClient)(nil).GetSidebarCategoriesForTeamForUser), arg0, arg1, arg2, arg3)
+}
+
+// GetSortedEmojiList mocks base method.
+func (m *MockClient) GetSortedEmojiList(arg0 context.Context, arg1, arg2 int, arg3 string) ([]*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSortedEmojiList", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSortedEmojiList indicates an expected call of GetSortedEmojiList.
+func (mr *MockClientMockRecorder) GetSortedEmojiList(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSortedEmojiList", reflect.TypeOf((*MockClient)(nil).GetSortedEmojiList), arg0, arg1, arg2, arg3)
+}
+
 // GetTeam mocks base method.
 func (m *MockClient) GetTeam(arg0 context.Context, arg1, arg2 string) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1356,6 +1626,22 @@ func (mr *MockClientMockRecorder) GetTeamByName(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamByName", reflect.TypeOf((*MockClient)(nil).GetTeamByName), arg0, arg1, arg2)
 }
 
+// GetTeamMembers mocks base method.
+func (m *MockClient) GetTeamMembers(arg0 context.Context, arg1 string, arg2, arg3 int, arg4 string) ([]*model.TeamMember, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTeamMembers", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*model.TeamMember)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTeamMembers indicates an expected call of GetTeamMembers.
+func (mr *MockClientMockRecorder) GetTeamMembers(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamMembers", reflect.TypeOf((*MockClient)(nil).GetTeamMembers), arg0, arg1, arg2, arg3, arg4)
+}
+
 // GetUpload mocks base method.
 func (m *MockClient) GetUpload(arg0 context.Context, arg1 string) (*model.UploadSession, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1563,6 +1849,22 @@ func (mr *MockClientMockRecorder) InviteUsersToTeam(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteUsersToTeam", reflect.TypeOf((*MockClient)(nil).InviteUsersToTeam), arg0, arg1, arg2)
 }
 
+// ListChannelBookmarksForChannel mocks base method.
+func (m *MockClient) ListChannelBookmarksForChannel(arg0 context.Context, arg1 string, arg2 int64) ([]*model.ChannelBookmarkWithFileInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListChannelBookmarksForChannel", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.ChannelBookmarkWithFileInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListChannelBookmarksForChannel indicates an expected call of ListChannelBookmarksForChannel.
+func (mr *MockClientMockRecorder) ListChannelBookmarksForChannel(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListChannelBookmarksForChannel", reflect.TypeOf((*MockClient)(nil).ListChannelBookmarksForChannel), arg0, arg1, arg2)
+}
+
 // ListCommands mocks base method.
 func (m *MockClient) ListCommands(arg0 context.Context, arg1 string, arg2 bool) ([]*model.Command, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1750,6 +2052,22 @@ func (mr *MockClientMockRecorder) PatchConfig(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchConfig", reflect.TypeOf((*MockClient)(nil).PatchConfig), arg0, arg1)
 }
 
+// PatchLegalHold mocks base method.
+func (m *MockClient) PatchLegalHold(arg0 context.Context, arg1 string, arg2 *model.LegalHoldPatch) (*model.LegalHold, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchLegalHold", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.LegalHold)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PatchLegalHold indicates an expected call of PatchLegalHold.
+func (mr *MockClientMockRecorder) PatchLegalHold(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchLegalHold", reflect.TypeOf((*MockClient)(nil).PatchLegalHold), arg0, arg1, arg2)
+}
+
 // PatchRole mocks base method.
 func (m *MockClient) PatchRole(arg0 context.Context, arg1 string, arg2 *model.RolePatch) (*model.Role, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1888,6 +2206,21 @@ func (mr *MockClientMockRecorder) RegenOutgoingHookToken(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenOutgoingHookToken", reflect.TypeOf((*MockClient)(nil).RegenOutgoingHookToken), arg0, arg1)
 }
 
+// ReleaseLegalHold mocks base method.
+func (m *MockClient) ReleaseLegalHold(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLegalHold", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseLegalHold indicates an expected call of ReleaseLegalHold.
+func (mr *MockClientMockRecorder) ReleaseLegalHold(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLegalHold", reflect.TypeOf((*MockClient)(nil).ReleaseLegalHold), arg0, arg1)
+}
+
 // ReloadConfig mocks base method.
 func (m *MockClient) ReloadConfig(arg0 context.Context) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2027,6 +2360,21 @@ func (mr *MockClientMockRecorder) RestoreTeam(arg0, arg1 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreTeam", reflect.TypeOf((*MockClient)(nil).RestoreTeam), arg0, arg1)
 }
 
+// ResyncRemoteCluster mocks base method.
+func (m *MockClient) ResyncRemoteCluster(arg0 context.Context, arg1 string, arg2 *model.SharedChannelResyncRequest) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResyncRemoteCluster", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResyncRemoteCluster indicates an expected call of ResyncRemoteCluster.
+func (mr *MockClientMockRecorder) ResyncRemoteCluster(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResyncRemoteCluster", reflect.TypeOf((*MockClient)(nil).ResyncRemoteCluster), arg0, arg1, arg2)
+}
+
 // RevokeUserAccessToken mocks base method.
 func (m *MockClient) RevokeUserAccessToken(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2042,6 +2390,22 @@ func (mr *MockClientMockRecorder) RevokeUserAccessToken(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeUserAccessToken", reflect.TypeOf((*MockClient)(nil).RevokeUserAccessToken), arg0, arg1)
 }
 
+// SearchEmoji mocks base method.
+func (m *MockClient) SearchEmoji(arg0 context.Context, arg1 *model.EmojiSearch) ([]*model.Emoji, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchEmoji", arg0, arg1)
+	ret0, _ := ret[0].([]*model.Emoji)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchEmoji indicates an expected call of SearchEmoji.
+func (mr *MockClientMockRecorder) SearchEmoji(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchEmoji", reflect.TypeOf((*MockClient)(nil).SearchEmoji), arg0, arg1)
+}
+
 // SearchTeams mocks base method.
 func (m *MockClient) SearchTeams(arg0 context.Context, arg1 *model.TeamSearch) ([]*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2118,6 +2482,53 @@ func (mr *MockClientMockRecorder) SyncLdap(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncLdap", reflect.TypeOf((*MockClient)(nil).SyncLdap), arg0)
 }
 
+// UpdateChannelBookmark mocks base method.
+func (m *MockClient) UpdateChannelBookmark(arg0 context.Context, arg1, arg2 string, arg3 *model.ChannelBookmarkPatch) (*model.UpdateChannelBookmarkResponse, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelBookmark", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.UpdateChannelBookmarkResponse)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateChannelBookmark indicates an expected call of UpdateChannelBookmark.
+func (mr *MockClientMockRecorder) UpdateChannelBookmark(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelBookmark", reflect.TypeOf((*MockClient)(nil).UpdateChannelBookmark), arg0, arg1, arg2, arg3)
+}
+
+// UpdateChannelBookmarkSortOrder mocks base method.
+func (m *MockClient) UpdateChannelBookmarkSortOrder(arg0 context.Context, arg1, arg2 string, arg3 int64) ([]*model.ChannelBookmarkWithFileInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelBookmarkSortOrder", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*model.ChannelBookmarkWithFileInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateChannelBookmarkSortOrder indicates an expected call of UpdateChannelBookmarkSortOrder.
+func (mr *MockClientMockRecorder) UpdateChannelBookmarkSortOrder(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelBookmarkSortOrder", reflect.TypeOf((*MockClient)(nil).UpdateChannelBookmarkSortOrder), arg0, arg1, arg2, arg3)
+}
+
+// UpdateChannelMemberSchemeRoles mocks base method.
+func (m *MockClient) UpdateChannelMemberSchemeRoles(arg0 context.Context, arg1, arg2 string, arg3 *model.SchemeRoles) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateChannelMemberSchemeRoles", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateChannelMemberSchemeRoles indicates an expected call of UpdateChannelMemberSchemeRoles.
+func (mr *MockClientMockRecorder) UpdateChannelMemberSchemeRoles(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateChannelMemberSchemeRoles", reflect.TypeOf((*MockClient)(nil).UpdateChannelMemberSchemeRoles), arg0, arg1, arg2, arg3)
+}
+
 // UpdateChannelPrivacy mocks base method.
 func (m *MockClient) UpdateChannelPrivacy(arg0 context.Context, arg1 string, arg2 model.ChannelType) (*model.Channel, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2228,6 +2639,22 @@ func (mr *MockClientMockRecorder) UpdatePreferences(arg0, arg1, arg2 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockClient)(nil).UpdatePreferences), arg0, arg1, arg2)
 }
 
+// UpdateSidebarCategoryForTeamForUser mocks base method.
+func (m *MockClient) UpdateSidebarCategoryForTeamForUser(arg0 context.Context, arg1, arg2, arg3 string, arg4 *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSidebarCategoryForTeamForUser", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*model.SidebarCategoryWithChannels)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// UpdateSidebarCategoryForTeamForUser indicates an expected call of UpdateSidebarCategoryForTeamForUser.
+func (mr *MockClientMockRecorder) UpdateSidebarCategoryForTeamForUser(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSidebarCategoryForTeamForUser", reflect.TypeOf((*MockClient)(nil).UpdateSidebarCategoryForTeamForUser), arg0, arg1, arg2, arg3, arg4)
+}
+
 // UpdateTeam mocks base method.
 func (m *MockClient) UpdateTeam(arg0 context.Context, arg1 *model.Team) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2244,6 +2671,21 @@ func (mr *MockClientMockRecorder) UpdateTeam(arg0, arg1 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeam", reflect.TypeOf((*MockClient)(nil).UpdateTeam), arg0, arg1)
 }
 
+// UpdateTeamMemberSchemeRoles mocks base method.
+func (m *MockClient) UpdateTeamMemberSchemeRoles(arg0 context.Context, arg1, arg2 string, arg3 *model.SchemeRoles) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTeamMemberSchemeRoles", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTeamMemberSchemeRoles indicates an expected call of UpdateTeamMemberSchemeRoles.
+func (mr *MockClientMockRecorder) UpdateTeamMemberSchemeRoles(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTeamMemberSchemeRoles", reflect.TypeOf((*MockClient)(nil).UpdateTeamMemberSchemeRoles), arg0, arg1, arg2, arg3)
+}
+
 // UpdateTeamPrivacy mocks base method.
 func (m *MockClient) UpdateTeamPrivacy(arg0 context.Context, arg1, arg2 string) (*model.Team, *model.Response, error) {
 	m.ctrl.T.Helper()
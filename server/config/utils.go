@@ -81,10 +81,18 @@ func desanitize(actual, target *model.Config) {
 		}
 	}
 
+	if *target.SemanticSearchSettings.EmbeddingAPIKey == model.FakeSetting {
+		*target.SemanticSearchSettings.EmbeddingAPIKey = *actual.SemanticSearchSettings.EmbeddingAPIKey
+	}
+
 	if *target.MessageExportSettings.GlobalRelaySettings.SMTPPassword == model.FakeSetting {
 		*target.MessageExportSettings.GlobalRelaySettings.SMTPPassword = *actual.MessageExportSettings.GlobalRelaySettings.SMTPPassword
 	}
 
+	if *target.MessageExportSettings.JsonlSettings.SigningKey == model.FakeSetting {
+		*target.MessageExportSettings.JsonlSettings.SigningKey = *actual.MessageExportSettings.JsonlSettings.SigningKey
+	}
+
 	if *target.ServiceSettings.SplitKey == model.FakeSetting {
 		*target.ServiceSettings.SplitKey = *actual.ServiceSettings.SplitKey
 	}
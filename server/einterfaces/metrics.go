@@ -14,6 +14,7 @@ type MetricsInterface interface {
 	Register()
 	RegisterDBCollector(db *sql.DB, name string)
 	UnregisterDBCollector(db *sql.DB, name string)
+	RegisterCacheStatsCollector(getStats func() []*model.CacheStats)
 
 	IncrementPostCreate()
 	IncrementWebhookPost()
@@ -49,6 +50,7 @@ type MetricsInterface interface {
 	IncrementWebSocketBroadcastUsersRegistered(hub string, amount float64)
 	DecrementWebSocketBroadcastUsersRegistered(hub string, amount float64)
 	IncrementWebsocketReconnectEventWithDisconnectErrCode(eventType string, disconnectErrCode string)
+	AddWebSocketFilteredEvent(eventType model.WebsocketEventType, bytes float64)
 
 	IncrementHTTPWebSockets(originClient string)
 	DecrementHTTPWebSockets(originClient string)
@@ -28,6 +28,11 @@ func (_m *MetricsInterface) AddMemCacheMissCounter(cacheName string, amount floa
 	_m.Called(cacheName, amount)
 }
 
+// AddWebSocketFilteredEvent provides a mock function with given fields: eventType, bytes
+func (_m *MetricsInterface) AddWebSocketFilteredEvent(eventType model.WebsocketEventType, bytes float64) {
+	_m.Called(eventType, bytes)
+}
+
 // ClearMobileClientSessionMetadata provides a mock function with no fields
 func (_m *MetricsInterface) ClearMobileClientSessionMetadata() {
 	_m.Called()
@@ -558,6 +563,11 @@ func (_m *MetricsInterface) Register() {
 	_m.Called()
 }
 
+// RegisterCacheStatsCollector provides a mock function with given fields: getStats
+func (_m *MetricsInterface) RegisterCacheStatsCollector(getStats func() []*model.CacheStats) {
+	_m.Called(getStats)
+}
+
 // RegisterDBCollector provides a mock function with given fields: db, name
 func (_m *MetricsInterface) RegisterDBCollector(db *sql.DB, name string) {
 	_m.Called(db, name)
@@ -0,0 +1,556 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package eml_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/enterprise/internal/file"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	ChannelIDHeader   = "X-Mattermost-ChannelID"
+	ChannelNameHeader = "X-Mattermost-ChannelName"
+	ChannelTypeHeader = "X-Mattermost-ChannelType"
+	TeamIDHeader      = "X-Mattermost-TeamID"
+	PostIDHeader      = "X-Mattermost-PostID"
+	UpdateTypeHeader  = "X-Mattermost-UpdateType"
+
+	// MessageIDDomain is the right-hand side of the Message-ID of every exported message.
+	MessageIDDomain = "mattermost"
+
+	// FallbackFromAddress is used as the sender of a conversation without any participant.
+	FallbackFromAddress = "compliance-export@mattermost.invalid"
+
+	EMLWarningFilename = "warning.txt"
+
+	// base64 encoded attachments are wrapped at 76 characters, as required by RFC 2045.
+	base64LineLength = 76
+)
+
+// conversationEntry is a single line of a conversation transcript.
+type conversationEntry struct {
+	time   int64
+	postId string
+	text   string
+}
+
+// EmlExport writes the batch as a zip containing either one .eml file per channel conversation
+// (ComplianceExportTypeEml) or one mbox file per channel holding a message per post
+// (ComplianceExportTypeMbox). Attachments uploaded during the batch are embedded as MIME parts.
+func EmlExport(rctx request.CTX, p shared.ExportParams) (shared.RunExportResults, error) {
+	exportData, err := shared.GetGenericExportData(p)
+	results := exportData.Results
+	if err != nil {
+		return results, err
+	}
+
+	// channels come out of GetGenericExportData in map order, sort them so the zip is stable.
+	exports := exportData.Exports
+	slices.SortFunc(exports, func(a, b shared.ChannelExport) int {
+		if a.ChannelName == b.ChannelName {
+			return strings.Compare(a.ChannelId, b.ChannelId)
+		}
+		return strings.Compare(a.ChannelName, b.ChannelName)
+	})
+
+	temp, err := os.CreateTemp("", "compliance-export-batch-*.zip")
+	if err != nil {
+		return results, fmt.Errorf("unable to create temporary EML export file: %w", err)
+	}
+	defer file.DeleteTemp(rctx.Logger(), temp)
+
+	zipFile := zip.NewWriter(temp)
+
+	var missingFiles []string
+	for _, channel := range exports {
+		name := fmt.Sprintf("%s - (%s)", channel.ChannelName, channel.ChannelId)
+
+		var missing []string
+		if p.ExportType == model.ComplianceExportTypeMbox {
+			var w io.Writer
+			w, err = zipFile.Create(name + ".mbox")
+			if err != nil {
+				return results, fmt.Errorf("unable to create the mbox file: %w", err)
+			}
+			missing, err = writeMbox(rctx, p.FileAttachmentBackend, channel, w)
+		} else {
+			var w io.Writer
+			w, err = zipFile.Create(name + ".eml")
+			if err != nil {
+				return results, fmt.Errorf("unable to create the eml file: %w", err)
+			}
+			missing, err = writeConversation(rctx, p.FileAttachmentBackend, channel, w)
+		}
+		if err != nil {
+			return results, err
+		}
+		missingFiles = append(missingFiles, missing...)
+	}
+
+	results.NumWarnings = len(missingFiles)
+	if results.NumWarnings > 0 {
+		var warningFile io.Writer
+		warningFile, err = zipFile.Create(EMLWarningFilename)
+		if err != nil {
+			return results, fmt.Errorf("unable to create the warning file: %w", err)
+		}
+		for _, value := range missingFiles {
+			if _, err = warningFile.Write([]byte(value + "\n")); err != nil {
+				return results, fmt.Errorf("unable to write the warning file: %w", err)
+			}
+		}
+	}
+
+	metadataFile, err := zipFile.Create("metadata.json")
+	if err != nil {
+		return results, fmt.Errorf("unable to create the zip file: %w", err)
+	}
+	data, err := json.MarshalIndent(exportData.Metadata, "", "  ")
+	if err != nil {
+		return results, fmt.Errorf("unable to convert metadata to json: %w", err)
+	}
+	if _, err = metadataFile.Write(data); err != nil {
+		return results, fmt.Errorf("unable to add metadata file to the zip file: %w", err)
+	}
+
+	if err = zipFile.Close(); err != nil {
+		return results, fmt.Errorf("unable to close the zip file: %w", err)
+	}
+
+	if _, err = temp.Seek(0, 0); err != nil {
+		return results, fmt.Errorf("unable to seek to start of export file: %w", err)
+	}
+
+	// Try to write the file without a timeout due to the potential size of the file.
+	if _, err = filestore.TryWriteFileContext(rctx.Context(), p.ExportBackend, temp, p.BatchPath); err != nil {
+		return results, fmt.Errorf("unable to write the eml export file: %w", err)
+	}
+	return results, nil
+}
+
+// writeConversation writes the whole channel activity of the batch as a single message: a plain text
+// transcript followed by the uploaded files. It returns a warning for every attachment it could not read.
+func writeConversation(rctx request.CTX, fileBackend filestore.FileBackend, channel shared.ChannelExport, w io.Writer) ([]string, error) {
+	participants := participantAddresses(channel.JoinEvents)
+	from := FallbackFromAddress
+	if len(participants) > 0 {
+		from = participants[0]
+	}
+
+	headers := [][2]string{
+		{"From", from},
+		{"To", strings.Join(participants, ",\r\n ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", "Mattermost Compliance Export: "+channel.DisplayName)},
+		{"Date", time.UnixMilli(channel.EndTime).UTC().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s.%d.%d@%s>", channel.ChannelId, channel.StartTime, channel.EndTime, MessageIDDomain)},
+	}
+	headers = append(headers, channelHeaders(channel)...)
+
+	files := make([]*model.FileInfo, 0, len(channel.UploadStarts))
+	for _, start := range channel.UploadStarts {
+		files = append(files, start.FileInfo)
+	}
+
+	return writeMessage(rctx, fileBackend, w, headers, conversationText(channel), channel.ChannelId, files)
+}
+
+// writeMbox writes one message per post of the batch, threaded with Message-ID, In-Reply-To and
+// References headers and addressed to the channel members at the time of the post.
+func writeMbox(rctx request.CTX, fileBackend filestore.FileBackend, channel shared.ChannelExport, w io.Writer) ([]string, error) {
+	posts := slices.Clone(channel.Posts)
+	slices.SortStableFunc(posts, func(a, b shared.PostExport) int {
+		if postTime(a) == postTime(b) {
+			return strings.Compare(model.SafeDereference(a.PostId), model.SafeDereference(b.PostId))
+		}
+		return int(postTime(a) - postTime(b))
+	})
+
+	var missingFiles []string
+	for _, post := range posts {
+		sentAt := postTime(post)
+		postId := model.SafeDereference(post.PostId)
+		from := (&mail.Address{Name: model.SafeDereference(post.Username), Address: model.SafeDereference(post.UserEmail)}).String()
+
+		headers := [][2]string{
+			{"From", from},
+			{"To", strings.Join(participantAddresses(membersAt(channel.JoinEvents, sentAt)), ",\r\n ")},
+			{"Subject", mime.QEncoding.Encode("utf-8", "Mattermost Compliance Export: "+channel.DisplayName)},
+			{"Date", time.UnixMilli(sentAt).UTC().Format(time.RFC1123Z)},
+			{"Message-ID", postMessageID(post)},
+		}
+		if references := postReferences(post); len(references) > 0 {
+			headers = append(headers,
+				[2]string{"In-Reply-To", references[len(references)-1]},
+				[2]string{"References", strings.Join(references, " ")},
+			)
+		}
+		headers = append(headers, channelHeaders(channel)...)
+		headers = append(headers, [2]string{PostIDHeader, postId})
+		if post.UpdatedType != "" {
+			headers = append(headers, [2]string{UpdateTypeHeader, string(post.UpdatedType)})
+		}
+
+		files := make([]*model.FileInfo, 0, len(post.AttachmentCreates))
+		for _, start := range post.AttachmentCreates {
+			files = append(files, start.FileInfo)
+		}
+
+		var msg bytes.Buffer
+		missing, err := writeMessage(rctx, fileBackend, &msg, headers, postText(post)+"\r\n", postId, files)
+		if err != nil {
+			return missingFiles, err
+		}
+		missingFiles = append(missingFiles, missing...)
+
+		if err = writeMboxMessage(w, model.SafeDereference(post.UserEmail), sentAt, msg.Bytes()); err != nil {
+			return missingFiles, fmt.Errorf("unable to write the mbox message: %w", err)
+		}
+	}
+	return missingFiles, nil
+}
+
+// writeMessage writes a multipart/mixed message with a quoted-printable text part and one base64 part per file.
+// The boundary is derived from boundaryId so that exports are reproducible.
+func writeMessage(rctx request.CTX, fileBackend filestore.FileBackend, w io.Writer, headers [][2]string, text string,
+	boundaryId string, files []*model.FileInfo) ([]string, error) {
+	mw := multipart.NewWriter(w)
+	if err := mw.SetBoundary("mattermost-" + boundaryId); err != nil {
+		return nil, fmt.Errorf("unable to set the MIME boundary: %w", err)
+	}
+
+	headers = append(headers,
+		[2]string{"MIME-Version", "1.0"},
+		[2]string{"Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{"boundary": mw.Boundary()})},
+	)
+	for _, h := range headers {
+		if h[1] == "" {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%s: %s\r\n", h[0], h[1]); err != nil {
+			return nil, fmt.Errorf("unable to write the message headers: %w", err)
+		}
+	}
+	if _, err := io.WriteString(w, "\r\n"); err != nil {
+		return nil, fmt.Errorf("unable to write the message headers: %w", err)
+	}
+
+	textPart, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=UTF-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the text part: %w", err)
+	}
+	qp := quotedprintable.NewWriter(textPart)
+	if _, err = io.WriteString(qp, text); err != nil {
+		return nil, fmt.Errorf("unable to write the text part: %w", err)
+	}
+	if err = qp.Close(); err != nil {
+		return nil, fmt.Errorf("unable to write the text part: %w", err)
+	}
+
+	var missingFiles []string
+	for _, fileInfo := range files {
+		missing, err := writeAttachment(rctx, fileBackend, mw, fileInfo)
+		if err != nil {
+			return missingFiles, err
+		}
+		if missing != "" {
+			missingFiles = append(missingFiles, missing)
+		}
+	}
+
+	if err = mw.Close(); err != nil {
+		return missingFiles, fmt.Errorf("unable to close the message: %w", err)
+	}
+	return missingFiles, nil
+}
+
+// writeAttachment embeds a file as a base64 MIME part. A file that cannot be read is reported as a warning
+// rather than failing the export, otherwise every future run would fail on the same file.
+func writeAttachment(rctx request.CTX, fileBackend filestore.FileBackend, mw *multipart.Writer, fileInfo *model.FileInfo) (string, error) {
+	reader, err := fileBackend.Reader(fileInfo.Path)
+	if err != nil {
+		rctx.Logger().Warn(shared.MissingFileMessageDuringBackendRead,
+			mlog.String("post_id", fileInfo.PostId),
+			mlog.String("filename", fileInfo.Path),
+			mlog.Err(err),
+		)
+		return "Warning:" + shared.MissingFileMessageDuringBackendRead + " - Post: " + fileInfo.PostId + " - " + fileInfo.Path, nil
+	}
+	defer reader.Close()
+
+	contentType := fileInfo.MimeType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	filename := path.Base(fileInfo.Name)
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {mime.FormatMediaType(contentType, map[string]string{"name": filename})},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": filename})},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-ID":                {fmt.Sprintf("<%s@%s>", fileInfo.Id, MessageIDDomain)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to create the attachment part: %w", err)
+	}
+
+	encoder := base64.NewEncoder(base64.StdEncoding, &lineWrapper{w: part, max: base64LineLength})
+	if _, err = io.Copy(encoder, reader); err != nil {
+		rctx.Logger().Warn(shared.MissingFileMessageDuringCopy,
+			mlog.String("post_id", fileInfo.PostId),
+			mlog.String("filename", fileInfo.Path),
+			mlog.Err(err),
+		)
+		return "Warning:" + shared.MissingFileMessageDuringCopy + " - Post: " + fileInfo.PostId + " - " + fileInfo.Path, nil
+	}
+	if err = encoder.Close(); err != nil {
+		return "", fmt.Errorf("unable to encode the attachment: %w", err)
+	}
+	return "", nil
+}
+
+// writeMboxMessage appends a message to an mboxrd file: a "From " separator line, the message with any
+// line starting with (quoted) "From " escaped, and a trailing blank line.
+func writeMboxMessage(w io.Writer, sender string, sentAt int64, msg []byte) error {
+	if sender == "" {
+		sender = "MAILER-DAEMON"
+	}
+	if _, err := fmt.Fprintf(w, "From %s %s\n", sender, time.UnixMilli(sentAt).UTC().Format(time.ANSIC)); err != nil {
+		return err
+	}
+
+	for line := range strings.SplitSeq(strings.ReplaceAll(string(msg), "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(strings.TrimLeft(line, ">"), "From ") {
+			line = ">" + line
+		}
+		if _, err := io.WriteString(w, line+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conversationText renders the transcript of a channel for the batch: a summary, the participants
+// and every join, leave, post and file event in chronological order.
+func conversationText(channel shared.ChannelExport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Mattermost Compliance Export\r\n\r\n")
+	fmt.Fprintf(&b, "Channel: %s (%s)\r\n", channel.DisplayName, channel.ChannelName)
+	fmt.Fprintf(&b, "Channel Id: %s\r\n", channel.ChannelId)
+	fmt.Fprintf(&b, "Channel Type: %s\r\n", shared.ChannelTypeDisplayName(channel.ChannelType))
+	if channel.TeamId != "" {
+		fmt.Fprintf(&b, "Team: %s (%s)\r\n", channel.TeamDisplayName, channel.TeamName)
+		fmt.Fprintf(&b, "Team Id: %s\r\n", channel.TeamId)
+	}
+	fmt.Fprintf(&b, "Started: %s\r\n", TimestampConvert(channel.StartTime))
+	fmt.Fprintf(&b, "Ended: %s\r\n", TimestampConvert(channel.EndTime))
+
+	messagesSent := make(map[string]int)
+	for _, post := range channel.Posts {
+		messagesSent[model.SafeDereference(post.UserId)]++
+	}
+
+	fmt.Fprintf(&b, "\r\nParticipants:\r\n")
+	for _, join := range channel.JoinEvents {
+		fmt.Fprintf(&b, "  @%s <%s> (%s), joined %s, left %s, %d messages\r\n", join.Username, join.UserEmail, join.UserType,
+			TimestampConvert(join.JoinTime), TimestampConvert(join.LeaveTime), messagesSent[join.UserId])
+	}
+
+	entries := make([]conversationEntry, 0, len(channel.JoinEvents)+len(channel.LeaveEvents)+len(channel.Posts)+len(channel.UploadStarts)+len(channel.DeletedFiles))
+	for _, join := range channel.JoinEvents {
+		text := fmt.Sprintf("@%s <%s> joined the channel", join.Username, join.UserEmail)
+		if join.JoinTime <= channel.StartTime {
+			text = fmt.Sprintf("@%s <%s> was already in the channel", join.Username, join.UserEmail)
+		}
+		entries = append(entries, conversationEntry{time: join.JoinTime, text: text})
+	}
+	for _, leave := range channel.LeaveEvents {
+		if leave.ClosedOut {
+			// the participant is still in the channel at the end of the batch, see export_data.go.
+			continue
+		}
+		entries = append(entries, conversationEntry{time: leave.LeaveTime, text: fmt.Sprintf("@%s <%s> left the channel", leave.Username, leave.UserEmail)})
+	}
+	for _, post := range channel.Posts {
+		entries = append(entries, conversationEntry{time: postTime(post), postId: model.SafeDereference(post.PostId), text: postText(post)})
+	}
+	for _, start := range channel.UploadStarts {
+		entries = append(entries, conversationEntry{
+			time:   start.UploadStartTime,
+			postId: model.SafeDereference(start.PostId),
+			text: fmt.Sprintf("@%s <%s> (post %s): uploaded file %s (attached)", model.SafeDereference(start.Username), start.UserEmail,
+				model.SafeDereference(start.PostId), start.FileInfo.Name),
+		})
+	}
+	for _, deleted := range channel.DeletedFiles {
+		entries = append(entries, conversationEntry{
+			time:   deleted.FileInfo.DeleteAt,
+			postId: model.SafeDereference(deleted.PostId),
+			text: fmt.Sprintf("@%s <%s> (post %s): deleted file %s", model.SafeDereference(deleted.Username), model.SafeDereference(deleted.UserEmail),
+				model.SafeDereference(deleted.PostId), deleted.FileInfo.Name),
+		})
+	}
+
+	// entries were added by type, sort them by (time, postId) keeping joins before posts at the same time.
+	slices.SortStableFunc(entries, func(a, c conversationEntry) int {
+		if a.time == c.time {
+			return strings.Compare(a.postId, c.postId)
+		}
+		return int(a.time - c.time)
+	})
+
+	fmt.Fprintf(&b, "\r\nMessages:\r\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "[%s] %s\r\n", TimestampConvert(e.time), e.text)
+	}
+	return b.String()
+}
+
+// postText renders a post as a transcript line, with continuation lines indented.
+func postText(post shared.PostExport) string {
+	notes := []string{"post " + model.SafeDereference(post.PostId)}
+	if rootId := model.SafeDereference(post.PostRootId); rootId != "" {
+		notes = append(notes, "reply to "+rootId)
+	}
+	switch post.UpdatedType {
+	case shared.EditedOriginalMsg:
+		notes = append(notes, fmt.Sprintf("edited at %s, new post %s", TimestampConvert(post.UpdateAt), post.EditedNewMsgId))
+	case shared.EditedNewMsg:
+		notes = append(notes, "edited at "+TimestampConvert(post.UpdateAt))
+	case shared.UpdatedNoMsgChange:
+		notes = append(notes, "updated at "+TimestampConvert(post.UpdateAt))
+	case shared.Deleted:
+		notes = append(notes, "deleted at "+TimestampConvert(post.UpdateAt))
+	}
+	if post.PreviewsPost != "" {
+		notes = append(notes, "previews post "+post.PreviewsPost)
+	}
+
+	message := strings.ReplaceAll(post.Message, "\r\n", "\n")
+	message = strings.ReplaceAll(message, "\n", "\r\n    ")
+	return fmt.Sprintf("@%s <%s> (%s): %s", model.SafeDereference(post.Username), model.SafeDereference(post.UserEmail),
+		strings.Join(notes, ", "), message)
+}
+
+// postTime is the time the post's event happened: its creation or, for updates, the update.
+func postTime(post shared.PostExport) int64 {
+	if post.UpdatedType != "" && post.UpdatedType != shared.EditedOriginalMsg {
+		return post.UpdateAt
+	}
+	return model.SafeDereference(post.PostCreateAt)
+}
+
+// postMessageID identifies the mbox message of a post. Updates of a post get their own id
+// and reference the message of the original post.
+func postMessageID(post shared.PostExport) string {
+	postId := model.SafeDereference(post.PostId)
+	if post.UpdatedType == "" {
+		return fmt.Sprintf("<%s@%s>", postId, MessageIDDomain)
+	}
+	return fmt.Sprintf("<%s.%d@%s>", postId, post.UpdateAt, MessageIDDomain)
+}
+
+func postReferences(post shared.PostExport) []string {
+	var references []string
+	if rootId := model.SafeDereference(post.PostRootId); rootId != "" {
+		references = append(references, fmt.Sprintf("<%s@%s>", rootId, MessageIDDomain))
+	}
+	switch post.UpdatedType {
+	case "":
+	case shared.EditedOriginalMsg:
+		references = append(references, fmt.Sprintf("<%s@%s>", post.EditedNewMsgId, MessageIDDomain))
+	default:
+		references = append(references, fmt.Sprintf("<%s@%s>", model.SafeDereference(post.PostId), MessageIDDomain))
+	}
+	return references
+}
+
+func channelHeaders(channel shared.ChannelExport) [][2]string {
+	headers := [][2]string{
+		{ChannelIDHeader, channel.ChannelId},
+		{ChannelNameHeader, mime.QEncoding.Encode("utf-8", channel.ChannelName)},
+		{ChannelTypeHeader, shared.ChannelTypeDisplayName(channel.ChannelType)},
+	}
+	if channel.TeamId != "" {
+		headers = append(headers, [2]string{TeamIDHeader, channel.TeamId})
+	}
+	return headers
+}
+
+// membersAt returns the participants that were in the channel at the given time.
+func membersAt(joins []shared.JoinExport, at int64) []shared.JoinExport {
+	members := make([]shared.JoinExport, 0, len(joins))
+	for _, join := range joins {
+		if join.JoinTime <= at && join.LeaveTime >= at {
+			members = append(members, join)
+		}
+	}
+	return members
+}
+
+func participantAddresses(joins []shared.JoinExport) []string {
+	addresses := make([]string, 0, len(joins))
+	for _, join := range joins {
+		address := (&mail.Address{Name: join.Username, Address: join.UserEmail}).String()
+		if !slices.Contains(addresses, address) {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+func TimestampConvert(timestampMS int64) string {
+	return time.UnixMilli(timestampMS).UTC().Format("2006-01-02T15:04:05.000Z07:00")
+}
+
+// lineWrapper breaks the written bytes into CRLF separated lines of at most max bytes.
+type lineWrapper struct {
+	w       io.Writer
+	max     int
+	written int
+}
+
+func (l *lineWrapper) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if l.written == l.max {
+			if _, err := io.WriteString(l.w, "\r\n"); err != nil {
+				return n, err
+			}
+			l.written = 0
+		}
+
+		chunk := min(l.max-l.written, len(p))
+		if _, err := l.w.Write(p[:chunk]); err != nil {
+			return n, err
+		}
+		n += chunk
+		l.written += chunk
+		p = p[chunk:]
+	}
+	return n, nil
+}
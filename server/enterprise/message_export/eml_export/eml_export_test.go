@@ -0,0 +1,284 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package eml_export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+type emlTestData struct {
+	fileBackend filestore.FileBackend
+	store       *storetest.Store
+	params      shared.ExportParams
+}
+
+func setupEmlTest(t *testing.T, exportType string) emlTestData {
+	tempDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = os.RemoveAll(tempDir)
+		assert.NoError(t, err)
+	})
+
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  tempDir,
+	})
+	require.NoError(t, err)
+
+	chanTypeOpen := model.ChannelTypeOpen
+	newPost := func(id, rootId, userId, username string, createAt int64, message string, fileIds []string) *model.MessageExport {
+		return &model.MessageExport{
+			PostId:             model.NewPointer(id),
+			PostOriginalId:     model.NewPointer(""),
+			PostRootId:         model.NewPointer(rootId),
+			TeamId:             model.NewPointer("team-id"),
+			TeamName:           model.NewPointer("team-name"),
+			TeamDisplayName:    model.NewPointer("Team Display Name"),
+			ChannelId:          model.NewPointer("channel-id"),
+			ChannelName:        model.NewPointer("channel-name"),
+			ChannelDisplayName: model.NewPointer("Channel Display Name"),
+			ChannelType:        &chanTypeOpen,
+			PostCreateAt:       model.NewPointer(createAt),
+			PostUpdateAt:       model.NewPointer(createAt),
+			PostMessage:        model.NewPointer(message),
+			UserEmail:          model.NewPointer(username + "@example.com"),
+			UserId:             model.NewPointer(userId),
+			Username:           model.NewPointer(username),
+			PostFileIds:        fileIds,
+		}
+	}
+
+	mockStore := &storetest.Store{}
+	t.Cleanup(func() { mockStore.AssertExpectations(t) })
+
+	return emlTestData{
+		fileBackend: fileBackend,
+		store:       mockStore,
+		params: shared.ExportParams{
+			ExportType: exportType,
+			ChannelMetadata: map[string]*shared.MetadataChannel{
+				"channel-id": {
+					TeamId:             model.NewPointer("team-id"),
+					TeamName:           model.NewPointer("team-name"),
+					TeamDisplayName:    model.NewPointer("Team Display Name"),
+					ChannelId:          "channel-id",
+					ChannelName:        "channel-name",
+					ChannelDisplayName: "Channel Display Name",
+					ChannelType:        chanTypeOpen,
+					RoomId:             "public - channel-id",
+					StartTime:          1,
+					EndTime:            1000,
+				},
+			},
+			ChannelMemberHistories: map[string][]*model.ChannelMemberHistoryResult{
+				"channel-id": {
+					{JoinTime: 0, UserId: "user-1", UserEmail: "user1@example.com", Username: "user1"},
+					{JoinTime: 200, UserId: "user-2", UserEmail: "user2@example.com", Username: "user2", LeaveTime: model.NewPointer(int64(600))},
+				},
+			},
+			Posts: []*model.MessageExport{
+				newPost("post-1", "", "user-1", "user1", 100, "hello\nFrom the other side", []string{"file-1"}),
+				newPost("post-2", "post-1", "user-2", "user2", 300, "a reply", []string{}),
+			},
+			BatchPath:             path.Join("export", "batch001.zip"),
+			BatchStartTime:        1,
+			BatchEndTime:          1000,
+			Db:                    shared.NewMessageExportStore(mockStore),
+			FileAttachmentBackend: fileBackend,
+			ExportBackend:         fileBackend,
+		},
+	}
+}
+
+func readZip(t *testing.T, backend filestore.FileBackend, batchPath string) map[string]string {
+	zipBytes, err := backend.ReadFile(batchPath)
+	require.NoError(t, err)
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	require.NoError(t, err)
+
+	files := make(map[string]string, len(zipReader.File))
+	for _, f := range zipReader.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+		files[f.Name] = string(data)
+	}
+	return files
+}
+
+// readParts parses a multipart/mixed message into its decoded text and attachments.
+func readParts(t *testing.T, msg *mail.Message) (string, map[string]string) {
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	require.Equal(t, "multipart/mixed", mediaType)
+
+	var text string
+	attachments := make(map[string]string)
+	mr := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		data, err := io.ReadAll(part)
+		require.NoError(t, err)
+		if part.FileName() == "" {
+			text = string(data)
+			continue
+		}
+		require.Equal(t, "base64", part.Header.Get("Content-Transfer-Encoding"))
+		decoded, err := io.ReadAll(base64Decoder(string(data)))
+		require.NoError(t, err)
+		attachments[part.FileName()] = string(decoded)
+	}
+	return text, attachments
+}
+
+func base64Decoder(s string) io.Reader {
+	return base64.NewDecoder(base64.StdEncoding, strings.NewReader(strings.ReplaceAll(s, "\r\n", "")))
+}
+
+func TestEmlExport(t *testing.T) {
+	rctx := request.TestContext(t)
+	fileInfo := &model.FileInfo{Id: "file-1", PostId: "post-1", Name: "report.txt", Path: "data/report.txt", MimeType: "text/plain"}
+
+	t.Run("one eml per conversation", func(t *testing.T) {
+		td := setupEmlTest(t, model.ComplianceExportTypeEml)
+		td.store.FileInfoStore.On("GetForPost", "post-1", true, true, false).Return([]*model.FileInfo{fileInfo}, nil)
+		_, err := td.fileBackend.WriteFile(strings.NewReader("file contents"), fileInfo.Path)
+		require.NoError(t, err)
+
+		results, err := EmlExport(rctx, td.params)
+		require.NoError(t, err)
+		assert.Zero(t, results.NumWarnings)
+		assert.Equal(t, 1, results.UploadedFiles)
+
+		files := readZip(t, td.fileBackend, td.params.BatchPath)
+		require.Len(t, files, 2)
+		require.Contains(t, files, "metadata.json")
+		require.Contains(t, files, "channel-name - (channel-id).eml")
+
+		msg, err := mail.ReadMessage(strings.NewReader(files["channel-name - (channel-id).eml"]))
+		require.NoError(t, err)
+		assert.Equal(t, `"user1" <user1@example.com>`, msg.Header.Get("From"))
+		to, err := msg.Header.AddressList("To")
+		require.NoError(t, err)
+		require.Len(t, to, 2)
+		assert.Equal(t, "user2@example.com", to[1].Address)
+		assert.Equal(t, "Mattermost Compliance Export: Channel Display Name", msg.Header.Get("Subject"))
+		assert.Equal(t, "channel-id", msg.Header.Get(ChannelIDHeader))
+		assert.Equal(t, "public", msg.Header.Get(ChannelTypeHeader))
+		assert.Equal(t, "team-id", msg.Header.Get(TeamIDHeader))
+
+		text, attachments := readParts(t, msg)
+		assert.Contains(t, text, "Channel: Channel Display Name (channel-name)\r\n")
+		assert.Contains(t, text, "  @user2 <user2@example.com> (user), joined "+TimestampConvert(200)+", left "+TimestampConvert(600)+", 1 messages\r\n")
+		assert.Contains(t, text, "["+TimestampConvert(100)+"] @user1 <user1@example.com> (post post-1): hello\r\n    From the other side\r\n")
+		assert.Contains(t, text, "["+TimestampConvert(100)+"] @user1 <user1@example.com> (post post-1): uploaded file report.txt (attached)\r\n")
+		assert.Contains(t, text, "["+TimestampConvert(200)+"] @user2 <user2@example.com> joined the channel\r\n")
+		assert.Contains(t, text, "["+TimestampConvert(300)+"] @user2 <user2@example.com> (post post-2, reply to post-1): a reply\r\n")
+		assert.Contains(t, text, "["+TimestampConvert(600)+"] @user2 <user2@example.com> left the channel\r\n")
+		assert.Equal(t, map[string]string{"report.txt": "file contents"}, attachments)
+	})
+
+	t.Run("one mbox per channel with a message per post", func(t *testing.T) {
+		td := setupEmlTest(t, model.ComplianceExportTypeMbox)
+		td.store.FileInfoStore.On("GetForPost", "post-1", true, true, false).Return([]*model.FileInfo{fileInfo}, nil)
+		_, err := td.fileBackend.WriteFile(strings.NewReader("file contents"), fileInfo.Path)
+		require.NoError(t, err)
+
+		_, err = EmlExport(rctx, td.params)
+		require.NoError(t, err)
+
+		files := readZip(t, td.fileBackend, td.params.BatchPath)
+		require.Contains(t, files, "channel-name - (channel-id).mbox")
+		mbox := files["channel-name - (channel-id).mbox"]
+
+		rawMessages := strings.Split(mbox, "\nFrom ")
+		require.Len(t, rawMessages, 2)
+		require.True(t, strings.HasPrefix(rawMessages[0], "From user1@example.com "))
+
+		var messages []*mail.Message
+		for _, raw := range rawMessages {
+			raw = raw[strings.Index(raw, "\n")+1:]
+			msg, err := mail.ReadMessage(strings.NewReader(raw))
+			require.NoError(t, err)
+			messages = append(messages, msg)
+		}
+
+		first := messages[0]
+		assert.Equal(t, "<post-1@mattermost>", first.Header.Get("Message-ID"))
+		assert.Empty(t, first.Header.Get("In-Reply-To"))
+		to, err := first.Header.AddressList("To")
+		require.NoError(t, err)
+		require.Len(t, to, 1, "user2 had not joined yet")
+		text, attachments := readParts(t, first)
+		assert.Contains(t, text, "hello\n    From the other side", "mbox files use LF line endings")
+		assert.Equal(t, map[string]string{"report.txt": "file contents"}, attachments)
+
+		reply := messages[1]
+		assert.Equal(t, `"user2" <user2@example.com>`, reply.Header.Get("From"))
+		assert.Equal(t, "<post-2@mattermost>", reply.Header.Get("Message-ID"))
+		assert.Equal(t, "<post-1@mattermost>", reply.Header.Get("In-Reply-To"))
+		assert.Equal(t, "post-2", reply.Header.Get(PostIDHeader))
+		to, err = reply.Header.AddressList("To")
+		require.NoError(t, err)
+		assert.Len(t, to, 2)
+	})
+
+	t.Run("missing attachments are reported as warnings", func(t *testing.T) {
+		td := setupEmlTest(t, model.ComplianceExportTypeEml)
+		td.store.FileInfoStore.On("GetForPost", "post-1", true, true, false).Return([]*model.FileInfo{fileInfo}, nil)
+
+		results, err := EmlExport(rctx, td.params)
+		require.NoError(t, err)
+		assert.Equal(t, 1, results.NumWarnings)
+
+		files := readZip(t, td.fileBackend, td.params.BatchPath)
+		assert.Equal(t, fmt.Sprintf("Warning:%s - Post: post-1 - data/report.txt\n", shared.MissingFileMessageDuringBackendRead), files[EMLWarningFilename])
+	})
+}
+
+func TestLineWrapper(t *testing.T) {
+	var b bytes.Buffer
+	w := &lineWrapper{w: &b, max: 4}
+
+	_, err := w.Write([]byte("abcdef"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("gh"))
+	require.NoError(t, err)
+	assert.Equal(t, "abcd\r\nefgh", b.String())
+}
+
+func TestWriteMboxMessage(t *testing.T) {
+	var b bytes.Buffer
+	err := writeMboxMessage(&b, "user1@example.com", 0, []byte("Subject: test\r\n\r\nFrom here\r\n>From there\r\nnot From\r\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "From user1@example.com Thu Jan  1 00:00:00 1970\nSubject: test\n\n>From here\n>>From there\nnot From\n\n", b.String())
+}
@@ -0,0 +1,521 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package jsonl_export
+
+import (
+	"archive/zip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/enterprise/internal/file"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+const (
+	RecordsFilename      = "messages.jsonl"
+	SchemaFilename       = "schema.json"
+	MetadataFilename     = "metadata.json"
+	ManifestFilename     = "manifest.json"
+	SignatureFilename    = "manifest.sig"
+	JSONLWarningFilename = "warning.txt"
+
+	// SchemaID is the $id of the published record schema, see schema.json. It changes whenever a record
+	// field is removed or changes meaning.
+	SchemaID = "urn:mattermost:compliance-export:jsonl:v1"
+
+	ManifestVersion = 1
+)
+
+// Schema is the JSON schema every line of messages.jsonl validates against. It is also written to every batch.
+//
+//go:embed schema.json
+var Schema []byte
+
+type RecordType string
+
+const (
+	MessageRecord          RecordType = "message"
+	JoinRecord             RecordType = "join"
+	PreviouslyJoinedRecord RecordType = "previously_joined"
+	LeaveRecord            RecordType = "leave"
+)
+
+// Record is a single line of messages.jsonl: either a message (a post create, edit, update or delete, or a file
+// delete) or a channel join/leave event.
+type Record struct {
+	Type               RecordType             `json:"type"`
+	Time               int64                  `json:"time"`
+	TeamId             string                 `json:"team_id,omitempty"`
+	TeamName           string                 `json:"team_name,omitempty"`
+	TeamDisplayName    string                 `json:"team_display_name,omitempty"`
+	ChannelId          string                 `json:"channel_id"`
+	ChannelName        string                 `json:"channel_name"`
+	ChannelDisplayName string                 `json:"channel_display_name"`
+	ChannelType        string                 `json:"channel_type"`
+	UserId             string                 `json:"user_id"`
+	UserEmail          string                 `json:"user_email"`
+	Username           string                 `json:"username"`
+	UserType           shared.UserType        `json:"user_type"`
+	PostId             string                 `json:"post_id,omitempty"`
+	PostCreateAt       int64                  `json:"post_create_at,omitempty"`
+	PostUpdateAt       int64                  `json:"post_update_at,omitempty"`
+	UpdateType         shared.PostUpdatedType `json:"update_type,omitempty"`
+	PostType           string                 `json:"post_type,omitempty"`
+	RootId             string                 `json:"root_id,omitempty"`
+	EditedNewPostId    string                 `json:"edited_new_post_id,omitempty"`
+	PreviewsPostId     string                 `json:"previews_post_id,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+	Files              []FileRecord           `json:"files,omitempty"`
+}
+
+type FileRecord struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	MimeType string `json:"mime_type,omitempty"`
+	Size     int64  `json:"size"`
+	DeleteAt int64  `json:"delete_at,omitempty"`
+
+	// Path is the location of the file in the batch zip. It is empty for deleted files and for files that
+	// could not be read from the file store, which are listed in warning.txt.
+	Path string `json:"path,omitempty"`
+
+	// storePath is the location of the file in the attachment file store.
+	storePath string
+}
+
+// Manifest lists every other file of the batch zip with its SHA-256 hash. It is signed with the configured
+// Ed25519 key, the base64 encoded signature of manifest.json is written to manifest.sig.
+type Manifest struct {
+	Version        int            `json:"version"`
+	SchemaId       string         `json:"schema_id"`
+	BatchPath      string         `json:"batch_path"`
+	BatchStartTime int64          `json:"batch_start_time"`
+	BatchEndTime   int64          `json:"batch_end_time"`
+	JobStartTime   int64          `json:"job_start_time"`
+	RecordCount    int            `json:"record_count"`
+	Files          []ManifestFile `json:"files"`
+	PublicKey      []byte         `json:"public_key"`
+}
+
+type ManifestFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+func JsonlExport(rctx request.CTX, p shared.ExportParams) (shared.RunExportResults, error) {
+	exportData, err := shared.GetGenericExportData(p)
+	results := exportData.Results
+	if err != nil {
+		return results, err
+	}
+
+	if p.Config == nil || p.Config.MessageExportSettings.JsonlSettings == nil {
+		return results, fmt.Errorf("unable to sign the JSONL export: the signing key is not configured")
+	}
+	privateKey, err := p.Config.MessageExportSettings.JsonlSettings.PrivateKey()
+	if err != nil {
+		return results, fmt.Errorf("unable to sign the JSONL export: %w", err)
+	}
+
+	// channels come out of GetGenericExportData in map order, sort them so the records are stable.
+	exports := exportData.Exports
+	slices.SortFunc(exports, func(a, b shared.ChannelExport) int {
+		if a.ChannelName == b.ChannelName {
+			return strings.Compare(a.ChannelId, b.ChannelId)
+		}
+		return strings.Compare(a.ChannelName, b.ChannelName)
+	})
+
+	var records []Record
+	for _, channel := range exports {
+		records = append(records, channelRecords(channel)...)
+	}
+
+	// We need to sort all the records by (Time, PostId) because they were added by type and by channel above.
+	slices.SortStableFunc(records, func(a, b Record) int {
+		if a.Time == b.Time {
+			return strings.Compare(a.PostId, b.PostId)
+		}
+		return int(a.Time - b.Time)
+	})
+
+	// Write this batch to a tmp zip, then copy the zip to the export directory.
+	temp, err := os.CreateTemp("", "compliance-export-batch-*.zip")
+	if err != nil {
+		return results, fmt.Errorf("unable to create temporary JSONL export file: %w", err)
+	}
+	defer file.DeleteTemp(rctx.Logger(), temp)
+
+	batch := &batchWriter{zip: zip.NewWriter(temp)}
+
+	missingFiles := writeAttachments(rctx, p.FileAttachmentBackend, batch, records)
+
+	recordsFile, err := batch.create(RecordsFilename)
+	if err != nil {
+		return results, fmt.Errorf("unable to create the records file: %w", err)
+	}
+	enc := json.NewEncoder(recordsFile)
+	enc.SetEscapeHTML(false)
+	for _, record := range records {
+		if err = enc.Encode(record); err != nil {
+			return results, fmt.Errorf("unable to export a record: %w", err)
+		}
+	}
+
+	schemaFile, err := batch.create(SchemaFilename)
+	if err != nil {
+		return results, fmt.Errorf("unable to create the schema file: %w", err)
+	}
+	if _, err = schemaFile.Write(Schema); err != nil {
+		return results, fmt.Errorf("unable to write the schema file: %w", err)
+	}
+
+	results.NumWarnings = len(missingFiles)
+	if results.NumWarnings > 0 {
+		var warningFile io.Writer
+		warningFile, err = batch.create(JSONLWarningFilename)
+		if err != nil {
+			return results, fmt.Errorf("unable to create the warning file: %w", err)
+		}
+		for _, value := range missingFiles {
+			if _, err = warningFile.Write([]byte(value + "\n")); err != nil {
+				return results, fmt.Errorf("unable to write the warning file: %w", err)
+			}
+		}
+	}
+
+	metadataFile, err := batch.create(MetadataFilename)
+	if err != nil {
+		return results, fmt.Errorf("unable to create the zip file: %w", err)
+	}
+	data, err := json.MarshalIndent(exportData.Metadata, "", "  ")
+	if err != nil {
+		return results, fmt.Errorf("unable to convert metadata to json: %w", err)
+	}
+	if _, err = metadataFile.Write(data); err != nil {
+		return results, fmt.Errorf("unable to add metadata file to the zip file: %w", err)
+	}
+
+	manifest := Manifest{
+		Version:        ManifestVersion,
+		SchemaId:       SchemaID,
+		BatchPath:      p.BatchPath,
+		BatchStartTime: p.BatchStartTime,
+		BatchEndTime:   p.BatchEndTime,
+		JobStartTime:   p.JobStartTime,
+		RecordCount:    len(records),
+		Files:          batch.manifestFiles(),
+		PublicKey:      privateKey.Public().(ed25519.PublicKey),
+	}
+	if err = writeManifest(batch.zip, manifest, privateKey); err != nil {
+		return results, err
+	}
+
+	if err = batch.zip.Close(); err != nil {
+		return results, fmt.Errorf("unable to close the zip file: %w", err)
+	}
+
+	if _, err = temp.Seek(0, 0); err != nil {
+		return results, fmt.Errorf("unable to seek to start of export file: %w", err)
+	}
+
+	// Try to write the file without a timeout due to the potential size of the file.
+	if _, err = filestore.TryWriteFileContext(rctx.Context(), p.ExportBackend, temp, p.BatchPath); err != nil {
+		return results, fmt.Errorf("unable to write the jsonl export file: %w", err)
+	}
+	return results, nil
+}
+
+// channelRecords returns the join, leave and message records of a channel, unsorted.
+func channelRecords(channel shared.ChannelExport) []Record {
+	base := Record{
+		TeamId:             channel.TeamId,
+		TeamName:           channel.TeamName,
+		TeamDisplayName:    channel.TeamDisplayName,
+		ChannelId:          channel.ChannelId,
+		ChannelName:        channel.ChannelName,
+		ChannelDisplayName: channel.DisplayName,
+		ChannelType:        shared.ChannelTypeDisplayName(channel.ChannelType),
+	}
+
+	records := make([]Record, 0, len(channel.JoinEvents)+len(channel.LeaveEvents)+len(channel.Posts)+len(channel.DeletedFiles))
+	for _, join := range channel.JoinEvents {
+		r := base
+		r.Type = JoinRecord
+		if join.JoinTime <= channel.StartTime {
+			r.Type = PreviouslyJoinedRecord
+		}
+		r.Time = join.JoinTime
+		r.UserId, r.UserEmail, r.Username, r.UserType = join.UserId, join.UserEmail, join.Username, join.UserType
+		records = append(records, r)
+	}
+	for _, leave := range channel.LeaveEvents {
+		if leave.ClosedOut {
+			// the participant is still in the channel at the end of the batch, see export_data.go.
+			continue
+		}
+		r := base
+		r.Type = LeaveRecord
+		r.Time = leave.LeaveTime
+		r.UserId, r.UserEmail, r.Username, r.UserType = leave.UserId, leave.UserEmail, leave.Username, leave.UserType
+		records = append(records, r)
+	}
+	for _, post := range channel.Posts {
+		r := messageRecord(base, post)
+		for _, upload := range post.AttachmentCreates {
+			r.Files = append(r.Files, fileRecord(post, upload.FileInfo))
+		}
+		records = append(records, r)
+	}
+	for _, deleted := range channel.DeletedFiles {
+		r := messageRecord(base, deleted)
+		r.Files = []FileRecord{fileRecord(deleted, deleted.FileInfo)}
+		records = append(records, r)
+	}
+	return records
+}
+
+func messageRecord(base Record, post shared.PostExport) Record {
+	r := base
+	r.Type = MessageRecord
+	r.Time = model.SafeDereference(post.PostCreateAt)
+	if post.UpdatedType != "" && post.UpdatedType != shared.EditedOriginalMsg {
+		r.Time = post.UpdateAt
+	}
+	r.UserId = model.SafeDereference(post.UserId)
+	r.UserEmail = model.SafeDereference(post.UserEmail)
+	r.Username = model.SafeDereference(post.Username)
+	r.UserType = post.UserType
+	r.PostId = model.SafeDereference(post.PostId)
+	r.PostCreateAt = model.SafeDereference(post.PostCreateAt)
+	r.PostUpdateAt = model.SafeDereference(post.PostUpdateAt)
+	r.UpdateType = post.UpdatedType
+	r.PostType = model.SafeDereference(post.PostType)
+	r.RootId = model.SafeDereference(post.PostRootId)
+	r.EditedNewPostId = post.EditedNewMsgId
+	r.PreviewsPostId = post.PreviewsPost
+	r.Message = post.Message
+	return r
+}
+
+func fileRecord(post shared.PostExport, info *model.FileInfo) FileRecord {
+	f := FileRecord{
+		Id:        info.Id,
+		Name:      info.Name,
+		MimeType:  info.MimeType,
+		Size:      info.Size,
+		DeleteAt:  info.DeleteAt,
+		storePath: info.Path,
+	}
+	if info.DeleteAt == 0 {
+		f.Path = path.Join("files", model.SafeDereference(post.PostId), fmt.Sprintf("%s-%s", info.Id, path.Base(info.Path)))
+	}
+	return f
+}
+
+// writeAttachments copies the files referenced by the records into the batch. Files that can't be read are
+// logged, unlinked from their record and returned as warnings instead of failing the export.
+func writeAttachments(rctx request.CTX, fileBackend filestore.FileBackend, batch *batchWriter, records []Record) []string {
+	// Using a 2M buffer because the file backend may be s3 and this optimizes speed and
+	// memory usage, see: https://github.com/mattermost/mattermost/pull/26629
+	buf := make([]byte, 1024*1024*2)
+
+	var missingFiles []string
+	for i := range records {
+		for j := range records[i].Files {
+			f := &records[i].Files[j]
+			if f.Path == "" {
+				continue
+			}
+
+			filePath := f.storePath
+			r, err := fileBackend.Reader(filePath)
+			if err != nil {
+				missingFiles = append(missingFiles, "Warning:"+shared.MissingFileMessageDuringBackendRead+" - Post: "+records[i].PostId+" - "+filePath)
+				rctx.Logger().Warn(shared.MissingFileMessageDuringBackendRead,
+					mlog.String("post_id", records[i].PostId),
+					mlog.String("filename", filePath),
+					mlog.Err(err),
+				)
+				f.Path = ""
+				continue
+			}
+
+			if err = func() error {
+				defer r.Close()
+				dst, err := batch.create(f.Path)
+				if err != nil {
+					return err
+				}
+				_, err = io.CopyBuffer(dst, r, buf)
+				return err
+			}(); err != nil {
+				// s3 only errors _here_ if the object key wasn't found, see csv_export.go.
+				missingFiles = append(missingFiles, "Warning:"+shared.MissingFileMessageDuringCopy+" - Post: "+records[i].PostId+" - "+filePath)
+				rctx.Logger().Warn(shared.MissingFileMessageDuringCopy,
+					mlog.String("post_id", records[i].PostId),
+					mlog.String("filename", filePath),
+					mlog.Err(err),
+				)
+				f.Path = ""
+			}
+		}
+	}
+	return missingFiles
+}
+
+func writeManifest(zipFile *zip.Writer, manifest Manifest, privateKey ed25519.PrivateKey) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to convert the manifest to json: %w", err)
+	}
+
+	manifestFile, err := zipFile.Create(ManifestFilename)
+	if err != nil {
+		return fmt.Errorf("unable to create the manifest file: %w", err)
+	}
+	if _, err = manifestFile.Write(data); err != nil {
+		return fmt.Errorf("unable to write the manifest file: %w", err)
+	}
+
+	signatureFile, err := zipFile.Create(SignatureFilename)
+	if err != nil {
+		return fmt.Errorf("unable to create the manifest signature file: %w", err)
+	}
+	if _, err = signatureFile.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, data)))); err != nil {
+		return fmt.Errorf("unable to write the manifest signature file: %w", err)
+	}
+	return nil
+}
+
+// VerifyBatch checks the manifest signature of a batch against publicKey, then checks that the batch holds
+// exactly the files listed in the manifest and that their SHA-256 hashes match. It returns the verified manifest.
+func VerifyBatch(r *zip.Reader, publicKey ed25519.PublicKey) (*Manifest, error) {
+	data, err := readZipFile(r, ManifestFilename)
+	if err != nil {
+		return nil, err
+	}
+	encodedSignature, err := readZipFile(r, SignatureFilename)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encodedSignature)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode the manifest signature: %w", err)
+	}
+	if !ed25519.Verify(publicKey, data, signature) {
+		return nil, fmt.Errorf("the manifest signature is invalid")
+	}
+
+	var manifest Manifest
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse the manifest: %w", err)
+	}
+
+	listed := make(map[string]ManifestFile, len(manifest.Files))
+	for _, f := range manifest.Files {
+		listed[f.Name] = f
+	}
+	for _, zf := range r.File {
+		if zf.Name == ManifestFilename || zf.Name == SignatureFilename {
+			continue
+		}
+		expected, ok := listed[zf.Name]
+		if !ok {
+			return nil, fmt.Errorf("file %q is not listed in the manifest", zf.Name)
+		}
+		delete(listed, zf.Name)
+
+		sum, size, err := hashZipFile(zf)
+		if err != nil {
+			return nil, err
+		}
+		if sum != expected.SHA256 || size != expected.Size {
+			return nil, fmt.Errorf("file %q does not match the manifest", zf.Name)
+		}
+	}
+	for name := range listed {
+		return nil, fmt.Errorf("file %q listed in the manifest is missing", name)
+	}
+	return &manifest, nil
+}
+
+func readZipFile(r *zip.Reader, name string) ([]byte, error) {
+	f, err := r.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s: %w", name, err)
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+func hashZipFile(zf *zip.File) (string, int64, error) {
+	f, err := zf.Open()
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to open %s: %w", zf.Name, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, fmt.Errorf("unable to read %s: %w", zf.Name, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// batchWriter creates the files of the batch zip, hashing everything written to them for the manifest.
+type batchWriter struct {
+	zip     *zip.Writer
+	entries []*hashedEntry
+}
+
+type hashedEntry struct {
+	name string
+	w    io.Writer
+	h    hash.Hash
+	size int64
+}
+
+func (e *hashedEntry) Write(p []byte) (int, error) {
+	n, err := e.w.Write(p)
+	e.h.Write(p[:n])
+	e.size += int64(n)
+	return n, err
+}
+
+// create adds a file to the zip. As with zip.Writer.Create, the previous file must not be written to afterwards.
+func (b *batchWriter) create(name string) (*hashedEntry, error) {
+	w, err := b.zip.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	entry := &hashedEntry{name: name, w: w, h: sha256.New()}
+	b.entries = append(b.entries, entry)
+	return entry, nil
+}
+
+func (b *batchWriter) manifestFiles() []ManifestFile {
+	files := make([]ManifestFile, 0, len(b.entries))
+	for _, e := range b.entries {
+		files = append(files, ManifestFile{Name: e.name, Size: e.size, SHA256: hex.EncodeToString(e.h.Sum(nil))})
+	}
+	return files
+}
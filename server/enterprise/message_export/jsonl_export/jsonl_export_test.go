@@ -0,0 +1,342 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package jsonl_export
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
+)
+
+var testPrivateKey = ed25519.NewKeyFromSeed(bytes.Repeat([]byte{7}, ed25519.SeedSize))
+
+type jsonlTestData struct {
+	fileBackend filestore.FileBackend
+	store       *storetest.Store
+	params      shared.ExportParams
+}
+
+func setupJsonlTest(t *testing.T) jsonlTestData {
+	tempDir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		err = os.RemoveAll(tempDir)
+		assert.NoError(t, err)
+	})
+
+	fileBackend, err := filestore.NewFileBackend(filestore.FileBackendSettings{
+		DriverName: model.ImageDriverLocal,
+		Directory:  tempDir,
+	})
+	require.NoError(t, err)
+
+	chanTypeOpen := model.ChannelTypeOpen
+	newPost := func(id, rootId, userId, username string, createAt int64, message string, fileIds []string) *model.MessageExport {
+		return &model.MessageExport{
+			PostId:             model.NewPointer(id),
+			PostOriginalId:     model.NewPointer(""),
+			PostRootId:         model.NewPointer(rootId),
+			PostType:           model.NewPointer(""),
+			TeamId:             model.NewPointer("team-id"),
+			TeamName:           model.NewPointer("team-name"),
+			TeamDisplayName:    model.NewPointer("Team Display Name"),
+			ChannelId:          model.NewPointer("channel-id"),
+			ChannelName:        model.NewPointer("channel-name"),
+			ChannelDisplayName: model.NewPointer("Channel Display Name"),
+			ChannelType:        &chanTypeOpen,
+			PostCreateAt:       model.NewPointer(createAt),
+			PostUpdateAt:       model.NewPointer(createAt),
+			PostMessage:        model.NewPointer(message),
+			UserEmail:          model.NewPointer(username + "@example.com"),
+			UserId:             model.NewPointer(userId),
+			Username:           model.NewPointer(username),
+			PostFileIds:        fileIds,
+		}
+	}
+
+	config := &model.Config{}
+	config.SetDefaults()
+	config.MessageExportSettings.JsonlSettings.SigningKey = model.NewPointer(base64.StdEncoding.EncodeToString(testPrivateKey.Seed()))
+
+	mockStore := &storetest.Store{}
+	t.Cleanup(func() { mockStore.AssertExpectations(t) })
+
+	return jsonlTestData{
+		fileBackend: fileBackend,
+		store:       mockStore,
+		params: shared.ExportParams{
+			ExportType: model.ComplianceExportTypeJsonl,
+			ChannelMetadata: map[string]*shared.MetadataChannel{
+				"channel-id": {
+					TeamId:             model.NewPointer("team-id"),
+					TeamName:           model.NewPointer("team-name"),
+					TeamDisplayName:    model.NewPointer("Team Display Name"),
+					ChannelId:          "channel-id",
+					ChannelName:        "channel-name",
+					ChannelDisplayName: "Channel Display Name",
+					ChannelType:        chanTypeOpen,
+					RoomId:             "public - channel-id",
+					StartTime:          1,
+					EndTime:            1000,
+				},
+			},
+			ChannelMemberHistories: map[string][]*model.ChannelMemberHistoryResult{
+				"channel-id": {
+					{JoinTime: 0, UserId: "user-1", UserEmail: "user1@example.com", Username: "user1"},
+					{JoinTime: 200, UserId: "user-2", UserEmail: "user2@example.com", Username: "user2", LeaveTime: model.NewPointer(int64(600))},
+				},
+			},
+			Posts: []*model.MessageExport{
+				newPost("post-1", "", "user-1", "user1", 100, "hello <world>", []string{"file-1"}),
+				newPost("post-2", "post-1", "user-2", "user2", 300, "a reply", []string{}),
+			},
+			BatchPath:             path.Join("export", "batch001-1-1000.zip"),
+			BatchStartTime:        1,
+			BatchEndTime:          1000,
+			Config:                config,
+			Db:                    shared.NewMessageExportStore(mockStore),
+			FileAttachmentBackend: fileBackend,
+			ExportBackend:         fileBackend,
+		},
+	}
+}
+
+func openBatch(t *testing.T, backend filestore.FileBackend, batchPath string) *zip.Reader {
+	zipBytes, err := backend.ReadFile(batchPath)
+	require.NoError(t, err)
+	zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+	require.NoError(t, err)
+	return zipReader
+}
+
+func readRecords(t *testing.T, r *zip.Reader) []Record {
+	data, err := readZipFile(r, RecordsFilename)
+	require.NoError(t, err)
+
+	var records []Record
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		dec := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+		dec.DisallowUnknownFields()
+		var record Record
+		require.NoError(t, dec.Decode(&record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+// rewriteZip copies a zip, replacing the contents of the given files.
+func rewriteZip(t *testing.T, r *zip.Reader, replace map[string]string) *zip.Reader {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range r.File {
+		dst, err := w.Create(f.Name)
+		require.NoError(t, err)
+		if contents, ok := replace[f.Name]; ok {
+			_, err = dst.Write([]byte(contents))
+			require.NoError(t, err)
+			continue
+		}
+		src, err := f.Open()
+		require.NoError(t, err)
+		_, err = io.Copy(dst, src)
+		require.NoError(t, err)
+		require.NoError(t, src.Close())
+	}
+	require.NoError(t, w.Close())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	return zipReader
+}
+
+func TestJsonlExport(t *testing.T) {
+	rctx := request.TestContext(t)
+	fileInfo := &model.FileInfo{Id: "file-1", PostId: "post-1", Name: "report.txt", Path: "data/report.txt", MimeType: "text/plain", Size: 13}
+
+	t.Run("records, attachments and a signed manifest", func(t *testing.T) {
+		td := setupJsonlTest(t)
+		td.store.FileInfoStore.On("GetForPost", "post-1", true, true, false).Return([]*model.FileInfo{fileInfo}, nil)
+		_, err := td.fileBackend.WriteFile(strings.NewReader("file contents"), fileInfo.Path)
+		require.NoError(t, err)
+
+		results, err := JsonlExport(rctx, td.params)
+		require.NoError(t, err)
+		assert.Zero(t, results.NumWarnings)
+		assert.Equal(t, 2, results.CreatedPosts)
+
+		r := openBatch(t, td.fileBackend, td.params.BatchPath)
+		manifest, err := VerifyBatch(r, testPrivateKey.Public().(ed25519.PublicKey))
+		require.NoError(t, err)
+		assert.Equal(t, SchemaID, manifest.SchemaId)
+		assert.Equal(t, td.params.BatchPath, manifest.BatchPath)
+		assert.Equal(t, 5, manifest.RecordCount)
+		var names []string
+		for _, f := range manifest.Files {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"files/post-1/file-1-report.txt", RecordsFilename, SchemaFilename, MetadataFilename}, names)
+
+		schema, err := readZipFile(r, SchemaFilename)
+		require.NoError(t, err)
+		assert.Equal(t, Schema, schema)
+		attachment, err := readZipFile(r, "files/post-1/file-1-report.txt")
+		require.NoError(t, err)
+		assert.Equal(t, "file contents", string(attachment))
+
+		records := readRecords(t, r)
+		require.Len(t, records, 5, "user1 is still in the channel, closed-out leaves are not records")
+
+		assert.Equal(t, PreviouslyJoinedRecord, records[0].Type)
+		assert.Equal(t, "user-1", records[0].UserId)
+		assert.Equal(t, "public", records[0].ChannelType)
+		assert.Equal(t, "team-name", records[0].TeamName)
+
+		assert.Equal(t, MessageRecord, records[1].Type)
+		assert.Equal(t, int64(100), records[1].Time)
+		assert.Equal(t, "post-1", records[1].PostId)
+		assert.Equal(t, "hello <world>", records[1].Message)
+		assert.Equal(t, shared.User, records[1].UserType)
+		assert.Equal(t, []FileRecord{{Id: "file-1", Name: "report.txt", MimeType: "text/plain", Size: 13, Path: "files/post-1/file-1-report.txt"}}, records[1].Files)
+
+		assert.Equal(t, JoinRecord, records[2].Type)
+		assert.Equal(t, int64(200), records[2].Time)
+		assert.Equal(t, "user-2", records[2].UserId)
+
+		assert.Equal(t, MessageRecord, records[3].Type)
+		assert.Equal(t, "post-2", records[3].PostId)
+		assert.Equal(t, "post-1", records[3].RootId)
+
+		assert.Equal(t, LeaveRecord, records[4].Type)
+		assert.Equal(t, int64(600), records[4].Time)
+		assert.Equal(t, "user-2", records[4].UserId)
+	})
+
+	t.Run("missing attachments are reported as warnings", func(t *testing.T) {
+		td := setupJsonlTest(t)
+		td.store.FileInfoStore.On("GetForPost", "post-1", true, true, false).Return([]*model.FileInfo{fileInfo}, nil)
+
+		results, err := JsonlExport(rctx, td.params)
+		require.NoError(t, err)
+		assert.Equal(t, 1, results.NumWarnings)
+
+		r := openBatch(t, td.fileBackend, td.params.BatchPath)
+		_, err = VerifyBatch(r, testPrivateKey.Public().(ed25519.PublicKey))
+		require.NoError(t, err)
+
+		warnings, err := readZipFile(r, JSONLWarningFilename)
+		require.NoError(t, err)
+		assert.Equal(t, "Warning:"+shared.MissingFileMessageDuringBackendRead+" - Post: post-1 - data/report.txt\n", string(warnings))
+
+		records := readRecords(t, r)
+		require.Len(t, records[1].Files, 1)
+		assert.Empty(t, records[1].Files[0].Path)
+	})
+
+	t.Run("fails without a signing key", func(t *testing.T) {
+		td := setupJsonlTest(t)
+		td.params.Posts = nil
+		td.params.Config.MessageExportSettings.JsonlSettings.SigningKey = model.NewPointer("")
+
+		_, err := JsonlExport(rctx, td.params)
+		require.Error(t, err)
+	})
+}
+
+func TestVerifyBatch(t *testing.T) {
+	rctx := request.TestContext(t)
+	td := setupJsonlTest(t)
+	td.params.Posts = td.params.Posts[1:]
+
+	_, err := JsonlExport(rctx, td.params)
+	require.NoError(t, err)
+	r := openBatch(t, td.fileBackend, td.params.BatchPath)
+	publicKey := testPrivateKey.Public().(ed25519.PublicKey)
+
+	t.Run("valid", func(t *testing.T) {
+		_, err := VerifyBatch(r, publicKey)
+		require.NoError(t, err)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		otherKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{8}, ed25519.SeedSize))
+		_, err := VerifyBatch(r, otherKey.Public().(ed25519.PublicKey))
+		require.EqualError(t, err, "the manifest signature is invalid")
+	})
+
+	t.Run("tampered records", func(t *testing.T) {
+		tampered := rewriteZip(t, r, map[string]string{RecordsFilename: "{}\n"})
+		_, err := VerifyBatch(tampered, publicKey)
+		require.EqualError(t, err, `file "messages.jsonl" does not match the manifest`)
+	})
+
+	t.Run("tampered manifest", func(t *testing.T) {
+		data, err := readZipFile(r, ManifestFilename)
+		require.NoError(t, err)
+		tampered := rewriteZip(t, r, map[string]string{ManifestFilename: strings.Replace(string(data), `"record_count": 4`, `"record_count": 3`, 1)})
+		_, err = VerifyBatch(tampered, publicKey)
+		require.EqualError(t, err, "the manifest signature is invalid")
+	})
+}
+
+// TestSchema makes sure the published schema describes every field of Record and FileRecord.
+func TestSchema(t *testing.T) {
+	var schema struct {
+		Id         string                     `json:"$id"`
+		Required   []string                   `json:"required"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       map[string]struct {
+			Required   []string                   `json:"required"`
+			Properties map[string]json.RawMessage `json:"properties"`
+		} `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal(Schema, &schema))
+	assert.Equal(t, SchemaID, schema.Id)
+
+	checkFields := func(typ reflect.Type, properties map[string]json.RawMessage, required []string) {
+		var fields []string
+		for i := 0; i < typ.NumField(); i++ {
+			tag := typ.Field(i).Tag.Get("json")
+			if tag == "" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			fields = append(fields, name)
+			if opts != "omitempty" {
+				assert.Contains(t, required, name, "%s.%s is always present", typ.Name(), name)
+			}
+		}
+		assert.ElementsMatch(t, fields, keys(properties), typ.Name())
+	}
+
+	checkFields(reflect.TypeOf(Record{}), schema.Properties, schema.Required)
+	checkFields(reflect.TypeOf(FileRecord{}), schema.Defs["file"].Properties, schema.Defs["file"].Required)
+}
+
+func keys(m map[string]json.RawMessage) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
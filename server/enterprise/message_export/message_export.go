@@ -17,7 +17,9 @@ import (
 	ejobs "github.com/mattermost/mattermost/server/v8/einterfaces/jobs"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/actiance_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/csv_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/eml_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/global_relay_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/jsonl_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
 )
 
@@ -198,6 +200,14 @@ func RunExportByType(rctx request.CTX, p ExportParams, b shared.BackendParams) (
 		rctx.Logger().Debug("Exporting GlobalRelay")
 		return global_relay_export.GlobalRelayExport(rctx, exportParams)
 
+	case model.ComplianceExportTypeEml, model.ComplianceExportTypeMbox:
+		rctx.Logger().Debug("Exporting EML")
+		return eml_export.EmlExport(rctx, exportParams)
+
+	case model.ComplianceExportTypeJsonl:
+		rctx.Logger().Debug("Exporting JSONL")
+		return jsonl_export.JsonlExport(rctx, exportParams)
+
 	default:
 		return results, errors.New("Unknown output format: " + p.ExportType)
 	}
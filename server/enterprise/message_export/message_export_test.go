@@ -6,10 +6,15 @@ package message_export
 import (
 	"archive/zip"
 	"bytes"
+	"crypto/ed25519"
 	_ "embed"
+	"encoding/base64"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
 	"os"
 	"path"
 	"slices"
@@ -27,7 +32,9 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/store/storetest"
 	st "github.com/mattermost/mattermost/server/v8/channels/store/storetest"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/actiance_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/eml_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/global_relay_export"
+	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/jsonl_export"
 	"github.com/mattermost/mattermost/server/v8/enterprise/message_export/shared"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
@@ -161,6 +168,15 @@ var csvE2E3Batch2Perm2 string
 //go:embed testdata/csvE2E4Batch1.tmpl
 var csvE2E4Batch1 string
 
+//go:embed testdata/emlE2E2Batch1.tmpl
+var emlE2E2Batch1 string
+
+//go:embed testdata/mboxE2E2Batch1.tmpl
+var mboxE2E2Batch1 string
+
+//go:embed testdata/jsonlE2E2Batch1.tmpl
+var jsonlE2E2Batch1 string
+
 func conv(dateTime int64) string {
 	return global_relay_export.TimestampConvert(dateTime)
 }
@@ -1147,6 +1163,127 @@ func testRunExportJobE2E(t *testing.T, exportBackend filestore.FileBackend, expo
 		assert.Equal(t, expectedExport, export)
 	})
 
+	t.Run("EML e2e 2 - post from user not in channel", func(t *testing.T) {
+		th := setup(t)
+		defer th.TearDown()
+		defer func() {
+			err := os.RemoveAll(exportDir)
+			assert.NoError(t, err)
+			err = os.RemoveAll(attachmentDir)
+			assert.NoError(t, err)
+		}()
+
+		ret := generateE2ETestType2Results(t, th, model.ComplianceExportTypeEml, attachmentDir,
+			exportDir, attachmentBackend, exportBackend)
+		posts := ret.posts
+		jl := ret.joinLeaves
+		batches := ret.batches
+		batchTimes := ret.batchTimes
+		cu := ret.createUpdateTimes
+		channels := ret.channels
+		teams := ret.teams
+
+		// one .eml per channel, the transcript is the first MIME part:
+		// 2 participants entered
+		//  message 1
+		//  message 2
+
+		expectedExport := fmt.Sprintf(emlE2E2Batch1,
+			// 1           2              3                      4
+			teams[0].Id, teams[0].Name, teams[0].DisplayName, channels[0].Id,
+			// 5          6            7                                            8
+			posts[0].Id, posts[1].Id, eml_export.TimestampConvert(batchTimes[0].start), eml_export.TimestampConvert(batchTimes[0].end),
+			// 9                                       10                                   11
+			eml_export.TimestampConvert(jl[0].join), eml_export.TimestampConvert(cu[0]), eml_export.TimestampConvert(cu[1]))
+
+		eml := openZipAndReadFile(t, exportBackend, batches[0], fmt.Sprintf("%s - (%s).eml", channels[0].Name, channels[0].Id))
+		assert.Equal(t, expectedExport, readEmlText(t, eml))
+	})
+
+	t.Run("mbox e2e 2 - post from user not in channel", func(t *testing.T) {
+		th := setup(t)
+		defer th.TearDown()
+		defer func() {
+			err := os.RemoveAll(exportDir)
+			assert.NoError(t, err)
+			err = os.RemoveAll(attachmentDir)
+			assert.NoError(t, err)
+		}()
+
+		ret := generateE2ETestType2Results(t, th, model.ComplianceExportTypeMbox, attachmentDir,
+			exportDir, attachmentBackend, exportBackend)
+		posts := ret.posts
+		batches := ret.batches
+		cu := ret.createUpdateTimes
+		channels := ret.channels
+		teams := ret.teams
+
+		// one .mbox per channel with a message per post, both addressed to
+		// user1 and user2 who are in the channel for the whole batch:
+		//  message 1
+		//  message 2
+
+		fromLine := func(ts int64) string { return time.UnixMilli(ts).UTC().Format(time.ANSIC) }
+		date := func(ts int64) string { return time.UnixMilli(ts).UTC().Format(time.RFC1123Z) }
+		expectedExport := fmt.Sprintf(mboxE2E2Batch1,
+			// 1           2               3            4
+			teams[0].Id, channels[0].Id, posts[0].Id, posts[1].Id,
+			// 5               6              7               8
+			fromLine(cu[0]), date(cu[0]), fromLine(cu[1]), date(cu[1]))
+
+		mbox := openZipAndReadFile(t, exportBackend, batches[0], fmt.Sprintf("%s - (%s).mbox", channels[0].Name, channels[0].Id))
+		assert.Equal(t, expectedExport, mbox)
+	})
+
+	t.Run("JSONL e2e 2 - post from user not in channel", func(t *testing.T) {
+		th := setup(t)
+		defer th.TearDown()
+		defer func() {
+			err := os.RemoveAll(exportDir)
+			assert.NoError(t, err)
+			err = os.RemoveAll(attachmentDir)
+			assert.NoError(t, err)
+		}()
+
+		signingKey := ed25519.NewKeyFromSeed(bytes.Repeat([]byte{1}, ed25519.SeedSize))
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.MessageExportSettings.JsonlSettings.SigningKey = base64.StdEncoding.EncodeToString(signingKey.Seed())
+		})
+
+		ret := generateE2ETestType2Results(t, th, model.ComplianceExportTypeJsonl, attachmentDir,
+			exportDir, attachmentBackend, exportBackend)
+		posts := ret.posts
+		jl := ret.joinLeaves
+		batches := ret.batches
+		batchTimes := ret.batchTimes
+		cu := ret.createUpdateTimes
+		users := ret.users
+		channels := ret.channels
+		teams := ret.teams
+
+		// to align with csv export:
+		// 2 participants entered
+		//  message 1
+		//  message 2
+
+		expectedExport := fmt.Sprintf(jsonlE2E2Batch1,
+			// 1           2              3                      4              5
+			teams[0].Id, teams[0].Name, teams[0].DisplayName, channels[0].Id, users[0].Id,
+			// 6          7              8           9          10                   11      12
+			users[1].Id, posts[0].Id, posts[1].Id, jl[0].join, batchTimes[0].start, cu[0], cu[1])
+
+		zipBytes, err := exportBackend.ReadFile(batches[0])
+		require.NoError(t, err)
+		zipReader, err := zip.NewReader(bytes.NewReader(zipBytes), int64(len(zipBytes)))
+		require.NoError(t, err)
+		manifest, err := jsonl_export.VerifyBatch(zipReader, signingKey.Public().(ed25519.PublicKey))
+		require.NoError(t, err)
+		assert.Equal(t, 4, manifest.RecordCount)
+
+		export := readFileFromZip(t, zipBytes, jsonl_export.RecordsFilename)
+		assert.Equal(t, expectedExport, export)
+	})
+
 	t.Run("actiance e2e 3 - test create, update, delete xml fields", func(t *testing.T) {
 		th := setup(t)
 		defer th.TearDown()
@@ -2591,6 +2728,21 @@ func readFilenumFromZip(t *testing.T, zipBytes []byte, fileNum int) string {
 	return string(contents)
 }
 
+// readEmlText returns the decoded transcript part of an exported message, with LF line endings.
+func readEmlText(t *testing.T, eml string) string {
+	msg, err := mail.ReadMessage(strings.NewReader(eml))
+	require.NoError(t, err)
+	_, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	require.NoError(t, err)
+
+	part, err := multipart.NewReader(msg.Body, params["boundary"]).NextPart()
+	require.NoError(t, err)
+	text, err := io.ReadAll(part)
+	require.NoError(t, err)
+
+	return strings.ReplaceAll(string(text), "\r\n", "\n")
+}
+
 func dataContainsOneOfExpected(data string, expected []string) bool {
 	for _, perm := range expected {
 		if strings.Contains(data, perm) {
@@ -52,6 +52,10 @@ const (
 	JobDataExportDir               = "export_dir"
 	JobDataBatchNumber             = "job_batch_number"
 	JobDataTotalPostsExpected      = "total_posts_expected"
+
+	// JobDataLegalHoldId restricts the job to the posts covered by a single legal hold. These jobs are created on
+	// demand and are never used as the starting point of the scheduled exports.
+	JobDataLegalHoldId = model.LegalHoldJobDataKey
 )
 
 type PostUpdatedType string
@@ -103,6 +107,7 @@ type JobDataExported struct {
 	MessagesExported        int
 	WarningCount            int
 	IsDownloadable          bool
+	LegalHoldId             string
 }
 
 func JobDataToStringMap(jd JobData) map[string]string {
@@ -122,6 +127,7 @@ func JobDataToStringMap(jd JobData) map[string]string {
 	ret[JobDataMessagesExported] = strconv.Itoa(jd.MessagesExported)
 	ret[JobDataWarningCount] = strconv.Itoa(jd.WarningCount)
 	ret[JobDataIsDownloadable] = strconv.FormatBool(jd.IsDownloadable)
+	ret[JobDataLegalHoldId] = jd.LegalHoldId
 	return ret
 }
 
@@ -224,6 +230,8 @@ func StringMapToJobDataWithZeroValues(sm map[string]string) (JobData, error) {
 		return jd, errors.Wrap(err, "error converting JobDataIsDownloadable")
 	}
 
+	jd.LegalHoldId = sm[JobDataLegalHoldId]
+
 	return jd, nil
 }
 
@@ -367,6 +375,7 @@ func GetInitialExportPeriodData(rctx request.CTX, store MessageExportStore, data
 		LastPostUpdateAt: data.BatchStartTime,
 		LastPostId:       data.BatchStartId,
 		UntilUpdateAt:    data.JobEndTime,
+		LegalHoldId:      data.LegalHoldId,
 	}
 
 	return data, nil
@@ -223,6 +223,7 @@ func TestJobDataToStringMap_and_StringMapToJobData(t *testing.T) {
 			TotalPostsExpected:      999999,
 			MessagesExported:        343499,
 			WarningCount:            39,
+			LegalHoldId:             "legalholdid",
 		},
 		ExportPeriodStartTime: 123456,                  // not exported
 		BatchEndTime:          999999999,               // not exported
@@ -247,6 +248,7 @@ func TestJobDataToStringMap_and_StringMapToJobData(t *testing.T) {
 	expected[JobDataMessagesExported] = "343499"
 	expected[JobDataWarningCount] = "39"
 	expected[JobDataIsDownloadable] = "false"
+	expected[JobDataLegalHoldId] = "legalholdid"
 
 	for k, v := range expected {
 		val, ok := strMap[k]
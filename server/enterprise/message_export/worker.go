@@ -366,7 +366,8 @@ func (w *MessageExportWorker) initJobData(rctx request.CTX, logger mlog.LoggerIF
 	job.Data[shared.JobDataExportDir] = getJobExportDir(logger, job.Data, job.Data[shared.JobDataJobStartTime], job.Data[shared.JobDataJobEndTime])
 }
 
-// getPreviousNonCliJob returns the most recent job that was not initiated by mmctl
+// getPreviousNonCliJob returns the most recent job that was not initiated by mmctl. Legal hold exports are skipped
+// too, since they only cover part of the data and must not move the starting point of the scheduled exports.
 func (w *MessageExportWorker) getPreviousNonCliJob(rctx request.CTX) (*model.Job, error) {
 	offset := 0
 
@@ -381,7 +382,7 @@ func (w *MessageExportWorker) getPreviousNonCliJob(rctx request.CTX) (*model.Job
 
 		// Find the first job not initiated by mmctl
 		for _, job := range jobs {
-			if job.Data == nil || job.Data[shared.JobDataInitiatedBy] != "mmctl" {
+			if job.Data == nil || (job.Data[shared.JobDataInitiatedBy] != "mmctl" && job.Data[shared.JobDataLegalHoldId] == "") {
 				return job, nil
 			}
 		}
@@ -424,7 +425,8 @@ func extractJobData(logger *mlog.Logger, strmap map[string]string) (shared.JobDa
 		mlog.Int("channel_batch_size", data.ChannelBatchSize),
 		mlog.Int("channel_history_batch_size", data.ChannelHistoryBatchSize),
 		mlog.Int("batch_number", data.BatchNumber),
-		mlog.Int("total_posts_exported", data.MessagesExported))
+		mlog.Int("total_posts_exported", data.MessagesExported),
+		mlog.String("legal_hold_id", data.LegalHoldId))
 
 	return data, err
 }
@@ -443,6 +445,9 @@ func getJobExportDir(logger mlog.LoggerIFace, data model.StringMap, startTime st
 	if !exists {
 		// If we don't have a jobDataExportDir, this is the first run for the job, so we use the batch startTime
 		exportDir = path.Join(model.ComplianceExportPath, fmt.Sprintf("%s-%s-%s", time.Now().Format(model.ComplianceExportDirectoryFormat), startTime, endTime))
+		if legalHoldID := data[shared.JobDataLegalHoldId]; legalHoldID != "" {
+			exportDir = path.Join(model.ComplianceExportPath, fmt.Sprintf("%s-legal-hold-%s-%s-%s", time.Now().Format(model.ComplianceExportDirectoryFormat), legalHoldID, startTime, endTime))
+		}
 		logger.Info("Worker: JobDataExportDir does not exist, using current datetime", mlog.String("job_data_export_dir", exportDir))
 	}
 
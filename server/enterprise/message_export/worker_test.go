@@ -682,6 +682,43 @@ func TestGetPreviousJobOneMmctlJob(t *testing.T) {
 	assert.Nil(t, job, "Expected nil job when only mmctl jobs are found")
 }
 
+func TestGetPreviousJobSkipsLegalHoldJob(t *testing.T) {
+	logger := mlog.CreateConsoleTestLogger(t)
+	mockStore := &storetest.Store{}
+	defer mockStore.AssertExpectations(t)
+
+	legalHoldJob := &model.Job{
+		Id:     st.NewTestID(),
+		Status: model.JobStatusSuccess,
+		Type:   model.JobTypeMessageExport,
+		Data:   map[string]string{shared.JobDataLegalHoldId: model.NewId()},
+	}
+	regularJob := &model.Job{
+		Id:     st.NewTestID(),
+		Status: model.JobStatusSuccess,
+		Type:   model.JobTypeMessageExport,
+		Data:   map[string]string{},
+	}
+
+	mockStore.JobStore.On("GetAllByTypesAndStatusesPage", mock.Anything,
+		[]string{model.JobTypeMessageExport},
+		[]string{model.JobStatusWarning, model.JobStatusSuccess},
+		0, DefaultPreviousJobPageSize).Return([]*model.Job{legalHoldJob, regularJob}, nil).Once()
+
+	worker := &MessageExportWorker{
+		jobServer: &jobs.JobServer{
+			Store: mockStore,
+		},
+		logger: logger,
+	}
+
+	rctx := request.EmptyContext(logger)
+	job, err := worker.getPreviousNonCliJob(rctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, regularJob.Id, job.Id, "Expected legal hold exports to be skipped")
+}
+
 func TestGetPreviousJobManyJobs(t *testing.T) {
 	logger := mlog.CreateConsoleTestLogger(t)
 	mockStore := &storetest.Store{}
@@ -0,0 +1,87 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// CacheStatsCollector exports the statistics of the server caches.
+// The statistics are read from the source when the metrics are
+// scraped, so the caches don't have to report every lookup.
+type CacheStatsCollector struct {
+	mut      sync.RWMutex
+	getStats func() []*model.CacheStats
+
+	size      *prometheus.Desc
+	length    *prometheus.Desc
+	hits      *prometheus.Desc
+	misses    *prometheus.Desc
+	evictions *prometheus.Desc
+	hitRatio  *prometheus.Desc
+	memory    *prometheus.Desc
+}
+
+// NewCacheStatsCollector creates a collector without any source. It
+// doesn't export anything until SetSource is called.
+func NewCacheStatsCollector(constLabels prometheus.Labels) *CacheStatsCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(MetricsNamespace, MetricsSubsystemCaching, name), help, []string{"name", "type"}, constLabels)
+	}
+
+	return &CacheStatsCollector{
+		size:      desc("size", "Maximum number of entries of the cache"),
+		length:    desc("entries", "Current number of entries of the cache"),
+		hits:      desc("hits_total", "Total number of lookups found in the cache"),
+		misses:    desc("misses_total", "Total number of lookups not found in the cache"),
+		evictions: desc("evictions_total", "Total number of entries evicted to make room for new ones"),
+		hitRatio:  desc("hit_ratio", "Fraction of the lookups found in the cache"),
+		memory:    desc("memory_bytes", "Estimated memory used by the entries of the cache"),
+	}
+}
+
+// SetSource sets the function returning the statistics of the caches.
+func (c *CacheStatsCollector) SetSource(getStats func() []*model.CacheStats) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.getStats = getStats
+}
+
+func (c *CacheStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.size
+	ch <- c.length
+	ch <- c.hits
+	ch <- c.misses
+	ch <- c.evictions
+	ch <- c.hitRatio
+	ch <- c.memory
+}
+
+func (c *CacheStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mut.RLock()
+	getStats := c.getStats
+	c.mut.RUnlock()
+
+	if getStats == nil {
+		return
+	}
+
+	for _, stats := range getStats() {
+		labels := []string{stats.Name, stats.Type}
+		// Redis caches are bounded and evicted by Redis itself
+		if stats.Type != model.CacheTypeRedis {
+			ch <- prometheus.MustNewConstMetric(c.size, prometheus.GaugeValue, float64(stats.Size), labels...)
+			ch <- prometheus.MustNewConstMetric(c.length, prometheus.GaugeValue, float64(stats.Len), labels...)
+			ch <- prometheus.MustNewConstMetric(c.evictions, prometheus.CounterValue, float64(stats.Evictions), labels...)
+			ch <- prometheus.MustNewConstMetric(c.memory, prometheus.GaugeValue, float64(stats.MemoryBytes), labels...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(stats.Hits), labels...)
+		ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(stats.Misses), labels...)
+		ch <- prometheus.MustNewConstMetric(c.hitRatio, prometheus.GaugeValue, stats.HitRatio, labels...)
+	}
+}
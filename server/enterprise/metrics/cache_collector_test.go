@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.enterprise for license information.
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestCacheStatsCollector(t *testing.T) {
+	collector := NewCacheStatsCollector(nil)
+
+	t.Run("nothing is collected without a source", func(t *testing.T) {
+		require.Equal(t, 0, testutil.CollectAndCount(collector))
+	})
+
+	collector.SetSource(func() []*model.CacheStats {
+		return []*model.CacheStats{
+			{Name: "Role", Type: model.CacheTypeLRU, Size: 20000, Len: 10, Hits: 3, Misses: 1, Evictions: 2, HitRatio: 0.75, MemoryBytes: 2048},
+			{Name: "Post", Type: model.CacheTypeRedis, Len: -1, Hits: 1, Misses: 1, HitRatio: 0.5},
+		}
+	})
+
+	t.Run("collects the statistics of every cache", func(t *testing.T) {
+		expected := `
+# HELP mattermost_cache_evictions_total Total number of entries evicted to make room for new ones
+# TYPE mattermost_cache_evictions_total counter
+mattermost_cache_evictions_total{name="Role",type="lru"} 2
+# HELP mattermost_cache_hit_ratio Fraction of the lookups found in the cache
+# TYPE mattermost_cache_hit_ratio gauge
+mattermost_cache_hit_ratio{name="Post",type="redis"} 0.5
+mattermost_cache_hit_ratio{name="Role",type="lru"} 0.75
+`
+		err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "mattermost_cache_evictions_total", "mattermost_cache_hit_ratio")
+		require.NoError(t, err)
+	})
+
+	t.Run("skips the size metrics of Redis caches", func(t *testing.T) {
+		require.Equal(t, 7+3, testutil.CollectAndCount(collector))
+	})
+}
@@ -95,6 +95,8 @@ type MetricsInterfaceImpl struct {
 	MemCacheMissCounterSession         prometheus.Counter
 	MemCacheInvalidationCounterSession prometheus.Counter
 
+	CacheStatsCollector *CacheStatsCollector
+
 	WebsocketEventCounters *prometheus.CounterVec
 
 	WebSocketBroadcastCounters                    *prometheus.CounterVec
@@ -151,6 +153,8 @@ type MetricsInterfaceImpl struct {
 	WebSocketBroadcastBufferGauge                *prometheus.GaugeVec
 	WebSocketBroadcastBufferUsersRegisteredGauge *prometheus.GaugeVec
 	WebSocketReconnectCounter                    *prometheus.CounterVec
+	WebSocketFilteredEventsCounter               *prometheus.CounterVec
+	WebSocketFilteredBytesCounter                prometheus.Counter
 
 	SearchPostSearchesCounter  prometheus.Counter
 	SearchPostSearchesDuration prometheus.Histogram
@@ -526,6 +530,7 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 		model.ClusterEventRemovePlugin,
 		model.ClusterEventPluginEvent,
 		model.ClusterEventInvalidateCacheForTermsOfService,
+		model.ClusterEventInvalidateCacheForHybrid,
 		model.ClusterEventBusyStateChanged,
 	} {
 		m.ClusterEventMap[event] = m.ClusterEventTypeCounters.With(prometheus.Labels{"name": string(event)})
@@ -617,6 +622,9 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	m.Registry.MustRegister(m.MemCacheInvalidationCounters)
 	m.MemCacheInvalidationCounterSession = m.MemCacheInvalidationCounters.With(prometheus.Labels{"name": "Session"})
 
+	m.CacheStatsCollector = NewCacheStatsCollector(additionalLabels)
+	m.Registry.MustRegister(m.CacheStatsCollector)
+
 	// Websocket Subsystem
 
 	m.WebSocketBroadcastCounters = prometheus.NewCounterVec(
@@ -728,6 +736,29 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.WebSocketReconnectCounter)
 
+	m.WebSocketFilteredEventsCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemWebsocket,
+			Name:        "filtered_events_total",
+			Help:        "Total number of websocket events not sent because of the subscription filters of a connection",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"type"},
+	)
+	m.Registry.MustRegister(m.WebSocketFilteredEventsCounter)
+
+	m.WebSocketFilteredBytesCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemWebsocket,
+			Name:        "filtered_bytes_total",
+			Help:        "Total number of bytes not sent because of the subscription filters of websocket connections",
+			ConstLabels: additionalLabels,
+		},
+	)
+	m.Registry.MustRegister(m.WebSocketFilteredBytesCounter)
+
 	// Search Subsystem
 
 	m.SearchPostSearchesCounter = prometheus.NewCounter(prometheus.CounterOpts{
@@ -1601,6 +1632,10 @@ func (mi *MetricsInterfaceImpl) UnregisterDBCollector(db *sql.DB, name string) {
 	mi.Registry.Unregister(collectors.NewDBStatsCollector(db, name))
 }
 
+func (mi *MetricsInterfaceImpl) RegisterCacheStatsCollector(getStats func() []*model.CacheStats) {
+	mi.CacheStatsCollector.SetSource(getStats)
+}
+
 func (mi *MetricsInterfaceImpl) IncrementPostCreate() {
 	mi.PostCreateCounter.Inc()
 }
@@ -1820,6 +1855,11 @@ func (mi *MetricsInterfaceImpl) IncrementWebsocketEvent(eventType model.Websocke
 	mi.WebsocketEventCounters.With(prometheus.Labels{"type": string(eventType)}).Inc()
 }
 
+func (mi *MetricsInterfaceImpl) AddWebSocketFilteredEvent(eventType model.WebsocketEventType, bytes float64) {
+	mi.WebSocketFilteredEventsCounter.With(prometheus.Labels{"type": string(eventType)}).Inc()
+	mi.WebSocketFilteredBytesCounter.Add(bytes)
+}
+
 func (mi *MetricsInterfaceImpl) IncrementWebsocketReconnectEventWithDisconnectErrCode(eventType string, disconnectErrCode string) {
 	if disconnectErrCode == "" {
 		disconnectErrCode = "unknown"
@@ -13,6 +13,9 @@ import (
 // ErrKeyNotFound is the error when the given key is not found
 var ErrKeyNotFound = errors.New("key not found")
 
+// ErrResizeNotSupported is the error when the cache can't be resized
+var ErrResizeNotSupported = errors.New("resize not supported")
+
 // Cache is a representation of a cache store that aims to replace cache.Cache
 type Cache interface {
 	// Purge is used to completely clear the cache.
@@ -51,6 +54,15 @@ type Cache interface {
 
 	// Name returns the name of the cache
 	Name() string
+
+	// Stats returns a snapshot of the usage statistics of the cache.
+	Stats() *model.CacheStats
+
+	// Resize changes the maximum number of entries of the cache, evicting the
+	// least recently used entries if needed. A size of 0 restores the size
+	// the cache was created with. Returns ErrResizeNotSupported for caches
+	// whose size isn't managed by the server.
+	Resize(size int) error
 }
 
 // ExternalCache is a super-set of the Cache interface with
@@ -66,3 +78,10 @@ type ExternalCache interface {
 	// number stored at that key by the value.
 	Decrement(key string, val int) error
 }
+
+func hitRatio(hits, misses int64) float64 {
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// hybridInvalidation is the message sent to the other nodes when the
+// entries of a hybrid cache change, so they drop their local copies.
+type hybridInvalidation struct {
+	Node  string   `json:"node"`
+	Cache string   `json:"cache"`
+	Keys  []string `json:"keys,omitempty"`
+	Purge bool     `json:"purge,omitempty"`
+}
+
+// Hybrid is a two-level cache: a small LRU local to the node in front of
+// a shared external cache. Reads are served from the LRU when possible,
+// and writes go to the external cache, dropping the local copies of every
+// node.
+type Hybrid struct {
+	local    *LRU
+	remote   ExternalCache
+	localTTL time.Duration
+	// publish sends an invalidation to the other nodes.
+	publish func(msg *hybridInvalidation)
+	// version is increased on every invalidation, to avoid storing
+	// locally a value that was invalidated while it was being read.
+	version atomic.Uint64
+	// localMut makes the invalidations and the version check of setLocal
+	// atomic with respect to each other.
+	localMut sync.Mutex
+}
+
+// NewHybrid creates a hybrid cache in front of the given external cache.
+// The local entries expire after localTTL at most, which bounds how stale
+// they can get if an invalidation is lost.
+func NewHybrid(opts *CacheOptions, remote ExternalCache, localTTL time.Duration, publish func(msg *hybridInvalidation)) (*Hybrid, error) {
+	if opts.Name == "" {
+		return nil, errors.New("no name specified for cache")
+	}
+	if opts.DefaultExpiry > 0 && opts.DefaultExpiry < localTTL {
+		localTTL = opts.DefaultExpiry
+	}
+
+	return &Hybrid{
+		local: NewLRU(&CacheOptions{
+			Name: opts.Name,
+			Size: opts.Size,
+		}).(*LRU),
+		remote:   remote,
+		localTTL: localTTL,
+		publish:  publish,
+	}, nil
+}
+
+// Purge is used to completely clear the cache.
+func (h *Hybrid) Purge() error {
+	err := h.remote.Purge()
+	h.invalidate(&hybridInvalidation{Purge: true})
+	return err
+}
+
+// SetWithDefaultExpiry adds the given key and value to the store with the default expiry. If
+// the key already exists, it will overwrite the previous value
+func (h *Hybrid) SetWithDefaultExpiry(key string, value any) error {
+	err := h.remote.SetWithDefaultExpiry(key, value)
+	h.invalidate(&hybridInvalidation{Keys: []string{key}})
+	return err
+}
+
+// SetWithExpiry adds the given key and value to the cache with the given expiry. If the key
+// already exists, it will overwrite the previous value
+func (h *Hybrid) SetWithExpiry(key string, value any, ttl time.Duration) error {
+	err := h.remote.SetWithExpiry(key, value, ttl)
+	h.invalidate(&hybridInvalidation{Keys: []string{key}})
+	return err
+}
+
+// Increment increments the value of the key by the value.
+func (h *Hybrid) Increment(key string, val int) error {
+	err := h.remote.Increment(key, val)
+	h.invalidate(&hybridInvalidation{Keys: []string{key}})
+	return err
+}
+
+// Decrement decrements the value of the key by the value.
+func (h *Hybrid) Decrement(key string, val int) error {
+	err := h.remote.Decrement(key, val)
+	h.invalidate(&hybridInvalidation{Keys: []string{key}})
+	return err
+}
+
+// Get the content stored in the cache for the given key, and decode it into the value interface.
+// Return ErrKeyNotFound if the key is missing from the cache
+func (h *Hybrid) Get(key string, value any) error {
+	if err := h.local.Get(key, value); err == nil {
+		return nil
+	}
+
+	version := h.version.Load()
+	if err := h.remote.Get(key, value); err != nil {
+		return err
+	}
+	h.setLocal(version, key, value)
+
+	return nil
+}
+
+// GetMulti returns the values of multiple keys, reading from the external
+// cache only the ones missing locally.
+func (h *Hybrid) GetMulti(keys []string, values []any) []error {
+	errs := h.local.GetMulti(keys, values)
+
+	var missingIndexes []int
+	for i, err := range errs {
+		if err != nil {
+			missingIndexes = append(missingIndexes, i)
+		}
+	}
+	if len(missingIndexes) == 0 {
+		return errs
+	}
+
+	missingKeys := make([]string, len(missingIndexes))
+	missingValues := make([]any, len(missingIndexes))
+	for i, index := range missingIndexes {
+		missingKeys[i] = keys[index]
+		missingValues[i] = values[index]
+	}
+
+	version := h.version.Load()
+	remoteErrs := h.remote.GetMulti(missingKeys, missingValues)
+	for i, index := range missingIndexes {
+		errs[index] = remoteErrs[i]
+		if remoteErrs[i] == nil {
+			h.setLocal(version, keys[index], values[index])
+		}
+	}
+
+	return errs
+}
+
+// Remove deletes the value for a given key.
+func (h *Hybrid) Remove(key string) error {
+	err := h.remote.Remove(key)
+	h.invalidate(&hybridInvalidation{Keys: []string{key}})
+	return err
+}
+
+// RemoveMulti deletes multiple keys in a single operation.
+func (h *Hybrid) RemoveMulti(keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	err := h.remote.RemoveMulti(keys)
+	h.invalidate(&hybridInvalidation{Keys: keys})
+	return err
+}
+
+// Scan iterates over the keys of the external cache.
+func (h *Hybrid) Scan(f func([]string) error) error {
+	return h.remote.Scan(f)
+}
+
+// GetInvalidateClusterEvent returns ClusterEventNone, since the cache
+// sends its own invalidations to the other nodes.
+func (h *Hybrid) GetInvalidateClusterEvent() model.ClusterEvent {
+	return model.ClusterEventNone
+}
+
+func (h *Hybrid) Name() string {
+	return h.remote.Name()
+}
+
+// Stats returns the size of the local cache and the lookups made by this
+// node. A lookup missing locally counts as a hit if it's found in the
+// external cache.
+func (h *Hybrid) Stats() *model.CacheStats {
+	stats := h.local.Stats()
+	remoteStats := h.remote.Stats()
+
+	stats.Name = h.Name()
+	stats.Type = model.CacheTypeHybrid
+	stats.Hits += remoteStats.Hits
+	stats.Misses = remoteStats.Misses
+	stats.HitRatio = hitRatio(stats.Hits, stats.Misses)
+
+	return stats
+}
+
+// Resize changes the maximum number of entries of the local cache.
+func (h *Hybrid) Resize(size int) error {
+	return h.local.Resize(size)
+}
+
+// invalidate drops the local copies of the entries of the message, on
+// this node and on the others.
+func (h *Hybrid) invalidate(msg *hybridInvalidation) {
+	h.invalidateLocal(msg)
+
+	msg.Cache = h.Name()
+	if h.publish != nil {
+		h.publish(msg)
+	}
+}
+
+// invalidateLocal drops the local copies of the entries of the message.
+func (h *Hybrid) invalidateLocal(msg *hybridInvalidation) {
+	h.localMut.Lock()
+	defer h.localMut.Unlock()
+
+	h.version.Add(1)
+	if msg.Purge {
+		_ = h.local.Purge()
+		return
+	}
+	_ = h.local.RemoveMulti(msg.Keys)
+}
+
+// setLocal stores a value read from the external cache, unless there was
+// an invalidation since the read started. value is the pointer the entry
+// was decoded into, which encodes the same as the entry itself.
+func (h *Hybrid) setLocal(version uint64, key string, value any) {
+	h.localMut.Lock()
+	defer h.localMut.Unlock()
+
+	if h.version.Load() != version {
+		return
+	}
+	_ = h.local.SetWithExpiry(key, value, h.localTTL)
+}
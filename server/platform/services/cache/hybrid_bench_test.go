@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+// slowExternalCache adds a fixed latency to the reads of the external
+// cache, standing in for the round trip to Redis.
+type slowExternalCache struct {
+	*fakeExternalCache
+	latency time.Duration
+}
+
+func (c *slowExternalCache) Get(key string, value any) error {
+	time.Sleep(c.latency)
+	return c.fakeExternalCache.Get(key, value)
+}
+
+// BenchmarkHybrid compares the read latency of the external cache alone
+// with the hybrid cache in front of it, reporting the p99 of the reads.
+func BenchmarkHybrid(b *testing.B) {
+	const (
+		keyCount = 1000
+		// most reads go to a small set of hot keys, like the users
+		// and channels of an active team
+		hotKeyCount = 50
+		latency     = 200 * time.Microsecond
+	)
+
+	remote := &slowExternalCache{
+		fakeExternalCache: &fakeExternalCache{NewLRU(&CacheOptions{Name: "bench", Size: keyCount})},
+		latency:           latency,
+	}
+	keys := make([]string, keyCount)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+		if err := remote.SetWithDefaultExpiry(keys[i], "value"); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	// keyAt returns a hot key for 199 reads out of 200
+	keyAt := func(i int) string {
+		if i%200 == 0 {
+			return keys[(i/200)%keyCount]
+		}
+		return keys[i%hotKeyCount]
+	}
+
+	run := func(b *testing.B, c ExternalCache) {
+		durations := make([]time.Duration, 0, b.N)
+		var v string
+		i := 0
+		for b.Loop() {
+			start := time.Now()
+			if err := c.Get(keyAt(i), &v); err != nil {
+				b.Fatal(err)
+			}
+			durations = append(durations, time.Since(start))
+			i++
+		}
+
+		slices.Sort(durations)
+		b.ReportMetric(float64(durations[len(durations)*99/100].Nanoseconds()), "p99-ns")
+	}
+
+	b.Run("remote", func(b *testing.B) {
+		run(b, remote)
+	})
+
+	b.Run("hybrid", func(b *testing.B) {
+		h, err := NewHybrid(&CacheOptions{Name: "bench", Size: hotKeyCount * 2}, remote, time.Minute, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+		run(b, h)
+	})
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package cache
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// fakeExternalCache stands in for Redis, shared by every node.
+type fakeExternalCache struct {
+	Cache
+}
+
+func (c *fakeExternalCache) Increment(key string, val int) error {
+	var count int64
+	if err := c.Get(key, &count); err != nil {
+		return err
+	}
+	return c.SetWithDefaultExpiry(key, count+int64(val))
+}
+
+func (c *fakeExternalCache) Decrement(key string, val int) error {
+	return c.Increment(key, -val)
+}
+
+// newHybridNodes creates a hybrid cache for each of two nodes, sharing
+// the same external cache and delivering the invalidations to each other.
+func newHybridNodes(t *testing.T) (*Hybrid, *Hybrid, *fakeExternalCache) {
+	remote := &fakeExternalCache{NewLRU(&CacheOptions{Name: "test", Size: 100})}
+
+	var node1, node2 *Hybrid
+	var err error
+	node1, err = NewHybrid(&CacheOptions{Name: "test", Size: 10}, remote, time.Minute, func(msg *hybridInvalidation) {
+		node2.invalidateLocal(msg)
+	})
+	require.NoError(t, err)
+	node2, err = NewHybrid(&CacheOptions{Name: "test", Size: 10}, remote, time.Minute, func(msg *hybridInvalidation) {
+		node1.invalidateLocal(msg)
+	})
+	require.NoError(t, err)
+
+	return node1, node2, remote
+}
+
+func TestHybrid(t *testing.T) {
+	t.Run("reads are served locally once fetched", func(t *testing.T) {
+		node1, _, remote := newHybridNodes(t)
+
+		require.NoError(t, node1.SetWithDefaultExpiry("key", "value"))
+
+		var v string
+		require.NoError(t, node1.Get("key", &v))
+		assert.Equal(t, "value", v)
+		assert.Equal(t, int64(1), remote.Stats().Hits)
+
+		require.NoError(t, node1.Get("key", &v))
+		assert.Equal(t, "value", v)
+		assert.Equal(t, int64(1), remote.Stats().Hits)
+	})
+
+	t.Run("writes drop the local copies of every node", func(t *testing.T) {
+		node1, node2, _ := newHybridNodes(t)
+
+		require.NoError(t, node1.SetWithDefaultExpiry("key", "old"))
+		var v string
+		require.NoError(t, node2.Get("key", &v))
+		require.Equal(t, "old", v)
+
+		require.NoError(t, node1.SetWithDefaultExpiry("key", "new"))
+		require.NoError(t, node2.Get("key", &v))
+		assert.Equal(t, "new", v)
+
+		require.NoError(t, node1.Remove("key"))
+		assert.Equal(t, ErrKeyNotFound, node2.Get("key", &v))
+	})
+
+	t.Run("purge drops every local copy", func(t *testing.T) {
+		node1, node2, _ := newHybridNodes(t)
+
+		require.NoError(t, node1.SetWithDefaultExpiry("key", "value"))
+		var v string
+		require.NoError(t, node2.Get("key", &v))
+
+		require.NoError(t, node1.Purge())
+		assert.Equal(t, ErrKeyNotFound, node2.Get("key", &v))
+	})
+
+	t.Run("counters are invalidated", func(t *testing.T) {
+		node1, node2, _ := newHybridNodes(t)
+
+		require.NoError(t, node1.SetWithDefaultExpiry("count", int64(1)))
+		var count int64
+		require.NoError(t, node2.Get("count", &count))
+		require.Equal(t, int64(1), count)
+
+		require.NoError(t, node1.Increment("count", 2))
+		require.NoError(t, node2.Get("count", &count))
+		assert.Equal(t, int64(3), count)
+	})
+
+	t.Run("get multi only reads the missing keys remotely", func(t *testing.T) {
+		node1, _, remote := newHybridNodes(t)
+
+		require.NoError(t, node1.SetWithDefaultExpiry("key1", "value1"))
+		require.NoError(t, node1.SetWithDefaultExpiry("key2", "value2"))
+		var v string
+		require.NoError(t, node1.Get("key1", &v))
+
+		var v1, v2, v3 string
+		errs := node1.GetMulti([]string{"key1", "key2", "key3"}, []any{&v1, &v2, &v3})
+		require.NoError(t, errs[0])
+		require.NoError(t, errs[1])
+		assert.Equal(t, ErrKeyNotFound, errs[2])
+		assert.Equal(t, "value1", v1)
+		assert.Equal(t, "value2", v2)
+
+		stats := remote.Stats()
+		assert.Equal(t, int64(2), stats.Hits)
+		assert.Equal(t, int64(1), stats.Misses)
+	})
+
+	t.Run("values invalidated while being read aren't kept locally", func(t *testing.T) {
+		node1, _, _ := newHybridNodes(t)
+
+		require.NoError(t, node1.SetWithDefaultExpiry("key", "value"))
+
+		version := node1.version.Load()
+		node1.invalidateLocal(&hybridInvalidation{Keys: []string{"key"}})
+		node1.setLocal(version, "key", "value")
+
+		var v string
+		assert.Equal(t, ErrKeyNotFound, node1.local.Get("key", &v))
+	})
+
+	t.Run("stats", func(t *testing.T) {
+		node1, _, _ := newHybridNodes(t)
+
+		require.NoError(t, node1.SetWithDefaultExpiry("key", "value"))
+		var v string
+		require.NoError(t, node1.Get("key", &v))
+		require.NoError(t, node1.Get("key", &v))
+		require.Equal(t, ErrKeyNotFound, node1.Get("missing", &v))
+
+		stats := node1.Stats()
+		assert.Equal(t, model.CacheTypeHybrid, stats.Type)
+		assert.Equal(t, 10, stats.Size)
+		assert.Equal(t, 1, stats.Len)
+		assert.Equal(t, int64(2), stats.Hits)
+		assert.Equal(t, int64(1), stats.Misses)
+	})
+}
+
+func TestHybridProviderReceive(t *testing.T) {
+	remote := &fakeExternalCache{NewLRU(&CacheOptions{Name: "test", Size: 100})}
+	h, err := NewHybrid(&CacheOptions{Name: "test", Size: 10}, remote, time.Minute, nil)
+	require.NoError(t, err)
+	p := &hybridProvider{
+		nodeID:  model.NewId(),
+		hybrids: map[string]*Hybrid{"test": h},
+	}
+
+	require.NoError(t, h.SetWithDefaultExpiry("key", "value"))
+	var v string
+	require.NoError(t, h.Get("key", &v))
+
+	receive := func(msg *hybridInvalidation) {
+		data, err := json.Marshal(msg)
+		require.NoError(t, err)
+		p.receive(data)
+	}
+
+	receive(&hybridInvalidation{Node: p.nodeID, Cache: "test", Keys: []string{"key"}})
+	require.NoError(t, h.local.Get("key", &v), "invalidations sent by the node itself should be ignored")
+
+	receive(&hybridInvalidation{Node: model.NewId(), Cache: "other", Keys: []string{"key"}})
+	require.NoError(t, h.local.Get("key", &v))
+
+	receive(&hybridInvalidation{Node: model.NewId(), Cache: "test", Keys: []string{"key"}})
+	require.Equal(t, ErrKeyNotFound, h.local.Get("key", &v))
+}
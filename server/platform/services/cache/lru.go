@@ -5,6 +5,7 @@ package cache
 
 import (
 	"container/list"
+	"fmt"
 	"sync"
 	"time"
 
@@ -14,10 +15,15 @@ import (
 	"github.com/mattermost/mattermost/server/public/model"
 )
 
+// entryOverhead is a rough estimate of the memory used by the
+// bookkeeping of every entry, on top of its key and value.
+const entryOverhead = 128
+
 // LRU is a thread-safe fixed size LRU cache.
 type LRU struct {
 	lock                   sync.RWMutex
 	size                   int
+	initialSize            int
 	len                    int
 	currentGeneration      int64
 	evictList              *list.List
@@ -25,6 +31,13 @@ type LRU struct {
 	defaultExpiry          time.Duration
 	name                   string
 	invalidateClusterEvent model.ClusterEvent
+
+	hits      int64
+	misses    int64
+	evictions int64
+	// memory is the estimated size of every entry of the evictList,
+	// including the ones invalidated by a Purge.
+	memory int64
 }
 
 // entry is used to hold a value in the evictList.
@@ -40,6 +53,7 @@ func NewLRU(opts *CacheOptions) Cache {
 	return &LRU{
 		name:                   opts.Name,
 		size:                   opts.Size,
+		initialSize:            opts.Size,
 		evictList:              list.New(),
 		items:                  make(map[string]*list.Element, opts.Size),
 		defaultExpiry:          opts.DefaultExpiry,
@@ -147,6 +161,43 @@ func (l *LRU) Name() string {
 	return l.name
 }
 
+// Stats returns a snapshot of the usage statistics of the cache.
+func (l *LRU) Stats() *model.CacheStats {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return &model.CacheStats{
+		Name:        l.name,
+		Type:        model.CacheTypeLRU,
+		Size:        l.size,
+		Len:         l.len,
+		Hits:        l.hits,
+		Misses:      l.misses,
+		Evictions:   l.evictions,
+		HitRatio:    hitRatio(l.hits, l.misses),
+		MemoryBytes: l.memory,
+	}
+}
+
+// Resize changes the maximum number of entries of the cache, evicting
+// the least recently used entries that don't fit anymore.
+func (l *LRU) Resize(size int) error {
+	if size < 0 {
+		return fmt.Errorf("invalid cache size %d", size)
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if size == 0 {
+		size = l.initialSize
+	}
+	l.size = size
+	l.evictOverflow()
+
+	return nil
+}
+
 func (l *LRU) set(key string, value any, ttl time.Duration) error {
 	var expires time.Time
 	if ttl > 0 {
@@ -173,6 +224,7 @@ func (l *LRU) set(key string, value any, ttl time.Duration) error {
 	if ent, ok := l.items[key]; ok {
 		l.evictList.MoveToFront(ent)
 		e := ent.Value.(*entry)
+		l.memory += int64(len(buf) - len(e.value))
 		e.value = buf
 		e.expires = expires
 		if e.generation != l.currentGeneration {
@@ -187,13 +239,25 @@ func (l *LRU) set(key string, value any, ttl time.Duration) error {
 	entry := l.evictList.PushFront(ent)
 	l.items[key] = entry
 	l.len++
+	l.memory += entrySize(ent)
 
-	if l.evictList.Len() > l.size {
-		l.removeElement(l.evictList.Back())
-	}
+	l.evictOverflow()
 	return nil
 }
 
+// evictOverflow removes the least recently used entries until the
+// cache fits its size.
+func (l *LRU) evictOverflow() {
+	for l.evictList.Len() > l.size {
+		ent := l.evictList.Back()
+		// Entries invalidated by a Purge don't count as evictions.
+		if ent.Value.(*entry).generation == l.currentGeneration {
+			l.evictions++
+		}
+		l.removeElement(ent)
+	}
+}
+
 func (l *LRU) get(key string, value any) error {
 	val, err := l.getItem(key)
 	if err != nil {
@@ -216,14 +280,17 @@ func (l *LRU) getItem(key string) ([]byte, error) {
 
 	ent, ok := l.items[key]
 	if !ok {
+		l.misses++
 		return nil, ErrKeyNotFound
 	}
 	e := ent.Value.(*entry)
 	if e.generation != l.currentGeneration || (!e.expires.IsZero() && time.Now().After(e.expires)) {
 		l.removeElement(ent)
+		l.misses++
 		return nil, ErrKeyNotFound
 	}
 	l.evictList.MoveToFront(ent)
+	l.hits++
 	return e.value, nil
 }
 
@@ -233,5 +300,10 @@ func (l *LRU) removeElement(e *list.Element) {
 	if kv.generation == l.currentGeneration {
 		l.len--
 	}
+	l.memory -= entrySize(kv)
 	delete(l.items, kv.key)
 }
+
+func entrySize(e *entry) int64 {
+	return int64(len(e.key) + len(e.value) + entryOverhead)
+}
@@ -129,6 +129,47 @@ func (L LRUStriped) Name() string {
 	return L.name
 }
 
+// Stats sums the statistics of every bucket. As for LRUStriped.Len, the
+// buckets are read one after another, so the snapshot cannot be precise.
+func (L LRUStriped) Stats() *model.CacheStats {
+	stats := &model.CacheStats{
+		Name: L.name,
+		Type: model.CacheTypeLRU,
+	}
+	for _, lru := range L.buckets {
+		bucketStats := lru.Stats()
+		stats.Size += bucketStats.Size
+		stats.Len += bucketStats.Len
+		stats.Hits += bucketStats.Hits
+		stats.Misses += bucketStats.Misses
+		stats.Evictions += bucketStats.Evictions
+		stats.MemoryBytes += bucketStats.MemoryBytes
+	}
+	stats.HitRatio = hitRatio(stats.Hits, stats.Misses)
+
+	return stats
+}
+
+// Resize splits the new size between the buckets the same way
+// NewLRUStriped does.
+func (L LRUStriped) Resize(size int) error {
+	if size != 0 && size < len(L.buckets) {
+		return fmt.Errorf("cache size must at least be equal to the number of buckets")
+	}
+
+	bucketSize := 0
+	if size != 0 {
+		bucketSize = stripedBucketSize(size, len(L.buckets))
+	}
+	for _, lru := range L.buckets {
+		if err := lru.Resize(bucketSize); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // NewLRUStriped creates a striped LRU cache using the special CacheOptions.StripedBuckets value.
 // See LRUStriped and CacheOptions for more details.
 //
@@ -143,10 +184,7 @@ func NewLRUStriped(opts *CacheOptions) (Cache, error) {
 		return nil, fmt.Errorf("cache size must at least be equal to the number of buckets")
 	}
 
-	// add 10% to the total size, before splitting
-	opts.Size += int(math.Ceil(float64(opts.Size) * 10.0 / 100.0))
-	// now this is the size for each bucket
-	opts.Size = (opts.Size / opts.StripedBuckets) + (opts.Size % opts.StripedBuckets)
+	opts.Size = stripedBucketSize(opts.Size, opts.StripedBuckets)
 
 	buckets := make([]*LRU, opts.StripedBuckets)
 	for i := 0; i < opts.StripedBuckets; i++ {
@@ -159,3 +197,10 @@ func NewLRUStriped(opts *CacheOptions) (Cache, error) {
 		name:                   opts.Name,
 	}, nil
 }
+
+func stripedBucketSize(size, buckets int) int {
+	// add 10% to the total size, before splitting
+	size += int(math.Ceil(float64(size) * 10.0 / 100.0))
+	// now this is the size for each bucket
+	return (size / buckets) + (size % buckets)
+}
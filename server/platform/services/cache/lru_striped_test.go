@@ -80,6 +80,40 @@ func TestLRUStriped_Size(t *testing.T) {
 	assert.Equal(t, 128+13+1, acc) // +10% +modulo padding
 }
 
+func TestLRUStriped_Resize(t *testing.T) {
+	scache, err := NewLRUStriped(&CacheOptions{StripedBuckets: 2, Size: 128})
+	require.NoError(t, err)
+	cache := scache.(LRUStriped)
+
+	require.NoError(t, cache.Resize(256))
+	assert.Equal(t, 256+26, cache.Stats().Size) // +10%
+
+	require.NoError(t, cache.Resize(0))
+	assert.Equal(t, 128+13+1, cache.Stats().Size)
+
+	require.Error(t, cache.Resize(1))
+}
+
+func TestLRUStriped_Stats(t *testing.T) {
+	scache, err := NewLRUStriped(&CacheOptions{Name: "striped", StripedBuckets: 4, Size: 128})
+	require.NoError(t, err)
+
+	for i := range 10 {
+		require.NoError(t, scache.SetWithDefaultExpiry(fmt.Sprintf("%d", i), i))
+	}
+	var v int
+	for i := range 20 {
+		_ = scache.Get(fmt.Sprintf("%d", i), &v)
+	}
+
+	stats := scache.Stats()
+	assert.Equal(t, "striped", stats.Name)
+	assert.Equal(t, 10, stats.Len)
+	assert.Equal(t, int64(10), stats.Hits)
+	assert.Equal(t, int64(10), stats.Misses)
+	assert.Equal(t, 0.5, stats.HitRatio)
+}
+
 func TestLRUStriped_HashKey(t *testing.T) {
 	scache, err := NewLRUStriped(&CacheOptions{StripedBuckets: 2, Size: 128})
 	require.NoError(t, err)
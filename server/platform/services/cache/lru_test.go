@@ -121,6 +121,91 @@ func TestLRUExpire(t *testing.T) {
 	require.Equal(t, 3, r2)
 }
 
+func TestLRUStats(t *testing.T) {
+	l := NewLRU(&CacheOptions{
+		Name: "test",
+		Size: 2,
+	})
+
+	require.NoError(t, l.SetWithDefaultExpiry("1", 1))
+	require.NoError(t, l.SetWithDefaultExpiry("2", 2))
+	require.NoError(t, l.SetWithDefaultExpiry("3", 3))
+
+	var v int
+	require.Equal(t, ErrKeyNotFound, l.Get("1", &v))
+	require.NoError(t, l.Get("2", &v))
+	require.NoError(t, l.Get("3", &v))
+	require.NoError(t, l.Get("3", &v))
+
+	stats := l.Stats()
+	assert.Equal(t, "test", stats.Name)
+	assert.Equal(t, model.CacheTypeLRU, stats.Type)
+	assert.Equal(t, 2, stats.Size)
+	assert.Equal(t, 2, stats.Len)
+	assert.Equal(t, int64(3), stats.Hits)
+	assert.Equal(t, int64(1), stats.Misses)
+	assert.Equal(t, int64(1), stats.Evictions)
+	assert.Equal(t, 0.75, stats.HitRatio)
+	assert.Greater(t, stats.MemoryBytes, int64(2*entryOverhead))
+
+	t.Run("purged and removed entries aren't evictions", func(t *testing.T) {
+		require.NoError(t, l.Remove("2"))
+		require.NoError(t, l.Purge())
+		require.NoError(t, l.SetWithDefaultExpiry("4", 4))
+		require.NoError(t, l.SetWithDefaultExpiry("5", 5))
+
+		stats := l.Stats()
+		assert.Equal(t, 2, stats.Len)
+		assert.Equal(t, int64(1), stats.Evictions)
+	})
+
+	t.Run("memory estimate is released with the entries", func(t *testing.T) {
+		require.NoError(t, l.RemoveMulti([]string{"3", "4", "5"}))
+
+		assert.Equal(t, int64(0), l.Stats().MemoryBytes)
+	})
+}
+
+func TestLRUResize(t *testing.T) {
+	l := NewLRU(&CacheOptions{
+		Size: 4,
+	})
+
+	for i := range 4 {
+		require.NoError(t, l.SetWithDefaultExpiry(fmt.Sprintf("%d", i), i))
+	}
+
+	t.Run("shrinking evicts the least recently used entries", func(t *testing.T) {
+		require.NoError(t, l.Resize(2))
+
+		var v int
+		require.Equal(t, ErrKeyNotFound, l.Get("0", &v))
+		require.Equal(t, ErrKeyNotFound, l.Get("1", &v))
+		require.NoError(t, l.Get("2", &v))
+		require.NoError(t, l.Get("3", &v))
+
+		stats := l.Stats()
+		assert.Equal(t, 2, stats.Size)
+		assert.Equal(t, 2, stats.Len)
+		assert.Equal(t, int64(2), stats.Evictions)
+	})
+
+	t.Run("a size of 0 restores the initial size", func(t *testing.T) {
+		require.NoError(t, l.Resize(0))
+		for i := 4; i < 6; i++ {
+			require.NoError(t, l.SetWithDefaultExpiry(fmt.Sprintf("%d", i), i))
+		}
+
+		stats := l.Stats()
+		assert.Equal(t, 4, stats.Size)
+		assert.Equal(t, 4, stats.Len)
+	})
+
+	t.Run("negative sizes are rejected", func(t *testing.T) {
+		require.Error(t, l.Resize(-1))
+	})
+}
+
 func TestLRUMarshalUnMarshal(t *testing.T) {
 	l := NewLRU(&CacheOptions{
 		Size:                   1,
@@ -144,6 +144,24 @@ func (_m *Cache) RemoveMulti(keys []string) error {
 	return r0
 }
 
+// Resize provides a mock function with given fields: size
+func (_m *Cache) Resize(size int) error {
+	ret := _m.Called(size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resize")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(size)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Scan provides a mock function with given fields: f
 func (_m *Cache) Scan(f func([]string) error) error {
 	ret := _m.Called(f)
@@ -198,6 +216,26 @@ func (_m *Cache) SetWithExpiry(key string, value interface{}, ttl time.Duration)
 	return r0
 }
 
+// Stats provides a mock function with no fields
+func (_m *Cache) Stats() *model.CacheStats {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 *model.CacheStats
+	if rf, ok := ret.Get(0).(func() *model.CacheStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CacheStats)
+		}
+	}
+
+	return r0
+}
+
 // NewCache creates a new instance of Cache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewCache(t interface {
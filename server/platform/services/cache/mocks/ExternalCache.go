@@ -180,6 +180,24 @@ func (_m *ExternalCache) RemoveMulti(keys []string) error {
 	return r0
 }
 
+// Resize provides a mock function with given fields: size
+func (_m *ExternalCache) Resize(size int) error {
+	ret := _m.Called(size)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resize")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(size)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Scan provides a mock function with given fields: f
 func (_m *ExternalCache) Scan(f func([]string) error) error {
 	ret := _m.Called(f)
@@ -234,6 +252,26 @@ func (_m *ExternalCache) SetWithExpiry(key string, value interface{}, ttl time.D
 	return r0
 }
 
+// Stats provides a mock function with no fields
+func (_m *ExternalCache) Stats() *model.CacheStats {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Stats")
+	}
+
+	var r0 *model.CacheStats
+	if rf, ok := ret.Get(0).(func() *model.CacheStats); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.CacheStats)
+		}
+	}
+
+	return r0
+}
+
 // NewExternalCache creates a new instance of ExternalCache. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewExternalCache(t interface {
@@ -16,6 +16,26 @@ type Provider struct {
 	mock.Mock
 }
 
+// Caches provides a mock function with no fields
+func (_m *Provider) Caches() []cache.Cache {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Caches")
+	}
+
+	var r0 []cache.Cache
+	if rf, ok := ret.Get(0).(func() []cache.Cache); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]cache.Cache)
+		}
+	}
+
+	return r0
+}
+
 // Close provides a mock function with no fields
 func (_m *Provider) Close() error {
 	ret := _m.Called()
@@ -92,11 +112,34 @@ func (_m *Provider) NewCache(opts *cache.CacheOptions) (cache.Cache, error) {
 	return r0, r1
 }
 
+// SetCluster provides a mock function with given fields: cluster
+func (_m *Provider) SetCluster(cluster einterfaces.ClusterInterface) {
+	_m.Called(cluster)
+}
+
 // SetMetrics provides a mock function with given fields: metrics
 func (_m *Provider) SetMetrics(metrics einterfaces.MetricsInterface) {
 	_m.Called(metrics)
 }
 
+// SetSizes provides a mock function with given fields: sizes
+func (_m *Provider) SetSizes(sizes map[string]int) error {
+	ret := _m.Called(sizes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSizes")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(map[string]int) error); ok {
+		r0 = rf(sizes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Type provides a mock function with no fields
 func (_m *Provider) Type() string {
 	ret := _m.Called()
@@ -5,7 +5,12 @@ package cache
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -34,13 +39,88 @@ type Provider interface {
 	Connect() (string, error)
 	// SetMetrics
 	SetMetrics(metrics einterfaces.MetricsInterface)
+	// SetCluster sets the cluster used by the caches to reach the other nodes.
+	SetCluster(cluster einterfaces.ClusterInterface)
 	// Close releases any resources used by the cache provider.
 	Close() error
 	// Type returns what type of cache it generates.
 	Type() string
+	// Caches returns the caches created by the provider, sorted by name.
+	Caches() []Cache
+	// SetSizes overrides the size of the caches created by the provider,
+	// by cache name, including the caches created afterwards.
+	SetSizes(sizes map[string]int) error
+}
+
+// cacheRegistry keeps track of the caches created by a provider, so
+// their statistics can be collected and they can be resized. Caches are
+// registered under the name they were created with, without the prefix
+// of the Redis caches, which is how they are named in the sizes.
+type cacheRegistry struct {
+	mut    sync.RWMutex
+	caches map[string]Cache
+	sizes  map[string]int
+}
+
+func (r *cacheRegistry) register(name string, c Cache) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	if r.caches == nil {
+		r.caches = make(map[string]Cache)
+	}
+	r.caches[name] = c
+
+	if size, ok := r.sizes[name]; ok {
+		// The cache keeps the size it was created with if the
+		// configured one is invalid. SetSizes reports the error.
+		_ = c.Resize(size)
+	}
+}
+
+// SetSizes resizes the caches whose size changed since the previous
+// call. The caches that aren't in sizes anymore get back the size they
+// were created with.
+func (r *cacheRegistry) SetSizes(sizes map[string]int) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	var errs error
+	for name, c := range r.caches {
+		size, ok := sizes[name]
+		oldSize, wasSet := r.sizes[name]
+		if ok == wasSet && size == oldSize {
+			continue
+		}
+
+		// size is 0 if the cache isn't in sizes anymore, which restores its initial size
+		if err := c.Resize(size); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("could not resize cache %q: %w", name, err))
+		}
+	}
+	r.sizes = maps.Clone(sizes)
+
+	return errs
+}
+
+// Caches returns the caches created by the provider, sorted by name.
+func (r *cacheRegistry) Caches() []Cache {
+	r.mut.RLock()
+	defer r.mut.RUnlock()
+
+	caches := make([]Cache, 0, len(r.caches))
+	for _, c := range r.caches {
+		caches = append(caches, c)
+	}
+	sort.Slice(caches, func(i, j int) bool {
+		return caches[i].Name() < caches[j].Name()
+	})
+
+	return caches
 }
 
 type cacheProvider struct {
+	cacheRegistry
 }
 
 // NewProvider creates a new CacheProvider
@@ -50,10 +130,19 @@ func NewProvider() Provider {
 
 // NewCache creates a new cache with given opts
 func (c *cacheProvider) NewCache(opts *CacheOptions) (Cache, error) {
+	var newCache Cache
 	if opts.Striped {
-		return NewLRUStriped(opts)
+		var err error
+		newCache, err = NewLRUStriped(opts)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		newCache = NewLRU(opts)
 	}
-	return NewLRU(opts), nil
+
+	c.register(opts.Name, newCache)
+	return newCache, nil
 }
 
 // Connect opens a new connection to the cache using specific provider parameters.
@@ -64,6 +153,9 @@ func (c *cacheProvider) Connect() (string, error) {
 func (c *cacheProvider) SetMetrics(metrics einterfaces.MetricsInterface) {
 }
 
+func (c *cacheProvider) SetCluster(cluster einterfaces.ClusterInterface) {
+}
+
 // Close releases any resources used by the cache provider.
 func (c *cacheProvider) Close() error {
 	return nil
@@ -74,6 +166,7 @@ func (c *cacheProvider) Type() string {
 }
 
 type redisProvider struct {
+	cacheRegistry
 	client      rueidis.Client
 	cachePrefix string
 	metrics     einterfaces.MetricsInterface
@@ -89,6 +182,10 @@ type RedisOptions struct {
 
 // NewProvider creates a new CacheProvider
 func NewRedisProvider(opts *RedisOptions) (Provider, error) {
+	return newRedisProvider(opts)
+}
+
+func newRedisProvider(opts *RedisOptions) (*redisProvider, error) {
 	client, err := rueidis.NewClient(rueidis.ClientOption{
 		InitAddress:       []string{opts.RedisAddr},
 		Password:          opts.RedisPassword,
@@ -114,12 +211,25 @@ func NewRedisProvider(opts *RedisOptions) (Provider, error) {
 
 // NewCache creates a new cache with given opts
 func (r *redisProvider) NewCache(opts *CacheOptions) (Cache, error) {
+	name := opts.Name
+	rr, err := r.newRedis(opts)
+	if err != nil {
+		return nil, err
+	}
+	r.register(name, rr)
+	return rr, nil
+}
+
+func (r *redisProvider) newRedis(opts *CacheOptions) (*Redis, error) {
 	if r.cachePrefix != "" {
 		opts.Name = r.cachePrefix + ":" + opts.Name
 	}
 	rr, err := NewRedis(opts, r.client)
+	if err != nil {
+		return nil, err
+	}
 	rr.metrics = r.metrics
-	return rr, err
+	return rr, nil
 }
 
 // Connect opens a new connection to the cache using specific provider parameters.
@@ -135,6 +245,9 @@ func (r *redisProvider) SetMetrics(metrics einterfaces.MetricsInterface) {
 	r.metrics = metrics
 }
 
+func (r *redisProvider) SetCluster(cluster einterfaces.ClusterInterface) {
+}
+
 func (r *redisProvider) Type() string {
 	return model.CacheTypeRedis
 }
@@ -144,3 +257,219 @@ func (r *redisProvider) Close() error {
 	r.client.Close()
 	return nil
 }
+
+type hybridProvider struct {
+	cacheRegistry
+	redis        *redisProvider
+	localCaches  map[string]bool
+	invalidation string
+	nodeID       string
+
+	mut     sync.RWMutex
+	hybrids map[string]*Hybrid
+	cluster einterfaces.ClusterInterface
+
+	stopSubscription context.CancelFunc
+	subscriptionDone chan struct{}
+}
+
+type HybridOptions struct {
+	RedisOptions
+	// LocalCaches are the names of the caches that keep a local copy
+	// of their entries. The other caches are only stored in Redis.
+	LocalCaches []string
+	// Invalidation is how the other nodes are told to drop their local
+	// copies, either model.HybridInvalidationRedis or
+	// model.HybridInvalidationCluster.
+	Invalidation string
+}
+
+// NewHybridProvider creates a provider of caches stored in Redis, with a
+// local LRU in front of the caches listed in the options.
+func NewHybridProvider(opts *HybridOptions) (Provider, error) {
+	redis, err := newRedisProvider(&opts.RedisOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	localCaches := make(map[string]bool, len(opts.LocalCaches))
+	for _, name := range opts.LocalCaches {
+		localCaches[name] = true
+	}
+
+	return &hybridProvider{
+		redis:        redis,
+		localCaches:  localCaches,
+		invalidation: opts.Invalidation,
+		nodeID:       model.NewId(),
+		hybrids:      make(map[string]*Hybrid),
+	}, nil
+}
+
+// NewCache creates a new cache with given opts
+func (p *hybridProvider) NewCache(opts *CacheOptions) (Cache, error) {
+	name := opts.Name
+	rr, err := p.redis.newRedis(opts)
+	if err != nil {
+		return nil, err
+	}
+	if !p.localCaches[name] {
+		p.register(name, rr)
+		return rr, nil
+	}
+
+	h, err := NewHybrid(opts, rr, clientSideTTL, p.publish)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mut.Lock()
+	p.hybrids[h.Name()] = h
+	p.mut.Unlock()
+
+	p.register(name, h)
+	return h, nil
+}
+
+// Connect opens a new connection to Redis, and subscribes to the
+// invalidations if they are sent through Redis.
+func (p *hybridProvider) Connect() (string, error) {
+	res, err := p.redis.Connect()
+	if err != nil {
+		return "", err
+	}
+
+	if p.invalidation == model.HybridInvalidationRedis {
+		ctx, cancel := context.WithCancel(context.Background())
+		p.stopSubscription = cancel
+		p.subscriptionDone = make(chan struct{})
+		go p.subscribe(ctx)
+	}
+
+	return res, nil
+}
+
+func (p *hybridProvider) SetMetrics(metrics einterfaces.MetricsInterface) {
+	p.redis.SetMetrics(metrics)
+}
+
+// SetCluster sets the cluster the invalidations are sent through, if
+// they aren't sent through Redis.
+func (p *hybridProvider) SetCluster(cluster einterfaces.ClusterInterface) {
+	if p.invalidation != model.HybridInvalidationCluster || cluster == nil {
+		return
+	}
+
+	p.mut.Lock()
+	p.cluster = cluster
+	p.mut.Unlock()
+
+	cluster.RegisterClusterMessageHandler(model.ClusterEventInvalidateCacheForHybrid, func(msg *model.ClusterMessage) {
+		p.receive(msg.Data)
+	})
+}
+
+func (p *hybridProvider) Type() string {
+	return model.CacheTypeHybrid
+}
+
+// Close releases any resources used by the cache provider.
+func (p *hybridProvider) Close() error {
+	if p.stopSubscription != nil {
+		p.stopSubscription()
+	}
+	err := p.redis.Close()
+	if p.subscriptionDone != nil {
+		<-p.subscriptionDone
+	}
+	return err
+}
+
+func (p *hybridProvider) invalidationChannel() string {
+	if p.redis.cachePrefix != "" {
+		return p.redis.cachePrefix + ":hybrid_invalidations"
+	}
+	return "hybrid_invalidations"
+}
+
+// subscribe receives the invalidations sent through Redis until ctx is
+// done. The local copies are dropped whenever the subscription is lost,
+// since invalidations could have been missed in the meantime.
+func (p *hybridProvider) subscribe(ctx context.Context) {
+	defer close(p.subscriptionDone)
+
+	client := p.redis.client
+	for {
+		err := client.Receive(ctx, client.B().Subscribe().Channel(p.invalidationChannel()).Build(), func(msg rueidis.PubSubMessage) {
+			p.receive([]byte(msg.Message))
+		})
+		if ctx.Err() != nil || errors.Is(err, rueidis.ErrClosing) {
+			return
+		}
+
+		p.purgeLocal()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// publish sends an invalidation to the other nodes. Invalidations are
+// best effort: a local copy that misses one expires after clientSideTTL.
+func (p *hybridProvider) publish(msg *hybridInvalidation) {
+	msg.Node = p.nodeID
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	if p.invalidation == model.HybridInvalidationCluster {
+		p.mut.RLock()
+		cluster := p.cluster
+		p.mut.RUnlock()
+
+		if cluster != nil {
+			cluster.SendClusterMessage(&model.ClusterMessage{
+				Event:    model.ClusterEventInvalidateCacheForHybrid,
+				SendType: model.ClusterSendBestEffort,
+				Data:     data,
+			})
+		}
+		return
+	}
+
+	client := p.redis.client
+	_ = client.Do(context.Background(),
+		client.B().Publish().
+			Channel(p.invalidationChannel()).
+			Message(rueidis.BinaryString(data)).
+			Build(),
+	).Error()
+}
+
+// receive applies an invalidation sent by another node.
+func (p *hybridProvider) receive(data []byte) {
+	var msg hybridInvalidation
+	if err := json.Unmarshal(data, &msg); err != nil || msg.Node == p.nodeID {
+		return
+	}
+
+	p.mut.RLock()
+	h := p.hybrids[msg.Cache]
+	p.mut.RUnlock()
+
+	if h != nil {
+		h.invalidateLocal(&msg)
+	}
+}
+
+func (p *hybridProvider) purgeLocal() {
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	for _, h := range p.hybrids {
+		h.invalidateLocal(&hybridInvalidation{Purge: true})
+	}
+}
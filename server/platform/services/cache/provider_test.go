@@ -162,6 +162,70 @@ func TestNewCache_Striped(t *testing.T) {
 	})
 }
 
+func TestProviderSetSizes(t *testing.T) {
+	p := NewProvider()
+
+	first, err := p.NewCache(&CacheOptions{Name: "first", Size: 10})
+	require.NoError(t, err)
+	second, err := p.NewCache(&CacheOptions{Name: "second", Size: 10})
+	require.NoError(t, err)
+
+	caches := p.Caches()
+	require.Len(t, caches, 2)
+	require.Equal(t, "first", caches[0].Name())
+	require.Equal(t, "second", caches[1].Name())
+
+	t.Run("resizes the existing caches", func(t *testing.T) {
+		err := p.SetSizes(map[string]int{"first": 20})
+		require.NoError(t, err)
+		require.Equal(t, 20, first.Stats().Size)
+		require.Equal(t, 10, second.Stats().Size)
+	})
+
+	t.Run("applies the sizes to the caches created afterwards", func(t *testing.T) {
+		err := p.SetSizes(map[string]int{"first": 20, "third": 30})
+		require.NoError(t, err)
+
+		third, err := p.NewCache(&CacheOptions{Name: "third", Size: 10})
+		require.NoError(t, err)
+		require.Equal(t, 30, third.Stats().Size)
+	})
+
+	t.Run("restores the initial size of the caches removed from the sizes", func(t *testing.T) {
+		err := p.SetSizes(map[string]int{})
+		require.NoError(t, err)
+		for _, c := range p.Caches() {
+			require.Equal(t, 10, c.Stats().Size)
+		}
+	})
+
+	t.Run("reports the caches that can't be resized", func(t *testing.T) {
+		_, err := p.NewCache(&CacheOptions{Name: "striped", Size: 10, Striped: true, StripedBuckets: 4})
+		require.NoError(t, err)
+
+		err = p.SetSizes(map[string]int{"striped": 2})
+		require.ErrorContains(t, err, `could not resize cache "striped"`)
+	})
+}
+
+func TestCacheRegistrySetSizes(t *testing.T) {
+	// The caches of the Redis providers are named with the prefix, but
+	// registered under the name they were created with.
+	var r cacheRegistry
+	c := NewLRU(&CacheOptions{Name: "prefix:first", Size: 10})
+	r.register("first", c)
+
+	err := r.SetSizes(map[string]int{"first": 20})
+	require.NoError(t, err)
+	require.Equal(t, 20, c.Stats().Size)
+
+	other := NewLRU(&CacheOptions{Name: "prefix:second", Size: 10})
+	err = r.SetSizes(map[string]int{"first": 20, "second": 30})
+	require.NoError(t, err)
+	r.register("second", other)
+	require.Equal(t, 30, other.Stats().Size)
+}
+
 func TestConnectClose(t *testing.T) {
 	p := NewProvider()
 
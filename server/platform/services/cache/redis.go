@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -26,6 +27,9 @@ type Redis struct {
 	client        rueidis.Client
 	defaultExpiry time.Duration
 	metrics       einterfaces.MetricsInterface
+
+	hits   atomic.Int64
+	misses atomic.Int64
 }
 
 func NewRedis(opts *CacheOptions, client rueidis.Client) (*Redis, error) {
@@ -153,10 +157,12 @@ func (r *Redis) Get(key string, value any) error {
 	}
 	if err != nil {
 		if rueidis.IsRedisNil(err) {
+			r.misses.Add(1)
 			return ErrKeyNotFound
 		}
 		return err
 	}
+	r.hits.Add(1)
 
 	if ok {
 		*vPtr = intVal
@@ -209,9 +215,11 @@ func (r *Redis) GetMulti(keys []string, values []any) []error {
 
 	for i, resp := range vals {
 		if resp.IsNil() {
+			r.misses.Add(1)
 			errs[i] = ErrKeyNotFound
 			continue
 		}
+		r.hits.Add(1)
 
 		var intVal int64
 		var bytesVal []byte
@@ -330,6 +338,27 @@ func (r *Redis) Name() string {
 	return r.name
 }
 
+// Stats returns the lookups made by this node. The size, the length
+// and the evictions of the cache are managed by Redis, so they aren't
+// reported.
+func (r *Redis) Stats() *model.CacheStats {
+	hits := r.hits.Load()
+	misses := r.misses.Load()
+	return &model.CacheStats{
+		Name:     r.name,
+		Type:     model.CacheTypeRedis,
+		Len:      -1,
+		Hits:     hits,
+		Misses:   misses,
+		HitRatio: hitRatio(hits, misses),
+	}
+}
+
+// Resize is not supported, since the memory of the cache is managed by Redis.
+func (r *Redis) Resize(size int) error {
+	return ErrResizeNotSupported
+}
+
 func sliceMapper[S ~[]E, E, R any](slice S, mapper func(E) R) []R {
 	newSlice := make([]R, len(slice))
 	for i, v := range slice {
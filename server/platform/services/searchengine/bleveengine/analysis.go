@@ -0,0 +1,161 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package bleveengine
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/blevesearch/bleve/v2/analysis"
+	"github.com/blevesearch/bleve/v2/analysis/analyzer/custom"
+	"github.com/blevesearch/bleve/v2/analysis/char/regexp"
+	"github.com/blevesearch/bleve/v2/analysis/lang/en"
+	"github.com/blevesearch/bleve/v2/analysis/token/lowercase"
+	"github.com/blevesearch/bleve/v2/analysis/token/porter"
+	"github.com/blevesearch/bleve/v2/analysis/token/stop"
+	"github.com/blevesearch/bleve/v2/analysis/tokenizer/unicode"
+	"github.com/blevesearch/bleve/v2/analysis/tokenmap"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/blevesearch/bleve/v2/registry"
+)
+
+const (
+	markdownCharFilterName     = "mm_markdown"
+	dotCharFilterName          = "mm_dot"
+	sharpSCharFilterName       = "mm_sharp_s"
+	stopTokenMapName           = "mm_stop_en"
+	stopTokenFilterName        = "mm_stop_en"
+	messageAnalyzerName        = "mm_message"
+	stemmedMessageAnalyzerName = "mm_message_stemmed"
+)
+
+// markdownCharFilter blanks out the underscores used by markdown emphasis
+// (_word_) so that the words they surround are indexed on their own, while
+// keeping underscores that join two words (snake_case). The targets of
+// markdown links ([text](url)) are blanked out too, as they are not part of
+// the text of the message. The input length is preserved so that term
+// locations still point into the original message.
+type markdownCharFilter struct{}
+
+func (f *markdownCharFilter) Filter(input []byte) []byte {
+	output := make([]byte, len(input))
+	copy(output, input)
+
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case c == ']' && i+1 < len(input) && input[i+1] == '(':
+			end := bytes.IndexByte(input[i+1:], ')')
+			if end < 0 {
+				continue
+			}
+			for j := i + 1; j <= i+1+end; j++ {
+				output[j] = ' '
+			}
+			i += 1 + end
+		case c == '_':
+			if i == 0 || i == len(input)-1 || !isWordByte(input[i-1]) || !isWordByte(input[i+1]) {
+				output[i] = ' '
+			}
+		}
+	}
+
+	return output
+}
+
+// isWordByte reports whether c is part of a word. Bytes of multibyte
+// characters are considered part of a word.
+func isWordByte(c byte) bool {
+	return c >= utf8.RuneSelf ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z') ||
+		(c >= '0' && c <= '9')
+}
+
+func markdownCharFilterConstructor(config map[string]any, cache *registry.Cache) (analysis.CharFilter, error) {
+	return &markdownCharFilter{}, nil
+}
+
+// dotCharFilter blanks out the dots joining two words (www.example.com) so
+// that each part is indexed on its own. Words with dots are still searched
+// as written against the exact analyzer, which keeps them whole.
+type dotCharFilter struct{}
+
+func (f *dotCharFilter) Filter(input []byte) []byte {
+	output := make([]byte, len(input))
+	copy(output, input)
+
+	for i, c := range input {
+		if c == '.' && i > 0 && i < len(input)-1 && isWordByte(input[i-1]) && isWordByte(input[i+1]) {
+			output[i] = ' '
+		}
+	}
+
+	return output
+}
+
+func dotCharFilterConstructor(config map[string]any, cache *registry.Cache) (analysis.CharFilter, error) {
+	return &dotCharFilter{}, nil
+}
+
+// englishStopWords are the stop words removed by Elasticsearch, shorter
+// than the list of the en package which drops words like "where" or "you"
+// that users do search for.
+var englishStopWords = []any{
+	"a", "an", "and", "are", "as", "at", "be", "but", "by", "for", "if", "in", "into", "is", "it",
+	"no", "not", "of", "on", "or", "such", "that", "the", "their", "then", "there", "these",
+	"they", "this", "to", "was", "will", "with",
+}
+
+func init() {
+	if err := registry.RegisterCharFilter(markdownCharFilterName, markdownCharFilterConstructor); err != nil {
+		panic(err)
+	}
+	if err := registry.RegisterCharFilter(dotCharFilterName, dotCharFilterConstructor); err != nil {
+		panic(err)
+	}
+}
+
+// addMessageAnalyzers registers the analyzers used for post messages. The
+// exact analyzer backs quoted phrases, words with dots and highlighting,
+// while the stemmed analyzer backs the rest of the unquoted terms.
+func addMessageAnalyzers(indexMapping *mapping.IndexMappingImpl) error {
+	// ß and ss are both two bytes long, so the term locations are kept
+	if err := indexMapping.AddCustomCharFilter(sharpSCharFilterName, map[string]any{
+		"type":    regexp.Name,
+		"regexp":  "ß",
+		"replace": "ss",
+	}); err != nil {
+		return err
+	}
+
+	if err := indexMapping.AddCustomTokenMap(stopTokenMapName, map[string]any{
+		"type":   tokenmap.Name,
+		"tokens": englishStopWords,
+	}); err != nil {
+		return err
+	}
+
+	if err := indexMapping.AddCustomTokenFilter(stopTokenFilterName, map[string]any{
+		"type":           stop.Name,
+		"stop_token_map": stopTokenMapName,
+	}); err != nil {
+		return err
+	}
+
+	if err := indexMapping.AddCustomAnalyzer(messageAnalyzerName, map[string]any{
+		"type":          custom.Name,
+		"char_filters":  []string{markdownCharFilterName, sharpSCharFilterName},
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{lowercase.Name, stopTokenFilterName},
+	}); err != nil {
+		return err
+	}
+
+	return indexMapping.AddCustomAnalyzer(stemmedMessageAnalyzerName, map[string]any{
+		"type":          custom.Name,
+		"char_filters":  []string{markdownCharFilterName, dotCharFilterName, sharpSCharFilterName},
+		"tokenizer":     unicode.Name,
+		"token_filters": []string{en.PossessiveName, lowercase.Name, stopTokenFilterName, porter.Name},
+	})
+}
@@ -44,6 +44,8 @@ type BleveEngine struct {
 var keywordMapping *mapping.FieldMapping
 var standardMapping *mapping.FieldMapping
 var dateMapping *mapping.FieldMapping
+var messageMapping *mapping.FieldMapping
+var stemmedMessageMapping *mapping.FieldMapping
 
 func init() {
 	keywordMapping = bleve.NewTextFieldMapping()
@@ -53,6 +55,15 @@ func init() {
 	standardMapping.Analyzer = standard.Name
 
 	dateMapping = bleve.NewNumericFieldMapping()
+
+	messageMapping = bleve.NewTextFieldMapping()
+	messageMapping.Analyzer = messageAnalyzerName
+
+	stemmedMessageMapping = bleve.NewTextFieldMapping()
+	stemmedMessageMapping.Name = stemmedMessageField
+	stemmedMessageMapping.Analyzer = stemmedMessageAnalyzerName
+	stemmedMessageMapping.Store = false
+	stemmedMessageMapping.IncludeInAll = false
 }
 
 func getChannelIndexMapping() *mapping.IndexMappingImpl {
@@ -70,22 +81,25 @@ func getChannelIndexMapping() *mapping.IndexMappingImpl {
 	return indexMapping
 }
 
-func getPostIndexMapping() *mapping.IndexMappingImpl {
+func getPostIndexMapping() (*mapping.IndexMappingImpl, error) {
 	postMapping := bleve.NewDocumentMapping()
 	postMapping.AddFieldMappingsAt("Id", keywordMapping)
 	postMapping.AddFieldMappingsAt("TeamId", keywordMapping)
 	postMapping.AddFieldMappingsAt("ChannelId", keywordMapping)
 	postMapping.AddFieldMappingsAt("UserId", keywordMapping)
 	postMapping.AddFieldMappingsAt("CreateAt", dateMapping)
-	postMapping.AddFieldMappingsAt("Message", standardMapping)
+	postMapping.AddFieldMappingsAt("Message", messageMapping, stemmedMessageMapping)
 	postMapping.AddFieldMappingsAt("Type", keywordMapping)
 	postMapping.AddFieldMappingsAt("Hashtags", standardMapping)
 	postMapping.AddFieldMappingsAt("Attachments", standardMapping)
 
 	indexMapping := bleve.NewIndexMapping()
+	if err := addMessageAnalyzers(indexMapping); err != nil {
+		return nil, err
+	}
 	indexMapping.AddDocumentMapping("_default", postMapping)
 
-	return indexMapping
+	return indexMapping, nil
 }
 
 func getFileIndexMapping() *mapping.IndexMappingImpl {
@@ -151,8 +165,12 @@ func (b *BleveEngine) openIndexes() *model.AppError {
 		return model.NewAppError("Bleveengine.Start", "bleveengine.already_started.error", nil, "", http.StatusInternalServerError)
 	}
 
-	var err error
-	b.PostIndex, err = b.createOrOpenIndex(PostIndex, getPostIndexMapping())
+	postIndexMapping, err := getPostIndexMapping()
+	if err != nil {
+		return model.NewAppError("Bleveengine.Start", "bleveengine.create_post_index.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	b.PostIndex, err = b.createOrOpenIndex(PostIndex, postIndexMapping)
 	if err != nil {
 		return model.NewAppError("Bleveengine.Start", "bleveengine.create_post_index.error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -233,3 +233,31 @@ func (s *BleveEngineTestSuite) TestDeletePosts() {
 	require.NoError(s.T(), err)
 	require.Equal(s.T(), 1, int(numberDocs))
 }
+
+func (s *BleveEngineTestSuite) TestSearchPostsMatches() {
+	s.BleveEngine.PurgeIndexes(s.Context)
+	teamID := model.NewId()
+	channel := &model.Channel{Id: model.NewId()}
+
+	post := createPost(model.NewId(), channel.Id)
+	post.Message = "Searching the _archived_ posts for #launch"
+	post.Hashtags = "#launch"
+	appErr := s.SearchEngine.BleveEngine.IndexPost(post, teamID)
+	require.Nil(s.T(), appErr)
+
+	s.Run("Should return the matched words of the message", func() {
+		params := &model.SearchParams{Terms: `search "archived posts"`}
+		postIDs, matches, appErr := s.BleveEngine.SearchPosts(model.ChannelList{channel}, []*model.SearchParams{params}, 0, 20)
+		require.Nil(s.T(), appErr)
+		require.Equal(s.T(), []string{post.Id}, postIDs)
+		require.Equal(s.T(), []string{"Searching", "archived", "posts"}, matches[post.Id])
+	})
+
+	s.Run("Should return the matched hashtags", func() {
+		params := &model.SearchParams{Terms: "#launch", IsHashtag: true}
+		postIDs, matches, appErr := s.BleveEngine.SearchPosts(model.ChannelList{channel}, []*model.SearchParams{params}, 0, 20)
+		require.Nil(s.T(), appErr)
+		require.Equal(s.T(), []string{post.Id}, postIDs)
+		require.Equal(s.T(), []string{"#launch"}, matches[post.Id])
+	})
+}
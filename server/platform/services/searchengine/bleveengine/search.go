@@ -5,9 +5,12 @@ package bleveengine
 
 import (
 	"net/http"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search"
 	"github.com/blevesearch/bleve/v2/search/query"
 
 	"github.com/mattermost/mattermost/server/public/model"
@@ -18,6 +21,10 @@ import (
 const DeletePostsBatchSize = 500
 const DeleteFilesBatchSize = 500
 
+const stemmedMessageField = "MessageStemmed"
+
+var searchTermsRegexp = regexp.MustCompile(`"([^"]+)"|(\S+)`)
+
 // createInactiveUserFilter creates a query to filter out inactive users
 func createInactiveUserFilter() query.Query {
 	inactiveQ := bleve.NewTermQuery("0")
@@ -25,6 +32,132 @@ func createInactiveUserFilter() query.Query {
 	return inactiveQ
 }
 
+// createAtRangeQuery creates a query matching the posts created within the
+// given range, both ends included. A nil end leaves the range open.
+func createAtRangeQuery(rangeMin, rangeMax *float64) query.Query {
+	inclusive := true
+	dateQ := bleve.NewNumericRangeInclusiveQuery(rangeMin, rangeMax, &inclusive, &inclusive)
+	dateQ.SetField("CreateAt")
+	return dateQ
+}
+
+// splitSearchTerms splits the search terms into single words and quoted
+// phrases. An unbalanced quote is ignored.
+func splitSearchTerms(terms string) (words []string, phrases []string) {
+	for _, match := range searchTermsRegexp.FindAllStringSubmatch(terms, -1) {
+		if match[1] != "" {
+			phrases = append(phrases, match[1])
+		} else if word := strings.Trim(match[2], `"`); word != "" {
+			words = append(words, word)
+		}
+	}
+	return words, phrases
+}
+
+// messageTermsQueries builds the queries matching the given search terms in
+// the post messages. Quoted phrases, email addresses and words with dots
+// match exactly, words ending with a wildcard match by prefix and the rest
+// of the words match their stems.
+func messageTermsQueries(terms string, operator query.MatchQueryOperator, stemmedField string) []query.Query {
+	var queries []query.Query
+	var plainWords []string
+
+	words, phrases := splitSearchTerms(terms)
+	for _, word := range words {
+		switch {
+		case strings.HasSuffix(word, "*"):
+			messageQ := bleve.NewWildcardQuery(strings.ToLower(word))
+			messageQ.SetField("Message")
+			queries = append(queries, messageQ)
+		case strings.Contains(word, "@"), strings.Contains(strings.Trim(word, "."), "."):
+			phrases = append(phrases, word)
+		default:
+			plainWords = append(plainWords, word)
+		}
+	}
+
+	for _, phrase := range phrases {
+		phraseQ := bleve.NewMatchPhraseQuery(phrase)
+		phraseQ.SetField("Message")
+		queries = append(queries, phraseQ)
+	}
+
+	if len(plainWords) > 0 {
+		messageQ := bleve.NewMatchQuery(strings.Join(plainWords, " "))
+		messageQ.SetField(stemmedField)
+		messageQ.SetOperator(operator)
+		queries = append(queries, messageQ)
+	}
+
+	return queries
+}
+
+// stemmedMessageField returns the field holding the stemmed post messages.
+// Indexes created before the field existed only have the Message field until
+// they are purged and rebuilt.
+func (b *BleveEngine) stemmedMessageField() string {
+	if b.PostIndex.Mapping().FieldMappingForPath(stemmedMessageField).Name == stemmedMessageField {
+		return stemmedMessageField
+	}
+	return "Message"
+}
+
+// getMatchesForHit returns the words of a post that matched the search, in
+// the order they appear and without duplicates.
+func getMatchesForHit(hit *search.DocumentMatch) []string {
+	var locations []*search.Location
+	for _, field := range []string{"Message", stemmedMessageField} {
+		for _, termLocations := range hit.Locations[field] {
+			locations = append(locations, termLocations...)
+		}
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		return locations[i].Start < locations[j].Start
+	})
+
+	var matches []string
+	seen := map[string]bool{}
+	addMatch := func(match string) {
+		if match != "" && !seen[match] {
+			seen[match] = true
+			matches = append(matches, match)
+		}
+	}
+
+	message, _ := hit.Fields["Message"].(string)
+	for _, location := range locations {
+		if location.Start < location.End && location.End <= uint64(len(message)) {
+			addMatch(message[location.Start:location.End])
+		}
+	}
+
+	// Hashtags match as a whole, the same as they are searched
+	var hashtags []string
+	switch value := hit.Fields["Hashtags"].(type) {
+	case string:
+		hashtags = []string{value}
+	case []any:
+		for _, hashtag := range value {
+			if hashtagStr, ok := hashtag.(string); ok {
+				hashtags = append(hashtags, hashtagStr)
+			}
+		}
+	}
+	for _, termLocations := range hit.Locations["Hashtags"] {
+		for _, location := range termLocations {
+			position := uint64(0)
+			if len(location.ArrayPositions) > 0 {
+				position = location.ArrayPositions[0]
+			}
+			if position < uint64(len(hashtags)) {
+				addMatch(hashtags[position])
+			}
+		}
+	}
+
+	return matches
+}
+
 func (b *BleveEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
 	b.Mutex.RLock()
 	defer b.Mutex.RUnlock()
@@ -45,6 +178,8 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 	}
 	channelDisjunctionQ := bleve.NewDisjunctionQuery(channelQueries...)
 
+	stemmedField := b.stemmedMessageField()
+
 	var termQueries []query.Query
 	var notTermQueries []query.Query
 	var filters []query.Query
@@ -104,13 +239,13 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 				notFilters = append(notFilters, bleve.NewDisjunctionQuery(excludedUsers...))
 			}
 
+			// Date ranges include both of their ends, the same as in the
+			// database search
 			if params.OnDate != "" {
 				before, after := params.GetOnDateMillis()
 				beforeFloat64 := float64(before)
 				afterFloat64 := float64(after)
-				onDateQ := bleve.NewNumericRangeQuery(&beforeFloat64, &afterFloat64)
-				onDateQ.SetField("CreateAt")
-				filters = append(filters, onDateQ)
+				filters = append(filters, createAtRangeQuery(&beforeFloat64, &afterFloat64))
 			} else {
 				if params.AfterDate != "" || params.BeforeDate != "" {
 					var rangeMin, rangeMax *float64
@@ -124,32 +259,24 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 						rangeMax = &maxf
 					}
 
-					dateQ := bleve.NewNumericRangeQuery(rangeMin, rangeMax)
-					dateQ.SetField("CreateAt")
-					filters = append(filters, dateQ)
+					filters = append(filters, createAtRangeQuery(rangeMin, rangeMax))
 				}
 
 				if params.ExcludedAfterDate != "" {
 					minf := float64(params.GetExcludedAfterDateMillis())
-					dateQ := bleve.NewNumericRangeQuery(&minf, nil)
-					dateQ.SetField("CreateAt")
-					notFilters = append(notFilters, dateQ)
+					notFilters = append(notFilters, createAtRangeQuery(&minf, nil))
 				}
 
 				if params.ExcludedBeforeDate != "" {
 					maxf := float64(params.GetExcludedBeforeDateMillis())
-					dateQ := bleve.NewNumericRangeQuery(nil, &maxf)
-					dateQ.SetField("CreateAt")
-					notFilters = append(notFilters, dateQ)
+					notFilters = append(notFilters, createAtRangeQuery(nil, &maxf))
 				}
 
 				if params.ExcludedDate != "" {
 					before, after := params.GetExcludedDateMillis()
 					beforef := float64(before)
 					afterf := float64(after)
-					onDateQ := bleve.NewNumericRangeQuery(&beforef, &afterf)
-					onDateQ.SetField("CreateAt")
-					notFilters = append(notFilters, onDateQ)
+					notFilters = append(notFilters, createAtRangeQuery(&beforef, &afterf))
 				}
 			}
 		}
@@ -168,30 +295,12 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 			}
 		} else {
 			if params.Terms != "" {
-				terms := []string{}
-				for term := range strings.SplitSeq(params.Terms, " ") {
-					if strings.HasSuffix(term, "*") {
-						messageQ := bleve.NewWildcardQuery(term)
-						messageQ.SetField("Message")
-						termQueries = append(termQueries, messageQ)
-					} else {
-						terms = append(terms, term)
-					}
-				}
-
-				if len(terms) > 0 {
-					messageQ := bleve.NewMatchQuery(strings.Join(terms, " "))
-					messageQ.SetField("Message")
-					messageQ.SetOperator(termOperator)
-					termQueries = append(termQueries, messageQ)
-				}
+				termQueries = append(termQueries, messageTermsQueries(params.Terms, termOperator, stemmedField)...)
 			}
 
 			if params.ExcludedTerms != "" {
-				messageQ := bleve.NewMatchQuery(params.ExcludedTerms)
-				messageQ.SetField("Message")
-				messageQ.SetOperator(termOperator)
-				notTermQueries = append(notTermQueries, messageQ)
+				// A post is excluded when it contains any of the excluded terms
+				notTermQueries = append(notTermQueries, messageTermsQueries(params.ExcludedTerms, query.MatchQueryOperatorOr, stemmedField)...)
 			}
 		}
 	}
@@ -220,6 +329,8 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 
 	search := bleve.NewSearchRequestOptions(query, perPage, page*perPage, false)
 	search.SortBy([]string{"-CreateAt"})
+	search.Fields = []string{"Message", "Hashtags"}
+	search.IncludeLocations = true
 	results, err := b.PostIndex.Search(search)
 	if err != nil {
 		return nil, nil, model.NewAppError("Bleveengine.SearchPosts", "bleveengine.search_posts.error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -230,6 +341,10 @@ func (b *BleveEngine) SearchPosts(channels model.ChannelList, searchParams []*mo
 
 	for _, r := range results.Hits {
 		postIds = append(postIds, r.ID)
+
+		if postMatches := getMatchesForHit(r); len(postMatches) > 0 {
+			matches[r.ID] = postMatches
+		}
 	}
 
 	return postIds, matches, nil
@@ -679,13 +794,13 @@ func (b *BleveEngine) SearchFiles(channels model.ChannelList, searchParams []*mo
 				notFilters = append(notFilters, bleve.NewDisjunctionQuery(excludedExtensions...))
 			}
 
+			// Date ranges include both of their ends, the same as in the
+			// database search
 			if params.OnDate != "" {
 				before, after := params.GetOnDateMillis()
 				beforeFloat64 := float64(before)
 				afterFloat64 := float64(after)
-				onDateQ := bleve.NewNumericRangeQuery(&beforeFloat64, &afterFloat64)
-				onDateQ.SetField("CreateAt")
-				filters = append(filters, onDateQ)
+				filters = append(filters, createAtRangeQuery(&beforeFloat64, &afterFloat64))
 			} else {
 				if params.AfterDate != "" || params.BeforeDate != "" {
 					var rangeMin, rangeMax *float64
@@ -699,32 +814,24 @@ func (b *BleveEngine) SearchFiles(channels model.ChannelList, searchParams []*mo
 						rangeMax = &maxf
 					}
 
-					dateQ := bleve.NewNumericRangeQuery(rangeMin, rangeMax)
-					dateQ.SetField("CreateAt")
-					filters = append(filters, dateQ)
+					filters = append(filters, createAtRangeQuery(rangeMin, rangeMax))
 				}
 
 				if params.ExcludedAfterDate != "" {
 					minf := float64(params.GetExcludedAfterDateMillis())
-					dateQ := bleve.NewNumericRangeQuery(&minf, nil)
-					dateQ.SetField("CreateAt")
-					notFilters = append(notFilters, dateQ)
+					notFilters = append(notFilters, createAtRangeQuery(&minf, nil))
 				}
 
 				if params.ExcludedBeforeDate != "" {
 					maxf := float64(params.GetExcludedBeforeDateMillis())
-					dateQ := bleve.NewNumericRangeQuery(nil, &maxf)
-					dateQ.SetField("CreateAt")
-					notFilters = append(notFilters, dateQ)
+					notFilters = append(notFilters, createAtRangeQuery(nil, &maxf))
 				}
 
 				if params.ExcludedDate != "" {
 					before, after := params.GetExcludedDateMillis()
 					beforef := float64(before)
 					afterf := float64(after)
-					onDateQ := bleve.NewNumericRangeQuery(&beforef, &afterf)
-					onDateQ.SetField("CreateAt")
-					notFilters = append(notFilters, onDateQ)
+					notFilters = append(notFilters, createAtRangeQuery(&beforef, &afterf))
 				}
 			}
 		}
@@ -4,6 +4,8 @@
 package searchengine
 
 import (
+	"sync"
+
 	"github.com/mattermost/mattermost/server/public/model"
 )
 
@@ -21,10 +23,44 @@ func (seb *Broker) RegisterBleveEngine(be SearchEngineInterface) {
 	seb.BleveEngine = be
 }
 
+func (seb *Broker) RegisterSemanticEngine(se SearchEngineInterface) {
+	seb.SemanticEngine = se
+}
+
+// RegisterPluginEngine registers the engine provided by a plugin, replacing any
+// previously registered one. Unlike the other engines, it comes and goes at runtime.
+func (seb *Broker) RegisterPluginEngine(pe SearchEngineInterface) {
+	seb.pluginEngineMut.Lock()
+	defer seb.pluginEngineMut.Unlock()
+	seb.pluginEngine = pe
+}
+
+// UnregisterPluginEngine removes the engine provided by a plugin, if any.
+func (seb *Broker) UnregisterPluginEngine() {
+	seb.pluginEngineMut.Lock()
+	defer seb.pluginEngineMut.Unlock()
+	seb.pluginEngine = nil
+}
+
+// GetPluginEngine returns the engine provided by a plugin, or nil if there is none.
+func (seb *Broker) GetPluginEngine() SearchEngineInterface {
+	seb.pluginEngineMut.RLock()
+	defer seb.pluginEngineMut.RUnlock()
+	return seb.pluginEngine
+}
+
 type Broker struct {
 	cfg                 *model.Config
 	ElasticsearchEngine SearchEngineInterface
 	BleveEngine         SearchEngineInterface
+	// SemanticEngine complements the keyword engines rather than replacing
+	// them, so it is never part of the active engines.
+	SemanticEngine SearchEngineInterface
+
+	// pluginEngine only handles posts and files, taking precedence over the
+	// active engines for those, which remain in use as a fallback.
+	pluginEngineMut sync.RWMutex
+	pluginEngine    SearchEngineInterface
 }
 
 func (seb *Broker) UpdateConfig(cfg *model.Config) *model.AppError {
@@ -37,6 +73,10 @@ func (seb *Broker) UpdateConfig(cfg *model.Config) *model.AppError {
 		seb.BleveEngine.UpdateConfig(cfg)
 	}
 
+	if seb.SemanticEngine != nil {
+		seb.SemanticEngine.UpdateConfig(cfg)
+	}
+
 	return nil
 }
 
@@ -51,6 +91,25 @@ func (seb *Broker) GetActiveEngines() []SearchEngineInterface {
 	return engines
 }
 
+// GetActiveContentEngines returns the engines handling posts and files: the
+// engine provided by a plugin if it is active, followed by the active engines.
+func (seb *Broker) GetActiveContentEngines() []SearchEngineInterface {
+	engines := []SearchEngineInterface{}
+	if pluginEngine := seb.GetPluginEngine(); pluginEngine != nil && pluginEngine.IsActive() {
+		engines = append(engines, pluginEngine)
+	}
+	return append(engines, seb.GetActiveEngines()...)
+}
+
+// GetActiveSemanticEngine returns the semantic engine if it is indexing
+// posts, or nil otherwise.
+func (seb *Broker) GetActiveSemanticEngine() SearchEngineInterface {
+	if seb.SemanticEngine != nil && seb.SemanticEngine.IsActive() && seb.SemanticEngine.IsIndexingEnabled() {
+		return seb.SemanticEngine
+	}
+	return nil
+}
+
 func (seb *Broker) ActiveEngine() string {
 	activeEngines := seb.GetActiveEngines()
 	if len(activeEngines) > 0 {
@@ -40,3 +40,56 @@ func TestActiveEngine(t *testing.T) {
 
 	assert.Equal(t, "none", b.ActiveEngine())
 }
+
+func TestGetActiveSemanticEngine(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	b := NewBroker(cfg)
+	assert.Nil(t, b.GetActiveSemanticEngine())
+
+	semanticMock := &mocks.SearchEngineInterface{}
+	semanticMock.On("IsActive").Return(true)
+	semanticMock.On("IsIndexingEnabled").Return(true)
+	semanticMock.On("GetName").Return("semantic")
+
+	b.SemanticEngine = semanticMock
+	assert.Equal(t, semanticMock, b.GetActiveSemanticEngine())
+
+	// The semantic engine never replaces the keyword search.
+	assert.Empty(t, b.GetActiveEngines())
+	assert.Equal(t, "database", b.ActiveEngine())
+
+	inactiveMock := &mocks.SearchEngineInterface{}
+	inactiveMock.On("IsActive").Return(false)
+	b.SemanticEngine = inactiveMock
+	assert.Nil(t, b.GetActiveSemanticEngine())
+}
+
+func TestGetActiveContentEngines(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+
+	b := NewBroker(cfg)
+	assert.Empty(t, b.GetActiveContentEngines())
+
+	bleveMock := &mocks.SearchEngineInterface{}
+	bleveMock.On("IsActive").Return(true)
+	bleveMock.On("IsIndexingEnabled").Return(true)
+	b.BleveEngine = bleveMock
+
+	pluginMock := &mocks.SearchEngineInterface{}
+	pluginMock.On("IsActive").Return(true).Once()
+	pluginMock.On("IsActive").Return(false)
+	b.RegisterPluginEngine(pluginMock)
+
+	// The plugin engine takes precedence, and is skipped while inactive.
+	assert.Equal(t, []SearchEngineInterface{pluginMock, bleveMock}, b.GetActiveContentEngines())
+	assert.Equal(t, []SearchEngineInterface{bleveMock}, b.GetActiveContentEngines())
+
+	// It never replaces the engines for channels and users.
+	assert.Equal(t, []SearchEngineInterface{bleveMock}, b.GetActiveEngines())
+
+	b.UnregisterPluginEngine()
+	assert.Nil(t, b.GetPluginEngine())
+}
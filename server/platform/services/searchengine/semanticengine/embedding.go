@@ -0,0 +1,197 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package semanticengine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// EmbeddingProvider turns texts into vectors whose cosine similarity reflects
+// how close the texts are in meaning.
+type EmbeddingProvider interface {
+	// Embed returns one vector per text, in the same order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+	// Dimensions is the length of the returned vectors.
+	Dimensions() int
+}
+
+// NewEmbeddingProvider returns the provider configured in the settings.
+func NewEmbeddingProvider(settings *model.SemanticSearchSettings) (EmbeddingProvider, error) {
+	switch *settings.EmbeddingProvider {
+	case model.SemanticSearchEmbeddingProviderStub:
+		return NewStubEmbeddingProvider(*settings.EmbeddingDimensions), nil
+	case model.SemanticSearchEmbeddingProviderOpenAI:
+		return &openAIEmbeddingProvider{
+			url:        strings.TrimRight(*settings.EmbeddingAPIURL, "/") + "/embeddings",
+			apiKey:     *settings.EmbeddingAPIKey,
+			model:      *settings.EmbeddingModel,
+			dimensions: *settings.EmbeddingDimensions,
+			client: &http.Client{
+				Timeout: time.Duration(*settings.RequestTimeoutSeconds) * time.Second,
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown embedding provider %q", *settings.EmbeddingProvider)
+	}
+}
+
+// stubEmbeddingProvider is a local and deterministic provider that hashes the
+// words and character trigrams of a text into a vector. It has no notion of
+// meaning, but texts sharing words or word fragments end up close to each
+// other, which makes it suitable for tests and for trying the feature out
+// without an external service.
+type stubEmbeddingProvider struct {
+	dimensions int
+}
+
+func NewStubEmbeddingProvider(dimensions int) EmbeddingProvider {
+	return &stubEmbeddingProvider{dimensions: dimensions}
+}
+
+func (p *stubEmbeddingProvider) Dimensions() int {
+	return p.dimensions
+}
+
+func (p *stubEmbeddingProvider) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		vectors[i] = p.embed(text)
+	}
+	return vectors, nil
+}
+
+func (p *stubEmbeddingProvider) embed(text string) []float32 {
+	vector := make([]float32, p.dimensions)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+
+	for _, word := range words {
+		p.addFeature(vector, word, 2)
+
+		runes := []rune("^" + word + "$")
+		for i := 0; i+3 <= len(runes); i++ {
+			p.addFeature(vector, string(runes[i:i+3]), 1)
+		}
+	}
+
+	return normalize(vector)
+}
+
+// addFeature adds the weight to the position the feature hashes to, with a
+// sign taken from the hash so that collisions tend to cancel out.
+func (p *stubEmbeddingProvider) addFeature(vector []float32, feature string, weight float32) {
+	h := fnv.New64a()
+	h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	if sum&1 == 1 {
+		weight = -weight
+	}
+	vector[(sum>>1)%uint64(len(vector))] += weight
+}
+
+// openAIEmbeddingProvider calls an OpenAI compatible embeddings API.
+type openAIEmbeddingProvider struct {
+	url        string
+	apiKey     string
+	model      string
+	dimensions int
+	client     *http.Client
+}
+
+type openAIEmbeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+type openAIEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+func (p *openAIEmbeddingProvider) Dimensions() int {
+	return p.dimensions
+}
+
+func (p *openAIEmbeddingProvider) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(openAIEmbeddingRequest{
+		Model:      p.model,
+		Input:      texts,
+		Dimensions: p.dimensions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal embedding request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create embedding request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request embeddings: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedding response: %w", err)
+	}
+
+	var embeddingResp openAIEmbeddingResponse
+	if err := json.Unmarshal(data, &embeddingResp); err != nil {
+		return nil, fmt.Errorf("failed to decode embedding response with status %d: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if embeddingResp.Error != nil {
+			return nil, fmt.Errorf("embedding request failed with status %d: %s", resp.StatusCode, embeddingResp.Error.Message)
+		}
+		return nil, fmt.Errorf("embedding request failed with status %d", resp.StatusCode)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, item := range embeddingResp.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, fmt.Errorf("embedding response has an out of range index %d", item.Index)
+		}
+		if len(item.Embedding) != p.dimensions {
+			return nil, fmt.Errorf("embedding has %d dimensions, expected %d", len(item.Embedding), p.dimensions)
+		}
+		vectors[item.Index] = item.Embedding
+	}
+	for i, vector := range vectors {
+		if vector == nil {
+			return nil, fmt.Errorf("embedding response is missing input %d", i)
+		}
+	}
+
+	return vectors, nil
+}
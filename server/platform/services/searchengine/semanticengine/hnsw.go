@@ -0,0 +1,348 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package semanticengine
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+const (
+	// hnswM is the number of neighbours a node keeps on the upper layers,
+	// twice as many are kept on the bottom layer.
+	hnswM              = 16
+	hnswEfConstruction = 200
+	hnswEfSearch       = 100
+)
+
+// hnswNode is a vector of the index. Deleted nodes are kept in the graph, so
+// that it stays connected, until the index is compacted.
+type hnswNode struct {
+	ID        string
+	Vector    []float32
+	Neighbors [][]int32
+	Deleted   bool
+}
+
+// hnswIndex is an in memory Hierarchical Navigable Small World graph used to
+// find the nearest neighbours of a vector in logarithmic time. Vectors are
+// normalized on insertion so that the cosine similarity is a dot product.
+//
+// The index is not safe for concurrent use.
+type hnswIndex struct {
+	Nodes    []*hnswNode
+	Entry    int32
+	MaxLevel int
+
+	ids          map[string]int32
+	deletedCount int
+	rand         *rand.Rand
+}
+
+type hnswResult struct {
+	ID         string
+	Similarity float32
+}
+
+func newHNSWIndex() *hnswIndex {
+	return &hnswIndex{
+		Entry: -1,
+		ids:   map[string]int32{},
+		rand:  rand.New(rand.NewSource(1)),
+	}
+}
+
+// rebuildState restores the lookup tables of an index that was decoded.
+func (h *hnswIndex) rebuildState() {
+	h.ids = make(map[string]int32, len(h.Nodes))
+	h.deletedCount = 0
+	for i, node := range h.Nodes {
+		if node.Deleted {
+			h.deletedCount++
+			continue
+		}
+		h.ids[node.ID] = int32(i)
+	}
+	if h.rand == nil {
+		h.rand = rand.New(rand.NewSource(int64(len(h.Nodes)) + 1))
+	}
+}
+
+// Len returns the number of live vectors in the index.
+func (h *hnswIndex) Len() int {
+	return len(h.ids)
+}
+
+func (h *hnswIndex) Contains(id string) bool {
+	_, ok := h.ids[id]
+	return ok
+}
+
+// Add inserts the vector of the given id, replacing any previous one.
+func (h *hnswIndex) Add(id string, vector []float32) {
+	h.Delete(id)
+
+	node := &hnswNode{
+		ID:     id,
+		Vector: normalize(vector),
+	}
+	level := h.randomLevel()
+	node.Neighbors = make([][]int32, level+1)
+
+	nodeIdx := int32(len(h.Nodes))
+	h.Nodes = append(h.Nodes, node)
+	h.ids[id] = nodeIdx
+
+	if h.Entry == -1 {
+		h.Entry = nodeIdx
+		h.MaxLevel = level
+		return
+	}
+
+	entry := h.Entry
+	for l := h.MaxLevel; l > level; l-- {
+		entry = h.searchLayer(node.Vector, []int32{entry}, 1, l)[0].idx
+	}
+
+	for l := min(level, h.MaxLevel); l >= 0; l-- {
+		candidates := h.searchLayer(node.Vector, []int32{entry}, hnswEfConstruction, l)
+		neighbors := closestIndexes(candidates, maxNeighbors(l))
+		node.Neighbors[l] = neighbors
+
+		for _, neighborIdx := range neighbors {
+			neighbor := h.Nodes[neighborIdx]
+			neighbor.Neighbors[l] = append(neighbor.Neighbors[l], nodeIdx)
+			if len(neighbor.Neighbors[l]) > maxNeighbors(l) {
+				neighbor.Neighbors[l] = h.closestTo(neighbor.Vector, neighbor.Neighbors[l], maxNeighbors(l))
+			}
+		}
+		entry = candidates[0].idx
+	}
+
+	if level > h.MaxLevel {
+		h.Entry = nodeIdx
+		h.MaxLevel = level
+	}
+}
+
+// Delete removes the vector of the given id. It reports whether the id was
+// in the index.
+func (h *hnswIndex) Delete(id string) bool {
+	idx, ok := h.ids[id]
+	if !ok {
+		return false
+	}
+
+	h.Nodes[idx].Deleted = true
+	delete(h.ids, id)
+	h.deletedCount++
+
+	if h.deletedCount > hnswM && h.deletedCount > len(h.Nodes)/2 {
+		h.compact()
+	}
+	return true
+}
+
+// Search returns up to k vectors most similar to the query that are accepted
+// by the filter, most similar first. When the filter discards too many of the
+// approximate candidates, the index is scanned exhaustively instead.
+func (h *hnswIndex) Search(query []float32, k int, minSimilarity float32, filter func(id string) bool) []hnswResult {
+	if h.Entry == -1 || k <= 0 {
+		return nil
+	}
+	query = normalize(query)
+
+	entry := h.Entry
+	for l := h.MaxLevel; l > 0; l-- {
+		entry = h.searchLayer(query, []int32{entry}, 1, l)[0].idx
+	}
+	candidates := h.searchLayer(query, []int32{entry}, max(hnswEfSearch, k), 0)
+
+	results := h.collect(candidates, k, minSimilarity, filter)
+	if len(results) < k && len(candidates) < len(h.Nodes) {
+		results = h.collect(h.scan(query), k, minSimilarity, filter)
+	}
+	return results
+}
+
+func (h *hnswIndex) collect(candidates []hnswCandidate, k int, minSimilarity float32, filter func(id string) bool) []hnswResult {
+	var results []hnswResult
+	for _, candidate := range candidates {
+		node := h.Nodes[candidate.idx]
+		similarity := 1 - candidate.distance
+		if node.Deleted || similarity < minSimilarity || (filter != nil && !filter(node.ID)) {
+			continue
+		}
+		results = append(results, hnswResult{ID: node.ID, Similarity: similarity})
+		if len(results) == k {
+			break
+		}
+	}
+	return results
+}
+
+// scan computes the distance of the query to every vector in the index.
+func (h *hnswIndex) scan(query []float32) []hnswCandidate {
+	candidates := make([]hnswCandidate, 0, len(h.Nodes))
+	for i, node := range h.Nodes {
+		if !node.Deleted {
+			candidates = append(candidates, hnswCandidate{idx: int32(i), distance: distance(query, node.Vector)})
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	return candidates
+}
+
+// compact rebuilds the graph without the deleted nodes.
+func (h *hnswIndex) compact() {
+	nodes := h.Nodes
+	h.Nodes = nil
+	h.Entry = -1
+	h.MaxLevel = 0
+	h.ids = map[string]int32{}
+	h.deletedCount = 0
+
+	for _, node := range nodes {
+		if !node.Deleted {
+			h.Add(node.ID, node.Vector)
+		}
+	}
+}
+
+func (h *hnswIndex) randomLevel() int {
+	return int(math.Floor(-math.Log(1-h.rand.Float64()) / math.Log(hnswM)))
+}
+
+func maxNeighbors(level int) int {
+	if level == 0 {
+		return 2 * hnswM
+	}
+	return hnswM
+}
+
+type hnswCandidate struct {
+	idx      int32
+	distance float32
+}
+
+// searchLayer returns the ef nodes of the layer closest to the query, closest
+// first.
+func (h *hnswIndex) searchLayer(query []float32, entries []int32, ef int, level int) []hnswCandidate {
+	visited := map[int32]bool{}
+	candidates := &candidateHeap{}
+	results := &candidateHeap{farthestFirst: true}
+
+	for _, entry := range entries {
+		visited[entry] = true
+		candidate := hnswCandidate{idx: entry, distance: distance(query, h.Nodes[entry].Vector)}
+		heap.Push(candidates, candidate)
+		heap.Push(results, candidate)
+	}
+
+	for candidates.Len() > 0 {
+		closest := heap.Pop(candidates).(hnswCandidate)
+		if closest.distance > results.items[0].distance && results.Len() >= ef {
+			break
+		}
+
+		node := h.Nodes[closest.idx]
+		if level >= len(node.Neighbors) {
+			continue
+		}
+		for _, neighborIdx := range node.Neighbors[level] {
+			if visited[neighborIdx] {
+				continue
+			}
+			visited[neighborIdx] = true
+
+			neighbor := hnswCandidate{idx: neighborIdx, distance: distance(query, h.Nodes[neighborIdx].Vector)}
+			if results.Len() < ef || neighbor.distance < results.items[0].distance {
+				heap.Push(candidates, neighbor)
+				heap.Push(results, neighbor)
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := results.items
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].distance < sorted[j].distance
+	})
+	return sorted
+}
+
+func (h *hnswIndex) closestTo(vector []float32, indexes []int32, n int) []int32 {
+	candidates := make([]hnswCandidate, len(indexes))
+	for i, idx := range indexes {
+		candidates[i] = hnswCandidate{idx: idx, distance: distance(vector, h.Nodes[idx].Vector)}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].distance < candidates[j].distance
+	})
+	return closestIndexes(candidates, n)
+}
+
+// closestIndexes returns the indexes of the first n candidates, which are
+// expected to be sorted by distance.
+func closestIndexes(candidates []hnswCandidate, n int) []int32 {
+	n = min(n, len(candidates))
+	indexes := make([]int32, n)
+	for i := range n {
+		indexes[i] = candidates[i].idx
+	}
+	return indexes
+}
+
+// candidateHeap is a heap of candidates ordered by distance, closest first
+// unless farthestFirst is set.
+type candidateHeap struct {
+	items         []hnswCandidate
+	farthestFirst bool
+}
+
+func (c *candidateHeap) Len() int { return len(c.items) }
+func (c *candidateHeap) Less(i, j int) bool {
+	if c.farthestFirst {
+		return c.items[i].distance > c.items[j].distance
+	}
+	return c.items[i].distance < c.items[j].distance
+}
+func (c *candidateHeap) Swap(i, j int) { c.items[i], c.items[j] = c.items[j], c.items[i] }
+func (c *candidateHeap) Push(x any)    { c.items = append(c.items, x.(hnswCandidate)) }
+func (c *candidateHeap) Pop() any {
+	last := c.items[len(c.items)-1]
+	c.items = c.items[:len(c.items)-1]
+	return last
+}
+
+// distance returns the cosine distance between two normalized vectors.
+func distance(a, b []float32) float32 {
+	var dot float32
+	for i := range min(len(a), len(b)) {
+		dot += a[i] * b[i]
+	}
+	return 1 - dot
+}
+
+func normalize(vector []float32) []float32 {
+	var norm float64
+	for _, v := range vector {
+		norm += float64(v) * float64(v)
+	}
+	normalized := make([]float32, len(vector))
+	if norm == 0 {
+		return normalized
+	}
+	norm = math.Sqrt(norm)
+	for i, v := range vector {
+		normalized[i] = float32(float64(v) / norm)
+	}
+	return normalized
+}
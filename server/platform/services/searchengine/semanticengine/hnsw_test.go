@@ -0,0 +1,165 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package semanticengine
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func randomVector(r *rand.Rand, dimensions int) []float32 {
+	vector := make([]float32, dimensions)
+	for i := range vector {
+		vector[i] = float32(r.NormFloat64())
+	}
+	return vector
+}
+
+func TestHNSWIndex(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+
+	t.Run("should find the nearest neighbours", func(t *testing.T) {
+		index := newHNSWIndex()
+		vectors := map[string][]float32{}
+		for i := range 2000 {
+			id := fmt.Sprintf("post%d", i)
+			vectors[id] = randomVector(r, 32)
+			index.Add(id, vectors[id])
+		}
+		require.Equal(t, 2000, index.Len())
+
+		found := 0
+		for range 20 {
+			query := randomVector(r, 32)
+			expectedResults := index.collect(index.scan(normalize(query)), 10, -1, nil)
+			require.Len(t, expectedResults, 10)
+
+			results := index.Search(query, 10, -1, nil)
+			require.Len(t, results, 10)
+			for i := 1; i < len(results); i++ {
+				assert.GreaterOrEqual(t, results[i-1].Similarity, results[i].Similarity)
+			}
+
+			resultIds := map[string]bool{}
+			for _, result := range results {
+				resultIds[result.ID] = true
+			}
+			for _, result := range expectedResults {
+				if resultIds[result.ID] {
+					found++
+				}
+			}
+		}
+
+		// The search is approximate, but should find most of the exact
+		// nearest neighbours.
+		assert.Greater(t, found, 180)
+	})
+
+	t.Run("should return an exact match first", func(t *testing.T) {
+		index := newHNSWIndex()
+		for i := range 200 {
+			index.Add(fmt.Sprintf("post%d", i), randomVector(r, 16))
+		}
+		target := randomVector(r, 16)
+		index.Add("target", target)
+
+		results := index.Search(target, 1, 0, nil)
+		require.Len(t, results, 1)
+		assert.Equal(t, "target", results[0].ID)
+		assert.InDelta(t, 1, results[0].Similarity, 0.0001)
+	})
+
+	t.Run("should apply the filter and the minimum similarity", func(t *testing.T) {
+		index := newHNSWIndex()
+		for i := range 500 {
+			index.Add(fmt.Sprintf("post%d", i), randomVector(r, 16))
+		}
+
+		// Only a single vector is accepted, which forces the search to
+		// fall back to scanning the whole index.
+		results := index.Search(randomVector(r, 16), 5, -1, func(id string) bool {
+			return id == "post123"
+		})
+		require.Len(t, results, 1)
+		assert.Equal(t, "post123", results[0].ID)
+
+		results = index.Search(randomVector(r, 16), 500, 0.5, nil)
+		for _, result := range results {
+			assert.GreaterOrEqual(t, result.Similarity, float32(0.5))
+		}
+	})
+
+	t.Run("should delete and replace vectors", func(t *testing.T) {
+		index := newHNSWIndex()
+		vectors := map[string][]float32{}
+		for i := range 100 {
+			id := fmt.Sprintf("post%d", i)
+			vectors[id] = randomVector(r, 16)
+			index.Add(id, vectors[id])
+		}
+
+		assert.True(t, index.Delete("post1"))
+		assert.False(t, index.Delete("post1"))
+		assert.False(t, index.Contains("post1"))
+		for _, result := range index.Search(vectors["post1"], 100, -1, nil) {
+			assert.NotEqual(t, "post1", result.ID)
+		}
+
+		replacement := randomVector(r, 16)
+		index.Add("post2", replacement)
+		assert.Equal(t, 99, index.Len())
+		results := index.Search(replacement, 1, -1, nil)
+		require.Len(t, results, 1)
+		assert.Equal(t, "post2", results[0].ID)
+
+		// Deleting most of the vectors compacts the graph.
+		for i := 3; i < 90; i++ {
+			index.Delete(fmt.Sprintf("post%d", i))
+		}
+		assert.Equal(t, 12, index.Len())
+		assert.Less(t, len(index.Nodes), 99)
+		results = index.Search(vectors["post95"], 1, -1, nil)
+		require.Len(t, results, 1)
+		assert.Equal(t, "post95", results[0].ID)
+	})
+
+	t.Run("should survive encoding", func(t *testing.T) {
+		index := newHNSWIndex()
+		vectors := map[string][]float32{}
+		for i := range 100 {
+			id := fmt.Sprintf("post%d", i)
+			vectors[id] = randomVector(r, 16)
+			index.Add(id, vectors[id])
+		}
+		index.Delete("post0")
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(index))
+
+		decoded := &hnswIndex{}
+		require.NoError(t, gob.NewDecoder(&buf).Decode(decoded))
+		decoded.rebuildState()
+
+		assert.Equal(t, 99, decoded.Len())
+		assert.False(t, decoded.Contains("post0"))
+		results := decoded.Search(vectors["post50"], 1, -1, nil)
+		require.Len(t, results, 1)
+		assert.Equal(t, "post50", results[0].ID)
+
+		decoded.Add("post100", randomVector(r, 16))
+		assert.True(t, decoded.Contains("post100"))
+	})
+
+	t.Run("should handle an empty index", func(t *testing.T) {
+		index := newHNSWIndex()
+		assert.Empty(t, index.Search(randomVector(r, 16), 10, -1, nil))
+	})
+}
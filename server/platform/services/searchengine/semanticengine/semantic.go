@@ -0,0 +1,664 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package semanticengine
+
+import (
+	"context"
+	"encoding/gob"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const (
+	EngineName    = "semantic"
+	PostIndexFile = "posts.vectors"
+
+	// DefaultSaveIndexInterval is how often the changes to the index are
+	// saved, bounding what is lost if the server does not stop cleanly.
+	DefaultSaveIndexInterval = time.Minute
+)
+
+// postMeta holds the fields of an indexed post that the search filters on.
+type postMeta struct {
+	ChannelId string
+	UserId    string
+	CreateAt  int64
+}
+
+// indexFile is the content of the file the index is persisted to. Vectors
+// are only comparable when they come from the same model, so the index is
+// discarded when it was built with different settings.
+type indexFile struct {
+	Provider   string
+	Model      string
+	Dimensions int
+	Index      *hnswIndex
+	Posts      map[string]postMeta
+}
+
+// SemanticEngine searches posts by meaning rather than by keywords. Post
+// messages are turned into vectors by an EmbeddingProvider and stored in a
+// local HNSW index, which is persisted to the IndexDir periodically and when
+// the engine stops.
+//
+// The index is local to the server, so the engine only supports a single
+// node and the configuration can't enable it with clustering.
+//
+// Only posts are indexed; the user, channel and file methods are no-ops.
+type SemanticEngine struct {
+	Mutex    sync.RWMutex
+	ready    int32
+	cfg      *model.Config
+	provider EmbeddingProvider
+	index    *hnswIndex
+	posts    map[string]postMeta
+
+	saveInterval time.Duration
+	changed      atomic.Bool
+	stopSaving   chan struct{}
+}
+
+func NewSemanticEngine(cfg *model.Config) *SemanticEngine {
+	return &SemanticEngine{
+		cfg:          cfg,
+		saveInterval: DefaultSaveIndexInterval,
+	}
+}
+
+func (s *SemanticEngine) getIndexPath() string {
+	return filepath.Join(*s.cfg.SemanticSearchSettings.IndexDir, PostIndexFile)
+}
+
+func (s *SemanticEngine) openIndex() *model.AppError {
+	if atomic.LoadInt32(&s.ready) != 0 {
+		return model.NewAppError("Semanticengine.Start", "semanticengine.already_started.error", nil, "", http.StatusInternalServerError)
+	}
+
+	provider, err := NewEmbeddingProvider(&s.cfg.SemanticSearchSettings)
+	if err != nil {
+		return model.NewAppError("Semanticengine.Start", "semanticengine.create_provider.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := os.MkdirAll(*s.cfg.SemanticSearchSettings.IndexDir, 0700); err != nil {
+		return model.NewAppError("Semanticengine.Start", "semanticengine.open_index.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	s.provider = provider
+	s.index = newHNSWIndex()
+	s.posts = map[string]postMeta{}
+
+	file, err := s.loadIndexFile()
+	if err != nil {
+		return model.NewAppError("Semanticengine.Start", "semanticengine.open_index.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	settings := s.cfg.SemanticSearchSettings
+	if file != nil {
+		if file.Provider == *settings.EmbeddingProvider && file.Model == *settings.EmbeddingModel && file.Dimensions == *settings.EmbeddingDimensions {
+			s.index = file.Index
+			s.index.rebuildState()
+			s.posts = file.Posts
+		} else {
+			mlog.Warn("Discarding the semantic search index as it was built with different embedding settings")
+		}
+	}
+
+	s.changed.Store(false)
+	s.stopSaving = make(chan struct{})
+	go s.saveIndexPeriodically(s.stopSaving)
+
+	atomic.StoreInt32(&s.ready, 1)
+	return nil
+}
+
+func (s *SemanticEngine) loadIndexFile() (*indexFile, error) {
+	f, err := os.Open(s.getIndexPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var file indexFile
+	if err := gob.NewDecoder(f).Decode(&file); err != nil {
+		return nil, err
+	}
+	if file.Index == nil || file.Posts == nil {
+		return nil, nil
+	}
+	return &file, nil
+}
+
+// saveIndex writes the index to a temporary file before renaming it, so that
+// a crash never leaves a truncated index behind.
+func (s *SemanticEngine) saveIndex() error {
+	settings := s.cfg.SemanticSearchSettings
+	path := s.getIndexPath()
+
+	f, err := os.CreateTemp(filepath.Dir(path), PostIndexFile+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	err = gob.NewEncoder(f).Encode(&indexFile{
+		Provider:   *settings.EmbeddingProvider,
+		Model:      *settings.EmbeddingModel,
+		Dimensions: *settings.EmbeddingDimensions,
+		Index:      s.index,
+		Posts:      s.posts,
+	})
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// saveIndexPeriodically saves the index when it changed since the last save,
+// until stop is closed.
+func (s *SemanticEngine) saveIndexPeriodically(stop <-chan struct{}) {
+	ticker := time.NewTicker(s.saveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.saveIndexIfChanged()
+		}
+	}
+}
+
+func (s *SemanticEngine) saveIndexIfChanged() {
+	// The read lock is enough to save the index and keeps the searches
+	// running meanwhile
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	if !s.IsActive() || !s.changed.Swap(false) {
+		return
+	}
+
+	if err := s.saveIndex(); err != nil {
+		s.changed.Store(true)
+		mlog.Warn("Failed to save the semantic search index", mlog.Err(err))
+	}
+}
+
+func (s *SemanticEngine) closeIndex() *model.AppError {
+	if s.IsActive() {
+		close(s.stopSaving)
+		if err := s.saveIndex(); err != nil {
+			return model.NewAppError("Semanticengine.Stop", "semanticengine.save_index.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	s.index = nil
+	s.posts = nil
+	s.provider = nil
+	atomic.StoreInt32(&s.ready, 0)
+	return nil
+}
+
+func (s *SemanticEngine) Start() *model.AppError {
+	if !*s.cfg.SemanticSearchSettings.EnableIndexing || *s.cfg.SemanticSearchSettings.IndexDir == "" {
+		return nil
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	mlog.Info("EXPERIMENTAL: Starting semantic search")
+
+	return s.openIndex()
+}
+
+func (s *SemanticEngine) Stop() *model.AppError {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	mlog.Info("Stopping semantic search")
+
+	return s.closeIndex()
+}
+
+func (s *SemanticEngine) IsEnabled() bool {
+	return s.IsIndexingEnabled()
+}
+
+func (s *SemanticEngine) IsActive() bool {
+	return atomic.LoadInt32(&s.ready) == 1
+}
+
+func (s *SemanticEngine) IsIndexingSync() bool {
+	return false
+}
+
+func (s *SemanticEngine) RefreshIndexes(_ request.CTX) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) GetVersion() int {
+	return 0
+}
+
+func (s *SemanticEngine) GetFullVersion() string {
+	return "0"
+}
+
+func (s *SemanticEngine) GetPlugins() []string {
+	return []string{}
+}
+
+func (s *SemanticEngine) GetName() string {
+	return EngineName
+}
+
+func (s *SemanticEngine) IsAutocompletionEnabled() bool {
+	return false
+}
+
+func (s *SemanticEngine) IsIndexingEnabled() bool {
+	return *s.cfg.SemanticSearchSettings.EnableIndexing
+}
+
+func (s *SemanticEngine) IsSearchEnabled() bool {
+	return *s.cfg.SemanticSearchSettings.EnableSearching
+}
+
+// TestConfig checks that the embedding provider of the given configuration
+// answers with vectors of the expected size.
+func (s *SemanticEngine) TestConfig(rctx request.CTX, cfg *model.Config) *model.AppError {
+	provider, err := NewEmbeddingProvider(&cfg.SemanticSearchSettings)
+	if err != nil {
+		return model.NewAppError("Semanticengine.TestConfig", "semanticengine.create_provider.error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(*cfg.SemanticSearchSettings.RequestTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	if _, err := provider.Embed(ctx, []string{"test"}); err != nil {
+		return model.NewAppError("Semanticengine.TestConfig", "semanticengine.embed.error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	return nil
+}
+
+func (s *SemanticEngine) PurgeIndexes(rctx request.CTX) *model.AppError {
+	if *s.cfg.SemanticSearchSettings.IndexDir == "" {
+		return nil
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	rctx.Logger().Info("PurgeIndexes semantic search")
+	if !s.IsActive() {
+		if err := os.RemoveAll(s.getIndexPath()); err != nil {
+			return model.NewAppError("Semanticengine.PurgeIndexes", "semanticengine.purge_index.error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		return nil
+	}
+
+	s.index = newHNSWIndex()
+	s.posts = map[string]postMeta{}
+	if err := s.saveIndex(); err != nil {
+		return model.NewAppError("Semanticengine.PurgeIndexes", "semanticengine.purge_index.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (s *SemanticEngine) PurgeIndexList(rctx request.CTX, indexes []string) *model.AppError {
+	return model.NewAppError("Semanticengine.PurgeIndexList", "semanticengine.purge_list.not_implemented", nil, "not implemented", http.StatusNotFound)
+}
+
+// DataRetentionDeleteIndexes removes the posts created before the cutoff.
+func (s *SemanticEngine) DataRetentionDeleteIndexes(rctx request.CTX, cutoff time.Time) *model.AppError {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if !s.IsActive() {
+		return nil
+	}
+
+	cutoffMillis := model.GetMillisForTime(cutoff)
+	deleted := s.deletePostsWhere(func(meta postMeta) bool {
+		return meta.CreateAt < cutoffMillis
+	})
+	rctx.Logger().Info("Posts deleted from the semantic search index by data retention", mlog.Int("deleted", deleted))
+
+	return nil
+}
+
+func (s *SemanticEngine) UpdateConfig(cfg *model.Config) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if reflect.DeepEqual(cfg.SemanticSearchSettings, s.cfg.SemanticSearchSettings) {
+		s.cfg = cfg
+		return
+	}
+
+	mlog.Info("UpdateConf semantic search")
+
+	if err := s.closeIndex(); err != nil {
+		mlog.Error("Error closing the semantic search index to update the config", mlog.Err(err))
+		return
+	}
+	s.cfg = cfg
+
+	if !*cfg.SemanticSearchSettings.EnableIndexing || *cfg.SemanticSearchSettings.IndexDir == "" {
+		return
+	}
+	if err := s.openIndex(); err != nil {
+		mlog.Error("Error opening the semantic search index after updating the config", mlog.Err(err))
+	}
+}
+
+func (s *SemanticEngine) embed(texts []string) ([][]float32, error) {
+	s.Mutex.RLock()
+	provider := s.provider
+	timeout := time.Duration(*s.cfg.SemanticSearchSettings.RequestTimeoutSeconds) * time.Second
+	s.Mutex.RUnlock()
+
+	if provider == nil {
+		return nil, errors.New("semantic search is not started")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	return provider.Embed(ctx, texts)
+}
+
+// isIndexable reports whether the post has a message written by a user.
+func isIndexable(post *model.Post) bool {
+	return post.DeleteAt == 0 && post.Type == "" && strings.TrimSpace(post.Message) != ""
+}
+
+// IndexPost embeds the message of the post and adds it to the index. Posts
+// without a user message are removed from the index instead, so that edits
+// clearing a message are reflected.
+func (s *SemanticEngine) IndexPost(post *model.Post, teamId string) *model.AppError {
+	if !isIndexable(post) {
+		return s.DeletePost(post)
+	}
+
+	vectors, err := s.embed([]string{post.Message})
+	if err != nil {
+		return model.NewAppError("Semanticengine.IndexPost", "semanticengine.embed.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if !s.IsActive() {
+		return nil
+	}
+
+	s.index.Add(post.Id, vectors[0])
+	s.posts[post.Id] = postMeta{
+		ChannelId: post.ChannelId,
+		UserId:    post.UserId,
+		CreateAt:  post.CreateAt,
+	}
+	s.changed.Store(true)
+	return nil
+}
+
+// searchText returns the text whose meaning is searched for. Quotes and
+// wildcards only make sense for keywords and are dropped, and hashtag
+// searches are left to the keyword engines.
+func searchText(searchParams []*model.SearchParams) string {
+	var terms []string
+	for _, params := range searchParams {
+		if params.IsHashtag || params.Terms == "" {
+			continue
+		}
+		terms = append(terms, params.Terms)
+	}
+
+	return strings.TrimSpace(strings.NewReplacer(`"`, " ", "*", " ").Replace(strings.Join(terms, " ")))
+}
+
+// postFilter returns a function accepting the posts that match the channels
+// and the channel, user and date filters of the search.
+func postFilter(channels model.ChannelList, params *model.SearchParams) func(meta postMeta) bool {
+	toSet := func(ids []string) map[string]bool {
+		set := make(map[string]bool, len(ids))
+		for _, id := range ids {
+			set[id] = true
+		}
+		return set
+	}
+
+	channelIds := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		channelIds[channel.Id] = true
+	}
+	inChannels := toSet(params.InChannels)
+	excludedChannels := toSet(params.ExcludedChannels)
+	fromUsers := toSet(params.FromUsers)
+	excludedUsers := toSet(params.ExcludedUsers)
+
+	// Date ranges include both of their ends, the same as in the database
+	// search
+	type dateRange struct{ min, max int64 }
+	var included []dateRange
+	var excluded []dateRange
+	if params.OnDate != "" {
+		start, end := params.GetOnDateMillis()
+		included = append(included, dateRange{start, end})
+	} else {
+		if params.AfterDate != "" || params.BeforeDate != "" {
+			r := dateRange{0, 1<<63 - 1}
+			if params.AfterDate != "" {
+				r.min = params.GetAfterDateMillis()
+			}
+			if params.BeforeDate != "" {
+				r.max = params.GetBeforeDateMillis()
+			}
+			included = append(included, r)
+		}
+		if params.ExcludedAfterDate != "" {
+			excluded = append(excluded, dateRange{params.GetExcludedAfterDateMillis(), 1<<63 - 1})
+		}
+		if params.ExcludedBeforeDate != "" {
+			excluded = append(excluded, dateRange{0, params.GetExcludedBeforeDateMillis()})
+		}
+		if params.ExcludedDate != "" {
+			start, end := params.GetExcludedDateMillis()
+			excluded = append(excluded, dateRange{start, end})
+		}
+	}
+
+	return func(meta postMeta) bool {
+		if !channelIds[meta.ChannelId] || excludedChannels[meta.ChannelId] || excludedUsers[meta.UserId] {
+			return false
+		}
+		if len(inChannels) > 0 && !inChannels[meta.ChannelId] {
+			return false
+		}
+		if len(fromUsers) > 0 && !fromUsers[meta.UserId] {
+			return false
+		}
+		for _, r := range included {
+			if meta.CreateAt < r.min || meta.CreateAt > r.max {
+				return false
+			}
+		}
+		for _, r := range excluded {
+			if meta.CreateAt >= r.min && meta.CreateAt <= r.max {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// SearchPosts returns the ids of the posts closest in meaning to the search
+// terms, most similar first. Excluded terms are not supported and no matches
+// are returned, as a post may be found without containing any of the terms.
+func (s *SemanticEngine) SearchPosts(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, *model.AppError) {
+	text := searchText(searchParams)
+	if text == "" || len(channels) == 0 || !s.IsActive() {
+		return []string{}, model.PostSearchMatches{}, nil
+	}
+
+	vectors, err := s.embed([]string{text})
+	if err != nil {
+		return nil, nil, model.NewAppError("Semanticengine.SearchPosts", "semanticengine.embed.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	if !s.IsActive() {
+		return []string{}, model.PostSearchMatches{}, nil
+	}
+
+	filter := postFilter(channels, searchParams[0])
+	minSimilarity := float32(*s.cfg.SemanticSearchSettings.MinimumSimilarity) / 100
+	results := s.index.Search(vectors[0], (page+1)*perPage, minSimilarity, func(id string) bool {
+		return filter(s.posts[id])
+	})
+
+	postIds := []string{}
+	for i := page * perPage; i < len(results); i++ {
+		postIds = append(postIds, results[i].ID)
+	}
+
+	return postIds, model.PostSearchMatches{}, nil
+}
+
+func (s *SemanticEngine) DeletePost(post *model.Post) *model.AppError {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if !s.IsActive() {
+		return nil
+	}
+
+	if s.index.Delete(post.Id) {
+		s.changed.Store(true)
+	}
+	delete(s.posts, post.Id)
+	return nil
+}
+
+// deletePostsWhere removes the posts accepted by the predicate and returns
+// how many were removed. The caller must hold the write lock.
+func (s *SemanticEngine) deletePostsWhere(predicate func(meta postMeta) bool) int {
+	deleted := 0
+	for id, meta := range s.posts {
+		if predicate(meta) {
+			s.index.Delete(id)
+			delete(s.posts, id)
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		s.changed.Store(true)
+	}
+	return deleted
+}
+
+func (s *SemanticEngine) DeleteChannelPosts(rctx request.CTX, channelID string) *model.AppError {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if !s.IsActive() {
+		return nil
+	}
+
+	deleted := s.deletePostsWhere(func(meta postMeta) bool {
+		return meta.ChannelId == channelID
+	})
+	rctx.Logger().Info("Posts for channel deleted", mlog.String("channel_id", channelID), mlog.Int("deleted", deleted))
+
+	return nil
+}
+
+func (s *SemanticEngine) DeleteUserPosts(rctx request.CTX, userID string) *model.AppError {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if !s.IsActive() {
+		return nil
+	}
+
+	deleted := s.deletePostsWhere(func(meta postMeta) bool {
+		return meta.UserId == userID
+	})
+	rctx.Logger().Info("Posts for user deleted", mlog.String("user_id", userID), mlog.Int("deleted", deleted))
+
+	return nil
+}
+
+func (s *SemanticEngine) IndexChannel(rctx request.CTX, channel *model.Channel, userIDs, teamMemberIDs []string) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) SearchChannels(teamId, userID, term string, isGuest, includeDeleted bool) ([]string, *model.AppError) {
+	return []string{}, nil
+}
+
+func (s *SemanticEngine) DeleteChannel(channel *model.Channel) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) IndexUser(rctx request.CTX, user *model.User, teamsIds, channelsIds []string) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) SearchUsersInChannel(teamId, channelId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, []string, *model.AppError) {
+	return []string{}, []string{}, nil
+}
+
+func (s *SemanticEngine) SearchUsersInTeam(teamId string, restrictedToChannels []string, term string, options *model.UserSearchOptions) ([]string, *model.AppError) {
+	return []string{}, nil
+}
+
+func (s *SemanticEngine) DeleteUser(user *model.User) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) IndexFile(file *model.FileInfo, channelId string) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) SearchFiles(channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, *model.AppError) {
+	return []string{}, nil
+}
+
+func (s *SemanticEngine) DeleteFile(fileID string) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) DeletePostFiles(rctx request.CTX, postID string) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) DeleteUserFiles(rctx request.CTX, userID string) *model.AppError {
+	return nil
+}
+
+func (s *SemanticEngine) DeleteFilesBatch(rctx request.CTX, endTime, limit int64) *model.AppError {
+	return nil
+}
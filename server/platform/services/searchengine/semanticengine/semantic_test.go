@@ -0,0 +1,345 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package semanticengine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+func setupSemanticEngine(t *testing.T) (*SemanticEngine, *model.Config) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.SemanticSearchSettings.EnableIndexing = model.NewPointer(true)
+	cfg.SemanticSearchSettings.EnableSearching = model.NewPointer(true)
+	cfg.SemanticSearchSettings.IndexDir = model.NewPointer(t.TempDir())
+	cfg.SemanticSearchSettings.EmbeddingProvider = model.NewPointer(model.SemanticSearchEmbeddingProviderStub)
+	cfg.SemanticSearchSettings.MinimumSimilarity = model.NewPointer(0)
+
+	engine := NewSemanticEngine(cfg)
+	require.Nil(t, engine.Start())
+	t.Cleanup(func() {
+		engine.Stop()
+	})
+
+	return engine, cfg
+}
+
+func indexPost(t *testing.T, engine *SemanticEngine, channelId, userId, message string, createAt int64) *model.Post {
+	post := &model.Post{
+		Id:        model.NewId(),
+		ChannelId: channelId,
+		UserId:    userId,
+		Message:   message,
+		CreateAt:  createAt,
+	}
+	require.Nil(t, engine.IndexPost(post, ""))
+	return post
+}
+
+func TestStubEmbeddingProvider(t *testing.T) {
+	provider := NewStubEmbeddingProvider(64)
+
+	vectors, err := provider.Embed(context.Background(), []string{
+		"The deployment failed",
+		"the DEPLOYMENT failed!",
+		"deployments are failing",
+		"lunch at noon",
+	})
+	require.NoError(t, err)
+	require.Len(t, vectors, 4)
+	assert.Len(t, vectors[0], 64)
+
+	assert.Equal(t, vectors[0], vectors[1])
+	assert.Greater(t, 1-distance(vectors[0], vectors[2]), 1-distance(vectors[0], vectors[3]))
+}
+
+func TestOpenAIEmbeddingProvider(t *testing.T) {
+	var received openAIEmbeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		if received.Model == "unknown" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"message": "model not found"}}`))
+			return
+		}
+
+		// Answer out of order to check that the index is honoured.
+		w.Write([]byte(`{"data": [
+			{"index": 1, "embedding": [0, 1]},
+			{"index": 0, "embedding": [1, 0]}
+		]}`))
+	}))
+	defer server.Close()
+
+	settings := &model.SemanticSearchSettings{
+		EmbeddingProvider:   model.NewPointer(model.SemanticSearchEmbeddingProviderOpenAI),
+		EmbeddingAPIURL:     model.NewPointer(server.URL + "/v1/"),
+		EmbeddingAPIKey:     model.NewPointer("secret"),
+		EmbeddingDimensions: model.NewPointer(2),
+	}
+	settings.SetDefaults()
+
+	provider, err := NewEmbeddingProvider(settings)
+	require.NoError(t, err)
+
+	vectors, err := provider.Embed(context.Background(), []string{"first", "second"})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{1, 0}, {0, 1}}, vectors)
+	assert.Equal(t, []string{"first", "second"}, received.Input)
+	assert.Equal(t, model.SemanticSearchSettingsDefaultModel, received.Model)
+	assert.Equal(t, 2, received.Dimensions)
+
+	settings.EmbeddingModel = model.NewPointer("unknown")
+	provider, err = NewEmbeddingProvider(settings)
+	require.NoError(t, err)
+	_, err = provider.Embed(context.Background(), []string{"first"})
+	require.ErrorContains(t, err, "model not found")
+
+	settings.EmbeddingDimensions = model.NewPointer(3)
+	settings.EmbeddingModel = model.NewPointer(model.SemanticSearchSettingsDefaultModel)
+	provider, err = NewEmbeddingProvider(settings)
+	require.NoError(t, err)
+	_, err = provider.Embed(context.Background(), []string{"first", "second"})
+	require.ErrorContains(t, err, "expected 3")
+}
+
+func TestSemanticEngineSearchPosts(t *testing.T) {
+	engine, _ := setupSemanticEngine(t)
+
+	channel := &model.Channel{Id: model.NewId()}
+	otherChannel := &model.Channel{Id: model.NewId()}
+	channels := model.ChannelList{channel, otherChannel}
+	userId := model.NewId()
+	otherUserId := model.NewId()
+
+	day := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	deployment := indexPost(t, engine, channel.Id, userId, "the production deployment failed again", model.GetMillisForTime(day))
+	deploymentRollback := indexPost(t, engine, otherChannel.Id, otherUserId, "rolling back the failed deployment", model.GetMillisForTime(day.AddDate(0, 0, 1)))
+	lunch := indexPost(t, engine, channel.Id, otherUserId, "who wants to grab lunch", model.GetMillisForTime(day.AddDate(0, 0, 2)))
+
+	// System and empty posts are not indexed.
+	require.Nil(t, engine.IndexPost(&model.Post{Id: model.NewId(), ChannelId: channel.Id, Message: "deployment joined the channel", Type: model.PostTypeJoinChannel}, ""))
+	require.Nil(t, engine.IndexPost(&model.Post{Id: model.NewId(), ChannelId: channel.Id, Message: "  "}, ""))
+
+	search := func(params *model.SearchParams, page, perPage int) []string {
+		t.Helper()
+		ids, matches, appErr := engine.SearchPosts(channels, []*model.SearchParams{params}, page, perPage)
+		require.Nil(t, appErr)
+		assert.Empty(t, matches)
+		return ids
+	}
+
+	t.Run("should rank posts by similarity", func(t *testing.T) {
+		ids := search(&model.SearchParams{Terms: "deployment failed"}, 0, 10)
+		require.Len(t, ids, 3)
+		assert.ElementsMatch(t, []string{deployment.Id, deploymentRollback.Id}, ids[:2])
+		assert.Equal(t, lunch.Id, ids[2])
+
+		ids = search(&model.SearchParams{Terms: `"lunch"`}, 0, 10)
+		assert.Equal(t, lunch.Id, ids[0])
+	})
+
+	t.Run("should paginate", func(t *testing.T) {
+		all := search(&model.SearchParams{Terms: "deployment failed"}, 0, 10)
+		assert.Equal(t, all[:2], search(&model.SearchParams{Terms: "deployment failed"}, 0, 2))
+		assert.Equal(t, all[2:], search(&model.SearchParams{Terms: "deployment failed"}, 1, 2))
+		assert.Empty(t, search(&model.SearchParams{Terms: "deployment failed"}, 2, 2))
+	})
+
+	t.Run("should apply the minimum similarity", func(t *testing.T) {
+		engine.cfg.SemanticSearchSettings.MinimumSimilarity = model.NewPointer(40)
+		defer func() {
+			engine.cfg.SemanticSearchSettings.MinimumSimilarity = model.NewPointer(0)
+		}()
+
+		ids := search(&model.SearchParams{Terms: "deployment failed"}, 0, 10)
+		assert.ElementsMatch(t, []string{deployment.Id, deploymentRollback.Id}, ids)
+	})
+
+	t.Run("should filter by channels", func(t *testing.T) {
+		ids, _, appErr := engine.SearchPosts(model.ChannelList{channel}, []*model.SearchParams{{Terms: "deployment"}}, 0, 10)
+		require.Nil(t, appErr)
+		assert.ElementsMatch(t, []string{deployment.Id, lunch.Id}, ids)
+
+		ids = search(&model.SearchParams{Terms: "deployment", InChannels: []string{otherChannel.Id}}, 0, 10)
+		assert.Equal(t, []string{deploymentRollback.Id}, ids)
+
+		ids = search(&model.SearchParams{Terms: "deployment", ExcludedChannels: []string{otherChannel.Id}}, 0, 10)
+		assert.ElementsMatch(t, []string{deployment.Id, lunch.Id}, ids)
+	})
+
+	t.Run("should filter by users", func(t *testing.T) {
+		ids := search(&model.SearchParams{Terms: "deployment", FromUsers: []string{userId}}, 0, 10)
+		assert.Equal(t, []string{deployment.Id}, ids)
+
+		ids = search(&model.SearchParams{Terms: "deployment", ExcludedUsers: []string{userId}}, 0, 10)
+		assert.ElementsMatch(t, []string{deploymentRollback.Id, lunch.Id}, ids)
+	})
+
+	t.Run("should filter by dates", func(t *testing.T) {
+		ids := search(&model.SearchParams{Terms: "deployment", OnDate: "2024-05-11"}, 0, 10)
+		assert.Equal(t, []string{deploymentRollback.Id}, ids)
+
+		ids = search(&model.SearchParams{Terms: "deployment", AfterDate: "2024-05-10"}, 0, 10)
+		assert.ElementsMatch(t, []string{deploymentRollback.Id, lunch.Id}, ids)
+
+		ids = search(&model.SearchParams{Terms: "deployment", BeforeDate: "2024-05-12"}, 0, 10)
+		assert.ElementsMatch(t, []string{deployment.Id, deploymentRollback.Id}, ids)
+
+		ids = search(&model.SearchParams{Terms: "deployment", ExcludedDate: "2024-05-11"}, 0, 10)
+		assert.ElementsMatch(t, []string{deployment.Id, lunch.Id}, ids)
+	})
+
+	t.Run("should ignore hashtag searches", func(t *testing.T) {
+		assert.Empty(t, search(&model.SearchParams{Terms: "#deployment", IsHashtag: true}, 0, 10))
+	})
+
+	t.Run("should remove deleted posts", func(t *testing.T) {
+		post := indexPost(t, engine, channel.Id, userId, "deployment", 1)
+		require.Contains(t, search(&model.SearchParams{Terms: "deployment"}, 0, 10), post.Id)
+
+		post.DeleteAt = 1
+		require.Nil(t, engine.IndexPost(post, ""))
+		require.NotContains(t, search(&model.SearchParams{Terms: "deployment"}, 0, 10), post.Id)
+	})
+}
+
+func TestSemanticEngineDeletePosts(t *testing.T) {
+	engine, _ := setupSemanticEngine(t)
+	rctx := request.TestContext(t)
+
+	channel := &model.Channel{Id: model.NewId()}
+	otherChannel := &model.Channel{Id: model.NewId()}
+	channels := model.ChannelList{channel, otherChannel}
+	userId := model.NewId()
+
+	post := indexPost(t, engine, channel.Id, userId, "first message", 1000)
+	indexPost(t, engine, channel.Id, model.NewId(), "second message", 2000)
+	indexPost(t, engine, otherChannel.Id, userId, "third message", 3000)
+	remaining := indexPost(t, engine, otherChannel.Id, model.NewId(), "fourth message", 4000)
+
+	search := func() []string {
+		ids, _, appErr := engine.SearchPosts(channels, []*model.SearchParams{{Terms: "message"}}, 0, 10)
+		require.Nil(t, appErr)
+		return ids
+	}
+	require.Len(t, search(), 4)
+
+	require.Nil(t, engine.DeletePost(post))
+	assert.Len(t, search(), 3)
+
+	require.Nil(t, engine.DataRetentionDeleteIndexes(rctx, time.UnixMilli(2500)))
+	assert.Len(t, search(), 2)
+
+	require.Nil(t, engine.DeleteUserPosts(rctx, userId))
+	assert.Equal(t, []string{remaining.Id}, search())
+
+	require.Nil(t, engine.DeleteChannelPosts(rctx, otherChannel.Id))
+	assert.Empty(t, search())
+}
+
+func TestSemanticEnginePersistence(t *testing.T) {
+	engine, cfg := setupSemanticEngine(t)
+	rctx := request.TestContext(t)
+
+	channels := model.ChannelList{{Id: model.NewId()}}
+	post := indexPost(t, engine, channels[0].Id, model.NewId(), "persisted message", 1)
+
+	search := func() []string {
+		ids, _, appErr := engine.SearchPosts(channels, []*model.SearchParams{{Terms: "message"}}, 0, 10)
+		require.Nil(t, appErr)
+		return ids
+	}
+
+	t.Run("should load the index saved on stop", func(t *testing.T) {
+		require.Nil(t, engine.Stop())
+		assert.False(t, engine.IsActive())
+		assert.Empty(t, search())
+
+		require.Nil(t, engine.Start())
+		assert.Equal(t, []string{post.Id}, search())
+	})
+
+	t.Run("should discard the index when the embedding settings change", func(t *testing.T) {
+		newCfg := cfg.Clone()
+		newCfg.SemanticSearchSettings.EmbeddingDimensions = model.NewPointer(128)
+		engine.UpdateConfig(newCfg)
+		assert.True(t, engine.IsActive())
+		assert.Empty(t, search())
+
+		indexPost(t, engine, channels[0].Id, model.NewId(), "new message", 2)
+		assert.Len(t, search(), 1)
+	})
+
+	t.Run("should purge the index", func(t *testing.T) {
+		require.Nil(t, engine.PurgeIndexes(rctx))
+		assert.True(t, engine.IsActive())
+		assert.Empty(t, search())
+	})
+
+	t.Run("should stop when indexing is disabled", func(t *testing.T) {
+		newCfg := engine.cfg.Clone()
+		newCfg.SemanticSearchSettings.EnableIndexing = model.NewPointer(false)
+		newCfg.SemanticSearchSettings.EnableSearching = model.NewPointer(false)
+		engine.UpdateConfig(newCfg)
+		assert.False(t, engine.IsActive())
+	})
+}
+
+func TestSemanticEngineSaveIndexPeriodically(t *testing.T) {
+	cfg := &model.Config{}
+	cfg.SetDefaults()
+	cfg.SemanticSearchSettings.EnableIndexing = model.NewPointer(true)
+	cfg.SemanticSearchSettings.IndexDir = model.NewPointer(t.TempDir())
+	cfg.SemanticSearchSettings.EmbeddingProvider = model.NewPointer(model.SemanticSearchEmbeddingProviderStub)
+
+	engine := NewSemanticEngine(cfg)
+	engine.saveInterval = 10 * time.Millisecond
+	require.Nil(t, engine.Start())
+	t.Cleanup(func() {
+		engine.Stop()
+	})
+
+	savedPosts := func() map[string]postMeta {
+		engine.Mutex.RLock()
+		defer engine.Mutex.RUnlock()
+
+		file, err := engine.loadIndexFile()
+		require.NoError(t, err)
+		if file == nil {
+			return nil
+		}
+		return file.Posts
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Nil(t, savedPosts(), "the index is only saved when it changes")
+
+	post := indexPost(t, engine, model.NewId(), model.NewId(), "saved message", 1)
+	require.Eventually(t, func() bool {
+		_, ok := savedPosts()[post.Id]
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.Nil(t, engine.DeletePost(post))
+	require.Eventually(t, func() bool {
+		posts := savedPosts()
+		_, ok := posts[post.Id]
+		return posts != nil && !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
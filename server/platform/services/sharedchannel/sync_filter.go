@@ -0,0 +1,244 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/wiggin77/merror"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// syncFilterState caches lookups needed to evaluate a remote's sync filters
+// for the duration of a single sync.
+type syncFilterState struct {
+	filters      *model.SharedChannelRemoteSyncFilters
+	bots         map[string]bool
+	groupMembers map[string]bool
+}
+
+// applySyncFilters removes any posts, reactions, acknowledgements, users and attachments
+// excluded by the sync filters configured for the remote. Suppressed posts and files are
+// recorded so admins can see what was withheld.
+func (scs *Service) applySyncFilters(sd *syncData) error {
+	filters := sd.scr.SyncFilters
+	if filters.IsEmpty() {
+		return nil
+	}
+
+	merr := merror.New()
+
+	state, err := scs.newSyncFilterState(filters)
+	if err != nil {
+		merr.Append(err)
+	}
+
+	now := model.GetMillis()
+	var suppressions []*model.SharedChannelSyncSuppression
+	suppressedPosts := make(map[string]bool)
+
+	posts := make([]*model.Post, 0, len(sd.posts))
+	for _, post := range sd.posts {
+		reason, err := scs.postSuppressionReason(post, state)
+		if err != nil {
+			merr.Append(err)
+		}
+		if reason == "" {
+			posts = append(posts, post)
+			continue
+		}
+		suppressedPosts[post.Id] = true
+		suppressions = append(suppressions, &model.SharedChannelSyncSuppression{
+			RemoteId:  sd.rc.RemoteId,
+			ItemId:    post.Id,
+			ChannelId: sd.scr.ChannelId,
+			ItemType:  model.SharedChannelSyncItemPost,
+			Reason:    reason,
+			CreateAt:  now,
+		})
+	}
+	sd.posts = posts
+
+	// reactions and acknowledgements may reference posts suppressed during an earlier sync.
+	if err := scs.addPreviouslySuppressedPosts(sd, suppressedPosts); err != nil {
+		merr.Append(err)
+	}
+
+	sd.reactions = slices.DeleteFunc(sd.reactions, func(r *model.Reaction) bool {
+		return suppressedPosts[r.PostId]
+	})
+	sd.acknowledgements = slices.DeleteFunc(sd.acknowledgements, func(a *model.PostAcknowledgement) bool {
+		return suppressedPosts[a.PostId]
+	})
+
+	for userID, user := range sd.users {
+		if state.excludesUser(user) {
+			delete(sd.users, userID)
+		}
+	}
+	for userID, user := range sd.profileImages {
+		if state.excludesUser(user) {
+			delete(sd.profileImages, userID)
+		}
+	}
+	sd.statuses = slices.DeleteFunc(sd.statuses, func(s *model.Status) bool {
+		return filters.ExcludesUser(s.UserId) || state.groupMembers[s.UserId] || state.bots[s.UserId]
+	})
+
+	attachments := make([]attachment, 0, len(sd.attachments))
+	for _, a := range sd.attachments {
+		if suppressedPosts[a.post.Id] {
+			continue
+		}
+		if !filters.ExcludesAttachmentSize(a.fi.Size) {
+			attachments = append(attachments, a)
+			continue
+		}
+		removeFileFromPost(a.post, a.fi.Id)
+		suppressions = append(suppressions, &model.SharedChannelSyncSuppression{
+			RemoteId:  sd.rc.RemoteId,
+			ItemId:    a.fi.Id,
+			ChannelId: sd.scr.ChannelId,
+			ItemType:  model.SharedChannelSyncItemFile,
+			Reason:    model.SharedChannelSyncSuppressedAttachmentSize,
+			CreateAt:  now,
+		})
+	}
+	sd.attachments = attachments
+
+	if len(suppressions) > 0 {
+		if err := scs.server.GetStore().SharedChannel().SaveSyncSuppressions(suppressions); err != nil {
+			merr.Append(fmt.Errorf("could not save sync suppressions: %w", err))
+		}
+
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Suppressed items by sync filters",
+			mlog.String("remote", sd.rc.DisplayName),
+			mlog.String("channel_id", sd.scr.ChannelId),
+			mlog.Int("count", len(suppressions)),
+		)
+	}
+	return merr.ErrorOrNil()
+}
+
+func (scs *Service) newSyncFilterState(filters *model.SharedChannelRemoteSyncFilters) (*syncFilterState, error) {
+	state := &syncFilterState{
+		filters:      filters,
+		bots:         make(map[string]bool),
+		groupMembers: make(map[string]bool),
+	}
+
+	merr := merror.New()
+	for _, groupID := range filters.ExcludeGroupIds {
+		members, err := scs.server.GetStore().Group().GetMemberUsers(groupID)
+		if err != nil {
+			merr.Append(fmt.Errorf("could not get members of group %s: %w", groupID, err))
+			continue
+		}
+		for _, member := range members {
+			state.groupMembers[member.Id] = true
+		}
+	}
+	return state, merr.ErrorOrNil()
+}
+
+// postSuppressionReason returns the reason a post is excluded by the sync filters, or
+// an empty string if the post should sync.
+func (scs *Service) postSuppressionReason(post *model.Post, state *syncFilterState) (string, error) {
+	filters := state.filters
+
+	switch {
+	case filters.ExcludesUser(post.UserId):
+		return model.SharedChannelSyncSuppressedUser, nil
+	case state.groupMembers[post.UserId]:
+		return model.SharedChannelSyncSuppressedGroup, nil
+	case filters.ExcludesPostType(post.Type):
+		return model.SharedChannelSyncSuppressedPostType, nil
+	}
+
+	if filters.ExcludeBots {
+		isBot, ok := state.bots[post.UserId]
+		if !ok {
+			if post.GetProp(model.PostPropsFromBot) == "true" {
+				isBot = true
+			} else {
+				user, err := scs.server.GetStore().User().Get(context.Background(), post.UserId)
+				if err != nil {
+					return "", fmt.Errorf("could not get user %s: %w", post.UserId, err)
+				}
+				isBot = user.IsBot
+			}
+			state.bots[post.UserId] = isBot
+		}
+		if isBot {
+			return model.SharedChannelSyncSuppressedBot, nil
+		}
+	}
+	return "", nil
+}
+
+// addPreviouslySuppressedPosts adds to suppressedPosts any post referenced by the sync data's
+// reactions or acknowledgements that was suppressed during an earlier sync.
+func (scs *Service) addPreviouslySuppressedPosts(sd *syncData, suppressedPosts map[string]bool) error {
+	var postIDs []string
+	addPostID := func(postID string) {
+		if suppressedPosts[postID] || slices.Contains(postIDs, postID) || containsPostID(sd.posts, postID) {
+			return
+		}
+		postIDs = append(postIDs, postID)
+	}
+	for _, r := range sd.reactions {
+		addPostID(r.PostId)
+	}
+	for _, a := range sd.acknowledgements {
+		addPostID(a.PostId)
+	}
+	if len(postIDs) == 0 {
+		return nil
+	}
+
+	opts := model.SharedChannelSyncSuppressionFilterOpts{
+		RemoteId: sd.rc.RemoteId,
+		ItemIds:  postIDs,
+	}
+	suppressions, err := scs.server.GetStore().SharedChannel().GetSyncSuppressions(0, len(postIDs), opts)
+	if err != nil {
+		return fmt.Errorf("could not get sync suppressions: %w", err)
+	}
+	for _, s := range suppressions {
+		if s.ItemType == model.SharedChannelSyncItemPost {
+			suppressedPosts[s.ItemId] = true
+		}
+	}
+	return nil
+}
+
+func (s *syncFilterState) excludesUser(user *model.User) bool {
+	if s.filters.ExcludesUser(user.Id) || s.groupMembers[user.Id] {
+		return true
+	}
+	return s.filters.ExcludeBots && user.IsBot
+}
+
+func containsPostID(posts []*model.Post, postID string) bool {
+	return slices.ContainsFunc(posts, func(p *model.Post) bool {
+		return p.Id == postID
+	})
+}
+
+// removeFileFromPost strips a suppressed file from the post so the remote does not
+// reference an attachment it will never receive.
+func removeFileFromPost(post *model.Post, fileID string) {
+	post.FileIds = slices.DeleteFunc(slices.Clone(post.FileIds), func(id string) bool {
+		return id == fileID
+	})
+	if post.Metadata != nil {
+		post.Metadata.Files = slices.DeleteFunc(post.Metadata.Files, func(fi *model.FileInfo) bool {
+			return fi.Id == fileID
+		})
+	}
+}
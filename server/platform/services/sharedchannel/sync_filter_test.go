@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestApplySyncFilters(t *testing.T) {
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), DisplayName: "remote"}
+	channelID := model.NewId()
+
+	setup := func(t *testing.T, filters *model.SharedChannelRemoteSyncFilters) (*Service, *mocks.Store, *syncData) {
+		mockServer := &MockServerIface{}
+		mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+		mockStore := &mocks.Store{}
+		mockServer.On("GetStore").Return(mockStore)
+
+		scs := &Service{server: mockServer, app: &MockAppIface{}}
+		scr := &model.SharedChannelRemote{Id: model.NewId(), ChannelId: channelID, RemoteId: rc.RemoteId, SyncFilters: filters}
+		return scs, mockStore, newSyncData(newSyncTask(channelID, "", rc.RemoteId, nil, nil), rc, scr)
+	}
+
+	t.Run("no filters leaves sync data untouched", func(t *testing.T) {
+		scs, mockStore, sd := setup(t, nil)
+		sd.posts = []*model.Post{{Id: model.NewId(), UserId: model.NewId()}}
+
+		require.NoError(t, scs.applySyncFilters(sd))
+		assert.Len(t, sd.posts, 1)
+		mockStore.AssertNotCalled(t, "SharedChannel")
+	})
+
+	t.Run("excluded posts, reactions and users are suppressed", func(t *testing.T) {
+		excludedUser := &model.User{Id: model.NewId()}
+		author := &model.User{Id: model.NewId()}
+		scs, mockStore, sd := setup(t, &model.SharedChannelRemoteSyncFilters{
+			ExcludeUserIds:   []string{excludedUser.Id},
+			ExcludePostTypes: []string{model.PostTypeJoinChannel},
+		})
+
+		kept := &model.Post{Id: model.NewId(), UserId: author.Id}
+		byUser := &model.Post{Id: model.NewId(), UserId: excludedUser.Id}
+		byType := &model.Post{Id: model.NewId(), UserId: author.Id, Type: model.PostTypeJoinChannel}
+		sd.posts = []*model.Post{kept, byUser, byType}
+		sd.reactions = []*model.Reaction{
+			{PostId: kept.Id, UserId: author.Id},
+			{PostId: byUser.Id, UserId: author.Id},
+		}
+		sd.users[author.Id] = author
+		sd.users[excludedUser.Id] = excludedUser
+
+		mockSharedChannelStore := &mocks.SharedChannelStore{}
+		mockSharedChannelStore.On("SaveSyncSuppressions", mock.MatchedBy(func(s []*model.SharedChannelSyncSuppression) bool {
+			return len(s) == 2 &&
+				s[0].ItemId == byUser.Id && s[0].Reason == model.SharedChannelSyncSuppressedUser &&
+				s[1].ItemId == byType.Id && s[1].Reason == model.SharedChannelSyncSuppressedPostType
+		})).Return(nil)
+		mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+		require.NoError(t, scs.applySyncFilters(sd))
+		require.Len(t, sd.posts, 1)
+		assert.Equal(t, kept.Id, sd.posts[0].Id)
+		require.Len(t, sd.reactions, 1)
+		assert.Equal(t, kept.Id, sd.reactions[0].PostId)
+		assert.Contains(t, sd.users, author.Id)
+		assert.NotContains(t, sd.users, excludedUser.Id)
+		mockSharedChannelStore.AssertExpectations(t)
+	})
+
+	t.Run("reactions to previously suppressed posts are dropped", func(t *testing.T) {
+		scs, mockStore, sd := setup(t, &model.SharedChannelRemoteSyncFilters{ExcludePostTypes: []string{model.PostTypeJoinChannel}})
+
+		suppressedPostID := model.NewId()
+		sd.reactions = []*model.Reaction{{PostId: suppressedPostID, UserId: model.NewId()}}
+
+		mockSharedChannelStore := &mocks.SharedChannelStore{}
+		mockSharedChannelStore.On("GetSyncSuppressions", 0, 1, model.SharedChannelSyncSuppressionFilterOpts{
+			RemoteId: rc.RemoteId,
+			ItemIds:  []string{suppressedPostID},
+		}).Return([]*model.SharedChannelSyncSuppression{
+			{RemoteId: rc.RemoteId, ItemId: suppressedPostID, ItemType: model.SharedChannelSyncItemPost},
+		}, nil)
+		mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+		require.NoError(t, scs.applySyncFilters(sd))
+		assert.Empty(t, sd.reactions)
+	})
+
+	t.Run("oversized attachments are removed from posts", func(t *testing.T) {
+		scs, mockStore, sd := setup(t, &model.SharedChannelRemoteSyncFilters{MaxAttachmentSize: 1024})
+
+		small := &model.FileInfo{Id: model.NewId(), Size: 512}
+		large := &model.FileInfo{Id: model.NewId(), Size: 4096}
+		post := &model.Post{
+			Id:       model.NewId(),
+			UserId:   model.NewId(),
+			FileIds:  model.StringArray{small.Id, large.Id},
+			Metadata: &model.PostMetadata{Files: []*model.FileInfo{small, large}},
+		}
+		sd.posts = []*model.Post{post}
+		sd.attachments = []attachment{{fi: small, post: post}, {fi: large, post: post}}
+
+		mockSharedChannelStore := &mocks.SharedChannelStore{}
+		mockSharedChannelStore.On("SaveSyncSuppressions", mock.MatchedBy(func(s []*model.SharedChannelSyncSuppression) bool {
+			return len(s) == 1 && s[0].ItemId == large.Id && s[0].ItemType == model.SharedChannelSyncItemFile
+		})).Return(nil)
+		mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+		require.NoError(t, scs.applySyncFilters(sd))
+		require.Len(t, sd.attachments, 1)
+		assert.Equal(t, small.Id, sd.attachments[0].fi.Id)
+		assert.Equal(t, model.StringArray{small.Id}, post.FileIds)
+		assert.Equal(t, []*model.FileInfo{small}, post.Metadata.Files)
+		mockSharedChannelStore.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// sharedChannelRemotesPerPage is the number of channels shared with a remote fetched at once.
+const sharedChannelRemotesPerPage = 200
+
+func syncHealthKey(channelID, remoteID string) string {
+	return channelID + remoteID
+}
+
+// recordSyncSuccess stores that the channel was synchronized with the remote. An empty
+// channelID records the global user sync of the remote.
+func (scs *Service) recordSyncSuccess(channelID, remoteID string) {
+	if err := scs.server.GetStore().SharedChannel().SaveSyncSuccess(channelID, remoteID, model.GetMillis()); err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceWarn, "Failed to save the sync success of a shared channel",
+			mlog.String("channel_id", channelID),
+			mlog.String("remote_id", remoteID),
+			mlog.Err(err),
+		)
+	}
+}
+
+// recordSyncError stores a failed sync of the channel with the remote and whether
+// the failure was scheduled for retry.
+func (scs *Service) recordSyncError(channelID, remoteID string, syncErr error, retry bool) {
+	if err := scs.server.GetStore().SharedChannel().SaveSyncError(channelID, remoteID, syncErr.Error(), model.GetMillis(), retry); err != nil {
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceWarn, "Failed to save the sync error of a shared channel",
+			mlog.String("channel_id", channelID),
+			mlog.String("remote_id", remoteID),
+			mlog.Err(err),
+		)
+	}
+}
+
+// getAllRemotes fetches all the shared channel remotes matching the options, a page at a time.
+func (scs *Service) getAllRemotes(opts model.SharedChannelRemoteFilterOpts) ([]*model.SharedChannelRemote, error) {
+	var scrs []*model.SharedChannelRemote
+	for offset := 0; ; offset += sharedChannelRemotesPerPage {
+		page, err := scs.server.GetStore().SharedChannel().GetRemotes(offset, sharedChannelRemotesPerPage, opts)
+		if err != nil {
+			return nil, err
+		}
+		scrs = append(scrs, page...)
+		if len(page) < sharedChannelRemotesPerPage {
+			return scrs, nil
+		}
+	}
+}
+
+// pendingTaskCounts returns the number of queued tasks per channel + remote combination.
+// Tasks not targeting a specific remote are counted under an empty remoteID.
+func (scs *Service) pendingTaskCounts() map[string]int {
+	scs.mux.RLock()
+	defer scs.mux.RUnlock()
+
+	counts := make(map[string]int)
+	for _, task := range scs.tasks {
+		counts[syncHealthKey(task.channelID, task.remoteID)]++
+	}
+	return counts
+}
+
+// GetSyncHealth reports the sync cursor, lag, pending tasks, retries and last error
+// for every channel shared with the remote. The pending tasks are only known by the
+// cluster leader, which runs the sync.
+func (scs *Service) GetSyncHealth(rc *model.RemoteCluster) (*model.RemoteClusterSyncHealth, error) {
+	opts := model.SharedChannelRemoteFilterOpts{
+		RemoteId: rc.RemoteId,
+	}
+	scrs, err := scs.getAllRemotes(opts)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch shared channel remotes for remote %s: %w", rc.RemoteId, err)
+	}
+
+	stored, err := scs.server.GetStore().SharedChannel().GetSyncHealth(rc.RemoteId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot fetch sync health for remote %s: %w", rc.RemoteId, err)
+	}
+	syncHealth := make(map[string]*model.SharedChannelSyncHealth, len(stored))
+	for _, h := range stored {
+		syncHealth[h.ChannelId] = h
+	}
+
+	pending := scs.pendingTaskCounts()
+	now := model.GetMillis()
+
+	health := &model.RemoteClusterSyncHealth{
+		RemoteId:    rc.RemoteId,
+		DisplayName: rc.DisplayName,
+		LastPingAt:  rc.LastPingAt,
+		Online:      rc.IsOnline(),
+		Channels:    make([]*model.SharedChannelSyncHealth, 0, len(scrs)),
+	}
+
+	for _, scr := range scrs {
+		ch := &model.SharedChannelSyncHealth{
+			ChannelId:        scr.ChannelId,
+			LastPostCreateAt: scr.LastPostCreateAt,
+			LastPostCreateID: scr.LastPostCreateID,
+			LastPostUpdateAt: scr.LastPostUpdateAt,
+			LastPostUpdateID: scr.LastPostUpdateID,
+			PendingTasks:     pending[syncHealthKey(scr.ChannelId, rc.RemoteId)] + pending[syncHealthKey(scr.ChannelId, "")],
+		}
+
+		options := model.GetPostsSinceForSyncOptions{
+			ChannelId:                         scr.ChannelId,
+			ExcludeRemoteId:                   rc.RemoteId,
+			IncludeDeleted:                    true,
+			ExcludeChannelMetadataSystemPosts: true,
+		}
+		cursor := model.GetPostsSinceForSyncCursor{
+			LastPostUpdateAt: scr.LastPostUpdateAt,
+			LastPostUpdateID: scr.LastPostUpdateID,
+		}
+		lagPosts, oldest, err := scs.server.GetStore().Post().CountPostsSinceForSync(options, cursor)
+		if err != nil {
+			return nil, fmt.Errorf("cannot count posts to sync for channel %s: %w", scr.ChannelId, err)
+		}
+		ch.LagPosts = lagPosts
+		if lagPosts > 0 {
+			ch.LagMillis = max(now-oldest, 0)
+		}
+
+		fillSyncHealth(ch, syncHealth[scr.ChannelId])
+		health.AddChannel(ch)
+	}
+
+	// account for the global user sync of the remote.
+	global := &model.SharedChannelSyncHealth{PendingTasks: pending[syncHealthKey("", rc.RemoteId)]}
+	fillSyncHealth(global, syncHealth[""])
+	health.PendingTasks += global.PendingTasks
+	health.RetryCount += global.RetryCount
+	if global.LastErrorAt > health.LastErrorAt {
+		health.LastError = global.LastError
+		health.LastErrorAt = global.LastErrorAt
+	}
+
+	return health, nil
+}
+
+// fillSyncHealth copies the stored retries, last sync and last error, if any, to ch.
+func fillSyncHealth(ch *model.SharedChannelSyncHealth, stored *model.SharedChannelSyncHealth) {
+	if stored == nil {
+		return
+	}
+	ch.RetryCount = stored.RetryCount
+	ch.LastSyncAt = stored.LastSyncAt
+	ch.LastError = stored.LastError
+	ch.LastErrorAt = stored.LastErrorAt
+}
+
+// ResyncFromTimestamp rewinds the sync cursor of the channel shared with the remote so every
+// post created or updated since the given time (in milliseconds) is sent again. An empty channelID
+// rewinds all the channels shared with the remote.
+func (scs *Service) ResyncFromTimestamp(rc *model.RemoteCluster, channelID string, since int64) error {
+	opts := model.SharedChannelRemoteFilterOpts{
+		RemoteId:  rc.RemoteId,
+		ChannelId: channelID,
+	}
+	scrs, err := scs.getAllRemotes(opts)
+	if err != nil {
+		return fmt.Errorf("cannot fetch shared channel remotes for remote %s: %w", rc.RemoteId, err)
+	}
+	if channelID != "" && len(scrs) == 0 {
+		return fmt.Errorf("channel %s is not shared with remote %s", channelID, rc.RemoteId)
+	}
+
+	cursor := model.GetPostsSinceForSyncCursor{
+		LastPostCreateAt: since,
+		LastPostUpdateAt: since,
+	}
+
+	for _, scr := range scrs {
+		if err := scs.server.GetStore().SharedChannel().UpdateRemoteCursor(scr.Id, cursor); err != nil {
+			return fmt.Errorf("cannot rewind cursor for channel %s: %w", scr.ChannelId, err)
+		}
+
+		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Rewound sync cursor for remote",
+			mlog.String("remote", rc.DisplayName),
+			mlog.String("channel_id", scr.ChannelId),
+			mlog.Int("since", since),
+		)
+
+		task := newSyncTask(scr.ChannelId, "", rc.RemoteId, nil, nil)
+		task.schedule = time.Now().Add(NotifyMinimumDelay)
+		scs.addTask(task)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sharedchannel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest/mock"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/store/storetest/mocks"
+)
+
+func TestGetSyncHealth(t *testing.T) {
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), DisplayName: "remote", LastPingAt: model.GetMillis()}
+	scr := &model.SharedChannelRemote{
+		Id:               model.NewId(),
+		ChannelId:        model.NewId(),
+		RemoteId:         rc.RemoteId,
+		LastPostUpdateAt: 1000,
+		LastPostUpdateID: model.NewId(),
+	}
+
+	mockServer := &MockServerIface{}
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+	mockStore := &mocks.Store{}
+	mockServer.On("GetStore").Return(mockStore)
+
+	mockSharedChannelStore := &mocks.SharedChannelStore{}
+	mockSharedChannelStore.On("GetRemotes", 0, sharedChannelRemotesPerPage, model.SharedChannelRemoteFilterOpts{RemoteId: rc.RemoteId}).
+		Return([]*model.SharedChannelRemote{scr}, nil)
+	mockSharedChannelStore.On("GetSyncHealth", rc.RemoteId).
+		Return([]*model.SharedChannelSyncHealth{
+			{ChannelId: scr.ChannelId, RetryCount: 1, LastSyncAt: 3000, LastError: "remote is offline", LastErrorAt: 2000},
+			{ChannelId: "", RetryCount: 2, LastError: "user sync failed", LastErrorAt: 1000},
+		}, nil)
+	mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+	mockPostStore := &mocks.PostStore{}
+	mockPostStore.On("CountPostsSinceForSync", mock.MatchedBy(func(opts model.GetPostsSinceForSyncOptions) bool {
+		return opts.ChannelId == scr.ChannelId && opts.ExcludeRemoteId == rc.RemoteId
+	}), model.GetPostsSinceForSyncCursor{LastPostUpdateAt: scr.LastPostUpdateAt, LastPostUpdateID: scr.LastPostUpdateID}).
+		Return(int64(4), int64(1500), nil)
+	mockStore.On("Post").Return(mockPostStore)
+
+	scs := &Service{server: mockServer, app: &MockAppIface{}, tasks: make(map[string]syncTask)}
+	scs.tasks["a"] = newSyncTask(scr.ChannelId, "", rc.RemoteId, nil, nil)
+	scs.tasks["b"] = newSyncTask(scr.ChannelId, "", "", nil, nil)
+	scs.tasks["c"] = newSyncTask("", model.NewId(), rc.RemoteId, nil, nil)
+
+	health, err := scs.GetSyncHealth(rc)
+	require.NoError(t, err)
+
+	assert.Equal(t, rc.RemoteId, health.RemoteId)
+	assert.True(t, health.Online)
+	assert.Equal(t, int64(4), health.LagPosts)
+	assert.Equal(t, 3, health.PendingTasks)
+	assert.Equal(t, int64(3), health.RetryCount)
+	assert.Equal(t, "remote is offline", health.LastError)
+
+	require.Len(t, health.Channels, 1)
+	ch := health.Channels[0]
+	assert.Equal(t, scr.ChannelId, ch.ChannelId)
+	assert.Equal(t, scr.LastPostUpdateID, ch.LastPostUpdateID)
+	assert.Equal(t, int64(4), ch.LagPosts)
+	assert.Positive(t, ch.LagMillis)
+	assert.Equal(t, 2, ch.PendingTasks)
+	assert.Equal(t, int64(1), ch.RetryCount)
+	assert.Equal(t, int64(3000), ch.LastSyncAt)
+}
+
+func TestRecordSyncHealth(t *testing.T) {
+	channelID := model.NewId()
+	remoteID := model.NewId()
+
+	mockServer := &MockServerIface{}
+	mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+	mockStore := &mocks.Store{}
+	mockServer.On("GetStore").Return(mockStore)
+
+	mockSharedChannelStore := &mocks.SharedChannelStore{}
+	mockSharedChannelStore.On("SaveSyncError", channelID, remoteID, "remote is offline", mock.AnythingOfType("int64"), false).Return(nil).Once()
+	mockSharedChannelStore.On("SaveSyncError", channelID, remoteID, "first failure", mock.AnythingOfType("int64"), true).Return(errors.New("store failure")).Once()
+	mockSharedChannelStore.On("SaveSyncSuccess", channelID, remoteID, mock.AnythingOfType("int64")).Return(nil).Once()
+	mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+	scs := &Service{server: mockServer, app: &MockAppIface{}, tasks: make(map[string]syncTask)}
+	scs.recordSyncError(channelID, remoteID, errors.New("remote is offline"), false)
+	// failing to store the health doesn't affect the sync
+	scs.recordSyncError(channelID, remoteID, errors.New("first failure"), true)
+	scs.recordSyncSuccess(channelID, remoteID)
+
+	mockSharedChannelStore.AssertExpectations(t)
+}
+
+func TestGetAllRemotes(t *testing.T) {
+	opts := model.SharedChannelRemoteFilterOpts{RemoteId: model.NewId()}
+	firstPage := make([]*model.SharedChannelRemote, sharedChannelRemotesPerPage)
+	for i := range firstPage {
+		firstPage[i] = &model.SharedChannelRemote{Id: model.NewId()}
+	}
+	secondPage := []*model.SharedChannelRemote{{Id: model.NewId()}}
+
+	mockServer := &MockServerIface{}
+	mockStore := &mocks.Store{}
+	mockServer.On("GetStore").Return(mockStore)
+	mockSharedChannelStore := &mocks.SharedChannelStore{}
+	mockSharedChannelStore.On("GetRemotes", 0, sharedChannelRemotesPerPage, opts).Return(firstPage, nil).Once()
+	mockSharedChannelStore.On("GetRemotes", sharedChannelRemotesPerPage, sharedChannelRemotesPerPage, opts).Return(secondPage, nil).Once()
+	mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+	scs := &Service{server: mockServer}
+	scrs, err := scs.getAllRemotes(opts)
+	require.NoError(t, err)
+	assert.Len(t, scrs, sharedChannelRemotesPerPage+1)
+	mockSharedChannelStore.AssertExpectations(t)
+}
+
+func TestResyncFromTimestamp(t *testing.T) {
+	rc := &model.RemoteCluster{RemoteId: model.NewId(), DisplayName: "remote"}
+	channelID := model.NewId()
+	since := model.GetMillis() - 60000
+
+	setup := func(t *testing.T, scrs []*model.SharedChannelRemote) (*Service, *mocks.SharedChannelStore) {
+		mockServer := &MockServerIface{}
+		mockServer.On("Log").Return(mlog.CreateConsoleTestLogger(t))
+		mockStore := &mocks.Store{}
+		mockServer.On("GetStore").Return(mockStore)
+
+		mockSharedChannelStore := &mocks.SharedChannelStore{}
+		mockSharedChannelStore.On("GetRemotes", 0, sharedChannelRemotesPerPage, model.SharedChannelRemoteFilterOpts{RemoteId: rc.RemoteId, ChannelId: channelID}).
+			Return(scrs, nil)
+		mockStore.On("SharedChannel").Return(mockSharedChannelStore)
+
+		scs := &Service{
+			server:       mockServer,
+			app:          &MockAppIface{},
+			changeSignal: make(chan struct{}, 1),
+			tasks:        make(map[string]syncTask),
+		}
+		return scs, mockSharedChannelStore
+	}
+
+	t.Run("rewinds the cursor and schedules a sync", func(t *testing.T) {
+		scr := &model.SharedChannelRemote{Id: model.NewId(), ChannelId: channelID, RemoteId: rc.RemoteId}
+		scs, mockSharedChannelStore := setup(t, []*model.SharedChannelRemote{scr})
+		mockSharedChannelStore.On("UpdateRemoteCursor", scr.Id, model.GetPostsSinceForSyncCursor{LastPostCreateAt: since, LastPostUpdateAt: since}).
+			Return(nil)
+
+		require.NoError(t, scs.ResyncFromTimestamp(rc, channelID, since))
+		mockSharedChannelStore.AssertExpectations(t)
+		assert.Equal(t, 1, scs.pendingTaskCounts()[syncHealthKey(channelID, rc.RemoteId)])
+	})
+
+	t.Run("fails if the channel is not shared with the remote", func(t *testing.T) {
+		scs, _ := setup(t, nil)
+
+		require.Error(t, scs.ResyncFromTimestamp(rc, channelID, since))
+		assert.Empty(t, scs.tasks)
+	})
+}
@@ -429,8 +429,10 @@ func (scs *Service) processTask(task syncTask) error {
 		rtask := task
 		rtask.remoteID = rc.RemoteId
 		if err := scs.syncForRemote(rtask, rc); err != nil {
+			retry := rtask.incRetry()
+			scs.recordSyncError(rtask.channelID, rc.RemoteId, err, retry)
 			// retry...
-			if rtask.incRetry() {
+			if retry {
 				scs.addTask(rtask)
 			} else {
 				scs.server.Log().Error("Failed to synchronize shared channel for remote cluster",
@@ -439,6 +441,8 @@ func (scs *Service) processTask(task syncTask) error {
 					mlog.Err(err),
 				)
 			}
+		} else {
+			scs.recordSyncSuccess(rtask.channelID, rc.RemoteId)
 		}
 	}
 	return nil
@@ -447,7 +451,9 @@ func (scs *Service) processTask(task syncTask) error {
 func (scs *Service) handlePostError(postId string, task syncTask, rc *model.RemoteCluster) {
 	if task.retryMsg != nil && len(task.retryMsg.Posts) == 1 && task.retryMsg.Posts[0].Id == postId {
 		// this was a retry for specific post that failed previously. Try again if within MaxRetries.
-		if task.incRetry() {
+		retry := task.incRetry()
+		scs.recordSyncError(task.channelID, rc.RemoteId, fmt.Errorf("remote failed to sync post %s", postId), retry)
+		if retry {
 			scs.addTask(task)
 		} else {
 			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "error syncing post",
@@ -461,6 +467,7 @@ func (scs *Service) handlePostError(postId string, task syncTask, rc *model.Remo
 	// this post failed as part of a group of posts. Retry as an individual post.
 	post, err := scs.server.GetStore().Post().GetSingle(request.EmptyContext(scs.server.Log()), postId, true)
 	if err != nil {
+		scs.recordSyncError(task.channelID, rc.RemoteId, fmt.Errorf("remote failed to sync post %s", postId), false)
 		scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "error fetching post for sync retry",
 			mlog.String("remote", rc.DisplayName),
 			mlog.String("post_id", postId),
@@ -475,13 +482,17 @@ func (scs *Service) handlePostError(postId string, task syncTask, rc *model.Remo
 	syncMsg := model.NewSyncMsg(task.channelID)
 	syncMsg.Posts = []*model.Post{post}
 
+	scs.recordSyncError(task.channelID, rc.RemoteId, fmt.Errorf("remote failed to sync post %s", postId), true)
+
 	scs.addTask(newSyncTask(task.channelID, task.userID, task.remoteID, nil, syncMsg))
 }
 
 func (scs *Service) handleStatusError(userId string, task syncTask, rc *model.RemoteCluster) {
 	if task.retryMsg != nil && len(task.retryMsg.Statuses) == 1 && task.retryMsg.Statuses[0].UserId == userId {
 		// this was a retry for specific status that failed previously. Try again if within MaxRetries.
-		if task.incRetry() {
+		retry := task.incRetry()
+		scs.recordSyncError(task.channelID, rc.RemoteId, fmt.Errorf("remote failed to sync status of user %s", userId), retry)
+		if retry {
 			scs.addTask(task)
 		} else {
 			scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "error syncing status",
@@ -495,6 +506,7 @@ func (scs *Service) handleStatusError(userId string, task syncTask, rc *model.Re
 	// this status failed as part of a group of statuses. Retry as an individual status.
 	status, err := scs.server.GetStore().Status().Get(userId)
 	if err != nil {
+		scs.recordSyncError(task.channelID, rc.RemoteId, fmt.Errorf("remote failed to sync status of user %s", userId), false)
 		scs.server.Log().Log(mlog.LvlSharedChannelServiceError, "error fetching status for sync retry",
 			mlog.String("remote", rc.DisplayName),
 			mlog.String("user_id", userId),
@@ -506,6 +518,8 @@ func (scs *Service) handleStatusError(userId string, task syncTask, rc *model.Re
 	syncMsg := model.NewSyncMsg(task.channelID)
 	syncMsg.Statuses = []*model.Status{status}
 
+	scs.recordSyncError(task.channelID, rc.RemoteId, fmt.Errorf("remote failed to sync status of user %s", userId), true)
+
 	scs.addTask(newSyncTask(task.channelID, task.userID, task.remoteID, nil, syncMsg))
 }
 
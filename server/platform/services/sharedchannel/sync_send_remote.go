@@ -144,12 +144,18 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 	// if this is retrying a failed msg, just send it again.
 	if task.retryMsg != nil {
 		sd.setDataFromMsg(task.retryMsg)
+		if err := scs.applySyncFilters(sd); err != nil {
+			return fmt.Errorf("cannot apply sync filters %v: %w", sd, err)
+		}
 		return scs.sendSyncData(sd)
 	}
 
 	// if this has an already existing msg, just send it right away
 	if task.existingMsg != nil {
 		sd.setDataFromMsg(task.existingMsg)
+		if err := scs.applySyncFilters(sd); err != nil {
+			return fmt.Errorf("cannot apply sync filters %v: %w", sd, err)
+		}
 		return scs.sendSyncData(sd)
 	}
 
@@ -174,6 +180,7 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 	if !rc.IsOnline() {
 		if len(sd.posts) != 0 {
 			scs.notifyRemoteOffline(sd.posts, rc)
+			scs.recordSyncError(task.channelID, rc.RemoteId, fmt.Errorf("remote %s is offline", rc.DisplayName), false)
 		}
 		sd.resultRepeat = false
 		return nil
@@ -207,6 +214,11 @@ func (scs *Service) syncForRemote(task syncTask, rc *model.RemoteCluster) error
 		return fmt.Errorf("cannot fetch post attachments for sync %v: %w", sd, err)
 	}
 
+	// remove anything excluded by the remote's sync filters
+	if err := scs.applySyncFilters(sd); err != nil {
+		return fmt.Errorf("cannot apply sync filters %v: %w", sd, err)
+	}
+
 	if sd.isEmpty() {
 		scs.server.Log().Log(mlog.LvlSharedChannelServiceDebug, "Not sending sync data; everything filtered out",
 			mlog.String("remote", rc.DisplayName),
@@ -75,6 +75,7 @@ const (
 	TrackConfigGuestAccounts       = "config_guest_accounts"
 	TrackConfigImageProxy          = "config_image_proxy"
 	TrackConfigBleve               = "config_bleve"
+	TrackConfigSemanticSearch      = "config_semantic_search"
 	TrackConfigExport              = "config_export"
 	TrackConfigWrangler            = "config_wrangler"
 	TrackConfigConnectedWorkspaces = "config_connected_workspaces"
@@ -952,6 +953,16 @@ func (ts *TelemetryService) trackConfig() {
 		"bulk_indexing_batch_size": *cfg.BleveSettings.BatchSize,
 	}
 
+	configs[TrackConfigSemanticSearch] = map[string]any{
+		"enable_indexing":         *cfg.SemanticSearchSettings.EnableIndexing,
+		"enable_searching":        *cfg.SemanticSearchSettings.EnableSearching,
+		"embedding_provider":      *cfg.SemanticSearchSettings.EmbeddingProvider,
+		"embedding_model":         *cfg.SemanticSearchSettings.EmbeddingModel,
+		"embedding_dimensions":    *cfg.SemanticSearchSettings.EmbeddingDimensions,
+		"request_timeout_seconds": *cfg.SemanticSearchSettings.RequestTimeoutSeconds,
+		"minimum_similarity":      *cfg.SemanticSearchSettings.MinimumSimilarity,
+	}
+
 	configs[TrackConfigExport] = map[string]any{
 		"retention_days": *cfg.ExportSettings.RetentionDays,
 	}
@@ -1497,6 +1508,9 @@ func (ts *TelemetryService) trackPluginConfig(configs map[string]any, cfg *model
 		"is_default_marketplace_url":    isDefault(*cfg.PluginSettings.MarketplaceURL, model.PluginSettingsDefaultMarketplaceURL),
 		"signature_public_key_files":    len(cfg.PluginSettings.SignaturePublicKeyFiles),
 		"chimera_oauth_proxy_url":       *cfg.PluginSettings.ChimeraOAuthProxyURL,
+		"wasm_memory_limit_mb":          *cfg.PluginSettings.WasmMemoryLimitMB,
+		"wasm_hook_timeout_seconds":     *cfg.PluginSettings.WasmHookTimeoutSeconds,
+		"require_plugin_permissions":    *cfg.PluginSettings.RequirePluginPermissions,
 	}
 
 	// knownPluginIDs lists all known plugin IDs in the Marketplace
@@ -198,6 +198,14 @@ const (
 	AuditEventUnlinkLdapGroup              = "unlinkLdapGroup"              // unlink LDAP group from Mattermost team or channel
 )
 
+// Legal Holds
+const (
+	AuditEventCreateLegalHold  = "createLegalHold"  // create legal hold over users and channels
+	AuditEventExportLegalHold  = "exportLegalHold"  // start compliance export of data covered by legal hold
+	AuditEventPatchLegalHold   = "patchLegalHold"   // update legal hold
+	AuditEventReleaseLegalHold = "releaseLegalHold" // release legal hold
+)
+
 // Licensing
 const (
 	AuditEventAddLicense          = "addLicense"          // add license
@@ -230,11 +238,13 @@ const (
 
 // Plugins
 const (
+	AuditEventApprovePluginPermissions            = "approvePluginPermissions"            // approve the permissions declared by an installed plugin
 	AuditEventDisablePlugin                       = "disablePlugin"                       // disable installed plugin
 	AuditEventEnablePlugin                        = "enablePlugin"                        // enable installed plugin
 	AuditEventGetFirstAdminVisitMarketplaceStatus = "getFirstAdminVisitMarketplaceStatus" // get first admin visit status
 	AuditEventInstallMarketplacePlugin            = "installMarketplacePlugin"            // install plugin from official marketplace
 	AuditEventInstallPluginFromURL                = "installPluginFromURL"                // install plugin from external URL
+	AuditEventPluginAPICallDenied                 = "pluginAPICallDenied"                 // plugin API call denied for a missing permission
 	AuditEventRemovePlugin                        = "removePlugin"                        // delete plugin
 	AuditEventSetFirstAdminVisitMarketplaceStatus = "setFirstAdminVisitMarketplaceStatus" // set first admin visit status
 	AuditEventUploadPlugin                        = "uploadPlugin"                        // upload plugin file to server for installation
@@ -269,7 +279,9 @@ const (
 	AuditEventRemoteClusterAcceptInvite      = "remoteClusterAcceptInvite"      // accept invitation from remote cluster
 	AuditEventRemoteClusterAcceptMessage     = "remoteClusterAcceptMessage"     // accept message from remote cluster
 	AuditEventRemoteUploadProfileImage       = "remoteUploadProfileImage"       // upload profile image from remote cluster
+	AuditEventResyncRemoteCluster            = "resyncRemoteCluster"            // rewind shared channel sync cursors for remote cluster
 	AuditEventUninviteRemoteClusterToChannel = "uninviteRemoteClusterToChannel" // remove remote cluster access from shared channel
+	AuditEventUpdateRemoteSyncFilters        = "updateRemoteSyncFilters"        // update content filters for shared channel sync to remote cluster
 	AuditEventUploadRemoteData               = "uploadRemoteData"               // upload data to remote cluster
 )
 
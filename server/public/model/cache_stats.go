@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// CacheStats is a snapshot of the usage of one of the server caches.
+// The counters are local to the node that produced the snapshot and
+// are reset when the server restarts.
+type CacheStats struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Size is the maximum number of entries of the cache. It's 0 for
+	// caches that aren't bounded by the server, like Redis.
+	Size int `json:"size"`
+	// Len is the current number of entries of the cache, or -1 if the
+	// cache can't report it.
+	Len       int   `json:"len"`
+	Hits      int64 `json:"hits"`
+	Misses    int64 `json:"misses"`
+	Evictions int64 `json:"evictions"`
+	// HitRatio is the fraction of the lookups that were found in the
+	// cache.
+	HitRatio float64 `json:"hit_ratio"`
+	// MemoryBytes is an estimate of the memory used by the keys and the
+	// encoded values of the cache.
+	MemoryBytes int64 `json:"memory_bytes"`
+}
@@ -438,6 +438,14 @@ func (c *Client4) dataRetentionPolicyRoute(policyID string) string {
 	return fmt.Sprintf(c.dataRetentionRoute()+"/policies/%v", policyID)
 }
 
+func (c *Client4) legalHoldsRoute() string {
+	return "/legal_holds"
+}
+
+func (c *Client4) legalHoldRoute(legalHoldID string) string {
+	return fmt.Sprintf(c.legalHoldsRoute()+"/%v", legalHoldID)
+}
+
 func (c *Client4) elasticsearchRoute() string {
 	return "/elasticsearch"
 }
@@ -5105,6 +5113,37 @@ func (c *Client4) InvalidateCaches(ctx context.Context) (*Response, error) {
 	return BuildResponse(r), nil
 }
 
+// SendSSERequest sends a WebSocket API request through the Server-Sent
+// Events connection with the given id. The response is delivered
+// through the event stream.
+func (c *Client4) SendSSERequest(ctx context.Context, connectionId string, req *WebSocketRequest) (*Response, error) {
+	buf, err := json.Marshal(req)
+	if err != nil {
+		return nil, NewAppError("SendSSERequest", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, "/sse/requests?connection_id="+url.QueryEscape(connectionId), buf)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// GetCacheStats returns the usage statistics of the caches of the node
+// that handles the request.
+func (c *Client4) GetCacheStats(ctx context.Context) ([]*CacheStats, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.cacheRoute()+"/stats", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var stats []*CacheStats
+	if err := json.NewDecoder(r.Body).Decode(&stats); err != nil {
+		return nil, nil, NewAppError("GetCacheStats", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return stats, BuildResponse(r), nil
+}
+
 // UpdateConfig will update the server configuration.
 func (c *Client4) UpdateConfig(ctx context.Context, config *Config) (*Config, *Response, error) {
 	buf, err := json.Marshal(config)
@@ -5267,6 +5306,25 @@ func (c *Client4) UpdateIncomingWebhook(ctx context.Context, hook *IncomingWebho
 	return &iw, BuildResponse(r), nil
 }
 
+// TestIncomingWebhookTransform renders a transform template against a sample payload.
+func (c *Client4) TestIncomingWebhookTransform(ctx context.Context, test *IncomingWebhookTransformTest) (*IncomingWebhookRequest, *Response, error) {
+	buf, err := json.Marshal(test)
+	if err != nil {
+		return nil, nil, NewAppError("TestIncomingWebhookTransform", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.incomingWebhooksRoute()+"/transform/test", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var req IncomingWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, nil, NewAppError("TestIncomingWebhookTransform", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &req, BuildResponse(r), nil
+}
+
 // GetIncomingWebhooks returns a page of incoming webhooks on the system. Page counting starts at 0.
 func (c *Client4) GetIncomingWebhooks(ctx context.Context, page int, perPage int, etag string) ([]*IncomingWebhook, *Response, error) {
 	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
@@ -6778,6 +6836,109 @@ func (c *Client4) GetChannelPoliciesForUser(ctx context.Context, userID string,
 	return &channels, BuildResponse(r), nil
 }
 
+// Legal Hold Section
+
+// GetLegalHolds will get a page of the legal holds that have not been released.
+func (c *Client4) GetLegalHolds(ctx context.Context, page, perPage int) ([]*LegalHold, *Response, error) {
+	query := fmt.Sprintf("?page=%d&per_page=%d", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.legalHoldsRoute()+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var holds []*LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&holds); err != nil {
+		return nil, nil, NewAppError("GetLegalHolds", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return holds, BuildResponse(r), nil
+}
+
+// GetLegalHold will get the legal hold with the specified ID.
+func (c *Client4) GetLegalHold(ctx context.Context, legalHoldID string) (*LegalHold, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.legalHoldRoute(legalHoldID), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var hold LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&hold); err != nil {
+		return nil, nil, NewAppError("GetLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &hold, BuildResponse(r), nil
+}
+
+// CreateLegalHold will create a new legal hold over the specified users and channels.
+// The Id field of `hold` must be empty.
+func (c *Client4) CreateLegalHold(ctx context.Context, hold *LegalHold) (*LegalHold, *Response, error) {
+	holdJSON, err := json.Marshal(hold)
+	if err != nil {
+		return nil, nil, NewAppError("CreateLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.legalHoldsRoute(), holdJSON)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var created LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+		return nil, nil, NewAppError("CreateLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &created, BuildResponse(r), nil
+}
+
+// PatchLegalHold will patch the legal hold with the specified ID.
+func (c *Client4) PatchLegalHold(ctx context.Context, legalHoldID string, patch *LegalHoldPatch) (*LegalHold, *Response, error) {
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return nil, nil, NewAppError("PatchLegalHold", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPatchBytes(ctx, c.legalHoldRoute(legalHoldID), patchJSON)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var hold LegalHold
+	if err := json.NewDecoder(r.Body).Decode(&hold); err != nil {
+		return nil, nil, NewAppError("PatchLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &hold, BuildResponse(r), nil
+}
+
+// ReleaseLegalHold will release the legal hold with the specified ID. Data that was only
+// preserved by this hold becomes subject to data retention again.
+func (c *Client4) ReleaseLegalHold(ctx context.Context, legalHoldID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.legalHoldRoute(legalHoldID))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// ExportLegalHold will start a message export job restricted to the data covered by the
+// legal hold with the specified ID. If exportType is empty, the configured export format is used.
+func (c *Client4) ExportLegalHold(ctx context.Context, legalHoldID, exportType string) (*Job, *Response, error) {
+	query := ""
+	if exportType != "" {
+		query = "?export_type=" + url.QueryEscape(exportType)
+	}
+	r, err := c.DoAPIPost(ctx, c.legalHoldRoute(legalHoldID)+"/export"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var job Job
+	if err := json.NewDecoder(r.Body).Decode(&job); err != nil {
+		return nil, nil, NewAppError("ExportLegalHold", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &job, BuildResponse(r), nil
+}
+
 // Drafts Sections
 
 // UpsertDraft will create a new draft or update a draft if it already exists
@@ -7828,6 +7989,16 @@ func (c *Client4) EnablePlugin(ctx context.Context, id string) (*Response, error
 	return BuildResponse(r), nil
 }
 
+// ApprovePluginPermissions approves the permissions declared by an installed plugin.
+func (c *Client4) ApprovePluginPermissions(ctx context.Context, id string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.pluginRoute(id)+"/permissions/approve", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // DisablePlugin will disable an enabled plugin.
 func (c *Client4) DisablePlugin(ctx context.Context, id string) (*Response, error) {
 	r, err := c.DoAPIPost(ctx, c.pluginRoute(id)+"/disable", "")
@@ -9217,6 +9388,48 @@ func (c *Client4) DeleteRemoteCluster(ctx context.Context, remoteClusterId strin
 	return BuildResponse(r), nil
 }
 
+func (c *Client4) GetRemoteClustersSyncHealth(ctx context.Context) ([]*RemoteClusterSyncHealth, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.remoteClusterRoute()+"/sync_health", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var health []*RemoteClusterSyncHealth
+	if err := json.NewDecoder(r.Body).Decode(&health); err != nil {
+		return nil, nil, NewAppError("GetRemoteClustersSyncHealth", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return health, BuildResponse(r), nil
+}
+
+func (c *Client4) GetRemoteClusterSyncHealth(ctx context.Context, remoteClusterId string) (*RemoteClusterSyncHealth, *Response, error) {
+	r, err := c.DoAPIGet(ctx, fmt.Sprintf("%s/%s/sync_health", c.remoteClusterRoute(), remoteClusterId), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var health RemoteClusterSyncHealth
+	if err := json.NewDecoder(r.Body).Decode(&health); err != nil {
+		return nil, nil, NewAppError("GetRemoteClusterSyncHealth", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &health, BuildResponse(r), nil
+}
+
+func (c *Client4) ResyncRemoteCluster(ctx context.Context, remoteClusterId string, req *SharedChannelResyncRequest) (*Response, error) {
+	reqJSON, err := json.Marshal(req)
+	if err != nil {
+		return nil, NewAppError("ResyncRemoteCluster", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	r, err := c.DoAPIPostBytes(ctx, fmt.Sprintf("%s/%s/resync", c.remoteClusterRoute(), remoteClusterId), reqJSON)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 func (c *Client4) GetSharedChannelRemotesByRemoteCluster(ctx context.Context, remoteId string, filter SharedChannelRemoteFilterOpts, page, perPage int) ([]*SharedChannelRemote, *Response, error) {
 	v := url.Values{}
 	if filter.IncludeUnconfirmed {
@@ -9277,6 +9490,56 @@ func (c *Client4) UninviteRemoteClusterToChannel(ctx context.Context, remoteId,
 	return BuildResponse(r), nil
 }
 
+func (c *Client4) GetSharedChannelRemoteSyncFilters(ctx context.Context, remoteId, channelId string) (*SharedChannelRemoteSyncFilters, *Response, error) {
+	url := fmt.Sprintf("%s/sync_filters", c.channelRemoteRoute(remoteId, channelId))
+	r, err := c.DoAPIGet(ctx, url, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var filters SharedChannelRemoteSyncFilters
+	if err := json.NewDecoder(r.Body).Decode(&filters); err != nil {
+		return nil, nil, NewAppError("GetSharedChannelRemoteSyncFilters", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &filters, BuildResponse(r), nil
+}
+
+func (c *Client4) UpdateSharedChannelRemoteSyncFilters(ctx context.Context, remoteId, channelId string, filters *SharedChannelRemoteSyncFilters) (*SharedChannelRemoteSyncFilters, *Response, error) {
+	filtersJSON, err := json.Marshal(filters)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateSharedChannelRemoteSyncFilters", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	url := fmt.Sprintf("%s/sync_filters", c.channelRemoteRoute(remoteId, channelId))
+	r, err := c.DoAPIPutBytes(ctx, url, filtersJSON)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var updated SharedChannelRemoteSyncFilters
+	if err := json.NewDecoder(r.Body).Decode(&updated); err != nil {
+		return nil, nil, NewAppError("UpdateSharedChannelRemoteSyncFilters", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &updated, BuildResponse(r), nil
+}
+
+func (c *Client4) GetSharedChannelSyncSuppressions(ctx context.Context, remoteId, channelId string, page, perPage int) ([]*SharedChannelSyncSuppression, *Response, error) {
+	url := fmt.Sprintf("%s/suppressed?page=%d&per_page=%d", c.channelRemoteRoute(remoteId, channelId), page, perPage)
+	r, err := c.DoAPIGet(ctx, url, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var suppressions []*SharedChannelSyncSuppression
+	if err := json.NewDecoder(r.Body).Decode(&suppressions); err != nil {
+		return nil, nil, NewAppError("GetSharedChannelSyncSuppressions", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return suppressions, BuildResponse(r), nil
+}
+
 func (c *Client4) GetAncillaryPermissions(ctx context.Context, subsectionPermissions []string) ([]string, *Response, error) {
 	var returnedPermissions []string
 	url := fmt.Sprintf("%s/ancillary", c.permissionsRoute())
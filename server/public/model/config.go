@@ -4,7 +4,9 @@
 package model
 
 import (
+	"crypto/ed25519"
 	"crypto/tls"
+	"encoding/base64"
 	"encoding/json"
 	"io"
 	"math"
@@ -103,8 +105,12 @@ const (
 	EmailSMTPDefaultServer = "localhost"
 	EmailSMTPDefaultPort   = "10025"
 
-	CacheTypeLRU   = "lru"
-	CacheTypeRedis = "redis"
+	CacheTypeLRU    = "lru"
+	CacheTypeRedis  = "redis"
+	CacheTypeHybrid = "hybrid"
+
+	HybridInvalidationRedis   = "redis"
+	HybridInvalidationCluster = "cluster"
 
 	SitenameMaxLength = 30
 
@@ -227,6 +233,15 @@ const (
 	BleveSettingsDefaultIndexDir  = ""
 	BleveSettingsDefaultBatchSize = 10000
 
+	SemanticSearchEmbeddingProviderStub         = "stub"
+	SemanticSearchEmbeddingProviderOpenAI       = "openai"
+	SemanticSearchSettingsDefaultIndexDir       = ""
+	SemanticSearchSettingsDefaultAPIURL         = "https://api.openai.com/v1"
+	SemanticSearchSettingsDefaultModel          = "text-embedding-3-small"
+	SemanticSearchSettingsDefaultDimensions     = 256
+	SemanticSearchSettingsDefaultMinSimilarity  = 30
+	SemanticSearchSettingsDefaultRequestTimeout = 30
+
 	DataRetentionSettingsDefaultMessageRetentionDays           = 365
 	DataRetentionSettingsDefaultMessageRetentionHours          = 0
 	DataRetentionSettingsDefaultFileRetentionDays              = 365
@@ -244,6 +259,8 @@ const (
 	PluginSettingsDefaultEnableMarketplace = true
 	PluginSettingsDefaultMarketplaceURL    = "https://api.integrations.mattermost.com"
 	PluginSettingsOldMarketplaceURL        = "https://marketplace.integrations.mattermost.com"
+	PluginSettingsDefaultWasmMemoryLimitMB = 128
+	PluginSettingsDefaultWasmHookTimeout   = 30
 
 	ComplianceExportDirectoryFormat                = "compliance-export-2006-01-02-15h04m"
 	ComplianceExportPath                           = "export"
@@ -252,6 +269,9 @@ const (
 	ComplianceExportTypeActiance                   = "actiance"
 	ComplianceExportTypeGlobalrelay                = "globalrelay"
 	ComplianceExportTypeGlobalrelayZip             = "globalrelay-zip"
+	ComplianceExportTypeEml                        = "eml"
+	ComplianceExportTypeMbox                       = "mbox"
+	ComplianceExportTypeJsonl                      = "jsonl"
 	ComplianceExportChannelBatchSizeDefault        = 100
 	ComplianceExportChannelHistoryBatchSizeDefault = 10
 
@@ -1007,6 +1027,15 @@ type CacheSettings struct {
 	RedisDB            *int    `access:",write_restrictable,cloud_restrictable"` // telemetry: none
 	RedisCachePrefix   *string `access:",write_restrictable,cloud_restrictable"` // telemetry: none
 	DisableClientCache *bool   `access:",write_restrictable,cloud_restrictable"` // telemetry: none
+	// CacheSizes overrides the maximum number of entries of the in-memory
+	// caches, by cache name. Changes are applied without a restart.
+	CacheSizes map[string]int `access:",write_restrictable,cloud_restrictable"` // telemetry: none
+	// HybridLocalCaches are the names of the caches that keep a local
+	// copy of their entries in front of Redis, with the hybrid cache type.
+	HybridLocalCaches []string `access:",write_restrictable,cloud_restrictable"` // telemetry: none
+	// HybridInvalidation is how the nodes are told to drop their local
+	// copies with the hybrid cache type, either through Redis or the cluster.
+	HybridInvalidation *string `access:",write_restrictable,cloud_restrictable"` // telemetry: none
 }
 
 func (s *CacheSettings) SetDefaults() {
@@ -1033,21 +1062,45 @@ func (s *CacheSettings) SetDefaults() {
 	if s.DisableClientCache == nil {
 		s.DisableClientCache = NewPointer(false)
 	}
+
+	if s.CacheSizes == nil {
+		s.CacheSizes = map[string]int{}
+	}
+
+	if s.HybridLocalCaches == nil {
+		s.HybridLocalCaches = []string{"UserProfileByIds", "ChannelMembersForUser"}
+	}
+
+	if s.HybridInvalidation == nil {
+		s.HybridInvalidation = NewPointer(HybridInvalidationRedis)
+	}
 }
 
 func (s *CacheSettings) isValid() *AppError {
-	if *s.CacheType != CacheTypeLRU && *s.CacheType != CacheTypeRedis {
+	if *s.CacheType != CacheTypeLRU && *s.CacheType != CacheTypeRedis && *s.CacheType != CacheTypeHybrid {
 		return NewAppError("Config.IsValid", "model.config.is_valid.cache_type.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if *s.CacheType == CacheTypeRedis && *s.RedisAddress == "" {
+	usesRedis := *s.CacheType == CacheTypeRedis || *s.CacheType == CacheTypeHybrid
+
+	if usesRedis && *s.RedisAddress == "" {
 		return NewAppError("Config.IsValid", "model.config.is_valid.empty_redis_address.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	if *s.CacheType == CacheTypeRedis && *s.RedisDB < 0 {
+	if usesRedis && *s.RedisDB < 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.invalid_redis_db.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.HybridInvalidation != HybridInvalidationRedis && *s.HybridInvalidation != HybridInvalidationCluster {
+		return NewAppError("Config.IsValid", "model.config.is_valid.hybrid_invalidation.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for name, size := range s.CacheSizes {
+		if size <= 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.cache_size.app_error", map[string]any{"Name": name}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -3177,6 +3230,68 @@ func (bs *BleveSettings) SetDefaults() {
 	}
 }
 
+// SemanticSearchSettings configures the embeddings-backed post search that
+// complements the keyword search engines. The index is kept on the local
+// disk, so semantic search can't be enabled with clustering.
+type SemanticSearchSettings struct {
+	EnableIndexing  *bool   `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	EnableSearching *bool   `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	IndexDir        *string `access:"experimental_features,write_restrictable,cloud_restrictable"` // telemetry: none
+	// EmbeddingProvider is either "stub", a local deterministic provider meant
+	// for testing, or "openai" for any OpenAI compatible embeddings API.
+	EmbeddingProvider     *string `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	EmbeddingAPIURL       *string `access:"experimental_features,write_restrictable,cloud_restrictable"` // telemetry: none
+	EmbeddingAPIKey       *string `access:"experimental_features,write_restrictable,cloud_restrictable"` // telemetry: none
+	EmbeddingModel        *string `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	EmbeddingDimensions   *int    `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	RequestTimeoutSeconds *int    `access:"experimental_features,write_restrictable,cloud_restrictable"`
+	// MinimumSimilarity is the cosine similarity, as a percentage, below which
+	// posts are not returned by the semantic search.
+	MinimumSimilarity *int `access:"experimental_features,write_restrictable,cloud_restrictable"`
+}
+
+func (s *SemanticSearchSettings) SetDefaults() {
+	if s.EnableIndexing == nil {
+		s.EnableIndexing = NewPointer(false)
+	}
+
+	if s.EnableSearching == nil {
+		s.EnableSearching = NewPointer(false)
+	}
+
+	if s.IndexDir == nil {
+		s.IndexDir = NewPointer(SemanticSearchSettingsDefaultIndexDir)
+	}
+
+	if s.EmbeddingProvider == nil {
+		s.EmbeddingProvider = NewPointer(SemanticSearchEmbeddingProviderOpenAI)
+	}
+
+	if s.EmbeddingAPIURL == nil {
+		s.EmbeddingAPIURL = NewPointer(SemanticSearchSettingsDefaultAPIURL)
+	}
+
+	if s.EmbeddingAPIKey == nil {
+		s.EmbeddingAPIKey = NewPointer("")
+	}
+
+	if s.EmbeddingModel == nil {
+		s.EmbeddingModel = NewPointer(SemanticSearchSettingsDefaultModel)
+	}
+
+	if s.EmbeddingDimensions == nil {
+		s.EmbeddingDimensions = NewPointer(SemanticSearchSettingsDefaultDimensions)
+	}
+
+	if s.RequestTimeoutSeconds == nil {
+		s.RequestTimeoutSeconds = NewPointer(SemanticSearchSettingsDefaultRequestTimeout)
+	}
+
+	if s.MinimumSimilarity == nil {
+		s.MinimumSimilarity = NewPointer(SemanticSearchSettingsDefaultMinSimilarity)
+	}
+}
+
 type DataRetentionSettings struct {
 	EnableMessageDeletion          *bool   `access:"compliance_data_retention_policy"`
 	EnableFileDeletion             *bool   `access:"compliance_data_retention_policy"`
@@ -3338,6 +3453,9 @@ func (s *CloudSettings) SetDefaults() {
 
 type PluginState struct {
 	Enable bool
+	// ApprovedPermissions are the permissions declared by the manifest of the plugin that
+	// were approved by the system admin when enabling it.
+	ApprovedPermissions []string `json:",omitempty"`
 }
 
 type PluginSettings struct {
@@ -3356,6 +3474,9 @@ type PluginSettings struct {
 	MarketplaceURL              *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
 	SignaturePublicKeyFiles     []string                  `access:"plugins,write_restrictable,cloud_restrictable"`
 	ChimeraOAuthProxyURL        *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
+	WasmMemoryLimitMB           *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	WasmHookTimeoutSeconds      *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	RequirePluginPermissions    *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
 }
 
 func (s *PluginSettings) SetDefaults(ls LogSettings) {
@@ -3438,6 +3559,18 @@ func (s *PluginSettings) SetDefaults(ls LogSettings) {
 	if s.ChimeraOAuthProxyURL == nil {
 		s.ChimeraOAuthProxyURL = NewPointer("")
 	}
+
+	if s.WasmMemoryLimitMB == nil || *s.WasmMemoryLimitMB <= 0 {
+		s.WasmMemoryLimitMB = NewPointer(PluginSettingsDefaultWasmMemoryLimitMB)
+	}
+
+	if s.WasmHookTimeoutSeconds == nil || *s.WasmHookTimeoutSeconds <= 0 {
+		s.WasmHookTimeoutSeconds = NewPointer(PluginSettingsDefaultWasmHookTimeout)
+	}
+
+	if s.RequirePluginPermissions == nil {
+		s.RequirePluginPermissions = NewPointer(false)
+	}
 }
 
 // Sanitize cleans up the plugin settings by removing any sensitive information.
@@ -3599,6 +3732,34 @@ func (s *GlobalRelayMessageExportSettings) SetDefaults() {
 	}
 }
 
+type JsonlMessageExportSettings struct {
+	// SigningKey is the base64 encoded Ed25519 private key (or 32 byte seed) used to sign the manifest of every batch.
+	SigningKey *string `access:"compliance_compliance_export"`
+}
+
+func (s *JsonlMessageExportSettings) SetDefaults() {
+	if s.SigningKey == nil {
+		s.SigningKey = NewPointer("")
+	}
+}
+
+// PrivateKey decodes the SigningKey into an Ed25519 private key.
+func (s *JsonlMessageExportSettings) PrivateKey() (ed25519.PrivateKey, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(SafeDereference(s.SigningKey)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the signing key")
+	}
+
+	switch len(key) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(key), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(key), nil
+	default:
+		return nil, errors.Errorf("invalid signing key size %d", len(key))
+	}
+}
+
 type MessageExportSettings struct {
 	EnableExport            *bool   `access:"compliance_compliance_export"`
 	ExportFormat            *string `access:"compliance_compliance_export"`
@@ -3611,6 +3772,7 @@ type MessageExportSettings struct {
 
 	// formatter-specific settings - these are only expected to be non-nil if ExportFormat is set to the associated format
 	GlobalRelaySettings *GlobalRelayMessageExportSettings `access:"compliance_compliance_export"`
+	JsonlSettings       *JsonlMessageExportSettings       `access:"compliance_compliance_export"`
 }
 
 func (s *MessageExportSettings) SetDefaults() {
@@ -3650,6 +3812,11 @@ func (s *MessageExportSettings) SetDefaults() {
 		s.GlobalRelaySettings = &GlobalRelayMessageExportSettings{}
 	}
 	s.GlobalRelaySettings.SetDefaults()
+
+	if s.JsonlSettings == nil {
+		s.JsonlSettings = &JsonlMessageExportSettings{}
+	}
+	s.JsonlSettings.SetDefaults()
 }
 
 type DisplaySettings struct {
@@ -3884,6 +4051,7 @@ type Config struct {
 	AnalyticsSettings           AnalyticsSettings
 	ElasticsearchSettings       ElasticsearchSettings
 	BleveSettings               BleveSettings
+	SemanticSearchSettings      SemanticSearchSettings
 	DataRetentionSettings       DataRetentionSettings
 	MessageExportSettings       MessageExportSettings
 	JobSettings                 JobSettings
@@ -3996,6 +4164,7 @@ func (o *Config) SetDefaults() {
 	o.LocalizationSettings.SetDefaults()
 	o.ElasticsearchSettings.SetDefaults()
 	o.BleveSettings.SetDefaults()
+	o.SemanticSearchSettings.SetDefaults()
 	o.NativeAppSettings.SetDefaults()
 	o.DataRetentionSettings.SetDefaults()
 	o.RateLimitSettings.SetDefaults()
@@ -4029,6 +4198,11 @@ func (o *Config) IsValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_email_batching.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	// The semantic search index is local to each server
+	if *o.ClusterSettings.Enable && *o.SemanticSearchSettings.EnableIndexing {
+		return NewAppError("Config.IsValid", "model.config.is_valid.cluster_semantic_search.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if appErr := o.MetricsSettings.isValid(); appErr != nil {
 		return appErr
 	}
@@ -4089,6 +4263,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.SemanticSearchSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if appErr := o.DataRetentionSettings.isValid(); appErr != nil {
 		return appErr
 	}
@@ -4668,6 +4846,46 @@ func (bs *BleveSettings) isValid() *AppError {
 	return nil
 }
 
+func (s *SemanticSearchSettings) isValid() *AppError {
+	if !*s.EnableIndexing {
+		if *s.EnableSearching {
+			return NewAppError("Config.IsValid", "model.config.is_valid.semantic_search.enable_searching.app_error", nil, "", http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	if *s.IndexDir == "" {
+		return NewAppError("Config.IsValid", "model.config.is_valid.semantic_search.index_dir.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *s.EmbeddingProvider {
+	case SemanticSearchEmbeddingProviderStub:
+	case SemanticSearchEmbeddingProviderOpenAI:
+		if !IsValidHTTPURL(*s.EmbeddingAPIURL) {
+			return NewAppError("Config.IsValid", "model.config.is_valid.semantic_search.api_url.app_error", nil, "", http.StatusBadRequest)
+		}
+		if *s.EmbeddingModel == "" {
+			return NewAppError("Config.IsValid", "model.config.is_valid.semantic_search.model.app_error", nil, "", http.StatusBadRequest)
+		}
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.semantic_search.provider.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.EmbeddingDimensions <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.semantic_search.dimensions.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.RequestTimeoutSeconds <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.semantic_search.request_timeout.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.MinimumSimilarity < 0 || *s.MinimumSimilarity > 100 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.semantic_search.minimum_similarity.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
 func (s *DataRetentionSettings) isValid() *AppError {
 	if s.MessageRetentionDays == nil || *s.MessageRetentionDays < 0 {
 		return NewAppError("Config.IsValid", "model.config.is_valid.data_retention.message_retention_days_too_low.app_error", nil, "", http.StatusBadRequest)
@@ -4731,7 +4949,7 @@ func (s *MessageExportSettings) isValid() *AppError {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.daily_runtime.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 		} else if s.BatchSize == nil || *s.BatchSize < 0 {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.batch_size.app_error", nil, "", http.StatusBadRequest)
-		} else if s.ExportFormat == nil || (*s.ExportFormat != ComplianceExportTypeActiance && *s.ExportFormat != ComplianceExportTypeGlobalrelay && *s.ExportFormat != ComplianceExportTypeCsv && *s.ExportFormat != ComplianceExportTypeGlobalrelayZip) {
+		} else if s.ExportFormat == nil || (*s.ExportFormat != ComplianceExportTypeActiance && *s.ExportFormat != ComplianceExportTypeGlobalrelay && *s.ExportFormat != ComplianceExportTypeCsv && *s.ExportFormat != ComplianceExportTypeGlobalrelayZip && *s.ExportFormat != ComplianceExportTypeEml && *s.ExportFormat != ComplianceExportTypeMbox && *s.ExportFormat != ComplianceExportTypeJsonl) {
 			return NewAppError("Config.IsValid", "model.config.is_valid.message_export.export_type.app_error", nil, "", http.StatusBadRequest)
 		}
 
@@ -4752,6 +4970,14 @@ func (s *MessageExportSettings) isValid() *AppError {
 				return NewAppError("Config.IsValid", "model.config.is_valid.message_export.global_relay.smtp_password.app_error", nil, "", http.StatusBadRequest)
 			}
 		}
+
+		if *s.ExportFormat == ComplianceExportTypeJsonl {
+			if s.JsonlSettings == nil {
+				return NewAppError("Config.IsValid", "model.config.is_valid.message_export.jsonl.config_missing.app_error", nil, "", http.StatusBadRequest)
+			} else if _, err := s.JsonlSettings.PrivateKey(); err != nil {
+				return NewAppError("Config.IsValid", "model.config.is_valid.message_export.jsonl.signing_key.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+			}
+		}
 	}
 	return nil
 }
@@ -4904,6 +5130,16 @@ func (o *Config) Sanitize(pluginManifests []*Manifest, opts *SanitizeOptions) {
 		*o.MessageExportSettings.GlobalRelaySettings.SMTPPassword = FakeSetting
 	}
 
+	if o.SemanticSearchSettings.EmbeddingAPIKey != nil && *o.SemanticSearchSettings.EmbeddingAPIKey != "" {
+		*o.SemanticSearchSettings.EmbeddingAPIKey = FakeSetting
+	}
+
+	if o.MessageExportSettings.JsonlSettings != nil &&
+		o.MessageExportSettings.JsonlSettings.SigningKey != nil &&
+		*o.MessageExportSettings.JsonlSettings.SigningKey != "" {
+		*o.MessageExportSettings.JsonlSettings.SigningKey = FakeSetting
+	}
+
 	if o.ServiceSettings.SplitKey != nil {
 		*o.ServiceSettings.SplitKey = FakeSetting
 	}
@@ -4,6 +4,8 @@
 package model
 
 import (
+	"crypto/ed25519"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -108,6 +110,51 @@ func TestConfigIsValid(t *testing.T) {
 			require.Nil(t, c.IsValid())
 		})
 	})
+
+	t.Run("semantic search with clustering", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.SemanticSearchSettings.EnableIndexing = NewPointer(true)
+		c.SemanticSearchSettings.IndexDir = NewPointer("semantic")
+		c.SemanticSearchSettings.EmbeddingProvider = NewPointer(SemanticSearchEmbeddingProviderStub)
+		require.Nil(t, c.IsValid())
+
+		c.ClusterSettings.Enable = NewPointer(true)
+		appErr := c.IsValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.cluster_semantic_search.app_error", appErr.Id)
+	})
+
+	t.Run("cache sizes", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.CacheSettings.CacheSizes = map[string]int{"Session": 0}
+		appErr := c.IsValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.cache_size.app_error", appErr.Id)
+
+		c.CacheSettings.CacheSizes = map[string]int{"Session": 1000}
+		require.Nil(t, c.IsValid())
+	})
+
+	t.Run("hybrid cache", func(t *testing.T) {
+		c := Config{}
+		c.SetDefaults()
+		c.CacheSettings.CacheType = NewPointer(CacheTypeHybrid)
+		appErr := c.IsValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.empty_redis_address.app_error", appErr.Id)
+
+		c.CacheSettings.RedisAddress = NewPointer("localhost:6379")
+		c.CacheSettings.RedisDB = NewPointer(0)
+		c.CacheSettings.HybridInvalidation = NewPointer("gossip")
+		appErr = c.IsValid()
+		require.NotNil(t, appErr)
+		require.Equal(t, "model.config.is_valid.hybrid_invalidation.app_error", appErr.Id)
+
+		c.CacheSettings.HybridInvalidation = NewPointer(HybridInvalidationCluster)
+		require.Nil(t, c.IsValid())
+	})
 }
 
 func TestConfigEmptySiteName(t *testing.T) {
@@ -635,6 +682,59 @@ func TestMessageExportSettingsIsValidActiance(t *testing.T) {
 	require.Nil(t, mes.isValid())
 }
 
+func TestMessageExportSettingsIsValidEml(t *testing.T) {
+	for _, format := range []string{ComplianceExportTypeEml, ComplianceExportTypeMbox} {
+		mes := &MessageExportSettings{
+			EnableExport:        NewPointer(true),
+			ExportFormat:        NewPointer(format),
+			ExportFromTimestamp: NewPointer(int64(0)),
+			DailyRunTime:        NewPointer("15:04"),
+			BatchSize:           NewPointer(100),
+		}
+
+		// should pass because no delivery settings are needed
+		require.Nil(t, mes.isValid(), format)
+	}
+}
+
+func TestMessageExportSettingsIsValidJsonl(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	privateKey := ed25519.NewKeyFromSeed(seed)
+
+	for name, tc := range map[string]struct {
+		settings *JsonlMessageExportSettings
+		valid    bool
+	}{
+		"missing settings": {nil, false},
+		"missing key":      {&JsonlMessageExportSettings{SigningKey: NewPointer("")}, false},
+		"not base64":       {&JsonlMessageExportSettings{SigningKey: NewPointer("not a key!")}, false},
+		"wrong size":       {&JsonlMessageExportSettings{SigningKey: NewPointer(base64.StdEncoding.EncodeToString([]byte("short")))}, false},
+		"seed":             {&JsonlMessageExportSettings{SigningKey: NewPointer(base64.StdEncoding.EncodeToString(seed))}, true},
+		"private key":      {&JsonlMessageExportSettings{SigningKey: NewPointer(base64.StdEncoding.EncodeToString(privateKey))}, true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mes := &MessageExportSettings{
+				EnableExport:        NewPointer(true),
+				ExportFormat:        NewPointer(ComplianceExportTypeJsonl),
+				ExportFromTimestamp: NewPointer(int64(0)),
+				DailyRunTime:        NewPointer("15:04"),
+				BatchSize:           NewPointer(100),
+				JsonlSettings:       tc.settings,
+			}
+
+			if !tc.valid {
+				require.NotNil(t, mes.isValid())
+				return
+			}
+			require.Nil(t, mes.isValid())
+
+			key, err := tc.settings.PrivateKey()
+			require.NoError(t, err)
+			require.Equal(t, privateKey, key)
+		})
+	}
+}
+
 func TestMessageExportSettingsIsValidGlobalRelaySettingsMissing(t *testing.T) {
 	mes := &MessageExportSettings{
 		EnableExport:        NewPointer(true),
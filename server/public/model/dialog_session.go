@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DialogSessionExpiry is how long an interactive dialog can be submitted after it's opened.
+const DialogSessionExpiry = time.Hour
+
+// DialogSession is the server-side state of an open interactive dialog: the integration it's
+// submitted to, its pages, and the submissions of the pages already submitted. It's encrypted
+// and authenticated with the post action cookie secret, and travels with the dialog so that
+// any server of the cluster can trust it when the dialog is submitted.
+type DialogSession struct {
+	UserId     string         `json:"user_id"`
+	URL        string         `json:"url"`
+	Dialog     Dialog         `json:"dialog"`
+	Page       int            `json:"page"`
+	Submission map[string]any `json:"submission,omitempty"`
+	ExpireAt   int64          `json:"expire_at"`
+}
+
+func NewDialogSession(userID, url string, dialog Dialog) *DialogSession {
+	return &DialogSession{
+		UserId:   userID,
+		URL:      url,
+		Dialog:   dialog,
+		ExpireAt: GetMillis() + DialogSessionExpiry.Milliseconds(),
+	}
+}
+
+func (s *DialogSession) IsExpired() bool {
+	return GetMillis() > s.ExpireAt
+}
+
+// IsLastPage returns whether the current page is the last one, or the only one of a dialog
+// without pages.
+func (s *DialogSession) IsLastPage() bool {
+	return s.Page >= len(s.Dialog.Pages)-1
+}
+
+// PageElements returns the elements of the current page.
+func (s *DialogSession) PageElements() []DialogElement {
+	if s.Page < len(s.Dialog.Pages) {
+		return s.Dialog.Pages[s.Page].Elements
+	}
+	return s.Dialog.Elements
+}
+
+// PageDialog returns the dialog shown to the user for the current page, without the other pages.
+func (s *DialogSession) PageDialog() Dialog {
+	dialog := s.Dialog
+	dialog.Pages = nil
+
+	if s.Page < len(s.Dialog.Pages) {
+		page := s.Dialog.Pages[s.Page]
+		dialog.Elements = page.Elements
+		if page.Title != "" {
+			dialog.Title = page.Title
+		}
+		if page.IntroductionText != "" {
+			dialog.IntroductionText = page.IntroductionText
+		}
+		if page.SubmitLabel != "" {
+			dialog.SubmitLabel = page.SubmitLabel
+		}
+	}
+
+	return dialog
+}
+
+func EncryptDialogSession(session *DialogSession, secret []byte) (string, error) {
+	// Unlike post action cookies, a session is never sent in the clear
+	if len(secret) == 0 {
+		return "", errors.New("missing secret")
+	}
+
+	b, err := json.Marshal(session)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode the dialog session")
+	}
+
+	return encryptPostActionCookie(string(b), secret)
+}
+
+func DecryptDialogSession(encoded string, secret []byte) (*DialogSession, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("missing secret")
+	}
+
+	plain, err := DecryptPostActionCookie(encoded, secret)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt the dialog session")
+	}
+
+	var session DialogSession
+	if err := json.Unmarshal([]byte(plain), &session); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the dialog session")
+	}
+
+	return &session, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDialogSessionPages(t *testing.T) {
+	dialog := Dialog{
+		Title:       "Dialog",
+		SubmitLabel: "Submit",
+		Pages: []DialogPage{
+			{Elements: []DialogElement{{Name: "first", Type: "text"}}, SubmitLabel: "Next"},
+			{Title: "Second", Elements: []DialogElement{{Name: "second", Type: "bool"}}},
+		},
+	}
+	session := NewDialogSession(NewId(), "http://localhost", dialog)
+	assert.False(t, session.IsExpired())
+
+	assert.False(t, session.IsLastPage())
+	page := session.PageDialog()
+	assert.Equal(t, "Dialog", page.Title)
+	assert.Equal(t, "Next", page.SubmitLabel)
+	assert.Equal(t, "first", page.Elements[0].Name)
+	assert.Nil(t, page.Pages)
+	assert.Equal(t, page.Elements, session.PageElements())
+
+	session.Page++
+	assert.True(t, session.IsLastPage())
+	page = session.PageDialog()
+	assert.Equal(t, "Second", page.Title)
+	assert.Equal(t, "Submit", page.SubmitLabel)
+	assert.Equal(t, "second", page.Elements[0].Name)
+
+	t.Run("without pages", func(t *testing.T) {
+		session := NewDialogSession(NewId(), "http://localhost", Dialog{Title: "Dialog", Elements: []DialogElement{{Name: "only", Type: "text"}}})
+		assert.True(t, session.IsLastPage())
+		assert.Equal(t, session.Dialog, session.PageDialog())
+		assert.Equal(t, "only", session.PageElements()[0].Name)
+	})
+}
+
+func TestDialogSessionEncryption(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	session := NewDialogSession(NewId(), "http://localhost", Dialog{Title: "Dialog", State: "state"})
+	session.Submission = map[string]any{"name": "value"}
+
+	encoded, err := EncryptDialogSession(session, secret)
+	require.NoError(t, err)
+	assert.NotContains(t, encoded, "localhost")
+
+	decoded, err := DecryptDialogSession(encoded, secret)
+	require.NoError(t, err)
+	assert.Equal(t, session, decoded)
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := []byte(encoded)
+		tampered[len(tampered)/2] ^= 1
+		_, err := DecryptDialogSession(string(tampered), secret)
+		assert.Error(t, err)
+	})
+
+	t.Run("other secret", func(t *testing.T) {
+		_, err := DecryptDialogSession(encoded, []byte("abcdef0123456789abcdef0123456789"))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing secret", func(t *testing.T) {
+		_, err := EncryptDialogSession(session, nil)
+		assert.Error(t, err)
+		_, err = DecryptDialogSession(encoded, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// EmailNotification is the email sent to a user to notify them of a post. It
+// is handed to plugins before it is sent so that it can be modified or
+// cancelled.
+type EmailNotification struct {
+	// To is the address the email is sent to. It defaults to the email of the
+	// recipient.
+	To string `json:"to"`
+	// Subject is the rendered, plain text subject of the email.
+	Subject string `json:"subject"`
+	// Body is the rendered HTML body of the email.
+	Body string `json:"body"`
+
+	PostId      string      `json:"post_id"`
+	ChannelId   string      `json:"channel_id"`
+	ChannelName string      `json:"channel_name"`
+	ChannelType ChannelType `json:"channel_type"`
+	TeamName    string      `json:"team_name"`
+	SenderId    string      `json:"sender_id"`
+	SenderName  string      `json:"sender_name"`
+	// Batched is true if the post is sent as part of an email batch. The
+	// Body then holds the rendered message of the post alone.
+	Batched bool `json:"batched"`
+}
+
+func (en *EmailNotification) DeepCopy() *EmailNotification {
+	enCopy := *en
+	return &enCopy
+}
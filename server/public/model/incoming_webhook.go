@@ -28,22 +28,26 @@ type IncomingWebhook struct {
 	Username      string `json:"username"`
 	IconURL       string `json:"icon_url"`
 	ChannelLocked bool   `json:"channel_locked"`
+	// TransformTemplate is a text/template rendering the JSON payloads posted to the webhook,
+	// see TransformIncomingWebhookPayload.
+	TransformTemplate string `json:"transform_template"`
 }
 
 func (o *IncomingWebhook) Auditable() map[string]any {
 	return map[string]any{
-		"id":             o.Id,
-		"create_at":      o.CreateAt,
-		"update_at":      o.UpdateAt,
-		"delete_at":      o.DeleteAt,
-		"user_id":        o.UserId,
-		"channel_id":     o.ChannelId,
-		"team_id":        o.TeamId,
-		"display_name":   o.DisplayName,
-		"description":    o.Description,
-		"username":       o.Username,
-		"icon_url:":      o.IconURL,
-		"channel_locked": o.ChannelLocked,
+		"id":                 o.Id,
+		"create_at":          o.CreateAt,
+		"update_at":          o.UpdateAt,
+		"delete_at":          o.DeleteAt,
+		"user_id":            o.UserId,
+		"channel_id":         o.ChannelId,
+		"team_id":            o.TeamId,
+		"display_name":       o.DisplayName,
+		"description":        o.Description,
+		"username":           o.Username,
+		"icon_url:":          o.IconURL,
+		"channel_locked":     o.ChannelLocked,
+		"transform_template": o.TransformTemplate,
 	}
 }
 
@@ -105,6 +109,16 @@ func (o *IncomingWebhook) IsValid() *AppError {
 		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if len(o.TransformTemplate) > IncomingWebhookTransformMaxSize {
+		return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.transform_template_size.app_error", map[string]any{"Max": IncomingWebhookTransformMaxSize}, "", http.StatusBadRequest)
+	}
+
+	if o.TransformTemplate != "" {
+		if _, err := parseIncomingWebhookTransform(o.TransformTemplate, &transformBudget{}); err != nil {
+			return NewAppError("IncomingWebhook.IsValid", "model.incoming_hook.transform_template.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
 	return nil
 }
 
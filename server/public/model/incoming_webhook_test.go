@@ -65,6 +65,15 @@ func TestIncomingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	require.Nil(t, o.IsValid())
+
+	o.TransformTemplate = "{{.title"
+	require.NotNil(t, o.IsValid())
+
+	o.TransformTemplate = strings.Repeat("1", IncomingWebhookTransformMaxSize+1)
+	require.NotNil(t, o.IsValid())
+
+	o.TransformTemplate = "{{.title}}"
+	require.Nil(t, o.IsValid())
 }
 
 func TestIncomingWebhookPreSave(t *testing.T) {
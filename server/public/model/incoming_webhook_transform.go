@@ -0,0 +1,394 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// IncomingWebhookTransformMaxSize is the maximum size of the transform template of an
+	// incoming webhook.
+	IncomingWebhookTransformMaxSize = 16 * 1024
+	// IncomingWebhookTransformMaxOutputSize is the maximum size of the output of a transform
+	// template.
+	IncomingWebhookTransformMaxOutputSize = 64 * 1024
+	// IncomingWebhookTransformMaxWork is the maximum size of the values produced by the
+	// functions called by a transform template, over the whole rendering.
+	IncomingWebhookTransformMaxWork = 4 * 1024 * 1024
+	// IncomingWebhookTransformTimeout is the maximum duration of the rendering of a transform
+	// template.
+	IncomingWebhookTransformTimeout = 2 * time.Second
+	// IncomingWebhookTransformMaxConcurrent is the maximum number of transform templates
+	// rendering at once on the server.
+	IncomingWebhookTransformMaxConcurrent = 32
+	// IncomingWebhookTransformMaxConcurrentPerHook is the maximum number of transform templates
+	// rendering at once for an incoming webhook.
+	IncomingWebhookTransformMaxConcurrentPerHook = 2
+)
+
+// IncomingWebhookTransformTest is the request to render a transform template against a
+// sample payload, before saving it to an incoming webhook.
+type IncomingWebhookTransformTest struct {
+	TeamId            string          `json:"team_id"`
+	TransformTemplate string          `json:"transform_template"`
+	Payload           json.RawMessage `json:"payload"`
+}
+
+var (
+	errIncomingWebhookTransformOutputTooLarge = errors.New("the output is too large")
+	errIncomingWebhookTransformTooMuchWork    = errors.New("the template functions produced too much data")
+)
+
+// printfWidthRegexp matches the widths and precisions which are given as arguments or are
+// larger than 3 digits, which could allocate huge strings.
+var printfWidthRegexp = regexp.MustCompile(`%[-+# 0]*(\[\d+\])?(\*|\d{4,}|\d*\.(\[\d+\])?(\*|\d{4,}))`)
+
+// transformBudget is the work left to a rendering of a transform template.
+type transformBudget struct {
+	left int
+}
+
+// spend charges the size of a value produced by a template function to the budget.
+func (b *transformBudget) spend(s string) (string, error) {
+	b.left -= len(s) + 1
+	if b.left < 0 {
+		return "", errIncomingWebhookTransformTooMuchWork
+	}
+	return s, nil
+}
+
+// newIncomingWebhookTransformFuncs returns the functions available to the transform templates,
+// charging the data they produce to the given budget. They replace the builtin functions
+// producing strings.
+func newIncomingWebhookTransformFuncs(budget *transformBudget) template.FuncMap {
+	return template.FuncMap{
+		// json encodes a value, to be embedded in a JSON payload.
+		"json": func(v any) (string, error) {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return budget.spend(string(b))
+		},
+		// default returns the given default value if the value is missing or empty.
+		"default": func(def, v any) any {
+			if v == nil || v == "" {
+				return def
+			}
+			return v
+		},
+		"join": func(sep string, v any) (string, error) {
+			items, ok := v.([]any)
+			if !ok {
+				return budget.spend(fmt.Sprint(v))
+			}
+			strs := make([]string, len(items))
+			for i, item := range items {
+				strs[i] = fmt.Sprint(item)
+			}
+			return budget.spend(strings.Join(strs, sep))
+		},
+		"lower": func(s string) (string, error) { return budget.spend(strings.ToLower(s)) },
+		"upper": func(s string) (string, error) { return budget.spend(strings.ToUpper(s)) },
+		"trim":  func(s string) (string, error) { return budget.spend(strings.TrimSpace(s)) },
+		"truncate": func(length int, s string) (string, error) {
+			if length >= 0 && len([]rune(s)) > length {
+				s = string([]rune(s)[:length])
+			}
+			return budget.spend(s)
+		},
+		"print":   func(args ...any) (string, error) { return budget.spend(fmt.Sprint(args...)) },
+		"println": func(args ...any) (string, error) { return budget.spend(fmt.Sprintln(args...)) },
+		"printf": func(format string, args ...any) (string, error) {
+			if printfWidthRegexp.MatchString(format) {
+				return "", errors.New("printf widths and precisions must be at most 3 digits")
+			}
+			return budget.spend(fmt.Sprintf(format, args...))
+		},
+		"html":     func(args ...any) (string, error) { return budget.spend(template.HTMLEscaper(args...)) },
+		"js":       func(args ...any) (string, error) { return budget.spend(template.JSEscaper(args...)) },
+		"urlquery": func(args ...any) (string, error) { return budget.spend(template.URLQueryEscaper(args...)) },
+	}
+}
+
+func parseIncomingWebhookTransform(transformTemplate string, budget *transformBudget) (*template.Template, error) {
+	tmpl, err := template.New("transform").Funcs(newIncomingWebhookTransformFuncs(budget)).Option("missingkey=zero").Parse(transformTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := &transformChecker{}
+	if err := checker.checkList(tmpl.Root, &transformScope{dot: rootPayload}); err != nil {
+		return nil, err
+	}
+
+	return tmpl, nil
+}
+
+// The origins of the values of a transform template: notPayload for the values which aren't
+// part of the payload, such as literals or function results, rootPayload for the payload and its
+// fields, and n > 0 for the element of the nth nested range and its fields.
+const (
+	notPayload  = -1
+	rootPayload = 0
+)
+
+// transformScope holds the origins of the dot and of the variables in a block of a transform
+// template.
+type transformScope struct {
+	parent *transformScope
+	dot    int
+	vars   map[string]int
+}
+
+func (s *transformScope) child(dot int) *transformScope {
+	return &transformScope{parent: s, dot: dot}
+}
+
+func (s *transformScope) lookup(name string) (int, bool) {
+	if name == "$" {
+		return rootPayload, true
+	}
+	for scope := s; scope != nil; scope = scope.parent {
+		if origin, ok := scope.vars[name]; ok {
+			return origin, true
+		}
+	}
+	return notPayload, false
+}
+
+func (s *transformScope) declare(name string, origin int) {
+	if s.vars == nil {
+		s.vars = make(map[string]int)
+	}
+	s.vars[name] = origin
+}
+
+// transformChecker bounds the work of a transform template by only allowing ranges over the
+// payload, and, within a range, over the current element. Each range then iterates over a
+// distinct part of the payload, so the iterations are bounded by the size of the template times
+// the size of the payload.
+type transformChecker struct {
+	// depth is the number of ranges enclosing the checked node.
+	depth int
+}
+
+func (c *transformChecker) checkList(list *parse.ListNode, scope *transformScope) error {
+	if list == nil {
+		return nil
+	}
+	for _, node := range list.Nodes {
+		if err := c.checkNode(node, scope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *transformChecker) checkNode(node parse.Node, scope *transformScope) error {
+	switch n := node.(type) {
+	case *parse.ActionNode:
+		return c.declare(n.Pipe, scope, c.origin(n.Pipe, scope))
+	case *parse.IfNode:
+		body := scope.child(scope.dot)
+		if err := c.declare(n.Pipe, body, c.origin(n.Pipe, scope)); err != nil {
+			return err
+		}
+		if err := c.checkList(n.List, body); err != nil {
+			return err
+		}
+		return c.checkList(n.ElseList, scope.child(scope.dot))
+	case *parse.WithNode:
+		origin := c.origin(n.Pipe, scope)
+		body := scope.child(origin)
+		if err := c.declare(n.Pipe, body, origin); err != nil {
+			return err
+		}
+		if err := c.checkList(n.List, body); err != nil {
+			return err
+		}
+		return c.checkList(n.ElseList, scope.child(scope.dot))
+	case *parse.RangeNode:
+		origin := c.origin(n.Pipe, scope)
+		if origin == notPayload {
+			return errors.New("ranges must be over the payload")
+		}
+		if c.depth > 0 && origin != c.depth {
+			return errors.New("ranges within a range must be over the current element")
+		}
+
+		c.depth++
+		body := scope.child(c.depth)
+		// The key of the range is an index or a map key, and the value is the element.
+		for i, decl := range n.Pipe.Decl {
+			if i == 0 && len(n.Pipe.Decl) == 2 {
+				body.declare(decl.Ident[0], notPayload)
+			} else {
+				body.declare(decl.Ident[0], c.depth)
+			}
+		}
+		err := c.checkList(n.List, body)
+		c.depth--
+		if err != nil {
+			return err
+		}
+		return c.checkList(n.ElseList, scope.child(scope.dot))
+	case *parse.TemplateNode:
+		return errors.New("calling templates is not allowed")
+	}
+
+	return nil
+}
+
+// declare records the origin of the variables declared or assigned by a pipeline. Assigning a
+// variable a value of another origin is rejected, as ranges could then run over a value of
+// another origin on their next iteration.
+func (c *transformChecker) declare(pipe *parse.PipeNode, scope *transformScope, origin int) error {
+	if pipe == nil {
+		return nil
+	}
+	for _, decl := range pipe.Decl {
+		name := decl.Ident[0]
+		if !pipe.IsAssign {
+			scope.declare(name, origin)
+			continue
+		}
+		if current, _ := scope.lookup(name); current != origin {
+			return errors.Errorf("variable %s can't be assigned a value of another kind", name)
+		}
+	}
+	return nil
+}
+
+// origin returns the origin of the value of a pipeline, which is only part of the payload if
+// the pipeline is a field, a variable, or a chain of fields of the payload.
+func (c *transformChecker) origin(pipe *parse.PipeNode, scope *transformScope) int {
+	if pipe == nil || len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return notPayload
+	}
+	return c.argOrigin(pipe.Cmds[0].Args[0], scope)
+}
+
+func (c *transformChecker) argOrigin(arg parse.Node, scope *transformScope) int {
+	switch a := arg.(type) {
+	case *parse.DotNode, *parse.FieldNode:
+		return scope.dot
+	case *parse.VariableNode:
+		origin, _ := scope.lookup(a.Ident[0])
+		return origin
+	case *parse.ChainNode:
+		return c.argOrigin(a.Node, scope)
+	case *parse.PipeNode:
+		return c.origin(a, scope)
+	}
+	return notPayload
+}
+
+// limitedBuffer fails the writes past its limit, aborting the execution of the template.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, errIncomingWebhookTransformOutputTooLarge
+	}
+	return b.Buffer.Write(p)
+}
+
+// IncomingWebhookTransformLimiter caps the number of transform templates rendering at once, in
+// total and per incoming webhook. Its zero value is ready to use.
+type IncomingWebhookTransformLimiter struct {
+	mut     sync.Mutex
+	total   int
+	perHook map[string]int
+}
+
+func (l *IncomingWebhookTransformLimiter) acquire(hookID string) bool {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	if l.total >= IncomingWebhookTransformMaxConcurrent || l.perHook[hookID] >= IncomingWebhookTransformMaxConcurrentPerHook {
+		return false
+	}
+	if l.perHook == nil {
+		l.perHook = make(map[string]int)
+	}
+	l.total++
+	l.perHook[hookID]++
+	return true
+}
+
+func (l *IncomingWebhookTransformLimiter) release(hookID string) {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+
+	l.total--
+	if l.perHook[hookID]--; l.perHook[hookID] <= 0 {
+		delete(l.perHook, hookID)
+	}
+}
+
+// TransformIncomingWebhookPayload renders the given transform template with the decoded JSON
+// payload posted to an incoming webhook. The output is used as the incoming webhook request if
+// it's a JSON object, and as the text of the post otherwise.
+//
+// The template runs with a limited output size, work and duration. Templates can't be
+// interrupted, so a template exceeding the duration is abandoned to finish in the background,
+// holding on to its slot of the limiter until then. The hookID identifies the incoming webhook
+// in the limiter.
+func TransformIncomingWebhookPayload(limiter *IncomingWebhookTransformLimiter, hookID, transformTemplate string, payload []byte) (*IncomingWebhookRequest, *AppError) {
+	budget := &transformBudget{left: IncomingWebhookTransformMaxWork}
+	tmpl, err := parseIncomingWebhookTransform(transformTemplate, budget)
+	if err != nil {
+		return nil, NewAppError("TransformIncomingWebhookPayload", "model.incoming_hook.transform_template.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	var data any
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err = decoder.Decode(&data); err != nil {
+		return nil, NewAppError("TransformIncomingWebhookPayload", "model.incoming_hook.parse_data.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if !limiter.acquire(hookID) {
+		return nil, NewAppError("TransformIncomingWebhookPayload", "model.incoming_hook.transform_busy.app_error", nil, "", http.StatusTooManyRequests)
+	}
+
+	done := make(chan error, 1)
+	out := &limitedBuffer{limit: IncomingWebhookTransformMaxOutputSize}
+	go func() {
+		defer limiter.release(hookID)
+		done <- tmpl.Execute(out, data)
+	}()
+
+	select {
+	case err = <-done:
+	case <-time.After(IncomingWebhookTransformTimeout):
+		err = errors.New("the rendering timed out")
+	}
+	if err != nil {
+		return nil, NewAppError("TransformIncomingWebhookPayload", "model.incoming_hook.transform.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	output := bytes.TrimSpace(out.Bytes())
+	if bytes.HasPrefix(output, []byte("{")) {
+		return IncomingWebhookRequestFromJSON(bytes.NewReader(output))
+	}
+
+	return &IncomingWebhookRequest{Text: string(output)}, nil
+}
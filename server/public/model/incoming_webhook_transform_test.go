@@ -0,0 +1,140 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformIncomingWebhookPayload(t *testing.T) {
+	payload := []byte(`{
+		"status": "firing",
+		"alerts": [{"labels": {"alertname": "HighLatency", "severity": "critical"}}, {"labels": {"alertname": "DiskFull"}}],
+		"count": 2,
+		"message": "line one\nline two"
+	}`)
+	limiter := &IncomingWebhookTransformLimiter{}
+	hookID := NewId()
+
+	t.Run("text output", func(t *testing.T) {
+		req, appErr := TransformIncomingWebhookPayload(limiter, hookID, `[{{upper .status}}] {{.count}} alerts{{range .alerts}} {{.labels.alertname}}{{end}}`, payload)
+		require.Nil(t, appErr)
+		assert.Equal(t, "[FIRING] 2 alerts HighLatency DiskFull", req.Text)
+	})
+
+	t.Run("JSON output", func(t *testing.T) {
+		transform := `{
+			"text": {{json .message}},
+			"channel": "#{{default "alerts" .channel}}",
+			"props": {"severity": {{json (index .alerts 0).labels.severity}}},
+			"attachments": [{{range $i, $alert := .alerts}}{{if $i}},{{end}}{"title": {{json $alert.labels.alertname}}}{{end}}]
+		}`
+		req, appErr := TransformIncomingWebhookPayload(limiter, hookID, transform, payload)
+		require.Nil(t, appErr)
+		assert.Equal(t, "line one\nline two", req.Text)
+		assert.Equal(t, "#alerts", req.ChannelName)
+		assert.Equal(t, "critical", req.Props["severity"])
+		require.Len(t, req.Attachments, 2)
+		assert.Equal(t, "HighLatency", req.Attachments[0].Title)
+		assert.Equal(t, "DiskFull", req.Attachments[1].Title)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{.status`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.incoming_hook.transform_template.app_error", appErr.Id)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		_, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{.status}}`, []byte("not json"))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.incoming_hook.parse_data.app_error", appErr.Id)
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		_, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{index .alerts 5}}`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.incoming_hook.transform.app_error", appErr.Id)
+	})
+
+	for name, transform := range map[string]string{
+		"range over a number":            `{{if .status}}{{range 100000000000}}{{end}}{{end}}`,
+		"range over a variable":          `{{$n := 1000000000}}{{range $n}}{{end}}`,
+		"range over a function result":   `{{range (len .alerts)}}{{end}}`,
+		"range over an index":            `{{range $i, $alert := .alerts}}{{range $i}}{{end}}{{end}}`,
+		"range within a range over root": `{{range .alerts}}{{range $.alerts}}{{end}}{{end}}`,
+		"range within a range over with": `{{range .alerts}}{{with $}}{{range .alerts}}{{end}}{{end}}{{end}}`,
+		"variable reassigned a number":   `{{$a := .alerts}}{{range .alerts}}{{$a = 5}}{{end}}`,
+		"template call":                  `{{define "t"}}{{range .}}{{end}}{{end}}{{template "t" .alerts}}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, appErr := TransformIncomingWebhookPayload(limiter, hookID, transform, payload)
+			require.NotNil(t, appErr)
+			assert.Equal(t, "model.incoming_hook.transform_template.app_error", appErr.Id)
+		})
+	}
+
+	t.Run("range within a range over the element", func(t *testing.T) {
+		req, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{range $alert := .alerts}}{{range $alert.labels}}{{.}} {{end}}{{with .labels}}{{range .}}{{.}} {{end}}{{end}}{{end}}`, payload)
+		require.Nil(t, appErr)
+		assert.Equal(t, "HighLatency critical HighLatency critical DiskFull DiskFull", req.Text)
+	})
+
+	t.Run("printf width", func(t *testing.T) {
+		_, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{printf "%*d" 1000000000 1}}`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.incoming_hook.transform.app_error", appErr.Id)
+
+		req, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{printf "%-5s|%.2f" .status 1.5}}`, payload)
+		require.Nil(t, appErr)
+		assert.Equal(t, "firing|1.50", req.Text)
+	})
+
+	largePayload := []byte(`{"items": [` + strings.Repeat(`"0123456789",`, 2000) + `""]}`)
+
+	t.Run("output too large", func(t *testing.T) {
+		_, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{range .items}}{{$.items}}{{end}}`, largePayload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.incoming_hook.transform.app_error", appErr.Id)
+	})
+
+	t.Run("too much work", func(t *testing.T) {
+		_, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{range .items}}{{$_ := json $}}{{end}}`, largePayload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.incoming_hook.transform.app_error", appErr.Id)
+		assert.ErrorIs(t, appErr, errIncomingWebhookTransformTooMuchWork)
+	})
+
+	t.Run("concurrent renders", func(t *testing.T) {
+		limiter := &IncomingWebhookTransformLimiter{}
+		for range IncomingWebhookTransformMaxConcurrentPerHook {
+			require.True(t, limiter.acquire(hookID))
+		}
+
+		_, appErr := TransformIncomingWebhookPayload(limiter, hookID, `{{.status}}`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.incoming_hook.transform_busy.app_error", appErr.Id)
+		assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+
+		req, appErr := TransformIncomingWebhookPayload(limiter, NewId(), `{{.status}}`, payload)
+		require.Nil(t, appErr)
+		assert.Equal(t, "firing", req.Text)
+
+		limiter.release(hookID)
+		_, appErr = TransformIncomingWebhookPayload(limiter, hookID, `{{.status}}`, payload)
+		require.Nil(t, appErr)
+
+		for limiter.total < IncomingWebhookTransformMaxConcurrent {
+			require.True(t, limiter.acquire(NewId()))
+		}
+		_, appErr = TransformIncomingWebhookPayload(limiter, NewId(), `{{.status}}`, payload)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.incoming_hook.transform_busy.app_error", appErr.Id)
+	})
+}
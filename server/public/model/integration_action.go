@@ -37,6 +37,7 @@ const (
 	DialogElementTextareaMaxLength    = 3000
 	DialogElementSelectMaxLength      = 3000
 	DialogElementBoolMaxLength        = 150
+	DialogMaxPages                    = 10
 )
 
 var PostActionRetainPropKeys = []string{PostPropsFromWebhook, PostPropsOverrideUsername, PostPropsOverrideIconURL}
@@ -316,6 +317,19 @@ type Dialog struct {
 	SubmitLabel      string          `json:"submit_label"`
 	NotifyOnCancel   bool            `json:"notify_on_cancel"`
 	State            string          `json:"state"`
+	// Pages make a multi-page dialog, in place of Elements. Each page is validated by the server
+	// when it's submitted, and the integration receives the submissions of all the pages at once
+	// when the last one is submitted.
+	Pages []DialogPage `json:"pages,omitempty"`
+}
+
+// DialogPage is a page of a multi-page dialog. The title, introduction text and submit label
+// default to the ones of the dialog.
+type DialogPage struct {
+	Title            string          `json:"title,omitempty"`
+	IntroductionText string          `json:"introduction_text,omitempty"`
+	Elements         []DialogElement `json:"elements"`
+	SubmitLabel      string          `json:"submit_label,omitempty"`
 }
 
 type DialogElement struct {
@@ -337,6 +351,8 @@ type OpenDialogRequest struct {
 	TriggerId string `json:"trigger_id"`
 	URL       string `json:"url"`
 	Dialog    Dialog `json:"dialog"`
+	// Session is the encrypted DialogSession set by the server when the dialog is opened.
+	Session string `json:"session,omitempty"`
 }
 
 type SubmitDialogRequest struct {
@@ -349,11 +365,20 @@ type SubmitDialogRequest struct {
 	TeamId     string         `json:"team_id"`
 	Submission map[string]any `json:"submission"`
 	Cancelled  bool           `json:"cancelled"`
+	// Validated is set by the server when it checked the submission against the elements of the
+	// dialog. Older servers forwarded submissions unchecked.
+	Validated bool `json:"validated"`
+	// Session is the session of the dialog received when it was opened, or with the last page.
+	// It's never sent to the integration.
+	Session string `json:"session,omitempty"`
 }
 
 type SubmitDialogResponse struct {
 	Error  string            `json:"error,omitempty"`
 	Errors map[string]string `json:"errors,omitempty"`
+	// Dialog and Session are the next page of a multi-page dialog, set by the server.
+	Dialog  *Dialog `json:"dialog,omitempty"`
+	Session string  `json:"session,omitempty"`
 }
 
 func GenerateTriggerId(userId string, s crypto.Signer) (string, string, *AppError) {
@@ -463,10 +488,27 @@ func (d *Dialog) IsValid() error {
 		multiErr = multierror.Append(multiErr, errors.New("invalid icon url"))
 	}
 
-	if len(d.Elements) != 0 {
+	if len(d.Pages) != 0 && len(d.Elements) != 0 {
+		multiErr = multierror.Append(multiErr, errors.New("a dialog can't have both elements and pages"))
+	}
+
+	if len(d.Pages) > DialogMaxPages {
+		multiErr = multierror.Append(multiErr, errors.Errorf("a dialog can't have more than %d pages, got %d", DialogMaxPages, len(d.Pages)))
+	}
+
+	// The element names are unique across the pages, as the submissions of the pages are merged
+	elements := slices.Clone(d.Elements)
+	for i, page := range d.Pages {
+		if len(page.Title) > DialogTitleMaxLength {
+			multiErr = multierror.Append(multiErr, errors.Errorf("invalid title %q of page %d", page.Title, i))
+		}
+		elements = append(elements, page.Elements...)
+	}
+
+	if len(elements) != 0 {
 		elementMap := make(map[string]bool)
 
-		for _, element := range d.Elements {
+		for _, element := range elements {
 			if elementMap[element.Name] {
 				multiErr = multierror.Append(multiErr, errors.Errorf("duplicate dialog element %q", element.Name))
 			}
@@ -548,6 +590,13 @@ func (e *DialogElement) IsValid() error {
 	return multiErr.ErrorOrNil()
 }
 
+// HasOption returns whether the value is one of the options of the element.
+func (e *DialogElement) HasOption(value string) bool {
+	return slices.ContainsFunc(e.Options, func(option *PostActionOptions) bool {
+		return option != nil && option.Value == value
+	})
+}
+
 func isDefaultInOptions(defaultValue string, options []*PostActionOptions) bool {
 	if defaultValue == "" {
 		return true
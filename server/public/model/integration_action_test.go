@@ -675,4 +675,30 @@ func TestOpenDialogRequestIsValid(t *testing.T) {
 		err := request.IsValid()
 		assert.ErrorContains(t, err, "Placeholder cannot be longer than 150 characters")
 	})
+
+	t.Run("should pass validation with pages", func(t *testing.T) {
+		request := getBaseOpenDialogRequest()
+		request.Dialog.Pages = []DialogPage{
+			{Elements: request.Dialog.Elements},
+			{Title: "Second Page", Elements: []DialogElement{{DisplayName: "Other", Name: "other", Type: "bool"}}},
+		}
+		request.Dialog.Elements = nil
+		err := request.IsValid()
+		assert.NoError(t, err)
+	})
+
+	t.Run("should fail on both elements and pages", func(t *testing.T) {
+		request := getBaseOpenDialogRequest()
+		request.Dialog.Pages = []DialogPage{{Elements: []DialogElement{{DisplayName: "Other", Name: "other", Type: "bool"}}}}
+		err := request.IsValid()
+		assert.ErrorContains(t, err, "a dialog can't have both elements and pages")
+	})
+
+	t.Run("should fail on duplicate elements across pages", func(t *testing.T) {
+		request := getBaseOpenDialogRequest()
+		request.Dialog.Pages = []DialogPage{{Elements: request.Dialog.Elements}, {Elements: request.Dialog.Elements}}
+		request.Dialog.Elements = nil
+		err := request.IsValid()
+		assert.ErrorContains(t, err, "duplicate dialog element \"element_name\"")
+	})
 }
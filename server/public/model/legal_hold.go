@@ -0,0 +1,181 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	LegalHoldNameMaxRunes        = 64
+	LegalHoldDisplayNameMaxRunes = 64
+	LegalHoldDescriptionMaxRunes = 1024
+	LegalHoldMaxMembers          = 1000
+
+	// LegalHoldJobDataKey is the message export job data key that restricts an export run
+	// to the data covered by a single legal hold.
+	LegalHoldJobDataKey = "legal_hold_id"
+)
+
+// LegalHold preserves the posts, files and channel history of the named users and channels
+// that were created between StartsAt and EndsAt. Data covered by a hold is skipped by the
+// data retention jobs and blocks the permanent deletion of the users and channels it names.
+// A zero StartsAt or EndsAt leaves that side of the time range open.
+type LegalHold struct {
+	Id          string      `json:"id"`
+	Name        string      `json:"name"`
+	DisplayName string      `json:"display_name"`
+	Description string      `json:"description"`
+	UserIds     StringArray `json:"user_ids"`
+	ChannelIds  StringArray `json:"channel_ids"`
+	StartsAt    int64       `json:"starts_at"`
+	EndsAt      int64       `json:"ends_at"`
+	CreatorId   string      `json:"creator_id"`
+	CreateAt    int64       `json:"create_at"`
+	UpdateAt    int64       `json:"update_at"`
+	DeleteAt    int64       `json:"delete_at"`
+}
+
+type LegalHoldPatch struct {
+	DisplayName *string      `json:"display_name"`
+	Description *string      `json:"description"`
+	UserIds     *StringArray `json:"user_ids"`
+	ChannelIds  *StringArray `json:"channel_ids"`
+	StartsAt    *int64       `json:"starts_at"`
+	EndsAt      *int64       `json:"ends_at"`
+}
+
+func (lh *LegalHold) Auditable() map[string]any {
+	return map[string]any{
+		"id":          lh.Id,
+		"name":        lh.Name,
+		"user_ids":    lh.UserIds,
+		"channel_ids": lh.ChannelIds,
+		"starts_at":   lh.StartsAt,
+		"ends_at":     lh.EndsAt,
+		"creator_id":  lh.CreatorId,
+		"create_at":   lh.CreateAt,
+		"update_at":   lh.UpdateAt,
+		"delete_at":   lh.DeleteAt,
+	}
+}
+
+func (p *LegalHoldPatch) Auditable() map[string]any {
+	return map[string]any{
+		"display_name": p.DisplayName,
+		"user_ids":     p.UserIds,
+		"channel_ids":  p.ChannelIds,
+		"starts_at":    p.StartsAt,
+		"ends_at":      p.EndsAt,
+	}
+}
+
+func (lh *LegalHold) PreSave() {
+	if lh.Id == "" {
+		lh.Id = NewId()
+	}
+
+	lh.UserIds = RemoveDuplicateStrings(lh.UserIds)
+	lh.ChannelIds = RemoveDuplicateStrings(lh.ChannelIds)
+
+	lh.CreateAt = GetMillis()
+	lh.UpdateAt = lh.CreateAt
+}
+
+func (lh *LegalHold) PreUpdate() {
+	lh.UserIds = RemoveDuplicateStrings(lh.UserIds)
+	lh.ChannelIds = RemoveDuplicateStrings(lh.ChannelIds)
+
+	lh.UpdateAt = GetMillis()
+}
+
+// Patch applies the non-nil fields of the patch. The name of a hold cannot be changed.
+func (lh *LegalHold) Patch(patch *LegalHoldPatch) {
+	if patch.DisplayName != nil {
+		lh.DisplayName = *patch.DisplayName
+	}
+	if patch.Description != nil {
+		lh.Description = *patch.Description
+	}
+	if patch.UserIds != nil {
+		lh.UserIds = *patch.UserIds
+	}
+	if patch.ChannelIds != nil {
+		lh.ChannelIds = *patch.ChannelIds
+	}
+	if patch.StartsAt != nil {
+		lh.StartsAt = *patch.StartsAt
+	}
+	if patch.EndsAt != nil {
+		lh.EndsAt = *patch.EndsAt
+	}
+}
+
+// Covers reports whether data created at the given time falls within the hold's time range.
+func (lh *LegalHold) Covers(createAt int64) bool {
+	if lh.StartsAt > 0 && createAt < lh.StartsAt {
+		return false
+	}
+	if lh.EndsAt > 0 && createAt > lh.EndsAt {
+		return false
+	}
+	return true
+}
+
+func (lh *LegalHold) IsValid() *AppError {
+	if !IsValidId(lh.Id) {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidAlphaNumHyphenUnderscore(lh.Name, true) || utf8.RuneCountInString(lh.Name) > LegalHoldNameMaxRunes {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.name.app_error", map[string]any{"MaxLength": LegalHoldNameMaxRunes}, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	if lh.DisplayName == "" || utf8.RuneCountInString(lh.DisplayName) > LegalHoldDisplayNameMaxRunes {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.display_name.app_error", map[string]any{"MaxLength": LegalHoldDisplayNameMaxRunes}, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	if utf8.RuneCountInString(lh.Description) > LegalHoldDescriptionMaxRunes {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.description.app_error", map[string]any{"MaxLength": LegalHoldDescriptionMaxRunes}, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	if len(lh.UserIds) == 0 && len(lh.ChannelIds) == 0 {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.empty.app_error", nil, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	if len(lh.UserIds)+len(lh.ChannelIds) > LegalHoldMaxMembers {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.too_many_members.app_error", map[string]any{"Max": LegalHoldMaxMembers}, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	for _, userID := range lh.UserIds {
+		if !IsValidId(userID) {
+			return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.user_id.app_error", nil, "id="+lh.Id, http.StatusBadRequest)
+		}
+	}
+
+	for _, channelID := range lh.ChannelIds {
+		if !IsValidId(channelID) {
+			return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.channel_id.app_error", nil, "id="+lh.Id, http.StatusBadRequest)
+		}
+	}
+
+	if lh.StartsAt < 0 || lh.EndsAt < 0 || (lh.EndsAt > 0 && lh.EndsAt < lh.StartsAt) {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.time_range.app_error", nil, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(lh.CreatorId) {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.creator_id.app_error", nil, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	if lh.CreateAt == 0 {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.create_at.app_error", nil, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	if lh.UpdateAt == 0 {
+		return NewAppError("LegalHold.IsValid", "model.legal_hold.is_valid.update_at.app_error", nil, "id="+lh.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
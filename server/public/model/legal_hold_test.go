@@ -0,0 +1,104 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidLegalHold() *LegalHold {
+	hold := &LegalHold{
+		Name:        "acme-litigation",
+		DisplayName: "ACME Litigation",
+		UserIds:     []string{NewId()},
+		ChannelIds:  []string{NewId()},
+		CreatorId:   NewId(),
+	}
+	hold.PreSave()
+	return hold
+}
+
+func TestLegalHoldIsValid(t *testing.T) {
+	require.Nil(t, newValidLegalHold().IsValid())
+
+	data := []struct {
+		name   string
+		modify func(hold *LegalHold)
+	}{
+		{name: "Invalid id", modify: func(hold *LegalHold) { hold.Id = "foo" }},
+		{name: "Invalid name", modify: func(hold *LegalHold) { hold.Name = "Not A Slug" }},
+		{name: "Long name", modify: func(hold *LegalHold) { hold.Name = strings.Repeat("a", LegalHoldNameMaxRunes+1) }},
+		{name: "Missing display name", modify: func(hold *LegalHold) { hold.DisplayName = "" }},
+		{name: "Long description", modify: func(hold *LegalHold) { hold.Description = strings.Repeat("a", LegalHoldDescriptionMaxRunes+1) }},
+		{name: "No users or channels", modify: func(hold *LegalHold) { hold.UserIds = nil; hold.ChannelIds = nil }},
+		{name: "Invalid user id", modify: func(hold *LegalHold) { hold.UserIds = []string{"foo"} }},
+		{name: "Invalid channel id", modify: func(hold *LegalHold) { hold.ChannelIds = []string{"foo"} }},
+		{name: "Negative start", modify: func(hold *LegalHold) { hold.StartsAt = -1 }},
+		{name: "End before start", modify: func(hold *LegalHold) { hold.StartsAt = 200; hold.EndsAt = 100 }},
+		{name: "Invalid creator id", modify: func(hold *LegalHold) { hold.CreatorId = "" }},
+	}
+
+	for _, item := range data {
+		t.Run(item.name, func(t *testing.T) {
+			hold := newValidLegalHold()
+			item.modify(hold)
+			assert.NotNil(t, hold.IsValid())
+		})
+	}
+
+	t.Run("Users only", func(t *testing.T) {
+		hold := newValidLegalHold()
+		hold.ChannelIds = nil
+		assert.Nil(t, hold.IsValid())
+	})
+}
+
+func TestLegalHoldPreSave(t *testing.T) {
+	userID := NewId()
+	hold := &LegalHold{UserIds: []string{userID, userID}}
+	hold.PreSave()
+
+	assert.True(t, IsValidId(hold.Id))
+	assert.NotZero(t, hold.CreateAt)
+	assert.Equal(t, hold.CreateAt, hold.UpdateAt)
+	assert.Equal(t, StringArray{userID}, hold.UserIds)
+}
+
+func TestLegalHoldPatch(t *testing.T) {
+	hold := newValidLegalHold()
+	name := hold.Name
+	channelIDs := hold.ChannelIds
+
+	userIDs := StringArray{NewId(), NewId()}
+	hold.Patch(&LegalHoldPatch{
+		DisplayName: NewPointer("Renamed"),
+		UserIds:     &userIDs,
+		EndsAt:      NewPointer(int64(1000)),
+	})
+
+	assert.Equal(t, name, hold.Name)
+	assert.Equal(t, "Renamed", hold.DisplayName)
+	assert.Equal(t, userIDs, hold.UserIds)
+	assert.Equal(t, channelIDs, hold.ChannelIds)
+	assert.Equal(t, int64(0), hold.StartsAt)
+	assert.Equal(t, int64(1000), hold.EndsAt)
+}
+
+func TestLegalHoldCovers(t *testing.T) {
+	hold := &LegalHold{}
+	assert.True(t, hold.Covers(1))
+
+	hold.StartsAt = 100
+	assert.False(t, hold.Covers(99))
+	assert.True(t, hold.Covers(100))
+	assert.True(t, hold.Covers(GetMillis()))
+
+	hold.EndsAt = 200
+	assert.True(t, hold.Covers(200))
+	assert.False(t, hold.Covers(201))
+}
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -221,6 +222,95 @@ type Manifest struct {
 
 	// Plugins can store any kind of data in Props to allow other plugins to use it.
 	Props map[string]any `json:"props,omitempty" yaml:"props,omitempty"`
+
+	// Permissions are the capabilities your plugin needs, such as PluginPermissionReadPosts.
+	// They are approved by the system admin when enabling the plugin, and plugins get a
+	// permission error when calling an API method covered by a permission they don't have.
+	// Plugins declaring no permissions request all of them, and plugins running in the
+	// WebAssembly runtime can only call the API methods covered by the declared ones.
+	//
+	// Minimum server version: 10.12
+	Permissions []string `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+}
+
+const (
+	// ManifestServerRuntimeNative runs the server component as a native executable.
+	ManifestServerRuntimeNative = "native"
+	// ManifestServerRuntimeWasm runs the server component as a sandboxed WebAssembly module.
+	ManifestServerRuntimeWasm = "wasm"
+)
+
+const (
+	PluginPermissionReadPosts            = "read_posts"
+	PluginPermissionManagePosts          = "manage_posts"
+	PluginPermissionReadChannels         = "read_channels"
+	PluginPermissionManageChannels       = "manage_channels"
+	PluginPermissionReadUsers            = "read_users"
+	PluginPermissionManageUsers          = "manage_users"
+	PluginPermissionReadTeams            = "read_teams"
+	PluginPermissionManageTeams          = "manage_teams"
+	PluginPermissionReadFiles            = "read_files"
+	PluginPermissionManageFiles          = "manage_files"
+	PluginPermissionReadGroups           = "read_groups"
+	PluginPermissionManageGroups         = "manage_groups"
+	PluginPermissionReadProperties       = "read_properties"
+	PluginPermissionManageProperties     = "manage_properties"
+	PluginPermissionManageBots           = "manage_bots"
+	PluginPermissionManageRoles          = "manage_roles"
+	PluginPermissionManageCommands       = "manage_commands"
+	PluginPermissionManageOAuthApps      = "manage_oauth_apps"
+	PluginPermissionManageSharedChannels = "manage_shared_channels"
+	PluginPermissionSendNotifications    = "send_notifications"
+	PluginPermissionReadConfig           = "read_config"
+	PluginPermissionManageConfig         = "manage_config"
+	PluginPermissionManagePlugins        = "manage_plugins"
+	PluginPermissionCallPlugins          = "call_plugins"
+)
+
+// AllPluginPermissions lists the permissions a plugin may declare in its manifest.
+var AllPluginPermissions = []string{
+	PluginPermissionReadPosts,
+	PluginPermissionManagePosts,
+	PluginPermissionReadChannels,
+	PluginPermissionManageChannels,
+	PluginPermissionReadUsers,
+	PluginPermissionManageUsers,
+	PluginPermissionReadTeams,
+	PluginPermissionManageTeams,
+	PluginPermissionReadFiles,
+	PluginPermissionManageFiles,
+	PluginPermissionReadGroups,
+	PluginPermissionManageGroups,
+	PluginPermissionReadProperties,
+	PluginPermissionManageProperties,
+	PluginPermissionManageBots,
+	PluginPermissionManageRoles,
+	PluginPermissionManageCommands,
+	PluginPermissionManageOAuthApps,
+	PluginPermissionManageSharedChannels,
+	PluginPermissionSendNotifications,
+	PluginPermissionReadConfig,
+	PluginPermissionManageConfig,
+	PluginPermissionManagePlugins,
+	PluginPermissionCallPlugins,
+}
+
+func IsValidPluginPermission(permission string) bool {
+	return slices.Contains(AllPluginPermissions, permission)
+}
+
+// RequestedPermissions returns the permissions the plugin needs approved: the declared ones, or
+// all of them when the manifest declares none.
+func (m *Manifest) RequestedPermissions() []string {
+	if len(m.Permissions) == 0 {
+		return AllPluginPermissions
+	}
+	return m.Permissions
+}
+
+// HasPermission reports whether the manifest declares the given permission.
+func (m *Manifest) HasPermission(permission string) bool {
+	return slices.Contains(m.Permissions, permission)
 }
 
 type ManifestServer struct {
@@ -236,6 +326,14 @@ type ManifestServer struct {
 	// If your plugin is compiled for multiple platforms, consider bundling them together
 	// and using the Executables field instead.
 	Executable string `json:"executable" yaml:"executable"`
+
+	// Runtime is either ManifestServerRuntimeNative, the default, or ManifestServerRuntimeWasm
+	// to run Executable as a WebAssembly module. WebAssembly modules have no access to the file
+	// system or the network, and only reach the server through the hooks and the API methods
+	// allowed by the permissions of the manifest.
+	//
+	// Minimum server version: 10.12
+	Runtime string `json:"runtime,omitempty" yaml:"runtime,omitempty"`
 }
 
 type ManifestWebapp struct {
@@ -294,6 +392,11 @@ func (m *Manifest) HasServer() bool {
 	return m.Server != nil
 }
 
+// IsWasm reports whether the server component runs in the WebAssembly runtime.
+func (m *Manifest) IsWasm() bool {
+	return m.Server != nil && m.Server.Runtime == ManifestServerRuntimeWasm
+}
+
 func (m *Manifest) HasWebapp() bool {
 	return m.Webapp != nil
 }
@@ -345,6 +448,24 @@ func (m *Manifest) IsValid() error {
 		}
 	}
 
+	if m.Server != nil {
+		switch m.Server.Runtime {
+		case "", ManifestServerRuntimeNative:
+		case ManifestServerRuntimeWasm:
+			if m.Server.Executable == "" {
+				return errors.New("a WebAssembly module is needed in Executable")
+			}
+		default:
+			return errors.Errorf("invalid server runtime %q", m.Server.Runtime)
+		}
+	}
+
+	for _, permission := range m.Permissions {
+		if !IsValidPluginPermission(permission) {
+			return errors.Errorf("invalid permission %q", permission)
+		}
+	}
+
 	if m.SettingsSchema != nil {
 		err := m.SettingsSchema.isValid()
 		if err != nil {
@@ -31,7 +31,11 @@ func TestIsValid(t *testing.T) {
 		{"SettingSchema error", &Manifest{Id: "com.company.test", Name: "some name", HomepageURL: "http://someurl.com", SupportURL: "http://someotherurl.com", Version: "5.10.0", MinServerVersion: "5.10.8", SettingsSchema: &PluginSettingsSchema{
 			Settings: []*PluginSetting{{Type: "Invalid"}},
 		}}, true},
+		{"Invalid server runtime", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin", Runtime: "jvm"}}, true},
+		{"WebAssembly runtime without module", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Runtime: ManifestServerRuntimeWasm}}, true},
+		{"Invalid permission", &Manifest{Id: "com.company.test", Name: "some name", Permissions: []string{PluginPermissionReadPosts, "sudo"}}, true},
 		{"Minimal valid manifest", &Manifest{Id: "com.company.test", Name: "some name"}, false},
+		{"WebAssembly runtime", &Manifest{Id: "com.company.test", Name: "some name", Server: &ManifestServer{Executable: "plugin.wasm", Runtime: ManifestServerRuntimeWasm}, Permissions: []string{PluginPermissionReadPosts}}, false},
 		{"Happy case", &Manifest{
 			Id:               "com.company.test",
 			Name:             "thename",
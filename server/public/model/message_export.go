@@ -35,10 +35,12 @@ type MessageExport struct {
 
 // MessageExportCursor retrieves posts in the inclusive range:
 // [LastPostUpdateAt + LastPostId, UntilUpdateAt]
+// If LegalHoldId is set, only posts covered by that legal hold are retrieved.
 type MessageExportCursor struct {
 	LastPostUpdateAt int64
 	LastPostId       string
 	UntilUpdateAt    int64
+	LegalHoldId      string
 }
 
 // PreviewID returns the value of the post's previewed_post prop, if present, or an empty string.
@@ -29,6 +29,10 @@ type OutgoingWebhook struct {
 	ContentType  string      `json:"content_type"`
 	Username     string      `json:"username"`
 	IconURL      string      `json:"icon_url"`
+	// EventTypes are the events the webhook is subscribed to. A webhook subscribed to events
+	// only receives the posts if subscribed to OutgoingWebhookEventPosted, or those matching
+	// its trigger words.
+	EventTypes StringArray `json:"event_types"`
 }
 
 func (o *OutgoingWebhook) Auditable() map[string]any {
@@ -48,6 +52,7 @@ func (o *OutgoingWebhook) Auditable() map[string]any {
 		"content_type":  o.ContentType,
 		"username":      o.Username,
 		"icon_url":      o.IconURL,
+		"event_types":   o.EventTypes,
 	}
 }
 
@@ -170,6 +175,21 @@ func (o *OutgoingWebhook) IsValid() *AppError {
 		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.icon_url.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if len(fmt.Sprintf("%s", o.EventTypes)) > 1024 {
+		return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.event_types.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, eventType := range o.EventTypes {
+		if !IsValidOutgoingWebhookEventType(eventType) {
+			return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.event_types.app_error", nil, "event_type="+eventType, http.StatusBadRequest)
+		}
+
+		// The webhooks of a channel can't be subscribed to the events of the whole team
+		if o.ChannelId != "" && !IsChannelOutgoingWebhookEventType(eventType) {
+			return NewAppError("OutgoingWebhook.IsValid", "model.outgoing_hook.is_valid.channel_event_types.app_error", map[string]any{"EventType": eventType}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -190,6 +210,17 @@ func (o *OutgoingWebhook) PreUpdate() {
 	o.UpdateAt = GetMillis()
 }
 
+// IsSubscribedTo returns whether the webhook is subscribed to the given event type.
+func (o *OutgoingWebhook) IsSubscribedTo(eventType string) bool {
+	return slices.Contains(o.EventTypes, eventType)
+}
+
+// ReceivesPosts returns whether the posts matching the channel or trigger words of the webhook
+// are sent to it.
+func (o *OutgoingWebhook) ReceivesPosts() bool {
+	return len(o.EventTypes) == 0 || len(o.TriggerWords) > 0 || o.IsSubscribedTo(OutgoingWebhookEventPosted)
+}
+
 func (o *OutgoingWebhook) TriggerWordExactMatch(word string) bool {
 	if word == "" {
 		return false
@@ -0,0 +1,94 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// The events outgoing webhooks can be subscribed to. OutgoingWebhookEventPosted sends the posts
+// of the channel, or team, with the payload of the webhooks not subscribed to any event.
+const (
+	OutgoingWebhookEventPosted            = "posted"
+	OutgoingWebhookEventUserJoinedChannel = "user_joined_channel"
+	OutgoingWebhookEventUserLeftChannel   = "user_left_channel"
+	OutgoingWebhookEventUserJoinedTeam    = "user_joined_team"
+	OutgoingWebhookEventUserLeftTeam      = "user_left_team"
+	OutgoingWebhookEventReactionAdded     = "reaction_added"
+	OutgoingWebhookEventChannelCreated    = "channel_created"
+	OutgoingWebhookEventChannelArchived   = "channel_archived"
+	OutgoingWebhookEventUserDeactivated   = "user_deactivated"
+)
+
+var outgoingWebhookEventTypes = []string{
+	OutgoingWebhookEventPosted,
+	OutgoingWebhookEventUserJoinedChannel,
+	OutgoingWebhookEventUserLeftChannel,
+	OutgoingWebhookEventUserJoinedTeam,
+	OutgoingWebhookEventUserLeftTeam,
+	OutgoingWebhookEventReactionAdded,
+	OutgoingWebhookEventChannelCreated,
+	OutgoingWebhookEventChannelArchived,
+	OutgoingWebhookEventUserDeactivated,
+}
+
+func IsValidOutgoingWebhookEventType(eventType string) bool {
+	return slices.Contains(outgoingWebhookEventTypes, eventType)
+}
+
+// IsChannelOutgoingWebhookEventType returns whether the event happens in a channel. The webhooks
+// of a channel can only be subscribed to these events, and receive them for their channel only.
+func IsChannelOutgoingWebhookEventType(eventType string) bool {
+	switch eventType {
+	case OutgoingWebhookEventPosted,
+		OutgoingWebhookEventUserJoinedChannel,
+		OutgoingWebhookEventUserLeftChannel,
+		OutgoingWebhookEventReactionAdded,
+		OutgoingWebhookEventChannelCreated,
+		OutgoingWebhookEventChannelArchived:
+		return true
+	}
+	return false
+}
+
+// OutgoingWebhookEventPayload is the payload sent to the outgoing webhooks subscribed to an
+// event. The fields relevant to the event are set: the channel for the channel events, the
+// post and emoji for the reactions, and the actor when a user is added or removed by someone
+// else.
+type OutgoingWebhookEventPayload struct {
+	Token       string `json:"token"`
+	Event       string `json:"event"`
+	TeamId      string `json:"team_id"`
+	TeamDomain  string `json:"team_domain"`
+	ChannelId   string `json:"channel_id,omitempty"`
+	ChannelName string `json:"channel_name,omitempty"`
+	Timestamp   int64  `json:"timestamp"`
+	UserId      string `json:"user_id"`
+	UserName    string `json:"user_name"`
+	ActorId     string `json:"actor_id,omitempty"`
+	ActorName   string `json:"actor_name,omitempty"`
+	PostId      string `json:"post_id,omitempty"`
+	EmojiName   string `json:"emoji_name,omitempty"`
+}
+
+func (o *OutgoingWebhookEventPayload) ToFormValues() string {
+	v := url.Values{}
+	v.Set("token", o.Token)
+	v.Set("event", o.Event)
+	v.Set("team_id", o.TeamId)
+	v.Set("team_domain", o.TeamDomain)
+	v.Set("channel_id", o.ChannelId)
+	v.Set("channel_name", o.ChannelName)
+	v.Set("timestamp", strconv.FormatInt(o.Timestamp/1000, 10))
+	v.Set("user_id", o.UserId)
+	v.Set("user_name", o.UserName)
+	v.Set("actor_id", o.ActorId)
+	v.Set("actor_name", o.ActorName)
+	v.Set("post_id", o.PostId)
+	v.Set("emoji_name", o.EmojiName)
+
+	return v.Encode()
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutgoingWebhookEventTypes(t *testing.T) {
+	assert.True(t, IsValidOutgoingWebhookEventType(OutgoingWebhookEventUserDeactivated))
+	assert.True(t, IsValidOutgoingWebhookEventType(OutgoingWebhookEventPosted))
+	assert.False(t, IsValidOutgoingWebhookEventType(""))
+	assert.False(t, IsValidOutgoingWebhookEventType("post_created"))
+
+	assert.True(t, IsChannelOutgoingWebhookEventType(OutgoingWebhookEventReactionAdded))
+	assert.False(t, IsChannelOutgoingWebhookEventType(OutgoingWebhookEventUserJoinedTeam))
+
+	o := OutgoingWebhook{EventTypes: []string{OutgoingWebhookEventChannelCreated}}
+	assert.True(t, o.IsSubscribedTo(OutgoingWebhookEventChannelCreated))
+	assert.False(t, o.IsSubscribedTo(OutgoingWebhookEventChannelArchived))
+}
+
+func TestOutgoingWebhookReceivesPosts(t *testing.T) {
+	assert.True(t, (&OutgoingWebhook{}).ReceivesPosts())
+	assert.False(t, (&OutgoingWebhook{EventTypes: []string{OutgoingWebhookEventReactionAdded}}).ReceivesPosts())
+	assert.True(t, (&OutgoingWebhook{EventTypes: []string{OutgoingWebhookEventReactionAdded, OutgoingWebhookEventPosted}}).ReceivesPosts())
+	assert.True(t, (&OutgoingWebhook{EventTypes: []string{OutgoingWebhookEventReactionAdded}, TriggerWords: []string{"hello"}}).ReceivesPosts())
+}
+
+func TestOutgoingWebhookEventPayloadToFormValues(t *testing.T) {
+	p := &OutgoingWebhookEventPayload{
+		Token:       "Token",
+		Event:       OutgoingWebhookEventReactionAdded,
+		TeamId:      "TeamId",
+		TeamDomain:  "TeamDomain",
+		ChannelId:   "ChannelId",
+		ChannelName: "ChannelName",
+		Timestamp:   123000,
+		UserId:      "UserId",
+		UserName:    "UserName",
+		PostId:      "PostId",
+		EmojiName:   "smile",
+	}
+	v := url.Values{}
+	v.Set("token", "Token")
+	v.Set("event", "reaction_added")
+	v.Set("team_id", "TeamId")
+	v.Set("team_domain", "TeamDomain")
+	v.Set("channel_id", "ChannelId")
+	v.Set("channel_name", "ChannelName")
+	v.Set("timestamp", "123")
+	v.Set("user_id", "UserId")
+	v.Set("user_name", "UserName")
+	v.Set("actor_id", "")
+	v.Set("actor_name", "")
+	v.Set("post_id", "PostId")
+	v.Set("emoji_name", "smile")
+	assert.Equal(t, v.Encode(), p.ToFormValues())
+}
+
+func TestOutgoingWebhookEventPayloadJSON(t *testing.T) {
+	p := &OutgoingWebhookEventPayload{
+		Token:      "Token",
+		Event:      OutgoingWebhookEventUserJoinedTeam,
+		TeamId:     "TeamId",
+		TeamDomain: "TeamDomain",
+		Timestamp:  123000,
+		UserId:     "UserId",
+		UserName:   "UserName",
+	}
+	b, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(b, &fields))
+	assert.Equal(t, "user_joined_team", fields["event"])
+	assert.NotContains(t, fields, "channel_id")
+	assert.NotContains(t, fields, "emoji_name")
+}
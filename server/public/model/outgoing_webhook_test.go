@@ -83,6 +83,18 @@ func TestOutgoingWebhookIsValid(t *testing.T) {
 
 	o.IconURL = strings.Repeat("1", 1024)
 	assert.Nilf(t, o.IsValid(), "IconURL length %d should be valid", len(o.IconURL))
+
+	o.EventTypes = []string{OutgoingWebhookEventUserJoinedChannel, "unknown"}
+	assert.NotNilf(t, o.IsValid(), "%v for EventTypes should be invalid", o.EventTypes)
+
+	o.EventTypes = []string{OutgoingWebhookEventUserJoinedTeam}
+	assert.NotNilf(t, o.IsValid(), "%v for EventTypes should be invalid for a channel webhook", o.EventTypes)
+
+	o.ChannelId = ""
+	assert.Nilf(t, o.IsValid(), "%v for EventTypes should be valid", o.EventTypes)
+
+	o.EventTypes = []string{OutgoingWebhookEventUserJoinedChannel, OutgoingWebhookEventReactionAdded}
+	assert.Nilf(t, o.IsValid(), "%v for EventTypes should be valid", o.EventTypes)
 }
 
 func TestOutgoingWebhookPayloadToFormValues(t *testing.T) {
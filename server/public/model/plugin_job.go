@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// PluginJobTypePrefix is the prefix every job type registered by a plugin must start with,
+// keeping plugin job types apart from the built-in ones.
+const PluginJobTypePrefix = "plugin_"
+
+// PluginJobTypeMaxLength is bound by the size of the Jobs.Type column.
+const PluginJobTypeMaxLength = 32
+
+var validPluginJobType = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// PluginJobTypeOptions describes how jobs of a type registered by a plugin are scheduled.
+type PluginJobTypeOptions struct {
+	// IntervalSeconds, when positive, makes the cluster leader create a job of this type
+	// periodically. Otherwise, jobs are only created on demand.
+	IntervalSeconds int64 `json:"interval_seconds"`
+}
+
+func (o *PluginJobTypeOptions) IsValid() *AppError {
+	if o.IntervalSeconds < 0 {
+		return NewAppError("PluginJobTypeOptions.IsValid", "model.plugin_job_type_options.is_valid.interval_seconds.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// IsValidPluginJobType reports whether the given job type can be registered by a plugin.
+func IsValidPluginJobType(jobType string) bool {
+	if !strings.HasPrefix(jobType, PluginJobTypePrefix) || len(jobType) == len(PluginJobTypePrefix) {
+		return false
+	}
+
+	if len(jobType) > PluginJobTypeMaxLength {
+		return false
+	}
+
+	return validPluginJobType.MatchString(jobType)
+}
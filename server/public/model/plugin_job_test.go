@@ -0,0 +1,37 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidPluginJobType(t *testing.T) {
+	for name, tc := range map[string]struct {
+		jobType string
+		valid   bool
+	}{
+		"valid":             {"plugin_reindex", true},
+		"digits":            {"plugin_sync_2", true},
+		"missing prefix":    {"reindex", false},
+		"prefix only":       {"plugin_", false},
+		"built-in type":     {JobTypeDataRetention, false},
+		"uppercase":         {"plugin_Reindex", false},
+		"invalid character": {"plugin_re-index", false},
+		"too long":          {PluginJobTypePrefix + strings.Repeat("a", PluginJobTypeMaxLength), false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.valid, IsValidPluginJobType(tc.jobType))
+		})
+	}
+}
+
+func TestPluginJobTypeOptionsIsValid(t *testing.T) {
+	assert.Nil(t, (&PluginJobTypeOptions{}).IsValid())
+	assert.Nil(t, (&PluginJobTypeOptions{IntervalSeconds: 60}).IsValid())
+	assert.NotNil(t, (&PluginJobTypeOptions{IntervalSeconds: -1}).IsValid())
+}
@@ -5,6 +5,7 @@ package model
 
 import (
 	"net/http"
+	"unicode/utf8"
 )
 
 // PluginKVSetOptions contains information on how to store a value in the plugin KV store.
@@ -45,3 +46,25 @@ func NewPluginKeyValueFromOptions(pluginId, key string, value []byte, opt Plugin
 
 	return kv, nil
 }
+
+// PluginKVCompareAndSwap is one of the operations of an atomic, multi-key compare and swap of
+// the plugin KV store.
+type PluginKVCompareAndSwap struct {
+	Key             string
+	OldValue        []byte // The expected current value. An empty value expects the key not to exist
+	NewValue        []byte // The value to store. An empty value deletes the key
+	ExpireInSeconds int64  // Set an expire counter on the new value
+}
+
+// IsValid returns nil if the operation is valid.
+func (op *PluginKVCompareAndSwap) IsValid() *AppError {
+	if op.Key == "" || utf8.RuneCountInString(op.Key) > KeyValueKeyMaxRunes {
+		return NewAppError("PluginKVCompareAndSwap.IsValid", "model.plugin_key_value.is_valid.key.app_error", map[string]any{"Max": KeyValueKeyMaxRunes, "Min": 0}, "key="+op.Key, http.StatusBadRequest)
+	}
+
+	if op.ExpireInSeconds < 0 {
+		return NewAppError("PluginKVCompareAndSwap.IsValid", "model.plugin_kv_compare_and_swap.is_valid.expire_in_seconds.app_error", nil, "key="+op.Key, http.StatusBadRequest)
+	}
+
+	return nil
+}
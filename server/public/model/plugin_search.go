@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	PluginSearchDocumentTypePost = "post"
+	PluginSearchDocumentTypeFile = "file"
+
+	PluginDocumentExtractorMaxEntries = 100
+)
+
+// PluginDocumentExtractor describes the files a plugin extracts the text content of, matched
+// either by file extension, such as ".dwg", or by MIME type.
+type PluginDocumentExtractor struct {
+	Extensions []string `json:"extensions"`
+	MimeTypes  []string `json:"mime_types"`
+}
+
+func (e *PluginDocumentExtractor) IsValid() *AppError {
+	if len(e.Extensions) == 0 && len(e.MimeTypes) == 0 {
+		return NewAppError("PluginDocumentExtractor.IsValid", "model.plugin_document_extractor.is_valid.empty.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(e.Extensions)+len(e.MimeTypes) > PluginDocumentExtractorMaxEntries {
+		return NewAppError("PluginDocumentExtractor.IsValid", "model.plugin_document_extractor.is_valid.too_many.app_error", map[string]any{"Max": PluginDocumentExtractorMaxEntries}, "", http.StatusBadRequest)
+	}
+
+	for _, extension := range e.Extensions {
+		if len(extension) < 2 || !strings.HasPrefix(extension, ".") {
+			return NewAppError("PluginDocumentExtractor.IsValid", "model.plugin_document_extractor.is_valid.extension.app_error", nil, "extension="+extension, http.StatusBadRequest)
+		}
+	}
+
+	for _, mimeType := range e.MimeTypes {
+		if !strings.Contains(mimeType, "/") {
+			return NewAppError("PluginDocumentExtractor.IsValid", "model.plugin_document_extractor.is_valid.mime_type.app_error", nil, "mime_type="+mimeType, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// Matches reports whether a file with the given name and MIME type is handled by the extractor.
+// Extensions and MIME types are compared case-insensitively.
+func (e *PluginDocumentExtractor) Matches(filename, mimeType string) bool {
+	lowerName := strings.ToLower(filename)
+	for _, extension := range e.Extensions {
+		if strings.HasSuffix(lowerName, strings.ToLower(extension)) {
+			return true
+		}
+	}
+
+	if mimeType == "" {
+		return false
+	}
+	// Drop parameters such as the charset.
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+	mimeType = strings.TrimSpace(mimeType)
+	for _, mt := range e.MimeTypes {
+		if strings.EqualFold(mt, mimeType) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PluginSearchDeleteRequest describes the documents to remove from the index of a search
+// engine provided by a plugin. Only one criterion is set per request, and DocumentType is
+// empty when documents of every type are concerned.
+type PluginSearchDeleteRequest struct {
+	DocumentType string `json:"document_type"`
+	// Ids of the posts or files to delete.
+	Ids []string `json:"ids,omitempty"`
+	// ChannelId, UserId and PostId select the documents of a channel, a user or a post.
+	ChannelId string `json:"channel_id,omitempty"`
+	UserId    string `json:"user_id,omitempty"`
+	PostId    string `json:"post_id,omitempty"`
+	// EndTime selects the documents created before that time, in milliseconds, up to Limit
+	// documents when Limit is positive.
+	EndTime int64 `json:"end_time,omitempty"`
+	Limit   int64 `json:"limit,omitempty"`
+	// All selects every document of the index.
+	All bool `json:"all,omitempty"`
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPluginDocumentExtractorIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		extractor PluginDocumentExtractor
+		valid     bool
+	}{
+		"extension":             {PluginDocumentExtractor{Extensions: []string{".dwg"}}, true},
+		"mime type":             {PluginDocumentExtractor{MimeTypes: []string{"image/vnd.dwg"}}, true},
+		"empty":                 {PluginDocumentExtractor{}, false},
+		"extension without dot": {PluginDocumentExtractor{Extensions: []string{"dwg"}}, false},
+		"dot only":              {PluginDocumentExtractor{Extensions: []string{"."}}, false},
+		"invalid mime type":     {PluginDocumentExtractor{MimeTypes: []string{"dwg"}}, false},
+		"too many":              {PluginDocumentExtractor{Extensions: make([]string, PluginDocumentExtractorMaxEntries+1)}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if tc.valid {
+				assert.Nil(t, tc.extractor.IsValid())
+			} else {
+				assert.NotNil(t, tc.extractor.IsValid())
+			}
+		})
+	}
+}
+
+func TestPluginDocumentExtractorMatches(t *testing.T) {
+	extractor := PluginDocumentExtractor{
+		Extensions: []string{".dwg"},
+		MimeTypes:  []string{"application/acad"},
+	}
+
+	assert.True(t, extractor.Matches("drawing.dwg", ""))
+	assert.True(t, extractor.Matches("DRAWING.DWG", ""))
+	assert.True(t, extractor.Matches("drawing", "application/acad"))
+	assert.True(t, extractor.Matches("drawing", "Application/ACAD; charset=binary"))
+	assert.False(t, extractor.Matches("drawing.pdf", "application/pdf"))
+	assert.False(t, extractor.Matches("drawing.dwg.pdf", ""))
+}
@@ -5,6 +5,10 @@ package model
 
 type PluginInfo struct {
 	Manifest
+
+	// PendingPermissions are the permissions requested by the manifest which the system admin
+	// hasn't approved yet.
+	PendingPermissions []string `json:"pending_permissions,omitempty"`
 }
 
 type PluginsResponse struct {
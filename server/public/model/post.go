@@ -210,6 +210,10 @@ type SearchParameter struct {
 	Page                   *int    `json:"page"`
 	PerPage                *int    `json:"per_page"`
 	IncludeDeletedChannels *bool   `json:"include_deleted_channels"`
+	// SemanticWeight is the share, between 0 and 1, of the semantic search in
+	// the ranking of the results. It is ignored when semantic search is not
+	// enabled.
+	SemanticWeight *float64 `json:"semantic_weight"`
 }
 
 func (sp SearchParameter) Auditable() map[string]any {
@@ -220,6 +224,7 @@ func (sp SearchParameter) Auditable() map[string]any {
 		"page":                     sp.Page,
 		"per_page":                 sp.PerPage,
 		"include_deleted_channels": sp.IncludeDeletedChannels,
+		"semantic_weight":          sp.SemanticWeight,
 	}
 }
 
@@ -35,6 +35,9 @@ type SearchParams struct {
 	// True if this search doesn't originate from a "current user".
 	SearchWithoutUserId bool   `json:"search_without_user_id,omitempty"`
 	Modifier            string `json:"modifier"`
+	// SemanticWeight is the share, between 0 and 1, of the semantic search in
+	// the ranking of the results. Zero searches by keywords only.
+	SemanticWeight float64 `json:"semantic_weight,omitempty"`
 }
 
 // Returns the epoch timestamp of the start of the day specified by SearchParams.AfterDate
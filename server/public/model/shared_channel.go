@@ -4,8 +4,11 @@
 package model
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"slices"
 	"unicode/utf8"
 
 	"github.com/pkg/errors"
@@ -16,6 +19,17 @@ const (
 	UserPropsKeyRemoteEmail      = "RemoteEmail"
 	UserPropsKeyOriginalRemoteId = "OriginalRemoteId"
 	UserOriginalRemoteIdUnknown  = "UNKNOWN"
+
+	SharedChannelSyncFiltersMaxItems = 256
+
+	SharedChannelSyncItemPost = "post"
+	SharedChannelSyncItemFile = "file"
+
+	SharedChannelSyncSuppressedBot            = "bot"
+	SharedChannelSyncSuppressedPostType       = "post_type"
+	SharedChannelSyncSuppressedUser           = "user"
+	SharedChannelSyncSuppressedGroup          = "group"
+	SharedChannelSyncSuppressedAttachmentSize = "attachment_size"
 )
 
 var (
@@ -121,6 +135,8 @@ type SharedChannelRemote struct {
 	LastPostCreateAt  int64  `json:"last_post_create_at"`
 	LastPostCreateID  string `json:"last_post_create_id"`
 	LastMembersSyncAt int64  `json:"last_members_sync_at"`
+
+	SyncFilters *SharedChannelRemoteSyncFilters `json:"sync_filters,omitempty"`
 }
 
 func (sc *SharedChannelRemote) IsValid() *AppError {
@@ -143,6 +159,12 @@ func (sc *SharedChannelRemote) IsValid() *AppError {
 	if !IsValidId(sc.CreatorId) {
 		return NewAppError("SharedChannelRemote.IsValid", "model.channel.is_valid.creator_id.app_error", nil, "id="+sc.CreatorId, http.StatusBadRequest)
 	}
+
+	if sc.SyncFilters != nil {
+		if err := sc.SyncFilters.IsValid(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -158,6 +180,150 @@ func (sc *SharedChannelRemote) PreUpdate() {
 	sc.UpdateAt = GetMillis()
 }
 
+// SharedChannelRemoteSyncFilters restricts the content of a shared channel
+// that is synchronized with a remote. The excluded posts and files are
+// recorded as SharedChannelSyncSuppression.
+type SharedChannelRemoteSyncFilters struct {
+	ExcludeBots       bool     `json:"exclude_bots"`
+	ExcludePostTypes  []string `json:"exclude_post_types,omitempty"`
+	ExcludeUserIds    []string `json:"exclude_user_ids,omitempty"`
+	ExcludeGroupIds   []string `json:"exclude_group_ids,omitempty"`
+	MaxAttachmentSize int64    `json:"max_attachment_size"` // in bytes, 0 means no limit
+}
+
+func (f *SharedChannelRemoteSyncFilters) IsValid() *AppError {
+	if len(f.ExcludePostTypes) > SharedChannelSyncFiltersMaxItems ||
+		len(f.ExcludeUserIds) > SharedChannelSyncFiltersMaxItems ||
+		len(f.ExcludeGroupIds) > SharedChannelSyncFiltersMaxItems {
+		return NewAppError("SharedChannelRemoteSyncFilters.IsValid", "model.shared_channel_remote.sync_filters.too_many.app_error", map[string]any{"Max": SharedChannelSyncFiltersMaxItems}, "", http.StatusBadRequest)
+	}
+
+	for _, postType := range f.ExcludePostTypes {
+		if postType == "" {
+			return NewAppError("SharedChannelRemoteSyncFilters.IsValid", "model.shared_channel_remote.sync_filters.post_type.app_error", nil, "type="+postType, http.StatusBadRequest)
+		}
+	}
+
+	for _, userID := range f.ExcludeUserIds {
+		if !IsValidId(userID) {
+			return NewAppError("SharedChannelRemoteSyncFilters.IsValid", "model.shared_channel_remote.sync_filters.user_id.app_error", nil, "user_id="+userID, http.StatusBadRequest)
+		}
+	}
+
+	for _, groupID := range f.ExcludeGroupIds {
+		if !IsValidId(groupID) {
+			return NewAppError("SharedChannelRemoteSyncFilters.IsValid", "model.shared_channel_remote.sync_filters.group_id.app_error", nil, "group_id="+groupID, http.StatusBadRequest)
+		}
+	}
+
+	if f.MaxAttachmentSize < 0 {
+		return NewAppError("SharedChannelRemoteSyncFilters.IsValid", "model.shared_channel_remote.sync_filters.max_attachment_size.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+func (f *SharedChannelRemoteSyncFilters) Auditable() map[string]any {
+	return map[string]any{
+		"exclude_bots":        f.ExcludeBots,
+		"exclude_post_types":  f.ExcludePostTypes,
+		"exclude_user_ids":    f.ExcludeUserIds,
+		"exclude_group_ids":   f.ExcludeGroupIds,
+		"max_attachment_size": f.MaxAttachmentSize,
+	}
+}
+
+// IsEmpty returns true if the filters don't exclude anything.
+func (f *SharedChannelRemoteSyncFilters) IsEmpty() bool {
+	return f == nil || (!f.ExcludeBots && len(f.ExcludePostTypes) == 0 && len(f.ExcludeUserIds) == 0 &&
+		len(f.ExcludeGroupIds) == 0 && f.MaxAttachmentSize == 0)
+}
+
+// ExcludesPostType returns true if the posts of the given type must not be synchronized.
+func (f *SharedChannelRemoteSyncFilters) ExcludesPostType(postType string) bool {
+	return f != nil && slices.Contains(f.ExcludePostTypes, postType)
+}
+
+// ExcludesUser returns true if the content of the given user must not be synchronized.
+func (f *SharedChannelRemoteSyncFilters) ExcludesUser(userID string) bool {
+	return f != nil && slices.Contains(f.ExcludeUserIds, userID)
+}
+
+// ExcludesAttachmentSize returns true if a file of the given size must not be synchronized.
+func (f *SharedChannelRemoteSyncFilters) ExcludesAttachmentSize(size int64) bool {
+	return f != nil && f.MaxAttachmentSize > 0 && size > f.MaxAttachmentSize
+}
+
+func (f *SharedChannelRemoteSyncFilters) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", value)
+	}
+
+	return json.Unmarshal(b, f)
+}
+
+func (f SharedChannelRemoteSyncFilters) Value() (driver.Value, error) {
+	if f.IsEmpty() {
+		return nil, nil
+	}
+
+	j, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(j), nil
+}
+
+// SharedChannelSyncSuppression records a post or file of a shared channel
+// that was not synchronized with a remote because of its sync filters.
+type SharedChannelSyncSuppression struct {
+	RemoteId  string `json:"remote_id"`
+	ItemId    string `json:"item_id"`
+	ChannelId string `json:"channel_id"`
+	ItemType  string `json:"item_type"`
+	Reason    string `json:"reason"`
+	CreateAt  int64  `json:"create_at"`
+}
+
+func (s *SharedChannelSyncSuppression) PreSave() {
+	if s.CreateAt == 0 {
+		s.CreateAt = GetMillis()
+	}
+}
+
+func (s *SharedChannelSyncSuppression) IsValid() *AppError {
+	if !IsValidId(s.RemoteId) {
+		return NewAppError("SharedChannelSyncSuppression.IsValid", "model.channel.is_valid.id.app_error", nil, "RemoteId="+s.RemoteId, http.StatusBadRequest)
+	}
+
+	if !IsValidId(s.ItemId) {
+		return NewAppError("SharedChannelSyncSuppression.IsValid", "model.channel.is_valid.id.app_error", nil, "ItemId="+s.ItemId, http.StatusBadRequest)
+	}
+
+	if !IsValidId(s.ChannelId) {
+		return NewAppError("SharedChannelSyncSuppression.IsValid", "model.channel.is_valid.id.app_error", nil, "ChannelId="+s.ChannelId, http.StatusBadRequest)
+	}
+
+	if s.ItemType != SharedChannelSyncItemPost && s.ItemType != SharedChannelSyncItemFile {
+		return NewAppError("SharedChannelSyncSuppression.IsValid", "model.shared_channel_sync_suppression.item_type.app_error", nil, "ItemType="+s.ItemType, http.StatusBadRequest)
+	}
+
+	if s.CreateAt == 0 {
+		return NewAppError("SharedChannelSyncSuppression.IsValid", "model.channel.is_valid.create_at.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
+
+type SharedChannelSyncSuppressionFilterOpts struct {
+	ChannelId string
+	RemoteId  string
+	ItemIds   []string
+}
+
 type SharedChannelRemoteStatus struct {
 	ChannelId        string `json:"channel_id"`
 	DisplayName      string `json:"display_name"`
@@ -0,0 +1,78 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+)
+
+// SharedChannelSyncErrorMaxLength is the maximum length of the last sync error stored
+// for a shared channel.
+const SharedChannelSyncErrorMaxLength = 1024
+
+// SharedChannelSyncHealth reports the synchronization state of a channel shared
+// with a remote cluster.
+type SharedChannelSyncHealth struct {
+	ChannelId        string `json:"channel_id"`
+	LastPostCreateAt int64  `json:"last_post_create_at"`
+	LastPostCreateID string `json:"last_post_create_id"`
+	LastPostUpdateAt int64  `json:"last_post_update_at"`
+	LastPostUpdateID string `json:"last_post_update_id"`
+	LagPosts         int64  `json:"lag_posts"`  // number of posts not yet synchronized
+	LagMillis        int64  `json:"lag_millis"` // age of the oldest post not yet synchronized
+	PendingTasks     int    `json:"pending_tasks"`
+	RetryCount       int64  `json:"retry_count"`
+	LastSyncAt       int64  `json:"last_sync_at"`
+	LastError        string `json:"last_error,omitempty"`
+	LastErrorAt      int64  `json:"last_error_at,omitempty"`
+}
+
+// RemoteClusterSyncHealth reports the synchronization state of all the channels
+// shared with a remote cluster. Retries and errors are stored, and reported by every
+// node. Pending tasks are queued in memory by the cluster leader, which runs the sync,
+// so they're only reported by the leader.
+type RemoteClusterSyncHealth struct {
+	RemoteId     string                     `json:"remote_id"`
+	DisplayName  string                     `json:"display_name"`
+	LastPingAt   int64                      `json:"last_ping_at"`
+	Online       bool                       `json:"online"`
+	LagPosts     int64                      `json:"lag_posts"`
+	LagMillis    int64                      `json:"lag_millis"`
+	PendingTasks int                        `json:"pending_tasks"`
+	RetryCount   int64                      `json:"retry_count"`
+	LastError    string                     `json:"last_error,omitempty"`
+	LastErrorAt  int64                      `json:"last_error_at,omitempty"`
+	Channels     []*SharedChannelSyncHealth `json:"channels"`
+}
+
+// AddChannel adds the health of a shared channel to the remote's totals.
+func (h *RemoteClusterSyncHealth) AddChannel(ch *SharedChannelSyncHealth) {
+	h.Channels = append(h.Channels, ch)
+	h.LagPosts += ch.LagPosts
+	h.LagMillis = max(h.LagMillis, ch.LagMillis)
+	h.PendingTasks += ch.PendingTasks
+	h.RetryCount += ch.RetryCount
+	if ch.LastErrorAt > h.LastErrorAt {
+		h.LastError = ch.LastError
+		h.LastErrorAt = ch.LastErrorAt
+	}
+}
+
+// SharedChannelResyncRequest rewinds the sync cursor of the channels shared with a
+// remote so every post created or updated since the given time is sent again.
+type SharedChannelResyncRequest struct {
+	ChannelId string `json:"channel_id,omitempty"` // empty means all channels shared with the remote
+	Since     int64  `json:"since"`
+}
+
+func (r *SharedChannelResyncRequest) IsValid() *AppError {
+	if r.ChannelId != "" && !IsValidId(r.ChannelId) {
+		return NewAppError("SharedChannelResyncRequest.IsValid", "model.channel.is_valid.id.app_error", nil, "ChannelId="+r.ChannelId, http.StatusBadRequest)
+	}
+
+	if r.Since <= 0 || r.Since > GetMillis() {
+		return NewAppError("SharedChannelResyncRequest.IsValid", "model.shared_channel_resync_request.since.app_error", nil, "", http.StatusBadRequest)
+	}
+	return nil
+}
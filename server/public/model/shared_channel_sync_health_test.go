@@ -0,0 +1,51 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteClusterSyncHealthAddChannel(t *testing.T) {
+	health := &RemoteClusterSyncHealth{RemoteId: NewId()}
+
+	health.AddChannel(&SharedChannelSyncHealth{ChannelId: NewId(), LagPosts: 2, LagMillis: 500, PendingTasks: 1, RetryCount: 3,
+		LastError: "old", LastErrorAt: 100})
+	health.AddChannel(&SharedChannelSyncHealth{ChannelId: NewId(), LagPosts: 5, LagMillis: 200, RetryCount: 1,
+		LastError: "new", LastErrorAt: 200})
+	health.AddChannel(&SharedChannelSyncHealth{ChannelId: NewId()})
+
+	assert.Len(t, health.Channels, 3)
+	assert.Equal(t, int64(7), health.LagPosts)
+	assert.Equal(t, int64(500), health.LagMillis)
+	assert.Equal(t, 1, health.PendingTasks)
+	assert.Equal(t, int64(4), health.RetryCount)
+	assert.Equal(t, "new", health.LastError)
+	assert.Equal(t, int64(200), health.LastErrorAt)
+}
+
+func TestSharedChannelResyncRequestIsValid(t *testing.T) {
+	data := []struct {
+		name  string
+		req   *SharedChannelResyncRequest
+		valid bool
+	}{
+		{name: "All channels", req: &SharedChannelResyncRequest{Since: GetMillis() - 1000}, valid: true},
+		{name: "Single channel", req: &SharedChannelResyncRequest{ChannelId: NewId(), Since: 1}, valid: true},
+		{name: "Invalid channel id", req: &SharedChannelResyncRequest{ChannelId: "foo", Since: 1}, valid: false},
+		{name: "Missing since", req: &SharedChannelResyncRequest{}, valid: false},
+		{name: "Since in the future", req: &SharedChannelResyncRequest{Since: GetMillis() + 60000}, valid: false},
+	}
+
+	for _, item := range data {
+		appErr := item.req.IsValid()
+		if item.valid {
+			assert.Nil(t, appErr, item.name)
+		} else {
+			assert.NotNil(t, appErr, item.name)
+		}
+	}
+}
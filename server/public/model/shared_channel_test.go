@@ -64,3 +64,65 @@ func TestSharedChannelPreUpdate(t *testing.T) {
 
 	require.GreaterOrEqual(t, o.UpdateAt, now)
 }
+
+func TestSharedChannelRemoteSyncFiltersIsValid(t *testing.T) {
+	data := []struct {
+		name    string
+		filters *SharedChannelRemoteSyncFilters
+		valid   bool
+	}{
+		{name: "Zero value", filters: &SharedChannelRemoteSyncFilters{}, valid: true},
+		{name: "Valid filters", filters: &SharedChannelRemoteSyncFilters{ExcludeBots: true, ExcludePostTypes: []string{PostTypeJoinChannel},
+			ExcludeUserIds: []string{NewId()}, ExcludeGroupIds: []string{NewId()}, MaxAttachmentSize: 1024}, valid: true},
+		{name: "Empty post type", filters: &SharedChannelRemoteSyncFilters{ExcludePostTypes: []string{""}}, valid: false},
+		{name: "Invalid user id", filters: &SharedChannelRemoteSyncFilters{ExcludeUserIds: []string{"foo"}}, valid: false},
+		{name: "Invalid group id", filters: &SharedChannelRemoteSyncFilters{ExcludeGroupIds: []string{"foo"}}, valid: false},
+		{name: "Negative attachment size", filters: &SharedChannelRemoteSyncFilters{MaxAttachmentSize: -1}, valid: false},
+		{name: "Too many users", filters: &SharedChannelRemoteSyncFilters{
+			ExcludeUserIds: make([]string, SharedChannelSyncFiltersMaxItems+1)}, valid: false},
+	}
+
+	for _, item := range data {
+		appErr := item.filters.IsValid()
+		if item.valid {
+			assert.Nil(t, appErr, item.name)
+		} else {
+			assert.NotNil(t, appErr, item.name)
+		}
+	}
+}
+
+func TestSharedChannelRemoteSyncFiltersValue(t *testing.T) {
+	t.Run("empty filters are stored as null", func(t *testing.T) {
+		value, err := SharedChannelRemoteSyncFilters{}.Value()
+		require.NoError(t, err)
+		assert.Nil(t, value)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		filters := SharedChannelRemoteSyncFilters{ExcludeBots: true, ExcludeUserIds: []string{NewId()}, MaxAttachmentSize: 10}
+		value, err := filters.Value()
+		require.NoError(t, err)
+
+		var scanned SharedChannelRemoteSyncFilters
+		require.NoError(t, scanned.Scan([]byte(value.(string))))
+		assert.Equal(t, filters, scanned)
+	})
+
+	t.Run("exclusions", func(t *testing.T) {
+		var filters *SharedChannelRemoteSyncFilters
+		assert.True(t, filters.IsEmpty())
+		assert.False(t, filters.ExcludesUser(NewId()))
+		assert.False(t, filters.ExcludesAttachmentSize(1<<40))
+
+		userID := NewId()
+		filters = &SharedChannelRemoteSyncFilters{ExcludeUserIds: []string{userID}, ExcludePostTypes: []string{PostTypeJoinChannel}, MaxAttachmentSize: 10}
+		assert.False(t, filters.IsEmpty())
+		assert.True(t, filters.ExcludesUser(userID))
+		assert.False(t, filters.ExcludesUser(NewId()))
+		assert.True(t, filters.ExcludesPostType(PostTypeJoinChannel))
+		assert.False(t, filters.ExcludesPostType(PostTypeDefault))
+		assert.True(t, filters.ExcludesAttachmentSize(11))
+		assert.False(t, filters.ExcludesAttachmentSize(10))
+	})
+}
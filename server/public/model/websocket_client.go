@@ -232,7 +232,7 @@ func (wsc *WebSocketClient) Listen() {
 		for {
 			// Reset buffer.
 			buf.Reset()
-			_, r, err := wsc.Conn.NextReader()
+			msgType, r, err := wsc.Conn.NextReader()
 			if err != nil {
 				if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseNoStatusReceived) {
 					wsc.ListenError = NewAppError("NewWebSocketClient", "model.websocket_client.connect_fail.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -249,6 +249,18 @@ func (wsc *WebSocketClient) Listen() {
 				return
 			}
 
+			// Binary frames are sent when the msgpack encoding was requested
+			// on connect, and are converted back to JSON.
+			if msgType == websocket.BinaryMessage {
+				data, msgpackErr := msgpackToJSON(bytes.NewReader(buf.Bytes()))
+				if msgpackErr != nil {
+					mlog.Warn("Failed to decode from msgpack", mlog.Err(msgpackErr))
+					continue
+				}
+				buf.Reset()
+				buf.Write(data)
+			}
+
 			event, jsonErr := WebSocketEventFromJSON(bytes.NewReader(buf.Bytes()))
 			if jsonErr != nil {
 				mlog.Warn("Failed to decode from JSON", mlog.Err(jsonErr))
@@ -326,6 +338,16 @@ func (wsc *WebSocketClient) GetStatusesByIds(userIds []string) {
 	wsc.SendMessage("get_statuses_by_ids", data)
 }
 
+// Subscribe restricts the events sent to the connection to the given event
+// types and channels. An empty list lifts the corresponding restriction.
+func (wsc *WebSocketClient) Subscribe(eventTypes []WebsocketEventType, channelIDs []string) {
+	data := map[string]any{
+		"event_types": eventTypes,
+		"channel_ids": channelIDs,
+	}
+	wsc.SendMessage("subscribe", data)
+}
+
 // UpdateActiveChannel sets the current channel that the user is viewing.
 func (wsc *WebSocketClient) UpdateActiveChannel(channelID string) {
 	data := map[string]any{
@@ -4,12 +4,19 @@
 package model
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"maps"
 	"strconv"
+	"sync"
+
+	"github.com/tinylib/msgp/msgp"
+	"github.com/vmihailenco/msgpack/v5"
 )
 
+//msgp:ignore WebsocketEventType ActiveQueueItem WSQueues precomputedWebSocketEventJSON webSocketEventJSON precomputedWebSocketEventMsgpack WebSocketEvent WebSocketResponse
+
 type WebsocketEventType string
 
 const (
@@ -101,6 +108,11 @@ const (
 
 	WebSocketMsgTypeResponse = "response"
 	WebSocketMsgTypeEvent    = "event"
+
+	// The encodings of the messages sent by the server, chosen through
+	// the encoding query parameter on connect.
+	WebSocketEncodingJSON    = "json"
+	WebSocketEncodingMsgpack = "msgpack"
 )
 
 type ActiveQueueItem struct {
@@ -121,28 +133,28 @@ type WebSocketMessage interface {
 }
 
 type WebsocketBroadcast struct {
-	OmitUsers             map[string]bool `json:"omit_users"`                        // broadcast is omitted for users listed here
-	UserId                string          `json:"user_id"`                           // broadcast only occurs for this user
-	ChannelId             string          `json:"channel_id"`                        // broadcast only occurs for users in this channel
-	TeamId                string          `json:"team_id"`                           // broadcast only occurs for users in this team
-	ConnectionId          string          `json:"connection_id"`                     // broadcast only occurs for this connection
-	OmitConnectionId      string          `json:"omit_connection_id"`                // broadcast is omitted for this connection
-	ContainsSanitizedData bool            `json:"contains_sanitized_data,omitempty"` // broadcast only occurs for non-sysadmins
-	ContainsSensitiveData bool            `json:"contains_sensitive_data,omitempty"` // broadcast only occurs for sysadmins
+	OmitUsers             map[string]bool `json:"omit_users" msg:"omit_users"`                                             // broadcast is omitted for users listed here
+	UserId                string          `json:"user_id" msg:"user_id"`                                                   // broadcast only occurs for this user
+	ChannelId             string          `json:"channel_id" msg:"channel_id"`                                             // broadcast only occurs for users in this channel
+	TeamId                string          `json:"team_id" msg:"team_id"`                                                   // broadcast only occurs for users in this team
+	ConnectionId          string          `json:"connection_id" msg:"connection_id"`                                       // broadcast only occurs for this connection
+	OmitConnectionId      string          `json:"omit_connection_id" msg:"omit_connection_id"`                             // broadcast is omitted for this connection
+	ContainsSanitizedData bool            `json:"contains_sanitized_data,omitempty" msg:"contains_sanitized_data,omitempty"` // broadcast only occurs for non-sysadmins
+	ContainsSensitiveData bool            `json:"contains_sensitive_data,omitempty" msg:"contains_sensitive_data,omitempty"` // broadcast only occurs for sysadmins
 	// ReliableClusterSend indicates whether or not the message should
 	// be sent through the cluster using the reliable, TCP backed channel.
-	ReliableClusterSend bool `json:"-"`
+	ReliableClusterSend bool `json:"-" msg:"-"`
 
 	// BroadcastHooks is a slice of hooks IDs used to process events before sending them on individual connections. The
 	// IDs should be understood by the WebSocket code.
 	//
 	// This field should never be sent to the client.
-	BroadcastHooks []string `json:"broadcast_hooks,omitempty"`
+	BroadcastHooks []string `json:"broadcast_hooks,omitempty" msg:"broadcast_hooks,omitempty"`
 	// BroadcastHookArgs is a slice of named arguments for each hook invocation. The index of each entry corresponds to
 	// the index of a hook ID in BroadcastHooks
 	//
 	// This field should never be sent to the client.
-	BroadcastHookArgs []map[string]any `json:"broadcast_hook_args,omitempty"`
+	BroadcastHookArgs []map[string]any `json:"broadcast_hook_args,omitempty" msg:"broadcast_hook_args,omitempty"`
 }
 
 func (wb *WebsocketBroadcast) copy() *WebsocketBroadcast {
@@ -211,16 +223,47 @@ type webSocketEventJSON struct {
 	Sequence  int64               `json:"seq"`
 }
 
+// precomputedWebSocketEventMsgpack holds the msgpack encoding of the data
+// and the broadcast of a precomputed event. It's computed on first use,
+// since most connections use JSON, and shared by all the copies of the event.
+type precomputedWebSocketEventMsgpack struct {
+	once      sync.Once
+	data      msgp.Raw
+	broadcast msgp.Raw
+	err       error
+}
+
+// webSocketEventMsgp mirrors webSocketEventJSON for the generated msgpack
+// serializer, with the data and the broadcast already encoded.
+type webSocketEventMsgp struct {
+	Event     string   `msg:"event"`
+	Data      msgp.Raw `msg:"data"`
+	Broadcast msgp.Raw `msg:"broadcast"`
+	Sequence  int64    `msg:"seq"`
+}
+
+// webSocketResponseMsgp mirrors WebSocketResponse for the generated msgpack
+// serializer, with the data and the error already encoded. They're
+// pointers to be omitted when empty, like in the JSON encoding.
+type webSocketResponseMsgp struct {
+	Status   string    `msg:"status"`
+	SeqReply int64     `msg:"seq_reply,omitempty"`
+	Data     *msgp.Raw `msg:"data,omitempty"`
+	Error    *msgp.Raw `msg:"error,omitempty"`
+}
+
 type WebSocketEvent struct {
-	event           WebsocketEventType
-	data            map[string]any
-	broadcast       *WebsocketBroadcast
-	sequence        int64
-	precomputedJSON *precomputedWebSocketEventJSON
+	event              WebsocketEventType
+	data               map[string]any
+	broadcast          *WebsocketBroadcast
+	sequence           int64
+	precomputedJSON    *precomputedWebSocketEventJSON
+	precomputedMsgpack *precomputedWebSocketEventMsgpack
 }
 
 // PrecomputeJSON precomputes and stores the serialized JSON for all fields other than Sequence.
 // This makes ToJSON much more efficient when sending the same event to multiple connections.
+// The msgpack encoding used by ToMsgpack is computed the first time it's needed.
 func (ev *WebSocketEvent) PrecomputeJSON() *WebSocketEvent {
 	evCopy := ev.Copy()
 	event, _ := json.Marshal(evCopy.event)
@@ -231,12 +274,23 @@ func (ev *WebSocketEvent) PrecomputeJSON() *WebSocketEvent {
 		Data:      json.RawMessage(data),
 		Broadcast: json.RawMessage(broadcast),
 	}
+	evCopy.precomputedMsgpack = &precomputedWebSocketEventMsgpack{}
 	return evCopy
 }
 
+// PrecomputedJSONSize returns the size of the precomputed JSON of the event,
+// without the sequence number, or 0 if it wasn't precomputed.
+func (ev *WebSocketEvent) PrecomputedJSONSize() int {
+	if ev.precomputedJSON == nil {
+		return 0
+	}
+	return len(ev.precomputedJSON.Event) + len(ev.precomputedJSON.Data) + len(ev.precomputedJSON.Broadcast)
+}
+
 func (ev *WebSocketEvent) RemovePrecomputedJSON() *WebSocketEvent {
 	evCopy := ev.DeepCopy()
 	evCopy.precomputedJSON = nil
+	evCopy.precomputedMsgpack = nil
 	return evCopy
 }
 
@@ -279,11 +333,12 @@ func NewWebSocketEvent(event WebsocketEventType, teamId, channelId, userId strin
 
 func (ev *WebSocketEvent) Copy() *WebSocketEvent {
 	evCopy := &WebSocketEvent{
-		event:           ev.event,
-		data:            ev.data,
-		broadcast:       ev.broadcast,
-		sequence:        ev.sequence,
-		precomputedJSON: ev.precomputedJSON,
+		event:              ev.event,
+		data:               ev.data,
+		broadcast:          ev.broadcast,
+		sequence:           ev.sequence,
+		precomputedJSON:    ev.precomputedJSON,
+		precomputedMsgpack: ev.precomputedMsgpack,
 	}
 	return evCopy
 }
@@ -296,6 +351,9 @@ func (ev *WebSocketEvent) DeepCopy() *WebSocketEvent {
 		sequence:        ev.sequence,
 		precomputedJSON: ev.precomputedJSON.copy(),
 	}
+	if evCopy.precomputedJSON != nil {
+		evCopy.precomputedMsgpack = &precomputedWebSocketEventMsgpack{}
+	}
 	return evCopy
 }
 
@@ -384,6 +442,50 @@ func (ev *WebSocketEvent) precomputedJSONBuf() []byte {
 		`}`)
 }
 
+// ToMsgpack returns the msgpack encoding of the event, which holds the
+// same values as its JSON encoding.
+func (ev *WebSocketEvent) ToMsgpack() ([]byte, error) {
+	return ev.MarshalMsg(nil)
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (ev *WebSocketEvent) MarshalMsg(b []byte) ([]byte, error) {
+	o := webSocketEventMsgp{
+		Event:    string(ev.event),
+		Sequence: ev.sequence,
+	}
+
+	if p := ev.precomputedMsgpack; p != nil {
+		p.once.Do(func() {
+			p.data, p.broadcast, p.err = ev.marshalMsgFields()
+		})
+		if p.err != nil {
+			return b, p.err
+		}
+		o.Data, o.Broadcast = p.data, p.broadcast
+	} else {
+		var err error
+		if o.Data, o.Broadcast, err = ev.marshalMsgFields(); err != nil {
+			return b, err
+		}
+	}
+
+	return o.MarshalMsg(b)
+}
+
+func (ev *WebSocketEvent) marshalMsgFields() (data, broadcast msgp.Raw, err error) {
+	if data, err = appendWebSocketValue(nil, ev.data); err != nil {
+		return nil, nil, err
+	}
+	if ev.broadcast == nil {
+		return data, msgp.AppendNil(nil), nil
+	}
+	if broadcast, err = ev.broadcast.MarshalMsg(nil); err != nil {
+		return nil, nil, err
+	}
+	return data, broadcast, nil
+}
+
 func WebSocketEventFromJSON(data io.Reader) (*WebSocketEvent, error) {
 	var ev WebSocketEvent
 	var o webSocketEventJSON
@@ -445,7 +547,145 @@ func (m *WebSocketResponse) ToJSON() ([]byte, error) {
 	return json.Marshal(m)
 }
 
+// ToMsgpack returns the msgpack encoding of the response, which holds the
+// same values as its JSON encoding.
+func (m *WebSocketResponse) ToMsgpack() ([]byte, error) {
+	return m.MarshalMsg(nil)
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (m *WebSocketResponse) MarshalMsg(b []byte) ([]byte, error) {
+	o := webSocketResponseMsgp{
+		Status:   m.Status,
+		SeqReply: m.SeqReply,
+	}
+
+	if len(m.Data) > 0 {
+		data, err := appendWebSocketValue(nil, m.Data)
+		if err != nil {
+			return b, err
+		}
+		o.Data = (*msgp.Raw)(&data)
+	}
+	if m.Error != nil {
+		appErr, err := appendWebSocketValue(nil, m.Error)
+		if err != nil {
+			return b, err
+		}
+		o.Error = (*msgp.Raw)(&appErr)
+	}
+
+	return o.MarshalMsg(b)
+}
+
 func WebSocketResponseFromJSON(data io.Reader) (*WebSocketResponse, error) {
 	var o *WebSocketResponse
 	return o, json.NewDecoder(data).Decode(&o)
 }
+
+// WebSocketEventFromMsgpack decodes an event encoded with ToMsgpack.
+func WebSocketEventFromMsgpack(data io.Reader) (*WebSocketEvent, error) {
+	buf, err := msgpackToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return WebSocketEventFromJSON(bytes.NewReader(buf))
+}
+
+// WebSocketResponseFromMsgpack decodes a response encoded with ToMsgpack.
+func WebSocketResponseFromMsgpack(data io.Reader) (*WebSocketResponse, error) {
+	buf, err := msgpackToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return WebSocketResponseFromJSON(bytes.NewReader(buf))
+}
+
+// appendWebSocketValue appends the msgpack encoding of a value of the data
+// of an event or a response. The basic types, and the maps and slices of
+// them, are encoded as they are. Any other value is encoded the same as its
+// JSON encoding, since the clients rely on its JSON tags and marshalers.
+func appendWebSocketValue(b []byte, v any) ([]byte, error) {
+	var err error
+	switch t := v.(type) {
+	case nil:
+		return msgp.AppendNil(b), nil
+	case string:
+		return msgp.AppendString(b, t), nil
+	case bool:
+		return msgp.AppendBool(b, t), nil
+	case int:
+		return msgp.AppendInt(b, t), nil
+	case int32:
+		return msgp.AppendInt32(b, t), nil
+	case int64:
+		return msgp.AppendInt64(b, t), nil
+	case uint32:
+		return msgp.AppendUint32(b, t), nil
+	case uint64:
+		return msgp.AppendUint64(b, t), nil
+	case float32:
+		return msgp.AppendFloat32(b, t), nil
+	case float64:
+		return msgp.AppendFloat64(b, t), nil
+	case json.Number:
+		return msgp.AppendJSONNumber(b, t)
+	case map[string]any:
+		if t == nil {
+			return msgp.AppendNil(b), nil
+		}
+		b = msgp.AppendMapHeader(b, uint32(len(t)))
+		for k, e := range t {
+			b = msgp.AppendString(b, k)
+			if b, err = appendWebSocketValue(b, e); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	case map[string]string:
+		if t == nil {
+			return msgp.AppendNil(b), nil
+		}
+		return msgp.AppendMapStrStr(b, t), nil
+	case []any:
+		if t == nil {
+			return msgp.AppendNil(b), nil
+		}
+		b = msgp.AppendArrayHeader(b, uint32(len(t)))
+		for _, e := range t {
+			if b, err = appendWebSocketValue(b, e); err != nil {
+				return b, err
+			}
+		}
+		return b, nil
+	case []string:
+		if t == nil {
+			return msgp.AppendNil(b), nil
+		}
+		b = msgp.AppendArrayHeader(b, uint32(len(t)))
+		for _, e := range t {
+			b = msgp.AppendString(b, e)
+		}
+		return b, nil
+	}
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return b, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return b, err
+	}
+	return appendWebSocketValue(b, decoded)
+}
+
+func msgpackToJSON(data io.Reader) ([]byte, error) {
+	var v any
+	if err := msgpack.NewDecoder(data).Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
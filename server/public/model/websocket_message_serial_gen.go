@@ -0,0 +1,1139 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// DecodeMsg implements msgp.Decodable
+func (z *WebsocketBroadcast) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "omit_users":
+			var zb0002 uint32
+			zb0002, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "OmitUsers")
+				return
+			}
+			if z.OmitUsers == nil {
+				z.OmitUsers = make(map[string]bool, zb0002)
+			} else if len(z.OmitUsers) > 0 {
+				for key := range z.OmitUsers {
+					delete(z.OmitUsers, key)
+				}
+			}
+			for zb0002 > 0 {
+				zb0002--
+				var za0001 string
+				var za0002 bool
+				za0001, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "OmitUsers")
+					return
+				}
+				za0002, err = dc.ReadBool()
+				if err != nil {
+					err = msgp.WrapError(err, "OmitUsers", za0001)
+					return
+				}
+				z.OmitUsers[za0001] = za0002
+			}
+		case "user_id":
+			z.UserId, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "UserId")
+				return
+			}
+		case "channel_id":
+			z.ChannelId, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ChannelId")
+				return
+			}
+		case "team_id":
+			z.TeamId, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "TeamId")
+				return
+			}
+		case "connection_id":
+			z.ConnectionId, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ConnectionId")
+				return
+			}
+		case "omit_connection_id":
+			z.OmitConnectionId, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "OmitConnectionId")
+				return
+			}
+		case "contains_sanitized_data":
+			z.ContainsSanitizedData, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "ContainsSanitizedData")
+				return
+			}
+		case "contains_sensitive_data":
+			z.ContainsSensitiveData, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "ContainsSensitiveData")
+				return
+			}
+		case "broadcast_hooks":
+			var zb0003 uint32
+			zb0003, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "BroadcastHooks")
+				return
+			}
+			if cap(z.BroadcastHooks) >= int(zb0003) {
+				z.BroadcastHooks = (z.BroadcastHooks)[:zb0003]
+			} else {
+				z.BroadcastHooks = make([]string, zb0003)
+			}
+			for za0003 := range z.BroadcastHooks {
+				z.BroadcastHooks[za0003], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "BroadcastHooks", za0003)
+					return
+				}
+			}
+		case "broadcast_hook_args":
+			var zb0004 uint32
+			zb0004, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "BroadcastHookArgs")
+				return
+			}
+			if cap(z.BroadcastHookArgs) >= int(zb0004) {
+				z.BroadcastHookArgs = (z.BroadcastHookArgs)[:zb0004]
+			} else {
+				z.BroadcastHookArgs = make([]map[string]interface{}, zb0004)
+			}
+			for za0004 := range z.BroadcastHookArgs {
+				var zb0005 uint32
+				zb0005, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "BroadcastHookArgs", za0004)
+					return
+				}
+				if z.BroadcastHookArgs[za0004] == nil {
+					z.BroadcastHookArgs[za0004] = make(map[string]interface{}, zb0005)
+				} else if len(z.BroadcastHookArgs[za0004]) > 0 {
+					for key := range z.BroadcastHookArgs[za0004] {
+						delete(z.BroadcastHookArgs[za0004], key)
+					}
+				}
+				for zb0005 > 0 {
+					zb0005--
+					var za0005 string
+					var za0006 interface{}
+					za0005, err = dc.ReadString()
+					if err != nil {
+						err = msgp.WrapError(err, "BroadcastHookArgs", za0004)
+						return
+					}
+					za0006, err = dc.ReadIntf()
+					if err != nil {
+						err = msgp.WrapError(err, "BroadcastHookArgs", za0004, za0005)
+						return
+					}
+					z.BroadcastHookArgs[za0004][za0005] = za0006
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *WebsocketBroadcast) EncodeMsg(en *msgp.Writer) (err error) {
+	// check for omitted fields
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
+	_ = zb0001Mask
+	if z.ContainsSanitizedData == false {
+		zb0001Len--
+		zb0001Mask |= 0x40
+	}
+	if z.ContainsSensitiveData == false {
+		zb0001Len--
+		zb0001Mask |= 0x80
+	}
+	if z.BroadcastHooks == nil {
+		zb0001Len--
+		zb0001Mask |= 0x100
+	}
+	if z.BroadcastHookArgs == nil {
+		zb0001Len--
+		zb0001Mask |= 0x200
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
+	if err != nil {
+		return
+	}
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// write "omit_users"
+		err = en.Append(0xaa, 0x6f, 0x6d, 0x69, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteMapHeader(uint32(len(z.OmitUsers)))
+		if err != nil {
+			err = msgp.WrapError(err, "OmitUsers")
+			return
+		}
+		for za0001, za0002 := range z.OmitUsers {
+			err = en.WriteString(za0001)
+			if err != nil {
+				err = msgp.WrapError(err, "OmitUsers")
+				return
+			}
+			err = en.WriteBool(za0002)
+			if err != nil {
+				err = msgp.WrapError(err, "OmitUsers", za0001)
+				return
+			}
+		}
+		// write "user_id"
+		err = en.Append(0xa7, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.UserId)
+		if err != nil {
+			err = msgp.WrapError(err, "UserId")
+			return
+		}
+		// write "channel_id"
+		err = en.Append(0xaa, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.ChannelId)
+		if err != nil {
+			err = msgp.WrapError(err, "ChannelId")
+			return
+		}
+		// write "team_id"
+		err = en.Append(0xa7, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.TeamId)
+		if err != nil {
+			err = msgp.WrapError(err, "TeamId")
+			return
+		}
+		// write "connection_id"
+		err = en.Append(0xad, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.ConnectionId)
+		if err != nil {
+			err = msgp.WrapError(err, "ConnectionId")
+			return
+		}
+		// write "omit_connection_id"
+		err = en.Append(0xb2, 0x6f, 0x6d, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.OmitConnectionId)
+		if err != nil {
+			err = msgp.WrapError(err, "OmitConnectionId")
+			return
+		}
+		if (zb0001Mask & 0x40) == 0 { // if not omitted
+			// write "contains_sanitized_data"
+			err = en.Append(0xb7, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x5f, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61)
+			if err != nil {
+				return
+			}
+			err = en.WriteBool(z.ContainsSanitizedData)
+			if err != nil {
+				err = msgp.WrapError(err, "ContainsSanitizedData")
+				return
+			}
+		}
+		if (zb0001Mask & 0x80) == 0 { // if not omitted
+			// write "contains_sensitive_data"
+			err = en.Append(0xb7, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61)
+			if err != nil {
+				return
+			}
+			err = en.WriteBool(z.ContainsSensitiveData)
+			if err != nil {
+				err = msgp.WrapError(err, "ContainsSensitiveData")
+				return
+			}
+		}
+		if (zb0001Mask & 0x100) == 0 { // if not omitted
+			// write "broadcast_hooks"
+			err = en.Append(0xaf, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x73)
+			if err != nil {
+				return
+			}
+			err = en.WriteArrayHeader(uint32(len(z.BroadcastHooks)))
+			if err != nil {
+				err = msgp.WrapError(err, "BroadcastHooks")
+				return
+			}
+			for za0003 := range z.BroadcastHooks {
+				err = en.WriteString(z.BroadcastHooks[za0003])
+				if err != nil {
+					err = msgp.WrapError(err, "BroadcastHooks", za0003)
+					return
+				}
+			}
+		}
+		if (zb0001Mask & 0x200) == 0 { // if not omitted
+			// write "broadcast_hook_args"
+			err = en.Append(0xb3, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x61, 0x72, 0x67, 0x73)
+			if err != nil {
+				return
+			}
+			err = en.WriteArrayHeader(uint32(len(z.BroadcastHookArgs)))
+			if err != nil {
+				err = msgp.WrapError(err, "BroadcastHookArgs")
+				return
+			}
+			for za0004 := range z.BroadcastHookArgs {
+				err = en.WriteMapHeader(uint32(len(z.BroadcastHookArgs[za0004])))
+				if err != nil {
+					err = msgp.WrapError(err, "BroadcastHookArgs", za0004)
+					return
+				}
+				for za0005, za0006 := range z.BroadcastHookArgs[za0004] {
+					err = en.WriteString(za0005)
+					if err != nil {
+						err = msgp.WrapError(err, "BroadcastHookArgs", za0004)
+						return
+					}
+					err = en.WriteIntf(za0006)
+					if err != nil {
+						err = msgp.WrapError(err, "BroadcastHookArgs", za0004, za0005)
+						return
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *WebsocketBroadcast) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// check for omitted fields
+	zb0001Len := uint32(10)
+	var zb0001Mask uint16 /* 10 bits */
+	_ = zb0001Mask
+	if z.ContainsSanitizedData == false {
+		zb0001Len--
+		zb0001Mask |= 0x40
+	}
+	if z.ContainsSensitiveData == false {
+		zb0001Len--
+		zb0001Mask |= 0x80
+	}
+	if z.BroadcastHooks == nil {
+		zb0001Len--
+		zb0001Mask |= 0x100
+	}
+	if z.BroadcastHookArgs == nil {
+		zb0001Len--
+		zb0001Mask |= 0x200
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// string "omit_users"
+		o = append(o, 0xaa, 0x6f, 0x6d, 0x69, 0x74, 0x5f, 0x75, 0x73, 0x65, 0x72, 0x73)
+		o = msgp.AppendMapHeader(o, uint32(len(z.OmitUsers)))
+		for za0001, za0002 := range z.OmitUsers {
+			o = msgp.AppendString(o, za0001)
+			o = msgp.AppendBool(o, za0002)
+		}
+		// string "user_id"
+		o = append(o, 0xa7, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64)
+		o = msgp.AppendString(o, z.UserId)
+		// string "channel_id"
+		o = append(o, 0xaa, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x69, 0x64)
+		o = msgp.AppendString(o, z.ChannelId)
+		// string "team_id"
+		o = append(o, 0xa7, 0x74, 0x65, 0x61, 0x6d, 0x5f, 0x69, 0x64)
+		o = msgp.AppendString(o, z.TeamId)
+		// string "connection_id"
+		o = append(o, 0xad, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64)
+		o = msgp.AppendString(o, z.ConnectionId)
+		// string "omit_connection_id"
+		o = append(o, 0xb2, 0x6f, 0x6d, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64)
+		o = msgp.AppendString(o, z.OmitConnectionId)
+		if (zb0001Mask & 0x40) == 0 { // if not omitted
+			// string "contains_sanitized_data"
+			o = append(o, 0xb7, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x5f, 0x73, 0x61, 0x6e, 0x69, 0x74, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x64, 0x61, 0x74, 0x61)
+			o = msgp.AppendBool(o, z.ContainsSanitizedData)
+		}
+		if (zb0001Mask & 0x80) == 0 { // if not omitted
+			// string "contains_sensitive_data"
+			o = append(o, 0xb7, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x61, 0x74, 0x61)
+			o = msgp.AppendBool(o, z.ContainsSensitiveData)
+		}
+		if (zb0001Mask & 0x100) == 0 { // if not omitted
+			// string "broadcast_hooks"
+			o = append(o, 0xaf, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x73)
+			o = msgp.AppendArrayHeader(o, uint32(len(z.BroadcastHooks)))
+			for za0003 := range z.BroadcastHooks {
+				o = msgp.AppendString(o, z.BroadcastHooks[za0003])
+			}
+		}
+		if (zb0001Mask & 0x200) == 0 { // if not omitted
+			// string "broadcast_hook_args"
+			o = append(o, 0xb3, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x5f, 0x68, 0x6f, 0x6f, 0x6b, 0x5f, 0x61, 0x72, 0x67, 0x73)
+			o = msgp.AppendArrayHeader(o, uint32(len(z.BroadcastHookArgs)))
+			for za0004 := range z.BroadcastHookArgs {
+				o = msgp.AppendMapHeader(o, uint32(len(z.BroadcastHookArgs[za0004])))
+				for za0005, za0006 := range z.BroadcastHookArgs[za0004] {
+					o = msgp.AppendString(o, za0005)
+					o, err = msgp.AppendIntf(o, za0006)
+					if err != nil {
+						err = msgp.WrapError(err, "BroadcastHookArgs", za0004, za0005)
+						return
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *WebsocketBroadcast) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "omit_users":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "OmitUsers")
+				return
+			}
+			if z.OmitUsers == nil {
+				z.OmitUsers = make(map[string]bool, zb0002)
+			} else if len(z.OmitUsers) > 0 {
+				for key := range z.OmitUsers {
+					delete(z.OmitUsers, key)
+				}
+			}
+			for zb0002 > 0 {
+				var za0001 string
+				var za0002 bool
+				zb0002--
+				za0001, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "OmitUsers")
+					return
+				}
+				za0002, bts, err = msgp.ReadBoolBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "OmitUsers", za0001)
+					return
+				}
+				z.OmitUsers[za0001] = za0002
+			}
+		case "user_id":
+			z.UserId, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "UserId")
+				return
+			}
+		case "channel_id":
+			z.ChannelId, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ChannelId")
+				return
+			}
+		case "team_id":
+			z.TeamId, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "TeamId")
+				return
+			}
+		case "connection_id":
+			z.ConnectionId, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ConnectionId")
+				return
+			}
+		case "omit_connection_id":
+			z.OmitConnectionId, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "OmitConnectionId")
+				return
+			}
+		case "contains_sanitized_data":
+			z.ContainsSanitizedData, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ContainsSanitizedData")
+				return
+			}
+		case "contains_sensitive_data":
+			z.ContainsSensitiveData, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ContainsSensitiveData")
+				return
+			}
+		case "broadcast_hooks":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BroadcastHooks")
+				return
+			}
+			if cap(z.BroadcastHooks) >= int(zb0003) {
+				z.BroadcastHooks = (z.BroadcastHooks)[:zb0003]
+			} else {
+				z.BroadcastHooks = make([]string, zb0003)
+			}
+			for za0003 := range z.BroadcastHooks {
+				z.BroadcastHooks[za0003], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "BroadcastHooks", za0003)
+					return
+				}
+			}
+		case "broadcast_hook_args":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "BroadcastHookArgs")
+				return
+			}
+			if cap(z.BroadcastHookArgs) >= int(zb0004) {
+				z.BroadcastHookArgs = (z.BroadcastHookArgs)[:zb0004]
+			} else {
+				z.BroadcastHookArgs = make([]map[string]interface{}, zb0004)
+			}
+			for za0004 := range z.BroadcastHookArgs {
+				var zb0005 uint32
+				zb0005, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "BroadcastHookArgs", za0004)
+					return
+				}
+				if z.BroadcastHookArgs[za0004] == nil {
+					z.BroadcastHookArgs[za0004] = make(map[string]interface{}, zb0005)
+				} else if len(z.BroadcastHookArgs[za0004]) > 0 {
+					for key := range z.BroadcastHookArgs[za0004] {
+						delete(z.BroadcastHookArgs[za0004], key)
+					}
+				}
+				for zb0005 > 0 {
+					var za0005 string
+					var za0006 interface{}
+					zb0005--
+					za0005, bts, err = msgp.ReadStringBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "BroadcastHookArgs", za0004)
+						return
+					}
+					za0006, bts, err = msgp.ReadIntfBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "BroadcastHookArgs", za0004, za0005)
+						return
+					}
+					z.BroadcastHookArgs[za0004][za0005] = za0006
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *WebsocketBroadcast) Msgsize() (s int) {
+	s = 1 + 11 + msgp.MapHeaderSize
+	if z.OmitUsers != nil {
+		for za0001, za0002 := range z.OmitUsers {
+			_ = za0002
+			s += msgp.StringPrefixSize + len(za0001) + msgp.BoolSize
+		}
+	}
+	s += 8 + msgp.StringPrefixSize + len(z.UserId) + 11 + msgp.StringPrefixSize + len(z.ChannelId) + 8 + msgp.StringPrefixSize + len(z.TeamId) + 14 + msgp.StringPrefixSize + len(z.ConnectionId) + 19 + msgp.StringPrefixSize + len(z.OmitConnectionId) + 24 + msgp.BoolSize + 24 + msgp.BoolSize + 16 + msgp.ArrayHeaderSize
+	for za0003 := range z.BroadcastHooks {
+		s += msgp.StringPrefixSize + len(z.BroadcastHooks[za0003])
+	}
+	s += 20 + msgp.ArrayHeaderSize
+	for za0004 := range z.BroadcastHookArgs {
+		s += msgp.MapHeaderSize
+		if z.BroadcastHookArgs[za0004] != nil {
+			for za0005, za0006 := range z.BroadcastHookArgs[za0004] {
+				_ = za0006
+				s += msgp.StringPrefixSize + len(za0005) + msgp.GuessSize(za0006)
+			}
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *webSocketEventMsgp) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "event":
+			z.Event, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Event")
+				return
+			}
+		case "data":
+			err = z.Data.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Data")
+				return
+			}
+		case "broadcast":
+			err = z.Broadcast.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Broadcast")
+				return
+			}
+		case "seq":
+			z.Sequence, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "Sequence")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *webSocketEventMsgp) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 4
+	// write "event"
+	err = en.Append(0x84, 0xa5, 0x65, 0x76, 0x65, 0x6e, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Event)
+	if err != nil {
+		err = msgp.WrapError(err, "Event")
+		return
+	}
+	// write "data"
+	err = en.Append(0xa4, 0x64, 0x61, 0x74, 0x61)
+	if err != nil {
+		return
+	}
+	err = z.Data.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Data")
+		return
+	}
+	// write "broadcast"
+	err = en.Append(0xa9, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74)
+	if err != nil {
+		return
+	}
+	err = z.Broadcast.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Broadcast")
+		return
+	}
+	// write "seq"
+	err = en.Append(0xa3, 0x73, 0x65, 0x71)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt64(z.Sequence)
+	if err != nil {
+		err = msgp.WrapError(err, "Sequence")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *webSocketEventMsgp) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "event"
+	o = append(o, 0x84, 0xa5, 0x65, 0x76, 0x65, 0x6e, 0x74)
+	o = msgp.AppendString(o, z.Event)
+	// string "data"
+	o = append(o, 0xa4, 0x64, 0x61, 0x74, 0x61)
+	o, err = z.Data.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Data")
+		return
+	}
+	// string "broadcast"
+	o = append(o, 0xa9, 0x62, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74)
+	o, err = z.Broadcast.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Broadcast")
+		return
+	}
+	// string "seq"
+	o = append(o, 0xa3, 0x73, 0x65, 0x71)
+	o = msgp.AppendInt64(o, z.Sequence)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *webSocketEventMsgp) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "event":
+			z.Event, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Event")
+				return
+			}
+		case "data":
+			bts, err = z.Data.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Data")
+				return
+			}
+		case "broadcast":
+			bts, err = z.Broadcast.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Broadcast")
+				return
+			}
+		case "seq":
+			z.Sequence, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Sequence")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *webSocketEventMsgp) Msgsize() (s int) {
+	s = 1 + 6 + msgp.StringPrefixSize + len(z.Event) + 5 + z.Data.Msgsize() + 10 + z.Broadcast.Msgsize() + 4 + msgp.Int64Size
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *webSocketResponseMsgp) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "status":
+			z.Status, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Status")
+				return
+			}
+		case "seq_reply":
+			z.SeqReply, err = dc.ReadInt64()
+			if err != nil {
+				err = msgp.WrapError(err, "SeqReply")
+				return
+			}
+		case "data":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Data")
+					return
+				}
+				z.Data = nil
+			} else {
+				if z.Data == nil {
+					z.Data = new(msgp.Raw)
+				}
+				err = z.Data.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Data")
+					return
+				}
+			}
+		case "error":
+			if dc.IsNil() {
+				err = dc.ReadNil()
+				if err != nil {
+					err = msgp.WrapError(err, "Error")
+					return
+				}
+				z.Error = nil
+			} else {
+				if z.Error == nil {
+					z.Error = new(msgp.Raw)
+				}
+				err = z.Error.DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Error")
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *webSocketResponseMsgp) EncodeMsg(en *msgp.Writer) (err error) {
+	// check for omitted fields
+	zb0001Len := uint32(4)
+	var zb0001Mask uint8 /* 4 bits */
+	_ = zb0001Mask
+	if z.SeqReply == 0 {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	if z.Data == nil {
+		zb0001Len--
+		zb0001Mask |= 0x4
+	}
+	if z.Error == nil {
+		zb0001Len--
+		zb0001Mask |= 0x8
+	}
+	// variable map header, size zb0001Len
+	err = en.Append(0x80 | uint8(zb0001Len))
+	if err != nil {
+		return
+	}
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// write "status"
+		err = en.Append(0xa6, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(z.Status)
+		if err != nil {
+			err = msgp.WrapError(err, "Status")
+			return
+		}
+		if (zb0001Mask & 0x2) == 0 { // if not omitted
+			// write "seq_reply"
+			err = en.Append(0xa9, 0x73, 0x65, 0x71, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x79)
+			if err != nil {
+				return
+			}
+			err = en.WriteInt64(z.SeqReply)
+			if err != nil {
+				err = msgp.WrapError(err, "SeqReply")
+				return
+			}
+		}
+		if (zb0001Mask & 0x4) == 0 { // if not omitted
+			// write "data"
+			err = en.Append(0xa4, 0x64, 0x61, 0x74, 0x61)
+			if err != nil {
+				return
+			}
+			if z.Data == nil {
+				err = en.WriteNil()
+				if err != nil {
+					return
+				}
+			} else {
+				err = z.Data.EncodeMsg(en)
+				if err != nil {
+					err = msgp.WrapError(err, "Data")
+					return
+				}
+			}
+		}
+		if (zb0001Mask & 0x8) == 0 { // if not omitted
+			// write "error"
+			err = en.Append(0xa5, 0x65, 0x72, 0x72, 0x6f, 0x72)
+			if err != nil {
+				return
+			}
+			if z.Error == nil {
+				err = en.WriteNil()
+				if err != nil {
+					return
+				}
+			} else {
+				err = z.Error.EncodeMsg(en)
+				if err != nil {
+					err = msgp.WrapError(err, "Error")
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *webSocketResponseMsgp) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// check for omitted fields
+	zb0001Len := uint32(4)
+	var zb0001Mask uint8 /* 4 bits */
+	_ = zb0001Mask
+	if z.SeqReply == 0 {
+		zb0001Len--
+		zb0001Mask |= 0x2
+	}
+	if z.Data == nil {
+		zb0001Len--
+		zb0001Mask |= 0x4
+	}
+	if z.Error == nil {
+		zb0001Len--
+		zb0001Mask |= 0x8
+	}
+	// variable map header, size zb0001Len
+	o = append(o, 0x80|uint8(zb0001Len))
+
+	// skip if no fields are to be emitted
+	if zb0001Len != 0 {
+		// string "status"
+		o = append(o, 0xa6, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73)
+		o = msgp.AppendString(o, z.Status)
+		if (zb0001Mask & 0x2) == 0 { // if not omitted
+			// string "seq_reply"
+			o = append(o, 0xa9, 0x73, 0x65, 0x71, 0x5f, 0x72, 0x65, 0x70, 0x6c, 0x79)
+			o = msgp.AppendInt64(o, z.SeqReply)
+		}
+		if (zb0001Mask & 0x4) == 0 { // if not omitted
+			// string "data"
+			o = append(o, 0xa4, 0x64, 0x61, 0x74, 0x61)
+			if z.Data == nil {
+				o = msgp.AppendNil(o)
+			} else {
+				o, err = z.Data.MarshalMsg(o)
+				if err != nil {
+					err = msgp.WrapError(err, "Data")
+					return
+				}
+			}
+		}
+		if (zb0001Mask & 0x8) == 0 { // if not omitted
+			// string "error"
+			o = append(o, 0xa5, 0x65, 0x72, 0x72, 0x6f, 0x72)
+			if z.Error == nil {
+				o = msgp.AppendNil(o)
+			} else {
+				o, err = z.Error.MarshalMsg(o)
+				if err != nil {
+					err = msgp.WrapError(err, "Error")
+					return
+				}
+			}
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *webSocketResponseMsgp) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "status":
+			z.Status, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Status")
+				return
+			}
+		case "seq_reply":
+			z.SeqReply, bts, err = msgp.ReadInt64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SeqReply")
+				return
+			}
+		case "data":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Data = nil
+			} else {
+				if z.Data == nil {
+					z.Data = new(msgp.Raw)
+				}
+				bts, err = z.Data.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Data")
+					return
+				}
+			}
+		case "error":
+			if msgp.IsNil(bts) {
+				bts, err = msgp.ReadNilBytes(bts)
+				if err != nil {
+					return
+				}
+				z.Error = nil
+			} else {
+				if z.Error == nil {
+					z.Error = new(msgp.Raw)
+				}
+				bts, err = z.Error.UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Error")
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *webSocketResponseMsgp) Msgsize() (s int) {
+	s = 1 + 7 + msgp.StringPrefixSize + len(z.Status) + 10 + msgp.Int64Size + 5
+	if z.Data == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Data.Msgsize()
+	}
+	s += 6
+	if z.Error == nil {
+		s += msgp.NilSize
+	} else {
+		s += z.Error.Msgsize()
+	}
+	return
+}
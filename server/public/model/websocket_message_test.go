@@ -7,10 +7,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"io"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
 )
 
 func TestWebSocketEvent(t *testing.T) {
@@ -125,6 +127,113 @@ func TestWebSocketEvent_PrecomputeJSON(t *testing.T) {
 	assert.Equal(t, before, after)
 }
 
+func TestWebSocketEvent_PrecomputedJSONSize(t *testing.T) {
+	event := NewWebSocketEvent(WebsocketEventPosted, "foo", "bar", "baz", nil, "")
+	assert.Zero(t, event.PrecomputedJSONSize())
+
+	event = event.PrecomputeJSON()
+	size := event.PrecomputedJSONSize()
+	data, err := event.ToJSON()
+	require.NoError(t, err)
+	assert.Positive(t, size)
+	assert.Less(t, size, len(data))
+}
+
+func TestWebSocketEvent_ToMsgpack(t *testing.T) {
+	newEvent := func() *WebSocketEvent {
+		event := NewWebSocketEvent(WebsocketEventPosted, NewId(), NewId(), NewId(), map[string]bool{NewId(): true}, "")
+		event.Add("post", `{"id":"abc","message":"hello"}`)
+		event.Add("count", 42)
+		event.Add("ratio", 0.5)
+		event.Add("mentions", []string{NewId(), NewId()})
+		event.Add("user", &User{Id: NewId(), Username: "user", CreateAt: GetMillis()})
+		return event.SetSequence(7)
+	}
+
+	// Both encodings are compared once decoded, since the numbers
+	// aren't decoded to the same types.
+	assertParity := func(t *testing.T, event *WebSocketEvent) {
+		t.Helper()
+		jsonBuf, err := event.ToJSON()
+		require.NoError(t, err)
+		msgpackBuf, err := event.ToMsgpack()
+		require.NoError(t, err)
+		assert.Less(t, len(msgpackBuf), len(jsonBuf))
+
+		converted, err := msgpackToJSON(bytes.NewReader(msgpackBuf))
+		require.NoError(t, err)
+		assert.JSONEq(t, string(jsonBuf), string(converted))
+
+		fromJSON, err := WebSocketEventFromJSON(bytes.NewReader(jsonBuf))
+		require.NoError(t, err)
+		fromMsgpack, err := WebSocketEventFromMsgpack(bytes.NewReader(msgpackBuf))
+		require.NoError(t, err)
+		assert.Equal(t, fromJSON, fromMsgpack)
+	}
+
+	t.Run("serialized", func(t *testing.T) {
+		assertParity(t, newEvent())
+	})
+
+	t.Run("precomputed", func(t *testing.T) {
+		event := newEvent().PrecomputeJSON()
+		assertParity(t, event)
+
+		// The precomputed encoding is shared by the copies of the
+		// event, which only differ by their sequence number.
+		copied := event.SetSequence(8)
+		assertParity(t, copied)
+		assert.Same(t, event.precomputedMsgpack, copied.precomputedMsgpack)
+
+		assert.Nil(t, event.RemovePrecomputedJSON().precomputedMsgpack)
+	})
+
+	t.Run("keeps the types of the values", func(t *testing.T) {
+		event := newEvent()
+		event.Add("timestamp", int64(1<<60+1))
+
+		msgpackBuf, err := event.ToMsgpack()
+		require.NoError(t, err)
+
+		decoded, _, err := msgp.ReadMapStrIntfBytes(msgpackBuf, nil)
+		require.NoError(t, err)
+		data := decoded["data"].(map[string]any)
+		assert.Equal(t, int64(1<<60+1), data["timestamp"])
+		assert.Equal(t, int64(42), data["count"])
+		assert.Equal(t, 0.5, data["ratio"])
+		assert.Equal(t, int64(7), decoded["seq"])
+	})
+
+	t.Run("response with an error", func(t *testing.T) {
+		resp := NewWebSocketError(4, NewAppError("test", "model.test.app_error", nil, "", http.StatusBadRequest))
+		jsonBuf, err := resp.ToJSON()
+		require.NoError(t, err)
+		msgpackBuf, err := resp.ToMsgpack()
+		require.NoError(t, err)
+
+		converted, err := msgpackToJSON(bytes.NewReader(msgpackBuf))
+		require.NoError(t, err)
+		assert.JSONEq(t, string(jsonBuf), string(converted))
+	})
+
+	t.Run("response", func(t *testing.T) {
+		resp := NewWebSocketResponse(StatusOk, 3, map[string]any{"status": "online", "count": 2})
+		jsonBuf, err := resp.ToJSON()
+		require.NoError(t, err)
+		msgpackBuf, err := resp.ToMsgpack()
+		require.NoError(t, err)
+
+		converted, err := msgpackToJSON(bytes.NewReader(msgpackBuf))
+		require.NoError(t, err)
+		assert.JSONEq(t, string(jsonBuf), string(converted))
+
+		fromMsgpack, err := WebSocketResponseFromMsgpack(bytes.NewReader(msgpackBuf))
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), fromMsgpack.SeqReply)
+		assert.Equal(t, "online", fromMsgpack.Data["status"])
+	})
+}
+
 var stringSink []byte
 
 func BenchmarkWebSocketEvent_ToJSON(b *testing.B) {
@@ -160,6 +269,32 @@ func BenchmarkWebSocketEvent_ToJSON(b *testing.B) {
 	})
 }
 
+func BenchmarkWebSocketEvent_ToMsgpack(b *testing.B) {
+	event := NewWebSocketEvent(WebsocketEventPosted, "foo", "bar", "baz", nil, "")
+	for range 100 {
+		event.GetData()[NewId()] = NewId()
+	}
+
+	b.Run("SerializedNTimes", func(b *testing.B) {
+		for b.Loop() {
+			stringSink, _ = event.ToMsgpack()
+		}
+	})
+
+	b.Run("PrecomputedAndSerializedNTimes", func(b *testing.B) {
+		for b.Loop() {
+			stringSink, _ = event.PrecomputeJSON().ToMsgpack()
+		}
+	})
+
+	event = event.PrecomputeJSON()
+	b.Run("PrecomputedOnceAndSerializedNTimes", func(b *testing.B) {
+		for b.Loop() {
+			stringSink, _ = event.ToMsgpack()
+		}
+	})
+}
+
 func TestWebsocketBroadcastCopy(t *testing.T) {
 	w := &WebsocketBroadcast{}
 	require.Equal(t, w, w.copy())
@@ -965,6 +965,39 @@ type API interface {
 	// Minimum server version: 5.6
 	KVList(page, perPage int) ([]string, *model.AppError)
 
+	// KVListWithValues lists the key-value pairs of a plugin whose key starts with the given prefix,
+	// ordered by key. The expiry time of each pair is returned in ExpireAt, zero if it doesn't expire.
+	// An empty prefix lists all the key-value pairs.
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 10.12
+	KVListWithValues(prefix string, page, perPage int) ([]*model.PluginKeyValue, *model.AppError)
+
+	// KVGetMany retrieves the values of the given keys, unique per plugin. Non-existent keys are
+	// left out of the returned map.
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 10.12
+	KVGetMany(keys []string) (map[string][]byte, *model.AppError)
+
+	// KVSetMany stores the given key-value pairs, unique per plugin, in a single transaction. Keys
+	// with an empty value are removed. A non-zero expireInSeconds applies to all the pairs.
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 10.12
+	KVSetMany(values map[string][]byte, expireInSeconds int64) *model.AppError
+
+	// KVCompareAndSwapMany applies all the given operations in a single transaction, but only if
+	// the current value of every key matches the OldValue of its operation. An empty OldValue
+	// expects the key not to exist, and an empty NewValue removes the key.
+	// Returns (false, err) if DB error occurred
+	// Returns (false, nil) if a current value didn't match, in which case nothing was changed
+	// Returns (true, nil) if all the operations were applied
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 10.12
+	KVCompareAndSwapMany(operations []*model.PluginKVCompareAndSwap) (bool, *model.AppError)
+
 	// PublishWebSocketEvent sends an event to WebSocket connections.
 	// event is the type and will be prepended with "custom_<pluginid>_".
 	// payload is the data sent with the event. Interface values must be primitive Go types or mattermost-server/model types.
@@ -1552,6 +1585,79 @@ type API interface {
 	// @tag Audit
 	// Minimum server version: 10.10
 	LogAuditRecWithLevel(rec *model.AuditRecord, level mlog.Level)
+
+	// RegisterJobType registers a job type with the server's job server. Jobs of that type show
+	// up alongside the built-in jobs and are run by your plugin via the ExecuteJob hook, on
+	// whichever node of the cluster claims them first. The job type must start with
+	// model.PluginJobTypePrefix, and registering it again replaces its options.
+	//
+	// Registrations don't survive a restart of the plugin, so this is best called in OnActivate.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	RegisterJobType(jobType string, options *model.PluginJobTypeOptions) *model.AppError
+
+	// UnregisterJobType unregisters a job type previously registered via RegisterJobType.
+	// Pending jobs of that type are kept until the type is registered again.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	UnregisterJobType(jobType string) *model.AppError
+
+	// CreateJob creates a pending job of a type registered by your plugin.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	CreateJob(jobType string, data map[string]string) (*model.Job, *model.AppError)
+
+	// GetJob gets a job of a type registered by your plugin. The status of a job being run
+	// turns to model.JobStatusCancelRequested when its cancellation is requested.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	GetJob(jobID string) (*model.Job, *model.AppError)
+
+	// UpdateJobProgress updates the progress, as a percentage, of a job of a type registered
+	// by your plugin while it's being run.
+	//
+	// @tag Job
+	// Minimum server version: 10.12
+	UpdateJobProgress(jobID string, progress int64) *model.AppError
+
+	// RegisterDocumentExtractor registers your plugin to extract the text content of the files
+	// matching the given extensions or MIME types, via the ExtractDocumentText hook, ahead of the
+	// server's own extractors. Registering again replaces the previous registration.
+	//
+	// Registrations don't survive a restart of the plugin, so this is best called in OnActivate.
+	//
+	// @tag File
+	// Minimum server version: 10.12
+	RegisterDocumentExtractor(extractor *model.PluginDocumentExtractor) *model.AppError
+
+	// UnregisterDocumentExtractor unregisters the document extractor previously registered via
+	// RegisterDocumentExtractor.
+	//
+	// @tag File
+	// Minimum server version: 10.12
+	UnregisterDocumentExtractor() *model.AppError
+
+	// RegisterSearchEngine registers your plugin as a search engine for posts and files, through
+	// the SearchEngine* hooks. It takes precedence over the other search engines, which remain in
+	// use for channels and users, and as a fallback should the plugin fail. Only one plugin can
+	// provide a search engine at a time.
+	//
+	// Registrations don't survive a restart of the plugin, so this is best called in OnActivate.
+	//
+	// @tag Search
+	// Minimum server version: 10.12
+	RegisterSearchEngine() *model.AppError
+
+	// UnregisterSearchEngine unregisters the search engine previously registered via
+	// RegisterSearchEngine.
+	//
+	// @tag Search
+	// Minimum server version: 10.12
+	UnregisterSearchEngine() *model.AppError
 }
 
 var handshake = plugin.HandshakeConfig{
@@ -0,0 +1,1744 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make pluginapi"
+// DO NOT EDIT
+
+package plugin
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (api *apiPermissionLayer) LoadPluginConfiguration(dest any) error {
+	if appErr := api.check("LoadPluginConfiguration"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.LoadPluginConfiguration(dest)
+}
+
+func (api *apiPermissionLayer) RegisterCommand(command *model.Command) error {
+	if appErr := api.check("RegisterCommand"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RegisterCommand(command)
+}
+
+func (api *apiPermissionLayer) UnregisterCommand(teamID, trigger string) error {
+	if appErr := api.check("UnregisterCommand"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UnregisterCommand(teamID, trigger)
+}
+
+func (api *apiPermissionLayer) ExecuteSlashCommand(commandArgs *model.CommandArgs) (*model.CommandResponse, error) {
+	if appErr := api.check("ExecuteSlashCommand"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.ExecuteSlashCommand(commandArgs)
+}
+
+func (api *apiPermissionLayer) GetConfig() *model.Config {
+	if appErr := api.check("GetConfig"); appErr != nil {
+		return nil
+	}
+	return api.apiImpl.GetConfig()
+}
+
+func (api *apiPermissionLayer) GetUnsanitizedConfig() *model.Config {
+	if appErr := api.check("GetUnsanitizedConfig"); appErr != nil {
+		return nil
+	}
+	return api.apiImpl.GetUnsanitizedConfig()
+}
+
+func (api *apiPermissionLayer) SaveConfig(config *model.Config) *model.AppError {
+	if appErr := api.check("SaveConfig"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.SaveConfig(config)
+}
+
+func (api *apiPermissionLayer) GetPluginConfig() map[string]any {
+	if appErr := api.check("GetPluginConfig"); appErr != nil {
+		return nil
+	}
+	return api.apiImpl.GetPluginConfig()
+}
+
+func (api *apiPermissionLayer) SavePluginConfig(config map[string]any) *model.AppError {
+	if appErr := api.check("SavePluginConfig"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.SavePluginConfig(config)
+}
+
+func (api *apiPermissionLayer) GetBundlePath() (string, error) {
+	if appErr := api.check("GetBundlePath"); appErr != nil {
+		return "", appErr
+	}
+	return api.apiImpl.GetBundlePath()
+}
+
+func (api *apiPermissionLayer) GetLicense() *model.License {
+	if appErr := api.check("GetLicense"); appErr != nil {
+		return nil
+	}
+	return api.apiImpl.GetLicense()
+}
+
+func (api *apiPermissionLayer) IsEnterpriseReady() bool {
+	if appErr := api.check("IsEnterpriseReady"); appErr != nil {
+		return false
+	}
+	return api.apiImpl.IsEnterpriseReady()
+}
+
+func (api *apiPermissionLayer) GetServerVersion() string {
+	if appErr := api.check("GetServerVersion"); appErr != nil {
+		return ""
+	}
+	return api.apiImpl.GetServerVersion()
+}
+
+func (api *apiPermissionLayer) GetSystemInstallDate() (int64, *model.AppError) {
+	if appErr := api.check("GetSystemInstallDate"); appErr != nil {
+		return 0, appErr
+	}
+	return api.apiImpl.GetSystemInstallDate()
+}
+
+func (api *apiPermissionLayer) GetDiagnosticId() string {
+	if appErr := api.check("GetDiagnosticId"); appErr != nil {
+		return ""
+	}
+	return api.apiImpl.GetDiagnosticId()
+}
+
+func (api *apiPermissionLayer) GetTelemetryId() string {
+	if appErr := api.check("GetTelemetryId"); appErr != nil {
+		return ""
+	}
+	return api.apiImpl.GetTelemetryId()
+}
+
+func (api *apiPermissionLayer) CreateUser(user *model.User) (*model.User, *model.AppError) {
+	if appErr := api.check("CreateUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateUser(user)
+}
+
+func (api *apiPermissionLayer) DeleteUser(userID string) *model.AppError {
+	if appErr := api.check("DeleteUser"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeleteUser(userID)
+}
+
+func (api *apiPermissionLayer) GetUsers(options *model.UserGetOptions) ([]*model.User, *model.AppError) {
+	if appErr := api.check("GetUsers"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUsers(options)
+}
+
+func (api *apiPermissionLayer) GetUsersByIds(userIDs []string) ([]*model.User, *model.AppError) {
+	if appErr := api.check("GetUsersByIds"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUsersByIds(userIDs)
+}
+
+func (api *apiPermissionLayer) GetUser(userID string) (*model.User, *model.AppError) {
+	if appErr := api.check("GetUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUser(userID)
+}
+
+func (api *apiPermissionLayer) GetUserByEmail(email string) (*model.User, *model.AppError) {
+	if appErr := api.check("GetUserByEmail"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUserByEmail(email)
+}
+
+func (api *apiPermissionLayer) GetUserByUsername(name string) (*model.User, *model.AppError) {
+	if appErr := api.check("GetUserByUsername"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUserByUsername(name)
+}
+
+func (api *apiPermissionLayer) GetUsersByUsernames(usernames []string) ([]*model.User, *model.AppError) {
+	if appErr := api.check("GetUsersByUsernames"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUsersByUsernames(usernames)
+}
+
+func (api *apiPermissionLayer) GetUsersInTeam(teamID string, page int, perPage int) ([]*model.User, *model.AppError) {
+	if appErr := api.check("GetUsersInTeam"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUsersInTeam(teamID, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetPreferenceForUser(userID, category, name string) (model.Preference, *model.AppError) {
+	if appErr := api.check("GetPreferenceForUser"); appErr != nil {
+		return *new(model.Preference), appErr
+	}
+	return api.apiImpl.GetPreferenceForUser(userID, category, name)
+}
+
+func (api *apiPermissionLayer) GetPreferencesForUser(userID string) ([]model.Preference, *model.AppError) {
+	if appErr := api.check("GetPreferencesForUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPreferencesForUser(userID)
+}
+
+func (api *apiPermissionLayer) UpdatePreferencesForUser(userID string, preferences []model.Preference) *model.AppError {
+	if appErr := api.check("UpdatePreferencesForUser"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UpdatePreferencesForUser(userID, preferences)
+}
+
+func (api *apiPermissionLayer) DeletePreferencesForUser(userID string, preferences []model.Preference) *model.AppError {
+	if appErr := api.check("DeletePreferencesForUser"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeletePreferencesForUser(userID, preferences)
+}
+
+func (api *apiPermissionLayer) GetSession(sessionID string) (*model.Session, *model.AppError) {
+	if appErr := api.check("GetSession"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetSession(sessionID)
+}
+
+func (api *apiPermissionLayer) CreateSession(session *model.Session) (*model.Session, *model.AppError) {
+	if appErr := api.check("CreateSession"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateSession(session)
+}
+
+func (api *apiPermissionLayer) ExtendSessionExpiry(sessionID string, newExpiry int64) *model.AppError {
+	if appErr := api.check("ExtendSessionExpiry"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.ExtendSessionExpiry(sessionID, newExpiry)
+}
+
+func (api *apiPermissionLayer) RevokeSession(sessionID string) *model.AppError {
+	if appErr := api.check("RevokeSession"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RevokeSession(sessionID)
+}
+
+func (api *apiPermissionLayer) CreateUserAccessToken(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError) {
+	if appErr := api.check("CreateUserAccessToken"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateUserAccessToken(token)
+}
+
+func (api *apiPermissionLayer) RevokeUserAccessToken(tokenID string) *model.AppError {
+	if appErr := api.check("RevokeUserAccessToken"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RevokeUserAccessToken(tokenID)
+}
+
+func (api *apiPermissionLayer) GetTeamIcon(teamID string) ([]byte, *model.AppError) {
+	if appErr := api.check("GetTeamIcon"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeamIcon(teamID)
+}
+
+func (api *apiPermissionLayer) SetTeamIcon(teamID string, data []byte) *model.AppError {
+	if appErr := api.check("SetTeamIcon"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.SetTeamIcon(teamID, data)
+}
+
+func (api *apiPermissionLayer) RemoveTeamIcon(teamID string) *model.AppError {
+	if appErr := api.check("RemoveTeamIcon"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RemoveTeamIcon(teamID)
+}
+
+func (api *apiPermissionLayer) UpdateUser(user *model.User) (*model.User, *model.AppError) {
+	if appErr := api.check("UpdateUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateUser(user)
+}
+
+func (api *apiPermissionLayer) GetUserStatus(userID string) (*model.Status, *model.AppError) {
+	if appErr := api.check("GetUserStatus"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUserStatus(userID)
+}
+
+func (api *apiPermissionLayer) GetUserStatusesByIds(userIds []string) ([]*model.Status, *model.AppError) {
+	if appErr := api.check("GetUserStatusesByIds"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUserStatusesByIds(userIds)
+}
+
+func (api *apiPermissionLayer) UpdateUserStatus(userID, status string) (*model.Status, *model.AppError) {
+	if appErr := api.check("UpdateUserStatus"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateUserStatus(userID, status)
+}
+
+func (api *apiPermissionLayer) SetUserStatusTimedDND(userId string, endtime int64) (*model.Status, *model.AppError) {
+	if appErr := api.check("SetUserStatusTimedDND"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.SetUserStatusTimedDND(userId, endtime)
+}
+
+func (api *apiPermissionLayer) UpdateUserActive(userID string, active bool) *model.AppError {
+	if appErr := api.check("UpdateUserActive"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UpdateUserActive(userID, active)
+}
+
+func (api *apiPermissionLayer) UpdateUserCustomStatus(userID string, customStatus *model.CustomStatus) *model.AppError {
+	if appErr := api.check("UpdateUserCustomStatus"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UpdateUserCustomStatus(userID, customStatus)
+}
+
+func (api *apiPermissionLayer) RemoveUserCustomStatus(userID string) *model.AppError {
+	if appErr := api.check("RemoveUserCustomStatus"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RemoveUserCustomStatus(userID)
+}
+
+func (api *apiPermissionLayer) GetUsersInChannel(channelID, sortBy string, page, perPage int) ([]*model.User, *model.AppError) {
+	if appErr := api.check("GetUsersInChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUsersInChannel(channelID, sortBy, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetLDAPUserAttributes(userID string, attributes []string) (map[string]string, *model.AppError) {
+	if appErr := api.check("GetLDAPUserAttributes"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetLDAPUserAttributes(userID, attributes)
+}
+
+func (api *apiPermissionLayer) CreateTeam(team *model.Team) (*model.Team, *model.AppError) {
+	if appErr := api.check("CreateTeam"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateTeam(team)
+}
+
+func (api *apiPermissionLayer) DeleteTeam(teamID string) *model.AppError {
+	if appErr := api.check("DeleteTeam"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeleteTeam(teamID)
+}
+
+func (api *apiPermissionLayer) GetTeams() ([]*model.Team, *model.AppError) {
+	if appErr := api.check("GetTeams"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeams()
+}
+
+func (api *apiPermissionLayer) GetTeam(teamID string) (*model.Team, *model.AppError) {
+	if appErr := api.check("GetTeam"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeam(teamID)
+}
+
+func (api *apiPermissionLayer) GetTeamByName(name string) (*model.Team, *model.AppError) {
+	if appErr := api.check("GetTeamByName"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeamByName(name)
+}
+
+func (api *apiPermissionLayer) GetTeamsUnreadForUser(userID string) ([]*model.TeamUnread, *model.AppError) {
+	if appErr := api.check("GetTeamsUnreadForUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeamsUnreadForUser(userID)
+}
+
+func (api *apiPermissionLayer) UpdateTeam(team *model.Team) (*model.Team, *model.AppError) {
+	if appErr := api.check("UpdateTeam"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateTeam(team)
+}
+
+func (api *apiPermissionLayer) SearchTeams(term string) ([]*model.Team, *model.AppError) {
+	if appErr := api.check("SearchTeams"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.SearchTeams(term)
+}
+
+func (api *apiPermissionLayer) GetTeamsForUser(userID string) ([]*model.Team, *model.AppError) {
+	if appErr := api.check("GetTeamsForUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeamsForUser(userID)
+}
+
+func (api *apiPermissionLayer) CreateTeamMember(teamID, userID string) (*model.TeamMember, *model.AppError) {
+	if appErr := api.check("CreateTeamMember"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateTeamMember(teamID, userID)
+}
+
+func (api *apiPermissionLayer) CreateTeamMembers(teamID string, userIds []string, requestorId string) ([]*model.TeamMember, *model.AppError) {
+	if appErr := api.check("CreateTeamMembers"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateTeamMembers(teamID, userIds, requestorId)
+}
+
+func (api *apiPermissionLayer) CreateTeamMembersGracefully(teamID string, userIds []string, requestorId string) ([]*model.TeamMemberWithError, *model.AppError) {
+	if appErr := api.check("CreateTeamMembersGracefully"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateTeamMembersGracefully(teamID, userIds, requestorId)
+}
+
+func (api *apiPermissionLayer) DeleteTeamMember(teamID, userID, requestorId string) *model.AppError {
+	if appErr := api.check("DeleteTeamMember"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeleteTeamMember(teamID, userID, requestorId)
+}
+
+func (api *apiPermissionLayer) GetTeamMembers(teamID string, page, perPage int) ([]*model.TeamMember, *model.AppError) {
+	if appErr := api.check("GetTeamMembers"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeamMembers(teamID, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetTeamMember(teamID, userID string) (*model.TeamMember, *model.AppError) {
+	if appErr := api.check("GetTeamMember"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeamMember(teamID, userID)
+}
+
+func (api *apiPermissionLayer) GetTeamMembersForUser(userID string, page int, perPage int) ([]*model.TeamMember, *model.AppError) {
+	if appErr := api.check("GetTeamMembersForUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeamMembersForUser(userID, page, perPage)
+}
+
+func (api *apiPermissionLayer) UpdateTeamMemberRoles(teamID, userID, newRoles string) (*model.TeamMember, *model.AppError) {
+	if appErr := api.check("UpdateTeamMemberRoles"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateTeamMemberRoles(teamID, userID, newRoles)
+}
+
+func (api *apiPermissionLayer) CreateChannel(channel *model.Channel) (*model.Channel, *model.AppError) {
+	if appErr := api.check("CreateChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateChannel(channel)
+}
+
+func (api *apiPermissionLayer) DeleteChannel(channelId string) *model.AppError {
+	if appErr := api.check("DeleteChannel"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeleteChannel(channelId)
+}
+
+func (api *apiPermissionLayer) GetPublicChannelsForTeam(teamID string, page, perPage int) ([]*model.Channel, *model.AppError) {
+	if appErr := api.check("GetPublicChannelsForTeam"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPublicChannelsForTeam(teamID, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetChannel(channelId string) (*model.Channel, *model.AppError) {
+	if appErr := api.check("GetChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetChannel(channelId)
+}
+
+func (api *apiPermissionLayer) GetChannelByName(teamID, name string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	if appErr := api.check("GetChannelByName"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetChannelByName(teamID, name, includeDeleted)
+}
+
+func (api *apiPermissionLayer) GetChannelByNameForTeamName(teamName, channelName string, includeDeleted bool) (*model.Channel, *model.AppError) {
+	if appErr := api.check("GetChannelByNameForTeamName"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetChannelByNameForTeamName(teamName, channelName, includeDeleted)
+}
+
+func (api *apiPermissionLayer) GetChannelsForTeamForUser(teamID, userID string, includeDeleted bool) ([]*model.Channel, *model.AppError) {
+	if appErr := api.check("GetChannelsForTeamForUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetChannelsForTeamForUser(teamID, userID, includeDeleted)
+}
+
+func (api *apiPermissionLayer) GetChannelStats(channelId string) (*model.ChannelStats, *model.AppError) {
+	if appErr := api.check("GetChannelStats"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetChannelStats(channelId)
+}
+
+func (api *apiPermissionLayer) GetDirectChannel(userId1, userId2 string) (*model.Channel, *model.AppError) {
+	if appErr := api.check("GetDirectChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetDirectChannel(userId1, userId2)
+}
+
+func (api *apiPermissionLayer) GetGroupChannel(userIds []string) (*model.Channel, *model.AppError) {
+	if appErr := api.check("GetGroupChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroupChannel(userIds)
+}
+
+func (api *apiPermissionLayer) UpdateChannel(channel *model.Channel) (*model.Channel, *model.AppError) {
+	if appErr := api.check("UpdateChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateChannel(channel)
+}
+
+func (api *apiPermissionLayer) SearchChannels(teamID string, term string) ([]*model.Channel, *model.AppError) {
+	if appErr := api.check("SearchChannels"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.SearchChannels(teamID, term)
+}
+
+func (api *apiPermissionLayer) CreateChannelSidebarCategory(userID, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError) {
+	if appErr := api.check("CreateChannelSidebarCategory"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateChannelSidebarCategory(userID, teamID, newCategory)
+}
+
+func (api *apiPermissionLayer) GetChannelSidebarCategories(userID, teamID string) (*model.OrderedSidebarCategories, *model.AppError) {
+	if appErr := api.check("GetChannelSidebarCategories"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetChannelSidebarCategories(userID, teamID)
+}
+
+func (api *apiPermissionLayer) UpdateChannelSidebarCategories(userID, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, *model.AppError) {
+	if appErr := api.check("UpdateChannelSidebarCategories"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateChannelSidebarCategories(userID, teamID, categories)
+}
+
+func (api *apiPermissionLayer) SearchUsers(search *model.UserSearch) ([]*model.User, *model.AppError) {
+	if appErr := api.check("SearchUsers"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.SearchUsers(search)
+}
+
+func (api *apiPermissionLayer) SearchPostsInTeam(teamID string, paramsList []*model.SearchParams) ([]*model.Post, *model.AppError) {
+	if appErr := api.check("SearchPostsInTeam"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.SearchPostsInTeam(teamID, paramsList)
+}
+
+func (api *apiPermissionLayer) SearchPostsInTeamForUser(teamID string, userID string, searchParams model.SearchParameter) (*model.PostSearchResults, *model.AppError) {
+	if appErr := api.check("SearchPostsInTeamForUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.SearchPostsInTeamForUser(teamID, userID, searchParams)
+}
+
+func (api *apiPermissionLayer) AddChannelMember(channelId, userID string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.check("AddChannelMember"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.AddChannelMember(channelId, userID)
+}
+
+func (api *apiPermissionLayer) AddUserToChannel(channelId, userID, asUserId string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.check("AddUserToChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.AddUserToChannel(channelId, userID, asUserId)
+}
+
+func (api *apiPermissionLayer) GetChannelMember(channelId, userID string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.check("GetChannelMember"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetChannelMember(channelId, userID)
+}
+
+func (api *apiPermissionLayer) GetChannelMembers(channelId string, page, perPage int) (model.ChannelMembers, *model.AppError) {
+	if appErr := api.check("GetChannelMembers"); appErr != nil {
+		return *new(model.ChannelMembers), appErr
+	}
+	return api.apiImpl.GetChannelMembers(channelId, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetChannelMembersByIds(channelId string, userIds []string) (model.ChannelMembers, *model.AppError) {
+	if appErr := api.check("GetChannelMembersByIds"); appErr != nil {
+		return *new(model.ChannelMembers), appErr
+	}
+	return api.apiImpl.GetChannelMembersByIds(channelId, userIds)
+}
+
+func (api *apiPermissionLayer) GetChannelMembersForUser(teamID, userID string, page, perPage int) ([]*model.ChannelMember, *model.AppError) {
+	if appErr := api.check("GetChannelMembersForUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetChannelMembersForUser(teamID, userID, page, perPage)
+}
+
+func (api *apiPermissionLayer) UpdateChannelMemberRoles(channelId, userID, newRoles string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.check("UpdateChannelMemberRoles"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateChannelMemberRoles(channelId, userID, newRoles)
+}
+
+func (api *apiPermissionLayer) UpdateChannelMemberNotifications(channelId, userID string, notifications map[string]string) (*model.ChannelMember, *model.AppError) {
+	if appErr := api.check("UpdateChannelMemberNotifications"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateChannelMemberNotifications(channelId, userID, notifications)
+}
+
+func (api *apiPermissionLayer) PatchChannelMembersNotifications(members []*model.ChannelMemberIdentifier, notifyProps map[string]string) *model.AppError {
+	if appErr := api.check("PatchChannelMembersNotifications"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.PatchChannelMembersNotifications(members, notifyProps)
+}
+
+func (api *apiPermissionLayer) GetGroup(groupId string) (*model.Group, *model.AppError) {
+	if appErr := api.check("GetGroup"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroup(groupId)
+}
+
+func (api *apiPermissionLayer) GetGroupByName(name string) (*model.Group, *model.AppError) {
+	if appErr := api.check("GetGroupByName"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroupByName(name)
+}
+
+func (api *apiPermissionLayer) GetGroupMemberUsers(groupID string, page, perPage int) ([]*model.User, *model.AppError) {
+	if appErr := api.check("GetGroupMemberUsers"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroupMemberUsers(groupID, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetGroupsBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError) {
+	if appErr := api.check("GetGroupsBySource"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroupsBySource(groupSource)
+}
+
+func (api *apiPermissionLayer) GetGroupsForUser(userID string) ([]*model.Group, *model.AppError) {
+	if appErr := api.check("GetGroupsForUser"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroupsForUser(userID)
+}
+
+func (api *apiPermissionLayer) DeleteChannelMember(channelId, userID string) *model.AppError {
+	if appErr := api.check("DeleteChannelMember"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeleteChannelMember(channelId, userID)
+}
+
+func (api *apiPermissionLayer) CreatePost(post *model.Post) (*model.Post, *model.AppError) {
+	if appErr := api.check("CreatePost"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreatePost(post)
+}
+
+func (api *apiPermissionLayer) AddReaction(reaction *model.Reaction) (*model.Reaction, *model.AppError) {
+	if appErr := api.check("AddReaction"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.AddReaction(reaction)
+}
+
+func (api *apiPermissionLayer) RemoveReaction(reaction *model.Reaction) *model.AppError {
+	if appErr := api.check("RemoveReaction"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RemoveReaction(reaction)
+}
+
+func (api *apiPermissionLayer) GetReactions(postId string) ([]*model.Reaction, *model.AppError) {
+	if appErr := api.check("GetReactions"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetReactions(postId)
+}
+
+func (api *apiPermissionLayer) SendEphemeralPost(userID string, post *model.Post) *model.Post {
+	if appErr := api.check("SendEphemeralPost"); appErr != nil {
+		return nil
+	}
+	return api.apiImpl.SendEphemeralPost(userID, post)
+}
+
+func (api *apiPermissionLayer) UpdateEphemeralPost(userID string, post *model.Post) *model.Post {
+	if appErr := api.check("UpdateEphemeralPost"); appErr != nil {
+		return nil
+	}
+	return api.apiImpl.UpdateEphemeralPost(userID, post)
+}
+
+func (api *apiPermissionLayer) DeleteEphemeralPost(userID, postId string) {
+	if appErr := api.check("DeleteEphemeralPost"); appErr != nil {
+		return
+	}
+	api.apiImpl.DeleteEphemeralPost(userID, postId)
+}
+
+func (api *apiPermissionLayer) DeletePost(postId string) *model.AppError {
+	if appErr := api.check("DeletePost"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeletePost(postId)
+}
+
+func (api *apiPermissionLayer) GetPostThread(postId string) (*model.PostList, *model.AppError) {
+	if appErr := api.check("GetPostThread"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPostThread(postId)
+}
+
+func (api *apiPermissionLayer) GetPost(postId string) (*model.Post, *model.AppError) {
+	if appErr := api.check("GetPost"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPost(postId)
+}
+
+func (api *apiPermissionLayer) GetPostsSince(channelId string, time int64) (*model.PostList, *model.AppError) {
+	if appErr := api.check("GetPostsSince"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPostsSince(channelId, time)
+}
+
+func (api *apiPermissionLayer) GetPostsAfter(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError) {
+	if appErr := api.check("GetPostsAfter"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPostsAfter(channelId, postId, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetPostsBefore(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError) {
+	if appErr := api.check("GetPostsBefore"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPostsBefore(channelId, postId, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetPostsForChannel(channelId string, page, perPage int) (*model.PostList, *model.AppError) {
+	if appErr := api.check("GetPostsForChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPostsForChannel(channelId, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetTeamStats(teamID string) (*model.TeamStats, *model.AppError) {
+	if appErr := api.check("GetTeamStats"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetTeamStats(teamID)
+}
+
+func (api *apiPermissionLayer) UpdatePost(post *model.Post) (*model.Post, *model.AppError) {
+	if appErr := api.check("UpdatePost"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdatePost(post)
+}
+
+func (api *apiPermissionLayer) GetProfileImage(userID string) ([]byte, *model.AppError) {
+	if appErr := api.check("GetProfileImage"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetProfileImage(userID)
+}
+
+func (api *apiPermissionLayer) SetProfileImage(userID string, data []byte) *model.AppError {
+	if appErr := api.check("SetProfileImage"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.SetProfileImage(userID, data)
+}
+
+func (api *apiPermissionLayer) GetEmojiList(sortBy string, page, perPage int) ([]*model.Emoji, *model.AppError) {
+	if appErr := api.check("GetEmojiList"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetEmojiList(sortBy, page, perPage)
+}
+
+func (api *apiPermissionLayer) GetEmojiByName(name string) (*model.Emoji, *model.AppError) {
+	if appErr := api.check("GetEmojiByName"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetEmojiByName(name)
+}
+
+func (api *apiPermissionLayer) GetEmoji(emojiId string) (*model.Emoji, *model.AppError) {
+	if appErr := api.check("GetEmoji"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetEmoji(emojiId)
+}
+
+func (api *apiPermissionLayer) CopyFileInfos(userID string, fileIds []string) ([]string, *model.AppError) {
+	if appErr := api.check("CopyFileInfos"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CopyFileInfos(userID, fileIds)
+}
+
+func (api *apiPermissionLayer) GetFileInfo(fileId string) (*model.FileInfo, *model.AppError) {
+	if appErr := api.check("GetFileInfo"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetFileInfo(fileId)
+}
+
+func (api *apiPermissionLayer) SetFileSearchableContent(fileID string, content string) *model.AppError {
+	if appErr := api.check("SetFileSearchableContent"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.SetFileSearchableContent(fileID, content)
+}
+
+func (api *apiPermissionLayer) GetFileInfos(page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError) {
+	if appErr := api.check("GetFileInfos"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetFileInfos(page, perPage, opt)
+}
+
+func (api *apiPermissionLayer) GetFile(fileId string) ([]byte, *model.AppError) {
+	if appErr := api.check("GetFile"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetFile(fileId)
+}
+
+func (api *apiPermissionLayer) GetFileLink(fileId string) (string, *model.AppError) {
+	if appErr := api.check("GetFileLink"); appErr != nil {
+		return "", appErr
+	}
+	return api.apiImpl.GetFileLink(fileId)
+}
+
+func (api *apiPermissionLayer) ReadFile(path string) ([]byte, *model.AppError) {
+	if appErr := api.check("ReadFile"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.ReadFile(path)
+}
+
+func (api *apiPermissionLayer) GetEmojiImage(emojiId string) ([]byte, string, *model.AppError) {
+	if appErr := api.check("GetEmojiImage"); appErr != nil {
+		return nil, "", appErr
+	}
+	return api.apiImpl.GetEmojiImage(emojiId)
+}
+
+func (api *apiPermissionLayer) UploadFile(data []byte, channelId string, filename string) (*model.FileInfo, *model.AppError) {
+	if appErr := api.check("UploadFile"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UploadFile(data, channelId, filename)
+}
+
+func (api *apiPermissionLayer) OpenInteractiveDialog(dialog model.OpenDialogRequest) *model.AppError {
+	if appErr := api.check("OpenInteractiveDialog"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.OpenInteractiveDialog(dialog)
+}
+
+func (api *apiPermissionLayer) GetPlugins() ([]*model.Manifest, *model.AppError) {
+	if appErr := api.check("GetPlugins"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPlugins()
+}
+
+func (api *apiPermissionLayer) EnablePlugin(id string) *model.AppError {
+	if appErr := api.check("EnablePlugin"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.EnablePlugin(id)
+}
+
+func (api *apiPermissionLayer) DisablePlugin(id string) *model.AppError {
+	if appErr := api.check("DisablePlugin"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DisablePlugin(id)
+}
+
+func (api *apiPermissionLayer) RemovePlugin(id string) *model.AppError {
+	if appErr := api.check("RemovePlugin"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RemovePlugin(id)
+}
+
+func (api *apiPermissionLayer) GetPluginStatus(id string) (*model.PluginStatus, *model.AppError) {
+	if appErr := api.check("GetPluginStatus"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPluginStatus(id)
+}
+
+func (api *apiPermissionLayer) InstallPlugin(file io.Reader, replace bool) (*model.Manifest, *model.AppError) {
+	if appErr := api.check("InstallPlugin"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.InstallPlugin(file, replace)
+}
+
+func (api *apiPermissionLayer) KVSet(key string, value []byte) *model.AppError {
+	if appErr := api.check("KVSet"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.KVSet(key, value)
+}
+
+func (api *apiPermissionLayer) KVCompareAndSet(key string, oldValue, newValue []byte) (bool, *model.AppError) {
+	if appErr := api.check("KVCompareAndSet"); appErr != nil {
+		return false, appErr
+	}
+	return api.apiImpl.KVCompareAndSet(key, oldValue, newValue)
+}
+
+func (api *apiPermissionLayer) KVCompareAndDelete(key string, oldValue []byte) (bool, *model.AppError) {
+	if appErr := api.check("KVCompareAndDelete"); appErr != nil {
+		return false, appErr
+	}
+	return api.apiImpl.KVCompareAndDelete(key, oldValue)
+}
+
+func (api *apiPermissionLayer) KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError) {
+	if appErr := api.check("KVSetWithOptions"); appErr != nil {
+		return false, appErr
+	}
+	return api.apiImpl.KVSetWithOptions(key, value, options)
+}
+
+func (api *apiPermissionLayer) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
+	if appErr := api.check("KVSetWithExpiry"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.KVSetWithExpiry(key, value, expireInSeconds)
+}
+
+func (api *apiPermissionLayer) KVGet(key string) ([]byte, *model.AppError) {
+	if appErr := api.check("KVGet"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.KVGet(key)
+}
+
+func (api *apiPermissionLayer) KVDelete(key string) *model.AppError {
+	if appErr := api.check("KVDelete"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.KVDelete(key)
+}
+
+func (api *apiPermissionLayer) KVDeleteAll() *model.AppError {
+	if appErr := api.check("KVDeleteAll"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.KVDeleteAll()
+}
+
+func (api *apiPermissionLayer) KVList(page, perPage int) ([]string, *model.AppError) {
+	if appErr := api.check("KVList"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.KVList(page, perPage)
+}
+
+func (api *apiPermissionLayer) KVListWithValues(prefix string, page, perPage int) ([]*model.PluginKeyValue, *model.AppError) {
+	if appErr := api.check("KVListWithValues"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.KVListWithValues(prefix, page, perPage)
+}
+
+func (api *apiPermissionLayer) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	if appErr := api.check("KVGetMany"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.KVGetMany(keys)
+}
+
+func (api *apiPermissionLayer) KVSetMany(values map[string][]byte, expireInSeconds int64) *model.AppError {
+	if appErr := api.check("KVSetMany"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.KVSetMany(values, expireInSeconds)
+}
+
+func (api *apiPermissionLayer) KVCompareAndSwapMany(operations []*model.PluginKVCompareAndSwap) (bool, *model.AppError) {
+	if appErr := api.check("KVCompareAndSwapMany"); appErr != nil {
+		return false, appErr
+	}
+	return api.apiImpl.KVCompareAndSwapMany(operations)
+}
+
+func (api *apiPermissionLayer) PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast) {
+	if appErr := api.check("PublishWebSocketEvent"); appErr != nil {
+		return
+	}
+	api.apiImpl.PublishWebSocketEvent(event, payload, broadcast)
+}
+
+func (api *apiPermissionLayer) HasPermissionTo(userID string, permission *model.Permission) bool {
+	if appErr := api.check("HasPermissionTo"); appErr != nil {
+		return false
+	}
+	return api.apiImpl.HasPermissionTo(userID, permission)
+}
+
+func (api *apiPermissionLayer) HasPermissionToTeam(userID, teamID string, permission *model.Permission) bool {
+	if appErr := api.check("HasPermissionToTeam"); appErr != nil {
+		return false
+	}
+	return api.apiImpl.HasPermissionToTeam(userID, teamID, permission)
+}
+
+func (api *apiPermissionLayer) HasPermissionToChannel(userID, channelId string, permission *model.Permission) bool {
+	if appErr := api.check("HasPermissionToChannel"); appErr != nil {
+		return false
+	}
+	return api.apiImpl.HasPermissionToChannel(userID, channelId, permission)
+}
+
+func (api *apiPermissionLayer) RolesGrantPermission(roleNames []string, permissionId string) bool {
+	if appErr := api.check("RolesGrantPermission"); appErr != nil {
+		return false
+	}
+	return api.apiImpl.RolesGrantPermission(roleNames, permissionId)
+}
+
+func (api *apiPermissionLayer) LogDebug(msg string, keyValuePairs ...any) {
+	if appErr := api.check("LogDebug"); appErr != nil {
+		return
+	}
+	api.apiImpl.LogDebug(msg, keyValuePairs...)
+}
+
+func (api *apiPermissionLayer) LogInfo(msg string, keyValuePairs ...any) {
+	if appErr := api.check("LogInfo"); appErr != nil {
+		return
+	}
+	api.apiImpl.LogInfo(msg, keyValuePairs...)
+}
+
+func (api *apiPermissionLayer) LogError(msg string, keyValuePairs ...any) {
+	if appErr := api.check("LogError"); appErr != nil {
+		return
+	}
+	api.apiImpl.LogError(msg, keyValuePairs...)
+}
+
+func (api *apiPermissionLayer) LogWarn(msg string, keyValuePairs ...any) {
+	if appErr := api.check("LogWarn"); appErr != nil {
+		return
+	}
+	api.apiImpl.LogWarn(msg, keyValuePairs...)
+}
+
+func (api *apiPermissionLayer) SendMail(to, subject, htmlBody string) *model.AppError {
+	if appErr := api.check("SendMail"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.SendMail(to, subject, htmlBody)
+}
+
+func (api *apiPermissionLayer) CreateBot(bot *model.Bot) (*model.Bot, *model.AppError) {
+	if appErr := api.check("CreateBot"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateBot(bot)
+}
+
+func (api *apiPermissionLayer) PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError) {
+	if appErr := api.check("PatchBot"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.PatchBot(botUserId, botPatch)
+}
+
+func (api *apiPermissionLayer) GetBot(botUserId string, includeDeleted bool) (*model.Bot, *model.AppError) {
+	if appErr := api.check("GetBot"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetBot(botUserId, includeDeleted)
+}
+
+func (api *apiPermissionLayer) GetBots(options *model.BotGetOptions) ([]*model.Bot, *model.AppError) {
+	if appErr := api.check("GetBots"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetBots(options)
+}
+
+func (api *apiPermissionLayer) UpdateBotActive(botUserId string, active bool) (*model.Bot, *model.AppError) {
+	if appErr := api.check("UpdateBotActive"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateBotActive(botUserId, active)
+}
+
+func (api *apiPermissionLayer) PermanentDeleteBot(botUserId string) *model.AppError {
+	if appErr := api.check("PermanentDeleteBot"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.PermanentDeleteBot(botUserId)
+}
+
+func (api *apiPermissionLayer) PluginHTTP(request *http.Request) *http.Response {
+	if appErr := api.check("PluginHTTP"); appErr != nil {
+		return deniedHTTPResponse(appErr)
+	}
+	return api.apiImpl.PluginHTTP(request)
+}
+
+func (api *apiPermissionLayer) PublishUserTyping(userID, channelId, parentId string) *model.AppError {
+	if appErr := api.check("PublishUserTyping"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.PublishUserTyping(userID, channelId, parentId)
+}
+
+func (api *apiPermissionLayer) CreateCommand(cmd *model.Command) (*model.Command, error) {
+	if appErr := api.check("CreateCommand"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateCommand(cmd)
+}
+
+func (api *apiPermissionLayer) ListCommands(teamID string) ([]*model.Command, error) {
+	if appErr := api.check("ListCommands"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.ListCommands(teamID)
+}
+
+func (api *apiPermissionLayer) ListCustomCommands(teamID string) ([]*model.Command, error) {
+	if appErr := api.check("ListCustomCommands"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.ListCustomCommands(teamID)
+}
+
+func (api *apiPermissionLayer) ListPluginCommands(teamID string) ([]*model.Command, error) {
+	if appErr := api.check("ListPluginCommands"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.ListPluginCommands(teamID)
+}
+
+func (api *apiPermissionLayer) ListBuiltInCommands() ([]*model.Command, error) {
+	if appErr := api.check("ListBuiltInCommands"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.ListBuiltInCommands()
+}
+
+func (api *apiPermissionLayer) GetCommand(commandID string) (*model.Command, error) {
+	if appErr := api.check("GetCommand"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetCommand(commandID)
+}
+
+func (api *apiPermissionLayer) UpdateCommand(commandID string, updatedCmd *model.Command) (*model.Command, error) {
+	if appErr := api.check("UpdateCommand"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateCommand(commandID, updatedCmd)
+}
+
+func (api *apiPermissionLayer) DeleteCommand(commandID string) error {
+	if appErr := api.check("DeleteCommand"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeleteCommand(commandID)
+}
+
+func (api *apiPermissionLayer) CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	if appErr := api.check("CreateOAuthApp"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateOAuthApp(app)
+}
+
+func (api *apiPermissionLayer) GetOAuthApp(appID string) (*model.OAuthApp, *model.AppError) {
+	if appErr := api.check("GetOAuthApp"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetOAuthApp(appID)
+}
+
+func (api *apiPermissionLayer) UpdateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
+	if appErr := api.check("UpdateOAuthApp"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateOAuthApp(app)
+}
+
+func (api *apiPermissionLayer) DeleteOAuthApp(appID string) *model.AppError {
+	if appErr := api.check("DeleteOAuthApp"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeleteOAuthApp(appID)
+}
+
+func (api *apiPermissionLayer) PublishPluginClusterEvent(ev model.PluginClusterEvent, opts model.PluginClusterEventSendOptions) error {
+	if appErr := api.check("PublishPluginClusterEvent"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.PublishPluginClusterEvent(ev, opts)
+}
+
+func (api *apiPermissionLayer) RequestTrialLicense(requesterID string, users int, termsAccepted bool, receiveEmailsAccepted bool) *model.AppError {
+	if appErr := api.check("RequestTrialLicense"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RequestTrialLicense(requesterID, users, termsAccepted, receiveEmailsAccepted)
+}
+
+func (api *apiPermissionLayer) GetCloudLimits() (*model.ProductLimits, error) {
+	if appErr := api.check("GetCloudLimits"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetCloudLimits()
+}
+
+func (api *apiPermissionLayer) EnsureBotUser(bot *model.Bot) (string, error) {
+	if appErr := api.check("EnsureBotUser"); appErr != nil {
+		return "", appErr
+	}
+	return api.apiImpl.EnsureBotUser(bot)
+}
+
+func (api *apiPermissionLayer) RegisterCollectionAndTopic(collectionType, topicType string) error {
+	if appErr := api.check("RegisterCollectionAndTopic"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RegisterCollectionAndTopic(collectionType, topicType)
+}
+
+func (api *apiPermissionLayer) CreateUploadSession(us *model.UploadSession) (*model.UploadSession, error) {
+	if appErr := api.check("CreateUploadSession"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateUploadSession(us)
+}
+
+func (api *apiPermissionLayer) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo, error) {
+	if appErr := api.check("UploadData"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UploadData(us, rd)
+}
+
+func (api *apiPermissionLayer) GetUploadSession(uploadID string) (*model.UploadSession, error) {
+	if appErr := api.check("GetUploadSession"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetUploadSession(uploadID)
+}
+
+func (api *apiPermissionLayer) SendPushNotification(notification *model.PushNotification, userID string) *model.AppError {
+	if appErr := api.check("SendPushNotification"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.SendPushNotification(notification, userID)
+}
+
+func (api *apiPermissionLayer) UpdateUserAuth(userID string, userAuth *model.UserAuth) (*model.UserAuth, *model.AppError) {
+	if appErr := api.check("UpdateUserAuth"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateUserAuth(userID, userAuth)
+}
+
+func (api *apiPermissionLayer) RegisterPluginForSharedChannels(opts model.RegisterPluginOpts) (remoteID string, err error) {
+	if appErr := api.check("RegisterPluginForSharedChannels"); appErr != nil {
+		return "", appErr
+	}
+	return api.apiImpl.RegisterPluginForSharedChannels(opts)
+}
+
+func (api *apiPermissionLayer) UnregisterPluginForSharedChannels(pluginID string) error {
+	if appErr := api.check("UnregisterPluginForSharedChannels"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UnregisterPluginForSharedChannels(pluginID)
+}
+
+func (api *apiPermissionLayer) ShareChannel(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	if appErr := api.check("ShareChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.ShareChannel(sc)
+}
+
+func (api *apiPermissionLayer) UpdateSharedChannel(sc *model.SharedChannel) (*model.SharedChannel, error) {
+	if appErr := api.check("UpdateSharedChannel"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateSharedChannel(sc)
+}
+
+func (api *apiPermissionLayer) UnshareChannel(channelID string) (unshared bool, err error) {
+	if appErr := api.check("UnshareChannel"); appErr != nil {
+		return false, appErr
+	}
+	return api.apiImpl.UnshareChannel(channelID)
+}
+
+func (api *apiPermissionLayer) UpdateSharedChannelCursor(channelID, remoteID string, cusror model.GetPostsSinceForSyncCursor) error {
+	if appErr := api.check("UpdateSharedChannelCursor"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UpdateSharedChannelCursor(channelID, remoteID, cusror)
+}
+
+func (api *apiPermissionLayer) SyncSharedChannel(channelID string) error {
+	if appErr := api.check("SyncSharedChannel"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.SyncSharedChannel(channelID)
+}
+
+func (api *apiPermissionLayer) InviteRemoteToChannel(channelID string, remoteID string, userID string, shareIfNotShared bool) error {
+	if appErr := api.check("InviteRemoteToChannel"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.InviteRemoteToChannel(channelID, remoteID, userID, shareIfNotShared)
+}
+
+func (api *apiPermissionLayer) UninviteRemoteFromChannel(channelID string, remoteID string) error {
+	if appErr := api.check("UninviteRemoteFromChannel"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UninviteRemoteFromChannel(channelID, remoteID)
+}
+
+func (api *apiPermissionLayer) UpsertGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	if appErr := api.check("UpsertGroupMember"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpsertGroupMember(groupID, userID)
+}
+
+func (api *apiPermissionLayer) UpsertGroupMembers(groupID string, userIDs []string) ([]*model.GroupMember, *model.AppError) {
+	if appErr := api.check("UpsertGroupMembers"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpsertGroupMembers(groupID, userIDs)
+}
+
+func (api *apiPermissionLayer) GetGroupByRemoteID(remoteID string, groupSource model.GroupSource) (*model.Group, *model.AppError) {
+	if appErr := api.check("GetGroupByRemoteID"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroupByRemoteID(remoteID, groupSource)
+}
+
+func (api *apiPermissionLayer) CreateGroup(group *model.Group) (*model.Group, *model.AppError) {
+	if appErr := api.check("CreateGroup"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateGroup(group)
+}
+
+func (api *apiPermissionLayer) UpdateGroup(group *model.Group) (*model.Group, *model.AppError) {
+	if appErr := api.check("UpdateGroup"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateGroup(group)
+}
+
+func (api *apiPermissionLayer) DeleteGroup(groupID string) (*model.Group, *model.AppError) {
+	if appErr := api.check("DeleteGroup"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.DeleteGroup(groupID)
+}
+
+func (api *apiPermissionLayer) RestoreGroup(groupID string) (*model.Group, *model.AppError) {
+	if appErr := api.check("RestoreGroup"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.RestoreGroup(groupID)
+}
+
+func (api *apiPermissionLayer) DeleteGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError) {
+	if appErr := api.check("DeleteGroupMember"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.DeleteGroupMember(groupID, userID)
+}
+
+func (api *apiPermissionLayer) GetGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	if appErr := api.check("GetGroupSyncable"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroupSyncable(groupID, syncableID, syncableType)
+}
+
+func (api *apiPermissionLayer) GetGroupSyncables(groupID string, syncableType model.GroupSyncableType) ([]*model.GroupSyncable, *model.AppError) {
+	if appErr := api.check("GetGroupSyncables"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroupSyncables(groupID, syncableType)
+}
+
+func (api *apiPermissionLayer) UpsertGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	if appErr := api.check("UpsertGroupSyncable"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpsertGroupSyncable(groupSyncable)
+}
+
+func (api *apiPermissionLayer) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.GroupSyncable, *model.AppError) {
+	if appErr := api.check("UpdateGroupSyncable"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateGroupSyncable(groupSyncable)
+}
+
+func (api *apiPermissionLayer) DeleteGroupSyncable(groupID string, syncableID string, syncableType model.GroupSyncableType) (*model.GroupSyncable, *model.AppError) {
+	if appErr := api.check("DeleteGroupSyncable"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.DeleteGroupSyncable(groupID, syncableID, syncableType)
+}
+
+func (api *apiPermissionLayer) UpdateUserRoles(userID, newRoles string) (*model.User, *model.AppError) {
+	if appErr := api.check("UpdateUserRoles"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdateUserRoles(userID, newRoles)
+}
+
+func (api *apiPermissionLayer) GetPluginID() string {
+	if appErr := api.check("GetPluginID"); appErr != nil {
+		return ""
+	}
+	return api.apiImpl.GetPluginID()
+}
+
+func (api *apiPermissionLayer) GetGroups(page, perPage int, opts model.GroupSearchOpts, viewRestrictions *model.ViewUsersRestrictions) ([]*model.Group, *model.AppError) {
+	if appErr := api.check("GetGroups"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetGroups(page, perPage, opts, viewRestrictions)
+}
+
+func (api *apiPermissionLayer) CreateDefaultSyncableMemberships(params model.CreateDefaultMembershipParams) *model.AppError {
+	if appErr := api.check("CreateDefaultSyncableMemberships"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.CreateDefaultSyncableMemberships(params)
+}
+
+func (api *apiPermissionLayer) DeleteGroupConstrainedMemberships() *model.AppError {
+	if appErr := api.check("DeleteGroupConstrainedMemberships"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeleteGroupConstrainedMemberships()
+}
+
+func (api *apiPermissionLayer) CreatePropertyField(field *model.PropertyField) (*model.PropertyField, error) {
+	if appErr := api.check("CreatePropertyField"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreatePropertyField(field)
+}
+
+func (api *apiPermissionLayer) GetPropertyField(groupID, fieldID string) (*model.PropertyField, error) {
+	if appErr := api.check("GetPropertyField"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPropertyField(groupID, fieldID)
+}
+
+func (api *apiPermissionLayer) GetPropertyFields(groupID string, ids []string) ([]*model.PropertyField, error) {
+	if appErr := api.check("GetPropertyFields"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPropertyFields(groupID, ids)
+}
+
+func (api *apiPermissionLayer) UpdatePropertyField(groupID string, field *model.PropertyField) (*model.PropertyField, error) {
+	if appErr := api.check("UpdatePropertyField"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdatePropertyField(groupID, field)
+}
+
+func (api *apiPermissionLayer) DeletePropertyField(groupID, fieldID string) error {
+	if appErr := api.check("DeletePropertyField"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeletePropertyField(groupID, fieldID)
+}
+
+func (api *apiPermissionLayer) SearchPropertyFields(groupID, targetID string, opts model.PropertyFieldSearchOpts) ([]*model.PropertyField, error) {
+	if appErr := api.check("SearchPropertyFields"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.SearchPropertyFields(groupID, targetID, opts)
+}
+
+func (api *apiPermissionLayer) CreatePropertyValue(value *model.PropertyValue) (*model.PropertyValue, error) {
+	if appErr := api.check("CreatePropertyValue"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreatePropertyValue(value)
+}
+
+func (api *apiPermissionLayer) GetPropertyValue(groupID, valueID string) (*model.PropertyValue, error) {
+	if appErr := api.check("GetPropertyValue"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPropertyValue(groupID, valueID)
+}
+
+func (api *apiPermissionLayer) GetPropertyValues(groupID string, ids []string) ([]*model.PropertyValue, error) {
+	if appErr := api.check("GetPropertyValues"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPropertyValues(groupID, ids)
+}
+
+func (api *apiPermissionLayer) UpdatePropertyValue(groupID string, value *model.PropertyValue) (*model.PropertyValue, error) {
+	if appErr := api.check("UpdatePropertyValue"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdatePropertyValue(groupID, value)
+}
+
+func (api *apiPermissionLayer) UpsertPropertyValue(value *model.PropertyValue) (*model.PropertyValue, error) {
+	if appErr := api.check("UpsertPropertyValue"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpsertPropertyValue(value)
+}
+
+func (api *apiPermissionLayer) DeletePropertyValue(groupID, valueID string) error {
+	if appErr := api.check("DeletePropertyValue"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeletePropertyValue(groupID, valueID)
+}
+
+func (api *apiPermissionLayer) SearchPropertyValues(groupID, targetID string, opts model.PropertyValueSearchOpts) ([]*model.PropertyValue, error) {
+	if appErr := api.check("SearchPropertyValues"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.SearchPropertyValues(groupID, targetID, opts)
+}
+
+func (api *apiPermissionLayer) RegisterPropertyGroup(name string) (*model.PropertyGroup, error) {
+	if appErr := api.check("RegisterPropertyGroup"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.RegisterPropertyGroup(name)
+}
+
+func (api *apiPermissionLayer) GetPropertyGroup(name string) (*model.PropertyGroup, error) {
+	if appErr := api.check("GetPropertyGroup"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPropertyGroup(name)
+}
+
+func (api *apiPermissionLayer) GetPropertyFieldByName(groupID, targetID, name string) (*model.PropertyField, error) {
+	if appErr := api.check("GetPropertyFieldByName"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetPropertyFieldByName(groupID, targetID, name)
+}
+
+func (api *apiPermissionLayer) UpdatePropertyFields(groupID string, fields []*model.PropertyField) ([]*model.PropertyField, error) {
+	if appErr := api.check("UpdatePropertyFields"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdatePropertyFields(groupID, fields)
+}
+
+func (api *apiPermissionLayer) UpdatePropertyValues(groupID string, values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	if appErr := api.check("UpdatePropertyValues"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpdatePropertyValues(groupID, values)
+}
+
+func (api *apiPermissionLayer) UpsertPropertyValues(values []*model.PropertyValue) ([]*model.PropertyValue, error) {
+	if appErr := api.check("UpsertPropertyValues"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.UpsertPropertyValues(values)
+}
+
+func (api *apiPermissionLayer) DeletePropertyValuesForTarget(groupID, targetType, targetID string) error {
+	if appErr := api.check("DeletePropertyValuesForTarget"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeletePropertyValuesForTarget(groupID, targetType, targetID)
+}
+
+func (api *apiPermissionLayer) DeletePropertyValuesForField(groupID, fieldID string) error {
+	if appErr := api.check("DeletePropertyValuesForField"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.DeletePropertyValuesForField(groupID, fieldID)
+}
+
+func (api *apiPermissionLayer) LogAuditRec(rec *model.AuditRecord) {
+	if appErr := api.check("LogAuditRec"); appErr != nil {
+		return
+	}
+	api.apiImpl.LogAuditRec(rec)
+}
+
+func (api *apiPermissionLayer) LogAuditRecWithLevel(rec *model.AuditRecord, level mlog.Level) {
+	if appErr := api.check("LogAuditRecWithLevel"); appErr != nil {
+		return
+	}
+	api.apiImpl.LogAuditRecWithLevel(rec, level)
+}
+
+func (api *apiPermissionLayer) RegisterJobType(jobType string, options *model.PluginJobTypeOptions) *model.AppError {
+	if appErr := api.check("RegisterJobType"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RegisterJobType(jobType, options)
+}
+
+func (api *apiPermissionLayer) UnregisterJobType(jobType string) *model.AppError {
+	if appErr := api.check("UnregisterJobType"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UnregisterJobType(jobType)
+}
+
+func (api *apiPermissionLayer) CreateJob(jobType string, data map[string]string) (*model.Job, *model.AppError) {
+	if appErr := api.check("CreateJob"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.CreateJob(jobType, data)
+}
+
+func (api *apiPermissionLayer) GetJob(jobID string) (*model.Job, *model.AppError) {
+	if appErr := api.check("GetJob"); appErr != nil {
+		return nil, appErr
+	}
+	return api.apiImpl.GetJob(jobID)
+}
+
+func (api *apiPermissionLayer) UpdateJobProgress(jobID string, progress int64) *model.AppError {
+	if appErr := api.check("UpdateJobProgress"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UpdateJobProgress(jobID, progress)
+}
+
+func (api *apiPermissionLayer) RegisterDocumentExtractor(extractor *model.PluginDocumentExtractor) *model.AppError {
+	if appErr := api.check("RegisterDocumentExtractor"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RegisterDocumentExtractor(extractor)
+}
+
+func (api *apiPermissionLayer) UnregisterDocumentExtractor() *model.AppError {
+	if appErr := api.check("UnregisterDocumentExtractor"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UnregisterDocumentExtractor()
+}
+
+func (api *apiPermissionLayer) RegisterSearchEngine() *model.AppError {
+	if appErr := api.check("RegisterSearchEngine"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.RegisterSearchEngine()
+}
+
+func (api *apiPermissionLayer) UnregisterSearchEngine() *model.AppError {
+	if appErr := api.check("UnregisterSearchEngine"); appErr != nil {
+		return appErr
+	}
+	return api.apiImpl.UnregisterSearchEngine()
+}
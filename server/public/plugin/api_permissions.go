@@ -0,0 +1,330 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// APIPermissionSelfOnly marks the API methods which only concern the plugin itself, and so don't
+// require a permission.
+const APIPermissionSelfOnly = "self_only"
+
+// APIPermissions maps every API method to the manifest permission required to call it, or to
+// APIPermissionSelfOnly. Methods missing from the map are denied.
+var APIPermissions = map[string]string{
+	// The methods only concerning the plugin itself.
+	"LoadPluginConfiguration":     APIPermissionSelfOnly,
+	"GetPluginConfig":             APIPermissionSelfOnly,
+	"SavePluginConfig":            APIPermissionSelfOnly,
+	"GetBundlePath":               APIPermissionSelfOnly,
+	"GetPluginID":                 APIPermissionSelfOnly,
+	"IsEnterpriseReady":           APIPermissionSelfOnly,
+	"GetServerVersion":            APIPermissionSelfOnly,
+	"KVSet":                       APIPermissionSelfOnly,
+	"KVCompareAndSet":             APIPermissionSelfOnly,
+	"KVCompareAndDelete":          APIPermissionSelfOnly,
+	"KVSetWithOptions":            APIPermissionSelfOnly,
+	"KVSetWithExpiry":             APIPermissionSelfOnly,
+	"KVGet":                       APIPermissionSelfOnly,
+	"KVDelete":                    APIPermissionSelfOnly,
+	"KVDeleteAll":                 APIPermissionSelfOnly,
+	"KVList":                      APIPermissionSelfOnly,
+	"KVListWithValues":            APIPermissionSelfOnly,
+	"KVGetMany":                   APIPermissionSelfOnly,
+	"KVSetMany":                   APIPermissionSelfOnly,
+	"KVCompareAndSwapMany":        APIPermissionSelfOnly,
+	"LogDebug":                    APIPermissionSelfOnly,
+	"LogInfo":                     APIPermissionSelfOnly,
+	"LogError":                    APIPermissionSelfOnly,
+	"LogWarn":                     APIPermissionSelfOnly,
+	"LogAuditRec":                 APIPermissionSelfOnly,
+	"LogAuditRecWithLevel":        APIPermissionSelfOnly,
+	"PublishWebSocketEvent":       APIPermissionSelfOnly,
+	"PublishPluginClusterEvent":   APIPermissionSelfOnly,
+	"RegisterCollectionAndTopic":  APIPermissionSelfOnly,
+	"OpenInteractiveDialog":       APIPermissionSelfOnly,
+	"RegisterJobType":             APIPermissionSelfOnly,
+	"UnregisterJobType":           APIPermissionSelfOnly,
+	"CreateJob":                   APIPermissionSelfOnly,
+	"GetJob":                      APIPermissionSelfOnly,
+	"UpdateJobProgress":           APIPermissionSelfOnly,
+	"UnregisterDocumentExtractor": APIPermissionSelfOnly,
+	"UnregisterSearchEngine":      APIPermissionSelfOnly,
+
+	"GetConfig":            model.PluginPermissionReadConfig,
+	"GetUnsanitizedConfig": model.PluginPermissionReadConfig,
+	"GetLicense":           model.PluginPermissionReadConfig,
+	"GetSystemInstallDate": model.PluginPermissionReadConfig,
+	"GetDiagnosticId":      model.PluginPermissionReadConfig,
+	"GetTelemetryId":       model.PluginPermissionReadConfig,
+	"GetPlugins":           model.PluginPermissionReadConfig,
+	"GetPluginStatus":      model.PluginPermissionReadConfig,
+	"GetCloudLimits":       model.PluginPermissionReadConfig,
+
+	"SaveConfig":          model.PluginPermissionManageConfig,
+	"RequestTrialLicense": model.PluginPermissionManageConfig,
+
+	"EnablePlugin":  model.PluginPermissionManagePlugins,
+	"DisablePlugin": model.PluginPermissionManagePlugins,
+	"RemovePlugin":  model.PluginPermissionManagePlugins,
+	"InstallPlugin": model.PluginPermissionManagePlugins,
+
+	"PluginHTTP": model.PluginPermissionCallPlugins,
+
+	"GetUsers":               model.PluginPermissionReadUsers,
+	"GetUsersByIds":          model.PluginPermissionReadUsers,
+	"GetUser":                model.PluginPermissionReadUsers,
+	"GetUserByEmail":         model.PluginPermissionReadUsers,
+	"GetUserByUsername":      model.PluginPermissionReadUsers,
+	"GetUsersByUsernames":    model.PluginPermissionReadUsers,
+	"GetUsersInTeam":         model.PluginPermissionReadUsers,
+	"GetUsersInChannel":      model.PluginPermissionReadUsers,
+	"SearchUsers":            model.PluginPermissionReadUsers,
+	"GetPreferenceForUser":   model.PluginPermissionReadUsers,
+	"GetPreferencesForUser":  model.PluginPermissionReadUsers,
+	"GetUserStatus":          model.PluginPermissionReadUsers,
+	"GetUserStatusesByIds":   model.PluginPermissionReadUsers,
+	"GetLDAPUserAttributes":  model.PluginPermissionReadUsers,
+	"GetProfileImage":        model.PluginPermissionReadUsers,
+	"HasPermissionTo":        model.PluginPermissionReadUsers,
+	"HasPermissionToTeam":    model.PluginPermissionReadUsers,
+	"HasPermissionToChannel": model.PluginPermissionReadUsers,
+
+	"CreateUser":               model.PluginPermissionManageUsers,
+	"GetSession":               model.PluginPermissionManageUsers,
+	"ExecuteSlashCommand":      model.PluginPermissionManageUsers,
+	"DeleteUser":               model.PluginPermissionManageUsers,
+	"UpdateUser":               model.PluginPermissionManageUsers,
+	"UpdateUserActive":         model.PluginPermissionManageUsers,
+	"UpdateUserAuth":           model.PluginPermissionManageUsers,
+	"UpdateUserRoles":          model.PluginPermissionManageUsers,
+	"CreateSession":            model.PluginPermissionManageUsers,
+	"ExtendSessionExpiry":      model.PluginPermissionManageUsers,
+	"RevokeSession":            model.PluginPermissionManageUsers,
+	"CreateUserAccessToken":    model.PluginPermissionManageUsers,
+	"RevokeUserAccessToken":    model.PluginPermissionManageUsers,
+	"UpdatePreferencesForUser": model.PluginPermissionManageUsers,
+	"DeletePreferencesForUser": model.PluginPermissionManageUsers,
+	"UpdateUserStatus":         model.PluginPermissionManageUsers,
+	"SetUserStatusTimedDND":    model.PluginPermissionManageUsers,
+	"UpdateUserCustomStatus":   model.PluginPermissionManageUsers,
+	"RemoveUserCustomStatus":   model.PluginPermissionManageUsers,
+	"SetProfileImage":          model.PluginPermissionManageUsers,
+
+	"GetTeams":              model.PluginPermissionReadTeams,
+	"GetTeam":               model.PluginPermissionReadTeams,
+	"GetTeamByName":         model.PluginPermissionReadTeams,
+	"GetTeamsUnreadForUser": model.PluginPermissionReadTeams,
+	"SearchTeams":           model.PluginPermissionReadTeams,
+	"GetTeamsForUser":       model.PluginPermissionReadTeams,
+	"GetTeamMembers":        model.PluginPermissionReadTeams,
+	"GetTeamMember":         model.PluginPermissionReadTeams,
+	"GetTeamMembersForUser": model.PluginPermissionReadTeams,
+	"GetTeamStats":          model.PluginPermissionReadTeams,
+	"GetTeamIcon":           model.PluginPermissionReadTeams,
+
+	"CreateTeam":                  model.PluginPermissionManageTeams,
+	"DeleteTeam":                  model.PluginPermissionManageTeams,
+	"UpdateTeam":                  model.PluginPermissionManageTeams,
+	"CreateTeamMember":            model.PluginPermissionManageTeams,
+	"CreateTeamMembers":           model.PluginPermissionManageTeams,
+	"CreateTeamMembersGracefully": model.PluginPermissionManageTeams,
+	"DeleteTeamMember":            model.PluginPermissionManageTeams,
+	"UpdateTeamMemberRoles":       model.PluginPermissionManageTeams,
+	"SetTeamIcon":                 model.PluginPermissionManageTeams,
+	"RemoveTeamIcon":              model.PluginPermissionManageTeams,
+
+	"GetPublicChannelsForTeam":    model.PluginPermissionReadChannels,
+	"GetChannel":                  model.PluginPermissionReadChannels,
+	"GetChannelByName":            model.PluginPermissionReadChannels,
+	"GetChannelByNameForTeamName": model.PluginPermissionReadChannels,
+	"GetChannelsForTeamForUser":   model.PluginPermissionReadChannels,
+	"GetChannelStats":             model.PluginPermissionReadChannels,
+	"SearchChannels":              model.PluginPermissionReadChannels,
+	"GetChannelSidebarCategories": model.PluginPermissionReadChannels,
+	"GetChannelMember":            model.PluginPermissionReadChannels,
+	"GetChannelMembers":           model.PluginPermissionReadChannels,
+	"GetChannelMembersByIds":      model.PluginPermissionReadChannels,
+	"GetChannelMembersForUser":    model.PluginPermissionReadChannels,
+
+	"CreateChannel":                    model.PluginPermissionManageChannels,
+	"DeleteChannel":                    model.PluginPermissionManageChannels,
+	"UpdateChannel":                    model.PluginPermissionManageChannels,
+	"GetDirectChannel":                 model.PluginPermissionManageChannels,
+	"GetGroupChannel":                  model.PluginPermissionManageChannels,
+	"CreateChannelSidebarCategory":     model.PluginPermissionManageChannels,
+	"UpdateChannelSidebarCategories":   model.PluginPermissionManageChannels,
+	"AddChannelMember":                 model.PluginPermissionManageChannels,
+	"AddUserToChannel":                 model.PluginPermissionManageChannels,
+	"DeleteChannelMember":              model.PluginPermissionManageChannels,
+	"UpdateChannelMemberRoles":         model.PluginPermissionManageChannels,
+	"UpdateChannelMemberNotifications": model.PluginPermissionManageChannels,
+	"PatchChannelMembersNotifications": model.PluginPermissionManageChannels,
+
+	"GetPostThread":            model.PluginPermissionReadPosts,
+	"GetPost":                  model.PluginPermissionReadPosts,
+	"GetPostsSince":            model.PluginPermissionReadPosts,
+	"GetPostsAfter":            model.PluginPermissionReadPosts,
+	"GetPostsBefore":           model.PluginPermissionReadPosts,
+	"GetPostsForChannel":       model.PluginPermissionReadPosts,
+	"SearchPostsInTeam":        model.PluginPermissionReadPosts,
+	"SearchPostsInTeamForUser": model.PluginPermissionReadPosts,
+	"GetReactions":             model.PluginPermissionReadPosts,
+	"GetEmojiList":             model.PluginPermissionReadPosts,
+	"GetEmojiByName":           model.PluginPermissionReadPosts,
+	"GetEmoji":                 model.PluginPermissionReadPosts,
+	"GetEmojiImage":            model.PluginPermissionReadPosts,
+	"RegisterSearchEngine":     model.PluginPermissionReadPosts,
+
+	"CreatePost":          model.PluginPermissionManagePosts,
+	"UpdatePost":          model.PluginPermissionManagePosts,
+	"DeletePost":          model.PluginPermissionManagePosts,
+	"AddReaction":         model.PluginPermissionManagePosts,
+	"RemoveReaction":      model.PluginPermissionManagePosts,
+	"SendEphemeralPost":   model.PluginPermissionManagePosts,
+	"UpdateEphemeralPost": model.PluginPermissionManagePosts,
+	"DeleteEphemeralPost": model.PluginPermissionManagePosts,
+	"PublishUserTyping":   model.PluginPermissionManagePosts,
+
+	"GetFileInfo":               model.PluginPermissionReadFiles,
+	"GetFileInfos":              model.PluginPermissionReadFiles,
+	"GetFile":                   model.PluginPermissionReadFiles,
+	"GetFileLink":               model.PluginPermissionReadFiles,
+	"ReadFile":                  model.PluginPermissionReadFiles,
+	"GetUploadSession":          model.PluginPermissionReadFiles,
+	"RegisterDocumentExtractor": model.PluginPermissionReadFiles,
+
+	"CopyFileInfos":            model.PluginPermissionManageFiles,
+	"SetFileSearchableContent": model.PluginPermissionManageFiles,
+	"UploadFile":               model.PluginPermissionManageFiles,
+	"CreateUploadSession":      model.PluginPermissionManageFiles,
+	"UploadData":               model.PluginPermissionManageFiles,
+
+	"GetGroup":            model.PluginPermissionReadGroups,
+	"GetGroupByName":      model.PluginPermissionReadGroups,
+	"GetGroupMemberUsers": model.PluginPermissionReadGroups,
+	"GetGroupsBySource":   model.PluginPermissionReadGroups,
+	"GetGroupsForUser":    model.PluginPermissionReadGroups,
+	"GetGroupByRemoteID":  model.PluginPermissionReadGroups,
+	"GetGroupSyncable":    model.PluginPermissionReadGroups,
+	"GetGroupSyncables":   model.PluginPermissionReadGroups,
+	"GetGroups":           model.PluginPermissionReadGroups,
+
+	"UpsertGroupMember":                 model.PluginPermissionManageGroups,
+	"UpsertGroupMembers":                model.PluginPermissionManageGroups,
+	"CreateGroup":                       model.PluginPermissionManageGroups,
+	"UpdateGroup":                       model.PluginPermissionManageGroups,
+	"DeleteGroup":                       model.PluginPermissionManageGroups,
+	"RestoreGroup":                      model.PluginPermissionManageGroups,
+	"DeleteGroupMember":                 model.PluginPermissionManageGroups,
+	"UpsertGroupSyncable":               model.PluginPermissionManageGroups,
+	"UpdateGroupSyncable":               model.PluginPermissionManageGroups,
+	"DeleteGroupSyncable":               model.PluginPermissionManageGroups,
+	"CreateDefaultSyncableMemberships":  model.PluginPermissionManageGroups,
+	"DeleteGroupConstrainedMemberships": model.PluginPermissionManageGroups,
+
+	"GetPropertyField":       model.PluginPermissionReadProperties,
+	"GetPropertyFields":      model.PluginPermissionReadProperties,
+	"SearchPropertyFields":   model.PluginPermissionReadProperties,
+	"GetPropertyValue":       model.PluginPermissionReadProperties,
+	"GetPropertyValues":      model.PluginPermissionReadProperties,
+	"SearchPropertyValues":   model.PluginPermissionReadProperties,
+	"GetPropertyGroup":       model.PluginPermissionReadProperties,
+	"GetPropertyFieldByName": model.PluginPermissionReadProperties,
+
+	"CreatePropertyField":           model.PluginPermissionManageProperties,
+	"UpdatePropertyField":           model.PluginPermissionManageProperties,
+	"DeletePropertyField":           model.PluginPermissionManageProperties,
+	"CreatePropertyValue":           model.PluginPermissionManageProperties,
+	"UpdatePropertyValue":           model.PluginPermissionManageProperties,
+	"UpsertPropertyValue":           model.PluginPermissionManageProperties,
+	"DeletePropertyValue":           model.PluginPermissionManageProperties,
+	"RegisterPropertyGroup":         model.PluginPermissionManageProperties,
+	"UpdatePropertyFields":          model.PluginPermissionManageProperties,
+	"UpdatePropertyValues":          model.PluginPermissionManageProperties,
+	"UpsertPropertyValues":          model.PluginPermissionManageProperties,
+	"DeletePropertyValuesForTarget": model.PluginPermissionManageProperties,
+	"DeletePropertyValuesForField":  model.PluginPermissionManageProperties,
+
+	"CreateBot":          model.PluginPermissionManageBots,
+	"PatchBot":           model.PluginPermissionManageBots,
+	"GetBot":             model.PluginPermissionManageBots,
+	"GetBots":            model.PluginPermissionManageBots,
+	"UpdateBotActive":    model.PluginPermissionManageBots,
+	"PermanentDeleteBot": model.PluginPermissionManageBots,
+	"EnsureBotUser":      model.PluginPermissionManageBots,
+
+	"RolesGrantPermission": model.PluginPermissionManageRoles,
+
+	"RegisterCommand":     model.PluginPermissionManageCommands,
+	"UnregisterCommand":   model.PluginPermissionManageCommands,
+	"CreateCommand":       model.PluginPermissionManageCommands,
+	"ListCommands":        model.PluginPermissionManageCommands,
+	"ListCustomCommands":  model.PluginPermissionManageCommands,
+	"ListPluginCommands":  model.PluginPermissionManageCommands,
+	"ListBuiltInCommands": model.PluginPermissionManageCommands,
+	"GetCommand":          model.PluginPermissionManageCommands,
+	"UpdateCommand":       model.PluginPermissionManageCommands,
+	"DeleteCommand":       model.PluginPermissionManageCommands,
+
+	"CreateOAuthApp": model.PluginPermissionManageOAuthApps,
+	"GetOAuthApp":    model.PluginPermissionManageOAuthApps,
+	"UpdateOAuthApp": model.PluginPermissionManageOAuthApps,
+	"DeleteOAuthApp": model.PluginPermissionManageOAuthApps,
+
+	"RegisterPluginForSharedChannels":   model.PluginPermissionManageSharedChannels,
+	"UnregisterPluginForSharedChannels": model.PluginPermissionManageSharedChannels,
+	"ShareChannel":                      model.PluginPermissionManageSharedChannels,
+	"UpdateSharedChannel":               model.PluginPermissionManageSharedChannels,
+	"UnshareChannel":                    model.PluginPermissionManageSharedChannels,
+	"UpdateSharedChannelCursor":         model.PluginPermissionManageSharedChannels,
+	"SyncSharedChannel":                 model.PluginPermissionManageSharedChannels,
+	"InviteRemoteToChannel":             model.PluginPermissionManageSharedChannels,
+	"UninviteRemoteFromChannel":         model.PluginPermissionManageSharedChannels,
+
+	"SendMail":             model.PluginPermissionSendNotifications,
+	"SendPushNotification": model.PluginPermissionSendNotifications,
+}
+
+// apiPermissionLayer checks the permission of every API method before calling it.
+type apiPermissionLayer struct {
+	apiImpl         API
+	checkPermission func(method, permission string) *model.AppError
+}
+
+// NewAPIPermissionLayer wraps the given API so that every method first checks its permission
+// from APIPermissions with checkPermission. Denied calls return checkPermission's error, along
+// with zero values for the other results, or an HTTP response carrying the error.
+func NewAPIPermissionLayer(apiImpl API, checkPermission func(method, permission string) *model.AppError) API {
+	return &apiPermissionLayer{
+		apiImpl:         apiImpl,
+		checkPermission: checkPermission,
+	}
+}
+
+// deniedHTTPResponse is the response of the HTTP methods of the API when they are denied.
+func deniedHTTPResponse(appErr *model.AppError) *http.Response {
+	return &http.Response{
+		Status:     http.StatusText(appErr.StatusCode),
+		StatusCode: appErr.StatusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(appErr.ToJSON())),
+	}
+}
+
+func (api *apiPermissionLayer) check(method string) *model.AppError {
+	permission, ok := APIPermissions[method]
+	if !ok {
+		return model.NewAppError(method, "plugin.api.permission_unmapped.app_error", map[string]any{"Method": method}, "", http.StatusForbidden)
+	}
+	if permission == APIPermissionSelfOnly {
+		return nil
+	}
+	return api.checkPermission(method, permission)
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestAPIPermissions(t *testing.T) {
+	apiType := reflect.TypeFor[API]()
+
+	t.Run("every method is mapped", func(t *testing.T) {
+		for i := range apiType.NumMethod() {
+			name := apiType.Method(i).Name
+			assert.Contains(t, APIPermissions, name, "API method %s has no permission, add it to APIPermissions", name)
+		}
+	})
+
+	t.Run("every mapping is valid", func(t *testing.T) {
+		for name, permission := range APIPermissions {
+			_, ok := apiType.MethodByName(name)
+			assert.True(t, ok, "APIPermissions maps %s, which isn't an API method", name)
+			assert.True(t, permission == APIPermissionSelfOnly || model.IsValidPluginPermission(permission), "API method %s maps to the unknown permission %s", name, permission)
+		}
+	})
+}
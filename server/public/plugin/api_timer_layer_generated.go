@@ -1035,6 +1035,34 @@ func (api *apiTimerLayer) KVList(page, perPage int) ([]string, *model.AppError)
 	return _returnsA, _returnsB
 }
 
+func (api *apiTimerLayer) KVListWithValues(prefix string, page, perPage int) ([]*model.PluginKeyValue, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVListWithValues(prefix, page, perPage)
+	api.recordTime(startTime, "KVListWithValues", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVGetMany(keys)
+	api.recordTime(startTime, "KVGetMany", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) KVSetMany(values map[string][]byte, expireInSeconds int64) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.KVSetMany(values, expireInSeconds)
+	api.recordTime(startTime, "KVSetMany", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) KVCompareAndSwapMany(operations []*model.PluginKVCompareAndSwap) (bool, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVCompareAndSwapMany(operations)
+	api.recordTime(startTime, "KVCompareAndSwapMany", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
 func (api *apiTimerLayer) PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast) {
 	startTime := timePkg.Now()
 	api.apiImpl.PublishWebSocketEvent(event, payload, broadcast)
@@ -1657,3 +1685,66 @@ func (api *apiTimerLayer) LogAuditRecWithLevel(rec *model.AuditRecord, level mlo
 	api.apiImpl.LogAuditRecWithLevel(rec, level)
 	api.recordTime(startTime, "LogAuditRecWithLevel", true)
 }
+
+func (api *apiTimerLayer) RegisterJobType(jobType string, options *model.PluginJobTypeOptions) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterJobType(jobType, options)
+	api.recordTime(startTime, "RegisterJobType", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) UnregisterJobType(jobType string) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterJobType(jobType)
+	api.recordTime(startTime, "UnregisterJobType", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) CreateJob(jobType string, data map[string]string) (*model.Job, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.CreateJob(jobType, data)
+	api.recordTime(startTime, "CreateJob", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) GetJob(jobID string) (*model.Job, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.GetJob(jobID)
+	api.recordTime(startTime, "GetJob", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) UpdateJobProgress(jobID string, progress int64) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UpdateJobProgress(jobID, progress)
+	api.recordTime(startTime, "UpdateJobProgress", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) RegisterDocumentExtractor(extractor *model.PluginDocumentExtractor) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterDocumentExtractor(extractor)
+	api.recordTime(startTime, "RegisterDocumentExtractor", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) UnregisterDocumentExtractor() *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterDocumentExtractor()
+	api.recordTime(startTime, "UnregisterDocumentExtractor", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) RegisterSearchEngine() *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.RegisterSearchEngine()
+	api.recordTime(startTime, "RegisterSearchEngine", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) UnregisterSearchEngine() *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnregisterSearchEngine()
+	api.recordTime(startTime, "UnregisterSearchEngine", _returnsA == nil)
+	return _returnsA
+}
@@ -1199,6 +1199,484 @@ func (s *hooksRPCServer) OnSAMLLogin(args *Z_OnSAMLLoginArgs, returns *Z_OnSAMLL
 	return nil
 }
 
+func init() {
+	hookNameToId["MessageWillBeDeleted"] = MessageWillBeDeletedID
+}
+
+type Z_MessageWillBeDeletedArgs struct {
+	A *Context
+	B *model.Post
+}
+
+type Z_MessageWillBeDeletedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) MessageWillBeDeleted(c *Context, post *model.Post) string {
+	_args := &Z_MessageWillBeDeletedArgs{c, post}
+	_returns := &Z_MessageWillBeDeletedReturns{}
+	if g.implemented[MessageWillBeDeletedID] {
+		if err := g.client.Call("Plugin.MessageWillBeDeleted", _args, _returns); err != nil {
+			g.log.Error("RPC call MessageWillBeDeleted to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) MessageWillBeDeleted(args *Z_MessageWillBeDeletedArgs, returns *Z_MessageWillBeDeletedReturns) error {
+	if hook, ok := s.impl.(interface {
+		MessageWillBeDeleted(c *Context, post *model.Post) string
+	}); ok {
+		returns.A = hook.MessageWillBeDeleted(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook MessageWillBeDeleted called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelWillBeArchived"] = ChannelWillBeArchivedID
+}
+
+type Z_ChannelWillBeArchivedArgs struct {
+	A *Context
+	B *model.Channel
+}
+
+type Z_ChannelWillBeArchivedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) ChannelWillBeArchived(c *Context, channel *model.Channel) string {
+	_args := &Z_ChannelWillBeArchivedArgs{c, channel}
+	_returns := &Z_ChannelWillBeArchivedReturns{}
+	if g.implemented[ChannelWillBeArchivedID] {
+		if err := g.client.Call("Plugin.ChannelWillBeArchived", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelWillBeArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ChannelWillBeArchived(args *Z_ChannelWillBeArchivedArgs, returns *Z_ChannelWillBeArchivedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelWillBeArchived(c *Context, channel *model.Channel) string
+	}); ok {
+		returns.A = hook.ChannelWillBeArchived(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelWillBeArchived called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ChannelHasBeenUpdated"] = ChannelHasBeenUpdatedID
+}
+
+type Z_ChannelHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.Channel
+	C *model.Channel
+}
+
+type Z_ChannelHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel) {
+	_args := &Z_ChannelHasBeenUpdatedArgs{c, newChannel, oldChannel}
+	_returns := &Z_ChannelHasBeenUpdatedReturns{}
+	if g.implemented[ChannelHasBeenUpdatedID] {
+		if err := g.client.Call("Plugin.ChannelHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call ChannelHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) ChannelHasBeenUpdated(args *Z_ChannelHasBeenUpdatedArgs, returns *Z_ChannelHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel)
+	}); ok {
+		hook.ChannelHasBeenUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook ChannelHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["TeamHasBeenCreated"] = TeamHasBeenCreatedID
+}
+
+type Z_TeamHasBeenCreatedArgs struct {
+	A *Context
+	B *model.Team
+}
+
+type Z_TeamHasBeenCreatedReturns struct {
+}
+
+func (g *hooksRPCClient) TeamHasBeenCreated(c *Context, team *model.Team) {
+	_args := &Z_TeamHasBeenCreatedArgs{c, team}
+	_returns := &Z_TeamHasBeenCreatedReturns{}
+	if g.implemented[TeamHasBeenCreatedID] {
+		if err := g.client.Call("Plugin.TeamHasBeenCreated", _args, _returns); err != nil {
+			g.log.Error("RPC call TeamHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) TeamHasBeenCreated(args *Z_TeamHasBeenCreatedArgs, returns *Z_TeamHasBeenCreatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		TeamHasBeenCreated(c *Context, team *model.Team)
+	}); ok {
+		hook.TeamHasBeenCreated(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook TeamHasBeenCreated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserWillBeUpdated"] = UserWillBeUpdatedID
+}
+
+type Z_UserWillBeUpdatedArgs struct {
+	A *Context
+	B *model.User
+	C *model.User
+}
+
+type Z_UserWillBeUpdatedReturns struct {
+	A *model.User
+	B string
+}
+
+func (g *hooksRPCClient) UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string) {
+	_args := &Z_UserWillBeUpdatedArgs{c, newUser, oldUser}
+	_returns := &Z_UserWillBeUpdatedReturns{}
+	if g.implemented[UserWillBeUpdatedID] {
+		if err := g.client.Call("Plugin.UserWillBeUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call UserWillBeUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) UserWillBeUpdated(args *Z_UserWillBeUpdatedArgs, returns *Z_UserWillBeUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string)
+	}); ok {
+		returns.A, returns.B = hook.UserWillBeUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserWillBeUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["EmailNotificationWillBeSent"] = EmailNotificationWillBeSentID
+}
+
+type Z_EmailNotificationWillBeSentArgs struct {
+	A *model.EmailNotification
+	B *model.User
+	C *model.Post
+}
+
+type Z_EmailNotificationWillBeSentReturns struct {
+	A *model.EmailNotification
+	B string
+}
+
+func (g *hooksRPCClient) EmailNotificationWillBeSent(emailNotification *model.EmailNotification, recipient *model.User, post *model.Post) (*model.EmailNotification, string) {
+	_args := &Z_EmailNotificationWillBeSentArgs{emailNotification, recipient, post}
+	_returns := &Z_EmailNotificationWillBeSentReturns{}
+	if g.implemented[EmailNotificationWillBeSentID] {
+		if err := g.client.Call("Plugin.EmailNotificationWillBeSent", _args, _returns); err != nil {
+			g.log.Error("RPC call EmailNotificationWillBeSent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) EmailNotificationWillBeSent(args *Z_EmailNotificationWillBeSentArgs, returns *Z_EmailNotificationWillBeSentReturns) error {
+	if hook, ok := s.impl.(interface {
+		EmailNotificationWillBeSent(emailNotification *model.EmailNotification, recipient *model.User, post *model.Post) (*model.EmailNotification, string)
+	}); ok {
+		returns.A, returns.B = hook.EmailNotificationWillBeSent(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook EmailNotificationWillBeSent called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ExecuteJob"] = ExecuteJobID
+}
+
+type Z_ExecuteJobArgs struct {
+	A *Context
+	B *model.Job
+}
+
+type Z_ExecuteJobReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) ExecuteJob(c *Context, job *model.Job) error {
+	_args := &Z_ExecuteJobArgs{c, job}
+	_returns := &Z_ExecuteJobReturns{}
+	if g.implemented[ExecuteJobID] {
+		if err := g.client.Call("Plugin.ExecuteJob", _args, _returns); err != nil {
+			g.log.Error("RPC call ExecuteJob to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) ExecuteJob(args *Z_ExecuteJobArgs, returns *Z_ExecuteJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		ExecuteJob(c *Context, job *model.Job) error
+	}); ok {
+		returns.A = hook.ExecuteJob(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook ExecuteJob called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["ExtractDocumentText"] = ExtractDocumentTextID
+}
+
+type Z_ExtractDocumentTextArgs struct {
+	A *Context
+	B *model.FileInfo
+	C []byte
+}
+
+type Z_ExtractDocumentTextReturns struct {
+	A string
+	B error
+}
+
+func (g *hooksRPCClient) ExtractDocumentText(c *Context, fileInfo *model.FileInfo, content []byte) (string, error) {
+	_args := &Z_ExtractDocumentTextArgs{c, fileInfo, content}
+	_returns := &Z_ExtractDocumentTextReturns{}
+	if g.implemented[ExtractDocumentTextID] {
+		if err := g.client.Call("Plugin.ExtractDocumentText", _args, _returns); err != nil {
+			g.log.Error("RPC call ExtractDocumentText to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) ExtractDocumentText(args *Z_ExtractDocumentTextArgs, returns *Z_ExtractDocumentTextReturns) error {
+	if hook, ok := s.impl.(interface {
+		ExtractDocumentText(c *Context, fileInfo *model.FileInfo, content []byte) (string, error)
+	}); ok {
+		returns.A, returns.B = hook.ExtractDocumentText(args.A, args.B, args.C)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook ExtractDocumentText called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineIndexPost"] = SearchEngineIndexPostID
+}
+
+type Z_SearchEngineIndexPostArgs struct {
+	A *Context
+	B *model.Post
+	C string
+}
+
+type Z_SearchEngineIndexPostReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error {
+	_args := &Z_SearchEngineIndexPostArgs{c, post, teamID}
+	_returns := &Z_SearchEngineIndexPostReturns{}
+	if g.implemented[SearchEngineIndexPostID] {
+		if err := g.client.Call("Plugin.SearchEngineIndexPost", _args, _returns); err != nil {
+			g.log.Error("RPC call SearchEngineIndexPost to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineIndexPost(args *Z_SearchEngineIndexPostArgs, returns *Z_SearchEngineIndexPostReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error
+	}); ok {
+		returns.A = hook.SearchEngineIndexPost(args.A, args.B, args.C)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook SearchEngineIndexPost called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineIndexFile"] = SearchEngineIndexFileID
+}
+
+type Z_SearchEngineIndexFileArgs struct {
+	A *Context
+	B *model.FileInfo
+	C string
+}
+
+type Z_SearchEngineIndexFileReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineIndexFile(c *Context, fileInfo *model.FileInfo, channelID string) error {
+	_args := &Z_SearchEngineIndexFileArgs{c, fileInfo, channelID}
+	_returns := &Z_SearchEngineIndexFileReturns{}
+	if g.implemented[SearchEngineIndexFileID] {
+		if err := g.client.Call("Plugin.SearchEngineIndexFile", _args, _returns); err != nil {
+			g.log.Error("RPC call SearchEngineIndexFile to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineIndexFile(args *Z_SearchEngineIndexFileArgs, returns *Z_SearchEngineIndexFileReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineIndexFile(c *Context, fileInfo *model.FileInfo, channelID string) error
+	}); ok {
+		returns.A = hook.SearchEngineIndexFile(args.A, args.B, args.C)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook SearchEngineIndexFile called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineDelete"] = SearchEngineDeleteID
+}
+
+type Z_SearchEngineDeleteArgs struct {
+	A *Context
+	B *model.PluginSearchDeleteRequest
+}
+
+type Z_SearchEngineDeleteReturns struct {
+	A error
+}
+
+func (g *hooksRPCClient) SearchEngineDelete(c *Context, request *model.PluginSearchDeleteRequest) error {
+	_args := &Z_SearchEngineDeleteArgs{c, request}
+	_returns := &Z_SearchEngineDeleteReturns{}
+	if g.implemented[SearchEngineDeleteID] {
+		if err := g.client.Call("Plugin.SearchEngineDelete", _args, _returns); err != nil {
+			g.log.Error("RPC call SearchEngineDelete to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (s *hooksRPCServer) SearchEngineDelete(args *Z_SearchEngineDeleteArgs, returns *Z_SearchEngineDeleteReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineDelete(c *Context, request *model.PluginSearchDeleteRequest) error
+	}); ok {
+		returns.A = hook.SearchEngineDelete(args.A, args.B)
+		returns.A = encodableError(returns.A)
+	} else {
+		return encodableError(fmt.Errorf("Hook SearchEngineDelete called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineSearchPosts"] = SearchEngineSearchPostsID
+}
+
+type Z_SearchEngineSearchPostsArgs struct {
+	A *Context
+	B model.ChannelList
+	C []*model.SearchParams
+	D int
+	E int
+}
+
+type Z_SearchEngineSearchPostsReturns struct {
+	A []string
+	B model.PostSearchMatches
+	C error
+}
+
+func (g *hooksRPCClient) SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, error) {
+	_args := &Z_SearchEngineSearchPostsArgs{c, channels, searchParams, page, perPage}
+	_returns := &Z_SearchEngineSearchPostsReturns{}
+	if g.implemented[SearchEngineSearchPostsID] {
+		if err := g.client.Call("Plugin.SearchEngineSearchPosts", _args, _returns); err != nil {
+			g.log.Error("RPC call SearchEngineSearchPosts to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B, _returns.C
+}
+
+func (s *hooksRPCServer) SearchEngineSearchPosts(args *Z_SearchEngineSearchPostsArgs, returns *Z_SearchEngineSearchPostsReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, error)
+	}); ok {
+		returns.A, returns.B, returns.C = hook.SearchEngineSearchPosts(args.A, args.B, args.C, args.D, args.E)
+		returns.C = encodableError(returns.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook SearchEngineSearchPosts called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchEngineSearchFiles"] = SearchEngineSearchFilesID
+}
+
+type Z_SearchEngineSearchFilesArgs struct {
+	A *Context
+	B model.ChannelList
+	C []*model.SearchParams
+	D int
+	E int
+}
+
+type Z_SearchEngineSearchFilesReturns struct {
+	A []string
+	B error
+}
+
+func (g *hooksRPCClient) SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, error) {
+	_args := &Z_SearchEngineSearchFilesArgs{c, channels, searchParams, page, perPage}
+	_returns := &Z_SearchEngineSearchFilesReturns{}
+	if g.implemented[SearchEngineSearchFilesID] {
+		if err := g.client.Call("Plugin.SearchEngineSearchFiles", _args, _returns); err != nil {
+			g.log.Error("RPC call SearchEngineSearchFiles to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) SearchEngineSearchFiles(args *Z_SearchEngineSearchFilesArgs, returns *Z_SearchEngineSearchFilesReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, error)
+	}); ok {
+		returns.A, returns.B = hook.SearchEngineSearchFiles(args.A, args.B, args.C, args.D, args.E)
+		returns.B = encodableError(returns.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook SearchEngineSearchFiles called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -5362,6 +5840,124 @@ func (s *apiRPCServer) KVList(args *Z_KVListArgs, returns *Z_KVListReturns) erro
 	return nil
 }
 
+type Z_KVListWithValuesArgs struct {
+	A string
+	B int
+	C int
+}
+
+type Z_KVListWithValuesReturns struct {
+	A []*model.PluginKeyValue
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVListWithValues(prefix string, page, perPage int) ([]*model.PluginKeyValue, *model.AppError) {
+	_args := &Z_KVListWithValuesArgs{prefix, page, perPage}
+	_returns := &Z_KVListWithValuesReturns{}
+	if err := g.client.Call("Plugin.KVListWithValues", _args, _returns); err != nil {
+		log.Printf("RPC call to KVListWithValues API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVListWithValues(args *Z_KVListWithValuesArgs, returns *Z_KVListWithValuesReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVListWithValues(prefix string, page, perPage int) ([]*model.PluginKeyValue, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVListWithValues(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API KVListWithValues called but not implemented."))
+	}
+	return nil
+}
+
+type Z_KVGetManyArgs struct {
+	A []string
+}
+
+type Z_KVGetManyReturns struct {
+	A map[string][]byte
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	_args := &Z_KVGetManyArgs{keys}
+	_returns := &Z_KVGetManyReturns{}
+	if err := g.client.Call("Plugin.KVGetMany", _args, _returns); err != nil {
+		log.Printf("RPC call to KVGetMany API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVGetMany(args *Z_KVGetManyArgs, returns *Z_KVGetManyReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVGetMany(keys []string) (map[string][]byte, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVGetMany(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVGetMany called but not implemented."))
+	}
+	return nil
+}
+
+type Z_KVSetManyArgs struct {
+	A map[string][]byte
+	B int64
+}
+
+type Z_KVSetManyReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) KVSetMany(values map[string][]byte, expireInSeconds int64) *model.AppError {
+	_args := &Z_KVSetManyArgs{values, expireInSeconds}
+	_returns := &Z_KVSetManyReturns{}
+	if err := g.client.Call("Plugin.KVSetMany", _args, _returns); err != nil {
+		log.Printf("RPC call to KVSetMany API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) KVSetMany(args *Z_KVSetManyArgs, returns *Z_KVSetManyReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVSetMany(values map[string][]byte, expireInSeconds int64) *model.AppError
+	}); ok {
+		returns.A = hook.KVSetMany(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API KVSetMany called but not implemented."))
+	}
+	return nil
+}
+
+type Z_KVCompareAndSwapManyArgs struct {
+	A []*model.PluginKVCompareAndSwap
+}
+
+type Z_KVCompareAndSwapManyReturns struct {
+	A bool
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVCompareAndSwapMany(operations []*model.PluginKVCompareAndSwap) (bool, *model.AppError) {
+	_args := &Z_KVCompareAndSwapManyArgs{operations}
+	_returns := &Z_KVCompareAndSwapManyReturns{}
+	if err := g.client.Call("Plugin.KVCompareAndSwapMany", _args, _returns); err != nil {
+		log.Printf("RPC call to KVCompareAndSwapMany API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVCompareAndSwapMany(args *Z_KVCompareAndSwapManyArgs, returns *Z_KVCompareAndSwapManyReturns) error {
+	if hook, ok := s.impl.(interface {
+		KVCompareAndSwapMany(operations []*model.PluginKVCompareAndSwap) (bool, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVCompareAndSwapMany(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVCompareAndSwapMany called but not implemented."))
+	}
+	return nil
+}
+
 type Z_PublishWebSocketEventArgs struct {
 	A string
 	B map[string]any
@@ -7813,3 +8409,257 @@ func (s *apiRPCServer) DeletePropertyValuesForField(args *Z_DeletePropertyValues
 	}
 	return nil
 }
+
+type Z_RegisterJobTypeArgs struct {
+	A string
+	B *model.PluginJobTypeOptions
+}
+
+type Z_RegisterJobTypeReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) RegisterJobType(jobType string, options *model.PluginJobTypeOptions) *model.AppError {
+	_args := &Z_RegisterJobTypeArgs{jobType, options}
+	_returns := &Z_RegisterJobTypeReturns{}
+	if err := g.client.Call("Plugin.RegisterJobType", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterJobType API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterJobType(args *Z_RegisterJobTypeArgs, returns *Z_RegisterJobTypeReturns) error {
+	if hook, ok := s.impl.(interface {
+		RegisterJobType(jobType string, options *model.PluginJobTypeOptions) *model.AppError
+	}); ok {
+		returns.A = hook.RegisterJobType(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API RegisterJobType called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnregisterJobTypeArgs struct {
+	A string
+}
+
+type Z_UnregisterJobTypeReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) UnregisterJobType(jobType string) *model.AppError {
+	_args := &Z_UnregisterJobTypeArgs{jobType}
+	_returns := &Z_UnregisterJobTypeReturns{}
+	if err := g.client.Call("Plugin.UnregisterJobType", _args, _returns); err != nil {
+		log.Printf("RPC call to UnregisterJobType API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnregisterJobType(args *Z_UnregisterJobTypeArgs, returns *Z_UnregisterJobTypeReturns) error {
+	if hook, ok := s.impl.(interface {
+		UnregisterJobType(jobType string) *model.AppError
+	}); ok {
+		returns.A = hook.UnregisterJobType(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API UnregisterJobType called but not implemented."))
+	}
+	return nil
+}
+
+type Z_CreateJobArgs struct {
+	A string
+	B map[string]string
+}
+
+type Z_CreateJobReturns struct {
+	A *model.Job
+	B *model.AppError
+}
+
+func (g *apiRPCClient) CreateJob(jobType string, data map[string]string) (*model.Job, *model.AppError) {
+	_args := &Z_CreateJobArgs{jobType, data}
+	_returns := &Z_CreateJobReturns{}
+	if err := g.client.Call("Plugin.CreateJob", _args, _returns); err != nil {
+		log.Printf("RPC call to CreateJob API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) CreateJob(args *Z_CreateJobArgs, returns *Z_CreateJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		CreateJob(jobType string, data map[string]string) (*model.Job, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.CreateJob(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API CreateJob called but not implemented."))
+	}
+	return nil
+}
+
+type Z_GetJobArgs struct {
+	A string
+}
+
+type Z_GetJobReturns struct {
+	A *model.Job
+	B *model.AppError
+}
+
+func (g *apiRPCClient) GetJob(jobID string) (*model.Job, *model.AppError) {
+	_args := &Z_GetJobArgs{jobID}
+	_returns := &Z_GetJobReturns{}
+	if err := g.client.Call("Plugin.GetJob", _args, _returns); err != nil {
+		log.Printf("RPC call to GetJob API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) GetJob(args *Z_GetJobArgs, returns *Z_GetJobReturns) error {
+	if hook, ok := s.impl.(interface {
+		GetJob(jobID string) (*model.Job, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.GetJob(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API GetJob called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UpdateJobProgressArgs struct {
+	A string
+	B int64
+}
+
+type Z_UpdateJobProgressReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) UpdateJobProgress(jobID string, progress int64) *model.AppError {
+	_args := &Z_UpdateJobProgressArgs{jobID, progress}
+	_returns := &Z_UpdateJobProgressReturns{}
+	if err := g.client.Call("Plugin.UpdateJobProgress", _args, _returns); err != nil {
+		log.Printf("RPC call to UpdateJobProgress API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UpdateJobProgress(args *Z_UpdateJobProgressArgs, returns *Z_UpdateJobProgressReturns) error {
+	if hook, ok := s.impl.(interface {
+		UpdateJobProgress(jobID string, progress int64) *model.AppError
+	}); ok {
+		returns.A = hook.UpdateJobProgress(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API UpdateJobProgress called but not implemented."))
+	}
+	return nil
+}
+
+type Z_RegisterDocumentExtractorArgs struct {
+	A *model.PluginDocumentExtractor
+}
+
+type Z_RegisterDocumentExtractorReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) RegisterDocumentExtractor(extractor *model.PluginDocumentExtractor) *model.AppError {
+	_args := &Z_RegisterDocumentExtractorArgs{extractor}
+	_returns := &Z_RegisterDocumentExtractorReturns{}
+	if err := g.client.Call("Plugin.RegisterDocumentExtractor", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterDocumentExtractor API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterDocumentExtractor(args *Z_RegisterDocumentExtractorArgs, returns *Z_RegisterDocumentExtractorReturns) error {
+	if hook, ok := s.impl.(interface {
+		RegisterDocumentExtractor(extractor *model.PluginDocumentExtractor) *model.AppError
+	}); ok {
+		returns.A = hook.RegisterDocumentExtractor(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API RegisterDocumentExtractor called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnregisterDocumentExtractorArgs struct {
+}
+
+type Z_UnregisterDocumentExtractorReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) UnregisterDocumentExtractor() *model.AppError {
+	_args := &Z_UnregisterDocumentExtractorArgs{}
+	_returns := &Z_UnregisterDocumentExtractorReturns{}
+	if err := g.client.Call("Plugin.UnregisterDocumentExtractor", _args, _returns); err != nil {
+		log.Printf("RPC call to UnregisterDocumentExtractor API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnregisterDocumentExtractor(args *Z_UnregisterDocumentExtractorArgs, returns *Z_UnregisterDocumentExtractorReturns) error {
+	if hook, ok := s.impl.(interface {
+		UnregisterDocumentExtractor() *model.AppError
+	}); ok {
+		returns.A = hook.UnregisterDocumentExtractor()
+	} else {
+		return encodableError(fmt.Errorf("API UnregisterDocumentExtractor called but not implemented."))
+	}
+	return nil
+}
+
+type Z_RegisterSearchEngineArgs struct {
+}
+
+type Z_RegisterSearchEngineReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) RegisterSearchEngine() *model.AppError {
+	_args := &Z_RegisterSearchEngineArgs{}
+	_returns := &Z_RegisterSearchEngineReturns{}
+	if err := g.client.Call("Plugin.RegisterSearchEngine", _args, _returns); err != nil {
+		log.Printf("RPC call to RegisterSearchEngine API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) RegisterSearchEngine(args *Z_RegisterSearchEngineArgs, returns *Z_RegisterSearchEngineReturns) error {
+	if hook, ok := s.impl.(interface {
+		RegisterSearchEngine() *model.AppError
+	}); ok {
+		returns.A = hook.RegisterSearchEngine()
+	} else {
+		return encodableError(fmt.Errorf("API RegisterSearchEngine called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnregisterSearchEngineArgs struct {
+}
+
+type Z_UnregisterSearchEngineReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) UnregisterSearchEngine() *model.AppError {
+	_args := &Z_UnregisterSearchEngineArgs{}
+	_returns := &Z_UnregisterSearchEngineReturns{}
+	if err := g.client.Call("Plugin.UnregisterSearchEngine", _args, _returns); err != nil {
+		log.Printf("RPC call to UnregisterSearchEngine API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnregisterSearchEngine(args *Z_UnregisterSearchEngineArgs, returns *Z_UnregisterSearchEngineReturns) error {
+	if hook, ok := s.impl.(interface {
+		UnregisterSearchEngine() *model.AppError
+	}); ok {
+		returns.A = hook.UnregisterSearchEngine()
+	} else {
+		return encodableError(fmt.Errorf("API UnregisterSearchEngine called but not implemented."))
+	}
+	return nil
+}
@@ -60,6 +60,9 @@ type Environment struct {
 	prepackagedPlugins               []*PrepackagedPlugin
 	transitionallyPrepackagedPlugins []*PrepackagedPlugin
 	prepackagedPluginsLock           sync.RWMutex
+	wasmLimits                       WasmLimits
+	wasmLimitsLock                   sync.RWMutex
+	deactivatedHandler               func(id string)
 }
 
 func NewEnvironment(
@@ -281,7 +284,13 @@ func checkMinServerVersion(pluginInfo *model.BundleInfo) error {
 }
 
 func (env *Environment) startPluginServer(pluginInfo *model.BundleInfo, opts ...func(*supervisor, *plugin.ClientConfig) error) error {
-	sup, err := newSupervisor(pluginInfo, env.newAPIImpl(pluginInfo.Manifest), env.dbDriver, env.logger, env.metrics, opts...)
+	var sup *supervisor
+	var err error
+	if pluginInfo.Manifest.IsWasm() {
+		sup, err = newWasmSupervisor(pluginInfo, env.newAPIImpl(pluginInfo.Manifest), env.logger, env.metrics, env.getWasmLimits())
+	} else {
+		sup, err = newSupervisor(pluginInfo, env.newAPIImpl(pluginInfo.Manifest), env.dbDriver, env.logger, env.metrics, opts...)
+	}
 	if err != nil {
 		return errors.Wrapf(err, "unable to start plugin: %v", pluginInfo.Manifest.Id)
 	}
@@ -425,6 +434,10 @@ func (env *Environment) Reattach(manifest *model.Manifest, pluginReattachConfig
 		return errors.New("cannot reattach plugin without server component")
 	}
 
+	if pluginInfo.Manifest.IsWasm() {
+		return errors.New("cannot reattach plugin running in the WebAssembly runtime")
+	}
+
 	if pluginInfo.Manifest.HasWebapp() {
 		env.logger.Warn("Ignoring webapp for reattached plugin", mlog.String("plugin_id", id))
 	}
@@ -468,6 +481,10 @@ func (env *Environment) Deactivate(id string) bool {
 		rp.supervisor.Shutdown()
 	}
 
+	if env.deactivatedHandler != nil {
+		env.deactivatedHandler(id)
+	}
+
 	return true
 }
 
@@ -595,6 +612,16 @@ func (env *Environment) HooksForPlugin(id string) (Hooks, error) {
 	return nil, fmt.Errorf("plugin not found: %v", id)
 }
 
+// PluginImplementsHook reports whether the given plugin is active and implements the given hook.
+func (env *Environment) PluginImplementsHook(id string, hookId int) bool {
+	if p, ok := env.registeredPlugins.Load(id); ok {
+		rp := p.(registeredPlugin)
+		return rp.supervisor != nil && env.IsActive(id) && rp.supervisor.Implements(hookId)
+	}
+
+	return false
+}
+
 // RunMultiPluginHook invokes hookRunnerFunc for each active plugin that implements the given hookId.
 //
 // If hookRunnerFunc returns false, iteration will not continue. The iteration order among active
@@ -641,6 +668,43 @@ func (env *Environment) PerformHealthCheck(id string) error {
 	return sup.PerformHealthCheck()
 }
 
+// SetDeactivatedHandler sets the function called after an active plugin is deactivated, be it
+// disabled, restarted or deactivated by the health check after crashing. It must be set before
+// any plugin is activated.
+func (env *Environment) SetDeactivatedHandler(handler func(id string)) {
+	env.deactivatedHandler = handler
+}
+
+// SetWasmLimits sets the resource limits of the plugins running in the WebAssembly runtime.
+// The limits are applied when the plugins are activated, so the active ones are restarted
+// if the limits changed.
+func (env *Environment) SetWasmLimits(limits WasmLimits) {
+	env.wasmLimitsLock.Lock()
+	changed := env.wasmLimits != limits
+	env.wasmLimits = limits
+	env.wasmLimitsLock.Unlock()
+
+	if !changed {
+		return
+	}
+
+	for _, bundle := range env.Active() {
+		if !bundle.Manifest.IsWasm() {
+			continue
+		}
+		env.logger.Info("Restarting plugin to apply the WebAssembly limits", mlog.String("plugin_id", bundle.Manifest.Id))
+		if err := env.RestartPlugin(bundle.Manifest.Id); err != nil {
+			env.logger.Error("Failed to restart plugin", mlog.String("plugin_id", bundle.Manifest.Id), mlog.Err(err))
+		}
+	}
+}
+
+func (env *Environment) getWasmLimits() WasmLimits {
+	env.wasmLimitsLock.RLock()
+	defer env.wasmLimitsLock.RUnlock()
+	return env.wasmLimits
+}
+
 // SetPrepackagedPlugins saves prepackaged plugins in the environment.
 func (env *Environment) SetPrepackagedPlugins(plugins, transitionalPlugins []*PrepackagedPlugin) {
 	env.prepackagedPluginsLock.Lock()
@@ -74,3 +74,21 @@ func TestAvailablePlugins(t *testing.T) {
 		require.Len(t, bundles, 0)
 	})
 }
+
+func TestDeactivatedHandler(t *testing.T) {
+	env, err := NewEnvironment(nil, nil, "", "", mlog.CreateConsoleTestLogger(t), nil)
+	require.NoError(t, err)
+
+	var deactivated []string
+	env.SetDeactivatedHandler(func(id string) {
+		deactivated = append(deactivated, id)
+	})
+
+	env.registeredPlugins.Store("active", registeredPlugin{State: model.PluginStateRunning})
+	env.registeredPlugins.Store("inactive", registeredPlugin{State: model.PluginStateFailedToStayRunning})
+
+	require.True(t, env.Deactivate("active"))
+	require.False(t, env.Deactivate("inactive"))
+	require.False(t, env.Deactivate("unknown"))
+	require.Equal(t, []string{"active"}, deactivated)
+}
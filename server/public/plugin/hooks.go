@@ -63,6 +63,19 @@ const (
 	OnSharedChannelsProfileImageSyncMsgID     = 44
 	GenerateSupportDataID                     = 45
 	OnSAMLLoginID                             = 46
+	MessageWillBeDeletedID                    = 47
+	ChannelWillBeArchivedID                   = 48
+	ChannelHasBeenUpdatedID                   = 49
+	TeamHasBeenCreatedID                      = 50
+	UserWillBeUpdatedID                       = 51
+	EmailNotificationWillBeSentID             = 52
+	ExecuteJobID                              = 53
+	ExtractDocumentTextID                     = 54
+	SearchEngineIndexPostID                   = 55
+	SearchEngineIndexFileID                   = 56
+	SearchEngineDeleteID                      = 57
+	SearchEngineSearchPostsID                 = 58
+	SearchEngineSearchFilesID                 = 59
 	TotalHooksID                              = iota
 )
 
@@ -402,4 +415,120 @@ type Hooks interface {
 	//
 	// Minimum server version: 10.7
 	OnSAMLLogin(c *Context, user *model.User, assertion *saml2.AssertionInfo) error
+
+	// MessageWillBeDeleted is invoked before a message is deleted from the database. Returning a
+	// non empty string will reject the deletion and the post will be kept.
+	//
+	// If you don't need to reject deletions, use MessageHasBeenDeleted instead.
+	//
+	// Note that this method will be called for posts deleted by plugins, including the plugin that
+	// deleted the post.
+	//
+	// Minimum server version: 10.12
+	MessageWillBeDeleted(c *Context, post *model.Post) string
+
+	// ChannelWillBeArchived is invoked before a channel is archived. Returning a non empty string
+	// will reject the archival and the channel will be kept active.
+	//
+	// Note that this method will be called for channels archived by plugins, including the plugin
+	// that archived the channel.
+	//
+	// Minimum server version: 10.12
+	ChannelWillBeArchived(c *Context, channel *model.Channel) string
+
+	// ChannelHasBeenUpdated is invoked after a channel has been updated in the database.
+	//
+	// Minimum server version: 10.12
+	ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel)
+
+	// TeamHasBeenCreated is invoked after a team was created.
+	//
+	// Minimum server version: 10.12
+	TeamHasBeenCreated(c *Context, team *model.Team)
+
+	// UserWillBeUpdated is invoked when a user is updated before it is committed to the database.
+	//
+	// To reject an update, return a non-empty string describing why the update was rejected.
+	// To modify the user, return the replacement, non-nil *model.User and an empty string.
+	// To allow the update without modification, return a nil *model.User and an empty string.
+	// The users are sanitized, so their password and MFA secret are empty, and neither those
+	// nor the id and creation time of the user can be changed.
+	//
+	// Note that this method will be called for users updated by plugins, including the plugin that
+	// updated the user.
+	//
+	// Minimum server version: 10.12
+	UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string)
+
+	// EmailNotificationWillBeSent is invoked before an email notification of a post is sent to
+	// a user. The notification holds the rendered subject and body, and the address the email
+	// is sent to.
+	//
+	// To reject a notification, return an non-empty string describing why the notification was rejected.
+	// To modify the notification, return the replacement, non-nil *model.EmailNotification and an empty string.
+	// To allow the notification without modification, return a nil *model.EmailNotification and an empty string.
+	//
+	// The hook is invoked off the posting path, so a slow plugin delays the email but not the post.
+	// When email batching is enabled, the hook is invoked for each post of the batch with Batched
+	// set and the rendered message of the post as the Body. Rejecting it leaves the post out of
+	// the batch, and changes to the To and Subject of a batched notification are ignored.
+	//
+	// Minimum server version: 10.12
+	EmailNotificationWillBeSent(emailNotification *model.EmailNotification, recipient *model.User, post *model.Post) (*model.EmailNotification, string)
+
+	// ExecuteJob is invoked to run a job of a type registered by the plugin via the
+	// RegisterJobType API. The job has been claimed by this node and is in progress.
+	//
+	// Return nil once the job is done, or an error describing why it failed. Long running jobs
+	// should periodically check for cancellation via the GetJob API and return early when it's
+	// requested, in which case the job is marked as canceled.
+	//
+	// Minimum server version: 10.12
+	ExecuteJob(c *Context, job *model.Job) error
+
+	// ExtractDocumentText is invoked to extract the text content of a file matching the document
+	// extractor registered by the plugin via the RegisterDocumentExtractor API. The extracted text
+	// is what file search matches against. For files found within archives, only the Name and
+	// MimeType of fileInfo are set.
+	//
+	// If an error is returned, the server falls back to its own extractors.
+	//
+	// Minimum server version: 10.12
+	ExtractDocumentText(c *Context, fileInfo *model.FileInfo, content []byte) (string, error)
+
+	// SearchEngineIndexPost is invoked to index a post in the search engine registered by the
+	// plugin via the RegisterSearchEngine API.
+	//
+	// Minimum server version: 10.12
+	SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error
+
+	// SearchEngineIndexFile is invoked to index a file, including its extracted content, in the
+	// search engine registered by the plugin via the RegisterSearchEngine API.
+	//
+	// Minimum server version: 10.12
+	SearchEngineIndexFile(c *Context, fileInfo *model.FileInfo, channelID string) error
+
+	// SearchEngineDelete is invoked to remove documents from the index of the search engine
+	// registered by the plugin via the RegisterSearchEngine API.
+	//
+	// Minimum server version: 10.12
+	SearchEngineDelete(c *Context, request *model.PluginSearchDeleteRequest) error
+
+	// SearchEngineSearchPosts is invoked to search posts in the search engine registered by the
+	// plugin via the RegisterSearchEngine API. Results must be restricted to the given channels,
+	// and are returned as post ids, most relevant first, along with the matched terms per post.
+	//
+	// If an error is returned, the server falls back to its next search engine, or the database.
+	//
+	// Minimum server version: 10.12
+	SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, error)
+
+	// SearchEngineSearchFiles is invoked to search files in the search engine registered by the
+	// plugin via the RegisterSearchEngine API. Results must be restricted to the given channels,
+	// and are returned as file ids, most relevant first.
+	//
+	// If an error is returned, the server falls back to its next search engine, or the database.
+	//
+	// Minimum server version: 10.12
+	SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, error)
 }
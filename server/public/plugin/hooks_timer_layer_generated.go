@@ -299,3 +299,92 @@ func (hooks *hooksTimerLayer) OnSAMLLogin(c *Context, user *model.User, assertio
 	hooks.recordTime(startTime, "OnSAMLLogin", _returnsA == nil)
 	return _returnsA
 }
+
+func (hooks *hooksTimerLayer) MessageWillBeDeleted(c *Context, post *model.Post) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.MessageWillBeDeleted(c, post)
+	hooks.recordTime(startTime, "MessageWillBeDeleted", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) ChannelWillBeArchived(c *Context, channel *model.Channel) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.ChannelWillBeArchived(c, channel)
+	hooks.recordTime(startTime, "ChannelWillBeArchived", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.ChannelHasBeenUpdated(c, newChannel, oldChannel)
+	hooks.recordTime(startTime, "ChannelHasBeenUpdated", true)
+}
+
+func (hooks *hooksTimerLayer) TeamHasBeenCreated(c *Context, team *model.Team) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.TeamHasBeenCreated(c, team)
+	hooks.recordTime(startTime, "TeamHasBeenCreated", true)
+}
+
+func (hooks *hooksTimerLayer) UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.UserWillBeUpdated(c, newUser, oldUser)
+	hooks.recordTime(startTime, "UserWillBeUpdated", true)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) EmailNotificationWillBeSent(emailNotification *model.EmailNotification, recipient *model.User, post *model.Post) (*model.EmailNotification, string) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.EmailNotificationWillBeSent(emailNotification, recipient, post)
+	hooks.recordTime(startTime, "EmailNotificationWillBeSent", true)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) ExecuteJob(c *Context, job *model.Job) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.ExecuteJob(c, job)
+	hooks.recordTime(startTime, "ExecuteJob", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) ExtractDocumentText(c *Context, fileInfo *model.FileInfo, content []byte) (string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.ExtractDocumentText(c, fileInfo, content)
+	hooks.recordTime(startTime, "ExtractDocumentText", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineIndexPost(c, post, teamID)
+	hooks.recordTime(startTime, "SearchEngineIndexPost", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineIndexFile(c *Context, fileInfo *model.FileInfo, channelID string) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineIndexFile(c, fileInfo, channelID)
+	hooks.recordTime(startTime, "SearchEngineIndexFile", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineDelete(c *Context, request *model.PluginSearchDeleteRequest) error {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.SearchEngineDelete(c, request)
+	hooks.recordTime(startTime, "SearchEngineDelete", _returnsA == nil)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB, _returnsC := hooks.hooksImpl.SearchEngineSearchPosts(c, channels, searchParams, page, perPage)
+	hooks.recordTime(startTime, "SearchEngineSearchPosts", _returnsC == nil)
+	return _returnsA, _returnsB, _returnsC
+}
+
+func (hooks *hooksTimerLayer) SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, error) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.SearchEngineSearchFiles(c, channels, searchParams, page, perPage)
+	hooks.recordTime(startTime, "SearchEngineSearchFiles", _returnsB == nil)
+	return _returnsA, _returnsB
+}
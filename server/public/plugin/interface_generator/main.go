@@ -43,6 +43,15 @@ var excludedPluginHooks = []string{
 	"ServeMetrics",
 }
 
+// excludedWasmHooks are the hooks whose arguments can't be encoded for WebAssembly plugins, along
+// with Implemented, all of which are implemented by hand in wasm.go.
+var excludedWasmHooks = []string{
+	"FileWillBeUploaded",
+	"Implemented",
+	"ServeHTTP",
+	"ServeMetrics",
+}
+
 type IHookEntry struct {
 	FuncName string
 	Args     *ast.FieldList
@@ -184,6 +193,56 @@ func FieldListToRecordSuccess(structPrefix string, fieldList *ast.FieldList) str
 	return fmt.Sprintf("%s == nil", result)
 }
 
+// FieldListToDeniedReturns returns the values to return when a call is denied: errName for the
+// error results, and the zero value for the others.
+func FieldListToDeniedReturns(errName string, fieldList *ast.FieldList, fileset *token.FileSet) string {
+	result := []string{}
+	if fieldList == nil || len(fieldList.List) == 0 {
+		return ""
+	}
+	for _, field := range fieldList.List {
+		value := zeroValue(field.Type, fileset)
+		if typeName := baseTypeName(field.Type); typeName == "error" || typeName == "AppError" {
+			value = errName
+		} else if typeName == "Response" {
+			value = "deniedHTTPResponse(" + errName + ")"
+		}
+		count := len(field.Names)
+		if count == 0 {
+			count = 1
+		}
+		for range count {
+			result = append(result, value)
+		}
+	}
+
+	return strings.Join(result, ", ")
+}
+
+func zeroValue(x ast.Expr, fileset *token.FileSet) string {
+	switch t := x.(type) {
+	case *ast.StarExpr, *ast.ArrayType, *ast.MapType, *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+		return "nil"
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return `""`
+		case "bool":
+			return "false"
+		case "any", "error":
+			return "nil"
+		case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64", "float32", "float64", "byte", "rune":
+			return "0"
+		}
+	}
+
+	typeNameBuffer := &bytes.Buffer{}
+	if err := printer.Fprint(typeNameBuffer, fileset, x); err != nil {
+		panic(err)
+	}
+	return "*new(" + typeNameBuffer.String() + ")"
+}
+
 func FieldListToStructList(fieldList *ast.FieldList, fileset *token.FileSet) string {
 	result := []string{}
 	if fieldList == nil || len(fieldList.List) == 0 {
@@ -431,6 +490,33 @@ func (api *apiTimerLayer) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
 {{end}}
 `
 
+var apiPermissionLayerTemplate = `// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make pluginapi"
+// DO NOT EDIT
+
+package plugin
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+{{range .APIMethods}}
+
+func (api *apiPermissionLayer) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
+	if appErr := api.check("{{.Name}}"); appErr != nil {
+		return {{deniedReturns "appErr" .Return}}
+	}
+	{{ if .Return }} return {{ end }} api.apiImpl.{{.Name}}({{valuesOnly .Params}})
+}
+
+{{end}}
+`
+
 var hooksTimerLayerTemplate = `// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
 // See LICENSE.txt for license information.
 
@@ -473,6 +559,41 @@ func (hooks *hooksTimerLayer) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return
 {{end}}
 `
 
+var wasmHooksTemplate = `// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make pluginapi"
+// DO NOT EDIT
+
+package plugin
+
+import (
+	saml2 "github.com/mattermost/gosaml2"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+{{range .HooksMethods}}
+
+func (h *wasmHooksClient) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
+	{{- if .Return}}
+	_returns := &struct {
+		{{structStyle .Return}}
+	}{}
+	{{- end}}
+	if h.implemented[{{.Name}}ID] {
+		if err := h.plugin.callHook("{{.Name}}", []any{ {{valuesOnly .Params}} }{{if .Return}}, {{destruct "&_returns." .Return}}{{end}}); err != nil {
+			h.log.Error("WASM call {{.Name}} to plugin failed.", mlog.Err(err))
+		}
+	}
+	{{- if .Return}}
+	return {{destruct "_returns." .Return}}
+	{{- end}}
+}
+
+{{end}}
+`
+
 type MethodParams struct {
 	Name   string
 	Params *ast.FieldList
@@ -550,6 +671,9 @@ func generatePluginTimerLayer(info *PluginInterfaceInfo) {
 		"shouldRecordSuccess": func(structPrefix string, fields *ast.FieldList) string {
 			return FieldListToRecordSuccess(structPrefix, fields)
 		},
+		"deniedReturns": func(errName string, fields *ast.FieldList) string {
+			return FieldListToDeniedReturns(errName, fields, info.FileSet)
+		},
 	}
 
 	// Prepare template params
@@ -570,8 +694,9 @@ func generatePluginTimerLayer(info *PluginInterfaceInfo) {
 	}
 
 	pluginTemplates := map[string]string{
-		"api_timer_layer_generated.go":   apiTimerLayerTemplate,
-		"hooks_timer_layer_generated.go": hooksTimerLayerTemplate,
+		"api_timer_layer_generated.go":      apiTimerLayerTemplate,
+		"api_permission_layer_generated.go": apiPermissionLayerTemplate,
+		"hooks_timer_layer_generated.go":    hooksTimerLayerTemplate,
 	}
 
 	for fileName, presetTemplate := range pluginTemplates {
@@ -597,6 +722,46 @@ func generatePluginTimerLayer(info *PluginInterfaceInfo) {
 	}
 }
 
+func generateWasmHooksGlue(info *PluginInterfaceInfo) {
+	templateFunctions := map[string]any{
+		"funcStyle":   func(fields *ast.FieldList) string { return FieldListToFuncList(fields, info.FileSet) },
+		"structStyle": func(fields *ast.FieldList) string { return FieldListToStructList(fields, info.FileSet) },
+		"valuesOnly":  func(fields *ast.FieldList) string { return FieldListToNames(fields, false) },
+		"destruct": func(structPrefix string, fields *ast.FieldList) string {
+			return FieldListDestruct(structPrefix, fields, info.FileSet)
+		},
+	}
+
+	parsedTemplate, err := template.New("hooks").Funcs(templateFunctions).Parse(wasmHooksTemplate)
+	if err != nil {
+		panic(err)
+	}
+
+	templateParams := HooksTemplateParams{}
+	for _, hook := range info.Hooks {
+		templateParams.HooksMethods = append(templateParams.HooksMethods, MethodParams{
+			Name:   hook.FuncName,
+			Params: hook.Args,
+			Return: hook.Results,
+		})
+	}
+
+	templateResult := &bytes.Buffer{}
+	err = parsedTemplate.Execute(templateResult, &templateParams)
+	if err != nil {
+		panic(err)
+	}
+
+	formatted, err := imports.Process("", templateResult.Bytes(), nil)
+	if err != nil {
+		panic(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(getPluginPackageDir(), "wasm_hooks_generated.go"), formatted, 0664); err != nil {
+		panic(err)
+	}
+}
+
 func getPluginPackageDir() string {
 	dirs, err := goList("github.com/mattermost/mattermost/server/public/plugin")
 	if err != nil {
@@ -645,11 +810,18 @@ func main() {
 	log.Println("Generating plugin hooks glue")
 	generateHooksGlue(removeExcluded(forRPC, excludedPluginHooks))
 
-	// Generate plugin timer layers
-	log.Println("Generating plugin timer glue")
+	// Generate plugin timer and permission layers
+	log.Println("Generating plugin timer and permission glue")
 	forPlugins, err := getPluginInfo(pluginPackageDir)
 	if err != nil {
 		fmt.Println("Unable to get plugin info: " + err.Error())
 	}
 	generatePluginTimerLayer(forPlugins)
+
+	log.Println("Generating WebAssembly plugin hooks glue")
+	forWasm, err := getPluginInfo(pluginPackageDir)
+	if err != nil {
+		fmt.Println("Unable to get plugin info: " + err.Error())
+	}
+	generateWasmHooksGlue(removeExcluded(forWasm, excludedWasmHooks))
 }
@@ -326,6 +326,38 @@ func (_m *API) CreateGroup(group *model.Group) (*model.Group, *model.AppError) {
 	return r0, r1
 }
 
+// CreateJob provides a mock function with given fields: jobType, data
+func (_m *API) CreateJob(jobType string, data map[string]string) (*model.Job, *model.AppError) {
+	ret := _m.Called(jobType, data)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJob")
+	}
+
+	var r0 *model.Job
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, map[string]string) (*model.Job, *model.AppError)); ok {
+		return rf(jobType, data)
+	}
+	if rf, ok := ret.Get(0).(func(string, map[string]string) *model.Job); ok {
+		r0 = rf(jobType, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, map[string]string) *model.AppError); ok {
+		r1 = rf(jobType, data)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // CreateOAuthApp provides a mock function with given fields: app
 func (_m *API) CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
 	ret := _m.Called(app)
@@ -2316,6 +2348,38 @@ func (_m *API) GetGroupsForUser(userID string) ([]*model.Group, *model.AppError)
 	return r0, r1
 }
 
+// GetJob provides a mock function with given fields: jobID
+func (_m *API) GetJob(jobID string) (*model.Job, *model.AppError) {
+	ret := _m.Called(jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *model.Job
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) (*model.Job, *model.AppError)); ok {
+		return rf(jobID)
+	}
+	if rf, ok := ret.Get(0).(func(string) *model.Job); ok {
+		r0 = rf(jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Job)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) *model.AppError); ok {
+		r1 = rf(jobID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // GetLDAPUserAttributes provides a mock function with given fields: userID, attributes
 func (_m *API) GetLDAPUserAttributes(userID string, attributes []string) (map[string]string, *model.AppError) {
 	ret := _m.Called(userID, attributes)
@@ -4002,6 +4066,36 @@ func (_m *API) KVCompareAndSet(key string, oldValue []byte, newValue []byte) (bo
 	return r0, r1
 }
 
+// KVCompareAndSwapMany provides a mock function with given fields: operations
+func (_m *API) KVCompareAndSwapMany(operations []*model.PluginKVCompareAndSwap) (bool, *model.AppError) {
+	ret := _m.Called(operations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVCompareAndSwapMany")
+	}
+
+	var r0 bool
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func([]*model.PluginKVCompareAndSwap) (bool, *model.AppError)); ok {
+		return rf(operations)
+	}
+	if rf, ok := ret.Get(0).(func([]*model.PluginKVCompareAndSwap) bool); ok {
+		r0 = rf(operations)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func([]*model.PluginKVCompareAndSwap) *model.AppError); ok {
+		r1 = rf(operations)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVDelete provides a mock function with given fields: key
 func (_m *API) KVDelete(key string) *model.AppError {
 	ret := _m.Called(key)
@@ -4074,6 +4168,38 @@ func (_m *API) KVGet(key string) ([]byte, *model.AppError) {
 	return r0, r1
 }
 
+// KVGetMany provides a mock function with given fields: keys
+func (_m *API) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	ret := _m.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVGetMany")
+	}
+
+	var r0 map[string][]byte
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func([]string) (map[string][]byte, *model.AppError)); ok {
+		return rf(keys)
+	}
+	if rf, ok := ret.Get(0).(func([]string) map[string][]byte); ok {
+		r0 = rf(keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) *model.AppError); ok {
+		r1 = rf(keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVList provides a mock function with given fields: page, perPage
 func (_m *API) KVList(page int, perPage int) ([]string, *model.AppError) {
 	ret := _m.Called(page, perPage)
@@ -4106,6 +4232,38 @@ func (_m *API) KVList(page int, perPage int) ([]string, *model.AppError) {
 	return r0, r1
 }
 
+// KVListWithValues provides a mock function with given fields: prefix, page, perPage
+func (_m *API) KVListWithValues(prefix string, page int, perPage int) ([]*model.PluginKeyValue, *model.AppError) {
+	ret := _m.Called(prefix, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVListWithValues")
+	}
+
+	var r0 []*model.PluginKeyValue
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]*model.PluginKeyValue, *model.AppError)); ok {
+		return rf(prefix, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []*model.PluginKeyValue); ok {
+		r0 = rf(prefix, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PluginKeyValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) *model.AppError); ok {
+		r1 = rf(prefix, page, perPage)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVSet provides a mock function with given fields: key, value
 func (_m *API) KVSet(key string, value []byte) *model.AppError {
 	ret := _m.Called(key, value)
@@ -4126,6 +4284,26 @@ func (_m *API) KVSet(key string, value []byte) *model.AppError {
 	return r0
 }
 
+// KVSetMany provides a mock function with given fields: values, expireInSeconds
+func (_m *API) KVSetMany(values map[string][]byte, expireInSeconds int64) *model.AppError {
+	ret := _m.Called(values, expireInSeconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVSetMany")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(map[string][]byte, int64) *model.AppError); ok {
+		r0 = rf(values, expireInSeconds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // KVSetWithExpiry provides a mock function with given fields: key, value, expireInSeconds
 func (_m *API) KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError {
 	ret := _m.Called(key, value, expireInSeconds)
@@ -4579,6 +4757,46 @@ func (_m *API) RegisterCommand(command *model.Command) error {
 	return r0
 }
 
+// RegisterDocumentExtractor provides a mock function with given fields: extractor
+func (_m *API) RegisterDocumentExtractor(extractor *model.PluginDocumentExtractor) *model.AppError {
+	ret := _m.Called(extractor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterDocumentExtractor")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(*model.PluginDocumentExtractor) *model.AppError); ok {
+		r0 = rf(extractor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
+// RegisterJobType provides a mock function with given fields: jobType, options
+func (_m *API) RegisterJobType(jobType string, options *model.PluginJobTypeOptions) *model.AppError {
+	ret := _m.Called(jobType, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterJobType")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, *model.PluginJobTypeOptions) *model.AppError); ok {
+		r0 = rf(jobType, options)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // RegisterPluginForSharedChannels provides a mock function with given fields: opts
 func (_m *API) RegisterPluginForSharedChannels(opts model.RegisterPluginOpts) (string, error) {
 	ret := _m.Called(opts)
@@ -4637,6 +4855,26 @@ func (_m *API) RegisterPropertyGroup(name string) (*model.PropertyGroup, error)
 	return r0, r1
 }
 
+// RegisterSearchEngine provides a mock function with no fields
+func (_m *API) RegisterSearchEngine() *model.AppError {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for RegisterSearchEngine")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func() *model.AppError); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // RemovePlugin provides a mock function with given fields: id
 func (_m *API) RemovePlugin(id string) *model.AppError {
 	ret := _m.Called(id)
@@ -5323,6 +5561,46 @@ func (_m *API) UnregisterCommand(teamID string, trigger string) error {
 	return r0
 }
 
+// UnregisterDocumentExtractor provides a mock function with no fields
+func (_m *API) UnregisterDocumentExtractor() *model.AppError {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnregisterDocumentExtractor")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func() *model.AppError); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
+// UnregisterJobType provides a mock function with given fields: jobType
+func (_m *API) UnregisterJobType(jobType string) *model.AppError {
+	ret := _m.Called(jobType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnregisterJobType")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(jobType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UnregisterPluginForSharedChannels provides a mock function with given fields: pluginID
 func (_m *API) UnregisterPluginForSharedChannels(pluginID string) error {
 	ret := _m.Called(pluginID)
@@ -5341,6 +5619,26 @@ func (_m *API) UnregisterPluginForSharedChannels(pluginID string) error {
 	return r0
 }
 
+// UnregisterSearchEngine provides a mock function with no fields
+func (_m *API) UnregisterSearchEngine() *model.AppError {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnregisterSearchEngine")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func() *model.AppError); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UnshareChannel provides a mock function with given fields: channelID
 func (_m *API) UnshareChannel(channelID string) (bool, error) {
 	ret := _m.Called(channelID)
@@ -5643,6 +5941,26 @@ func (_m *API) UpdateGroupSyncable(groupSyncable *model.GroupSyncable) (*model.G
 	return r0, r1
 }
 
+// UpdateJobProgress provides a mock function with given fields: jobID, progress
+func (_m *API) UpdateJobProgress(jobID string, progress int64) *model.AppError {
+	ret := _m.Called(jobID, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateJobProgress")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, int64) *model.AppError); ok {
+		r0 = rf(jobID, progress)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UpdateOAuthApp provides a mock function with given fields: app
 func (_m *API) UpdateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError) {
 	ret := _m.Called(app)
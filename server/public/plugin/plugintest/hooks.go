@@ -27,6 +27,29 @@ func (_m *Hooks) ChannelHasBeenCreated(c *plugin.Context, channel *model.Channel
 	_m.Called(c, channel)
 }
 
+// ChannelHasBeenUpdated provides a mock function with given fields: c, newChannel, oldChannel
+func (_m *Hooks) ChannelHasBeenUpdated(c *plugin.Context, newChannel *model.Channel, oldChannel *model.Channel) {
+	_m.Called(c, newChannel, oldChannel)
+}
+
+// ChannelWillBeArchived provides a mock function with given fields: c, channel
+func (_m *Hooks) ChannelWillBeArchived(c *plugin.Context, channel *model.Channel) string {
+	ret := _m.Called(c, channel)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelWillBeArchived")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Channel) string); ok {
+		r0 = rf(c, channel)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // ConfigurationWillBeSaved provides a mock function with given fields: newCfg
 func (_m *Hooks) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config, error) {
 	ret := _m.Called(newCfg)
@@ -57,6 +80,36 @@ func (_m *Hooks) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config,
 	return r0, r1
 }
 
+// EmailNotificationWillBeSent provides a mock function with given fields: emailNotification, recipient, post
+func (_m *Hooks) EmailNotificationWillBeSent(emailNotification *model.EmailNotification, recipient *model.User, post *model.Post) (*model.EmailNotification, string) {
+	ret := _m.Called(emailNotification, recipient, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EmailNotificationWillBeSent")
+	}
+
+	var r0 *model.EmailNotification
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*model.EmailNotification, *model.User, *model.Post) (*model.EmailNotification, string)); ok {
+		return rf(emailNotification, recipient, post)
+	}
+	if rf, ok := ret.Get(0).(func(*model.EmailNotification, *model.User, *model.Post) *model.EmailNotification); ok {
+		r0 = rf(emailNotification, recipient, post)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EmailNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*model.EmailNotification, *model.User, *model.Post) string); ok {
+		r1 = rf(emailNotification, recipient, post)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // ExecuteCommand provides a mock function with given fields: c, args
 func (_m *Hooks) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
 	ret := _m.Called(c, args)
@@ -89,6 +142,52 @@ func (_m *Hooks) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 	return r0, r1
 }
 
+// ExecuteJob provides a mock function with given fields: c, job
+func (_m *Hooks) ExecuteJob(c *plugin.Context, job *model.Job) error {
+	ret := _m.Called(c, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecuteJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Job) error); ok {
+		r0 = rf(c, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExtractDocumentText provides a mock function with given fields: c, fileInfo, content
+func (_m *Hooks) ExtractDocumentText(c *plugin.Context, fileInfo *model.FileInfo, content []byte) (string, error) {
+	ret := _m.Called(c, fileInfo, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtractDocumentText")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.FileInfo, []byte) (string, error)); ok {
+		return rf(c, fileInfo, content)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.FileInfo, []byte) string); ok {
+		r0 = rf(c, fileInfo, content)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.FileInfo, []byte) error); ok {
+		r1 = rf(c, fileInfo, content)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FileWillBeUploaded provides a mock function with given fields: c, info, file, output
 func (_m *Hooks) FileWillBeUploaded(c *plugin.Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
 	ret := _m.Called(c, info, file, output)
@@ -194,6 +293,24 @@ func (_m *Hooks) MessageHasBeenUpdated(c *plugin.Context, newPost *model.Post, o
 	_m.Called(c, newPost, oldPost)
 }
 
+// MessageWillBeDeleted provides a mock function with given fields: c, post
+func (_m *Hooks) MessageWillBeDeleted(c *plugin.Context, post *model.Post) string {
+	ret := _m.Called(c, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MessageWillBeDeleted")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Post) string); ok {
+		r0 = rf(c, post)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // MessageWillBePosted provides a mock function with given fields: c, post
 func (_m *Hooks) MessageWillBePosted(c *plugin.Context, post *model.Post) (*model.Post, string) {
 	ret := _m.Called(c, post)
@@ -544,6 +661,129 @@ func (_m *Hooks) RunDataRetention(nowTime int64, batchSize int64) (int64, error)
 	return r0, r1
 }
 
+// SearchEngineDelete provides a mock function with given fields: c, request
+func (_m *Hooks) SearchEngineDelete(c *plugin.Context, request *model.PluginSearchDeleteRequest) error {
+	ret := _m.Called(c, request)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineDelete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.PluginSearchDeleteRequest) error); ok {
+		r0 = rf(c, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineIndexFile provides a mock function with given fields: c, fileInfo, channelID
+func (_m *Hooks) SearchEngineIndexFile(c *plugin.Context, fileInfo *model.FileInfo, channelID string) error {
+	ret := _m.Called(c, fileInfo, channelID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineIndexFile")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.FileInfo, string) error); ok {
+		r0 = rf(c, fileInfo, channelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineIndexPost provides a mock function with given fields: c, post, teamID
+func (_m *Hooks) SearchEngineIndexPost(c *plugin.Context, post *model.Post, teamID string) error {
+	ret := _m.Called(c, post, teamID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineIndexPost")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.Post, string) error); ok {
+		r0 = rf(c, post, teamID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SearchEngineSearchFiles provides a mock function with given fields: c, channels, searchParams, page, perPage
+func (_m *Hooks) SearchEngineSearchFiles(c *plugin.Context, channels model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, error) {
+	ret := _m.Called(c, channels, searchParams, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineSearchFiles")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) ([]string, error)); ok {
+		return rf(c, channels, searchParams, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) []string); ok {
+		r0 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) error); ok {
+		r1 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchEngineSearchPosts provides a mock function with given fields: c, channels, searchParams, page, perPage
+func (_m *Hooks) SearchEngineSearchPosts(c *plugin.Context, channels model.ChannelList, searchParams []*model.SearchParams, page int, perPage int) ([]string, model.PostSearchMatches, error) {
+	ret := _m.Called(c, channels, searchParams, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchEngineSearchPosts")
+	}
+
+	var r0 []string
+	var r1 model.PostSearchMatches
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) ([]string, model.PostSearchMatches, error)); ok {
+		return rf(c, channels, searchParams, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) []string); ok {
+		r0 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) model.PostSearchMatches); ok {
+		r1 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(model.PostSearchMatches)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(*plugin.Context, model.ChannelList, []*model.SearchParams, int, int) error); ok {
+		r2 = rf(c, channels, searchParams, page, perPage)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // ServeHTTP provides a mock function with given fields: c, w, r
 func (_m *Hooks) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
 	_m.Called(c, w, r)
@@ -554,6 +794,11 @@ func (_m *Hooks) ServeMetrics(c *plugin.Context, w http.ResponseWriter, r *http.
 	_m.Called(c, w, r)
 }
 
+// TeamHasBeenCreated provides a mock function with given fields: c, team
+func (_m *Hooks) TeamHasBeenCreated(c *plugin.Context, team *model.Team) {
+	_m.Called(c, team)
+}
+
 // UserHasBeenCreated provides a mock function with given fields: c, user
 func (_m *Hooks) UserHasBeenCreated(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
@@ -589,6 +834,36 @@ func (_m *Hooks) UserHasLoggedIn(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserWillBeUpdated provides a mock function with given fields: c, newUser, oldUser
+func (_m *Hooks) UserWillBeUpdated(c *plugin.Context, newUser *model.User, oldUser *model.User) (*model.User, string) {
+	ret := _m.Called(c, newUser, oldUser)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UserWillBeUpdated")
+	}
+
+	var r0 *model.User
+	var r1 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.User, *model.User) (*model.User, string)); ok {
+		return rf(c, newUser, oldUser)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.User, *model.User) *model.User); ok {
+		r0 = rf(c, newUser, oldUser)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, *model.User, *model.User) string); ok {
+		r1 = rf(c, newUser, oldUser)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	return r0, r1
+}
+
 // UserWillLogIn provides a mock function with given fields: c, user
 func (_m *Hooks) UserWillLogIn(c *plugin.Context, user *model.User) string {
 	ret := _m.Called(c, user)
@@ -31,6 +31,8 @@ type supervisor struct {
 	implemented  [TotalHooksID]bool
 	hooksClient  *hooksRPCClient
 	isReattached bool
+	// wasm is set instead of client for plugins running in the WebAssembly runtime.
+	wasm *wasmPlugin
 }
 
 type driverForPlugin struct {
@@ -49,13 +51,11 @@ func WithExecutableFromManifest(pluginInfo *model.BundleInfo) func(*supervisor,
 			return fmt.Errorf("backend executable not found for environment: %s/%s", runtime.GOOS, runtime.GOARCH)
 		}
 
-		executable = filepath.Clean(filepath.Join(".", executable))
-		if strings.HasPrefix(executable, "..") {
-			return fmt.Errorf("invalid backend executable: %s", executable)
+		executable, err := pluginExecutablePath(pluginInfo, executable)
+		if err != nil {
+			return err
 		}
 
-		executable = filepath.Join(pluginInfo.Path, executable)
-
 		cmd := exec.Command(executable)
 
 		// This doesn't add more security than before
@@ -76,6 +76,16 @@ func WithExecutableFromManifest(pluginInfo *model.BundleInfo) func(*supervisor,
 	}
 }
 
+// pluginExecutablePath resolves the given executable within the plugin bundle.
+func pluginExecutablePath(pluginInfo *model.BundleInfo, executable string) (string, error) {
+	executable = filepath.Clean(filepath.Join(".", executable))
+	if strings.HasPrefix(executable, "..") {
+		return "", fmt.Errorf("invalid backend executable: %s", executable)
+	}
+
+	return filepath.Join(pluginInfo.Path, executable), nil
+}
+
 func WithReattachConfig(pluginReattachConfig *model.PluginReattachConfig) func(*supervisor, *plugin.ClientConfig) error {
 	return func(sup *supervisor, clientConfig *plugin.ClientConfig) error {
 		clientConfig.Reattach = pluginReattachConfig.ToHashicorpPluginReattachmentConfig()
@@ -182,6 +192,10 @@ func (sup *supervisor) Shutdown() {
 		sup.client.Kill()
 	}
 
+	if sup.wasm != nil {
+		sup.wasm.close()
+	}
+
 	// Wait for API RPC server and DB RPC server to exit.
 	// And then shutdown conns.
 	if sup.hooksClient != nil {
@@ -220,6 +234,10 @@ func (sup *supervisor) PerformHealthCheck() error {
 func (sup *supervisor) Ping() error {
 	sup.lock.RLock()
 	defer sup.lock.RUnlock()
+	if sup.wasm != nil {
+		return sup.wasm.ping()
+	}
+
 	client, err := sup.client.Client()
 	if err != nil {
 		return err
@@ -0,0 +1,441 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// The WebAssembly runtime runs the server component of plugins whose manifest sets the
+// ManifestServerRuntimeWasm runtime, in a sandbox with no access to the file system, the network
+// or the environment. The module is instantiated as a WASI reactor and must export:
+//
+//	memory
+//	mm_malloc(size u32) u32: allocates size bytes, for the host to write arguments into.
+//	mm_free(ptr u32): frees the memory allocated by mm_malloc or returned to the host.
+//	mm_implemented() u64: returns the hooks implemented by the plugin.
+//	mm_hook(name_ptr, name_len, args_ptr, args_len u32) u64: runs the given hook.
+//
+// The host provides, in the "mattermost" module:
+//
+//	mm_api(name_ptr, name_len, args_ptr, args_len u32) u64: calls the given API method.
+//	mm_log(level, msg_ptr, msg_len u32): logs a message, level being one of debug, info, warn
+//	  and error, from 0 to 3.
+//
+// Arguments are JSON arrays, of the hook or API method parameters in order. Returned values pack
+// the address of a JSON response in the upper 32 bits and its length in the lower 32 bits. The
+// response is either {"results": [...]}, holding the hook or API method return values in order
+// with errors as strings, or {"error": "..."}. Responses of mm_api are allocated through
+// mm_malloc, and must be freed by the plugin.
+//
+// API methods are only available to the plugin if its manifest declares the permissions they
+// require, see APIPermissions. Hooks can't run while the plugin waits for an API call, so those
+// triggered by the API calls of the plugin itself, or concurrently invoked meanwhile, are skipped.
+
+const (
+	wasmMemoryPageSize = 64 * 1024
+
+	wasmHostModule = "mattermost"
+)
+
+// WasmLimits bounds the resources of the plugins running in the WebAssembly runtime.
+type WasmLimits struct {
+	// MemoryLimitMB is the maximum memory of a plugin.
+	MemoryLimitMB int
+	// HookTimeout is the maximum duration of a hook, past which the plugin is terminated,
+	// to be restarted by the health check job.
+	HookTimeout time.Duration
+}
+
+func (l WasmLimits) withDefaults() WasmLimits {
+	if l.MemoryLimitMB <= 0 {
+		l.MemoryLimitMB = model.PluginSettingsDefaultWasmMemoryLimitMB
+	}
+	if l.HookTimeout <= 0 {
+		l.HookTimeout = model.PluginSettingsDefaultWasmHookTimeout * time.Second
+	}
+	return l
+}
+
+type wasmResponse struct {
+	Results []json.RawMessage `json:"results,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+type wasmPlugin struct {
+	// mut is held while the module runs, including while it waits for an API call, so that
+	// concurrent calls don't interleave in the memory of the module. inHostCall is set while the
+	// module waits for an API call, during which the hooks it may trigger can't run.
+	mut        sync.Mutex
+	inHostCall atomic.Bool
+	runtime    wazero.Runtime
+	module     api.Module
+	manifest   *model.Manifest
+	apiImpl    API
+	logger     *mlog.Logger
+	limits     WasmLimits
+}
+
+func newWasmPlugin(pluginInfo *model.BundleInfo, apiImpl API, logger *mlog.Logger, limits WasmLimits) (retPlugin *wasmPlugin, retErr error) {
+	path, err := pluginExecutablePath(pluginInfo, pluginInfo.Manifest.Server.Executable)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the WebAssembly module")
+	}
+
+	w := &wasmPlugin{
+		manifest: pluginInfo.Manifest,
+		apiImpl:  apiImpl,
+		logger:   logger,
+		limits:   limits.withDefaults(),
+	}
+
+	ctx := context.Background()
+	w.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(w.limits.MemoryLimitMB*1024*1024/wasmMemoryPageSize)).
+		WithCloseOnContextDone(true))
+	defer func() {
+		if retErr != nil {
+			w.runtime.Close(context.Background())
+		}
+	}()
+
+	if _, err = wasi_snapshot_preview1.Instantiate(ctx, w.runtime); err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate WASI")
+	}
+
+	_, err = w.runtime.NewHostModuleBuilder(wasmHostModule).
+		NewFunctionBuilder().WithFunc(w.hostAPI).Export("mm_api").
+		NewFunctionBuilder().WithFunc(w.hostLog).Export("mm_log").
+		Instantiate(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate the host module")
+	}
+
+	compiled, err := w.runtime.CompileModule(ctx, code)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compile the WebAssembly module")
+	}
+
+	config := wazero.NewModuleConfig().
+		WithName(pluginInfo.Manifest.Id).
+		WithStartFunctions("_initialize").
+		WithStdout(logger.With(mlog.String("source", "plugin_stdout")).StdLogWriter()).
+		WithStderr(logger.With(mlog.String("source", "plugin_stderr")).StdLogWriter()).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+	// Compiling can take a while, only the initialization of the module is bound by the timeout.
+	initCtx, cancel := context.WithTimeout(ctx, w.limits.HookTimeout)
+	defer cancel()
+	w.module, err = w.runtime.InstantiateModule(initCtx, compiled, config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to instantiate the WebAssembly module")
+	}
+
+	for _, export := range []string{"mm_malloc", "mm_free", "mm_implemented", "mm_hook"} {
+		if w.module.ExportedFunction(export) == nil {
+			return nil, errors.Errorf("the WebAssembly module doesn't export %s", export)
+		}
+	}
+
+	return w, nil
+}
+
+func (w *wasmPlugin) close() {
+	w.runtime.Close(context.Background())
+}
+
+func (w *wasmPlugin) ping() error {
+	if w.module.IsClosed() {
+		return errors.New("the WebAssembly module was terminated")
+	}
+	return nil
+}
+
+// write copies data into memory allocated by the module.
+func (w *wasmPlugin) write(ctx context.Context, m api.Module, data []byte) (uint32, error) {
+	results, err := m.ExportedFunction("mm_malloc").Call(ctx, uint64(len(data)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to allocate memory")
+	}
+
+	ptr := uint32(results[0])
+	if !m.Memory().Write(ptr, data) {
+		return 0, errors.New("allocated memory is out of range")
+	}
+
+	return ptr, nil
+}
+
+// read copies data out of the memory of the module.
+func (w *wasmPlugin) read(m api.Module, ptr, length uint32) ([]byte, error) {
+	data, ok := m.Memory().Read(ptr, length)
+	if !ok {
+		return nil, errors.New("memory is out of range")
+	}
+
+	return append([]byte(nil), data...), nil
+}
+
+func (w *wasmPlugin) free(ctx context.Context, m api.Module, ptr uint32) {
+	if _, err := m.ExportedFunction("mm_free").Call(ctx, uint64(ptr)); err != nil {
+		w.logger.Warn("Failed to free WebAssembly plugin memory.", mlog.Err(err))
+	}
+}
+
+// call runs the given exported function with the given byte slices as arguments, and returns
+// the response it points to. Calls made while the module waits for an API call are rejected,
+// since they may have been triggered by that API call and would otherwise wait for it forever.
+func (w *wasmPlugin) call(function string, args ...[]byte) (*wasmResponse, error) {
+	if !w.mut.TryLock() {
+		if w.inHostCall.Load() {
+			return nil, errors.Errorf("failed to call %s: the plugin is waiting for an API call", function)
+		}
+		w.mut.Lock()
+	}
+	defer w.mut.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.limits.HookTimeout)
+	defer cancel()
+
+	params := make([]uint64, 0, len(args)*2)
+	for _, arg := range args {
+		ptr, err := w.write(ctx, w.module, arg)
+		if err != nil {
+			return nil, err
+		}
+		defer w.free(ctx, w.module, ptr)
+		params = append(params, uint64(ptr), uint64(len(arg)))
+	}
+
+	results, err := w.module.ExportedFunction(function).Call(ctx, params...)
+	if err != nil {
+		// The module is left in an undefined state by a trap, so it's terminated for the
+		// health check job to restart the plugin.
+		w.module.Close(context.Background())
+		return nil, errors.Wrapf(err, "failed to call %s", function)
+	}
+
+	ptr, length := uint32(results[0]>>32), uint32(results[0])
+	data, err := w.read(w.module, ptr, length)
+	if err != nil {
+		return nil, err
+	}
+	w.free(ctx, w.module, ptr)
+
+	var response wasmResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the response")
+	}
+	if response.Error != "" {
+		return nil, errors.New(response.Error)
+	}
+
+	return &response, nil
+}
+
+func (w *wasmPlugin) implemented() ([]string, error) {
+	response, err := w.call("mm_implemented")
+	if err != nil {
+		return nil, err
+	}
+
+	var hooks []string
+	if err := decodeWasmResults(response.Results, &hooks); err != nil {
+		return nil, err
+	}
+
+	return hooks, nil
+}
+
+// callHook runs the given hook, decoding its return values into results.
+func (w *wasmPlugin) callHook(name string, args []any, results ...any) error {
+	encodedArgs, err := json.Marshal(args)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the arguments")
+	}
+
+	response, err := w.call("mm_hook", []byte(name), encodedArgs)
+	if err != nil {
+		return err
+	}
+
+	return decodeWasmResults(response.Results, results...)
+}
+
+func decodeWasmResults(encoded []json.RawMessage, results ...any) error {
+	for i, result := range results {
+		if i >= len(encoded) {
+			break
+		}
+
+		// Errors are passed as strings, or null.
+		if errResult, ok := result.(*error); ok {
+			var message *string
+			if err := json.Unmarshal(encoded[i], &message); err != nil {
+				return errors.Wrapf(err, "failed to decode result %d", i)
+			}
+			if message != nil {
+				*errResult = errors.New(*message)
+			}
+			continue
+		}
+
+		if err := json.Unmarshal(encoded[i], result); err != nil {
+			return errors.Wrapf(err, "failed to decode result %d", i)
+		}
+	}
+
+	return nil
+}
+
+func (w *wasmPlugin) hostAPI(ctx context.Context, m api.Module, namePtr, nameLen, argsPtr, argsLen uint32) uint64 {
+	var response wasmResponse
+	name, err := w.read(m, namePtr, nameLen)
+	if err != nil {
+		response = wasmResponse{Error: err.Error()}
+	} else if args, err := w.read(m, argsPtr, argsLen); err != nil {
+		response = wasmResponse{Error: err.Error()}
+	} else {
+		w.inHostCall.Store(true)
+		response = w.dispatchAPI(string(name), args)
+		w.inHostCall.Store(false)
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		data, _ = json.Marshal(wasmResponse{Error: "failed to encode the response: " + err.Error()})
+	}
+
+	ptr, err := w.write(ctx, m, data)
+	if err != nil {
+		// Aborts the call of the module.
+		panic(err)
+	}
+
+	return uint64(ptr)<<32 | uint64(len(data))
+}
+
+func (w *wasmPlugin) hostLog(_ context.Context, m api.Module, level, msgPtr, msgLen uint32) {
+	msg, err := w.read(m, msgPtr, msgLen)
+	if err != nil {
+		w.logger.Warn("Failed to read the message logged by the WebAssembly plugin.", mlog.Err(err))
+		return
+	}
+
+	switch level {
+	case 0:
+		w.logger.Debug(string(msg))
+	case 1:
+		w.logger.Info(string(msg))
+	case 2:
+		w.logger.Warn(string(msg))
+	default:
+		w.logger.Error(string(msg))
+	}
+}
+
+// wasmHooksClient implements the hooks of a plugin running in the WebAssembly runtime. The
+// hooks taking arguments that can't be encoded are not supported.
+type wasmHooksClient struct {
+	plugin      *wasmPlugin
+	log         *mlog.Logger
+	implemented [TotalHooksID]bool
+}
+
+var _ Hooks = (*wasmHooksClient)(nil)
+
+func (h *wasmHooksClient) Implemented() ([]string, error) {
+	var hooks []string
+	for name, id := range hookNameToId {
+		if h.implemented[id] {
+			hooks = append(hooks, name)
+		}
+	}
+	return hooks, nil
+}
+
+func (h *wasmHooksClient) ServeHTTP(_ *Context, w http.ResponseWriter, r *http.Request) {
+	http.NotFound(w, r)
+}
+
+func (h *wasmHooksClient) ServeMetrics(_ *Context, w http.ResponseWriter, r *http.Request) {
+	http.NotFound(w, r)
+}
+
+func (h *wasmHooksClient) FileWillBeUploaded(_ *Context, info *model.FileInfo, _ io.Reader, _ io.Writer) (*model.FileInfo, string) {
+	return info, ""
+}
+
+func newWasmSupervisor(pluginInfo *model.BundleInfo, apiImpl API, parentLogger *mlog.Logger, metrics metricsInterface, limits WasmLimits) (retSupervisor *supervisor, retErr error) {
+	sup := supervisor{
+		pluginID: pluginInfo.Manifest.Id,
+	}
+
+	defer func() {
+		if retErr != nil {
+			sup.Shutdown()
+		}
+	}()
+
+	wrappedLogger := pluginInfo.WrapLogger(parentLogger)
+
+	wp, err := newWasmPlugin(pluginInfo, &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics}, wrappedLogger, limits)
+	if err != nil {
+		return nil, err
+	}
+	sup.wasm = wp
+
+	hooks := &wasmHooksClient{
+		plugin: wp,
+		log:    wrappedLogger,
+	}
+
+	impl, err := wp.implemented()
+	if err != nil {
+		return nil, err
+	}
+	for _, hookName := range impl {
+		hookId, ok := hookNameToId[hookName]
+		if hookName == "OnActivate" {
+			// OnActivate is always called on RPC plugins, so it's missing from hookNameToId.
+			hookId, ok = OnActivateID, true
+		}
+		if !ok {
+			continue
+		}
+		switch hookId {
+		case FileWillBeUploadedID, ServeHTTPID, ServeMetricsID:
+			wrappedLogger.Warn("Hook is not supported by the WebAssembly runtime.", mlog.String("hook", hookName))
+			continue
+		}
+		sup.implemented[hookId] = true
+		hooks.implemented[hookId] = true
+	}
+
+	sup.hooks = &hooksTimerLayer{pluginInfo.Manifest.Id, hooks, metrics}
+
+	return &sup, nil
+}
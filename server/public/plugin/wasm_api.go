@@ -0,0 +1,204 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// wasmAPIMethod is an API method available to the plugins running in the WebAssembly runtime.
+type wasmAPIMethod func(api API, args []json.RawMessage) ([]any, error)
+
+// wasmAPIMethods is the subset of the API available to the plugins running in the WebAssembly
+// runtime. The permissions they require are given by APIPermissions.
+var wasmAPIMethods = map[string]wasmAPIMethod{
+	"GetPluginConfig":  wasmMethod0(API.GetPluginConfig),
+	"GetServerVersion": wasmMethod0(API.GetServerVersion),
+	"KVGet":            wasmMethod1R2(API.KVGet),
+	"KVSet":            wasmMethod2(API.KVSet),
+	"KVSetWithExpiry":  wasmMethod3(API.KVSetWithExpiry),
+	"KVDelete":         wasmMethod1(API.KVDelete),
+	"KVList":           wasmMethod2R2(API.KVList),
+
+	"GetPost":           wasmMethod1R2(API.GetPost),
+	"GetPostThread":     wasmMethod1R2(API.GetPostThread),
+	"SearchPostsInTeam": wasmMethod2R2(API.SearchPostsInTeam),
+
+	"CreatePost":        wasmMethod1R2(API.CreatePost),
+	"UpdatePost":        wasmMethod1R2(API.UpdatePost),
+	"DeletePost":        wasmMethod1(API.DeletePost),
+	"SendEphemeralPost": wasmMethod2(API.SendEphemeralPost),
+
+	"GetChannel":       wasmMethod1R2(API.GetChannel),
+	"GetChannelByName": wasmMethod3R2(API.GetChannelByName),
+	"GetChannelMember": wasmMethod2R2(API.GetChannelMember),
+	"GetDirectChannel": wasmMethod2R2(API.GetDirectChannel),
+
+	"GetUser":           wasmMethod1R2(API.GetUser),
+	"GetUserByUsername": wasmMethod1R2(API.GetUserByUsername),
+	"GetUserByEmail":    wasmMethod1R2(API.GetUserByEmail),
+
+	"GetTeam":       wasmMethod1R2(API.GetTeam),
+	"GetTeamByName": wasmMethod1R2(API.GetTeamByName),
+
+	"RegisterCommand":   wasmMethod1(API.RegisterCommand),
+	"UnregisterCommand": wasmMethod2(API.UnregisterCommand),
+
+	"GetConfig": wasmMethod0(API.GetConfig),
+}
+
+// dispatchAPI calls the given API method with the encoded arguments on behalf of the plugin.
+func (w *wasmPlugin) dispatchAPI(name string, args []byte) wasmResponse {
+	method, ok := wasmAPIMethods[name]
+	if !ok {
+		return wasmResponse{Error: fmt.Sprintf("API method %s is not available", name)}
+	}
+
+	if permission := APIPermissions[name]; permission != APIPermissionSelfOnly && !w.manifest.HasPermission(permission) {
+		w.logger.Warn("WebAssembly plugin called an API method without the required permission.", mlog.String("method", name), mlog.String("permission", permission))
+		return wasmResponse{Error: fmt.Sprintf("API method %s requires the %s permission", name, permission)}
+	}
+
+	var decodedArgs []json.RawMessage
+	if err := json.Unmarshal(args, &decodedArgs); err != nil {
+		return wasmResponse{Error: "failed to decode the arguments: " + err.Error()}
+	}
+
+	results, err := method(w.apiImpl, decodedArgs)
+	if err != nil {
+		return wasmResponse{Error: err.Error()}
+	}
+
+	response := wasmResponse{Results: make([]json.RawMessage, 0, len(results))}
+	for _, result := range results {
+		encoded, err := json.Marshal(wasmResult(result))
+		if err != nil {
+			return wasmResponse{Error: "failed to encode the results: " + err.Error()}
+		}
+		response.Results = append(response.Results, encoded)
+	}
+
+	return response
+}
+
+// wasmResult encodes errors other than app errors as strings.
+func wasmResult(result any) any {
+	if _, ok := result.(*model.AppError); ok {
+		return result
+	}
+	if err, ok := result.(error); ok {
+		return err.Error()
+	}
+	return result
+}
+
+func wasmArg[T any](args []json.RawMessage, i int) (T, error) {
+	var arg T
+	if i >= len(args) {
+		return arg, errors.Errorf("missing argument %d", i)
+	}
+	if err := json.Unmarshal(args[i], &arg); err != nil {
+		return arg, errors.Wrapf(err, "failed to decode argument %d", i)
+	}
+	return arg, nil
+}
+
+func wasmMethod0[R any](fn func(API) R) wasmAPIMethod {
+	return func(api API, _ []json.RawMessage) ([]any, error) {
+		return []any{fn(api)}, nil
+	}
+}
+
+func wasmMethod1[A, R any](fn func(API, A) R) wasmAPIMethod {
+	return func(api API, args []json.RawMessage) ([]any, error) {
+		a, err := wasmArg[A](args, 0)
+		if err != nil {
+			return nil, err
+		}
+		return []any{fn(api, a)}, nil
+	}
+}
+
+func wasmMethod2[A, B, R any](fn func(API, A, B) R) wasmAPIMethod {
+	return func(api API, args []json.RawMessage) ([]any, error) {
+		a, err := wasmArg[A](args, 0)
+		if err != nil {
+			return nil, err
+		}
+		b, err := wasmArg[B](args, 1)
+		if err != nil {
+			return nil, err
+		}
+		return []any{fn(api, a, b)}, nil
+	}
+}
+
+func wasmMethod3[A, B, C, R any](fn func(API, A, B, C) R) wasmAPIMethod {
+	return func(api API, args []json.RawMessage) ([]any, error) {
+		a, err := wasmArg[A](args, 0)
+		if err != nil {
+			return nil, err
+		}
+		b, err := wasmArg[B](args, 1)
+		if err != nil {
+			return nil, err
+		}
+		c, err := wasmArg[C](args, 2)
+		if err != nil {
+			return nil, err
+		}
+		return []any{fn(api, a, b, c)}, nil
+	}
+}
+
+func wasmMethod1R2[A, R1, R2 any](fn func(API, A) (R1, R2)) wasmAPIMethod {
+	return func(api API, args []json.RawMessage) ([]any, error) {
+		a, err := wasmArg[A](args, 0)
+		if err != nil {
+			return nil, err
+		}
+		r1, r2 := fn(api, a)
+		return []any{r1, r2}, nil
+	}
+}
+
+func wasmMethod2R2[A, B, R1, R2 any](fn func(API, A, B) (R1, R2)) wasmAPIMethod {
+	return func(api API, args []json.RawMessage) ([]any, error) {
+		a, err := wasmArg[A](args, 0)
+		if err != nil {
+			return nil, err
+		}
+		b, err := wasmArg[B](args, 1)
+		if err != nil {
+			return nil, err
+		}
+		r1, r2 := fn(api, a, b)
+		return []any{r1, r2}, nil
+	}
+}
+
+func wasmMethod3R2[A, B, C, R1, R2 any](fn func(API, A, B, C) (R1, R2)) wasmAPIMethod {
+	return func(api API, args []json.RawMessage) ([]any, error) {
+		a, err := wasmArg[A](args, 0)
+		if err != nil {
+			return nil, err
+		}
+		b, err := wasmArg[B](args, 1)
+		if err != nil {
+			return nil, err
+		}
+		c, err := wasmArg[C](args, 2)
+		if err != nil {
+			return nil, err
+		}
+		r1, r2 := fn(api, a, b, c)
+		return []any{r1, r2}, nil
+	}
+}
@@ -0,0 +1,551 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Code generated by "make pluginapi"
+// DO NOT EDIT
+
+package plugin
+
+import (
+	saml2 "github.com/mattermost/gosaml2"
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (h *wasmHooksClient) OnActivate() error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[OnActivateID] {
+		if err := h.plugin.callHook("OnActivate", []any{}, &_returns.A); err != nil {
+			h.log.Error("WASM call OnActivate to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) OnDeactivate() error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[OnDeactivateID] {
+		if err := h.plugin.callHook("OnDeactivate", []any{}, &_returns.A); err != nil {
+			h.log.Error("WASM call OnDeactivate to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) OnConfigurationChange() error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[OnConfigurationChangeID] {
+		if err := h.plugin.callHook("OnConfigurationChange", []any{}, &_returns.A); err != nil {
+			h.log.Error("WASM call OnConfigurationChange to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) ExecuteCommand(c *Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
+	_returns := &struct {
+		A *model.CommandResponse
+		B *model.AppError
+	}{}
+	if h.implemented[ExecuteCommandID] {
+		if err := h.plugin.callHook("ExecuteCommand", []any{c, args}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call ExecuteCommand to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) UserHasBeenCreated(c *Context, user *model.User) {
+	if h.implemented[UserHasBeenCreatedID] {
+		if err := h.plugin.callHook("UserHasBeenCreated", []any{c, user}); err != nil {
+			h.log.Error("WASM call UserHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) UserWillLogIn(c *Context, user *model.User) string {
+	_returns := &struct {
+		A string
+	}{}
+	if h.implemented[UserWillLogInID] {
+		if err := h.plugin.callHook("UserWillLogIn", []any{c, user}, &_returns.A); err != nil {
+			h.log.Error("WASM call UserWillLogIn to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) UserHasLoggedIn(c *Context, user *model.User) {
+	if h.implemented[UserHasLoggedInID] {
+		if err := h.plugin.callHook("UserHasLoggedIn", []any{c, user}); err != nil {
+			h.log.Error("WASM call UserHasLoggedIn to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) MessageWillBePosted(c *Context, post *model.Post) (*model.Post, string) {
+	_returns := &struct {
+		A *model.Post
+		B string
+	}{}
+	if h.implemented[MessageWillBePostedID] {
+		if err := h.plugin.callHook("MessageWillBePosted", []any{c, post}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call MessageWillBePosted to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) MessageWillBeUpdated(c *Context, newPost, oldPost *model.Post) (*model.Post, string) {
+	_returns := &struct {
+		A *model.Post
+		B string
+	}{}
+	if h.implemented[MessageWillBeUpdatedID] {
+		if err := h.plugin.callHook("MessageWillBeUpdated", []any{c, newPost, oldPost}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call MessageWillBeUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) MessageHasBeenPosted(c *Context, post *model.Post) {
+	if h.implemented[MessageHasBeenPostedID] {
+		if err := h.plugin.callHook("MessageHasBeenPosted", []any{c, post}); err != nil {
+			h.log.Error("WASM call MessageHasBeenPosted to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) MessageHasBeenUpdated(c *Context, newPost, oldPost *model.Post) {
+	if h.implemented[MessageHasBeenUpdatedID] {
+		if err := h.plugin.callHook("MessageHasBeenUpdated", []any{c, newPost, oldPost}); err != nil {
+			h.log.Error("WASM call MessageHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) MessagesWillBeConsumed(posts []*model.Post) []*model.Post {
+	_returns := &struct {
+		A []*model.Post
+	}{}
+	if h.implemented[MessagesWillBeConsumedID] {
+		if err := h.plugin.callHook("MessagesWillBeConsumed", []any{posts}, &_returns.A); err != nil {
+			h.log.Error("WASM call MessagesWillBeConsumed to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) MessageHasBeenDeleted(c *Context, post *model.Post) {
+	if h.implemented[MessageHasBeenDeletedID] {
+		if err := h.plugin.callHook("MessageHasBeenDeleted", []any{c, post}); err != nil {
+			h.log.Error("WASM call MessageHasBeenDeleted to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) ChannelHasBeenCreated(c *Context, channel *model.Channel) {
+	if h.implemented[ChannelHasBeenCreatedID] {
+		if err := h.plugin.callHook("ChannelHasBeenCreated", []any{c, channel}); err != nil {
+			h.log.Error("WASM call ChannelHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) UserHasJoinedChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
+	if h.implemented[UserHasJoinedChannelID] {
+		if err := h.plugin.callHook("UserHasJoinedChannel", []any{c, channelMember, actor}); err != nil {
+			h.log.Error("WASM call UserHasJoinedChannel to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) UserHasLeftChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
+	if h.implemented[UserHasLeftChannelID] {
+		if err := h.plugin.callHook("UserHasLeftChannel", []any{c, channelMember, actor}); err != nil {
+			h.log.Error("WASM call UserHasLeftChannel to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) UserHasJoinedTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
+	if h.implemented[UserHasJoinedTeamID] {
+		if err := h.plugin.callHook("UserHasJoinedTeam", []any{c, teamMember, actor}); err != nil {
+			h.log.Error("WASM call UserHasJoinedTeam to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) UserHasLeftTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
+	if h.implemented[UserHasLeftTeamID] {
+		if err := h.plugin.callHook("UserHasLeftTeam", []any{c, teamMember, actor}); err != nil {
+			h.log.Error("WASM call UserHasLeftTeam to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) ReactionHasBeenAdded(c *Context, reaction *model.Reaction) {
+	if h.implemented[ReactionHasBeenAddedID] {
+		if err := h.plugin.callHook("ReactionHasBeenAdded", []any{c, reaction}); err != nil {
+			h.log.Error("WASM call ReactionHasBeenAdded to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) ReactionHasBeenRemoved(c *Context, reaction *model.Reaction) {
+	if h.implemented[ReactionHasBeenRemovedID] {
+		if err := h.plugin.callHook("ReactionHasBeenRemoved", []any{c, reaction}); err != nil {
+			h.log.Error("WASM call ReactionHasBeenRemoved to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) OnPluginClusterEvent(c *Context, ev model.PluginClusterEvent) {
+	if h.implemented[OnPluginClusterEventID] {
+		if err := h.plugin.callHook("OnPluginClusterEvent", []any{c, ev}); err != nil {
+			h.log.Error("WASM call OnPluginClusterEvent to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) OnWebSocketConnect(webConnID, userID string) {
+	if h.implemented[OnWebSocketConnectID] {
+		if err := h.plugin.callHook("OnWebSocketConnect", []any{webConnID, userID}); err != nil {
+			h.log.Error("WASM call OnWebSocketConnect to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) OnWebSocketDisconnect(webConnID, userID string) {
+	if h.implemented[OnWebSocketDisconnectID] {
+		if err := h.plugin.callHook("OnWebSocketDisconnect", []any{webConnID, userID}); err != nil {
+			h.log.Error("WASM call OnWebSocketDisconnect to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) WebSocketMessageHasBeenPosted(webConnID, userID string, req *model.WebSocketRequest) {
+	if h.implemented[WebSocketMessageHasBeenPostedID] {
+		if err := h.plugin.callHook("WebSocketMessageHasBeenPosted", []any{webConnID, userID, req}); err != nil {
+			h.log.Error("WASM call WebSocketMessageHasBeenPosted to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) RunDataRetention(nowTime, batchSize int64) (int64, error) {
+	_returns := &struct {
+		A int64
+		B error
+	}{}
+	if h.implemented[RunDataRetentionID] {
+		if err := h.plugin.callHook("RunDataRetention", []any{nowTime, batchSize}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call RunDataRetention to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) OnInstall(c *Context, event model.OnInstallEvent) error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[OnInstallID] {
+		if err := h.plugin.callHook("OnInstall", []any{c, event}, &_returns.A); err != nil {
+			h.log.Error("WASM call OnInstall to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) OnSendDailyTelemetry() {
+	if h.implemented[OnSendDailyTelemetryID] {
+		if err := h.plugin.callHook("OnSendDailyTelemetry", []any{}); err != nil {
+			h.log.Error("WASM call OnSendDailyTelemetry to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) OnCloudLimitsUpdated(limits *model.ProductLimits) {
+	if h.implemented[OnCloudLimitsUpdatedID] {
+		if err := h.plugin.callHook("OnCloudLimitsUpdated", []any{limits}); err != nil {
+			h.log.Error("WASM call OnCloudLimitsUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config, error) {
+	_returns := &struct {
+		A *model.Config
+		B error
+	}{}
+	if h.implemented[ConfigurationWillBeSavedID] {
+		if err := h.plugin.callHook("ConfigurationWillBeSaved", []any{newCfg}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call ConfigurationWillBeSaved to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) NotificationWillBePushed(pushNotification *model.PushNotification, userID string) (*model.PushNotification, string) {
+	_returns := &struct {
+		A *model.PushNotification
+		B string
+	}{}
+	if h.implemented[NotificationWillBePushedID] {
+		if err := h.plugin.callHook("NotificationWillBePushed", []any{pushNotification, userID}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call NotificationWillBePushed to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) UserHasBeenDeactivated(c *Context, user *model.User) {
+	if h.implemented[UserHasBeenDeactivatedID] {
+		if err := h.plugin.callHook("UserHasBeenDeactivated", []any{c, user}); err != nil {
+			h.log.Error("WASM call UserHasBeenDeactivated to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
+	_returns := &struct {
+		A model.SyncResponse
+		B error
+	}{}
+	if h.implemented[OnSharedChannelsSyncMsgID] {
+		if err := h.plugin.callHook("OnSharedChannelsSyncMsg", []any{msg, rc}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call OnSharedChannelsSyncMsg to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) OnSharedChannelsPing(rc *model.RemoteCluster) bool {
+	_returns := &struct {
+		A bool
+	}{}
+	if h.implemented[OnSharedChannelsPingID] {
+		if err := h.plugin.callHook("OnSharedChannelsPing", []any{rc}, &_returns.A); err != nil {
+			h.log.Error("WASM call OnSharedChannelsPing to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) PreferencesHaveChanged(c *Context, preferences []model.Preference) {
+	if h.implemented[PreferencesHaveChangedID] {
+		if err := h.plugin.callHook("PreferencesHaveChanged", []any{c, preferences}); err != nil {
+			h.log.Error("WASM call PreferencesHaveChanged to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[OnSharedChannelsAttachmentSyncMsgID] {
+		if err := h.plugin.callHook("OnSharedChannelsAttachmentSyncMsg", []any{fi, post, rc}, &_returns.A); err != nil {
+			h.log.Error("WASM call OnSharedChannelsAttachmentSyncMsg to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) OnSharedChannelsProfileImageSyncMsg(user *model.User, rc *model.RemoteCluster) error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[OnSharedChannelsProfileImageSyncMsgID] {
+		if err := h.plugin.callHook("OnSharedChannelsProfileImageSyncMsg", []any{user, rc}, &_returns.A); err != nil {
+			h.log.Error("WASM call OnSharedChannelsProfileImageSyncMsg to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) GenerateSupportData(c *Context) ([]*model.FileData, error) {
+	_returns := &struct {
+		A []*model.FileData
+		B error
+	}{}
+	if h.implemented[GenerateSupportDataID] {
+		if err := h.plugin.callHook("GenerateSupportData", []any{c}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call GenerateSupportData to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) OnSAMLLogin(c *Context, user *model.User, assertion *saml2.AssertionInfo) error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[OnSAMLLoginID] {
+		if err := h.plugin.callHook("OnSAMLLogin", []any{c, user, assertion}, &_returns.A); err != nil {
+			h.log.Error("WASM call OnSAMLLogin to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) MessageWillBeDeleted(c *Context, post *model.Post) string {
+	_returns := &struct {
+		A string
+	}{}
+	if h.implemented[MessageWillBeDeletedID] {
+		if err := h.plugin.callHook("MessageWillBeDeleted", []any{c, post}, &_returns.A); err != nil {
+			h.log.Error("WASM call MessageWillBeDeleted to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) ChannelWillBeArchived(c *Context, channel *model.Channel) string {
+	_returns := &struct {
+		A string
+	}{}
+	if h.implemented[ChannelWillBeArchivedID] {
+		if err := h.plugin.callHook("ChannelWillBeArchived", []any{c, channel}, &_returns.A); err != nil {
+			h.log.Error("WASM call ChannelWillBeArchived to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) ChannelHasBeenUpdated(c *Context, newChannel, oldChannel *model.Channel) {
+	if h.implemented[ChannelHasBeenUpdatedID] {
+		if err := h.plugin.callHook("ChannelHasBeenUpdated", []any{c, newChannel, oldChannel}); err != nil {
+			h.log.Error("WASM call ChannelHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) TeamHasBeenCreated(c *Context, team *model.Team) {
+	if h.implemented[TeamHasBeenCreatedID] {
+		if err := h.plugin.callHook("TeamHasBeenCreated", []any{c, team}); err != nil {
+			h.log.Error("WASM call TeamHasBeenCreated to plugin failed.", mlog.Err(err))
+		}
+	}
+}
+
+func (h *wasmHooksClient) UserWillBeUpdated(c *Context, newUser, oldUser *model.User) (*model.User, string) {
+	_returns := &struct {
+		A *model.User
+		B string
+	}{}
+	if h.implemented[UserWillBeUpdatedID] {
+		if err := h.plugin.callHook("UserWillBeUpdated", []any{c, newUser, oldUser}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call UserWillBeUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) EmailNotificationWillBeSent(emailNotification *model.EmailNotification, recipient *model.User, post *model.Post) (*model.EmailNotification, string) {
+	_returns := &struct {
+		A *model.EmailNotification
+		B string
+	}{}
+	if h.implemented[EmailNotificationWillBeSentID] {
+		if err := h.plugin.callHook("EmailNotificationWillBeSent", []any{emailNotification, recipient, post}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call EmailNotificationWillBeSent to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) ExecuteJob(c *Context, job *model.Job) error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[ExecuteJobID] {
+		if err := h.plugin.callHook("ExecuteJob", []any{c, job}, &_returns.A); err != nil {
+			h.log.Error("WASM call ExecuteJob to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) ExtractDocumentText(c *Context, fileInfo *model.FileInfo, content []byte) (string, error) {
+	_returns := &struct {
+		A string
+		B error
+	}{}
+	if h.implemented[ExtractDocumentTextID] {
+		if err := h.plugin.callHook("ExtractDocumentText", []any{c, fileInfo, content}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call ExtractDocumentText to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (h *wasmHooksClient) SearchEngineIndexPost(c *Context, post *model.Post, teamID string) error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[SearchEngineIndexPostID] {
+		if err := h.plugin.callHook("SearchEngineIndexPost", []any{c, post, teamID}, &_returns.A); err != nil {
+			h.log.Error("WASM call SearchEngineIndexPost to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) SearchEngineIndexFile(c *Context, fileInfo *model.FileInfo, channelID string) error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[SearchEngineIndexFileID] {
+		if err := h.plugin.callHook("SearchEngineIndexFile", []any{c, fileInfo, channelID}, &_returns.A); err != nil {
+			h.log.Error("WASM call SearchEngineIndexFile to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) SearchEngineDelete(c *Context, request *model.PluginSearchDeleteRequest) error {
+	_returns := &struct {
+		A error
+	}{}
+	if h.implemented[SearchEngineDeleteID] {
+		if err := h.plugin.callHook("SearchEngineDelete", []any{c, request}, &_returns.A); err != nil {
+			h.log.Error("WASM call SearchEngineDelete to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A
+}
+
+func (h *wasmHooksClient) SearchEngineSearchPosts(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, model.PostSearchMatches, error) {
+	_returns := &struct {
+		A []string
+		B model.PostSearchMatches
+		C error
+	}{}
+	if h.implemented[SearchEngineSearchPostsID] {
+		if err := h.plugin.callHook("SearchEngineSearchPosts", []any{c, channels, searchParams, page, perPage}, &_returns.A, &_returns.B, &_returns.C); err != nil {
+			h.log.Error("WASM call SearchEngineSearchPosts to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B, _returns.C
+}
+
+func (h *wasmHooksClient) SearchEngineSearchFiles(c *Context, channels model.ChannelList, searchParams []*model.SearchParams, page, perPage int) ([]string, error) {
+	_returns := &struct {
+		A []string
+		B error
+	}{}
+	if h.implemented[SearchEngineSearchFilesID] {
+		if err := h.plugin.callHook("SearchEngineSearchFiles", []any{c, channels, searchParams, page, perPage}, &_returns.A, &_returns.B); err != nil {
+			h.log.Error("WASM call SearchEngineSearchFiles to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
@@ -0,0 +1,299 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const wasmTestPlugin = `
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"unsafe"
+)
+
+var allocations = map[uint32][]byte{}
+
+var retained [][]byte
+
+//go:wasmexport mm_malloc
+func malloc(size uint32) uint32 {
+	b := make([]byte, size+1)
+	ptr := uint32(uintptr(unsafe.Pointer(&b[0])))
+	allocations[ptr] = b
+	return ptr
+}
+
+//go:wasmexport mm_free
+func free(ptr uint32) {
+	delete(allocations, ptr)
+}
+
+//go:wasmimport mattermost mm_api
+func mmAPI(namePtr, nameLen, argsPtr, argsLen uint32) uint64
+
+func bytesAt(ptr, length uint32) []byte {
+	return unsafe.Slice((*byte)(unsafe.Pointer(uintptr(ptr))), length)
+}
+
+func respond(response any) uint64 {
+	data, _ := json.Marshal(response)
+	ptr := malloc(uint32(len(data)))
+	copy(bytesAt(ptr, uint32(len(data))), data)
+	return uint64(ptr)<<32 | uint64(len(data))
+}
+
+func callAPI(name string, args ...any) ([]json.RawMessage, string) {
+	encodedName := []byte(name)
+	encodedArgs, _ := json.Marshal(args)
+	packed := mmAPI(
+		uint32(uintptr(unsafe.Pointer(&encodedName[0]))), uint32(len(encodedName)),
+		uint32(uintptr(unsafe.Pointer(&encodedArgs[0]))), uint32(len(encodedArgs)),
+	)
+	runtime.KeepAlive(encodedName)
+	runtime.KeepAlive(encodedArgs)
+
+	ptr := uint32(packed >> 32)
+	defer free(ptr)
+
+	var response struct {
+		Results []json.RawMessage
+		Error   string
+	}
+	json.Unmarshal(bytesAt(ptr, uint32(packed)), &response)
+	return response.Results, response.Error
+}
+
+//go:wasmexport mm_implemented
+func implemented() uint64 {
+	return respond(map[string]any{"results": []any{[]string{"OnActivate", "MessageWillBePosted", "ExecuteCommand", "ServeHTTP"}}})
+}
+
+//go:wasmexport mm_hook
+func hook(namePtr, nameLen, argsPtr, argsLen uint32) uint64 {
+	var args []json.RawMessage
+	json.Unmarshal(bytesAt(argsPtr, argsLen), &args)
+
+	switch string(bytesAt(namePtr, nameLen)) {
+	case "OnActivate":
+		return respond(map[string]any{"results": []any{nil}})
+	case "MessageWillBePosted":
+		var post map[string]any
+		json.Unmarshal(args[1], &post)
+		results, errMessage := callAPI("GetUser", post["user_id"])
+		if errMessage != "" {
+			return respond(map[string]any{"results": []any{nil, errMessage}})
+		}
+		var user map[string]any
+		json.Unmarshal(results[0], &user)
+		post["message"] = fmt.Sprintf("%v by %v", post["message"], user["username"])
+		return respond(map[string]any{"results": []any{post, ""}})
+	case "ExecuteCommand":
+		var commandArgs map[string]any
+		json.Unmarshal(args[1], &commandArgs)
+		switch commandArgs["command"] {
+		case "/config":
+			_, errMessage := callAPI("GetConfig")
+			return respond(map[string]any{"results": []any{map[string]any{"text": errMessage}, nil}})
+		case "/alloc":
+			for {
+				retained = append(retained, make([]byte, 1024*1024))
+			}
+		case "/loop":
+			for {
+			}
+		}
+	}
+
+	return respond(map[string]any{"error": "unexpected hook"})
+}
+
+func main() {}
+`
+
+type wasmTestAPI struct {
+	API
+
+	// getUser, if set, is called by GetUser before it returns
+	getUser func(userID string)
+}
+
+func (api *wasmTestAPI) GetUser(userID string) (*model.User, *model.AppError) {
+	if api.getUser != nil {
+		api.getUser(userID)
+	}
+	return &model.User{Id: userID, Username: "alice"}, nil
+}
+
+func (api *wasmTestAPI) GetConfig() *model.Config {
+	return &model.Config{}
+}
+
+func compileWasmTestPlugin(t *testing.T, dir string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), []byte(wasmTestPlugin), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module plugin\n\ngo 1.24\n"), 0600))
+
+	out := &bytes.Buffer{}
+	cmd := exec.Command("go", "build", "-buildmode=c-shared", "-o", "plugin.wasm", "main.go")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm", "GOWORK=off", "GOFLAGS=")
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err := cmd.Run()
+	if err != nil {
+		t.Log("Go compile errors:\n", out.String())
+	}
+	require.NoError(t, err, "failed to compile go")
+}
+
+func TestWasmSupervisor(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	compileWasmTestPlugin(t, dir)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "server": {"runtime": "wasm", "executable": "plugin.wasm"}, "permissions": ["read_users"]}`), 0600))
+
+	bundle := model.BundleInfoForPath(dir)
+	require.NoError(t, bundle.ManifestError)
+	logger := mlog.CreateConsoleTestLogger(t)
+
+	t.Run("hooks", func(t *testing.T) {
+		sup, err := newWasmSupervisor(bundle, &wasmTestAPI{}, logger, nil, WasmLimits{})
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		assert.True(t, sup.Implements(OnActivateID))
+		assert.True(t, sup.Implements(MessageWillBePostedID))
+		assert.False(t, sup.Implements(ServeHTTPID))
+		assert.False(t, sup.Implements(OnDeactivateID))
+
+		require.NoError(t, sup.Hooks().OnActivate())
+
+		post, rejection := sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{UserId: "user_id", Message: "hello"})
+		assert.Empty(t, rejection)
+		require.NotNil(t, post)
+		assert.Equal(t, "hello by alice", post.Message)
+		assert.Equal(t, "user_id", post.UserId)
+
+		assert.NoError(t, sup.PerformHealthCheck())
+	})
+
+	t.Run("concurrent hooks", func(t *testing.T) {
+		api := &wasmTestAPI{getUser: func(string) { time.Sleep(10 * time.Millisecond) }}
+		sup, err := newWasmSupervisor(bundle, api, logger, nil, WasmLimits{})
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		var wg sync.WaitGroup
+		messages := make([]string, 10)
+		for i := range messages {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				post, _ := sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{UserId: "user_id", Message: fmt.Sprintf("hello %d", i)})
+				if post != nil {
+					messages[i] = post.Message
+				}
+			}()
+		}
+		wg.Wait()
+
+		// Hooks invoked while another waits for an API call are skipped, the others must not
+		// interleave.
+		var ran int
+		for i, message := range messages {
+			if message != "" {
+				assert.Equal(t, fmt.Sprintf("hello %d by alice", i), message)
+				ran++
+			}
+		}
+		assert.NotZero(t, ran)
+		assert.NoError(t, sup.PerformHealthCheck())
+
+		post, _ := sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{UserId: "user_id", Message: "hello"})
+		require.NotNil(t, post)
+		assert.Equal(t, "hello by alice", post.Message)
+	})
+
+	t.Run("hook triggered by an API call", func(t *testing.T) {
+		api := &wasmTestAPI{}
+		sup, err := newWasmSupervisor(bundle, api, logger, nil, WasmLimits{HookTimeout: 5 * time.Second})
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		var nestedCalled bool
+		var nestedResponse *model.CommandResponse
+		api.getUser = func(string) {
+			nestedCalled = true
+			nestedResponse, _ = sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/config"})
+		}
+
+		// The nested hook is skipped rather than waiting for the API call triggering it.
+		post, rejection := sup.Hooks().MessageWillBePosted(&Context{}, &model.Post{UserId: "user_id", Message: "hello"})
+		assert.Empty(t, rejection)
+		require.NotNil(t, post)
+		assert.Equal(t, "hello by alice", post.Message)
+		assert.True(t, nestedCalled)
+		assert.Nil(t, nestedResponse)
+
+		api.getUser = nil
+		response, appErr := sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/config"})
+		require.Nil(t, appErr)
+		require.NotNil(t, response)
+		assert.Equal(t, "API method GetConfig requires the read_config permission", response.Text)
+	})
+
+	t.Run("API method without permission", func(t *testing.T) {
+		sup, err := newWasmSupervisor(bundle, &wasmTestAPI{}, logger, nil, WasmLimits{})
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		response, appErr := sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/config"})
+		require.Nil(t, appErr)
+		require.NotNil(t, response)
+		assert.Equal(t, "API method GetConfig requires the read_config permission", response.Text)
+	})
+
+	t.Run("memory limit", func(t *testing.T) {
+		sup, err := newWasmSupervisor(bundle, &wasmTestAPI{}, logger, nil, WasmLimits{MemoryLimitMB: 32})
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		response, _ := sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/alloc"})
+		assert.Nil(t, response)
+		assert.Error(t, sup.PerformHealthCheck())
+	})
+
+	t.Run("hook timeout", func(t *testing.T) {
+		sup, err := newWasmSupervisor(bundle, &wasmTestAPI{}, logger, nil, WasmLimits{HookTimeout: time.Second})
+		require.NoError(t, err)
+		defer sup.Shutdown()
+
+		start := time.Now()
+		response, _ := sup.Hooks().ExecuteCommand(&Context{}, &model.CommandArgs{Command: "/loop"})
+		assert.Nil(t, response)
+		assert.Less(t, time.Since(start), 10*time.Second)
+		assert.Error(t, sup.PerformHealthCheck())
+	})
+}
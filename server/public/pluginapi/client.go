@@ -20,12 +20,14 @@ type Client struct {
 	File          FileService
 	Frontend      FrontendService
 	Group         GroupService
+	Job           JobService
 	KV            KVService
 	Log           LogService
 	Mail          MailService
 	Plugin        PluginService
 	Post          PostService
 	Property      PropertyService
+	Search        SearchService
 	Session       SessionService
 	Store         *StoreService
 	System        SystemService
@@ -51,12 +53,14 @@ func NewClient(api plugin.API, driver plugin.Driver) *Client {
 		File:          FileService{api: api},
 		Frontend:      FrontendService{api: api},
 		Group:         GroupService{api: api},
+		Job:           JobService{api: api},
 		KV:            KVService{api: api},
 		Log:           LogService{api: api},
 		Mail:          MailService{api: api},
 		Plugin:        PluginService{api: api},
 		Post:          PostService{api: api},
 		Property:      PropertyService{api: api},
+		Search:        SearchService{api: api},
 		Session:       SessionService{api: api},
 		Store: &StoreService{
 			api:    api,
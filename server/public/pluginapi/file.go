@@ -91,3 +91,19 @@ func (f *FileService) CopyInfos(ids []string, userID string) ([]string, error) {
 
 	return newIDs, normalizeAppErr(appErr)
 }
+
+// RegisterExtractor registers the plugin to extract the text content of the files matching the
+// given extensions or MIME types, via the ExtractDocumentText hook, ahead of the server's own
+// extractors. Registering again replaces the previous registration.
+//
+// Minimum server version: 10.12
+func (f *FileService) RegisterExtractor(extractor *model.PluginDocumentExtractor) error {
+	return normalizeAppErr(f.api.RegisterDocumentExtractor(extractor))
+}
+
+// UnregisterExtractor unregisters the document extractor previously registered by the plugin.
+//
+// Minimum server version: 10.12
+func (f *FileService) UnregisterExtractor() error {
+	return normalizeAppErr(f.api.UnregisterDocumentExtractor())
+}
@@ -183,3 +183,31 @@ func TestCopyFileInfos(t *testing.T) {
 		require.Zero(t, newIDs)
 	})
 }
+
+func TestRegisterExtractor(t *testing.T) {
+	extractor := &model.PluginDocumentExtractor{Extensions: []string{".dwg"}}
+
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("RegisterDocumentExtractor", extractor).Return(nil)
+
+		err := client.File.RegisterExtractor(extractor)
+		require.NoError(t, err)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := newAppError()
+
+		api.On("RegisterDocumentExtractor", extractor).Return(appErr)
+
+		err := client.File.RegisterExtractor(extractor)
+		require.Equal(t, appErr, err)
+	})
+}
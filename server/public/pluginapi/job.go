@@ -0,0 +1,90 @@
+package pluginapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+// JobService exposes methods to run background jobs through the server's job server,
+// so they show up alongside the built-in jobs in the System Console and mmctl.
+type JobService struct {
+	api plugin.API
+}
+
+// RegisterType registers a job type. Jobs of that type are run by your plugin via the
+// ExecuteJob hook, on whichever node of the cluster claims them first. The job type must
+// start with model.PluginJobTypePrefix. When options.IntervalSeconds is positive, a job is
+// created periodically by the cluster leader.
+//
+// Minimum server version: 10.12
+func (j *JobService) RegisterType(jobType string, options *model.PluginJobTypeOptions) error {
+	return normalizeAppErr(j.api.RegisterJobType(jobType, options))
+}
+
+// UnregisterType unregisters a job type previously registered via RegisterType.
+//
+// Minimum server version: 10.12
+func (j *JobService) UnregisterType(jobType string) error {
+	return normalizeAppErr(j.api.UnregisterJobType(jobType))
+}
+
+// Create creates a pending job of a type registered by the plugin.
+//
+// Minimum server version: 10.12
+func (j *JobService) Create(jobType string, data map[string]string) (*model.Job, error) {
+	job, appErr := j.api.CreateJob(jobType, data)
+
+	return job, normalizeAppErr(appErr)
+}
+
+// Get gets a job of a type registered by the plugin.
+//
+// Minimum server version: 10.12
+func (j *JobService) Get(jobID string) (*model.Job, error) {
+	job, appErr := j.api.GetJob(jobID)
+
+	return job, normalizeAppErr(appErr)
+}
+
+// UpdateProgress updates the progress, as a percentage, of a job being run.
+//
+// Minimum server version: 10.12
+func (j *JobService) UpdateProgress(jobID string, progress int64) error {
+	return normalizeAppErr(j.api.UpdateJobProgress(jobID, progress))
+}
+
+// CancellationContext returns a context that is canceled once the cancellation of the given
+// job is requested, which is checked every pollInterval. Use it in the ExecuteJob hook to stop
+// the work early, and call the returned cancel function when the job is done.
+//
+// Minimum server version: 10.12
+func (j *JobService) CancellationContext(parent context.Context, jobID string, pollInterval time.Duration) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	go func() {
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				job, appErr := j.api.GetJob(jobID)
+				if appErr != nil {
+					j.api.LogWarn("Failed to check for job cancellation", "job_id", jobID, "error", appErr.Error())
+					continue
+				}
+				if job.Status == model.JobStatusCancelRequested {
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	return ctx, cancel
+}
@@ -0,0 +1,97 @@
+package pluginapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+)
+
+func TestJobRegisterType(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		options := &model.PluginJobTypeOptions{IntervalSeconds: 60}
+		api.On("RegisterJobType", "plugin_reindex", options).Return(nil)
+
+		err := client.Job.RegisterType("plugin_reindex", options)
+		require.NoError(t, err)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := model.NewAppError("here", "id", nil, "an error occurred", http.StatusBadRequest)
+		api.On("RegisterJobType", "reindex", (*model.PluginJobTypeOptions)(nil)).Return(appErr)
+
+		err := client.Job.RegisterType("reindex", nil)
+		require.Equal(t, appErr, err)
+	})
+}
+
+func TestJobCreate(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	data := map[string]string{"channel_id": "1"}
+	api.On("CreateJob", "plugin_reindex", data).Return(&model.Job{Id: "2", Type: "plugin_reindex", Data: data}, nil)
+
+	job, err := client.Job.Create("plugin_reindex", data)
+	require.NoError(t, err)
+	assert.Equal(t, "2", job.Id)
+}
+
+func TestJobUpdateProgress(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("UpdateJobProgress", "1", int64(50)).Return(nil)
+
+	err := client.Job.UpdateProgress("1", 50)
+	require.NoError(t, err)
+}
+
+func TestJobCancellationContext(t *testing.T) {
+	t.Run("canceled when requested", func(t *testing.T) {
+		api := &plugintest.API{}
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("GetJob", "1").Return(&model.Job{Id: "1", Status: model.JobStatusInProgress}, nil).Once()
+		api.On("GetJob", "1").Return(&model.Job{Id: "1", Status: model.JobStatusCancelRequested}, nil)
+
+		ctx, cancel := client.Job.CancellationContext(context.Background(), "1", 10*time.Millisecond)
+		defer cancel()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "context was not canceled")
+		}
+	})
+
+	t.Run("stops polling once done", func(t *testing.T) {
+		api := &plugintest.API{}
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("GetJob", "1").Return(&model.Job{Id: "1", Status: model.JobStatusInProgress}, nil).Maybe()
+
+		ctx, cancel := client.Job.CancellationContext(context.Background(), "1", 10*time.Millisecond)
+		cancel()
+
+		<-ctx.Done()
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	})
+}
@@ -276,3 +276,131 @@ func (k *KVService) ListKeys(page, count int, options ...ListKeysOption) ([]stri
 
 	return ret, nil
 }
+
+// ListWithPrefix lists the key-value pairs whose key starts with the given prefix, ordered by key.
+// The expiry time of each pair, in milliseconds, is returned in ExpireAt, zero if it doesn't expire.
+//
+// Minimum server version: 10.12
+func (k *KVService) ListWithPrefix(prefix string, page, count int) ([]*model.PluginKeyValue, error) {
+	kvs, appErr := k.api.KVListWithValues(prefix, page, count)
+	if appErr != nil {
+		return nil, normalizeAppErr(appErr)
+	}
+
+	return kvs, nil
+}
+
+// GetMany gets the raw values of the given keys. Non-existent keys are left out of the returned
+// map.
+//
+// Minimum server version: 10.12
+func (k *KVService) GetMany(keys []string) (map[string][]byte, error) {
+	values, appErr := k.api.KVGetMany(keys)
+	if appErr != nil {
+		return nil, normalizeAppErr(appErr)
+	}
+
+	return values, nil
+}
+
+// SetMany stores the given key-value pairs in a single transaction. A nil value deletes the key.
+// Only the SetExpiry option is supported, and it applies to all the pairs.
+//
+// Minimum server version: 10.12
+func (k *KVService) SetMany(values map[string]any, options ...KVSetOption) error {
+	opts := KVSetOptions{}
+	for _, o := range options {
+		o(&opts)
+	}
+	if opts.Atomic {
+		return errors.New("SetAtomic is not supported, use CompareAndSwapMany instead")
+	}
+
+	valuesBytes := make(map[string][]byte, len(values))
+	for key, value := range values {
+		if strings.HasPrefix(key, internalKeyPrefix) {
+			return errors.Errorf("'%s' prefix is not allowed for keys", internalKeyPrefix)
+		}
+
+		valueBytes, err := marshalKVValue(value)
+		if err != nil {
+			return err
+		}
+		valuesBytes[key] = valueBytes
+	}
+
+	return normalizeAppErr(k.api.KVSetMany(valuesBytes, opts.ExpireInSeconds))
+}
+
+// KVCompareAndSwap is an operation of CompareAndSwapMany.
+type KVCompareAndSwap struct {
+	Key string
+	// OldValue is the expected current value of the key, nil if the key is expected not to exist.
+	OldValue any
+	// NewValue is the value to store, nil to delete the key.
+	NewValue any
+	// ExpireIn, if non-zero, configures the new value to expire after the given duration.
+	ExpireIn time.Duration
+}
+
+// CompareAndSwapMany applies all the given operations in a single transaction, but only if the
+// current value of every key matches the OldValue of its operation.
+//
+// Returns (false, err) if DB error occurred
+// Returns (false, nil) if a current value didn't match, in which case nothing was changed
+// Returns (true, nil) if all the operations were applied
+//
+// Minimum server version: 10.12
+func (k *KVService) CompareAndSwapMany(operations []KVCompareAndSwap) (bool, error) {
+	downstreamOps, err := toPluginKVCompareAndSwaps(operations)
+	if err != nil {
+		return false, err
+	}
+
+	swapped, appErr := k.api.KVCompareAndSwapMany(downstreamOps)
+	return swapped, normalizeAppErr(appErr)
+}
+
+func toPluginKVCompareAndSwaps(operations []KVCompareAndSwap) ([]*model.PluginKVCompareAndSwap, error) {
+	downstreamOps := make([]*model.PluginKVCompareAndSwap, 0, len(operations))
+	for _, op := range operations {
+		if strings.HasPrefix(op.Key, internalKeyPrefix) {
+			return nil, errors.Errorf("'%s' prefix is not allowed for keys", internalKeyPrefix)
+		}
+
+		oldValue, err := marshalKVValue(op.OldValue)
+		if err != nil {
+			return nil, err
+		}
+		newValue, err := marshalKVValue(op.NewValue)
+		if err != nil {
+			return nil, err
+		}
+
+		downstreamOps = append(downstreamOps, &model.PluginKVCompareAndSwap{
+			Key:             op.Key,
+			OldValue:        oldValue,
+			NewValue:        newValue,
+			ExpireInSeconds: int64(op.ExpireIn / time.Second),
+		})
+	}
+
+	return downstreamOps, nil
+}
+
+// marshalKVValue encodes the value as JSON, unless it's already a byte slice.
+func marshalKVValue(value any) ([]byte, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if valueBytes, ok := value.([]byte); ok {
+		return valueBytes, nil
+	}
+
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal value %v", value)
+	}
+	return valueBytes, nil
+}
@@ -261,6 +261,164 @@ func (s *MemoryStore) DeleteAll() error {
 	return nil
 }
 
+// ListWithPrefix lists the key-value pairs whose key starts with the given prefix, ordered by key.
+func (s *MemoryStore) ListWithPrefix(prefix string, page, count int) ([]*model.PluginKeyValue, error) {
+	if page < 0 {
+		return nil, errors.New("page number must not be negative")
+	}
+
+	if count < 0 {
+		return nil, errors.New("count must not be negative")
+	}
+
+	kvs := []*model.PluginKeyValue{}
+	s.mux.RLock()
+	for k, e := range s.elems {
+		if e.isExpired() || !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		kv := &model.PluginKeyValue{
+			Key:   k,
+			Value: e.value,
+		}
+		if e.expiresAt != nil {
+			kv.ExpireAt = e.expiresAt.UnixMilli()
+		}
+		kvs = append(kvs, kv)
+	}
+	s.mux.RUnlock()
+
+	slices.SortFunc(kvs, func(a, b *model.PluginKeyValue) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	return paginateSlice(kvs, page, count), nil
+}
+
+// GetMany gets the raw values of the given keys. Non-existent keys are left out of the returned
+// map.
+func (s *MemoryStore) GetMany(keys []string) (map[string][]byte, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+
+	values := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		e, ok := s.elems[key]
+		if !ok || len(e.value) == 0 || e.isExpired() {
+			continue
+		}
+		values[key] = e.value
+	}
+
+	return values, nil
+}
+
+// SetMany stores the given key-value pairs in a single transaction. A nil value deletes the key.
+func (s *MemoryStore) SetMany(values map[string]any, options ...KVSetOption) error {
+	opts := KVSetOptions{}
+	for _, o := range options {
+		if o != nil {
+			o(&opts)
+		}
+	}
+	if opts.Atomic {
+		return errors.New("SetAtomic is not supported, use CompareAndSwapMany instead")
+	}
+
+	valuesBytes := make(map[string][]byte, len(values))
+	for key, value := range values {
+		if err := validateMemoryStoreKey(key); err != nil {
+			return err
+		}
+
+		valueBytes, err := marshalKVValue(value)
+		if err != nil {
+			return err
+		}
+		valuesBytes[key] = valueBytes
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.elems == nil {
+		s.elems = make(map[string]kvElem)
+	}
+
+	for key, value := range valuesBytes {
+		s.setLocked(key, value, opts.ExpireInSeconds)
+	}
+
+	return nil
+}
+
+// CompareAndSwapMany applies all the given operations in a single transaction, but only if the
+// current value of every key matches the OldValue of its operation.
+func (s *MemoryStore) CompareAndSwapMany(operations []KVCompareAndSwap) (bool, error) {
+	for _, op := range operations {
+		if err := validateMemoryStoreKey(op.Key); err != nil {
+			return false, err
+		}
+	}
+
+	downstreamOps, err := toPluginKVCompareAndSwaps(operations)
+	if err != nil {
+		return false, err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.elems == nil {
+		s.elems = make(map[string]kvElem)
+	}
+
+	for _, op := range downstreamOps {
+		e, ok := s.elems[op.Key]
+		if !ok || e.isExpired() {
+			e = kvElem{}
+		}
+		if !bytes.Equal(e.value, op.OldValue) {
+			return false, nil
+		}
+	}
+
+	for _, op := range downstreamOps {
+		s.setLocked(op.Key, op.NewValue, op.ExpireInSeconds)
+	}
+
+	return true, nil
+}
+
+// setLocked stores or, if the value is empty, deletes a key. The caller must hold the lock.
+func (s *MemoryStore) setLocked(key string, value []byte, expireInSeconds int64) {
+	if len(value) == 0 {
+		delete(s.elems, key)
+		return
+	}
+
+	s.elems[key] = kvElem{
+		value:     value,
+		expiresAt: expireTime(expireInSeconds),
+	}
+}
+
+func validateMemoryStoreKey(key string) error {
+	if key == "" {
+		return errors.New("key must not be empty")
+	}
+
+	if strings.HasPrefix(key, internalKeyPrefix) {
+		return errors.Errorf("'%s' prefix is not allowed for keys", internalKeyPrefix)
+	}
+
+	if utf8.RuneCountInString(key) > model.KeyValueKeyMaxRunes {
+		return errors.Errorf("key must not be longer then %d", model.KeyValueKeyMaxRunes)
+	}
+
+	return nil
+}
+
 func expireTime(expireInSeconds int64) *time.Time {
 	if expireInSeconds == 0 {
 		return nil
@@ -12,18 +12,23 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/pluginapi"
 )
 
 // kvStore is used to check that KVService and MemoryStore implement the same interface.
 // Methods names are sorted alphabetically for easier comparison.
 type kvStore interface {
+	CompareAndSwapMany(operations []pluginapi.KVCompareAndSwap) (bool, error)
 	Delete(key string) error
 	DeleteAll() error
 	Get(key string, o any) error
+	GetMany(keys []string) (map[string][]byte, error)
 	ListKeys(page, count int, options ...pluginapi.ListKeysOption) ([]string, error)
+	ListWithPrefix(prefix string, page, count int) ([]*model.PluginKeyValue, error)
 	Set(key string, value any, options ...pluginapi.KVSetOption) (bool, error)
 	SetAtomicWithRetries(key string, valueFunc func(oldValue []byte) (newValue any, err error)) error
+	SetMany(values map[string]any, options ...pluginapi.KVSetOption) error
 }
 
 var _ kvStore = (*pluginapi.MemoryStore)(nil)
@@ -465,3 +470,145 @@ func isNil(t require.TestingT, store *pluginapi.MemoryStore, key string) {
 	require.NoError(t, err)
 	assert.Nil(t, out)
 }
+
+func TestMemoryStoreListWithPrefix(t *testing.T) {
+	t.Run("nil map", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		kvs, err := store.ListWithPrefix("", 0, 10)
+		assert.NoError(t, err)
+		assert.Empty(t, kvs)
+	})
+
+	t.Run("negative page", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		_, err := store.ListWithPrefix("", -1, 10)
+		assert.Error(t, err)
+	})
+
+	t.Run("prefix, expiry and paging", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+
+		_, err := store.Set("index_b", "b", pluginapi.SetExpiry(time.Minute))
+		require.NoError(t, err)
+		_, err = store.Set("index_a", "a")
+		require.NoError(t, err)
+		_, err = store.Set("other", "other")
+		require.NoError(t, err)
+
+		kvs, err := store.ListWithPrefix("index_", 0, 10)
+		require.NoError(t, err)
+		require.Len(t, kvs, 2)
+		assert.Equal(t, "index_a", kvs[0].Key)
+		assert.Equal(t, []byte(`"a"`), kvs[0].Value)
+		assert.Zero(t, kvs[0].ExpireAt)
+		assert.Equal(t, "index_b", kvs[1].Key)
+		assert.Greater(t, kvs[1].ExpireAt, time.Now().UnixMilli())
+
+		kvs, err = store.ListWithPrefix("index_", 1, 1)
+		require.NoError(t, err)
+		require.Len(t, kvs, 1)
+		assert.Equal(t, "index_b", kvs[0].Key)
+	})
+}
+
+func TestMemoryStoreGetMany(t *testing.T) {
+	store := pluginapi.MemoryStore{}
+
+	_, err := store.Set("key1", []byte("value1"))
+	require.NoError(t, err)
+	_, err = store.Set("key2", []byte("value2"))
+	require.NoError(t, err)
+
+	values, err := store.GetMany([]string{"key1", "key2", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key1": []byte("value1"), "key2": []byte("value2")}, values)
+}
+
+func TestMemoryStoreSetMany(t *testing.T) {
+	t.Run("invalid key", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		err := store.SetMany(map[string]any{"mmi_key": "value"})
+		assert.Error(t, err)
+	})
+
+	t.Run("atomic is not supported", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+		err := store.SetMany(map[string]any{"key": "value"}, pluginapi.SetAtomic(nil))
+		assert.Error(t, err)
+	})
+
+	t.Run("set and delete", func(t *testing.T) {
+		store := pluginapi.MemoryStore{}
+
+		_, err := store.Set("deleted", "value")
+		require.NoError(t, err)
+
+		err = store.SetMany(map[string]any{
+			"deleted": nil,
+			"key1":    []byte("value1"),
+			"key2":    map[string]string{"a": "b"},
+		}, pluginapi.SetExpiry(time.Minute))
+		require.NoError(t, err)
+
+		values, err := store.GetMany([]string{"deleted", "key1", "key2"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"key1": []byte("value1"), "key2": []byte(`{"a":"b"}`)}, values)
+	})
+}
+
+func TestMemoryStoreCompareAndSwapMany(t *testing.T) {
+	setup := func(t *testing.T) *pluginapi.MemoryStore {
+		store := &pluginapi.MemoryStore{}
+		err := store.SetMany(map[string]any{"key1": "value1", "key2": "value2"})
+		require.NoError(t, err)
+		return store
+	}
+
+	t.Run("mismatch", func(t *testing.T) {
+		store := setup(t)
+
+		swapped, err := store.CompareAndSwapMany([]pluginapi.KVCompareAndSwap{
+			{Key: "key1", OldValue: "value1", NewValue: "new1"},
+			{Key: "key2", OldValue: "wrong", NewValue: "new2"},
+		})
+		require.NoError(t, err)
+		assert.False(t, swapped)
+
+		var value string
+		require.NoError(t, store.Get("key1", &value))
+		assert.Equal(t, "value1", value)
+	})
+
+	t.Run("key expected to be missing exists", func(t *testing.T) {
+		store := setup(t)
+
+		swapped, err := store.CompareAndSwapMany([]pluginapi.KVCompareAndSwap{
+			{Key: "key1", NewValue: "new1"},
+		})
+		require.NoError(t, err)
+		assert.False(t, swapped)
+	})
+
+	t.Run("swap", func(t *testing.T) {
+		store := setup(t)
+
+		swapped, err := store.CompareAndSwapMany([]pluginapi.KVCompareAndSwap{
+			{Key: "key1", OldValue: "value1", NewValue: "new1"},
+			{Key: "key2", OldValue: "value2"},
+			{Key: "key3", NewValue: "new3", ExpireIn: time.Minute},
+		})
+		require.NoError(t, err)
+		assert.True(t, swapped)
+
+		values, err := store.GetMany([]string{"key1", "key2", "key3"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]byte{"key1": []byte(`"new1"`), "key3": []byte(`"new3"`)}, values)
+	})
+
+	t.Run("invalid key", func(t *testing.T) {
+		store := setup(t)
+
+		_, err := store.CompareAndSwapMany([]pluginapi.KVCompareAndSwap{{Key: "", NewValue: "value"}})
+		assert.Error(t, err)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -635,3 +636,87 @@ func getKeys(count int) []string {
 	}
 	return ret
 }
+
+func TestListWithPrefix(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	expected := []*model.PluginKeyValue{{Key: "index_a", Value: []byte("a"), ExpireAt: 10}}
+	api.On("KVListWithValues", "index_", 0, 100).Return(expected, nil)
+
+	kvs, err := client.KV.ListWithPrefix("index_", 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, expected, kvs)
+}
+
+func TestGetMany(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("KVGetMany", []string{"1", "2"}).Return(map[string][]byte{"1": []byte("a")}, nil)
+
+	values, err := client.KV.GetMany([]string{"1", "2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"1": []byte("a")}, values)
+}
+
+func TestSetMany(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("KVSetMany", map[string][]byte{
+			"1": []byte("a"),
+			"2": []byte(`{"a":"b"}`),
+			"3": nil,
+		}, int64(60)).Return(nil)
+
+		err := client.KV.SetMany(map[string]any{
+			"1": []byte("a"),
+			"2": map[string]string{"a": "b"},
+			"3": nil,
+		}, pluginapi.SetExpiry(time.Minute))
+		require.NoError(t, err)
+	})
+
+	t.Run("internal prefix", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		err := client.KV.SetMany(map[string]any{"mmi_1": "a"})
+		require.Error(t, err)
+	})
+
+	t.Run("app error", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("KVSetMany", map[string][]byte{"1": []byte("a")}, int64(0)).Return(newAppError())
+
+		err := client.KV.SetMany(map[string]any{"1": []byte("a")})
+		require.Error(t, err)
+	})
+}
+
+func TestCompareAndSwapMany(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("KVCompareAndSwapMany", []*model.PluginKVCompareAndSwap{
+		{Key: "1", OldValue: []byte(`"a"`), NewValue: []byte(`"b"`), ExpireInSeconds: 60},
+		{Key: "2", NewValue: []byte("c")},
+	}).Return(true, nil)
+
+	swapped, err := client.KV.CompareAndSwapMany([]pluginapi.KVCompareAndSwap{
+		{Key: "1", OldValue: "a", NewValue: "b", ExpireIn: time.Minute},
+		{Key: "2", NewValue: []byte("c")},
+	})
+	require.NoError(t, err)
+	assert.True(t, swapped)
+}
@@ -0,0 +1,26 @@
+package pluginapi
+
+import (
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+// SearchService exposes methods to provide a search engine for posts and files.
+type SearchService struct {
+	api plugin.API
+}
+
+// RegisterEngine registers the plugin as the search engine for posts and files, through the
+// SearchEngine* hooks. The server falls back to its other search engines, or the database,
+// should the plugin fail or be disabled.
+//
+// Minimum server version: 10.12
+func (s *SearchService) RegisterEngine() error {
+	return normalizeAppErr(s.api.RegisterSearchEngine())
+}
+
+// UnregisterEngine unregisters the search engine previously registered by the plugin.
+//
+// Minimum server version: 10.12
+func (s *SearchService) UnregisterEngine() error {
+	return normalizeAppErr(s.api.UnregisterSearchEngine())
+}
@@ -0,0 +1,47 @@
+package pluginapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+)
+
+func TestSearchRegisterEngine(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("RegisterSearchEngine").Return(nil)
+
+		err := client.Search.RegisterEngine()
+		require.NoError(t, err)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := newAppError()
+
+		api.On("RegisterSearchEngine").Return(appErr)
+
+		err := client.Search.RegisterEngine()
+		require.Equal(t, appErr, err)
+	})
+}
+
+func TestSearchUnregisterEngine(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("UnregisterSearchEngine").Return(nil)
+
+	err := client.Search.UnregisterEngine()
+	require.NoError(t, err)
+}
@@ -34,6 +34,7 @@ interface State extends BaseState {
     globalRelayCustomSMTPServerName: AdminConfig['MessageExportSettings']['GlobalRelaySettings']['CustomSMTPServerName'];
     globalRelayCustomSMTPPort: AdminConfig['MessageExportSettings']['GlobalRelaySettings']['CustomSMTPPort'];
     globalRelaySMTPServerTimeout: AdminConfig['MessageExportSettings']['GlobalRelaySettings']['SMTPServerTimeout'];
+    jsonlSigningKey: AdminConfig['MessageExportSettings']['JsonlSettings']['SigningKey'];
 }
 
 const messages = defineMessages({
@@ -45,6 +46,8 @@ const messages = defineMessages({
     globalRelaySMTPPassword_description: {id: 'admin.complianceExport.globalRelaySMTPPassword.description', defaultMessage: 'The password that is used to authenticate against the GlobalRelay SMTP server.'},
     globalRelayEmailAddress_title: {id: 'admin.complianceExport.globalRelayEmailAddress.title', defaultMessage: 'Email Address:'},
     globalRelayEmailAddress_description: {id: 'admin.complianceExport.globalRelayEmailAddress.description', defaultMessage: 'The email address that your GlobalRelay server monitors for incoming Compliance Exports.'},
+    jsonlSigningKey_title: {id: 'admin.complianceExport.jsonlSigningKey.title', defaultMessage: 'Manifest Signing Key:'},
+    jsonlSigningKey_description: {id: 'admin.complianceExport.jsonlSigningKey.description', defaultMessage: 'The base64 encoded Ed25519 private key used to sign the manifest of every JSONL export batch.'},
     complianceExportTitle: {id: 'admin.service.complianceExportTitle', defaultMessage: 'Enable Compliance Export:'},
     complianceExportDesc: {id: 'admin.service.complianceExportDesc', defaultMessage: 'When true, Mattermost will export all messages that were posted in the last 24 hours. The export task is scheduled to run once per day. See <link>the documentation</link> to learn more.'},
     exportJobStartTime_title: {id: 'admin.complianceExport.exportJobStartTime.title', defaultMessage: 'Compliance Export Time:'},
@@ -80,6 +83,8 @@ string | MessageDescriptor | [MessageDescriptor, { [key: string]: any }]
     messages.globalRelaySMTPPassword_description,
     messages.globalRelayEmailAddress_title,
     messages.globalRelayEmailAddress_description,
+    messages.jsonlSigningKey_title,
+    messages.jsonlSigningKey_description,
 ];
 
 export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedComponentProps, State> {
@@ -99,6 +104,12 @@ export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedC
                 SMTPServerTimeout: this.state.globalRelaySMTPServerTimeout,
             };
         }
+
+        if (this.state.exportFormat === exportFormats.EXPORT_FORMAT_JSONL) {
+            config.MessageExportSettings.JsonlSettings = {
+                SigningKey: this.state.jsonlSigningKey,
+            };
+        }
         return config;
     };
 
@@ -114,6 +125,7 @@ export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedC
             globalRelaySMTPServerTimeout: 0,
             globalRelayCustomSMTPServerName: '',
             globalRelayCustomSMTPPort: '',
+            jsonlSigningKey: '',
             saveNeeded: false,
             saving: false,
             serverError: null,
@@ -126,6 +138,9 @@ export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedC
             state.globalRelayCustomSMTPServerName = config.MessageExportSettings.GlobalRelaySettings.CustomSMTPServerName;
             state.globalRelayCustomSMTPPort = config.MessageExportSettings.GlobalRelaySettings.CustomSMTPPort;
         }
+        if (config.MessageExportSettings.JsonlSettings) {
+            state.jsonlSigningKey = config.MessageExportSettings.JsonlSettings.SigningKey;
+        }
         return state;
     }
 
@@ -194,6 +209,9 @@ export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedC
             {value: exportFormats.EXPORT_FORMAT_ACTIANCE, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.actiance', defaultMessage: 'Actiance XML'})},
             {value: exportFormats.EXPORT_FORMAT_CSV, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.csv', defaultMessage: 'CSV'})},
             {value: exportFormats.EXPORT_FORMAT_GLOBALRELAY, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.globalrelay', defaultMessage: 'GlobalRelay EML'})},
+            {value: exportFormats.EXPORT_FORMAT_EML, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.eml', defaultMessage: 'EML (one file per conversation)'})},
+            {value: exportFormats.EXPORT_FORMAT_MBOX, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.mbox', defaultMessage: 'mbox (one message per post)'})},
+            {value: exportFormats.EXPORT_FORMAT_JSONL, text: this.props.intl.formatMessage({id: 'admin.complianceExport.exportFormat.jsonl', defaultMessage: 'JSON Lines (signed)'})},
         ];
 
         // if the export format is globalrelay, the user needs to set some additional parameters
@@ -319,6 +337,24 @@ export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedC
             );
         }
 
+        // if the export format is jsonl, the manifest of every batch is signed with the configured key
+        let jsonlSettings;
+        if (this.state.exportFormat === exportFormats.EXPORT_FORMAT_JSONL) {
+            jsonlSettings = (
+                <SettingsGroup id={'jsonlSettings'} >
+                    <TextSetting
+                        id='jsonlSigningKey'
+                        label={<FormattedMessage {...messages.jsonlSigningKey_title}/>}
+                        helpText={<FormattedMessage {...messages.jsonlSigningKey_description}/>}
+                        value={this.state.jsonlSigningKey ? this.state.jsonlSigningKey : ''}
+                        onChange={this.handleChange}
+                        setByEnv={this.isSetByEnv('MessageExportSettings.JsonlSettings.SigningKey')}
+                        disabled={this.props.isDisabled || !this.state.enableComplianceExport}
+                    />
+                </SettingsGroup>
+            );
+        }
+
         const dropdownHelpText = (
             <>
                 <p>
@@ -390,6 +426,7 @@ export class MessageExportSettings extends OLDAdminSettings<BaseProps & WrappedC
                 />
 
                 {globalRelaySettings}
+                {jsonlSettings}
 
                 <JobsTable
                     jobType={JobTypes.MESSAGE_EXPORT}
@@ -39,7 +39,9 @@ function mapStateToProps(state: GlobalState) {
         state: data.dialog.state,
         emojiMap,
         isAppsFormEnabled,
-        hasUrl: Boolean(data.url),
+
+        // Newer servers keep the URL in the session of the dialog
+        hasUrl: Boolean(data.url || data.session),
     };
 }
 
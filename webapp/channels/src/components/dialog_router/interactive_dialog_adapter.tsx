@@ -218,6 +218,39 @@ class InteractiveDialogAdapter extends React.PureComponent<Props> {
                 };
             }
 
+            // The next page of a multi-page dialog replaces the current form
+            const nextPage = result?.data?.dialog;
+            if (nextPage) {
+                const {form, errors: pageErrors} = convertDialogToAppForm(
+                    nextPage.elements,
+                    nextPage.title,
+                    nextPage.introduction_text,
+                    nextPage.icon_url,
+                    nextPage.submit_label,
+                    this.conversionContext,
+                );
+
+                const {error} = this.handleValidationErrors(pageErrors);
+                if (error) {
+                    return {
+                        error: {
+                            type: 'error' as const,
+                            text: error,
+                            data: {
+                                errors: {},
+                            },
+                        },
+                    };
+                }
+
+                return {
+                    data: {
+                        type: 'form' as const,
+                        form,
+                    },
+                };
+            }
+
             // Success response
             return {
                 data: {
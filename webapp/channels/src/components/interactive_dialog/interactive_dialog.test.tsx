@@ -65,6 +65,38 @@ describe('components/interactive_dialog/InteractiveDialog', () => {
         });
     });
 
+    describe('multi-page dialog', () => {
+        test('should stay open with the default values of the next page', async () => {
+            const nextPageElement: TDialogElement = {
+                data_source: '',
+                default: 'true',
+                display_name: 'Agree',
+                name: 'agree',
+                optional: true,
+                options: [],
+                type: 'bool',
+                subtype: '',
+                placeholder: '',
+                help_text: '',
+                min_length: 0,
+                max_length: 0,
+            };
+            const props = {
+                ...baseProps,
+                actions: {
+                    submitInteractiveDialog: jest.fn().mockResolvedValue({data: {dialog: {title: 'Next Page', elements: [nextPageElement]}, session: 'session'}}),
+                },
+            };
+            const wrapper = shallow<InteractiveDialog>(<InteractiveDialog {...props}/>);
+
+            await wrapper.instance().handleSubmit(submitEvent);
+
+            expect(wrapper.state().show).toBe(true);
+            expect(wrapper.state().values).toEqual({agree: true});
+            expect(props.onExited).not.toHaveBeenCalled();
+        });
+    });
+
     describe('default select element in Interactive Dialog', () => {
         test('should be enabled by default', () => {
             const selectElement: TDialogElement = {
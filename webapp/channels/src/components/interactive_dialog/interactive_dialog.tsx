@@ -5,7 +5,7 @@ import React from 'react';
 import {Modal} from 'react-bootstrap';
 import {FormattedMessage} from 'react-intl';
 
-import type {DialogSubmission} from '@mattermost/types/integrations';
+import type {DialogElement as DialogElementType, DialogSubmission} from '@mattermost/types/integrations';
 
 import {
     checkDialogElementForError,
@@ -34,24 +34,27 @@ type State = {
     submitting: boolean;
 }
 
+function getDefaultValues(elements?: DialogElementType[]) {
+    const values: Record<string, string | number | boolean> = {};
+    if (elements != null) {
+        elements.forEach((e) => {
+            if (e.type === 'bool') {
+                values[e.name] = String(e.default).toLowerCase() === 'true';
+            } else {
+                values[e.name] = e.default ?? null;
+            }
+        });
+    }
+    return values;
+}
+
 export default class InteractiveDialog extends React.PureComponent<Props, State> {
     constructor(props: Props) {
         super(props);
 
-        const values: Record<string, string | number | boolean> = {};
-        if (props.elements != null) {
-            props.elements.forEach((e) => {
-                if (e.type === 'bool') {
-                    values[e.name] = String(e.default).toLowerCase() === 'true';
-                } else {
-                    values[e.name] = e.default ?? null;
-                }
-            });
-        }
-
         this.state = {
             show: true,
-            values,
+            values: getDefaultValues(props.elements),
             error: null,
             errors: {},
             submitting: false,
@@ -126,6 +129,12 @@ export default class InteractiveDialog extends React.PureComponent<Props, State>
             }
         }
 
+        if (!hasErrors && data?.dialog) {
+            // The next page of a multi-page dialog replaces the elements of the current one
+            this.setState({values: getDefaultValues(data.dialog.elements), error: null, errors: {}});
+            return;
+        }
+
         if (!hasErrors) {
             this.handleHide(true);
         }
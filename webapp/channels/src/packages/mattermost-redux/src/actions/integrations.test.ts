@@ -816,4 +816,43 @@ describe('Actions.Integrations', () => {
         const {data} = await store.dispatch(Actions.submitInteractiveDialog(submit));
         expect(data).toEqual(OK_RESPONSE);
     });
+
+    it('submitInteractiveDialog sends the session and receives the next page', async () => {
+        store = configureStore({
+            entities: {
+                integrations: {
+                    dialog: {
+                        url: 'https://mattermost.com',
+                        trigger_id: 'trigger_id',
+                        session: 'first_session',
+                        dialog: {title: 'First Page', elements: []},
+                    },
+                },
+            },
+        });
+
+        const submit: DialogSubmission = {
+            callback_id: 'callback_id',
+            channel_id: 'channel_id',
+            state: 'state',
+            submission: {field1: 'value1'},
+            cancelled: false,
+            team_id: '',
+            user_id: '',
+        };
+
+        const nextPage = {title: 'Second Page', elements: []};
+
+        nock(Client4.getBaseRoute()).
+            post('/actions/dialogs/submit', (body) => body.session === 'first_session').
+            reply(200, {dialog: nextPage, session: 'second_session'});
+
+        const {data} = await store.dispatch(Actions.submitInteractiveDialog(submit));
+        expect(data.dialog).toEqual(nextPage);
+
+        const dialog = store.getState().entities.integrations.dialog;
+        expect(dialog.dialog).toEqual(nextPage);
+        expect(dialog.session).toEqual('second_session');
+        expect(dialog.url).toEqual('https://mattermost.com');
+    });
 });
@@ -503,6 +503,12 @@ export function submitInteractiveDialog(submission: DialogSubmission): ActionFun
         submission.team_id = getCurrentTeamId(state);
         submission.user_id = getCurrentUserId(state);
 
+        // The session of the dialog lets the server validate the submission
+        const dialog = state.entities.integrations.dialog;
+        if (!submission.session && dialog?.session) {
+            submission.session = dialog.session;
+        }
+
         let data;
         try {
             data = await Client4.submitInteractiveDialog(submission);
@@ -513,6 +519,14 @@ export function submitInteractiveDialog(submission: DialogSubmission): ActionFun
             return {error};
         }
 
+        // Show the next page of a multi-page dialog in place of the current one
+        if (dialog && data?.dialog) {
+            dispatch({
+                type: IntegrationTypes.RECEIVED_DIALOG,
+                data: {...dialog, dialog: data.dialog, session: data.session},
+            });
+        }
+
         return {data};
     };
 }
@@ -1496,6 +1496,9 @@ export const exportFormats = {
     EXPORT_FORMAT_CSV: 'csv',
     EXPORT_FORMAT_ACTIANCE: 'actiance',
     EXPORT_FORMAT_GLOBALRELAY: 'globalrelay',
+    EXPORT_FORMAT_EML: 'eml',
+    EXPORT_FORMAT_MBOX: 'mbox',
+    EXPORT_FORMAT_JSONL: 'jsonl',
 };
 
 export const CacheTypes = {
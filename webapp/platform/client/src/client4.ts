@@ -3789,6 +3789,13 @@ export default class Client4 {
         );
     };
 
+    approvePluginPermissions = (pluginId: string) => {
+        return this.doFetch<StatusOK>(
+            `${this.getPluginRoute(pluginId)}/permissions/approve`,
+            {method: 'post'},
+        );
+    };
+
     // Groups
     linkGroupSyncable = (groupID: string, syncableID: string, syncableType: string, patch: Partial<SyncablePatch>) => {
         return this.doFetch<GroupSyncable>(
@@ -850,6 +850,9 @@ export type CacheSettings = {
     RedisDB: number;
     RedisCachePrefix: string;
     DisableClientCache: boolean;
+    CacheSizes: Record<string, number>;
+    HybridLocalCaches: string[];
+    HybridInvalidation: string;
 };
 
 export type ElasticsearchSettings = {
@@ -924,6 +927,9 @@ export type MessageExportSettings = {
         CustomSMTPServerName: string;
         CustomSMTPPort: string;
     };
+    JsonlSettings: {
+        SigningKey: string;
+    };
 };
 
 export type JobSettings = {
@@ -941,7 +947,7 @@ export type PluginSettings = {
     Directory: string;
     ClientDirectory: string;
     Plugins: Record<string, any>;
-    PluginStates: Record<string, { Enable: boolean }>;
+    PluginStates: Record<string, { Enable: boolean; ApprovedPermissions?: string[] }>;
     EnableMarketplace: boolean;
     EnableRemoteMarketplace: boolean;
     AutomaticPrepackagedPlugins: boolean;
@@ -949,6 +955,9 @@ export type PluginSettings = {
     MarketplaceURL: string;
     SignaturePublicKeyFiles: string[];
     ChimeraOAuthProxyURL: string;
+    WasmMemoryLimitMB: number;
+    WasmHookTimeoutSeconds: number;
+    RequirePluginPermissions: boolean;
 };
 
 export type DisplaySettings = {
@@ -17,6 +17,7 @@ export type IncomingWebhook = {
     username: string;
     icon_url: string;
     channel_locked: boolean;
+    transform_template?: string;
 };
 
 export type IncomingWebhooksWithCount = {
@@ -41,8 +42,20 @@ export type OutgoingWebhook = {
     content_type: string;
     username: string;
     icon_url: string;
+    event_types?: OutgoingWebhookEventType[];
 };
 
+export type OutgoingWebhookEventType =
+    'posted' |
+    'user_joined_channel' |
+    'user_left_channel' |
+    'user_joined_team' |
+    'user_left_team' |
+    'reaction_added' |
+    'channel_created' |
+    'channel_archived' |
+    'user_deactivated';
+
 export type Command = {
     'id': string;
     'token': string;
@@ -144,6 +157,9 @@ export type IntegrationsState = {
         url: string;
         dialog: Dialog;
         trigger_id: string;
+
+        // The session keeps the URL of the dialog on the server, which only sends it for single-page dialogs
+        session?: string;
     };
 };
 
@@ -156,6 +172,14 @@ type Dialog = {
     submit_label?: string;
     notify_on_cancel?: boolean;
     state?: string;
+    pages?: DialogPage[];
+};
+
+export type DialogPage = {
+    title?: string;
+    introduction_text?: string;
+    elements: DialogElement[];
+    submit_label?: string;
 };
 
 export type DialogSubmission = {
@@ -169,6 +193,7 @@ export type DialogSubmission = {
         [x: string]: string;
     };
     cancelled: boolean;
+    session?: string;
 };
 
 export type DialogElement = {
@@ -192,4 +217,8 @@ export type DialogElement = {
 export type SubmitDialogResponse = {
     error?: string;
     errors?: Record<string, string>;
+
+    // The next page of a multi-page dialog
+    dialog?: Dialog;
+    session?: string;
 };
@@ -23,6 +23,7 @@ export type PluginManifest = {
     webapp?: PluginManifestWebapp;
     settings_schema?: PluginSettingsSchema;
     props?: Record<string, any>;
+    permissions?: string[];
 };
 
 export type PluginRedux = PluginManifest & {active: boolean};
@@ -75,9 +76,13 @@ export type PluginSettingOption = {
     value: string;
 };
 
+export type PluginInfo = PluginManifest & {
+    pending_permissions?: string[];
+};
+
 export type PluginsResponse = {
-    active: PluginManifest[];
-    inactive: PluginManifest[];
+    active: PluginInfo[];
+    inactive: PluginInfo[];
 };
 
 export type PluginStatus = {